@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/events"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/client"
+)
+
+// Exemplo de uso do cliente tipado da API REST contra um nó em execução:
+//
+//	peer-vote start --config configs/config.yaml
+//	go run ./examples/api_client --api http://localhost:8080 --watch
+func main() {
+	apiURL := flag.String("api", client.DefaultBaseURL, "endereço da API REST do nó")
+	token := flag.String("token", os.Getenv("PEER_VOTE_TOKEN"), "JWT ou token administrativo")
+	watch := flag.Bool("watch", false, "acompanhar novos blocos via SSE")
+	flag.Parse()
+
+	var opts []client.Option
+	if *token != "" {
+		opts = append(opts, client.WithToken(*token))
+	}
+	api := client.NewClient(*apiURL, opts...)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	fmt.Println("🗳️  === PEER-VOTE: CLIENTE DA API REST ===")
+
+	// Estado da cadeia
+	requestCtx, cancelRequest := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelRequest()
+
+	status, err := api.GetChainStatus(requestCtx)
+	if err != nil {
+		log.Fatalf("❌ Erro ao consultar a cadeia: %v", err)
+	}
+	fmt.Printf("⛓️  Altura: %d (final: %d) - válida: %t\n", status.Height, status.FinalizedHeight, status.IsValid)
+
+	if latest, err := api.GetLatestBlock(requestCtx); err == nil {
+		fmt.Printf("🔗 Último bloco: #%d %s (%d transações)\n", latest.Index, latest.Hash, latest.Transactions)
+	} else if !client.IsNotFound(err) {
+		log.Printf("⚠️  Erro ao obter último bloco: %v", err)
+	}
+
+	// Eleições e resultados
+	elections, err := api.ListElections(requestCtx, client.ListElectionsOptions{})
+	if err != nil {
+		log.Fatalf("❌ Erro ao listar eleições: %v", err)
+	}
+	fmt.Printf("📋 Eleições: %d\n", elections.Count)
+
+	for _, election := range elections.Elections {
+		electionID := election.GetID().String()
+		fmt.Printf("   🗳️  %s [%s] %s\n", election.GetTitle(), election.GetStatus(), electionID)
+
+		count, err := api.CountVotes(requestCtx, electionID)
+		if err != nil {
+			log.Printf("⚠️  Erro ao contar votos: %v", err)
+			continue
+		}
+		for _, result := range count.Results {
+			fmt.Printf("      %s: %d votos (%.1f%%)\n", result.CandidateName, result.VoteCount, result.Percentage)
+		}
+	}
+
+	if !*watch {
+		return
+	}
+
+	// Novos blocos em tempo real
+	fmt.Println("\n📡 Acompanhando novos blocos (Ctrl+C para sair)...")
+	fromHeight := status.Height
+	stream, err := api.SubscribeEvents(ctx, client.EventsOptions{FromHeight: &fromHeight})
+	if err != nil {
+		log.Fatalf("❌ Erro ao assinar eventos: %v", err)
+	}
+	defer stream.Close()
+
+	for {
+		event, err := stream.Next()
+		if err != nil {
+			if err != io.EOF && ctx.Err() == nil {
+				log.Printf("⚠️  Assinatura encerrada: %v", err)
+			}
+			return
+		}
+
+		if event.Type != string(events.EventBlock) {
+			continue
+		}
+		var block events.BlockEventData
+		if err := event.DecodeData(&block); err != nil {
+			log.Printf("⚠️  Evento inválido: %v", err)
+			continue
+		}
+		fmt.Printf("📦 Bloco #%d %s (%d transações)\n", event.Height, block.Hash, block.TransactionCount)
+	}
+}
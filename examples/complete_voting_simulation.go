@@ -224,6 +224,7 @@ func setupMixedNetwork(ctx context.Context, validatorCount, normalCount int) ([]
 		if err != nil {
 			log.Fatalf("Erro ao criar P2PService para nó %d: %v", i+1, err)
 		}
+		p2pService.SetValidatorManager(sharedValidatorManager) // Verificação de cabeçalhos na sincronização
 		
 		// Criar adapter para interface NetworkService (DIP)
 		networkService := network.NewNetworkAdapter(p2pService)
@@ -336,6 +337,7 @@ func setupMixedNetwork(ctx context.Context, validatorCount, normalCount int) ([]
 		if err != nil {
 			log.Fatalf("Erro ao criar P2PService para eleitor %d: %v", i+1, err)
 		}
+		p2pService.SetValidatorManager(sharedValidatorManager) // Verificação de cabeçalhos na sincronização
 		node.P2PService = p2pService
 		
 		// CORREÇÃO: Nós normais não precisam de casos de uso próprios
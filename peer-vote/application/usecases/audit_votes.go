@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
 	"github.com/matscats/peer-vote/peer-vote/domain/services"
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
@@ -12,17 +13,24 @@ import (
 
 // AuditVotesRequest representa uma requisição para auditoria de votos
 type AuditVotesRequest struct {
-	ElectionID valueobjects.Hash `json:"election_id"`
+	ElectionID  valueobjects.Hash      `json:"election_id"`
+	CandidateID string                 `json:"candidate_id,omitempty"` // Lista apenas votos neste candidato
+	Valid       *bool                  `json:"valid,omitempty"`        // Lista apenas votos válidos (true) ou inválidos (false)
+	Cursor      string                 `json:"cursor,omitempty"`       // next_cursor da página anterior
+	Order       repositories.SortOrder `json:"order,omitempty"`        // asc (padrão) ou desc pela posição na cadeia
+	Limit       int                    `json:"limit,omitempty"`        // 0 = sem limite
 }
 
 // VoteAuditResult representa o resultado da auditoria de um voto
 type VoteAuditResult struct {
 	VoteID      string `json:"vote_id"`
+	TxHash      string `json:"tx_hash"`
 	IsValid     bool   `json:"is_valid"`
 	Errors      []string `json:"errors,omitempty"`
 	CandidateID string `json:"candidate_id"`
 	Timestamp   int64  `json:"timestamp"`
 	IsAnonymous bool   `json:"is_anonymous"`
+	BlockHeight uint64 `json:"block_height"`
 }
 
 // ElectionAuditSummary representa o resumo da auditoria de uma eleição
@@ -33,6 +41,9 @@ type ElectionAuditSummary struct {
 	AnonymousVotes   uint64            `json:"anonymous_votes"`
 	CandidateResults map[string]uint64 `json:"candidate_results"`
 	IntegrityScore   float64           `json:"integrity_score"`
+	// Votos válidos resumidos pelo snapshot de estado do qual o nó partiu; estão incluídos
+	// em TotalVotes, ValidVotes e CandidateResults, mas não em AuditResults
+	SnapshotVotes uint64 `json:"snapshot_votes,omitempty"`
 }
 
 // AuditVotesResponse representa a resposta da auditoria de votos
@@ -40,11 +51,31 @@ type AuditVotesResponse struct {
 	ElectionID    valueobjects.Hash     `json:"election_id"`
 	ElectionTitle string                `json:"election_title"`
 	AuditResults  []VoteAuditResult     `json:"audit_results"`
+	NextCursor    string                `json:"next_cursor,omitempty"`
+	HasMore       bool                  `json:"has_more"`
 	Summary       ElectionAuditSummary  `json:"summary"`
 	Message       string                `json:"message"`
 	AuditPassed   bool                  `json:"audit_passed"`
 }
 
+// AuditReportResponse representa o relatório de auditoria de uma eleição para exportação:
+// apuração por candidato e resumo, sem a lista de votos (lida com StreamAuditResults)
+type AuditReportResponse struct {
+	ElectionID    valueobjects.Hash       `json:"election_id"`
+	ElectionTitle string                  `json:"election_title"`
+	Description   string                  `json:"description,omitempty"`
+	Status        entities.ElectionStatus `json:"status"` // Status efetivo na geração do relatório
+	StartTime     int64                   `json:"start_time"`
+	EndTime       int64                   `json:"end_time"`
+	Results       []CandidateResult       `json:"results"` // Votos válidos por candidato, na ordem da eleição
+	Winner        *CandidateResult        `json:"winner,omitempty"`
+	IsTie         bool                    `json:"is_tie"`
+	Summary       ElectionAuditSummary    `json:"summary"`
+	AuditPassed   bool                    `json:"audit_passed"`
+	ChainHeight   uint64                  `json:"chain_height"`
+	GeneratedAt   int64                   `json:"generated_at"`
+}
+
 // CountVotesRequest representa uma requisição para contagem de votos
 type CountVotesRequest struct {
 	ElectionID valueobjects.Hash `json:"election_id"`
@@ -90,11 +121,31 @@ func NewAuditVotesUseCase(
 	}
 }
 
-// AuditVotes executa auditoria completa dos votos de uma eleição
+// chainVote é um voto extraído da blockchain com sua posição na cadeia
+type chainVote struct {
+	position repositories.ChainPosition
+	txHash   valueobjects.Hash
+	vote     *entities.Vote
+}
+
+// AuditVotes executa auditoria completa dos votos de uma eleição.
+// O resumo cobre todos os votos; AuditResults é paginado e filtrado conforme a requisição.
 func (uc *AuditVotesUseCase) AuditVotes(ctx context.Context, request *AuditVotesRequest) (*AuditVotesResponse, error) {
 	if request == nil || request.ElectionID.IsEmpty() {
 		return nil, fmt.Errorf("invalid request: election ID is required")
 	}
+	if request.Limit < 0 {
+		return nil, fmt.Errorf("invalid request: limit cannot be negative")
+	}
+
+	var after *repositories.ChainPosition
+	if request.Cursor != "" {
+		position, err := repositories.ParseCursor(request.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = &position
+	}
 
 	// Obter eleição
 	election, err := uc.chainManager.GetElectionFromBlockchain(ctx, request.ElectionID)
@@ -111,42 +162,32 @@ func (uc *AuditVotesUseCase) AuditVotes(ctx context.Context, request *AuditVotes
 
 	// Auditar cada voto
 	auditResults := make([]VoteAuditResult, 0, len(votes))
-	summary := ElectionAuditSummary{
-		CandidateResults: make(map[string]uint64),
-	}
+	positions := make([]repositories.ChainPosition, 0, len(votes))
+	summary := uc.newAuditSummary(request.ElectionID)
 
-	for _, vote := range votes {
-		result := uc.auditSingleVoteFromBlockchain(ctx, vote, election)
+	for _, entry := range votes {
+		result := uc.auditSingleVoteFromBlockchain(ctx, entry.vote, election)
+		result.BlockHeight = entry.position.Height
+		result.TxHash = entry.txHash.String()
 		auditResults = append(auditResults, result)
+		positions = append(positions, entry.position)
 
 		// Atualizar estatísticas do resumo
-		summary.TotalVotes++
-		if result.IsValid {
-			summary.ValidVotes++
-			summary.CandidateResults[result.CandidateID]++
-		} else {
-			summary.InvalidVotes++
-		}
-
-		if result.IsAnonymous {
-			summary.AnonymousVotes++
-		}
+		summary.add(result)
 	}
 
-	// Calcular score de integridade
-	if summary.TotalVotes > 0 {
-		summary.IntegrityScore = float64(summary.ValidVotes) / float64(summary.TotalVotes) * 100
-	}
+	auditPassed := summary.finish()
 
-	// Verificar se a auditoria passou (blockchain deve ter 100% de integridade)
-	auditPassed := summary.IntegrityScore >= 99.0 // 99% de votos válidos (tolerância mínima para blockchain)
+	pageResults, nextCursor := pageAuditResults(auditResults, positions, request, after)
 
 	return &AuditVotesResponse{
 		ElectionID:    request.ElectionID,
 		ElectionTitle: election.GetTitle(),
-		AuditResults:  auditResults,
+		AuditResults:  pageResults,
+		NextCursor:    nextCursor,
+		HasMore:       nextCursor != "",
 		Summary:       summary,
-		Message:       fmt.Sprintf("Blockchain audit completed for election '%s' - %d votes found", election.GetTitle(), len(votes)),
+		Message:       fmt.Sprintf("Blockchain audit completed for election '%s' - %d votes found", election.GetTitle(), summary.TotalVotes),
 		AuditPassed:   auditPassed,
 	}, nil
 }
@@ -178,7 +219,16 @@ func (uc *AuditVotesUseCase) CountVotes(ctx context.Context, request *CountVotes
 	candidateVotes := make(map[string]uint64)
 	totalVotes := uint64(0)
 
-	for _, vote := range votes {
+	// Votos até a base do snapshot já chegam apurados no estado verificado
+	if base, exists := uc.chainManager.GetBaseElection(request.ElectionID); exists {
+		for candidateID, count := range base.Results {
+			candidateVotes[candidateID] += count
+		}
+		totalVotes += base.TotalVotes
+	}
+
+	for _, entry := range votes {
+		vote := entry.vote
 		// Validar voto antes de contar
 		if vote.IsValid() && vote.GetElectionID().Equals(request.ElectionID) {
 			candidateVotes[vote.GetCandidateID()]++
@@ -187,6 +237,147 @@ func (uc *AuditVotesUseCase) CountVotes(ctx context.Context, request *CountVotes
 	}
 
 	// Preparar resultados dos candidatos
+	results, winner, isTie := rankCandidates(election, candidateVotes, totalVotes)
+
+	return &CountVotesResponse{
+		ElectionID:     request.ElectionID,
+		ElectionTitle:  election.GetTitle(),
+		Results:        results,
+		TotalVotes:     totalVotes,
+		Winner:         winner,
+		IsTie:          isTie,
+		CountCompleted: true,
+		Message:        fmt.Sprintf("Blockchain vote count completed for election '%s' - %d votes counted", election.GetTitle(), totalVotes),
+	}, nil
+}
+
+// StreamAuditResults audita os votos da eleição e entrega cada resultado a visit na ordem
+// pedida, sem reter a lista: usado nas exportações de eleições grandes. Os filtros de candidato
+// e de validade se aplicam; cursor e limite não. Um erro de visit interrompe a leitura.
+func (uc *AuditVotesUseCase) StreamAuditResults(ctx context.Context, request *AuditVotesRequest, visit func(VoteAuditResult) error) error {
+	if request == nil || request.ElectionID.IsEmpty() {
+		return fmt.Errorf("invalid request: election ID is required")
+	}
+
+	election, err := uc.chainManager.GetElectionFromBlockchain(ctx, request.ElectionID)
+	if err != nil {
+		return fmt.Errorf("failed to get election from blockchain: %w", err)
+	}
+
+	var visitErr error
+	err = uc.scanVotes(ctx, request.ElectionID, request.Order, func(entry chainVote) bool {
+		result := uc.auditSingleVoteFromBlockchain(ctx, entry.vote, election)
+		result.BlockHeight = entry.position.Height
+		result.TxHash = entry.txHash.String()
+
+		if request.CandidateID != "" && result.CandidateID != request.CandidateID {
+			return true
+		}
+		if request.Valid != nil && result.IsValid != *request.Valid {
+			return true
+		}
+
+		visitErr = visit(result)
+		return visitErr == nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to extract votes from blockchain: %w", err)
+	}
+	return visitErr
+}
+
+// AuditReport audita a eleição e retorna a apuração por candidato e o resumo, sem reter os votos
+func (uc *AuditVotesUseCase) AuditReport(ctx context.Context, electionID valueobjects.Hash) (*AuditReportResponse, error) {
+	if electionID.IsEmpty() {
+		return nil, fmt.Errorf("invalid request: election ID is required")
+	}
+
+	election, err := uc.chainManager.GetElectionFromBlockchain(ctx, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get election from blockchain: %w", err)
+	}
+
+	height, err := uc.chainManager.GetChainHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain height: %w", err)
+	}
+
+	summary := uc.newAuditSummary(electionID)
+	err = uc.scanVotes(ctx, electionID, repositories.SortAscending, func(entry chainVote) bool {
+		summary.add(uc.auditSingleVoteFromBlockchain(ctx, entry.vote, election))
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract votes from blockchain: %w", err)
+	}
+	auditPassed := summary.finish()
+
+	results, winner, isTie := rankCandidates(election, summary.CandidateResults, summary.ValidVotes)
+
+	now := valueobjects.Now()
+	return &AuditReportResponse{
+		ElectionID:    electionID,
+		ElectionTitle: election.GetTitle(),
+		Description:   election.GetDescription(),
+		Status:        election.EffectiveStatus(now),
+		StartTime:     election.GetStartTime().Unix(),
+		EndTime:       election.GetEndTime().Unix(),
+		Results:       results,
+		Winner:        winner,
+		IsTie:         isTie,
+		Summary:       summary,
+		AuditPassed:   auditPassed,
+		ChainHeight:   height,
+		GeneratedAt:   now.Unix(),
+	}, nil
+}
+
+// newAuditSummary inicia o resumo da auditoria com os votos anteriores à base do snapshot,
+// que só estão disponíveis como apuração verificada
+func (uc *AuditVotesUseCase) newAuditSummary(electionID valueobjects.Hash) ElectionAuditSummary {
+	summary := ElectionAuditSummary{
+		CandidateResults: make(map[string]uint64),
+	}
+
+	if base, exists := uc.chainManager.GetBaseElection(electionID); exists {
+		for candidateID, count := range base.Results {
+			summary.CandidateResults[candidateID] += count
+		}
+		summary.SnapshotVotes = base.TotalVotes
+		summary.TotalVotes += base.TotalVotes
+		summary.ValidVotes += base.TotalVotes
+	}
+
+	return summary
+}
+
+// add contabiliza um voto auditado no resumo
+func (s *ElectionAuditSummary) add(result VoteAuditResult) {
+	s.TotalVotes++
+	if result.IsValid {
+		s.ValidVotes++
+		s.CandidateResults[result.CandidateID]++
+	} else {
+		s.InvalidVotes++
+	}
+
+	if result.IsAnonymous {
+		s.AnonymousVotes++
+	}
+}
+
+// finish calcula o score de integridade e retorna se a auditoria passou
+func (s *ElectionAuditSummary) finish() bool {
+	if s.TotalVotes > 0 {
+		s.IntegrityScore = float64(s.ValidVotes) / float64(s.TotalVotes) * 100
+	}
+
+	// Verificar se a auditoria passou (blockchain deve ter 100% de integridade)
+	return s.IntegrityScore >= 99.0 // 99% de votos válidos (tolerância mínima para blockchain)
+}
+
+// rankCandidates monta o resultado de cada candidato, na ordem da eleição, e o vencedor
+func rankCandidates(election *entities.Election, candidateVotes map[string]uint64, totalVotes uint64) ([]CandidateResult, *CandidateResult, bool) {
 	candidates := election.GetCandidates()
 	results := make([]CandidateResult, 0, len(candidates))
 	var maxVotes uint64
@@ -220,18 +411,7 @@ func (uc *AuditVotesUseCase) CountVotes(ctx context.Context, request *CountVotes
 	}
 
 	// Verificar empate
-	isTie := winnersCount > 1
-
-	return &CountVotesResponse{
-		ElectionID:     request.ElectionID,
-		ElectionTitle:  election.GetTitle(),
-		Results:        results,
-		TotalVotes:     totalVotes,
-		Winner:         winner,
-		IsTie:          isTie,
-		CountCompleted: true,
-		Message:        fmt.Sprintf("Blockchain vote count completed for election '%s' - %d votes counted", election.GetTitle(), totalVotes),
-	}, nil
+	return results, winner, winnersCount > 1
 }
 
 // auditSingleVote audita um voto individual
@@ -282,45 +462,73 @@ func (uc *AuditVotesUseCase) auditSingleVote(ctx context.Context, vote *entities
 	return result
 }
 
-// extractVotesFromBlockchain extrai todos os votos de uma eleição da blockchain
-func (uc *AuditVotesUseCase) extractVotesFromBlockchain(ctx context.Context, electionID valueobjects.Hash) ([]*entities.Vote, error) {
-	// Obter altura atual da blockchain
-	height, err := uc.chainManager.GetChainHeight(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get chain height: %w", err)
-	}
+// pageAuditResults aplica filtros, ordem e cursor aos resultados da auditoria,
+// que estão na ordem da cadeia (positions[i] é a posição de results[i])
+func pageAuditResults(results []VoteAuditResult, positions []repositories.ChainPosition, request *AuditVotesRequest, after *repositories.ChainPosition) ([]VoteAuditResult, string) {
+	page := make([]VoteAuditResult, 0)
+	var last repositories.ChainPosition
 
-	var votes []*entities.Vote
+	for i := range results {
+		index := i
+		if request.Order == repositories.SortDescending {
+			index = len(results) - 1 - i
+		}
 
-	// Iterar por todos os blocos da blockchain
-	for i := uint64(0); i <= height; i++ {
-		block, err := uc.chainManager.GetBlockByIndex(ctx, i)
-		if err != nil {
-			continue // Pular blocos com erro
+		result := results[index]
+		position := positions[index]
+		if after != nil && !position.Follows(*after, request.Order) {
+			continue
+		}
+		if request.CandidateID != "" && result.CandidateID != request.CandidateID {
+			continue
+		}
+		if request.Valid != nil && result.IsValid != *request.Valid {
+			continue
 		}
 
-		// Processar transações do bloco
-		transactions := block.GetTransactions()
-		for _, tx := range transactions {
-			// Verificar se é uma transação de voto
-			if tx.GetType() == "VOTE" {
-				// Deserializar dados da transação para obter o voto
-				vote, err := uc.deserializeVoteFromTransaction(ctx, tx)
-				if err != nil {
-					continue // Pular transações inválidas
-				}
-
-				// Verificar se o voto pertence à eleição solicitada
-				if vote.GetElectionID().Equals(electionID) {
-					votes = append(votes, vote)
-				}
-			}
+		if request.Limit > 0 && len(page) == request.Limit {
+			return page, last.Cursor()
 		}
+		page = append(page, result)
+		last = position
+	}
+
+	return page, ""
+}
+
+// extractVotesFromBlockchain extrai todos os votos de uma eleição da blockchain, na ordem da cadeia.
+// Apenas os blocos com transações de voto são lidos (índice por tipo do repositório).
+func (uc *AuditVotesUseCase) extractVotesFromBlockchain(ctx context.Context, electionID valueobjects.Hash) ([]chainVote, error) {
+	var votes []chainVote
+
+	err := uc.scanVotes(ctx, electionID, repositories.SortAscending, func(entry chainVote) bool {
+		votes = append(votes, entry)
+		return true
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return votes, nil
 }
 
+// scanVotes percorre os votos de uma eleição na ordem pedida; visit retorna false para parar
+func (uc *AuditVotesUseCase) scanVotes(ctx context.Context, electionID valueobjects.Hash, order repositories.SortOrder, visit func(chainVote) bool) error {
+	return uc.chainManager.ScanTransactions(ctx, entities.VoteTransaction, nil, order, func(position repositories.ChainPosition, tx *entities.Transaction) bool {
+		// Deserializar dados da transação para obter o voto
+		vote, err := uc.deserializeVoteFromTransaction(ctx, tx)
+		if err != nil {
+			return true // Pular transações inválidas
+		}
+
+		// Verificar se o voto pertence à eleição solicitada
+		if !vote.GetElectionID().Equals(electionID) {
+			return true
+		}
+		return visit(chainVote{position: position, txHash: tx.GetHash(), vote: vote})
+	})
+}
+
 // deserializeVoteFromTransaction deserializa um voto a partir de uma transação
 func (uc *AuditVotesUseCase) deserializeVoteFromTransaction(ctx context.Context, tx *entities.Transaction) (*entities.Vote, error) {
 	// Obter dados da transação
@@ -0,0 +1,190 @@
+package usecases
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+)
+
+// Colunas do CSV de candidatos
+const (
+	candidateColumnID          = "id"
+	candidateColumnName        = "name"
+	candidateColumnDescription = "description"
+)
+
+// CandidateImportError descreve um problema em uma linha do CSV de candidatos
+type CandidateImportError struct {
+	Line    int    `json:"line,omitempty"` // Omitido em problemas do arquivo como um todo
+	Message string `json:"message"`
+}
+
+// String descreve o problema com a linha
+func (e CandidateImportError) String() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// CandidateImport é o resultado da importação de candidatos: as linhas aceitas e os problemas
+// das demais. Só é válido sem problemas, com as regras de Election.IsValid atendidas.
+type CandidateImport struct {
+	Candidates []entities.Candidate   `json:"candidates"`
+	Errors     []CandidateImportError `json:"errors"`
+	Valid      bool                   `json:"valid"`
+}
+
+// Err resume os problemas da importação em um erro (nil se válida)
+func (i *CandidateImport) Err() error {
+	if i.Valid {
+		return nil
+	}
+
+	messages := make([]string, 0, len(i.Errors))
+	for _, importErr := range i.Errors {
+		messages = append(messages, importErr.String())
+	}
+	return fmt.Errorf("invalid candidates: %s", strings.Join(messages, "; "))
+}
+
+// ImportCandidatesCSV lê candidatos de um CSV com cabeçalho id,name[,description] (em
+// qualquer ordem). Cada linha é validada com as regras de Election.IsValid (ID e nome
+// obrigatórios, IDs únicos); nomes repetidos também são rejeitados. O erro retornado
+// indica um arquivo ilegível; problemas nas linhas ficam em CandidateImport.Errors.
+func ImportCandidatesCSV(r io.Reader) (*CandidateImport, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("empty candidates file")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid candidates file: %w", err)
+	}
+
+	columns, err := candidateColumns(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CandidateImport{
+		Candidates: []entities.Candidate{},
+		Errors:     []CandidateImportError{},
+	}
+	idLines := make(map[string]int)
+	nameLines := make(map[string]int)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return nil, fmt.Errorf("invalid candidates file: line %d: %w", parseErr.StartLine, parseErr.Err)
+			}
+			return nil, fmt.Errorf("invalid candidates file: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(record) > len(header) {
+			result.addError(line, fmt.Sprintf("expected at most %d fields, got %d", len(header), len(record)))
+			continue
+		}
+
+		candidate := entities.Candidate{
+			ID:          columns.value(record, candidateColumnID),
+			Name:        columns.value(record, candidateColumnName),
+			Description: columns.value(record, candidateColumnDescription),
+		}
+
+		switch {
+		case candidate.ID == "":
+			result.addError(line, "candidate ID is required")
+		case candidate.Name == "":
+			result.addError(line, fmt.Sprintf("candidate '%s': name is required", candidate.ID))
+		case idLines[candidate.ID] > 0:
+			result.addError(line, fmt.Sprintf("duplicate candidate ID '%s' (first on line %d)", candidate.ID, idLines[candidate.ID]))
+		case nameLines[strings.ToLower(candidate.Name)] > 0:
+			result.addError(line, fmt.Sprintf("duplicate candidate name '%s' (first on line %d)", candidate.Name, nameLines[strings.ToLower(candidate.Name)]))
+		default:
+			idLines[candidate.ID] = line
+			nameLines[strings.ToLower(candidate.Name)] = line
+			result.Candidates = append(result.Candidates, candidate)
+		}
+	}
+
+	if len(result.Errors) == 0 {
+		if len(result.Candidates) < 2 {
+			result.addError(0, "at least 2 candidates are required")
+		} else if err := entities.ValidateCandidates(result.Candidates); err != nil {
+			result.addError(0, err.Error())
+		}
+	}
+	result.Valid = len(result.Errors) == 0
+
+	return result, nil
+}
+
+// addError registra um problema de importação
+func (i *CandidateImport) addError(line int, message string) {
+	i.Errors = append(i.Errors, CandidateImportError{Line: line, Message: message})
+}
+
+// candidateColumnIndex mapeia as colunas do cabeçalho para as posições no registro
+type candidateColumnIndex map[string]int
+
+// candidateColumns valida o cabeçalho do CSV de candidatos
+func candidateColumns(header []string) (candidateColumnIndex, error) {
+	columns := make(candidateColumnIndex, len(header))
+	for i, column := range header {
+		name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		switch name {
+		case candidateColumnID, candidateColumnName, candidateColumnDescription:
+		default:
+			return nil, fmt.Errorf("unknown column %q in candidates header (expected id, name and optionally description)", column)
+		}
+		if _, duplicated := columns[name]; duplicated {
+			return nil, fmt.Errorf("duplicate column %q in candidates header", name)
+		}
+		columns[name] = i
+	}
+
+	for _, required := range []string{candidateColumnID, candidateColumnName} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("candidates header must include the %q column", required)
+		}
+	}
+
+	return columns, nil
+}
+
+// value retorna o campo da coluna no registro (vazio se a coluna ou o campo não existem)
+func (c candidateColumnIndex) value(record []string, column string) string {
+	position, ok := c[column]
+	if !ok || position >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[position])
+}
+
+// isBlankRecord verifica se todos os campos do registro estão vazios
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,349 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/certification"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// Erros da certificação de resultados
+var (
+	ErrElectionNotClosed   = errors.New("election is not closed at the chain head")
+	ErrAlreadyCertified    = errors.New("election results already certified")
+	ErrNotValidator        = errors.New("node is not a validator at the chain head")
+	ErrCertificateNotFound = errors.New("result certificate not found")
+)
+
+// CertifyResultsRequest representa uma requisição para certificar o resultado de uma eleição
+type CertifyResultsRequest struct {
+	ElectionID valueobjects.Hash `json:"election_id"`
+}
+
+// CertifyResultsResponse representa o documento proposto (ou co-assinado) pelo nó
+type CertifyResultsResponse struct {
+	ElectionID      valueobjects.Hash        `json:"election_id"`
+	DocumentHash    string                   `json:"document_hash"`
+	Document        *entities.ResultDocument `json:"document"`
+	TransactionHash valueobjects.Hash        `json:"transaction_hash"`
+	Validators      int                      `json:"validators"`
+	Required        int                      `json:"required"`
+	Message         string                   `json:"message"`
+}
+
+// CertifyResultsUseCase produz o documento canônico de resultado de eleições encerradas e
+// coleta as co-assinaturas dos validadores na cadeia. Cada validador que vê uma proposta
+// recalcula o documento a partir da própria cadeia e só o co-assina se chegar ao mesmo hash.
+type CertifyResultsUseCase struct {
+	auditVotesUseCase *AuditVotesUseCase
+	chainManager      *blockchain.ChainManager
+	validatorManager  *consensus.ValidatorManager
+	registry          *certification.Registry
+	consensusService  services.ConsensusService
+	cryptoService     services.CryptographyService
+
+	// Identidade do nó, usada para propor e co-assinar documentos
+	nodeID         valueobjects.NodeID
+	nodePrivateKey *services.PrivateKey
+
+	logger *slog.Logger
+}
+
+// NewCertifyResultsUseCase cria o caso de uso e o registra para co-assinar novas propostas
+func NewCertifyResultsUseCase(
+	auditVotesUseCase *AuditVotesUseCase,
+	chainManager *blockchain.ChainManager,
+	validatorManager *consensus.ValidatorManager,
+	registry *certification.Registry,
+	consensusService services.ConsensusService,
+	cryptoService services.CryptographyService,
+) *CertifyResultsUseCase {
+	uc := &CertifyResultsUseCase{
+		auditVotesUseCase: auditVotesUseCase,
+		chainManager:      chainManager,
+		validatorManager:  validatorManager,
+		registry:          registry,
+		consensusService:  consensusService,
+		cryptoService:     cryptoService,
+		logger:            logging.Component("certification"),
+	}
+
+	registry.SetProposalHandler(uc.handleProposal)
+
+	return uc
+}
+
+// SetLogger define o logger do caso de uso
+func (uc *CertifyResultsUseCase) SetLogger(logger *slog.Logger) {
+	uc.logger = logger
+}
+
+// SetNodeKey define a chave do nó usada para assinar os documentos
+func (uc *CertifyResultsUseCase) SetNodeKey(nodeID valueobjects.NodeID, privateKey *services.PrivateKey) {
+	uc.nodeID = nodeID
+	uc.nodePrivateKey = privateKey
+}
+
+// CertifyResults apura a eleição no topo atual da cadeia e propõe o documento de resultado,
+// assinado por este nó. Se outro validador já propôs o mesmo documento, o nó o co-assina.
+func (uc *CertifyResultsUseCase) CertifyResults(ctx context.Context, request *CertifyResultsRequest) (*CertifyResultsResponse, error) {
+	if request == nil || request.ElectionID.IsEmpty() {
+		return nil, fmt.Errorf("invalid request: election ID is required")
+	}
+	if uc.nodeID.IsEmpty() || uc.nodePrivateKey == nil {
+		return nil, fmt.Errorf("node key not configured")
+	}
+
+	head, err := uc.chainManager.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain head: %w", err)
+	}
+
+	electorate := uc.validatorManager.GetValidatorSetAtHeight(ctx, head.GetIndex())
+	if _, exists := electorate[uc.nodeID.String()]; !exists {
+		return nil, ErrNotValidator
+	}
+
+	electionID := request.ElectionID.String()
+	if certificate, exists := uc.registry.GetCertificate(electionID); exists && certificate.Certified {
+		return nil, fmt.Errorf("%w (document %s)", ErrAlreadyCertified, certificate.DocumentHash)
+	}
+
+	document, err := uc.buildDocument(ctx, request.ElectionID, head)
+	if err != nil {
+		return nil, err
+	}
+	documentHash, err := certification.HashDocument(ctx, uc.cryptoService, document)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CertifyResultsResponse{
+		ElectionID:   request.ElectionID,
+		DocumentHash: documentHash.String(),
+		Document:     document,
+		Validators:   len(electorate),
+		Required:     certification.RequiredSignatures(len(electorate)),
+	}
+
+	var tx *entities.Transaction
+	if existing, exists := uc.registry.FindDocument(electionID, documentHash.String()); exists {
+		// Mesmo documento já proposto: basta co-assinar
+		if hasSigned(existing, uc.nodeID) {
+			response.Message = "Result document already proposed and signed by this node"
+			return response, nil
+		}
+		tx, err = certification.NewEndorsementTransaction(ctx, uc.cryptoService, electionID, documentHash.String(), uc.nodeID, uc.nodePrivateKey)
+		response.Message = "Result document already proposed; endorsement submitted"
+	} else {
+		tx, _, err = certification.NewProposalTransaction(ctx, uc.cryptoService, document, uc.nodeID, uc.nodePrivateKey)
+		response.Message = "Result document proposed; awaiting validator endorsements"
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.consensusService.AddTransaction(ctx, tx); err != nil {
+		return nil, fmt.Errorf("failed to submit certificate transaction: %w", err)
+	}
+	response.TransactionHash = tx.GetHash()
+
+	uc.logger.InfoContext(ctx, "election results certification requested",
+		"election_id", electionID, "document_hash", documentHash.String(), logging.Height(document.HeadHeight), logging.TxHash(tx.GetHash()))
+
+	return response, nil
+}
+
+// GetCertificate retorna o certificado de resultado da eleição
+func (uc *CertifyResultsUseCase) GetCertificate(ctx context.Context, electionID valueobjects.Hash) (*certification.Certificate, error) {
+	if electionID.IsEmpty() {
+		return nil, fmt.Errorf("invalid request: election ID is required")
+	}
+
+	certificate, exists := uc.registry.GetCertificate(electionID.String())
+	if !exists {
+		return nil, ErrCertificateNotFound
+	}
+	return certificate, nil
+}
+
+// buildDocument apura a eleição até o bloco head. A auditoria cobre a cadeia inteira, então
+// votos após o topo informado tornam o documento inválido em vez de serem ignorados.
+func (uc *CertifyResultsUseCase) buildDocument(ctx context.Context, electionID valueobjects.Hash, head *entities.Block) (*entities.ResultDocument, error) {
+	election, err := uc.chainManager.GetElectionFromBlockchain(ctx, electionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get election from blockchain: %w", err)
+	}
+
+	// O status é avaliado no timestamp do topo, igual em todos os nós
+	if status := election.EffectiveStatus(head.GetTimestamp()); status != entities.ElectionClosed {
+		return nil, fmt.Errorf("%w (status %s at height %d)", ErrElectionNotClosed, status, head.GetIndex())
+	}
+
+	// O último voto da cadeia define o fim do intervalo de blocos
+	audit, err := uc.auditVotesUseCase.AuditVotes(ctx, &AuditVotesRequest{
+		ElectionID: electionID,
+		Order:      repositories.SortDescending,
+		Limit:      1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to audit votes: %w", err)
+	}
+
+	first, err := uc.electionHeight(ctx, electionID)
+	if err != nil {
+		return nil, err
+	}
+	last := first
+	if len(audit.AuditResults) > 0 && audit.AuditResults[0].BlockHeight > last {
+		last = audit.AuditResults[0].BlockHeight
+	}
+	if last > head.GetIndex() {
+		return nil, fmt.Errorf("election has votes after head height %d (block %d)", head.GetIndex(), last)
+	}
+
+	document, err := entities.NewResultDocument(election)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range election.GetCandidates() {
+		votes := audit.Summary.CandidateResults[candidate.ID]
+		document.Tally = append(document.Tally, entities.CandidateTally{
+			CandidateID: candidate.ID,
+			Name:        candidate.Name,
+			Votes:       votes,
+		})
+		document.TotalVotes += votes
+	}
+
+	document.Blocks = entities.BlockRange{First: first, Last: last}
+	document.HeadHeight = head.GetIndex()
+	document.HeadHash = uc.chainManager.CalculateBlockHash(ctx, head).String()
+	document.Audit = entities.ResultAudit{
+		TotalVotes:     audit.Summary.TotalVotes,
+		ValidVotes:     audit.Summary.ValidVotes,
+		InvalidVotes:   audit.Summary.InvalidVotes,
+		AnonymousVotes: audit.Summary.AnonymousVotes,
+		SnapshotVotes:  audit.Summary.SnapshotVotes,
+		IntegrityScore: audit.Summary.IntegrityScore,
+		Passed:         audit.AuditPassed,
+	}
+
+	if err := document.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid result document: %w", err)
+	}
+	return document, nil
+}
+
+// electionHeight retorna a altura do bloco em que a eleição foi registrada
+func (uc *CertifyResultsUseCase) electionHeight(ctx context.Context, electionID valueobjects.Hash) (uint64, error) {
+	if base, exists := uc.chainManager.GetBaseElection(electionID); exists {
+		return base.Position.Height, nil
+	}
+
+	var height uint64
+	found := false
+	err := uc.chainManager.ScanTransactions(ctx, entities.ElectionTransaction, nil, repositories.SortAscending, func(position repositories.ChainPosition, tx *entities.Transaction) bool {
+		election := &entities.Election{}
+		if err := election.FromBytes(tx.GetData()); err != nil || !election.GetID().Equals(electionID) {
+			return true
+		}
+		height = position.Height
+		found = true
+		return false
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("election registration not found in blockchain")
+	}
+	return height, nil
+}
+
+// handleProposal co-assina em segundo plano documentos propostos por outros validadores:
+// a apuração lê a cadeia e não pode rodar dentro do listener de blocos
+func (uc *CertifyResultsUseCase) handleProposal(ctx context.Context, proposal certification.Proposal) {
+	if uc.nodePrivateKey == nil || proposal.Proposer == uc.nodeID.String() {
+		return
+	}
+	go uc.endorse(context.Background(), proposal)
+}
+
+// endorse recalcula o documento proposto e submete a co-assinatura se os hashes coincidirem
+func (uc *CertifyResultsUseCase) endorse(ctx context.Context, proposal certification.Proposal) {
+	certificate, exists := uc.registry.FindDocument(proposal.ElectionID, proposal.DocumentHash)
+	if !exists || certificate.Certified || hasSigned(certificate, uc.nodeID) {
+		return
+	}
+
+	isValidator := false
+	for _, validator := range certificate.Validators {
+		if validator.NodeID == uc.nodeID.String() {
+			isValidator = true
+			break
+		}
+	}
+	if !isValidator {
+		return
+	}
+
+	logger := uc.logger.With("election_id", proposal.ElectionID, "document_hash", proposal.DocumentHash)
+
+	electionID, err := valueobjects.NewHashFromString(proposal.ElectionID)
+	if err != nil {
+		logger.WarnContext(ctx, "invalid election ID in result document", logging.Err(err))
+		return
+	}
+	head, err := uc.chainManager.GetBlockByIndex(ctx, proposal.Document.HeadHeight)
+	if err != nil {
+		logger.WarnContext(ctx, "head block of result document not available", logging.Height(proposal.Document.HeadHeight), logging.Err(err))
+		return
+	}
+
+	document, err := uc.buildDocument(ctx, electionID, head)
+	if err != nil {
+		logger.WarnContext(ctx, "not endorsing result document: local recount failed", logging.Err(err))
+		return
+	}
+	documentHash, err := certification.HashDocument(ctx, uc.cryptoService, document)
+	if err != nil {
+		logger.WarnContext(ctx, "not endorsing result document", logging.Err(err))
+		return
+	}
+	if documentHash.String() != proposal.DocumentHash {
+		logger.WarnContext(ctx, "not endorsing result document: local recount differs", "local_hash", documentHash.String())
+		return
+	}
+
+	tx, err := certification.NewEndorsementTransaction(ctx, uc.cryptoService, proposal.ElectionID, proposal.DocumentHash, uc.nodeID, uc.nodePrivateKey)
+	if err == nil {
+		err = uc.consensusService.AddTransaction(ctx, tx)
+	}
+	if err != nil {
+		logger.WarnContext(ctx, "failed to submit result endorsement", logging.Err(err))
+		return
+	}
+
+	logger.InfoContext(ctx, "result document endorsed", logging.TxHash(tx.GetHash()))
+}
+
+// hasSigned verifica se o validador já assinou o certificado
+func hasSigned(certificate *certification.Certificate, nodeID valueobjects.NodeID) bool {
+	for _, signature := range certificate.Signatures {
+		if signature.Validator == nodeID.String() {
+			return true
+		}
+	}
+	return false
+}
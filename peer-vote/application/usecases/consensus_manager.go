@@ -3,6 +3,8 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"sort"
 	"time"
 
 	"github.com/matscats/peer-vote/peer-vote/domain/entities"
@@ -10,32 +12,90 @@ import (
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
 )
 
-// ConsensusManagerUseCase gerencia o consenso PoA
+// ConsensusManagerUseCase gerencia o consenso do nó, seja PoA ou BFT
 type ConsensusManagerUseCase struct {
-	poaEngine        *consensus.PoAEngine
+	engine           consensus.Engine
 	validatorManager *consensus.ValidatorManager
 	penaltySystem    *consensus.PenaltySystem
+	governance       *consensus.ValidatorGovernance
 	chainManager     *blockchain.ChainManager
 	cryptoService    services.CryptographyService
+
+	// Identidade do nó, usada para assinar propostas e aprovações sem chave explícita
+	nodeID         valueobjects.NodeID
+	nodePrivateKey *services.PrivateKey
+
+	// Contexto de vida do nó: os motores iniciados pela API não podem morrer com a requisição
+	runCtx context.Context
+
+	logger *slog.Logger
 }
 
 // NewConsensusManagerUseCase cria um novo gerenciador de consenso
 func NewConsensusManagerUseCase(
-	poaEngine *consensus.PoAEngine,
+	engine consensus.Engine,
 	validatorManager *consensus.ValidatorManager,
 	penaltySystem *consensus.PenaltySystem,
+	governance *consensus.ValidatorGovernance,
 	chainManager *blockchain.ChainManager,
 	cryptoService services.CryptographyService,
 ) *ConsensusManagerUseCase {
 	return &ConsensusManagerUseCase{
-		poaEngine:        poaEngine,
+		engine:           engine,
 		validatorManager: validatorManager,
 		penaltySystem:    penaltySystem,
+		governance:       governance,
 		chainManager:     chainManager,
 		cryptoService:    cryptoService,
+		runCtx:           context.Background(),
+		logger:           logging.Component("consensus-admin"),
+	}
+}
+
+// SetRunContext define o contexto de vida do nó, usado pelo consenso iniciado via API
+func (uc *ConsensusManagerUseCase) SetRunContext(ctx context.Context) {
+	uc.runCtx = ctx
+}
+
+// SetLogger define o logger do caso de uso
+func (uc *ConsensusManagerUseCase) SetLogger(logger *slog.Logger) {
+	uc.logger = logger
+}
+
+// SetNodeKey define a chave do nó usada quando a requisição não informa proponente e chave
+func (uc *ConsensusManagerUseCase) SetNodeKey(nodeID valueobjects.NodeID, privateKey *services.PrivateKey) {
+	uc.nodeID = nodeID
+	uc.nodePrivateKey = privateKey
+}
+
+// transactionBroadcaster é implementado pelos motores cujo AddTransaction não propaga a
+// transação para os peers (PoA; o BFT propaga ao adicionar)
+type transactionBroadcaster interface {
+	BroadcastTransaction(ctx context.Context, tx *entities.Transaction) error
+}
+
+// blockProductionConfigurer é implementado pelos motores com produção de blocos por intervalo (PoA)
+type blockProductionConfigurer interface {
+	SetConfiguration(blockInterval time.Duration, minTxPerBlock, maxTxPerBlock int)
+}
+
+// requireEngine verifica se o motor de consenso está disponível
+func (uc *ConsensusManagerUseCase) requireEngine() error {
+	if uc.engine == nil {
+		return fmt.Errorf("consensus engine not available")
 	}
+	return nil
+}
+
+// signer retorna o signatário da requisição ou, na ausência, a identidade do nó
+func (uc *ConsensusManagerUseCase) signer(nodeID valueobjects.NodeID, privateKey *services.PrivateKey) (valueobjects.NodeID, *services.PrivateKey) {
+	if privateKey == nil {
+		return uc.nodeID, uc.nodePrivateKey
+	}
+	return nodeID, privateKey
 }
 
 // StartConsensusRequest representa a requisição para iniciar consenso
@@ -68,7 +128,16 @@ func (uc *ConsensusManagerUseCase) StartConsensus(ctx context.Context, request *
 		}, fmt.Errorf("request cannot be nil")
 	}
 
-	if len(request.InitialValidators) == 0 {
+	if err := uc.requireEngine(); err != nil {
+		return &StartConsensusResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	// Sem validadores iniciais, usa o conjunto já configurado no nó
+	configured, _ := uc.validatorManager.GetValidatorCount(ctx)
+	if len(request.InitialValidators) == 0 && configured == 0 {
 		return &StartConsensusResponse{
 			Success: false,
 			Message: "no initial validators provided",
@@ -94,8 +163,8 @@ func (uc *ConsensusManagerUseCase) StartConsensus(ctx context.Context, request *
 		genesisBlockHash = valueobjects.EmptyHash() // Placeholder
 	}
 
-	// Iniciar consenso
-	err := uc.poaEngine.StartConsensus(ctx)
+	// Iniciar consenso no contexto do nó, não no da requisição que o iniciou
+	err := uc.engine.StartConsensus(uc.runCtx)
 	if err != nil {
 		return &StartConsensusResponse{
 			Success: false,
@@ -104,8 +173,9 @@ func (uc *ConsensusManagerUseCase) StartConsensus(ctx context.Context, request *
 	}
 
 	// Obter informações atuais
-	currentRound, _ := uc.poaEngine.GetCurrentRound(ctx)
-	validatorCount, _ := uc.poaEngine.GetValidatorCount(ctx)
+	currentRound, _ := uc.engine.GetCurrentRound(ctx)
+	validatorCount, _ := uc.engine.GetValidatorCount(ctx)
+	uc.logger.InfoContext(ctx, "consensus started", "validators", validatorCount, "round", currentRound)
 
 	return &StartConsensusResponse{
 		Success:          true,
@@ -116,19 +186,25 @@ func (uc *ConsensusManagerUseCase) StartConsensus(ctx context.Context, request *
 	}, nil
 }
 
-// AddValidatorRequest representa a requisição para adicionar validador
+// AddValidatorRequest representa a requisição para propor a adição de um validador.
+// A adição só vale depois de aprovada pela maioria dos validadores atuais na cadeia.
 type AddValidatorRequest struct {
-	NodeID    valueobjects.NodeID
-	PublicKey *services.PublicKey
+	NodeID          valueobjects.NodeID
+	PublicKey       *services.PublicKey
+	ProposerID      valueobjects.NodeID
+	PrivateKey      *services.PrivateKey `json:"-"` // Chave do validador proponente
+	EffectiveHeight uint64                // Altura a partir da qual a alteração vale (0 = próxima altura disponível)
 }
 
 // AddValidatorResponse representa a resposta da adição de validador
 type AddValidatorResponse struct {
-	Success bool
-	Message string
+	Success         bool
+	Message         string
+	ProposalID      valueobjects.Hash
+	EffectiveHeight uint64
 }
 
-// AddValidator adiciona um novo validador
+// AddValidator submete uma proposta de adição de validador para aprovação na cadeia
 func (uc *ConsensusManagerUseCase) AddValidator(ctx context.Context, request *AddValidatorRequest) (*AddValidatorResponse, error) {
 	if request == nil {
 		return &AddValidatorResponse{
@@ -151,20 +227,210 @@ func (uc *ConsensusManagerUseCase) AddValidator(ctx context.Context, request *Ad
 		}, fmt.Errorf("public key is invalid")
 	}
 
-	err := uc.poaEngine.AddValidator(ctx, request.NodeID, request.PublicKey)
+	proposalID, effectiveHeight, err := uc.submitValidatorProposal(ctx, entities.ValidatorActionAdd, request.NodeID,
+		request.PublicKey, request.ProposerID, request.EffectiveHeight, request.PrivateKey)
 	if err != nil {
 		return &AddValidatorResponse{
 			Success: false,
-			Message: fmt.Sprintf("failed to add validator: %v", err),
-		}, fmt.Errorf("failed to add validator: %w", err)
+			Message: fmt.Sprintf("failed to propose validator: %v", err),
+		}, fmt.Errorf("failed to propose validator: %w", err)
 	}
 
+	uc.logger.InfoContext(ctx, "validator addition proposed",
+		"validator", request.NodeID.String(), "proposal_id", proposalID.String(), "effective_height", effectiveHeight)
+
 	return &AddValidatorResponse{
-		Success: true,
-		Message: fmt.Sprintf("validator %s added successfully", request.NodeID.ShortString()),
+		Success:         true,
+		Message:         fmt.Sprintf("proposal to add validator %s submitted, awaiting majority approval", request.NodeID.ShortString()),
+		ProposalID:      proposalID,
+		EffectiveHeight: effectiveHeight,
 	}, nil
 }
 
+// RemoveValidatorRequest representa a requisição para propor a remoção de um validador
+type RemoveValidatorRequest struct {
+	NodeID          valueobjects.NodeID
+	ProposerID      valueobjects.NodeID
+	PrivateKey      *services.PrivateKey `json:"-"` // Chave do validador proponente
+	EffectiveHeight uint64                // Altura a partir da qual a alteração vale (0 = próxima altura disponível)
+}
+
+// RemoveValidatorResponse representa a resposta da remoção de validador
+type RemoveValidatorResponse struct {
+	Success         bool
+	Message         string
+	ProposalID      valueobjects.Hash
+	EffectiveHeight uint64
+}
+
+// RemoveValidator submete uma proposta de remoção de validador para aprovação na cadeia
+func (uc *ConsensusManagerUseCase) RemoveValidator(ctx context.Context, request *RemoveValidatorRequest) (*RemoveValidatorResponse, error) {
+	if request == nil {
+		return &RemoveValidatorResponse{
+			Success: false,
+			Message: "request is nil",
+		}, fmt.Errorf("request cannot be nil")
+	}
+
+	if request.NodeID.IsEmpty() {
+		return &RemoveValidatorResponse{
+			Success: false,
+			Message: "node ID is empty",
+		}, fmt.Errorf("node ID cannot be empty")
+	}
+
+	proposalID, effectiveHeight, err := uc.submitValidatorProposal(ctx, entities.ValidatorActionRemove, request.NodeID,
+		nil, request.ProposerID, request.EffectiveHeight, request.PrivateKey)
+	if err != nil {
+		return &RemoveValidatorResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to propose validator removal: %v", err),
+		}, fmt.Errorf("failed to propose validator removal: %w", err)
+	}
+
+	uc.logger.InfoContext(ctx, "validator removal proposed",
+		"validator", request.NodeID.String(), "proposal_id", proposalID.String(), "effective_height", effectiveHeight)
+
+	return &RemoveValidatorResponse{
+		Success:         true,
+		Message:         fmt.Sprintf("proposal to remove validator %s submitted, awaiting majority approval", request.NodeID.ShortString()),
+		ProposalID:      proposalID,
+		EffectiveHeight: effectiveHeight,
+	}, nil
+}
+
+// ApproveValidatorChangeRequest representa a requisição para aprovar uma proposta de validador
+type ApproveValidatorChangeRequest struct {
+	ProposalID  valueobjects.Hash
+	ValidatorID valueobjects.NodeID
+	PrivateKey  *services.PrivateKey `json:"-"` // Chave do validador que aprova
+}
+
+// ApproveValidatorChangeResponse representa a resposta da aprovação
+type ApproveValidatorChangeResponse struct {
+	Success       bool
+	Message       string
+	TransactionID valueobjects.Hash
+}
+
+// ApproveValidatorChange assina e submete a aprovação de uma proposta pendente
+func (uc *ConsensusManagerUseCase) ApproveValidatorChange(ctx context.Context, request *ApproveValidatorChangeRequest) (*ApproveValidatorChangeResponse, error) {
+	if request == nil {
+		return &ApproveValidatorChangeResponse{
+			Success: false,
+			Message: "request is nil",
+		}, fmt.Errorf("request cannot be nil")
+	}
+
+	validatorID, privateKey := uc.signer(request.ValidatorID, request.PrivateKey)
+	if request.ProposalID.IsEmpty() || validatorID.IsEmpty() || privateKey == nil {
+		return &ApproveValidatorChangeResponse{
+			Success: false,
+			Message: "proposal ID, validator ID and private key are required",
+		}, fmt.Errorf("proposal ID, validator ID and private key are required")
+	}
+
+	approval := entities.NewValidatorApproval(request.ProposalID, validatorID)
+	tx, err := consensus.NewValidatorApprovalTransaction(ctx, uc.cryptoService, approval, privateKey)
+	if err == nil {
+		err = uc.submitAndBroadcast(ctx, tx)
+	}
+
+	if err != nil {
+		return &ApproveValidatorChangeResponse{
+			Success: false,
+			Message: fmt.Sprintf("failed to approve validator change: %v", err),
+		}, fmt.Errorf("failed to approve validator change: %w", err)
+	}
+
+	uc.logger.InfoContext(ctx, "validator change approved",
+		"proposal_id", request.ProposalID.String(), "validator", validatorID.String(), logging.TxHash(tx.GetHash()))
+
+	return &ApproveValidatorChangeResponse{
+		Success:       true,
+		Message:       fmt.Sprintf("approval for proposal %s submitted", request.ProposalID.String()),
+		TransactionID: tx.GetHash(),
+	}, nil
+}
+
+// GetPendingValidatorProposals retorna as propostas de validador aguardando aprovação
+func (uc *ConsensusManagerUseCase) GetPendingValidatorProposals(ctx context.Context) ([]consensus.ValidatorProposalInfo, error) {
+	if uc.governance == nil {
+		return nil, fmt.Errorf("validator governance not configured")
+	}
+
+	return uc.governance.GetPendingProposals(ctx), nil
+}
+
+// submitValidatorProposal assina e submete uma proposta, definindo a altura efetiva
+func (uc *ConsensusManagerUseCase) submitValidatorProposal(
+	ctx context.Context,
+	action entities.ValidatorAction,
+	nodeID valueobjects.NodeID,
+	publicKey *services.PublicKey,
+	proposer valueobjects.NodeID,
+	effectiveHeight uint64,
+	privateKey *services.PrivateKey,
+) (valueobjects.Hash, uint64, error) {
+	proposer, privateKey = uc.signer(proposer, privateKey)
+	if proposer.IsEmpty() || privateKey == nil {
+		return valueobjects.EmptyHash(), 0, fmt.Errorf("proposer ID and private key are required")
+	}
+
+	height, err := uc.chainManager.GetChainHeight(ctx)
+	if err != nil {
+		return valueobjects.EmptyHash(), 0, fmt.Errorf("failed to get chain height: %w", err)
+	}
+
+	// A alteração precisa de tempo para receber aprovações antes de valer
+	minHeight := height + 2
+	if effectiveHeight == 0 {
+		effectiveHeight = minHeight + uint64(uc.chainManager.GetMaxReorgDepth())
+	}
+
+	if effectiveHeight < minHeight {
+		return valueobjects.EmptyHash(), 0, fmt.Errorf("effective height must be at least %d", minHeight)
+	}
+
+	var keyBytes []byte
+	var curve string
+	if publicKey != nil {
+		keyBytes = publicKey.ToBytes()
+		curve = publicKey.Curve
+	}
+
+	proposal := entities.NewValidatorProposal(action, nodeID, keyBytes, curve, effectiveHeight, proposer)
+
+	tx, err := consensus.NewValidatorProposalTransaction(ctx, uc.cryptoService, proposal, privateKey)
+	if err != nil {
+		return valueobjects.EmptyHash(), 0, err
+	}
+
+	if err := uc.submitAndBroadcast(ctx, tx); err != nil {
+		return valueobjects.EmptyHash(), 0, err
+	}
+
+	return tx.GetHash(), effectiveHeight, nil
+}
+
+// submitAndBroadcast adiciona a transação ao pool local e a propaga para os peers
+func (uc *ConsensusManagerUseCase) submitAndBroadcast(ctx context.Context, tx *entities.Transaction) error {
+	if err := uc.requireEngine(); err != nil {
+		return err
+	}
+
+	if err := uc.engine.AddTransaction(ctx, tx); err != nil {
+		return fmt.Errorf("failed to submit transaction: %w", err)
+	}
+
+	// Sem rede a transação ainda é incluída quando este nó produzir um bloco
+	if broadcaster, ok := uc.engine.(transactionBroadcaster); ok {
+		_ = broadcaster.BroadcastTransaction(ctx, tx)
+	}
+
+	return nil
+}
+
 // SubmitTransactionRequest representa a requisição para submeter transação
 type SubmitTransactionRequest struct {
 	Transaction *entities.Transaction
@@ -198,8 +464,15 @@ func (uc *ConsensusManagerUseCase) SubmitTransaction(ctx context.Context, reques
 		tx.SetID(tx.GetHash())
 	}
 
+	if err := uc.requireEngine(); err != nil {
+		return &SubmitTransactionResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
 	// Submeter para o consenso
-	err := uc.poaEngine.AddTransaction(ctx, tx)
+	err := uc.engine.AddTransaction(ctx, tx)
 	if err != nil {
 		return &SubmitTransactionResponse{
 			Success: false,
@@ -238,47 +511,116 @@ type ValidatorStatusInfo struct {
 
 // GetConsensusStatus retorna o status atual do consenso
 func (uc *ConsensusManagerUseCase) GetConsensusStatus(ctx context.Context) (*GetConsensusStatusResponse, error) {
+	if err := uc.requireEngine(); err != nil {
+		return nil, err
+	}
+
 	// Obter status do consenso
-	consensusStatus, err := uc.poaEngine.GetConsensusStatus(ctx)
+	consensusStatus, err := uc.engine.GetConsensusStatus(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get consensus status: %w", err)
 	}
 
 	// Obter informações dos validadores
+	validatorStats, err := uc.ListValidators(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetConsensusStatusResponse{
+		IsRunning:        consensusStatus.IsRunning,
+		CurrentValidator: consensusStatus.CurrentValidator,
+		CurrentRound:     consensusStatus.CurrentRound,
+		ValidatorCount:   consensusStatus.ValidatorCount,
+		PendingTxCount:   uc.engine.GetPendingTransactionCount(),
+		LastBlockTime:    consensusStatus.LastBlockTime,
+		ValidatorStats:   validatorStats,
+	}, nil
+}
+
+// ListValidators retorna as estatísticas de todos os validadores, ordenadas por NodeID
+func (uc *ConsensusManagerUseCase) ListValidators(ctx context.Context) ([]ValidatorStatusInfo, error) {
 	validators, err := uc.validatorManager.GetAllValidators(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get validators: %w", err)
 	}
 
-	validatorStats := make([]ValidatorStatusInfo, len(validators))
-	for i, validator := range validators {
-		stats, err := uc.validatorManager.GetValidatorStats(ctx, validator.NodeID)
+	validatorStats := make([]ValidatorStatusInfo, 0, len(validators))
+	for _, validator := range validators {
+		info, err := uc.GetValidator(ctx, validator.NodeID)
 		if err != nil {
 			continue // Pular validador com erro
 		}
+		validatorStats = append(validatorStats, *info)
+	}
 
-		validatorStats[i] = ValidatorStatusInfo{
-			NodeID:       stats.NodeID,
-			Status:       stats.Status,
-			TotalRounds:  stats.TotalRounds,
-			MissedRounds: stats.MissedRounds,
-			SuccessRate:  stats.SuccessRate,
-			PenaltyCount: stats.PenaltyCount,
-			LastActiveAt: stats.LastActiveAt,
-		}
+	sort.Slice(validatorStats, func(i, j int) bool {
+		return validatorStats[i].NodeID.String() < validatorStats[j].NodeID.String()
+	})
+
+	return validatorStats, nil
+}
+
+// GetValidator retorna as estatísticas de um validador
+func (uc *ConsensusManagerUseCase) GetValidator(ctx context.Context, nodeID valueobjects.NodeID) (*ValidatorStatusInfo, error) {
+	stats, err := uc.validatorManager.GetValidatorStats(ctx, nodeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get validator stats: %w", err)
 	}
 
-	return &GetConsensusStatusResponse{
-		IsRunning:        consensusStatus.IsRunning,
-		CurrentValidator: consensusStatus.CurrentValidator,
-		CurrentRound:     consensusStatus.CurrentRound,
-		ValidatorCount:   consensusStatus.ValidatorCount,
-		PendingTxCount:   uc.poaEngine.GetPendingTransactionCount(),
-		LastBlockTime:    consensusStatus.LastBlockTime,
-		ValidatorStats:   validatorStats,
+	return &ValidatorStatusInfo{
+		NodeID:       stats.NodeID,
+		Status:       stats.Status,
+		TotalRounds:  stats.TotalRounds,
+		MissedRounds: stats.MissedRounds,
+		SuccessRate:  stats.SuccessRate,
+		PenaltyCount: stats.PenaltyCount,
+		LastActiveAt: stats.LastActiveAt,
 	}, nil
 }
 
+// GetRoundInfoResponse representa o round atual e o proposer esperado
+type GetRoundInfoResponse struct {
+	Round            uint64
+	CurrentProposer  valueobjects.NodeID
+	NextHeight       uint64
+	InTurnValidator  valueobjects.NodeID // Validador da vez para a próxima altura
+	StartTime        valueobjects.Timestamp
+	Duration         time.Duration
+	TimeRemaining    time.Duration
+	ActiveValidators int
+	PendingTxCount   int
+}
+
+// GetRoundInfo retorna o round atual do RoundRobinScheduler e o proposer da próxima altura
+func (uc *ConsensusManagerUseCase) GetRoundInfo(ctx context.Context) (*GetRoundInfoResponse, error) {
+	if err := uc.requireEngine(); err != nil {
+		return nil, err
+	}
+
+	roundInfo, err := uc.engine.GetRoundRobinScheduler().GetRoundInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get round info: %w", err)
+	}
+
+	response := &GetRoundInfoResponse{
+		Round:            roundInfo.Round,
+		CurrentProposer:  roundInfo.CurrentValidator,
+		StartTime:        roundInfo.StartTime,
+		Duration:         roundInfo.Duration,
+		TimeRemaining:    roundInfo.TimeRemaining,
+		ActiveValidators: roundInfo.ValidatorCount,
+		PendingTxCount:   uc.engine.GetPendingTransactionCount(),
+	}
+
+	if height, err := uc.chainManager.GetChainHeight(ctx); err == nil {
+		response.NextHeight = height + 1
+		response.InTurnValidator, _ = uc.validatorManager.GetInTurnValidator(ctx, response.NextHeight)
+	}
+
+	return response, nil
+}
+
 // ApplyPenaltyRequest representa a requisição para aplicar penalidade
 type ApplyPenaltyRequest struct {
 	ValidatorID valueobjects.NodeID
@@ -317,6 +659,8 @@ func (uc *ConsensusManagerUseCase) ApplyPenalty(ctx context.Context, request *Ap
 		}, fmt.Errorf("failed to apply penalty: %w", err)
 	}
 
+	uc.logger.WarnContext(ctx, "penalty applied", "validator", request.ValidatorID.String(), "reason", request.Reason)
+
 	return &ApplyPenaltyResponse{
 		Success: true,
 		Message: fmt.Sprintf("penalty applied to validator %s", request.ValidatorID.ShortString()),
@@ -346,6 +690,7 @@ type PenaltyInfo struct {
 	AppliedAt valueobjects.Timestamp
 	ExpiresAt valueobjects.Timestamp
 	IsActive  bool
+	Evidence  map[string]interface{}
 }
 
 // GetValidatorPenalties retorna as penalidades de um validador
@@ -379,6 +724,7 @@ func (uc *ConsensusManagerUseCase) GetValidatorPenalties(ctx context.Context, re
 			AppliedAt: penalty.AppliedAt,
 			ExpiresAt: penalty.ExpiresAt,
 			IsActive:  penalty.IsActive,
+			Evidence:  penalty.Evidence,
 		}
 
 		if penalty.IsActive && valueobjects.Now().Before(penalty.ExpiresAt) {
@@ -418,10 +764,27 @@ func (uc *ConsensusManagerUseCase) ConfigureConsensus(ctx context.Context, reque
 		}, fmt.Errorf("request cannot be nil")
 	}
 
-	// Configurar motor PoA
+	if err := uc.requireEngine(); err != nil {
+		return &ConfigureConsensusResponse{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	// Intervalo e limites de transações valem apenas para motores com produção por intervalo
 	if request.BlockInterval > 0 || request.MinTxPerBlock > 0 || request.MaxTxPerBlock > 0 {
-		uc.poaEngine.SetConfiguration(request.BlockInterval, request.MinTxPerBlock, request.MaxTxPerBlock)
+		configurer, ok := uc.engine.(blockProductionConfigurer)
+		if !ok {
+			err := fmt.Errorf("block production settings are not supported by this consensus engine")
+			return &ConfigureConsensusResponse{
+				Success: false,
+				Message: err.Error(),
+			}, err
+		}
+		configurer.SetConfiguration(request.BlockInterval, request.MinTxPerBlock, request.MaxTxPerBlock)
 	}
+	uc.logger.InfoContext(ctx, "consensus configured",
+		"block_interval", request.BlockInterval.String(), "min_tx_per_block", request.MinTxPerBlock, "max_tx_per_block", request.MaxTxPerBlock)
 
 	return &ConfigureConsensusResponse{
 		Success: true,
@@ -431,5 +794,14 @@ func (uc *ConsensusManagerUseCase) ConfigureConsensus(ctx context.Context, reque
 
 // StopConsensus para o processo de consenso
 func (uc *ConsensusManagerUseCase) StopConsensus(ctx context.Context) error {
-	return uc.poaEngine.StopConsensus(ctx)
+	if err := uc.requireEngine(); err != nil {
+		return err
+	}
+
+	if err := uc.engine.StopConsensus(ctx); err != nil {
+		return err
+	}
+
+	uc.logger.InfoContext(ctx, "consensus stopped")
+	return nil
 }
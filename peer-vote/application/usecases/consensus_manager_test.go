@@ -0,0 +1,158 @@
+package usecases
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/persistence"
+)
+
+// bftTestNode é um nó em memória com um único validador e consenso BFT
+type bftTestNode struct {
+	useCase       *ConsensusManagerUseCase
+	engine        *consensus.BFTEngine
+	chainManager  *blockchain.ChainManager
+	cryptoService services.CryptographyService
+	nodeID        valueobjects.NodeID
+	keyPair       *services.KeyPair
+}
+
+// newBFTTestNode monta o caso de uso de consenso sobre o motor BFT, como o comando start
+func newBFTTestNode(t *testing.T) *bftTestNode {
+	t.Helper()
+	ctx := context.Background()
+
+	cryptoService := crypto.NewECDSAService()
+	chainManager := blockchain.NewChainManager(persistence.NewMemoryBlockchainRepository(cryptoService), cryptoService)
+
+	keyPair, err := cryptoService.GenerateKeyPair(ctx)
+	if err != nil {
+		t.Fatalf("generate key pair: %v", err)
+	}
+	nodeID := cryptoService.GenerateNodeID(ctx, keyPair.PublicKey)
+
+	validatorManager := consensus.NewValidatorManager()
+	if err := validatorManager.AddValidator(ctx, nodeID, keyPair.PublicKey); err != nil {
+		t.Fatalf("add validator: %v", err)
+	}
+	engine := consensus.NewBFTEngine(validatorManager, chainManager, cryptoService, nodeID, keyPair.PrivateKey, nil, consensus.DefaultBFTConfig())
+
+	useCase := NewConsensusManagerUseCase(engine, validatorManager, consensus.NewPenaltySystem(validatorManager),
+		consensus.NewValidatorGovernance(validatorManager, chainManager, cryptoService), chainManager, cryptoService)
+	useCase.SetNodeKey(nodeID, keyPair.PrivateKey)
+
+	return &bftTestNode{
+		useCase:       useCase,
+		engine:        engine,
+		chainManager:  chainManager,
+		cryptoService: cryptoService,
+		nodeID:        nodeID,
+		keyPair:       keyPair,
+	}
+}
+
+func TestConsensusManagerBFTStatus(t *testing.T) {
+	node := newBFTTestNode(t)
+	ctx := context.Background()
+
+	status, err := node.useCase.GetConsensusStatus(ctx)
+	if err != nil {
+		t.Fatalf("GetConsensusStatus: %v", err)
+	}
+	if status.IsRunning {
+		t.Errorf("consensus reported running before start")
+	}
+	if status.ValidatorCount != 1 {
+		t.Errorf("validator count = %d, want 1", status.ValidatorCount)
+	}
+
+	if _, err := node.useCase.GetRoundInfo(ctx); err != nil {
+		t.Fatalf("GetRoundInfo: %v", err)
+	}
+
+	validators, err := node.useCase.ListValidators(ctx)
+	if err != nil {
+		t.Fatalf("ListValidators: %v", err)
+	}
+	if len(validators) != 1 || !validators[0].NodeID.Equals(node.nodeID) {
+		t.Errorf("validators = %+v, want only the local node", validators)
+	}
+}
+
+func TestConsensusManagerBFTGovernanceProposal(t *testing.T) {
+	node := newBFTTestNode(t)
+	ctx := context.Background()
+
+	candidate, err := node.cryptoService.GenerateKeyPair(ctx)
+	if err != nil {
+		t.Fatalf("generate candidate key pair: %v", err)
+	}
+
+	response, err := node.useCase.AddValidator(ctx, &AddValidatorRequest{
+		NodeID:    node.cryptoService.GenerateNodeID(ctx, candidate.PublicKey),
+		PublicKey: candidate.PublicKey,
+	})
+	if err != nil {
+		t.Fatalf("AddValidator: %v", err)
+	}
+	if !response.Success || response.ProposalID.IsEmpty() {
+		t.Fatalf("AddValidator response = %+v, want a submitted proposal", response)
+	}
+
+	if pending := node.engine.GetPendingTransactionCount(); pending != 1 {
+		t.Errorf("pending transactions = %d, want the proposal in the BFT mempool", pending)
+	}
+}
+
+func TestConsensusManagerBFTStartStop(t *testing.T) {
+	node := newBFTTestNode(t)
+	ctx := context.Background()
+
+	// O gênesis precisa de ao menos uma transação
+	candidate, err := node.cryptoService.GenerateKeyPair(ctx)
+	if err != nil {
+		t.Fatalf("generate candidate key pair: %v", err)
+	}
+	if _, err := node.useCase.AddValidator(ctx, &AddValidatorRequest{
+		NodeID:    node.cryptoService.GenerateNodeID(ctx, candidate.PublicKey),
+		PublicKey: candidate.PublicKey,
+	}); err != nil {
+		t.Fatalf("AddValidator: %v", err)
+	}
+	if err := node.chainManager.CreateGenesisBlock(ctx, node.engine.GetMempool().Select(100), node.nodeID, node.keyPair.PrivateKey); err != nil {
+		t.Fatalf("create genesis block: %v", err)
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	node.useCase.SetRunContext(runCtx)
+
+	for i := 0; i < 2; i++ {
+		if _, err := node.useCase.StartConsensus(ctx, &StartConsensusRequest{}); err != nil {
+			t.Fatalf("StartConsensus #%d: %v", i+1, err)
+		}
+		status, err := node.useCase.GetConsensusStatus(ctx)
+		if err != nil || !status.IsRunning {
+			t.Fatalf("consensus not running after start #%d (err: %v)", i+1, err)
+		}
+		if err := node.useCase.StopConsensus(ctx); err != nil {
+			t.Fatalf("StopConsensus #%d: %v", i+1, err)
+		}
+	}
+}
+
+func TestConsensusManagerBFTRejectsBlockProductionSettings(t *testing.T) {
+	node := newBFTTestNode(t)
+
+	_, err := node.useCase.ConfigureConsensus(context.Background(), &ConfigureConsensusRequest{BlockInterval: time.Second})
+	if err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Fatalf("ConfigureConsensus error = %v, want unsupported settings", err)
+	}
+}
@@ -3,11 +3,13 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/matscats/peer-vote/peer-vote/domain/entities"
 	"github.com/matscats/peer-vote/peer-vote/domain/services"
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
 )
 
 // CreateElectionRequest representa uma requisição para criar eleição
@@ -38,6 +40,27 @@ type CreateElectionUseCase struct {
 	validationService services.VotingValidationService
 	blockchainService services.BlockchainService
 	consensusService  services.ConsensusService
+	nodePrivateKey    *services.PrivateKey
+	durationLimits    ElectionDurationLimits
+	logger            *slog.Logger
+}
+
+// ElectionDurationLimits são as durações mínima e máxima aceitas para uma eleição
+// (voting.validation.min/max_election_duration); zero desabilita o limite
+type ElectionDurationLimits struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// Check verifica se a duração da eleição está dentro dos limites
+func (l ElectionDurationLimits) Check(duration time.Duration) error {
+	if l.Min > 0 && duration < l.Min {
+		return fmt.Errorf("election duration %s is below the minimum of %s", duration, l.Min)
+	}
+	if l.Max > 0 && duration > l.Max {
+		return fmt.Errorf("election duration %s exceeds the maximum of %s", duration, l.Max)
+	}
+	return nil
 }
 
 // NewCreateElectionUseCase cria um novo caso de uso de criação de eleições
@@ -52,9 +75,31 @@ func NewCreateElectionUseCase(
 		validationService: validationService,
 		blockchainService: blockchainService,
 		consensusService:  consensusService,
+		logger:            logging.Component("elections"),
 	}
 }
 
+// SetLogger define o logger do caso de uso
+func (uc *CreateElectionUseCase) SetLogger(logger *slog.Logger) {
+	uc.logger = logger
+}
+
+// SetNodeKey define a chave do nó usada para assinar a transação quando a requisição não traz chave
+// (eleições criadas pela API REST)
+func (uc *CreateElectionUseCase) SetNodeKey(privateKey *services.PrivateKey) {
+	uc.nodePrivateKey = privateKey
+}
+
+// SetDurationLimits define as durações mínima e máxima aceitas para novas eleições
+func (uc *CreateElectionUseCase) SetDurationLimits(limits ElectionDurationLimits) {
+	uc.durationLimits = limits
+}
+
+// DurationLimits retorna os limites de duração aplicados às novas eleições
+func (uc *CreateElectionUseCase) DurationLimits() ElectionDurationLimits {
+	return uc.durationLimits
+}
+
 // Execute executa o caso de uso de criação de eleição
 func (uc *CreateElectionUseCase) Execute(ctx context.Context, request *CreateElectionRequest) (*CreateElectionResponse, error) {
 	// Validar entrada
@@ -93,7 +138,14 @@ func (uc *CreateElectionUseCase) Execute(ctx context.Context, request *CreateEle
 	}
 
 	// Criar transação blockchain com os dados da eleição
-	transaction, err := uc.createElectionTransaction(ctx, election, request.PrivateKey)
+	privateKey := request.PrivateKey
+	if privateKey == nil {
+		privateKey = uc.nodePrivateKey
+	}
+	if privateKey == nil || !privateKey.IsValid() {
+		return nil, fmt.Errorf("no private key available to sign the election transaction")
+	}
+	transaction, err := uc.createElectionTransaction(ctx, election, privateKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create election transaction: %w", err)
 	}
@@ -107,17 +159,24 @@ func (uc *CreateElectionUseCase) Execute(ctx context.Context, request *CreateEle
 	blockHash, err := uc.waitForTransactionConfirmation(ctx, transaction.GetHash(), 10*time.Second)
 	inBlockchain := err == nil && !blockHash.IsEmpty()
 	if err != nil {
-		fmt.Printf("Warning: election transaction confirmation timeout: %v\n", err)
+		uc.logger.WarnContext(ctx, "election transaction not confirmed in time",
+			"election_id", electionID.String(), logging.TxHash(transaction.GetHash()), logging.Err(err))
 	}
+	uc.logger.InfoContext(ctx, "election submitted",
+		"election_id", electionID.String(), logging.TxHash(transaction.GetHash()), "in_blockchain", inBlockchain)
 
 	// Eleição agora é criada apenas na blockchain
+	message := fmt.Sprintf("Election '%s' created on blockchain", election.GetTitle())
+	if !inBlockchain {
+		message = fmt.Sprintf("Election '%s' accepted and pending inclusion; track it by transaction hash", election.GetTitle())
+	}
 
 	return &CreateElectionResponse{
 		Election:        election,
 		TransactionHash: transaction.GetHash(),
 		BlockHash:       blockHash,
 		InBlockchain:    inBlockchain,
-		Message:         fmt.Sprintf("Election '%s' created on blockchain", election.GetTitle()),
+		Message:         message,
 	}, nil
 }
 
@@ -147,22 +206,13 @@ func (uc *CreateElectionUseCase) validateRequest(request *CreateElectionRequest)
 		return fmt.Errorf("max votes per voter must be positive")
 	}
 
-	// Validar candidatos
-	candidateIDs := make(map[string]bool)
-	for i, candidate := range request.Candidates {
-		if candidate.ID == "" {
-			return fmt.Errorf("candidate %d: ID is required", i)
-		}
-		if candidate.Name == "" {
-			return fmt.Errorf("candidate %d: name is required", i)
-		}
-		if candidateIDs[candidate.ID] {
-			return fmt.Errorf("candidate %d: duplicate ID '%s'", i, candidate.ID)
-		}
-		candidateIDs[candidate.ID] = true
+	// Validar candidatos com as regras de Election.IsValid
+	if err := entities.ValidateCandidates(request.Candidates); err != nil {
+		return err
 	}
 
-	return nil
+	// Validar duração com os limites do nó (voting.validation)
+	return uc.durationLimits.Check(request.EndTime.Sub(request.StartTime))
 }
 
 // createElectionTransaction cria uma transação blockchain para a eleição
@@ -195,66 +245,12 @@ func (uc *CreateElectionUseCase) createElectionTransaction(ctx context.Context,
 	return transaction, nil
 }
 
-// waitForTransactionConfirmation aguarda a confirmação da transação na blockchain
+// waitForTransactionConfirmation aguarda a inclusão da transação pelo índice da cadeia
 func (uc *CreateElectionUseCase) waitForTransactionConfirmation(ctx context.Context, txHash valueobjects.Hash, timeout time.Duration) (valueobjects.Hash, error) {
 	// Criar contexto com timeout
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Polling para verificar se a transação foi incluída em um bloco
-	ticker := time.NewTicker(500 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-timeoutCtx.Done():
-			return valueobjects.EmptyHash(), fmt.Errorf("transaction confirmation timeout")
-		case <-ticker.C:
-			// Verificar se a transação foi incluída na blockchain
-			blockHash, err := uc.findTransactionInBlockchain(ctx, txHash)
-			if err == nil && !blockHash.IsEmpty() {
-				return blockHash, nil
-			}
-		}
-	}
-}
-
-// findTransactionInBlockchain procura uma transação na blockchain
-func (uc *CreateElectionUseCase) findTransactionInBlockchain(ctx context.Context, txHash valueobjects.Hash) (valueobjects.Hash, error) {
-	// Obter altura atual da blockchain
-	height, err := uc.blockchainService.GetChainHeight(ctx)
-	if err != nil {
-		return valueobjects.EmptyHash(), err
-	}
-
-	// Procurar nos últimos blocos
-	searchDepth := uint64(10)
-	startIndex := uint64(0)
-	if height > searchDepth {
-		startIndex = height - searchDepth
-	}
-
-	for i := height; i >= startIndex && i <= height; i-- {
-		block, err := uc.blockchainService.GetBlockByIndex(ctx, i)
-		if err != nil {
-			continue
-		}
-
-		// Verificar se a transação está neste bloco
-		transactions := block.GetTransactions()
-		for _, tx := range transactions {
-			if tx.GetHash().Equals(txHash) {
-				// Calcular hash do bloco
-				blockHash := uc.blockchainService.CalculateBlockHash(ctx, block)
-				return blockHash, nil
-			}
-		}
-
-		// Evitar underflow
-		if i == 0 {
-			break
-		}
-	}
-
-	return valueobjects.EmptyHash(), fmt.Errorf("transaction not found in blockchain")
+	blockHash, _, err := uc.blockchainService.WaitForTransaction(timeoutCtx, txHash)
+	return blockHash, err
 }
@@ -0,0 +1,178 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// CreateElectionFromTemplateRequest representa uma requisição para criar eleição a partir de um modelo
+type CreateElectionFromTemplateRequest struct {
+	TemplateName string               `json:"template_name"`
+	Title        string               `json:"title"`
+	Description  string               `json:"description"`
+	Candidates   []entities.Candidate `json:"candidates"`
+	StartTime    time.Time            `json:"start_time"`
+	EndTime      time.Time            `json:"end_time"` // Zero usa a duração padrão do modelo
+	CreatedBy    valueobjects.NodeID  `json:"created_by"`
+	PrivateKey   *services.PrivateKey `json:"-"`
+}
+
+// ElectionTemplateUseCase implementa a gestão dos modelos de eleição do nó e a criação de
+// eleições a partir deles
+type ElectionTemplateUseCase struct {
+	templateRepository    repositories.ElectionTemplateRepository
+	createElectionUseCase *CreateElectionUseCase
+	logger                *slog.Logger
+}
+
+// NewElectionTemplateUseCase cria um novo caso de uso de modelos de eleição
+func NewElectionTemplateUseCase(
+	templateRepository repositories.ElectionTemplateRepository,
+	createElectionUseCase *CreateElectionUseCase,
+) *ElectionTemplateUseCase {
+	return &ElectionTemplateUseCase{
+		templateRepository:    templateRepository,
+		createElectionUseCase: createElectionUseCase,
+		logger:                logging.Component("elections"),
+	}
+}
+
+// SetLogger define o logger do caso de uso
+func (uc *ElectionTemplateUseCase) SetLogger(logger *slog.Logger) {
+	uc.logger = logger
+}
+
+// CreateTemplate valida e salva um modelo novo
+func (uc *ElectionTemplateUseCase) CreateTemplate(ctx context.Context, template *entities.ElectionTemplate) (*entities.ElectionTemplate, error) {
+	if err := uc.validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	template.CreatedAt = now
+	template.UpdatedAt = now
+
+	if err := uc.templateRepository.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "election template created", "template", template.Name)
+	return template, nil
+}
+
+// UpdateTemplate valida e substitui um modelo existente, preservando criador e data de criação
+func (uc *ElectionTemplateUseCase) UpdateTemplate(ctx context.Context, template *entities.ElectionTemplate) (*entities.ElectionTemplate, error) {
+	existing, err := uc.templateRepository.GetTemplate(ctx, template.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	template.CreatedBy = existing.CreatedBy
+	template.CreatedAt = existing.CreatedAt
+	template.UpdatedAt = time.Now().Unix()
+
+	if err := uc.templateRepository.UpdateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	uc.logger.InfoContext(ctx, "election template updated", "template", template.Name)
+	return template, nil
+}
+
+// GetTemplate retorna um modelo pelo nome
+func (uc *ElectionTemplateUseCase) GetTemplate(ctx context.Context, name string) (*entities.ElectionTemplate, error) {
+	return uc.templateRepository.GetTemplate(ctx, name)
+}
+
+// ListTemplates lista os modelos do nó em ordem de nome
+func (uc *ElectionTemplateUseCase) ListTemplates(ctx context.Context) ([]*entities.ElectionTemplate, error) {
+	return uc.templateRepository.ListTemplates(ctx)
+}
+
+// DeleteTemplate remove um modelo; eleições já criadas com ele não são afetadas
+func (uc *ElectionTemplateUseCase) DeleteTemplate(ctx context.Context, name string) error {
+	if err := uc.templateRepository.DeleteTemplate(ctx, name); err != nil {
+		return err
+	}
+
+	uc.logger.InfoContext(ctx, "election template deleted", "template", name)
+	return nil
+}
+
+// CreateElection cria uma eleição com as regras do modelo. Sem fim informado, a eleição dura
+// a duração padrão do modelo; a duração é conferida com a faixa do modelo e, na criação,
+// com os limites do nó.
+func (uc *ElectionTemplateUseCase) CreateElection(ctx context.Context, request *CreateElectionFromTemplateRequest) (*CreateElectionResponse, error) {
+	if request == nil {
+		return nil, fmt.Errorf("request is nil")
+	}
+
+	template, err := uc.templateRepository.GetTemplate(ctx, request.TemplateName)
+	if err != nil {
+		return nil, err
+	}
+
+	endTime := request.EndTime
+	if endTime.IsZero() {
+		if template.DurationMinutes == 0 {
+			return nil, fmt.Errorf("template %s has no default duration: end time is required", template.Name)
+		}
+		endTime = request.StartTime.Add(template.Duration())
+	}
+
+	if err := template.CheckDuration(endTime.Sub(request.StartTime)); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	return uc.createElectionUseCase.Execute(ctx, &CreateElectionRequest{
+		Title:            request.Title,
+		Description:      request.Description,
+		Candidates:       request.Candidates,
+		StartTime:        request.StartTime,
+		EndTime:          endTime,
+		CreatedBy:        request.CreatedBy,
+		AllowAnonymous:   template.AllowAnonymous,
+		MaxVotesPerVoter: template.MaxVotesPerVoter,
+		PrivateKey:       request.PrivateKey,
+	})
+}
+
+// validateTemplate normaliza e valida o modelo, incluindo a compatibilidade das durações
+// com os limites do nó (um modelo não pode permitir eleições que o nó rejeitaria)
+func (uc *ElectionTemplateUseCase) validateTemplate(template *entities.ElectionTemplate) error {
+	if template == nil {
+		return fmt.Errorf("template is nil")
+	}
+
+	template.Normalize()
+	if err := template.Validate(); err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	limits := uc.createElectionUseCase.DurationLimits()
+	if template.DurationMinutes > 0 {
+		if err := limits.Check(template.Duration()); err != nil {
+			return fmt.Errorf("invalid template: duration_minutes: %w", err)
+		}
+	}
+	if limits.Min > 0 && template.MaxDurationMinutes > 0 && template.MaxDuration() < limits.Min {
+		return fmt.Errorf("invalid template: max_duration_minutes is below the node minimum of %s", limits.Min)
+	}
+	if limits.Max > 0 && template.MinDuration() > limits.Max {
+		return fmt.Errorf("invalid template: min_duration_minutes exceeds the node maximum of %s", limits.Max)
+	}
+
+	return nil
+}
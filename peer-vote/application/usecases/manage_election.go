@@ -3,11 +3,14 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"log/slog"
 
 	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
 	"github.com/matscats/peer-vote/peer-vote/domain/services"
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
 )
 
 // GetElectionRequest representa uma requisição para obter eleição
@@ -24,15 +27,23 @@ type GetElectionResponse struct {
 
 // ListElectionsRequest representa uma requisição para listar eleições
 type ListElectionsRequest struct {
-	ActiveOnly bool                  `json:"active_only"`
-	CreatedBy  valueobjects.NodeID   `json:"created_by,omitempty"`
+	ActiveOnly bool                    `json:"active_only"`
+	Status     entities.ElectionStatus `json:"status,omitempty"` // Status efetivo (pelo período de votação)
+	CreatedBy  valueobjects.NodeID     `json:"created_by,omitempty"`
+	From       int64                   `json:"from,omitempty"`   // Unix: votação termina após From
+	To         int64                   `json:"to,omitempty"`     // Unix: votação começa antes de To
+	Cursor     string                  `json:"cursor,omitempty"` // next_cursor da página anterior
+	Order      repositories.SortOrder  `json:"order,omitempty"`  // asc (padrão) ou desc pela posição na cadeia
+	Limit      int                     `json:"limit,omitempty"`  // 0 = sem limite
 }
 
 // ListElectionsResponse representa a resposta de listar eleições
 type ListElectionsResponse struct {
-	Elections []*entities.Election `json:"elections"`
-	Count     int                  `json:"count"`
-	Message   string               `json:"message"`
+	Elections  []*entities.Election `json:"elections"`
+	Count      int                  `json:"count"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+	HasMore    bool                 `json:"has_more"`
+	Message    string               `json:"message"`
 }
 
 // UpdateElectionStatusRequest representa uma requisição para atualizar status
@@ -68,6 +79,7 @@ type GetElectionResultsResponse struct {
 type ManageElectionUseCase struct {
 	validationService services.VotingValidationService
 	chainManager      *blockchain.ChainManager
+	logger            *slog.Logger
 }
 
 // NewManageElectionUseCase cria um novo caso de uso de gerenciamento de eleições
@@ -78,9 +90,15 @@ func NewManageElectionUseCase(
 	return &ManageElectionUseCase{
 		validationService: validationService,
 		chainManager:      chainManager,
+		logger:            logging.Component("elections"),
 	}
 }
 
+// SetLogger define o logger do caso de uso
+func (uc *ManageElectionUseCase) SetLogger(logger *slog.Logger) {
+	uc.logger = logger
+}
+
 // GetElection obtém uma eleição específica
 func (uc *ManageElectionUseCase) GetElection(ctx context.Context, request *GetElectionRequest) (*GetElectionResponse, error) {
 	if request == nil || request.ElectionID.IsEmpty() {
@@ -104,42 +122,50 @@ func (uc *ManageElectionUseCase) GetElection(ctx context.Context, request *GetEl
 	}, nil
 }
 
-// ListElections lista eleições
+// ListElections lista eleições com filtros, ordem e paginação por cursor
 func (uc *ManageElectionUseCase) ListElections(ctx context.Context, request *ListElectionsRequest) (*ListElectionsResponse, error) {
-	var elections []*entities.Election
-	var err error
-
 	if request == nil {
 		request = &ListElectionsRequest{}
 	}
 
-	// Buscar eleições da blockchain
-	if request.ActiveOnly {
-		elections, err = uc.chainManager.GetActiveElectionsFromBlockchain(ctx)
-	} else {
-		elections, err = uc.chainManager.GetAllElectionsFromBlockchain(ctx)
+	query := blockchain.ElectionQuery{
+		ActiveOnly: request.ActiveOnly,
+		Status:     request.Status,
+		CreatedBy:  request.CreatedBy,
+		Order:      request.Order,
+		Limit:      request.Limit,
+	}
+	if request.From != 0 {
+		query.From = valueobjects.Unix(request.From, 0)
+	}
+	if request.To != 0 {
+		query.To = valueobjects.Unix(request.To, 0)
 	}
-	
-	// Filtrar por criador se especificado
-	if !request.CreatedBy.IsEmpty() && err == nil {
-		var filteredElections []*entities.Election
-		for _, election := range elections {
-			if election.GetCreatedBy().Equals(request.CreatedBy) {
-				filteredElections = append(filteredElections, election)
-			}
+	if request.Cursor != "" {
+		after, err := repositories.ParseCursor(request.Cursor)
+		if err != nil {
+			return nil, err
 		}
-		elections = filteredElections
+		query.After = &after
 	}
 
+	// Buscar eleições da blockchain
+	page, err := uc.chainManager.QueryElections(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list elections: %w", err)
 	}
 
-	return &ListElectionsResponse{
-		Elections: elections,
-		Count:     len(elections),
-		Message:   fmt.Sprintf("Found %d elections", len(elections)),
-	}, nil
+	response := &ListElectionsResponse{
+		Elections: page.Elections,
+		Count:     len(page.Elections),
+		HasMore:   page.NextCursor != nil,
+		Message:   fmt.Sprintf("Found %d elections", len(page.Elections)),
+	}
+	if page.NextCursor != nil {
+		response.NextCursor = page.NextCursor.Cursor()
+	}
+
+	return response, nil
 }
 
 // UpdateElectionStatus atualiza o status de uma eleição
@@ -154,8 +180,8 @@ func (uc *ManageElectionUseCase) UpdateElectionStatus(ctx context.Context, reque
 		return nil, fmt.Errorf("failed to get election from blockchain: %w", err)
 	}
 
-	// Validar transição de status
-	if err := uc.validateStatusTransition(election.GetStatus(), request.NewStatus); err != nil {
+	// Validar transição a partir do status efetivo (o mesmo exibido nas listagens)
+	if err := uc.validateStatusTransition(election.EffectiveStatus(valueobjects.Now()), request.NewStatus); err != nil {
 		return nil, fmt.Errorf("invalid status transition: %w", err)
 	}
 
@@ -172,6 +198,9 @@ func (uc *ManageElectionUseCase) UpdateElectionStatus(ctx context.Context, reque
 		return nil, fmt.Errorf("failed to get election from blockchain: %w", err)
 	}
 
+	uc.logger.InfoContext(ctx, "election status updated",
+		"election_id", request.ElectionID.String(), "status", string(request.NewStatus), "updated_by", request.UpdatedBy.String())
+
 	return &UpdateElectionStatusResponse{
 		Election: updatedElection,
 		Message:  fmt.Sprintf("Election status updated to %s", request.NewStatus),
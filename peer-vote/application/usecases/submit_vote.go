@@ -2,12 +2,16 @@ package usecases
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/matscats/peer-vote/peer-vote/domain/entities"
 	"github.com/matscats/peer-vote/peer-vote/domain/services"
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
 )
 
 // SubmitVoteRequest representa uma requisição para submeter um voto
@@ -17,6 +21,12 @@ type SubmitVoteRequest struct {
 	CandidateID string                `json:"candidate_id"`
 	IsAnonymous bool                  `json:"is_anonymous"`
 	PrivateKey  *services.PrivateKey  `json:"-"` // Não serializar por segurança
+
+	// Voto assinado pelo próprio eleitor (ver SignVote). Quando presente, o nó não conhece
+	// a chave privada: verifica as assinaturas com PublicKey e só repassa a transação.
+	SignedVote           *entities.Vote         `json:"-"`
+	TransactionSignature valueobjects.Signature `json:"-"`
+	PublicKey            *services.PublicKey    `json:"-"`
 }
 
 // SubmitVoteResponse representa a resposta da submissão de voto
@@ -25,17 +35,42 @@ type SubmitVoteResponse struct {
 	VoteID          string                `json:"vote_id"`
 	TransactionHash valueobjects.Hash     `json:"transaction_hash"`
 	BlockHash       valueobjects.Hash     `json:"block_hash,omitempty"`
+	BlockHeight     uint64                `json:"block_height,omitempty"`
+	FinalizedHeight uint64                `json:"finalized_height"`
+	IsFinal         bool                  `json:"is_final"`
 	Message         string                `json:"message"`
 	Submitted       bool                  `json:"submitted"`
 	InBlockchain    bool                  `json:"in_blockchain"`
 }
 
+// VoteOutcome é o resultado de uma submissão de voto
+type VoteOutcome string
+
+const (
+	VoteAccepted VoteOutcome = "accepted"
+
+	// Motivos de rejeição
+	VoteRejectedInvalidRequest   VoteOutcome = "invalid_request"
+	VoteRejectedElectionNotFound VoteOutcome = "election_not_found"
+	VoteRejectedSignature        VoteOutcome = "signature_failed"
+	VoteRejectedValidation       VoteOutcome = "validation_failed"
+	VoteRejectedTransaction      VoteOutcome = "transaction_failed"
+	VoteRejectedDuplicate        VoteOutcome = "duplicate"
+	VoteRejectedPoolLimit        VoteOutcome = "pool_limit"
+	VoteRejectedPool             VoteOutcome = "pool_rejected"
+)
+
+// VoteObserver é notificado do resultado de cada submissão de voto (ex: métricas)
+type VoteObserver func(outcome VoteOutcome)
+
 // SubmitVoteUseCase implementa o caso de uso de submissão de votos
 type SubmitVoteUseCase struct {
 	blockchainService services.BlockchainService
 	consensusService  services.ConsensusService
 	cryptoService     services.CryptographyService
 	validationService services.VotingValidationService
+	voteObserver      VoteObserver
+	logger            *slog.Logger
 }
 
 // NewSubmitVoteUseCase cria um novo caso de uso de submissão de votos
@@ -50,65 +85,106 @@ func NewSubmitVoteUseCase(
 		consensusService:  consensusService,
 		cryptoService:     cryptoService,
 		validationService: validationService,
+		logger:            logging.Component("votes"),
 	}
 }
 
+// SetLogger define o logger do caso de uso
+func (uc *SubmitVoteUseCase) SetLogger(logger *slog.Logger) {
+	uc.logger = logger
+}
+
+// SetVoteObserver define o observador dos resultados de submissão
+func (uc *SubmitVoteUseCase) SetVoteObserver(observer VoteObserver) {
+	uc.voteObserver = observer
+}
+
 // Execute executa o caso de uso de submissão de voto
 func (uc *SubmitVoteUseCase) Execute(ctx context.Context, request *SubmitVoteRequest) (*SubmitVoteResponse, error) {
+	response, outcome, err := uc.execute(ctx, request)
+	if uc.voteObserver != nil {
+		uc.voteObserver(outcome)
+	}
+
+	electionID := ""
+	if request != nil {
+		electionID = request.ElectionID.String()
+	}
+	if err != nil {
+		uc.logger.InfoContext(ctx, "vote rejected", "election_id", electionID, "reason", string(outcome), logging.Err(err))
+	} else {
+		uc.logger.InfoContext(ctx, "vote accepted", "election_id", electionID,
+			logging.TxHash(response.TransactionHash), "in_blockchain", response.InBlockchain)
+	}
+	return response, err
+}
+
+// execute submete o voto e classifica o resultado
+func (uc *SubmitVoteUseCase) execute(ctx context.Context, request *SubmitVoteRequest) (*SubmitVoteResponse, VoteOutcome, error) {
 	// Validar entrada
 	if err := uc.validateRequest(request); err != nil {
-		return nil, fmt.Errorf("invalid request: %w", err)
+		return nil, VoteRejectedInvalidRequest, fmt.Errorf("invalid request: %w", err)
 	}
 
 	// Obter eleição da blockchain
 	election, err := uc.blockchainService.GetElectionFromBlockchain(ctx, request.ElectionID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get election from blockchain: %w", err)
+		return nil, VoteRejectedElectionNotFound, fmt.Errorf("failed to get election from blockchain: %w", err)
 	}
 
-	// Criar voto
-	vote := entities.NewVote(
-		request.ElectionID,
-		request.VoterID,
-		request.CandidateID,
-		request.IsAnonymous,
-	)
-
-	// Assinar voto primeiro
-	if err := uc.signVote(ctx, vote, request.PrivateKey); err != nil {
-		return nil, fmt.Errorf("failed to sign vote: %w", err)
+	// Criar e assinar o voto, ou verificar o voto assinado pelo eleitor
+	var vote *entities.Vote
+	var txSignature valueobjects.Signature
+	if request.SignedVote != nil {
+		if err := uc.verifySignedVote(ctx, request); err != nil {
+			return nil, VoteRejectedSignature, fmt.Errorf("invalid signed vote: %w", err)
+		}
+		vote = request.SignedVote
+		txSignature = request.TransactionSignature
+	} else {
+		vote = entities.NewVote(
+			request.ElectionID,
+			request.VoterID,
+			request.CandidateID,
+			request.IsAnonymous,
+		)
+
+		txSignature, err = SignVote(ctx, uc.cryptoService, vote, request.PrivateKey)
+		if err != nil {
+			return nil, VoteRejectedSignature, fmt.Errorf("failed to sign vote: %w", err)
+		}
 	}
 
 	// Validar voto após assinatura
 	if err := uc.validationService.ValidateVote(ctx, vote, election); err != nil {
-		return nil, fmt.Errorf("vote validation failed: %w", err)
+		return nil, VoteRejectedValidation, fmt.Errorf("vote validation failed: %w", err)
 	}
 
-	// Gerar ID do voto
-	voteData, err := vote.ToBytes()
-	if err != nil {
-		return nil, fmt.Errorf("failed to serialize vote: %w", err)
-	}
-
-	voteID := uc.cryptoService.HashTransaction(ctx, voteData)
-	vote.SetID(voteID)
-
 	// Criar transação blockchain com os dados do voto
-	transaction, err := uc.createVoteTransaction(ctx, vote, request.PrivateKey)
+	transaction, err := uc.createVoteTransaction(ctx, vote, request.VoterID, txSignature)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create vote transaction: %w", err)
+		return nil, VoteRejectedTransaction, fmt.Errorf("failed to create vote transaction: %w", err)
 	}
 
 	// Adicionar transação ao pool do consenso
 	if err := uc.consensusService.AddTransaction(ctx, transaction); err != nil {
-		return nil, fmt.Errorf("failed to add vote transaction to consensus pool: %w", err)
+		return nil, poolRejection(err), fmt.Errorf("failed to add vote transaction to consensus pool: %w", err)
 	}
 
 	// Aguardar confirmação da transação (otimizado para consenso ultra-rápido)
-	blockHash, err := uc.waitForTransactionConfirmation(ctx, transaction.GetHash(), 5*time.Second)
+	blockHash, blockHeight, err := uc.waitForTransactionConfirmation(ctx, transaction.GetHash(), 5*time.Second)
 	if err != nil {
 		// Log do erro mas não falha - transação está no pool
-		fmt.Printf("Warning: transaction confirmation timeout: %v\n", err)
+		uc.logger.WarnContext(ctx, "vote transaction not confirmed in time", logging.TxHash(transaction.GetHash()), logging.Err(err))
+	}
+
+	// Voto só é irreversível quando seu bloco está abaixo da altura final
+	finalizedHeight, hasFinalized := uc.blockchainService.GetFinalizedHeight(ctx)
+	isFinal := hasFinalized && !blockHash.IsEmpty() && blockHeight <= finalizedHeight
+
+	message := "Vote submitted to blockchain successfully"
+	if blockHash.IsEmpty() {
+		message = "Vote accepted and pending inclusion; track it by transaction hash"
 	}
 
 	return &SubmitVoteResponse{
@@ -116,10 +192,25 @@ func (uc *SubmitVoteUseCase) Execute(ctx context.Context, request *SubmitVoteReq
 		VoteID:          vote.GetID().String(),
 		TransactionHash: transaction.GetHash(),
 		BlockHash:       blockHash,
-		Message:         "Vote submitted to blockchain successfully",
+		BlockHeight:     blockHeight,
+		FinalizedHeight: finalizedHeight,
+		IsFinal:         isFinal,
+		Message:         message,
 		Submitted:       true,
 		InBlockchain:    !blockHash.IsEmpty(),
-	}, nil
+	}, VoteAccepted, nil
+}
+
+// poolRejection classifica a recusa do pool de transações
+func poolRejection(err error) VoteOutcome {
+	switch {
+	case errors.Is(err, consensus.ErrTxAlreadyKnown), errors.Is(err, consensus.ErrTxAlreadyIncluded):
+		return VoteRejectedDuplicate
+	case errors.Is(err, consensus.ErrMempoolFull), errors.Is(err, consensus.ErrSenderLimit), errors.Is(err, consensus.ErrTxTooLarge):
+		return VoteRejectedPoolLimit
+	default:
+		return VoteRejectedPool
+	}
 }
 
 // validateRequest valida a requisição de submissão de voto
@@ -140,6 +231,10 @@ func (uc *SubmitVoteUseCase) validateRequest(request *SubmitVoteRequest) error {
 		return fmt.Errorf("candidate ID is required")
 	}
 
+	if request.SignedVote != nil {
+		return validateSignedVoteRequest(request)
+	}
+
 	if request.PrivateKey == nil || !request.PrivateKey.IsValid() {
 		return fmt.Errorf("valid private key is required")
 	}
@@ -147,26 +242,117 @@ func (uc *SubmitVoteUseCase) validateRequest(request *SubmitVoteRequest) error {
 	return nil
 }
 
-// signVote assina o voto com a chave privada
-func (uc *SubmitVoteUseCase) signVote(ctx context.Context, vote *entities.Vote, privateKey *services.PrivateKey) error {
-	// Serializar dados do voto para assinatura
+// validateSignedVoteRequest confere se o voto assinado corresponde aos campos da requisição
+func validateSignedVoteRequest(request *SubmitVoteRequest) error {
+	vote := request.SignedVote
+
+	if request.PublicKey == nil || !request.PublicKey.IsValid() {
+		return fmt.Errorf("valid public key is required for signed votes")
+	}
+
+	if request.TransactionSignature.IsEmpty() {
+		return fmt.Errorf("transaction signature is required for signed votes")
+	}
+
+	if vote.GetSignature().IsEmpty() || vote.GetID().IsEmpty() {
+		return fmt.Errorf("signed vote must include ID and signature")
+	}
+
+	if !vote.GetElectionID().Equals(request.ElectionID) ||
+		vote.GetCandidateID() != request.CandidateID ||
+		vote.IsAnonymous() != request.IsAnonymous {
+		return fmt.Errorf("signed vote does not match request fields")
+	}
+
+	if !request.IsAnonymous && !vote.GetVoterID().Equals(request.VoterID) {
+		return fmt.Errorf("signed vote voter ID does not match request")
+	}
+
+	return nil
+}
+
+// SignVote assina o voto com a chave privada do eleitor, define seu ID e retorna a assinatura
+// da transação que o carrega. É usado tanto pelo nó quanto por clientes que assinam localmente.
+func SignVote(ctx context.Context, cryptoService services.CryptographyService, vote *entities.Vote, privateKey *services.PrivateKey) (valueobjects.Signature, error) {
+	// Assinar os dados do voto (ainda sem assinatura e sem ID)
 	voteData, err := vote.ToBytes()
 	if err != nil {
-		return fmt.Errorf("failed to serialize vote for signing: %w", err)
+		return valueobjects.EmptySignature(), fmt.Errorf("failed to serialize vote for signing: %w", err)
 	}
 
-	// Assinar
-	signature, err := uc.cryptoService.Sign(ctx, voteData, privateKey)
+	signature, err := cryptoService.Sign(ctx, voteData, privateKey)
 	if err != nil {
-		return fmt.Errorf("failed to sign vote: %w", err)
+		return valueobjects.EmptySignature(), fmt.Errorf("failed to sign vote: %w", err)
 	}
-
 	vote.SetSignature(signature)
+
+	// Gerar ID do voto
+	signedData, err := vote.ToBytes()
+	if err != nil {
+		return valueobjects.EmptySignature(), fmt.Errorf("failed to serialize vote: %w", err)
+	}
+	vote.SetID(cryptoService.HashTransaction(ctx, signedData))
+
+	// Assinar os dados da transação (voto completo, incluindo ID)
+	txData, err := vote.ToBytesWithID()
+	if err != nil {
+		return valueobjects.EmptySignature(), fmt.Errorf("failed to serialize vote: %w", err)
+	}
+
+	txSignature, err := cryptoService.Sign(ctx, txData, privateKey)
+	if err != nil {
+		return valueobjects.EmptySignature(), fmt.Errorf("failed to sign transaction: %w", err)
+	}
+
+	return txSignature, nil
+}
+
+// verifySignedVote verifica as assinaturas e o ID de um voto assinado pelo eleitor
+func (uc *SubmitVoteUseCase) verifySignedVote(ctx context.Context, request *SubmitVoteRequest) error {
+	vote := request.SignedVote
+
+	// Eleitor informado deve ser o dono da chave pública (votos anônimos podem omiti-lo)
+	if !request.VoterID.IsEmpty() && !uc.cryptoService.GenerateNodeID(ctx, request.PublicKey).Equals(request.VoterID) {
+		return fmt.Errorf("public key does not belong to voter %s", request.VoterID.String())
+	}
+
+	// Assinatura do voto cobre os dados sem assinatura
+	unsigned := vote.Copy()
+	unsigned.SetSignature(valueobjects.EmptySignature())
+	unsignedData, err := unsigned.ToBytes()
+	if err != nil {
+		return fmt.Errorf("failed to serialize vote: %w", err)
+	}
+	valid, err := uc.cryptoService.Verify(ctx, unsignedData, vote.GetSignature(), request.PublicKey)
+	if err != nil || !valid {
+		return fmt.Errorf("vote signature verification failed")
+	}
+
+	// ID do voto é o hash dos dados assinados
+	signedData, err := vote.ToBytes()
+	if err != nil {
+		return fmt.Errorf("failed to serialize vote: %w", err)
+	}
+	if !uc.cryptoService.HashTransaction(ctx, signedData).Equals(vote.GetID()) {
+		return fmt.Errorf("vote ID does not match its content")
+	}
+
+	// Assinatura da transação cobre o voto completo
+	txData, err := vote.ToBytesWithID()
+	if err != nil {
+		return fmt.Errorf("failed to serialize vote: %w", err)
+	}
+	valid, err = uc.cryptoService.Verify(ctx, txData, request.TransactionSignature, request.PublicKey)
+	if err != nil || !valid {
+		return fmt.Errorf("transaction signature verification failed")
+	}
+
 	return nil
 }
 
 // createVoteTransaction cria uma transação blockchain para o voto
-func (uc *SubmitVoteUseCase) createVoteTransaction(ctx context.Context, vote *entities.Vote, privateKey *services.PrivateKey) (*entities.Transaction, error) {
+// (o remetente vem da requisição: votos anônimos desserializados não carregam o eleitor)
+func (uc *SubmitVoteUseCase) createVoteTransaction(ctx context.Context, vote *entities.Vote, sender valueobjects.NodeID, signature valueobjects.Signature) (*entities.Transaction, error) {
 	// Serializar o voto como dados da transação (incluindo ID)
 	voteData, err := vote.ToBytesWithID()
 	if err != nil {
@@ -176,7 +362,7 @@ func (uc *SubmitVoteUseCase) createVoteTransaction(ctx context.Context, vote *en
 	// Criar transação com timestamp único para evitar duplicatas
 	transaction := entities.NewTransaction(
 		"VOTE",                     // Tipo de transação
-		vote.GetVoterID(),         // Remetente (eleitor)
+		sender,                     // Remetente (eleitor)
 		valueobjects.EmptyNodeID(), // Destinatário vazio para votos
 		voteData,                  // Dados do voto
 	)
@@ -187,77 +373,18 @@ func (uc *SubmitVoteUseCase) createVoteTransaction(ctx context.Context, vote *en
 	transaction.SetID(txHash)
 	transaction.SetHash(txHash)
 
-	// Assinar transação
-	signature, err := uc.cryptoService.Sign(ctx, txData, privateKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to sign transaction: %w", err)
-	}
+	// Assinatura produzida por SignVote sobre os mesmos dados
 	transaction.SetSignature(signature)
 
 	return transaction, nil
 }
 
-// waitForTransactionConfirmation aguarda a confirmação da transação na blockchain
-func (uc *SubmitVoteUseCase) waitForTransactionConfirmation(ctx context.Context, txHash valueobjects.Hash, timeout time.Duration) (valueobjects.Hash, error) {
+// waitForTransactionConfirmation aguarda a inclusão da transação pelo índice da cadeia
+func (uc *SubmitVoteUseCase) waitForTransactionConfirmation(ctx context.Context, txHash valueobjects.Hash, timeout time.Duration) (valueobjects.Hash, uint64, error) {
 	// Criar contexto com timeout
 	timeoutCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	// Polling otimizado para verificar se a transação foi incluída em um bloco
-	ticker := time.NewTicker(200 * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-timeoutCtx.Done():
-			return valueobjects.EmptyHash(), fmt.Errorf("transaction confirmation timeout")
-		case <-ticker.C:
-			// Verificar se a transação foi incluída na blockchain
-			blockHash, err := uc.findTransactionInBlockchain(ctx, txHash)
-			if err == nil && !blockHash.IsEmpty() {
-				return blockHash, nil
-			}
-		}
-	}
-}
-
-// findTransactionInBlockchain procura uma transação na blockchain
-func (uc *SubmitVoteUseCase) findTransactionInBlockchain(ctx context.Context, txHash valueobjects.Hash) (valueobjects.Hash, error) {
-	// Obter altura atual da blockchain
-	height, err := uc.blockchainService.GetChainHeight(ctx)
-	if err != nil {
-		return valueobjects.EmptyHash(), err
-	}
-
-	// Procurar nos últimos blocos (otimização - transações recentes estão nos blocos mais novos)
-	searchDepth := uint64(10) // Procurar nos últimos 10 blocos
-	startIndex := uint64(0)
-	if height > searchDepth {
-		startIndex = height - searchDepth
-	}
-
-	for i := height; i >= startIndex && i <= height; i-- {
-		block, err := uc.blockchainService.GetBlockByIndex(ctx, i)
-		if err != nil {
-			continue // Pular blocos com erro
-		}
-
-		// Verificar se a transação está neste bloco
-		transactions := block.GetTransactions()
-		for _, tx := range transactions {
-			if tx.GetHash().Equals(txHash) {
-				// Calcular hash do bloco
-				blockHash := uc.blockchainService.CalculateBlockHash(ctx, block)
-				return blockHash, nil
-			}
-		}
-
-		// Evitar underflow
-		if i == 0 {
-			break
-		}
-	}
-
-	return valueobjects.EmptyHash(), fmt.Errorf("transaction not found in blockchain")
+	return uc.blockchainService.WaitForTransaction(timeoutCtx, txHash)
 }
 
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+)
+
+// TransactionStatus representa a etapa do ciclo de vida de uma transação
+type TransactionStatus string
+
+const (
+	// TransactionPending aguardando inclusão no mempool
+	TransactionPending TransactionStatus = "PENDING"
+	// TransactionIncluded incluída em um bloco canônico ainda reversível
+	TransactionIncluded TransactionStatus = "INCLUDED"
+	// TransactionFinalized incluída em um bloco abaixo da altura final
+	TransactionFinalized TransactionStatus = "FINALIZED"
+	// TransactionRejected rejeitada, descartada ou expirada no mempool
+	TransactionRejected TransactionStatus = "REJECTED"
+)
+
+// ErrTransactionNotFound indica que o nó não conhece a transação
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+// GetTransactionStatusResponse representa o estado de uma transação
+type GetTransactionStatusResponse struct {
+	Hash            valueobjects.Hash
+	Status          TransactionStatus
+	Reason          string // Motivo da rejeição
+	Type            entities.TransactionType
+	From            valueobjects.NodeID
+	BlockHash       valueobjects.Hash
+	BlockHeight     uint64
+	Position        int
+	Confirmations   uint64
+	FinalizedHeight uint64
+	AddedAt         time.Time // Entrada no mempool (pendentes)
+	ExpiresAt       time.Time // Expiração no mempool (pendentes)
+}
+
+// TransactionStatusUseCase consulta o ciclo de vida de transações pelo índice da cadeia e pelo mempool
+type TransactionStatusUseCase struct {
+	chainManager *blockchain.ChainManager
+	mempool      *consensus.Mempool
+}
+
+// NewTransactionStatusUseCase cria um novo caso de uso de status de transações
+func NewTransactionStatusUseCase(chainManager *blockchain.ChainManager, mempool *consensus.Mempool) *TransactionStatusUseCase {
+	return &TransactionStatusUseCase{
+		chainManager: chainManager,
+		mempool:      mempool,
+	}
+}
+
+// GetStatus retorna o estado atual da transação: incluída/final, pendente ou rejeitada
+func (uc *TransactionStatusUseCase) GetStatus(ctx context.Context, txHash valueobjects.Hash) (*GetTransactionStatusResponse, error) {
+	response := &GetTransactionStatusResponse{Hash: txHash}
+
+	// Cadeia canônica tem precedência: a transação pode ter sido incluída por outro nó
+	if location, ok := uc.chainManager.GetTransactionLocation(ctx, txHash); ok {
+		response.Status = TransactionIncluded
+		response.BlockHash = location.BlockHash
+		response.BlockHeight = location.BlockIndex
+		response.Position = location.Position
+
+		if block, err := uc.chainManager.GetBlockByIndex(ctx, location.BlockIndex); err == nil {
+			if transactions := block.GetTransactions(); location.Position < len(transactions) {
+				response.Type = transactions[location.Position].GetType()
+				response.From = transactions[location.Position].GetFrom()
+			}
+		}
+
+		if height, err := uc.chainManager.GetChainHeight(ctx); err == nil && height >= location.BlockIndex {
+			response.Confirmations = height - location.BlockIndex + 1
+		}
+
+		if finalizedHeight, hasFinalized := uc.chainManager.GetFinalizedHeight(ctx); hasFinalized {
+			response.FinalizedHeight = finalizedHeight
+			if location.BlockIndex <= finalizedHeight {
+				response.Status = TransactionFinalized
+			}
+		}
+
+		return response, nil
+	}
+
+	if uc.mempool != nil {
+		if entry, ok := uc.mempool.Get(txHash); ok {
+			response.Status = TransactionPending
+			response.Type = entry.Transaction.GetType()
+			response.From = entry.Transaction.GetFrom()
+			response.AddedAt = entry.AddedAt
+			response.ExpiresAt = entry.ExpiresAt
+			return response, nil
+		}
+
+		if reason, ok := uc.mempool.GetRejection(txHash); ok {
+			response.Status = TransactionRejected
+			response.Reason = reason
+			return response, nil
+		}
+	}
+
+	return nil, ErrTransactionNotFound
+}
@@ -0,0 +1,121 @@
+package entities
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// Attestation representa a assinatura de um validador confirmando um bloco da cadeia
+type Attestation struct {
+	blockHash valueobjects.Hash
+	height    uint64
+	validator valueobjects.NodeID
+	timestamp valueobjects.Timestamp
+	signature valueobjects.Signature
+}
+
+// AttestationData representa os dados serializáveis de uma atestação
+type AttestationData struct {
+	BlockHash string `json:"block_hash"`
+	Height    uint64 `json:"height"`
+	Validator string `json:"validator"`
+	Timestamp int64  `json:"timestamp"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// NewAttestation cria uma nova atestação (ainda não assinada)
+func NewAttestation(blockHash valueobjects.Hash, height uint64, validator valueobjects.NodeID) *Attestation {
+	return &Attestation{
+		blockHash: blockHash,
+		height:    height,
+		validator: validator,
+		timestamp: valueobjects.NewTimestamp(time.Now()),
+	}
+}
+
+// GetBlockHash retorna o hash do bloco atestado
+func (a *Attestation) GetBlockHash() valueobjects.Hash {
+	return a.blockHash
+}
+
+// GetHeight retorna a altura do bloco atestado
+func (a *Attestation) GetHeight() uint64 {
+	return a.height
+}
+
+// GetValidator retorna o validador que assinou a atestação
+func (a *Attestation) GetValidator() valueobjects.NodeID {
+	return a.validator
+}
+
+// GetTimestamp retorna o timestamp da atestação
+func (a *Attestation) GetTimestamp() valueobjects.Timestamp {
+	return a.timestamp
+}
+
+// GetSignature retorna a assinatura da atestação
+func (a *Attestation) GetSignature() valueobjects.Signature {
+	return a.signature
+}
+
+// SetSignature define a assinatura da atestação
+func (a *Attestation) SetSignature(signature valueobjects.Signature) {
+	a.signature = signature
+}
+
+// IsValid verifica se a atestação possui os campos obrigatórios
+func (a *Attestation) IsValid() bool {
+	return !a.blockHash.IsEmpty() && !a.validator.IsEmpty() && !a.signature.IsEmpty()
+}
+
+// SigningBytes retorna os dados determinísticos cobertos pela assinatura
+func (a *Attestation) SigningBytes() []byte {
+	return []byte(fmt.Sprintf("attestation|%d|%s|%s|%d",
+		a.height,
+		a.blockHash.String(),
+		a.validator.String(),
+		a.timestamp.Unix(),
+	))
+}
+
+// ToBytes serializa a atestação para bytes
+func (a *Attestation) ToBytes() ([]byte, error) {
+	return json.Marshal(AttestationData{
+		BlockHash: a.blockHash.String(),
+		Height:    a.height,
+		Validator: a.validator.String(),
+		Timestamp: a.timestamp.Unix(),
+		Signature: a.signature.String(),
+	})
+}
+
+// FromBytes deserializa uma atestação de bytes
+func (a *Attestation) FromBytes(data []byte) error {
+	var attestationData AttestationData
+	if err := json.Unmarshal(data, &attestationData); err != nil {
+		return err
+	}
+
+	blockHash, err := valueobjects.NewHashFromString(attestationData.BlockHash)
+	if err != nil {
+		return err
+	}
+
+	a.blockHash = blockHash
+	a.height = attestationData.Height
+	a.validator = valueobjects.NewNodeID(attestationData.Validator)
+	a.timestamp = valueobjects.Unix(attestationData.Timestamp, 0)
+
+	if attestationData.Signature != "" {
+		signature, err := valueobjects.NewSignatureFromString(attestationData.Signature)
+		if err != nil {
+			return err
+		}
+		a.signature = signature
+	}
+
+	return nil
+}
@@ -102,6 +102,16 @@ func (b *Block) SetSignature(signature valueobjects.Signature) {
 	b.header.signature = signature
 }
 
+// SetTimestamp define o timestamp do bloco (usado ao reconstruir blocos recebidos)
+func (b *Block) SetTimestamp(timestamp valueobjects.Timestamp) {
+	b.header.timestamp = timestamp
+}
+
+// SetNonce define o nonce do bloco (usado ao reconstruir blocos recebidos)
+func (b *Block) SetNonce(nonce uint64) {
+	b.header.nonce = nonce
+}
+
 // GetHeader retorna o header do bloco
 func (b *Block) GetHeader() *BlockHeader {
 	return b.header
@@ -0,0 +1,182 @@
+package entities
+
+import (
+	"fmt"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// ConsensusVoteType representa a etapa de votação do consenso BFT
+type ConsensusVoteType string
+
+const (
+	// VotePrevote primeiro voto de um round (sobre a proposta recebida)
+	VotePrevote ConsensusVoteType = "PREVOTE"
+	// VotePrecommit segundo voto de um round (compromisso com o bloco)
+	VotePrecommit ConsensusVoteType = "PRECOMMIT"
+)
+
+// ConsensusProposal representa a proposta de bloco do proposer de um round BFT
+type ConsensusProposal struct {
+	height    uint64
+	round     uint64
+	polRound  int64 // Round em que o bloco obteve 2/3 de prevotes (-1 se nenhum)
+	block     *Block
+	blockHash valueobjects.Hash
+	proposer  valueobjects.NodeID
+	signature valueobjects.Signature
+}
+
+// NewConsensusProposal cria uma nova proposta (ainda não assinada)
+func NewConsensusProposal(height, round uint64, polRound int64, block *Block, blockHash valueobjects.Hash, proposer valueobjects.NodeID) *ConsensusProposal {
+	return &ConsensusProposal{
+		height:    height,
+		round:     round,
+		polRound:  polRound,
+		block:     block,
+		blockHash: blockHash,
+		proposer:  proposer,
+	}
+}
+
+// GetHeight retorna a altura da proposta
+func (p *ConsensusProposal) GetHeight() uint64 {
+	return p.height
+}
+
+// GetRound retorna o round da proposta
+func (p *ConsensusProposal) GetRound() uint64 {
+	return p.round
+}
+
+// GetPOLRound retorna o round de prova de lock (-1 se nenhum)
+func (p *ConsensusProposal) GetPOLRound() int64 {
+	return p.polRound
+}
+
+// GetBlock retorna o bloco proposto
+func (p *ConsensusProposal) GetBlock() *Block {
+	return p.block
+}
+
+// GetBlockHash retorna o hash do bloco proposto
+func (p *ConsensusProposal) GetBlockHash() valueobjects.Hash {
+	return p.blockHash
+}
+
+// GetProposer retorna o validador que fez a proposta
+func (p *ConsensusProposal) GetProposer() valueobjects.NodeID {
+	return p.proposer
+}
+
+// GetSignature retorna a assinatura da proposta
+func (p *ConsensusProposal) GetSignature() valueobjects.Signature {
+	return p.signature
+}
+
+// SetSignature define a assinatura da proposta
+func (p *ConsensusProposal) SetSignature(signature valueobjects.Signature) {
+	p.signature = signature
+}
+
+// IsValid verifica se a proposta possui os campos obrigatórios
+func (p *ConsensusProposal) IsValid() bool {
+	return p.block != nil && !p.blockHash.IsEmpty() && !p.proposer.IsEmpty() && !p.signature.IsEmpty()
+}
+
+// SigningBytes retorna os dados determinísticos cobertos pela assinatura
+func (p *ConsensusProposal) SigningBytes() []byte {
+	return []byte(fmt.Sprintf("proposal|%d|%d|%d|%s|%s",
+		p.height,
+		p.round,
+		p.polRound,
+		p.blockHash.String(),
+		p.proposer.String(),
+	))
+}
+
+// ConsensusVote representa um prevote ou precommit de um validador.
+// Um hash de bloco vazio representa um voto nil.
+type ConsensusVote struct {
+	voteType  ConsensusVoteType
+	height    uint64
+	round     uint64
+	blockHash valueobjects.Hash
+	validator valueobjects.NodeID
+	signature valueobjects.Signature
+}
+
+// NewConsensusVote cria um novo voto de consenso (ainda não assinado)
+func NewConsensusVote(voteType ConsensusVoteType, height, round uint64, blockHash valueobjects.Hash, validator valueobjects.NodeID) *ConsensusVote {
+	// Normalizar voto nil para que a assinatura seja a mesma em todos os nós
+	if blockHash.IsEmpty() {
+		blockHash = valueobjects.EmptyHash()
+	}
+
+	return &ConsensusVote{
+		voteType:  voteType,
+		height:    height,
+		round:     round,
+		blockHash: blockHash,
+		validator: validator,
+	}
+}
+
+// GetType retorna a etapa do voto
+func (v *ConsensusVote) GetType() ConsensusVoteType {
+	return v.voteType
+}
+
+// GetHeight retorna a altura do voto
+func (v *ConsensusVote) GetHeight() uint64 {
+	return v.height
+}
+
+// GetRound retorna o round do voto
+func (v *ConsensusVote) GetRound() uint64 {
+	return v.round
+}
+
+// GetBlockHash retorna o hash do bloco votado (vazio para voto nil)
+func (v *ConsensusVote) GetBlockHash() valueobjects.Hash {
+	return v.blockHash
+}
+
+// IsNil verifica se é um voto nil
+func (v *ConsensusVote) IsNil() bool {
+	return v.blockHash.IsEmpty()
+}
+
+// GetValidator retorna o validador que votou
+func (v *ConsensusVote) GetValidator() valueobjects.NodeID {
+	return v.validator
+}
+
+// GetSignature retorna a assinatura do voto
+func (v *ConsensusVote) GetSignature() valueobjects.Signature {
+	return v.signature
+}
+
+// SetSignature define a assinatura do voto
+func (v *ConsensusVote) SetSignature(signature valueobjects.Signature) {
+	v.signature = signature
+}
+
+// IsValid verifica se o voto possui os campos obrigatórios
+func (v *ConsensusVote) IsValid() bool {
+	if v.voteType != VotePrevote && v.voteType != VotePrecommit {
+		return false
+	}
+	return !v.validator.IsEmpty() && !v.signature.IsEmpty()
+}
+
+// SigningBytes retorna os dados determinísticos cobertos pela assinatura
+func (v *ConsensusVote) SigningBytes() []byte {
+	return []byte(fmt.Sprintf("vote|%s|%d|%d|%s|%s",
+		v.voteType,
+		v.height,
+		v.round,
+		v.blockHash.String(),
+		v.validator.String(),
+	))
+}
@@ -2,6 +2,7 @@ package entities
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
@@ -189,6 +190,23 @@ func (e *Election) IsActive() bool {
 	return isInTimePeriod && isNotCancelled
 }
 
+// EffectiveStatus deriva o status da eleição pelo status registrado e pelo período de votação
+func (e *Election) EffectiveStatus(now valueobjects.Timestamp) ElectionStatus {
+	switch e.status {
+	case ElectionCancelled, ElectionClosed:
+		return e.status
+	}
+
+	switch {
+	case now.Before(e.startTime):
+		return ElectionPending
+	case now.Before(e.endTime):
+		return ElectionActive
+	default:
+		return ElectionClosed
+	}
+}
+
 // CanVote verifica se é possível votar nesta eleição
 func (e *Election) CanVote() bool {
 	return e.IsActive()
@@ -217,19 +235,28 @@ func (e *Election) IsValid() bool {
 		return false
 	}
 
-	// Verifica se todos os candidatos têm IDs únicos
+	// Verifica se todos os candidatos têm ID e nome, com IDs únicos
+	return ValidateCandidates(e.candidates) == nil
+}
+
+// ValidateCandidates aplica as regras de candidatos de IsValid (ID e nome obrigatórios, IDs
+// únicos) e descreve o primeiro candidato inválido pela posição na lista
+func ValidateCandidates(candidates []Candidate) error {
 	candidateIDs := make(map[string]bool)
-	for _, candidate := range e.candidates {
-		if candidate.ID == "" || candidate.Name == "" {
-			return false
+	for i, candidate := range candidates {
+		if candidate.ID == "" {
+			return fmt.Errorf("candidate %d: ID is required", i)
+		}
+		if candidate.Name == "" {
+			return fmt.Errorf("candidate %d: name is required", i)
 		}
 		if candidateIDs[candidate.ID] {
-			return false // ID duplicado
+			return fmt.Errorf("candidate %d: duplicate ID '%s'", i, candidate.ID)
 		}
 		candidateIDs[candidate.ID] = true
 	}
 
-	return true
+	return nil
 }
 
 // ToBytes serializa a eleição para bytes
@@ -278,6 +305,16 @@ func (e *Election) FromBytes(data []byte) error {
 	return nil
 }
 
+// MarshalJSON serializa a eleição no formato de ElectionData (respostas da API)
+func (e *Election) MarshalJSON() ([]byte, error) {
+	return e.ToBytes()
+}
+
+// UnmarshalJSON restaura a eleição a partir do formato de ElectionData
+func (e *Election) UnmarshalJSON(data []byte) error {
+	return e.FromBytes(data)
+}
+
 // GetResults retorna os resultados da eleição
 func (e *Election) GetResults() map[string]uint64 {
 	results := make(map[string]uint64)
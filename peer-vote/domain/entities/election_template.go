@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// BallotType define como o eleitor escolhe os candidatos
+type BallotType string
+
+const (
+	// BallotSingleChoice um voto por eleitor
+	BallotSingleChoice BallotType = "single_choice"
+	// BallotMultipleChoice até MaxVotesPerVoter votos por eleitor, em candidatos diferentes
+	BallotMultipleChoice BallotType = "multiple_choice"
+)
+
+// templateNamePattern restringe os nomes de modelo a identificadores seguros em URLs
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]{0,63}$`)
+
+// ElectionTemplate é um modelo de eleição mantido pelo nó: as regras reaproveitadas na
+// criação de eleições (tipo de cédula, duração, anonimato e votos por eleitor). Não é
+// registrado na cadeia; a eleição criada a partir dele carrega as regras aplicadas.
+type ElectionTemplate struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	BallotType  BallotType `json:"ballot_type"`
+	// Duração usada quando a eleição não informa o fim (0 exige end_time)
+	DurationMinutes int `json:"duration_minutes"`
+	// Faixa de duração aceita nas eleições do modelo (0 não limita)
+	MinDurationMinutes int    `json:"min_duration_minutes,omitempty"`
+	MaxDurationMinutes int    `json:"max_duration_minutes,omitempty"`
+	AllowAnonymous     bool   `json:"allow_anonymous"`
+	MaxVotesPerVoter   int    `json:"max_votes_per_voter"`
+	CreatedBy          string `json:"created_by,omitempty"`
+	CreatedAt          int64  `json:"created_at"`
+	UpdatedAt          int64  `json:"updated_at"`
+}
+
+// Normalize preenche os valores padrão: cédula de escolha única com um voto por eleitor
+func (t *ElectionTemplate) Normalize() {
+	if t.BallotType == "" {
+		t.BallotType = BallotSingleChoice
+	}
+	if t.BallotType == BallotSingleChoice && t.MaxVotesPerVoter == 0 {
+		t.MaxVotesPerVoter = 1
+	}
+}
+
+// Validate verifica se as regras do modelo são coerentes
+func (t *ElectionTemplate) Validate() error {
+	if !templateNamePattern.MatchString(t.Name) {
+		return fmt.Errorf("invalid template name %q (letters, digits, '.', '_' and '-', up to 64 characters)", t.Name)
+	}
+
+	switch t.BallotType {
+	case BallotSingleChoice:
+		if t.MaxVotesPerVoter != 1 {
+			return fmt.Errorf("single_choice ballots allow exactly 1 vote per voter")
+		}
+	case BallotMultipleChoice:
+		if t.MaxVotesPerVoter < 2 {
+			return fmt.Errorf("multiple_choice ballots require max_votes_per_voter of at least 2")
+		}
+	default:
+		return fmt.Errorf("unknown ballot type %q (expected %q or %q)", t.BallotType, BallotSingleChoice, BallotMultipleChoice)
+	}
+
+	if t.DurationMinutes < 0 || t.MinDurationMinutes < 0 || t.MaxDurationMinutes < 0 {
+		return fmt.Errorf("durations cannot be negative")
+	}
+	if t.MaxDurationMinutes > 0 && t.MinDurationMinutes > t.MaxDurationMinutes {
+		return fmt.Errorf("min_duration_minutes cannot exceed max_duration_minutes")
+	}
+	if t.DurationMinutes > 0 {
+		if err := t.CheckDuration(t.Duration()); err != nil {
+			return fmt.Errorf("duration_minutes: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Duration retorna a duração padrão das eleições do modelo
+func (t *ElectionTemplate) Duration() time.Duration {
+	return time.Duration(t.DurationMinutes) * time.Minute
+}
+
+// MinDuration retorna a duração mínima aceita pelo modelo (0 não limita)
+func (t *ElectionTemplate) MinDuration() time.Duration {
+	return time.Duration(t.MinDurationMinutes) * time.Minute
+}
+
+// MaxDuration retorna a duração máxima aceita pelo modelo (0 não limita)
+func (t *ElectionTemplate) MaxDuration() time.Duration {
+	return time.Duration(t.MaxDurationMinutes) * time.Minute
+}
+
+// CheckDuration verifica se a duração de uma eleição respeita a faixa do modelo
+func (t *ElectionTemplate) CheckDuration(duration time.Duration) error {
+	if t.MinDurationMinutes > 0 && duration < t.MinDuration() {
+		return fmt.Errorf("election duration %s is below the template minimum of %s", duration, t.MinDuration())
+	}
+	if t.MaxDurationMinutes > 0 && duration > t.MaxDuration() {
+		return fmt.Errorf("election duration %s exceeds the template maximum of %s", duration, t.MaxDuration())
+	}
+	return nil
+}
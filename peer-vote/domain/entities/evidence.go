@@ -0,0 +1,303 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// EvidenceType representa o tipo de mau comportamento comprovado por uma evidência
+type EvidenceType string
+
+const (
+	// EvidenceDoubleSign validador assinou dois blocos diferentes na mesma altura
+	EvidenceDoubleSign EvidenceType = "DOUBLE_SIGN"
+	// EvidenceInvalidBlock validador assinou um bloco estruturalmente inválido
+	EvidenceInvalidBlock EvidenceType = "INVALID_BLOCK"
+)
+
+// SignedHeader representa os dados de um cabeçalho de bloco cobertos pela assinatura do validador
+type SignedHeader struct {
+	payload   []byte
+	signature valueobjects.Signature
+}
+
+// NewSignedHeader cria um cabeçalho assinado a partir dos dados assinados e da assinatura
+func NewSignedHeader(payload []byte, signature valueobjects.Signature) *SignedHeader {
+	return &SignedHeader{
+		payload:   payload,
+		signature: signature,
+	}
+}
+
+// GetPayload retorna os dados assinados pelo validador
+func (h *SignedHeader) GetPayload() []byte {
+	return h.payload
+}
+
+// GetSignature retorna a assinatura do validador
+func (h *SignedHeader) GetSignature() valueobjects.Signature {
+	return h.signature
+}
+
+// DoubleSignEvidence comprova que um validador assinou dois cabeçalhos diferentes na mesma altura.
+// A evidência é autocontida: qualquer nó pode verificá-la com a chave pública do validador.
+type DoubleSignEvidence struct {
+	validator valueobjects.NodeID
+	height    uint64
+	first     *SignedHeader
+	second    *SignedHeader
+}
+
+// SignedHeaderData representa os dados serializáveis de um cabeçalho assinado
+type SignedHeaderData struct {
+	Payload   []byte `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// EvidenceData representa os dados serializáveis de uma evidência
+type EvidenceData struct {
+	Type      EvidenceType     `json:"type"`
+	Validator string           `json:"validator"`
+	Height    uint64           `json:"height"`
+	First     SignedHeaderData `json:"first"`
+	Second    SignedHeaderData `json:"second"`
+}
+
+// NewDoubleSignEvidence cria uma evidência de assinatura dupla.
+// Os cabeçalhos são ordenados para que nós diferentes produzam a mesma evidência.
+func NewDoubleSignEvidence(validator valueobjects.NodeID, height uint64, a, b *SignedHeader) *DoubleSignEvidence {
+	if a != nil && b != nil && bytes.Compare(a.payload, b.payload) > 0 {
+		a, b = b, a
+	}
+
+	return &DoubleSignEvidence{
+		validator: validator,
+		height:    height,
+		first:     a,
+		second:    b,
+	}
+}
+
+// GetValidator retorna o validador acusado
+func (e *DoubleSignEvidence) GetValidator() valueobjects.NodeID {
+	return e.validator
+}
+
+// GetHeight retorna a altura em que os cabeçalhos conflitam
+func (e *DoubleSignEvidence) GetHeight() uint64 {
+	return e.height
+}
+
+// GetFirst retorna o primeiro cabeçalho assinado
+func (e *DoubleSignEvidence) GetFirst() *SignedHeader {
+	return e.first
+}
+
+// GetSecond retorna o segundo cabeçalho assinado
+func (e *DoubleSignEvidence) GetSecond() *SignedHeader {
+	return e.second
+}
+
+// Key identifica a infração (uma única penalidade por validador e altura)
+func (e *DoubleSignEvidence) Key() string {
+	return fmt.Sprintf("%s|%s|%d", EvidenceDoubleSign, e.validator.String(), e.height)
+}
+
+// IsValid verifica se a evidência possui dois cabeçalhos assinados e diferentes
+func (e *DoubleSignEvidence) IsValid() bool {
+	if e.validator.IsEmpty() || e.first == nil || e.second == nil {
+		return false
+	}
+
+	if len(e.first.payload) == 0 || len(e.second.payload) == 0 {
+		return false
+	}
+
+	if e.first.signature.IsEmpty() || e.second.signature.IsEmpty() {
+		return false
+	}
+
+	return !bytes.Equal(e.first.payload, e.second.payload)
+}
+
+// ToBytes serializa a evidência para bytes
+func (e *DoubleSignEvidence) ToBytes() ([]byte, error) {
+	if e.first == nil || e.second == nil {
+		return nil, errors.New("evidence is incomplete")
+	}
+
+	return json.Marshal(EvidenceData{
+		Type:      EvidenceDoubleSign,
+		Validator: e.validator.String(),
+		Height:    e.height,
+		First: SignedHeaderData{
+			Payload:   e.first.payload,
+			Signature: e.first.signature.String(),
+		},
+		Second: SignedHeaderData{
+			Payload:   e.second.payload,
+			Signature: e.second.signature.String(),
+		},
+	})
+}
+
+// FromBytes deserializa uma evidência de bytes
+func (e *DoubleSignEvidence) FromBytes(data []byte) error {
+	var evidenceData EvidenceData
+	if err := json.Unmarshal(data, &evidenceData); err != nil {
+		return err
+	}
+
+	if evidenceData.Type != EvidenceDoubleSign {
+		return fmt.Errorf("unsupported evidence type: %s", evidenceData.Type)
+	}
+
+	first, err := evidenceData.First.toSignedHeader()
+	if err != nil {
+		return err
+	}
+
+	second, err := evidenceData.Second.toSignedHeader()
+	if err != nil {
+		return err
+	}
+
+	e.validator = valueobjects.NewNodeID(evidenceData.Validator)
+	e.height = evidenceData.Height
+	e.first = first
+	e.second = second
+
+	return nil
+}
+
+// toSignedHeader converte os dados serializados em um cabeçalho assinado
+func (d SignedHeaderData) toSignedHeader() (*SignedHeader, error) {
+	signature, err := valueobjects.NewSignatureFromString(d.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSignedHeader(d.Payload, signature), nil
+}
+
+// InvalidBlockEvidence comprova que um validador assinou uma proposta de bloco inválida.
+// Apenas falhas verificáveis a partir do cabeçalho assinado servem como prova (por exemplo,
+// Merkle root que não corresponde aos hashes das transações listadas).
+type InvalidBlockEvidence struct {
+	validator valueobjects.NodeID
+	height    uint64
+	header    *SignedHeader
+	reason    string
+}
+
+// InvalidBlockEvidenceData representa os dados serializáveis de uma evidência de bloco inválido
+type InvalidBlockEvidenceData struct {
+	Type      EvidenceType     `json:"type"`
+	Validator string           `json:"validator"`
+	Height    uint64           `json:"height"`
+	Header    SignedHeaderData `json:"header"`
+	Reason    string           `json:"reason"`
+}
+
+// NewInvalidBlockEvidence cria uma evidência de proposta inválida
+func NewInvalidBlockEvidence(validator valueobjects.NodeID, height uint64, header *SignedHeader, reason string) *InvalidBlockEvidence {
+	return &InvalidBlockEvidence{
+		validator: validator,
+		height:    height,
+		header:    header,
+		reason:    reason,
+	}
+}
+
+// GetValidator retorna o validador acusado
+func (e *InvalidBlockEvidence) GetValidator() valueobjects.NodeID {
+	return e.validator
+}
+
+// GetHeight retorna a altura da proposta inválida
+func (e *InvalidBlockEvidence) GetHeight() uint64 {
+	return e.height
+}
+
+// GetHeader retorna o cabeçalho assinado da proposta
+func (e *InvalidBlockEvidence) GetHeader() *SignedHeader {
+	return e.header
+}
+
+// GetReason retorna o motivo informado pelo nó que reportou a evidência
+func (e *InvalidBlockEvidence) GetReason() string {
+	return e.reason
+}
+
+// Key identifica a infração (uma única penalidade por validador e altura)
+func (e *InvalidBlockEvidence) Key() string {
+	return fmt.Sprintf("%s|%s|%d", EvidenceInvalidBlock, e.validator.String(), e.height)
+}
+
+// IsValid verifica se a evidência possui um cabeçalho assinado
+func (e *InvalidBlockEvidence) IsValid() bool {
+	if e.validator.IsEmpty() || e.header == nil {
+		return false
+	}
+
+	return len(e.header.payload) > 0 && !e.header.signature.IsEmpty()
+}
+
+// ToBytes serializa a evidência para bytes
+func (e *InvalidBlockEvidence) ToBytes() ([]byte, error) {
+	if e.header == nil {
+		return nil, errors.New("evidence is incomplete")
+	}
+
+	return json.Marshal(InvalidBlockEvidenceData{
+		Type:      EvidenceInvalidBlock,
+		Validator: e.validator.String(),
+		Height:    e.height,
+		Header: SignedHeaderData{
+			Payload:   e.header.payload,
+			Signature: e.header.signature.String(),
+		},
+		Reason: e.reason,
+	})
+}
+
+// FromBytes deserializa uma evidência de bytes
+func (e *InvalidBlockEvidence) FromBytes(data []byte) error {
+	var evidenceData InvalidBlockEvidenceData
+	if err := json.Unmarshal(data, &evidenceData); err != nil {
+		return err
+	}
+
+	if evidenceData.Type != EvidenceInvalidBlock {
+		return fmt.Errorf("unsupported evidence type: %s", evidenceData.Type)
+	}
+
+	header, err := evidenceData.Header.toSignedHeader()
+	if err != nil {
+		return err
+	}
+
+	e.validator = valueobjects.NewNodeID(evidenceData.Validator)
+	e.height = evidenceData.Height
+	e.header = header
+	e.reason = evidenceData.Reason
+
+	return nil
+}
+
+// ParseEvidenceType retorna o tipo de evidência contido nos dados de uma transação
+func ParseEvidenceType(data []byte) (EvidenceType, error) {
+	var header struct {
+		Type EvidenceType `json:"type"`
+	}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return "", err
+	}
+
+	return header.Type, nil
+}
@@ -0,0 +1,170 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// ResultDocumentFormat identifica o documento canônico de resultado de uma eleição
+	ResultDocumentFormat = "peer-vote-result-document"
+	// ResultDocumentVersion é a versão atual do documento de resultado
+	ResultDocumentVersion = 1
+)
+
+// CertificateTxKind identifica o conteúdo de uma transação de certificação
+type CertificateTxKind string
+
+const (
+	// CertificateTxProposal documento de resultado proposto, assinado pelo proponente
+	CertificateTxProposal CertificateTxKind = "PROPOSAL"
+	// CertificateTxEndorsement co-assinatura de um documento já proposto por outro validador
+	CertificateTxEndorsement CertificateTxKind = "ENDORSEMENT"
+)
+
+// ResultDocument é o documento canônico de resultado de uma eleição encerrada. Contém apenas
+// dados derivados da cadeia até HeadHeight, de forma que cada validador possa recalculá-lo
+// de forma independente e chegar aos mesmos bytes (e ao mesmo hash) antes de co-assinar.
+type ResultDocument struct {
+	Format     string           `json:"format"`
+	Version    int              `json:"version"`
+	ElectionID string           `json:"election_id"`
+	Election   ElectionData     `json:"election"`
+	Tally      []CandidateTally `json:"tally"`       // Votos válidos por candidato, na ordem da eleição
+	TotalVotes uint64           `json:"total_votes"` // Soma dos votos válidos apurados
+	Blocks     BlockRange       `json:"blocks"`
+	HeadHeight uint64           `json:"head_height"` // Topo da cadeia usado na apuração
+	HeadHash   string           `json:"head_hash"`
+	Audit      ResultAudit      `json:"audit"`
+}
+
+// CandidateTally representa a apuração final de um candidato
+type CandidateTally struct {
+	CandidateID string `json:"candidate_id"`
+	Name        string `json:"name"`
+	Votes       uint64 `json:"votes"`
+}
+
+// BlockRange representa os blocos que contêm a eleição: do registro ao último voto
+type BlockRange struct {
+	First uint64 `json:"first"`
+	Last  uint64 `json:"last"`
+}
+
+// ResultAudit resume a auditoria dos votos da eleição (AuditVotesUseCase)
+type ResultAudit struct {
+	TotalVotes     uint64  `json:"total_votes"`
+	ValidVotes     uint64  `json:"valid_votes"`
+	InvalidVotes   uint64  `json:"invalid_votes"`
+	AnonymousVotes uint64  `json:"anonymous_votes"`
+	SnapshotVotes  uint64  `json:"snapshot_votes,omitempty"`
+	IntegrityScore float64 `json:"integrity_score"`
+	Passed         bool    `json:"passed"`
+}
+
+// CertificateTxData representa os dados serializáveis de uma transação de certificação
+type CertificateTxData struct {
+	Kind         CertificateTxKind `json:"kind"`
+	ElectionID   string            `json:"election_id"`
+	DocumentHash string            `json:"document_hash"`
+	Document     *ResultDocument   `json:"document,omitempty"` // Apenas em propostas
+	Signer       string            `json:"signer"`
+	Timestamp    int64             `json:"timestamp"`
+	Signature    string            `json:"signature"`
+}
+
+// NewResultDocument cria o documento de resultado com a definição registrada da eleição
+func NewResultDocument(election *Election) (*ResultDocument, error) {
+	data, err := election.ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize election: %w", err)
+	}
+
+	document := &ResultDocument{
+		Format:     ResultDocumentFormat,
+		Version:    ResultDocumentVersion,
+		ElectionID: election.GetID().String(),
+		Tally:      make([]CandidateTally, 0, len(election.GetCandidates())),
+	}
+	if err := json.Unmarshal(data, &document.Election); err != nil {
+		return nil, fmt.Errorf("failed to decode election: %w", err)
+	}
+
+	return document, nil
+}
+
+// CanonicalBytes retorna a serialização canônica do documento, sobre a qual o hash é calculado
+func (d *ResultDocument) CanonicalBytes() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// Validate verifica a consistência interna do documento
+func (d *ResultDocument) Validate() error {
+	if d.Format != ResultDocumentFormat || d.Version != ResultDocumentVersion {
+		return fmt.Errorf("unsupported result document format %q version %d", d.Format, d.Version)
+	}
+	if d.ElectionID == "" || d.Election.ID != d.ElectionID {
+		return errors.New("election definition does not match election ID")
+	}
+	if d.HeadHash == "" {
+		return errors.New("head hash is required")
+	}
+	if d.Blocks.First > d.Blocks.Last || d.Blocks.Last > d.HeadHeight {
+		return fmt.Errorf("invalid block range %d-%d for head height %d", d.Blocks.First, d.Blocks.Last, d.HeadHeight)
+	}
+
+	candidates := make(map[string]bool, len(d.Election.Candidates))
+	for _, candidate := range d.Election.Candidates {
+		candidates[candidate.ID] = true
+	}
+
+	var total uint64
+	for _, entry := range d.Tally {
+		if !candidates[entry.CandidateID] {
+			return fmt.Errorf("tally references unknown candidate %s", entry.CandidateID)
+		}
+		total += entry.Votes
+	}
+	if total != d.TotalVotes {
+		return fmt.Errorf("tally sums to %d votes, declared %d", total, d.TotalVotes)
+	}
+	if d.Audit.ValidVotes+d.Audit.InvalidVotes != d.Audit.TotalVotes || d.Audit.ValidVotes != d.TotalVotes {
+		return errors.New("audit summary totals are inconsistent with the tally")
+	}
+
+	return nil
+}
+
+// CertificateSigningBytes retorna os dados cobertos pela assinatura de um validador:
+// a assinatura atesta o documento (pelo hash), independentemente de quem o propôs
+func CertificateSigningBytes(electionID, documentHash string) []byte {
+	return []byte(fmt.Sprintf("result-certificate|%s|%s", electionID, documentHash))
+}
+
+// ParseCertificateTransaction deserializa os dados de uma transação de certificação
+func ParseCertificateTransaction(data []byte) (*CertificateTxData, error) {
+	var txData CertificateTxData
+	if err := json.Unmarshal(data, &txData); err != nil {
+		return nil, err
+	}
+
+	switch txData.Kind {
+	case CertificateTxProposal:
+		if txData.Document == nil {
+			return nil, errors.New("certificate proposal has no document")
+		}
+		if txData.Document.ElectionID != txData.ElectionID {
+			return nil, errors.New("document election ID does not match transaction")
+		}
+	case CertificateTxEndorsement:
+	default:
+		return nil, fmt.Errorf("unknown certificate transaction kind: %s", txData.Kind)
+	}
+
+	if txData.ElectionID == "" || txData.DocumentHash == "" || txData.Signer == "" || txData.Signature == "" {
+		return nil, errors.New("certificate transaction is missing required fields")
+	}
+
+	return &txData, nil
+}
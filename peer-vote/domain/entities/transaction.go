@@ -16,6 +16,10 @@ const (
 	ElectionTransaction TransactionType = "ELECTION"
 	// ValidatorTransaction representa uma transação de validador
 	ValidatorTransaction TransactionType = "VALIDATOR"
+	// EvidenceTransaction representa uma transação com evidência de mau comportamento de validador
+	EvidenceTransaction TransactionType = "EVIDENCE"
+	// CertificateTransaction representa uma transação de certificação de resultado de eleição
+	CertificateTransaction TransactionType = "CERTIFICATE"
 )
 
 // Transaction representa uma transação na blockchain
@@ -96,6 +100,11 @@ func (t *Transaction) SetHash(hash valueobjects.Hash) {
 	t.hash = hash
 }
 
+// SetTimestamp define o timestamp da transação (usado ao reconstruir transações recebidas)
+func (t *Transaction) SetTimestamp(timestamp valueobjects.Timestamp) {
+	t.timestamp = timestamp
+}
+
 // IsValid verifica se a transação é válida
 func (t *Transaction) IsValid() bool {
 	// Validações básicas
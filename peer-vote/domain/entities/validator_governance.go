@@ -0,0 +1,271 @@
+package entities
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// ValidatorAction representa a alteração proposta no conjunto de validadores
+type ValidatorAction string
+
+const (
+	// ValidatorActionAdd adiciona um validador ao conjunto
+	ValidatorActionAdd ValidatorAction = "ADD"
+	// ValidatorActionRemove remove um validador do conjunto
+	ValidatorActionRemove ValidatorAction = "REMOVE"
+)
+
+// ValidatorTxKind identifica o conteúdo de uma transação de validador
+type ValidatorTxKind string
+
+const (
+	// ValidatorTxProposal proposta de alteração do conjunto de validadores
+	ValidatorTxProposal ValidatorTxKind = "PROPOSAL"
+	// ValidatorTxApproval aprovação de uma proposta por um validador atual
+	ValidatorTxApproval ValidatorTxKind = "APPROVAL"
+)
+
+// ValidatorProposal representa uma proposta de adição ou remoção de validador.
+// A alteração só vale a partir de effectiveHeight e depois de aprovada pela maioria
+// dos validadores do conjunto vigente na altura em que a proposta entrou na cadeia.
+type ValidatorProposal struct {
+	action          ValidatorAction
+	nodeID          valueobjects.NodeID
+	publicKey       []byte // Chave pública do validador (apenas para adição)
+	curve           string
+	effectiveHeight uint64
+	proposer        valueobjects.NodeID
+	timestamp       valueobjects.Timestamp
+	signature       valueobjects.Signature
+}
+
+// ValidatorApproval representa a assinatura de um validador aprovando uma proposta
+type ValidatorApproval struct {
+	proposalID valueobjects.Hash // Hash da transação da proposta
+	validator  valueobjects.NodeID
+	timestamp  valueobjects.Timestamp
+	signature  valueobjects.Signature
+}
+
+// ValidatorTxData representa os dados serializáveis de uma transação de validador
+type ValidatorTxData struct {
+	Kind            ValidatorTxKind `json:"kind"`
+	Action          ValidatorAction `json:"action,omitempty"`
+	NodeID          string          `json:"node_id,omitempty"`
+	PublicKey       string          `json:"public_key,omitempty"`
+	Curve           string          `json:"curve,omitempty"`
+	EffectiveHeight uint64          `json:"effective_height,omitempty"`
+	ProposalID      string          `json:"proposal_id,omitempty"`
+	Signer          string          `json:"signer"`
+	Timestamp       int64           `json:"timestamp"`
+	Signature       string          `json:"signature,omitempty"`
+}
+
+// NewValidatorProposal cria uma nova proposta (ainda não assinada)
+func NewValidatorProposal(action ValidatorAction, nodeID valueobjects.NodeID, publicKey []byte, curve string, effectiveHeight uint64, proposer valueobjects.NodeID) *ValidatorProposal {
+	return &ValidatorProposal{
+		action:          action,
+		nodeID:          nodeID,
+		publicKey:       publicKey,
+		curve:           curve,
+		effectiveHeight: effectiveHeight,
+		proposer:        proposer,
+		timestamp:       valueobjects.NewTimestamp(time.Now()),
+	}
+}
+
+// GetAction retorna a alteração proposta
+func (p *ValidatorProposal) GetAction() ValidatorAction {
+	return p.action
+}
+
+// GetNodeID retorna o validador afetado pela proposta
+func (p *ValidatorProposal) GetNodeID() valueobjects.NodeID {
+	return p.nodeID
+}
+
+// GetPublicKey retorna a chave pública do validador proposto
+func (p *ValidatorProposal) GetPublicKey() []byte {
+	return p.publicKey
+}
+
+// GetCurve retorna a curva da chave pública do validador proposto
+func (p *ValidatorProposal) GetCurve() string {
+	return p.curve
+}
+
+// GetEffectiveHeight retorna a altura a partir da qual a alteração vale
+func (p *ValidatorProposal) GetEffectiveHeight() uint64 {
+	return p.effectiveHeight
+}
+
+// GetProposer retorna o validador que fez a proposta
+func (p *ValidatorProposal) GetProposer() valueobjects.NodeID {
+	return p.proposer
+}
+
+// GetTimestamp retorna o timestamp da proposta
+func (p *ValidatorProposal) GetTimestamp() valueobjects.Timestamp {
+	return p.timestamp
+}
+
+// GetSignature retorna a assinatura do proposer
+func (p *ValidatorProposal) GetSignature() valueobjects.Signature {
+	return p.signature
+}
+
+// SetSignature define a assinatura do proposer
+func (p *ValidatorProposal) SetSignature(signature valueobjects.Signature) {
+	p.signature = signature
+}
+
+// IsValid verifica se a proposta possui os campos obrigatórios
+func (p *ValidatorProposal) IsValid() bool {
+	if p.action != ValidatorActionAdd && p.action != ValidatorActionRemove {
+		return false
+	}
+
+	if p.action == ValidatorActionAdd && (len(p.publicKey) == 0 || p.curve == "") {
+		return false
+	}
+
+	return !p.nodeID.IsEmpty() && !p.proposer.IsEmpty() && !p.signature.IsEmpty()
+}
+
+// SigningBytes retorna os dados determinísticos cobertos pela assinatura
+func (p *ValidatorProposal) SigningBytes() []byte {
+	return []byte(fmt.Sprintf("validator-proposal|%s|%s|%s|%s|%d|%s|%d",
+		p.action,
+		p.nodeID.String(),
+		hex.EncodeToString(p.publicKey),
+		p.curve,
+		p.effectiveHeight,
+		p.proposer.String(),
+		p.timestamp.Unix(),
+	))
+}
+
+// ToBytes serializa a proposta para os dados da transação
+func (p *ValidatorProposal) ToBytes() ([]byte, error) {
+	return json.Marshal(ValidatorTxData{
+		Kind:            ValidatorTxProposal,
+		Action:          p.action,
+		NodeID:          p.nodeID.String(),
+		PublicKey:       hex.EncodeToString(p.publicKey),
+		Curve:           p.curve,
+		EffectiveHeight: p.effectiveHeight,
+		Signer:          p.proposer.String(),
+		Timestamp:       p.timestamp.Unix(),
+		Signature:       p.signature.String(),
+	})
+}
+
+// NewValidatorApproval cria uma nova aprovação (ainda não assinada)
+func NewValidatorApproval(proposalID valueobjects.Hash, validator valueobjects.NodeID) *ValidatorApproval {
+	return &ValidatorApproval{
+		proposalID: proposalID,
+		validator:  validator,
+		timestamp:  valueobjects.NewTimestamp(time.Now()),
+	}
+}
+
+// GetProposalID retorna o hash da transação da proposta aprovada
+func (a *ValidatorApproval) GetProposalID() valueobjects.Hash {
+	return a.proposalID
+}
+
+// GetValidator retorna o validador que aprovou
+func (a *ValidatorApproval) GetValidator() valueobjects.NodeID {
+	return a.validator
+}
+
+// GetTimestamp retorna o timestamp da aprovação
+func (a *ValidatorApproval) GetTimestamp() valueobjects.Timestamp {
+	return a.timestamp
+}
+
+// GetSignature retorna a assinatura do validador
+func (a *ValidatorApproval) GetSignature() valueobjects.Signature {
+	return a.signature
+}
+
+// SetSignature define a assinatura do validador
+func (a *ValidatorApproval) SetSignature(signature valueobjects.Signature) {
+	a.signature = signature
+}
+
+// IsValid verifica se a aprovação possui os campos obrigatórios
+func (a *ValidatorApproval) IsValid() bool {
+	return !a.proposalID.IsEmpty() && !a.validator.IsEmpty() && !a.signature.IsEmpty()
+}
+
+// SigningBytes retorna os dados determinísticos cobertos pela assinatura
+func (a *ValidatorApproval) SigningBytes() []byte {
+	return []byte(fmt.Sprintf("validator-approval|%s|%s|%d",
+		a.proposalID.String(),
+		a.validator.String(),
+		a.timestamp.Unix(),
+	))
+}
+
+// ToBytes serializa a aprovação para os dados da transação
+func (a *ValidatorApproval) ToBytes() ([]byte, error) {
+	return json.Marshal(ValidatorTxData{
+		Kind:       ValidatorTxApproval,
+		ProposalID: a.proposalID.String(),
+		Signer:     a.validator.String(),
+		Timestamp:  a.timestamp.Unix(),
+		Signature:  a.signature.String(),
+	})
+}
+
+// ParseValidatorTransaction deserializa os dados de uma transação de validador,
+// retornando a proposta ou a aprovação conforme o tipo
+func ParseValidatorTransaction(data []byte) (*ValidatorProposal, *ValidatorApproval, error) {
+	var txData ValidatorTxData
+	if err := json.Unmarshal(data, &txData); err != nil {
+		return nil, nil, err
+	}
+
+	signature, err := valueobjects.NewSignatureFromString(txData.Signature)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch txData.Kind {
+	case ValidatorTxProposal:
+		publicKey, err := hex.DecodeString(txData.PublicKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid public key: %w", err)
+		}
+
+		return &ValidatorProposal{
+			action:          txData.Action,
+			nodeID:          valueobjects.NewNodeID(txData.NodeID),
+			publicKey:       publicKey,
+			curve:           txData.Curve,
+			effectiveHeight: txData.EffectiveHeight,
+			proposer:        valueobjects.NewNodeID(txData.Signer),
+			timestamp:       valueobjects.Unix(txData.Timestamp, 0),
+			signature:       signature,
+		}, nil, nil
+	case ValidatorTxApproval:
+		proposalID, err := valueobjects.NewHashFromString(txData.ProposalID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid proposal ID: %w", err)
+		}
+
+		return nil, &ValidatorApproval{
+			proposalID: proposalID,
+			validator:  valueobjects.NewNodeID(txData.Signer),
+			timestamp:  valueobjects.Unix(txData.Timestamp, 0),
+			signature:  signature,
+		}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown validator transaction kind: %s", txData.Kind)
+	}
+}
@@ -208,6 +208,16 @@ func (v *Vote) FromBytes(data []byte) error {
 	return nil
 }
 
+// MarshalJSON serializa o voto no formato de VoteData, incluindo o ID (respostas da API)
+func (v *Vote) MarshalJSON() ([]byte, error) {
+	return v.ToBytesWithID()
+}
+
+// UnmarshalJSON restaura o voto a partir do formato de VoteData
+func (v *Vote) UnmarshalJSON(data []byte) error {
+	return v.FromBytes(data)
+}
+
 // Copy retorna uma cópia do voto
 func (v *Vote) Copy() *Vote {
 	return &Vote{
@@ -218,5 +228,6 @@ func (v *Vote) Copy() *Vote {
 		timestamp:   v.timestamp,
 		signature:   v.signature.Copy(),
 		isAnonymous: v.isAnonymous,
+		nonce:       v.nonce,
 	}
 }
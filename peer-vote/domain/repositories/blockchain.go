@@ -50,4 +50,8 @@ type BlockchainRepository interface {
 	
 	// GetBlocksBefore retorna todos os blocos antes de um determinado índice
 	GetBlocksBefore(ctx context.Context, index uint64) ([]*entities.Block, error)
+	
+	// QueryBlocks consulta blocos por faixa de altura, validador e tipo de transação,
+	// na ordem pedida e paginados por cursor
+	QueryBlocks(ctx context.Context, query BlockQuery) (*BlockPage, error)
 }
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+)
+
+var (
+	// ErrTemplateNotFound indica que não há modelo de eleição com o nome informado
+	ErrTemplateNotFound = errors.New("election template not found")
+	// ErrTemplateExists indica que já há um modelo de eleição com o nome informado
+	ErrTemplateExists = errors.New("election template already exists")
+)
+
+// ElectionTemplateRepository define a persistência local dos modelos de eleição do nó
+type ElectionTemplateRepository interface {
+	// CreateTemplate salva um modelo novo (ErrTemplateExists se o nome já existe)
+	CreateTemplate(ctx context.Context, template *entities.ElectionTemplate) error
+
+	// UpdateTemplate substitui um modelo existente (ErrTemplateNotFound se não existe)
+	UpdateTemplate(ctx context.Context, template *entities.ElectionTemplate) error
+
+	// GetTemplate recupera um modelo pelo nome
+	GetTemplate(ctx context.Context, name string) (*entities.ElectionTemplate, error)
+
+	// ListTemplates lista os modelos em ordem de nome
+	ListTemplates(ctx context.Context) ([]*entities.ElectionTemplate, error)
+
+	// DeleteTemplate remove um modelo pelo nome
+	DeleteTemplate(ctx context.Context, name string) error
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// ErrInvalidCursor indica um cursor de paginação malformado
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// SortOrder define a ordem de uma consulta pela posição na cadeia
+type SortOrder string
+
+const (
+	// SortAscending ordena da altura mais baixa para a mais alta
+	SortAscending SortOrder = "asc"
+	// SortDescending ordena da altura mais alta para a mais baixa
+	SortDescending SortOrder = "desc"
+)
+
+// ParseSortOrder converte "asc"/"desc" em SortOrder (vazio assume a ordem padrão)
+func ParseSortOrder(value string, defaultOrder SortOrder) (SortOrder, error) {
+	switch SortOrder(strings.ToLower(value)) {
+	case "":
+		return defaultOrder, nil
+	case SortAscending:
+		return SortAscending, nil
+	case SortDescending:
+		return SortDescending, nil
+	default:
+		return "", fmt.Errorf("invalid sort order: %s", value)
+	}
+}
+
+// ChainPosition identifica uma transação na cadeia: altura do bloco e posição no bloco
+type ChainPosition struct {
+	Height uint64
+	Index  int
+}
+
+// Follows verifica se a posição vem depois de other na ordem informada
+func (p ChainPosition) Follows(other ChainPosition, order SortOrder) bool {
+	if order == SortDescending {
+		return p.Height < other.Height || (p.Height == other.Height && p.Index < other.Index)
+	}
+	return p.Height > other.Height || (p.Height == other.Height && p.Index > other.Index)
+}
+
+// Cursor codifica a posição como cursor opaco de paginação
+func (p ChainPosition) Cursor() string {
+	raw := strconv.FormatUint(p.Height, 10) + ":" + strconv.Itoa(p.Index)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor decodifica um cursor gerado por ChainPosition.Cursor
+func ParseCursor(cursor string) (ChainPosition, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return ChainPosition{}, ErrInvalidCursor
+	}
+
+	heightStr, indexStr, found := strings.Cut(string(raw), ":")
+	if !found {
+		return ChainPosition{}, ErrInvalidCursor
+	}
+	height, err := strconv.ParseUint(heightStr, 10, 64)
+	if err != nil {
+		return ChainPosition{}, ErrInvalidCursor
+	}
+	index, err := strconv.Atoi(indexStr)
+	if err != nil || index < 0 {
+		return ChainPosition{}, ErrInvalidCursor
+	}
+
+	return ChainPosition{Height: height, Index: index}, nil
+}
+
+// BlockQuery filtra e pagina blocos da cadeia canônica
+type BlockQuery struct {
+	FromHeight *uint64                  // Altura mínima (inclusive)
+	ToHeight   *uint64                  // Altura máxima (inclusive)
+	After      *uint64                  // Cursor: altura do último bloco da página anterior (exclusive)
+	Validator  valueobjects.NodeID      // Apenas blocos propostos por este validador
+	TxType     entities.TransactionType // Apenas blocos com transações deste tipo
+	Order      SortOrder                // Padrão: SortAscending
+	Limit      int                      // 0 = sem limite
+}
+
+// Bounds resolve a faixa de alturas [low, high] da consulta em uma cadeia de altura chainHeight.
+// ok é falso quando a faixa é vazia.
+func (q BlockQuery) Bounds(chainHeight uint64) (low, high uint64, ok bool) {
+	low, high = 0, chainHeight
+	if q.FromHeight != nil {
+		low = *q.FromHeight
+	}
+	if q.ToHeight != nil && *q.ToHeight < high {
+		high = *q.ToHeight
+	}
+
+	if q.After != nil {
+		if q.Order == SortDescending {
+			if *q.After == 0 {
+				return 0, 0, false
+			}
+			if *q.After-1 < high {
+				high = *q.After - 1
+			}
+		} else if *q.After >= low {
+			if *q.After == ^uint64(0) {
+				return 0, 0, false
+			}
+			low = *q.After + 1
+		}
+	}
+
+	return low, high, low <= high
+}
+
+// Matches verifica se o bloco atende aos filtros de validador e tipo de transação
+func (q BlockQuery) Matches(block *entities.Block) bool {
+	if !q.Validator.IsEmpty() && !block.GetValidator().Equals(q.Validator) {
+		return false
+	}
+	if q.TxType == "" {
+		return true
+	}
+	for _, tx := range block.GetTransactions() {
+		if tx.GetType() == q.TxType {
+			return true
+		}
+	}
+	return false
+}
+
+// BlockPage é uma página do resultado de QueryBlocks
+type BlockPage struct {
+	Blocks  []*entities.Block
+	HasMore bool // Há blocos que atendem à consulta após o último da página
+}
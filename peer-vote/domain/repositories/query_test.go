@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"testing"
+)
+
+func height(h uint64) *uint64 {
+	return &h
+}
+
+func TestBlockQueryBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     BlockQuery
+		low, high uint64
+		ok        bool
+	}{
+		{"whole chain", BlockQuery{}, 0, 10, true},
+		{"range", BlockQuery{FromHeight: height(3), ToHeight: height(7)}, 3, 7, true},
+		{"to above chain height", BlockQuery{ToHeight: height(50)}, 0, 10, true},
+		{"from above chain height", BlockQuery{FromHeight: height(11)}, 11, 10, false},
+		{"from after to", BlockQuery{FromHeight: height(7), ToHeight: height(3)}, 7, 3, false},
+
+		{"asc after", BlockQuery{After: height(4)}, 5, 10, true},
+		{"asc after below from", BlockQuery{FromHeight: height(6), After: height(2)}, 6, 10, true},
+		{"asc after tip", BlockQuery{After: height(10)}, 11, 10, false},
+		{"asc after max height", BlockQuery{After: height(math.MaxUint64)}, 0, 0, false},
+
+		{"desc after", BlockQuery{Order: SortDescending, After: height(4)}, 0, 3, true},
+		{"desc after above to", BlockQuery{Order: SortDescending, ToHeight: height(6), After: height(9)}, 0, 6, true},
+		{"desc after genesis", BlockQuery{Order: SortDescending, After: height(0)}, 0, 0, false},
+		{"desc after from", BlockQuery{Order: SortDescending, FromHeight: height(4), After: height(4)}, 4, 3, false},
+		{"desc after first in range", BlockQuery{Order: SortDescending, FromHeight: height(4), After: height(5)}, 4, 4, true},
+	}
+
+	for _, tt := range tests {
+		low, high, ok := tt.query.Bounds(10)
+		if ok != tt.ok || (ok && (low != tt.low || high != tt.high)) {
+			t.Errorf("%s: Bounds = [%d, %d] %v, want [%d, %d] %v", tt.name, low, high, ok, tt.low, tt.high, tt.ok)
+		}
+	}
+}
+
+func TestChainPositionFollows(t *testing.T) {
+	tests := []struct {
+		p, other  ChainPosition
+		asc, desc bool
+	}{
+		{ChainPosition{5, 0}, ChainPosition{4, 9}, true, false},
+		{ChainPosition{4, 9}, ChainPosition{5, 0}, false, true},
+		{ChainPosition{5, 2}, ChainPosition{5, 1}, true, false},
+		{ChainPosition{5, 1}, ChainPosition{5, 2}, false, true},
+		{ChainPosition{5, 1}, ChainPosition{5, 1}, false, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.p.Follows(tt.other, SortAscending); got != tt.asc {
+			t.Errorf("%+v.Follows(%+v, asc) = %v, want %v", tt.p, tt.other, got, tt.asc)
+		}
+		if got := tt.p.Follows(tt.other, SortDescending); got != tt.desc {
+			t.Errorf("%+v.Follows(%+v, desc) = %v, want %v", tt.p, tt.other, got, tt.desc)
+		}
+	}
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	position := ChainPosition{Height: math.MaxUint64, Index: 42}
+	parsed, err := ParseCursor(position.Cursor())
+	if err != nil || parsed != position {
+		t.Fatalf("ParseCursor(Cursor()) = %+v, %v; want %+v", parsed, err, position)
+	}
+
+	encode := func(raw string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	}
+	for _, cursor := range []string{"not base64!", encode("12"), encode("x:1"), encode("12:-1"), encode("12:y"), encode("-1:0")} {
+		if _, err := ParseCursor(cursor); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("ParseCursor(%q) error = %v, want ErrInvalidCursor", cursor, err)
+		}
+	}
+}
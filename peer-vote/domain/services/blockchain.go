@@ -32,4 +32,10 @@ type BlockchainService interface {
 	
 	// GetLatestBlock retorna o último bloco da cadeia
 	GetLatestBlock(ctx context.Context) (*entities.Block, error)
+	
+	// GetFinalizedHeight retorna a altura do último bloco final (false se nenhum bloco é final)
+	GetFinalizedHeight(ctx context.Context) (uint64, bool)
+	
+	// WaitForTransaction aguarda a inclusão da transação na cadeia, retornando hash e altura do bloco
+	WaitForTransaction(ctx context.Context, txHash valueobjects.Hash) (valueobjects.Hash, uint64, error)
 }
@@ -27,6 +27,15 @@ type NetworkService interface {
 	// BroadcastTransaction transmite uma transação para todos os peers
 	BroadcastTransaction(ctx context.Context, tx *entities.Transaction) error
 	
+	// BroadcastAttestation transmite a atestação de um bloco por um validador
+	BroadcastAttestation(ctx context.Context, attestation *entities.Attestation) error
+	
+	// BroadcastProposal transmite a proposta de bloco de um round de consenso
+	BroadcastProposal(ctx context.Context, proposal *entities.ConsensusProposal) error
+	
+	// BroadcastConsensusVote transmite um prevote ou precommit
+	BroadcastConsensusVote(ctx context.Context, vote *entities.ConsensusVote) error
+	
 	// SendBlockToPeer envia um bloco para um peer específico
 	SendBlockToPeer(ctx context.Context, peerID valueobjects.NodeID, block *entities.Block) error
 	
@@ -69,6 +78,12 @@ type NetworkService interface {
 	// RegisterTransactionHandler registra um handler para transações recebidas
 	RegisterTransactionHandler(handler TransactionHandler)
 	
+	// RegisterProposalHandler registra um handler para propostas de consenso recebidas
+	RegisterProposalHandler(handler ProposalHandler)
+	
+	// RegisterConsensusVoteHandler registra um handler para votos de consenso recebidos
+	RegisterConsensusVoteHandler(handler ConsensusVoteHandler)
+	
 	// GetNetworkStatus retorna o status da rede
 	GetNetworkStatus(ctx context.Context) (NetworkStatus, error)
 }
@@ -96,3 +111,9 @@ type BlockHandler func(ctx context.Context, block *entities.Block, fromPeer valu
 
 // TransactionHandler é chamado quando uma transação é recebida
 type TransactionHandler func(ctx context.Context, tx *entities.Transaction, fromPeer valueobjects.NodeID) error
+
+// ProposalHandler é chamado quando uma proposta de consenso é recebida
+type ProposalHandler func(ctx context.Context, proposal *entities.ConsensusProposal, fromPeer valueobjects.NodeID) error
+
+// ConsensusVoteHandler é chamado quando um voto de consenso é recebido
+type ConsensusVoteHandler func(ctx context.Context, vote *entities.ConsensusVote, fromPeer valueobjects.NodeID) error
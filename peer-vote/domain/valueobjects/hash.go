@@ -82,3 +82,23 @@ func (h Hash) IsValid() bool {
 func (h Hash) Copy() Hash {
 	return NewHash(h.value)
 }
+
+// MarshalText serializa o hash como hexadecimal (usado em respostas JSON)
+func (h Hash) MarshalText() ([]byte, error) {
+	return []byte(h.String()), nil
+}
+
+// UnmarshalText restaura o hash a partir de hexadecimal
+func (h *Hash) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*h = Hash{}
+		return nil
+	}
+
+	hash, err := NewHashFromString(string(text))
+	if err != nil {
+		return err
+	}
+	*h = hash
+	return nil
+}
@@ -84,3 +84,14 @@ func (n NodeID) ShortString() string {
 func (n NodeID) Copy() NodeID {
 	return NodeID{value: n.value}
 }
+
+// MarshalText serializa o NodeID como string (usado em respostas JSON)
+func (n NodeID) MarshalText() ([]byte, error) {
+	return []byte(n.value), nil
+}
+
+// UnmarshalText restaura o NodeID a partir da string
+func (n *NodeID) UnmarshalText(text []byte) error {
+	n.value = string(text)
+	return nil
+}
@@ -0,0 +1,304 @@
+package archive
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+)
+
+// Identificação do formato do arquivo de cadeia
+const (
+	Format  = "peer-vote-chain-archive"
+	Version = 1
+)
+
+// Algoritmos usados pela cadeia, registrados no arquivo para que ele seja verificável
+// sem consultar o código do nó que o gerou
+const (
+	HashAlgorithm      = "SHA-256"
+	SignatureAlgorithm = "ECDSA"
+	MerkleAlgorithm    = "SHA-256 binary Merkle tree over transaction data"
+)
+
+// Archive é uma cópia autodescritiva da cadeia: gênese, todos os blocos e o histórico
+// do conjunto de validadores. O conjunto gênese é a âncora de confiança da verificação;
+// as alterações posteriores são derivadas das transações VALIDATOR dos próprios blocos.
+type Archive struct {
+	Format             string           `json:"format"`
+	Version            int              `json:"version"`
+	CreatedAt          int64            `json:"created_at"`
+	ExportedBy         string           `json:"exported_by"`
+	HashAlgorithm      string           `json:"hash_algorithm"`
+	SignatureAlgorithm string           `json:"signature_algorithm"`
+	MerkleAlgorithm    string           `json:"merkle_algorithm"`
+	Chain              ChainSummary     `json:"chain"`
+	GenesisValidators  []ValidatorKey   `json:"genesis_validators"`
+	ValidatorHistory   []ValidatorEpoch `json:"validator_history"`
+	Blocks             []*ArchivedBlock `json:"blocks"`
+}
+
+// ChainSummary identifica a cadeia arquivada
+type ChainSummary struct {
+	Height          uint64 `json:"height"`
+	GenesisHash     string `json:"genesis_hash"`
+	HeadHash        string `json:"head_hash"`
+	FinalizedHeight uint64 `json:"finalized_height"`
+	Transactions    int    `json:"transactions"`
+}
+
+// ValidatorKey é a chave pública de um validador (hex de PublicKey.ToBytes)
+type ValidatorKey struct {
+	NodeID    string `json:"node_id"`
+	PublicKey string `json:"public_key"`
+	Curve     string `json:"curve"`
+}
+
+// ValidatorEpoch é o conjunto de validadores vigente a partir de uma altura
+type ValidatorEpoch struct {
+	FromHeight uint64         `json:"from_height"`
+	Validators []ValidatorKey `json:"validators"`
+}
+
+// ArchivedBlock é um bloco com o hash calculado pelo nó que o exportou
+type ArchivedBlock struct {
+	Index        uint64                 `json:"index"`
+	Hash         string                 `json:"hash"`
+	PreviousHash string                 `json:"previous_hash"`
+	Timestamp    int64                  `json:"timestamp"`
+	MerkleRoot   string                 `json:"merkle_root"`
+	Validator    string                 `json:"validator"`
+	Nonce        uint64                 `json:"nonce"`
+	Signature    string                 `json:"signature"`
+	Transactions []*ArchivedTransaction `json:"transactions"`
+}
+
+// ArchivedTransaction é uma transação com os dados completos (base64 no JSON)
+type ArchivedTransaction struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Data      []byte `json:"data"`
+	Timestamp int64  `json:"timestamp"`
+	Signature string `json:"signature"`
+	Hash      string `json:"hash"`
+}
+
+// Read lê um arquivo de cadeia e confere o formato e a versão
+func Read(r io.Reader) (*Archive, error) {
+	var archive Archive
+	if err := json.NewDecoder(r).Decode(&archive); err != nil {
+		return nil, fmt.Errorf("invalid chain archive: %w", err)
+	}
+
+	if archive.Format != Format {
+		return nil, fmt.Errorf("unknown archive format %q (expected %q)", archive.Format, Format)
+	}
+	if archive.Version != Version {
+		return nil, fmt.Errorf("unsupported archive version %d (expected %d)", archive.Version, Version)
+	}
+	if len(archive.Blocks) == 0 {
+		return nil, errors.New("chain archive has no blocks")
+	}
+
+	return &archive, nil
+}
+
+// Write grava o arquivo de cadeia como JSON indentado
+func Write(w io.Writer, archive *Archive) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+// NewArchivedBlock converte um bloco preservando timestamp, nonce e assinatura originais
+func NewArchivedBlock(block *entities.Block, hash valueobjects.Hash) *ArchivedBlock {
+	transactions := make([]*ArchivedTransaction, len(block.GetTransactions()))
+	for i, tx := range block.GetTransactions() {
+		transactions[i] = &ArchivedTransaction{
+			ID:        tx.GetID().String(),
+			Type:      string(tx.GetType()),
+			From:      tx.GetFrom().String(),
+			To:        tx.GetTo().String(),
+			Data:      tx.GetData(),
+			Timestamp: tx.GetTimestamp().Unix(),
+			Signature: tx.GetSignature().String(),
+			Hash:      tx.GetHash().String(),
+		}
+	}
+
+	return &ArchivedBlock{
+		Index:        block.GetIndex(),
+		Hash:         hash.String(),
+		PreviousHash: block.GetPreviousHash().String(),
+		Timestamp:    block.GetTimestamp().Unix(),
+		MerkleRoot:   block.GetMerkleRoot().String(),
+		Validator:    block.GetValidator().String(),
+		Nonce:        block.GetNonce(),
+		Signature:    block.GetSignature().String(),
+		Transactions: transactions,
+	}
+}
+
+// Block reconstrói o bloco arquivado. O hash do bloco não é copiado: quem lê o
+// arquivo deve recalculá-lo e compará-lo com Hash.
+func (b *ArchivedBlock) Block() (*entities.Block, error) {
+	transactions := make([]*entities.Transaction, len(b.Transactions))
+	for i, archived := range b.Transactions {
+		tx, err := archived.transaction()
+		if err != nil {
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		transactions[i] = tx
+	}
+
+	previousHash, err := parseOptionalHash(b.PreviousHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid previous hash: %w", err)
+	}
+
+	merkleRoot, err := valueobjects.NewHashFromString(b.MerkleRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merkle root: %w", err)
+	}
+
+	block := entities.NewBlock(b.Index, previousHash, transactions, valueobjects.NewNodeID(b.Validator))
+	block.SetTimestamp(valueobjects.Unix(b.Timestamp, 0))
+	block.SetNonce(b.Nonce)
+	block.SetMerkleRoot(merkleRoot)
+
+	if b.Signature != "" {
+		signature, err := valueobjects.NewSignatureFromString(b.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+		block.SetSignature(signature)
+	}
+
+	return block, nil
+}
+
+// transaction reconstrói a transação arquivada
+func (t *ArchivedTransaction) transaction() (*entities.Transaction, error) {
+	tx := entities.NewTransaction(
+		entities.TransactionType(t.Type),
+		valueobjects.NewNodeID(t.From),
+		valueobjects.NewNodeID(t.To),
+		t.Data,
+	)
+	tx.SetTimestamp(valueobjects.Unix(t.Timestamp, 0))
+
+	if t.ID != "" {
+		id, err := valueobjects.NewHashFromString(t.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction id: %w", err)
+		}
+		tx.SetID(id)
+	}
+
+	if t.Hash != "" {
+		hash, err := valueobjects.NewHashFromString(t.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction hash: %w", err)
+		}
+		tx.SetHash(hash)
+	}
+
+	if t.Signature != "" {
+		signature, err := valueobjects.NewSignatureFromString(t.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction signature: %w", err)
+		}
+		tx.SetSignature(signature)
+	}
+
+	return tx, nil
+}
+
+// parseOptionalHash converte um hash em hexadecimal; vazio é o hash anterior do gênese
+func parseOptionalHash(value string) (valueobjects.Hash, error) {
+	if value == "" {
+		return valueobjects.EmptyHash(), nil
+	}
+	return valueobjects.NewHashFromString(value)
+}
+
+// NewValidatorKeys converte um conjunto de validadores ordenando por NodeID
+func NewValidatorKeys(set map[string]*services.PublicKey) []ValidatorKey {
+	keys := make([]ValidatorKey, 0, len(set))
+	for _, nodeID := range sortedNodeIDs(set) {
+		key := ValidatorKey{NodeID: nodeID}
+		if publicKey := set[nodeID]; publicKey != nil {
+			key.PublicKey = hex.EncodeToString(publicKey.ToBytes())
+			key.Curve = publicKey.Curve
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// sortedNodeIDs retorna os NodeIDs de um conjunto de validadores em ordem alfabética
+func sortedNodeIDs(set map[string]*services.PublicKey) []string {
+	nodeIDs := make([]string, 0, len(set))
+	for nodeID := range set {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+	return nodeIDs
+}
+
+// NewValidatorHistory converte as épocas do conjunto de validadores
+func NewValidatorHistory(epochs []consensus.ValidatorSetEpoch) []ValidatorEpoch {
+	history := make([]ValidatorEpoch, len(epochs))
+	for i, epoch := range epochs {
+		history[i] = ValidatorEpoch{FromHeight: epoch.FromHeight, Validators: NewValidatorKeys(epoch.Validators)}
+	}
+	return history
+}
+
+// ValidatorSetAt retorna o conjunto de validadores vigente na altura segundo o histórico do arquivo
+func (a *Archive) ValidatorSetAt(height uint64) []ValidatorKey {
+	current := a.GenesisValidators
+	for _, epoch := range a.ValidatorHistory {
+		if epoch.FromHeight > height {
+			break
+		}
+		current = epoch.Validators
+	}
+	return current
+}
+
+// Key reconstrói a chave pública do validador
+func (k ValidatorKey) Key() (*services.PublicKey, error) {
+	data, err := hex.DecodeString(k.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key of validator %s: %w", k.NodeID, err)
+	}
+
+	publicKey := &services.PublicKey{}
+	if err := publicKey.FromBytes(data, k.Curve); err != nil || !publicKey.IsValid() {
+		return nil, fmt.Errorf("invalid public key of validator %s", k.NodeID)
+	}
+	return publicKey, nil
+}
+
+// DecodeValidatorKeys reconstrói um conjunto de validadores a partir das chaves arquivadas
+func DecodeValidatorKeys(keys []ValidatorKey) (map[string]*services.PublicKey, error) {
+	set := make(map[string]*services.PublicKey, len(keys))
+	for _, key := range keys {
+		publicKey, err := key.Key()
+		if err != nil {
+			return nil, err
+		}
+		set[key.NodeID] = publicKey
+	}
+	return set, nil
+}
@@ -0,0 +1,74 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+)
+
+// ChainSource fornece os blocos canônicos (ex: blockchain.ChainManager)
+type ChainSource interface {
+	GetChainHeight(ctx context.Context) (uint64, error)
+	GetBlockByIndex(ctx context.Context, index uint64) (*entities.Block, error)
+	CalculateBlockHash(ctx context.Context, block *entities.Block) valueobjects.Hash
+	GetFinalizedHeight(ctx context.Context) (uint64, bool)
+}
+
+// ValidatorSource fornece o conjunto gênese e as alterações aprovadas na cadeia (ex: consensus.ValidatorManager)
+type ValidatorSource interface {
+	GetGenesisValidatorSet(ctx context.Context) map[string]*services.PublicKey
+	GetValidatorSchedule(ctx context.Context) []consensus.ValidatorSetEpoch
+}
+
+// Export copia a cadeia canônica do gênese até a altura atual
+func Export(ctx context.Context, chain ChainSource, validators ValidatorSource, exportedBy valueobjects.NodeID) (*Archive, error) {
+	// A altura é lida uma vez: blocos adicionados durante a exportação ficam de fora
+	height, err := chain.GetChainHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain height: %w", err)
+	}
+
+	archive := &Archive{
+		Format:             Format,
+		Version:            Version,
+		CreatedAt:          time.Now().Unix(),
+		ExportedBy:         exportedBy.String(),
+		HashAlgorithm:      HashAlgorithm,
+		SignatureAlgorithm: SignatureAlgorithm,
+		MerkleAlgorithm:    MerkleAlgorithm,
+		GenesisValidators:  NewValidatorKeys(validators.GetGenesisValidatorSet(ctx)),
+		ValidatorHistory:   NewValidatorHistory(validators.GetValidatorSchedule(ctx)),
+		Blocks:             make([]*ArchivedBlock, 0, height+1),
+	}
+
+	for index := uint64(0); index <= height; index++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		block, err := chain.GetBlockByIndex(ctx, index)
+		if err != nil {
+			if index == 0 {
+				return nil, errors.New("chain is empty: no genesis block to export")
+			}
+			return nil, fmt.Errorf("failed to get block %d: %w", index, err)
+		}
+
+		archived := NewArchivedBlock(block, chain.CalculateBlockHash(ctx, block))
+		archive.Blocks = append(archive.Blocks, archived)
+		archive.Chain.Transactions += len(archived.Transactions)
+	}
+
+	archive.Chain.Height = height
+	archive.Chain.GenesisHash = archive.Blocks[0].Hash
+	archive.Chain.HeadHash = archive.Blocks[len(archive.Blocks)-1].Hash
+	archive.Chain.FinalizedHeight, _ = chain.GetFinalizedHeight(ctx)
+
+	return archive, nil
+}
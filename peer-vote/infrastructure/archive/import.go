@@ -0,0 +1,111 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+)
+
+// ErrConflict indica que o arquivo não é compatível com a cadeia ou os validadores do nó
+var ErrConflict = errors.New("chain archive conflicts with local node")
+
+// ImportResult resume a importação de um arquivo de cadeia
+type ImportResult struct {
+	Imported          int    `json:"imported"`
+	Skipped           int    `json:"skipped"`
+	Height            uint64 `json:"height"`
+	HeadHash          string `json:"head_hash"`
+	AdoptedValidators int    `json:"adopted_validators"`
+}
+
+// Import inicializa a cadeia do nó a partir de um arquivo já aprovado pelo Verify.
+// Se o nó ainda não tem validadores configurados, adota o conjunto gênese do arquivo;
+// blocos que o nó já possui com o mesmo hash são ignorados e qualquer divergência
+// (validadores ou blocos diferentes) retorna ErrConflict sem alterar a cadeia local.
+func Import(ctx context.Context, archive *Archive, chainManager *blockchain.ChainManager, validatorManager *consensus.ValidatorManager) (*ImportResult, error) {
+	result := &ImportResult{}
+
+	genesis, err := DecodeValidatorKeys(archive.GenesisValidators)
+	if err != nil {
+		return nil, err
+	}
+
+	local := validatorManager.GetGenesisValidatorSet(ctx)
+	if len(local) > 0 && !SameValidatorSet(local, genesis) {
+		return nil, fmt.Errorf("%w: node is configured with a different genesis validator set", ErrConflict)
+	}
+
+	// Conferir os blocos que o nó já possui antes de alterar qualquer estado
+	height, err := chainManager.GetChainHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain height: %w", err)
+	}
+	for i, archived := range archive.Blocks {
+		if uint64(i) > height {
+			break
+		}
+		block, err := chainManager.GetBlockByIndex(ctx, uint64(i))
+		if err != nil {
+			// Cadeia local vazia
+			break
+		}
+		if hash := chainManager.CalculateBlockHash(ctx, block); hash.String() != archived.Hash {
+			return nil, fmt.Errorf("%w: block %d has hash %s locally and %s in the archive", ErrConflict, i, hash.String(), archived.Hash)
+		}
+		result.Skipped++
+	}
+
+	if len(local) == 0 {
+		for _, nodeID := range sortedNodeIDs(genesis) {
+			if err := validatorManager.AddValidator(ctx, valueobjects.NewNodeID(nodeID), genesis[nodeID]); err != nil {
+				return nil, fmt.Errorf("failed to add genesis validator %s: %w", nodeID, err)
+			}
+			result.AdoptedValidators++
+		}
+	}
+
+	for _, archived := range archive.Blocks[result.Skipped:] {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		block, err := archived.Block()
+		if err != nil {
+			return result, fmt.Errorf("block %d: %w", archived.Index, err)
+		}
+		if err := chainManager.AddBlock(ctx, block); err != nil {
+			return result, fmt.Errorf("failed to import block %d: %w", archived.Index, err)
+		}
+		result.Imported++
+	}
+
+	// A cadeia local pode já estar à frente do arquivo
+	latest, err := chainManager.GetLatestBlock(ctx)
+	if err != nil {
+		return result, fmt.Errorf("failed to get latest block: %w", err)
+	}
+	result.Height = latest.GetIndex()
+	result.HeadHash = chainManager.CalculateBlockHash(ctx, latest).String()
+
+	return result, nil
+}
+
+// SameValidatorSet compara dois conjuntos de validadores pelas chaves públicas
+func SameValidatorSet(a, b map[string]*services.PublicKey) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for nodeID, keyA := range a {
+		keyB, exists := b[nodeID]
+		if !exists || keyA == nil || keyB == nil || string(keyA.ToBytes()) != string(keyB.ToBytes()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// Manifest identifica a cadeia servida por um nó, sem os blocos: altura, hashes e o
+// conjunto de validadores. Com ele e os blocos completos (ArchivedBlock) um auditor
+// externo remonta o Archive do nó e o verifica com Verify.
+type Manifest struct {
+	NodeID            string           `json:"node_id"`
+	Height            uint64           `json:"height"`
+	BaseHeight        uint64           `json:"base_height"` // Primeira altura servida (> 0 quando o nó partiu de um snapshot)
+	GenesisHash       string           `json:"genesis_hash"`
+	HeadHash          string           `json:"head_hash"`
+	FinalizedHeight   uint64           `json:"finalized_height"`
+	GenesisValidators []ValidatorKey   `json:"genesis_validators"`
+	ValidatorHistory  []ValidatorEpoch `json:"validator_history"`
+}
+
+// ManifestSource fornece a cadeia canônica e sua base (ex: blockchain.ChainManager)
+type ManifestSource interface {
+	ChainSource
+	GetBaseHeight() uint64
+	GetGenesisHash(ctx context.Context) (valueobjects.Hash, error)
+}
+
+// NewManifest descreve a cadeia canônica do nó na altura atual
+func NewManifest(ctx context.Context, chain ManifestSource, validators ValidatorSource, nodeID valueobjects.NodeID) (*Manifest, error) {
+	height, err := chain.GetChainHeight(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain height: %w", err)
+	}
+
+	head, err := chain.GetBlockByIndex(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+
+	genesisHash, err := chain.GetGenesisHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &Manifest{
+		NodeID:            nodeID.String(),
+		Height:            height,
+		BaseHeight:        chain.GetBaseHeight(),
+		GenesisHash:       genesisHash.String(),
+		HeadHash:          chain.CalculateBlockHash(ctx, head).String(),
+		GenesisValidators: NewValidatorKeys(validators.GetGenesisValidatorSet(ctx)),
+		ValidatorHistory:  NewValidatorHistory(validators.GetValidatorSchedule(ctx)),
+	}
+	manifest.FinalizedHeight, _ = chain.GetFinalizedHeight(ctx)
+
+	return manifest, nil
+}
+
+// Manifest descreve a cadeia do arquivo como se fosse servida por um nó
+func (a *Archive) Manifest() *Manifest {
+	return &Manifest{
+		NodeID:            a.ExportedBy,
+		Height:            a.Chain.Height,
+		GenesisHash:       a.Chain.GenesisHash,
+		HeadHash:          a.Chain.HeadHash,
+		FinalizedHeight:   a.Chain.FinalizedHeight,
+		GenesisValidators: a.GenesisValidators,
+		ValidatorHistory:  a.ValidatorHistory,
+	}
+}
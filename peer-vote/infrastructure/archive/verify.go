@@ -0,0 +1,388 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/persistence"
+)
+
+// Verificações aplicadas pelo Verify, na ordem em que aparecem no relatório
+const (
+	CheckDecode            = "decode"
+	CheckSequence          = "sequence"
+	CheckHash              = "block_hash"
+	CheckPreviousHash      = "previous_hash"
+	CheckBlock             = "block_validation"
+	CheckMerkleRoot        = "merkle_root"
+	CheckSignature         = "block_signature"
+	CheckReplay            = "replay"
+	CheckGenesisValidators = "genesis_validators"
+	CheckValidatorHistory  = "validator_history"
+	CheckSummary           = "chain_summary"
+)
+
+var checkOrder = []string{
+	CheckDecode,
+	CheckSequence,
+	CheckHash,
+	CheckPreviousHash,
+	CheckBlock,
+	CheckMerkleRoot,
+	CheckSignature,
+	CheckReplay,
+	CheckGenesisValidators,
+	CheckValidatorHistory,
+	CheckSummary,
+}
+
+// VerificationReport é o resultado da verificação de um arquivo de cadeia
+type VerificationReport struct {
+	Format            string        `json:"format"`
+	Version           int           `json:"version"`
+	ArchiveSHA256     string        `json:"archive_sha256,omitempty"`
+	ExportedBy        string        `json:"exported_by"`
+	CreatedAt         int64         `json:"created_at"`
+	GenesisHash       string        `json:"genesis_hash"`
+	HeadHash          string        `json:"head_hash"`
+	Height            uint64        `json:"height"`
+	Blocks            int           `json:"blocks"`
+	Transactions      int           `json:"transactions"`
+	GenesisValidators int           `json:"genesis_validators"`
+	ValidatorEpochs   int           `json:"validator_epochs"`
+	Checks            []CheckResult `json:"checks"`
+	Failures          []Failure     `json:"failures,omitempty"`
+	Valid             bool          `json:"valid"`
+	VerifiedAt        int64         `json:"verified_at"`
+}
+
+// CheckResult conta quantas vezes uma verificação passou ou falhou
+type CheckResult struct {
+	Name   string `json:"name"`
+	Passed int    `json:"passed"`
+	Failed int    `json:"failed"`
+}
+
+// Failure descreve uma verificação que falhou; Height é omitido em falhas do arquivo como um todo
+type Failure struct {
+	Height *uint64 `json:"height,omitempty"`
+	Check  string  `json:"check"`
+	Error  string  `json:"error"`
+}
+
+// verification acumula os resultados das verificações
+type verification struct {
+	counts   map[string]*CheckResult
+	failures []Failure
+}
+
+// record registra o resultado de uma verificação
+func (v *verification) record(check string, height *uint64, err error) bool {
+	result, exists := v.counts[check]
+	if !exists {
+		result = &CheckResult{Name: check}
+		v.counts[check] = result
+	}
+
+	if err == nil {
+		result.Passed++
+		return true
+	}
+
+	result.Failed++
+	v.failures = append(v.failures, Failure{Height: height, Check: check, Error: err.Error()})
+	return false
+}
+
+// replay é uma cadeia em memória, sem rede, onde os blocos do arquivo são reaplicados
+type replay struct {
+	chain      *blockchain.ChainManager
+	validators *consensus.ValidatorManager
+	builder    *blockchain.BlockBuilder
+}
+
+// newReplay cria o ambiente de reaplicação a partir do conjunto gênese do arquivo.
+// A governança é registrada na cadeia para derivar as alterações de validadores
+// das transações VALIDATOR à medida que os blocos são aplicados.
+func newReplay(ctx context.Context, cryptoService services.CryptographyService, genesis map[string]*services.PublicKey) (*replay, error) {
+	chainManager := blockchain.NewChainManager(persistence.NewMemoryBlockchainRepository(cryptoService), cryptoService)
+	validatorManager := consensus.NewValidatorManager()
+
+	for _, nodeID := range sortedNodeIDs(genesis) {
+		if err := validatorManager.AddValidator(ctx, valueobjects.NewNodeID(nodeID), genesis[nodeID]); err != nil {
+			return nil, fmt.Errorf("failed to add genesis validator %s: %w", nodeID, err)
+		}
+	}
+
+	consensus.NewValidatorGovernance(validatorManager, chainManager, cryptoService)
+
+	return &replay{
+		chain:      chainManager,
+		validators: validatorManager,
+		builder:    blockchain.NewBlockBuilder(cryptoService),
+	}, nil
+}
+
+// Verify verifica um arquivo de cadeia sem acesso à rede: reaplica todos os blocos
+// a partir do gênese com BlockBuilder.ValidateBlock, recalcula hashes e raízes de
+// Merkle, confere as assinaturas dos blocos contra o conjunto de validadores vigente
+// em cada altura e compara o histórico de validadores derivado com o declarado.
+func Verify(ctx context.Context, archive *Archive, cryptoService services.CryptographyService) *VerificationReport {
+	report := &VerificationReport{
+		Format:          archive.Format,
+		Version:         archive.Version,
+		ExportedBy:      archive.ExportedBy,
+		CreatedAt:       archive.CreatedAt,
+		Blocks:          len(archive.Blocks),
+		ValidatorEpochs: len(archive.ValidatorHistory),
+	}
+	v := &verification{counts: make(map[string]*CheckResult)}
+
+	genesis := verifyGenesisValidators(ctx, archive, cryptoService, v)
+	report.GenesisValidators = len(genesis)
+
+	env, err := newReplay(ctx, cryptoService, genesis)
+	if err != nil {
+		v.record(CheckGenesisValidators, nil, err)
+		return finishReport(report, v)
+	}
+
+	var previousHash valueobjects.Hash
+	replaying := true
+
+	for i, archived := range archive.Blocks {
+		if ctx.Err() != nil {
+			v.record(CheckReplay, nil, ctx.Err())
+			break
+		}
+
+		height := uint64(i)
+		block, hash, ok := verifyBlock(ctx, env, archived, height, previousHash, v)
+		if block == nil {
+			// Sem o bloco decodificado não há como continuar encadeando
+			replaying = false
+			previousHash = valueobjects.EmptyHash()
+			continue
+		}
+
+		report.Transactions += len(block.GetTransactions())
+		previousHash = hash
+		if i == 0 {
+			report.GenesisHash = hash.String()
+		}
+		report.HeadHash = hash.String()
+
+		if !replaying {
+			continue
+		}
+		if !ok {
+			v.record(CheckReplay, &height, errors.New("replay stopped: block failed verification"))
+			replaying = false
+			continue
+		}
+		if !v.record(CheckReplay, &height, env.chain.AddBlock(ctx, block)) {
+			replaying = false
+		}
+	}
+
+	if len(archive.Blocks) > 0 {
+		report.Height = uint64(len(archive.Blocks) - 1)
+	}
+
+	if replaying {
+		verifyValidatorHistory(ctx, archive, env, v)
+	} else {
+		v.record(CheckValidatorHistory, nil, errors.New("validator history not checked: replay did not reach the head"))
+	}
+	verifySummary(archive, report, v)
+
+	return finishReport(report, v)
+}
+
+// verifyBlock aplica as verificações individuais de um bloco e retorna o bloco
+// reconstruído, o hash recalculado e se todas as verificações passaram
+func verifyBlock(ctx context.Context, env *replay, archived *ArchivedBlock, height uint64, previousHash valueobjects.Hash, v *verification) (*entities.Block, valueobjects.Hash, bool) {
+	if archived == nil {
+		v.record(CheckDecode, &height, errors.New("missing block"))
+		return nil, valueobjects.EmptyHash(), false
+	}
+
+	block, err := archived.Block()
+	if !v.record(CheckDecode, &height, err) {
+		return nil, valueobjects.EmptyHash(), false
+	}
+
+	ok := true
+
+	if block.GetIndex() != height {
+		err = fmt.Errorf("block index %d at position %d", block.GetIndex(), height)
+	} else {
+		err = nil
+	}
+	ok = v.record(CheckSequence, &height, err) && ok
+
+	hash := env.chain.CalculateBlockHash(ctx, block)
+	err = nil
+	if hash.String() != archived.Hash {
+		err = fmt.Errorf("recomputed hash %s does not match archived hash %s", hash.String(), archived.Hash)
+	}
+	ok = v.record(CheckHash, &height, err) && ok
+
+	err = nil
+	if height == 0 && !block.GetPreviousHash().IsEmpty() {
+		err = errors.New("genesis block must have empty previous hash")
+	} else if height > 0 && !block.GetPreviousHash().Equals(previousHash) {
+		err = fmt.Errorf("previous hash %s does not match hash of block %d (%s)", block.GetPreviousHash().String(), height-1, previousHash.String())
+	}
+	ok = v.record(CheckPreviousHash, &height, err) && ok
+
+	ok = v.record(CheckBlock, &height, env.builder.ValidateBlock(ctx, block)) && ok
+	ok = v.record(CheckMerkleRoot, &height, verifyMerkleRoot(block)) && ok
+	ok = v.record(CheckSignature, &height, verifySignature(ctx, env, block, height)) && ok
+
+	return block, hash, ok
+}
+
+// verifyMerkleRoot recalcula a raiz de Merkle independentemente do BlockBuilder
+func verifyMerkleRoot(block *entities.Block) error {
+	transactions := block.GetTransactions()
+	txData := make([][]byte, len(transactions))
+	for i, tx := range transactions {
+		txData[i] = tx.ToBytes()
+	}
+
+	tree, err := blockchain.NewMerkleTree(txData)
+	if err != nil {
+		return fmt.Errorf("failed to build merkle tree: %w", err)
+	}
+
+	if !tree.GetRoot().Equals(block.GetMerkleRoot()) {
+		return fmt.Errorf("recomputed merkle root %s does not match %s", tree.GetRoot().String(), block.GetMerkleRoot().String())
+	}
+
+	return nil
+}
+
+// verifySignature confere a assinatura do bloco com a chave do validador autorizado na altura
+func verifySignature(ctx context.Context, env *replay, block *entities.Block, height uint64) error {
+	publicKey, err := env.validators.GetValidatorPublicKeyAtHeight(ctx, block.GetValidator(), height)
+	if err != nil {
+		return err
+	}
+	return env.builder.ValidateBlockSignature(ctx, block, publicKey)
+}
+
+// verifyGenesisValidators decodifica o conjunto gênese, a âncora de confiança da verificação
+func verifyGenesisValidators(ctx context.Context, archive *Archive, cryptoService services.CryptographyService, v *verification) map[string]*services.PublicKey {
+	genesis := make(map[string]*services.PublicKey, len(archive.GenesisValidators))
+
+	if len(archive.GenesisValidators) == 0 {
+		v.record(CheckGenesisValidators, nil, errors.New("archive has no genesis validators"))
+		return genesis
+	}
+
+	for _, key := range archive.GenesisValidators {
+		publicKey, err := key.Key()
+		if err == nil && !cryptoService.GenerateNodeID(ctx, publicKey).Equals(valueobjects.NewNodeID(key.NodeID)) {
+			err = fmt.Errorf("node ID %s does not match public key", key.NodeID)
+		}
+		if err == nil {
+			if _, exists := genesis[key.NodeID]; exists {
+				err = fmt.Errorf("duplicate genesis validator %s", key.NodeID)
+			}
+		}
+		if v.record(CheckGenesisValidators, nil, err) {
+			genesis[key.NodeID] = publicKey
+		}
+	}
+
+	return genesis
+}
+
+// verifyValidatorHistory compara as épocas derivadas na reaplicação com as declaradas no arquivo
+func verifyValidatorHistory(ctx context.Context, archive *Archive, env *replay, v *verification) {
+	derived := NewValidatorHistory(env.validators.GetValidatorSchedule(ctx))
+
+	if len(derived) != len(archive.ValidatorHistory) {
+		v.record(CheckValidatorHistory, nil, fmt.Errorf("archive declares %d validator set changes, replay derived %d", len(archive.ValidatorHistory), len(derived)))
+		return
+	}
+
+	for i, epoch := range derived {
+		declared := archive.ValidatorHistory[i]
+		var err error
+		if !equalEpochs(epoch, declared) {
+			err = fmt.Errorf("validator set from height %d differs from the one derived from the chain", declared.FromHeight)
+		}
+		v.record(CheckValidatorHistory, nil, err)
+	}
+
+	if len(derived) == 0 {
+		v.record(CheckValidatorHistory, nil, nil)
+	}
+}
+
+// verifySummary confere o resumo declarado no arquivo com o que foi recalculado
+func verifySummary(archive *Archive, report *VerificationReport, v *verification) {
+	summary := archive.Chain
+	var problems []string
+
+	if summary.Height != report.Height {
+		problems = append(problems, fmt.Sprintf("height %d, archive has %d blocks", summary.Height, report.Blocks))
+	}
+	if summary.GenesisHash != report.GenesisHash {
+		problems = append(problems, fmt.Sprintf("genesis hash %s, recomputed %s", summary.GenesisHash, report.GenesisHash))
+	}
+	if summary.HeadHash != report.HeadHash {
+		problems = append(problems, fmt.Sprintf("head hash %s, recomputed %s", summary.HeadHash, report.HeadHash))
+	}
+	if summary.Transactions != report.Transactions {
+		problems = append(problems, fmt.Sprintf("%d transactions, counted %d", summary.Transactions, report.Transactions))
+	}
+	if summary.FinalizedHeight > summary.Height {
+		problems = append(problems, fmt.Sprintf("finalized height %d above height %d", summary.FinalizedHeight, summary.Height))
+	}
+
+	var err error
+	if len(problems) > 0 {
+		err = fmt.Errorf("archive summary declares %s", strings.Join(problems, "; "))
+	}
+	v.record(CheckSummary, nil, err)
+}
+
+// finishReport ordena as verificações e define o resultado final
+func finishReport(report *VerificationReport, v *verification) *VerificationReport {
+	report.Checks = make([]CheckResult, 0, len(checkOrder))
+	for _, check := range checkOrder {
+		if result, exists := v.counts[check]; exists {
+			report.Checks = append(report.Checks, *result)
+		}
+	}
+
+	report.Failures = v.failures
+	report.Valid = len(v.failures) == 0
+	report.VerifiedAt = time.Now().Unix()
+	return report
+}
+
+// equalEpochs compara duas épocas do conjunto de validadores
+func equalEpochs(a, b ValidatorEpoch) bool {
+	if a.FromHeight != b.FromHeight || len(a.Validators) != len(b.Validators) {
+		return false
+	}
+
+	for i := range a.Validators {
+		if a.Validators[i] != b.Validators[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,465 @@
+// Package auditor implementa a auditoria independente de observadores: busca a cadeia em
+// vários nós (ou arquivos exportados), confere se eles concordam bloco a bloco, reverifica a
+// cadeia da maioria, revalida cada voto e recalcula a apuração sem confiar em nenhum nó.
+package auditor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/persistence"
+)
+
+// ExportedBy identifica a cadeia remontada pelo auditor no relatório de verificação
+const ExportedBy = "auditor"
+
+// Options configura uma auditoria
+type Options struct {
+	ElectionID valueobjects.Hash              // Audita apenas esta eleição (vazio: todas)
+	Height     *uint64                        // Altura auditada (nil: a maior declarada pelas fontes)
+	VoterKeys  map[string]*services.PublicKey // Chaves públicas conhecidas dos eleitores, por NodeID
+}
+
+// Auditor confronta as cópias da cadeia servidas por várias fontes
+type Auditor struct {
+	sources           []Source
+	cryptoService     services.CryptographyService
+	validationService services.VotingValidationService
+	hasher            *blockchain.ChainManager
+	logger            *slog.Logger
+}
+
+// NewAuditor cria um auditor sobre as fontes, na ordem de preferência usada para desempates
+func NewAuditor(cryptoService services.CryptographyService, validationService services.VotingValidationService, sources ...Source) *Auditor {
+	return &Auditor{
+		sources:           sources,
+		cryptoService:     cryptoService,
+		validationService: validationService,
+		hasher:            blockchain.NewChainManager(persistence.NewMemoryBlockchainRepository(cryptoService), cryptoService),
+		logger:            logging.Component("auditor"),
+	}
+}
+
+// SetLogger define o logger do auditor
+func (a *Auditor) SetLogger(logger *slog.Logger) {
+	a.logger = logger
+}
+
+// sourceState acompanha uma fonte durante a auditoria
+type sourceState struct {
+	source   Source
+	manifest *archive.Manifest
+	report   *SourceReport
+	ranges   map[string]*blockRange // Faixa aberta por tipo de discrepância
+}
+
+// blockRange é uma faixa contígua de alturas com a mesma discrepância
+type blockRange struct {
+	from, to uint64
+	detail   string
+}
+
+// fetched é o bloco servido por uma fonte em uma altura
+type fetched struct {
+	block *archive.ArchivedBlock
+	hash  string // Hash recalculado
+	err   error
+}
+
+// Run executa a auditoria. Erros são retornados apenas quando nenhuma fonte responde ou o
+// contexto é cancelado; todo o resto vira discrepância no relatório.
+func (a *Auditor) Run(ctx context.Context, options Options) (*Report, error) {
+	report := &Report{
+		Sources:       make([]SourceReport, 0, len(a.sources)),
+		Elections:     []ElectionReport{},
+		Discrepancies: []Discrepancy{},
+	}
+
+	states := a.loadManifests(ctx, report)
+	if len(states) == 0 {
+		return nil, errors.New("no chain source available")
+	}
+
+	reference := a.checkGenesisValidators(states, report)
+
+	report.Height = auditHeight(options, states)
+	a.logger.InfoContext(ctx, "auditing chain", "sources", len(states), logging.Height(report.Height))
+
+	blocks := make([]*archive.ArchivedBlock, report.Height+1)
+	for height := uint64(0); height <= report.Height; height++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		blocks[height] = a.compareBlock(ctx, states, height, report)
+	}
+	for _, state := range states {
+		state.close(report)
+	}
+
+	chainArchive := a.canonicalArchive(reference, blocks, report.Height)
+	report.Chain = archive.Verify(ctx, chainArchive, a.cryptoService)
+	report.GenesisHash = report.Chain.GenesisHash
+	report.HeadHash = report.Chain.HeadHash
+	for _, failure := range report.Chain.Failures {
+		// Alturas que nenhuma fonte serviu já foram relatadas como missing_block
+		if failure.Check == archive.CheckDecode && failure.Height != nil && blocks[*failure.Height] == nil {
+			continue
+		}
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Kind:   KindChainVerification,
+			Height: failure.Height,
+			Detail: fmt.Sprintf("%s: %s", failure.Check, failure.Error),
+		})
+	}
+
+	votes := newVoteAudit(a.cryptoService, a.validationService, options)
+	if err := votes.run(ctx, blocks); err != nil {
+		return nil, err
+	}
+	report.Votes = votes.summary
+	report.Discrepancies = append(report.Discrepancies, votes.discrepancies...)
+	report.Elections = votes.reports()
+
+	a.compareTallies(ctx, states, report)
+
+	report.Passed = len(report.Discrepancies) == 0 && report.Chain.Valid
+	report.AuditedAt = time.Now().Unix()
+	return report, nil
+}
+
+// auditHeight retorna a altura pedida ou a maior declarada pelas fontes
+func auditHeight(options Options, states []*sourceState) uint64 {
+	if options.Height != nil {
+		return *options.Height
+	}
+
+	var height uint64
+	for _, state := range states {
+		if state.manifest.Height > height {
+			height = state.manifest.Height
+		}
+	}
+	return height
+}
+
+// loadManifests consulta o manifesto de cada fonte; as que não respondem ficam de fora
+func (a *Auditor) loadManifests(ctx context.Context, report *Report) []*sourceState {
+	manifests := make([]*archive.Manifest, len(a.sources))
+	for i, source := range a.sources {
+		sourceReport := SourceReport{Name: source.Name()}
+
+		manifest, err := source.Manifest(ctx)
+		if err != nil {
+			sourceReport.Error = err.Error()
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Kind:   KindSourceUnavailable,
+				Source: source.Name(),
+				Detail: err.Error(),
+			})
+			a.logger.WarnContext(ctx, "chain source unavailable", "source", source.Name(), logging.Err(err))
+		} else {
+			sourceReport.NodeID = manifest.NodeID
+			sourceReport.Height = manifest.Height
+			sourceReport.BaseHeight = manifest.BaseHeight
+			sourceReport.HeadHash = manifest.HeadHash
+			sourceReport.Available = true
+			manifests[i] = manifest
+		}
+		report.Sources = append(report.Sources, sourceReport)
+	}
+
+	// Os relatórios das fontes só são referenciados depois que a lista para de crescer
+	var states []*sourceState
+	for i, source := range a.sources {
+		if manifests[i] == nil {
+			continue
+		}
+		states = append(states, &sourceState{
+			source:   source,
+			manifest: manifests[i],
+			report:   &report.Sources[i],
+			ranges:   make(map[string]*blockRange),
+		})
+	}
+
+	return states
+}
+
+// checkGenesisValidators compara o conjunto gênese declarado pelas fontes e retorna o
+// manifesto de referência: o da primeira fonte do conjunto mais frequente
+func (a *Auditor) checkGenesisValidators(states []*sourceState, report *Report) *archive.Manifest {
+	keys := make([]string, len(states))
+	counts := make(map[string]int)
+	for i, state := range states {
+		data, _ := json.Marshal(state.manifest.GenesisValidators)
+		keys[i] = string(data)
+		counts[keys[i]]++
+	}
+
+	best := 0
+	for i := range states {
+		if counts[keys[i]] > counts[keys[best]] {
+			best = i
+		}
+	}
+
+	for i, state := range states {
+		if keys[i] != keys[best] {
+			report.Discrepancies = append(report.Discrepancies, Discrepancy{
+				Kind:   KindGenesisValidators,
+				Source: state.source.Name(),
+				Detail: fmt.Sprintf("genesis validator set differs from the one declared by %d of %d sources", counts[keys[best]], len(states)),
+			})
+		}
+	}
+
+	return states[best].manifest
+}
+
+// compareBlock busca a altura em todas as fontes e retorna o bloco da maioria, registrando
+// as fontes que não o serviram ou serviram outro. Nil quando nenhuma fonte tem um bloco válido.
+func (a *Auditor) compareBlock(ctx context.Context, states []*sourceState, height uint64, report *Report) *archive.ArchivedBlock {
+	results := make([]*fetched, len(states))
+
+	var wg sync.WaitGroup
+	for i, state := range states {
+		// Abaixo da base o nó partiu de um snapshot e não guarda os blocos
+		if height < state.manifest.BaseHeight {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, state *sourceState) {
+			defer wg.Done()
+			results[i] = a.fetchBlock(ctx, state.source, height)
+		}(i, state)
+	}
+	wg.Wait()
+
+	// Maioria pelo hash recalculado; empates ficam com a fonte listada primeiro
+	counts := make(map[string]int)
+	best := -1
+	for i, result := range results {
+		if result == nil || result.err != nil {
+			continue
+		}
+		counts[result.hash]++
+		if best < 0 || counts[result.hash] > counts[results[best].hash] {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		report.Discrepancies = append(report.Discrepancies, Discrepancy{
+			Kind:   KindMissingBlock,
+			Height: uint64Ptr(height),
+			Detail: "no source served a valid block at this height",
+		})
+	}
+
+	for i, result := range results {
+		state := states[i]
+		switch {
+		case result == nil:
+			state.close(report)
+		case errors.Is(result.err, ErrBlockNotFound):
+			state.report.MissingBlocks++
+			state.mark(report, KindMissingBlock, height, "block not served")
+		case result.err != nil && result.block == nil:
+			state.report.MissingBlocks++
+			state.mark(report, KindMissingBlock, height, result.err.Error())
+		case result.err != nil:
+			state.report.DivergentBlocks++
+			state.mark(report, KindInvalidBlock, height, result.err.Error())
+		case result.hash != results[best].hash:
+			state.report.DivergentBlocks++
+			state.mark(report, KindDivergentBlock, height, fmt.Sprintf("block hash %s differs from %s served by %d of %d sources",
+				result.hash, results[best].hash, counts[results[best].hash], len(states)))
+		default:
+			state.report.BlocksAgreed++
+			state.close(report)
+		}
+	}
+
+	if best < 0 {
+		return nil
+	}
+	return results[best].block
+}
+
+// fetchBlock busca e decodifica um bloco, recalculando seu hash
+func (a *Auditor) fetchBlock(ctx context.Context, source Source, height uint64) *fetched {
+	archived, err := source.Block(ctx, height)
+	if err != nil {
+		return &fetched{err: err}
+	}
+
+	block, err := archived.Block()
+	if err != nil {
+		return &fetched{block: archived, err: fmt.Errorf("block does not decode: %w", err)}
+	}
+	if block.GetIndex() != height {
+		return &fetched{block: archived, err: fmt.Errorf("block index %d served for height %d", block.GetIndex(), height)}
+	}
+
+	hash := a.hasher.CalculateBlockHash(ctx, block).String()
+	if archived.Hash != hash {
+		return &fetched{block: archived, err: fmt.Errorf("declared hash %s, recomputed %s", archived.Hash, hash)}
+	}
+
+	return &fetched{block: archived, hash: hash}
+}
+
+// mark registra a discrepância da fonte na altura, estendendo a faixa aberta do mesmo tipo
+func (s *sourceState) mark(report *Report, kind string, height uint64, detail string) {
+	if open, exists := s.ranges[kind]; exists && open.to+1 == height {
+		open.to = height
+		return
+	}
+	s.close(report)
+	s.ranges[kind] = &blockRange{from: height, to: height, detail: detail}
+}
+
+// close encerra as faixas abertas da fonte
+func (s *sourceState) close(report *Report) {
+	for kind := range s.ranges {
+		s.flushRange(report, kind)
+	}
+}
+
+// flushRange converte a faixa aberta em discrepância
+func (s *sourceState) flushRange(report *Report, kind string) {
+	open := s.ranges[kind]
+	delete(s.ranges, kind)
+
+	discrepancy := Discrepancy{
+		Kind:   kind,
+		Source: s.source.Name(),
+		Height: uint64Ptr(open.from),
+		Detail: open.detail,
+	}
+	if open.to != open.from {
+		discrepancy.ToHeight = uint64Ptr(open.to)
+	}
+	report.Discrepancies = append(report.Discrepancies, discrepancy)
+}
+
+// canonicalArchive remonta o arquivo de cadeia com os blocos da maioria e o conjunto de
+// validadores da referência, para reverificação com archive.Verify
+func (a *Auditor) canonicalArchive(reference *archive.Manifest, blocks []*archive.ArchivedBlock, height uint64) *archive.Archive {
+	chainArchive := &archive.Archive{
+		Format:             archive.Format,
+		Version:            archive.Version,
+		CreatedAt:          time.Now().Unix(),
+		ExportedBy:         ExportedBy,
+		HashAlgorithm:      archive.HashAlgorithm,
+		SignatureAlgorithm: archive.SignatureAlgorithm,
+		MerkleAlgorithm:    archive.MerkleAlgorithm,
+		GenesisValidators:  reference.GenesisValidators,
+		ValidatorHistory:   reference.ValidatorHistory,
+		Blocks:             blocks,
+	}
+
+	chainArchive.Chain.Height = height
+	chainArchive.Chain.FinalizedHeight = min(reference.FinalizedHeight, height)
+	for _, block := range blocks {
+		if block != nil {
+			chainArchive.Chain.Transactions += len(block.Transactions)
+		}
+	}
+	if blocks[0] != nil {
+		chainArchive.Chain.GenesisHash = blocks[0].Hash
+	}
+	// Como no Verify, o topo é o último bloco presente
+	for i := len(blocks) - 1; i >= 0; i-- {
+		if blocks[i] != nil {
+			chainArchive.Chain.HeadHash = blocks[i].Hash
+			break
+		}
+	}
+
+	return chainArchive
+}
+
+// compareTallies compara a apuração recalculada com a informada por cada nó
+func (a *Auditor) compareTallies(ctx context.Context, states []*sourceState, report *Report) {
+	for i := range report.Elections {
+		election := &report.Elections[i]
+		electionID, err := valueobjects.NewHashFromString(election.ElectionID)
+		if err != nil {
+			continue
+		}
+
+		for _, state := range states {
+			tallySource, ok := state.source.(TallySource)
+			if !ok {
+				continue
+			}
+
+			tally := NodeTally{Source: state.source.Name()}
+			response, err := tallySource.CountVotes(ctx, electionID)
+			if err != nil {
+				// Nós que não apuram a eleição (ex: ainda pendente) não são comparados
+				tally.Error = err.Error()
+				election.Nodes = append(election.Nodes, tally)
+				continue
+			}
+
+			tally.TotalVotes = response.TotalVotes
+			tally.Results = make(map[string]uint64, len(response.Results))
+			for _, result := range response.Results {
+				tally.Results[result.CandidateID] = result.VoteCount
+			}
+
+			differences := tallyDifferences(election, tally)
+			tally.Matches = len(differences) == 0
+			election.Nodes = append(election.Nodes, tally)
+
+			if !tally.Matches {
+				report.Discrepancies = append(report.Discrepancies, Discrepancy{
+					Kind:       KindTallyMismatch,
+					Source:     state.source.Name(),
+					ElectionID: election.ElectionID,
+					Detail:     joinDetails(differences),
+				})
+			}
+		}
+	}
+}
+
+// tallyDifferences lista as diferenças entre a apuração recalculada e a de um nó
+func tallyDifferences(election *ElectionReport, tally NodeTally) []string {
+	var differences []string
+
+	if tally.TotalVotes != uint64(election.ValidVotes) {
+		differences = append(differences, fmt.Sprintf("node counts %d votes, recomputed %d valid", tally.TotalVotes, election.ValidVotes))
+	}
+
+	recomputed := make(map[string]uint64, len(election.Results))
+	for _, result := range election.Results {
+		recomputed[result.CandidateID] = result.Votes
+		if reported := tally.Results[result.CandidateID]; reported != result.Votes {
+			differences = append(differences, fmt.Sprintf("candidate %s: node %d, recomputed %d", result.CandidateID, reported, result.Votes))
+		}
+	}
+	for _, candidateID := range sortedKeys(tally.Results) {
+		if _, known := recomputed[candidateID]; !known && tally.Results[candidateID] > 0 {
+			differences = append(differences, fmt.Sprintf("candidate %s: node %d, not in election", candidateID, tally.Results[candidateID]))
+		}
+	}
+
+	return differences
+}
+
+func uint64Ptr(value uint64) *uint64 {
+	return &value
+}
@@ -0,0 +1,106 @@
+package auditor
+
+import (
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+)
+
+// Tipos de discrepância encontrados pelo auditor
+const (
+	// A fonte não respondeu ao manifesto e ficou fora da auditoria
+	KindSourceUnavailable = "source_unavailable"
+	// A fonte declara um conjunto gênese de validadores diferente do da maioria
+	KindGenesisValidators = "genesis_validators"
+	// A fonte não serviu blocos que deveria ter (acima da sua base)
+	KindMissingBlock = "missing_block"
+	// A fonte serviu um bloco que não decodifica ou cujo hash declarado não confere
+	KindInvalidBlock = "invalid_block"
+	// A fonte serviu um bloco diferente do da maioria na mesma altura
+	KindDivergentBlock = "divergent_block"
+	// A cadeia da maioria falhou na reverificação (archive.Verify)
+	KindChainVerification = "chain_verification"
+	// Voto que não passa na revalidação
+	KindInvalidVote = "invalid_vote"
+	// Segundo voto do mesmo eleitor na mesma eleição
+	KindDuplicateVote = "duplicate_vote"
+	// A apuração informada por um nó difere da recalculada
+	KindTallyMismatch = "tally_mismatch"
+)
+
+// Report é o resultado da auditoria independente
+type Report struct {
+	Sources       []SourceReport              `json:"sources"`
+	Height        uint64                      `json:"height"` // Altura auditada
+	GenesisHash   string                      `json:"genesis_hash"`
+	HeadHash      string                      `json:"head_hash"`
+	Chain         *archive.VerificationReport `json:"chain"` // Reverificação da cadeia da maioria
+	Elections     []ElectionReport            `json:"elections"`
+	Votes         VoteSummary                 `json:"votes"`
+	Discrepancies []Discrepancy               `json:"discrepancies"`
+	Passed        bool                        `json:"passed"`
+	AuditedAt     int64                       `json:"audited_at"`
+}
+
+// SourceReport resume o que uma fonte (nó ou arquivo) serviu
+type SourceReport struct {
+	Name            string `json:"name"`
+	NodeID          string `json:"node_id,omitempty"`
+	Height          uint64 `json:"height"`
+	BaseHeight      uint64 `json:"base_height"`
+	HeadHash        string `json:"head_hash,omitempty"`
+	Available       bool   `json:"available"`
+	Error           string `json:"error,omitempty"`
+	BlocksAgreed    uint64 `json:"blocks_agreed"`
+	MissingBlocks   uint64 `json:"missing_blocks"`
+	DivergentBlocks uint64 `json:"divergent_blocks"` // Inclui os blocos inválidos
+}
+
+// VoteSummary conta os votos revalidados
+type VoteSummary struct {
+	Total                int `json:"total"`
+	Valid                int `json:"valid"`
+	Invalid              int `json:"invalid"`
+	Anonymous            int `json:"anonymous"`
+	SignaturesVerified   int `json:"signatures_verified"`   // Assinaturas conferidas com a chave do eleitor
+	SignaturesUnverified int `json:"signatures_unverified"` // Eleitor sem chave conhecida: apenas a presença foi conferida
+}
+
+// ElectionReport é a apuração recalculada de uma eleição e a comparação com cada nó
+type ElectionReport struct {
+	ElectionID   string           `json:"election_id"`
+	Title        string           `json:"title"`
+	Height       uint64           `json:"height"` // Bloco que registrou a eleição
+	TotalVotes   int              `json:"total_votes"`
+	ValidVotes   int              `json:"valid_votes"`
+	InvalidVotes int              `json:"invalid_votes"`
+	Results      []CandidateTally `json:"results"`
+	Nodes        []NodeTally      `json:"nodes,omitempty"`
+}
+
+// CandidateTally são os votos válidos recalculados de um candidato
+type CandidateTally struct {
+	CandidateID string `json:"candidate_id"`
+	Name        string `json:"name"`
+	Votes       uint64 `json:"votes"`
+}
+
+// NodeTally é a apuração informada por um nó
+type NodeTally struct {
+	Source     string            `json:"source"`
+	TotalVotes uint64            `json:"total_votes"`
+	Results    map[string]uint64 `json:"results,omitempty"`
+	Matches    bool              `json:"matches"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// Discrepancy descreve um problema encontrado. Height e ToHeight delimitam faixas de
+// blocos (ToHeight omitido para um bloco só); Source é omitido quando o problema é da
+// cadeia da maioria e não de uma fonte específica.
+type Discrepancy struct {
+	Kind       string  `json:"kind"`
+	Source     string  `json:"source,omitempty"`
+	Height     *uint64 `json:"height,omitempty"`
+	ToHeight   *uint64 `json:"to_height,omitempty"`
+	ElectionID string  `json:"election_id,omitempty"`
+	TxHash     string  `json:"tx_hash,omitempty"`
+	Detail     string  `json:"detail"`
+}
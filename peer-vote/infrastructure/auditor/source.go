@@ -0,0 +1,128 @@
+package auditor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/client"
+)
+
+// ErrBlockNotFound indica que a fonte não tem o bloco pedido
+var ErrBlockNotFound = errors.New("block not found")
+
+// defaultVoterCurve é a curva das chaves geradas por vote keygen, usada quando o arquivo de chaves a omite
+const defaultVoterCurve = "P-256"
+
+// Source é uma cópia da cadeia consultada pelo auditor
+type Source interface {
+	// Name identifica a fonte no relatório (URL do nó ou caminho do arquivo)
+	Name() string
+	// Manifest descreve a cadeia servida pela fonte
+	Manifest(ctx context.Context) (*archive.Manifest, error)
+	// Block retorna o bloco completo na altura (ErrBlockNotFound se a fonte não o tiver)
+	Block(ctx context.Context, height uint64) (*archive.ArchivedBlock, error)
+}
+
+// TallySource é uma fonte que também informa a apuração das eleições (nós via REST)
+type TallySource interface {
+	Source
+	CountVotes(ctx context.Context, electionID valueobjects.Hash) (*usecases.CountVotesResponse, error)
+}
+
+// RESTSource lê a cadeia de um nó pela API REST, com os endpoints públicos
+// /chain/manifest e /chain/blocks/{index}
+type RESTSource struct {
+	client *client.Client
+}
+
+// NewRESTSource cria uma fonte a partir do cliente da API de um nó
+func NewRESTSource(apiClient *client.Client) *RESTSource {
+	return &RESTSource{client: apiClient}
+}
+
+// Name retorna o endereço da API do nó
+func (s *RESTSource) Name() string {
+	return s.client.BaseURL()
+}
+
+// Manifest obtém o manifesto da cadeia do nó
+func (s *RESTSource) Manifest(ctx context.Context) (*archive.Manifest, error) {
+	return s.client.GetChainManifest(ctx)
+}
+
+// Block obtém o bloco completo do nó
+func (s *RESTSource) Block(ctx context.Context, height uint64) (*archive.ArchivedBlock, error) {
+	block, err := s.client.GetArchivedBlock(ctx, height)
+	if client.IsNotFound(err) {
+		return nil, ErrBlockNotFound
+	}
+	return block, err
+}
+
+// CountVotes obtém a apuração da eleição calculada pelo nó
+func (s *RESTSource) CountVotes(ctx context.Context, electionID valueobjects.Hash) (*usecases.CountVotesResponse, error) {
+	return s.client.CountVotes(ctx, electionID.String())
+}
+
+// ArchiveSource lê a cadeia de um arquivo exportado com chain export
+type ArchiveSource struct {
+	name    string
+	archive *archive.Archive
+}
+
+// NewArchiveSource cria uma fonte a partir de um arquivo de cadeia já lido
+func NewArchiveSource(name string, chainArchive *archive.Archive) *ArchiveSource {
+	return &ArchiveSource{name: name, archive: chainArchive}
+}
+
+// Name retorna o nome do arquivo
+func (s *ArchiveSource) Name() string {
+	return s.name
+}
+
+// Manifest descreve a cadeia do arquivo
+func (s *ArchiveSource) Manifest(ctx context.Context) (*archive.Manifest, error) {
+	return s.archive.Manifest(), nil
+}
+
+// Block retorna o bloco arquivado na posição da altura
+func (s *ArchiveSource) Block(ctx context.Context, height uint64) (*archive.ArchivedBlock, error) {
+	if height >= uint64(len(s.archive.Blocks)) || s.archive.Blocks[height] == nil {
+		return nil, ErrBlockNotFound
+	}
+	return s.archive.Blocks[height], nil
+}
+
+// LoadVoterKeys lê as chaves públicas conhecidas dos eleitores, usadas para conferir as
+// assinaturas dos votos: uma lista JSON de {node_id, public_key, curve}, o formato de
+// vote keygen --output json. Cada chave deve gerar o NodeID declarado.
+func LoadVoterKeys(ctx context.Context, r io.Reader, cryptoService services.CryptographyService) (map[string]*services.PublicKey, error) {
+	var keys []archive.ValidatorKey
+	if err := json.NewDecoder(r).Decode(&keys); err != nil {
+		return nil, fmt.Errorf("invalid voter keys: %w", err)
+	}
+
+	voterKeys := make(map[string]*services.PublicKey, len(keys))
+	for _, key := range keys {
+		if key.Curve == "" {
+			key.Curve = defaultVoterCurve
+		}
+		publicKey, err := key.Key()
+		if err != nil {
+			return nil, err
+		}
+		if !cryptoService.GenerateNodeID(ctx, publicKey).Equals(valueobjects.NewNodeID(key.NodeID)) {
+			return nil, fmt.Errorf("node ID %s does not match its public key", key.NodeID)
+		}
+		voterKeys[key.NodeID] = publicKey
+	}
+
+	return voterKeys, nil
+}
@@ -0,0 +1,293 @@
+package auditor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+)
+
+// voteAudit revalida os votos da cadeia da maioria e recalcula a apuração
+type voteAudit struct {
+	cryptoService     services.CryptographyService
+	validationService services.VotingValidationService
+	options           Options
+
+	elections     map[string]*electionTally
+	order         []string
+	summary       VoteSummary
+	discrepancies []Discrepancy
+}
+
+// electionTally é o acumulado de uma eleição durante a revalidação
+type electionTally struct {
+	election *entities.Election
+	height   uint64
+	total    int
+	valid    int
+	invalid  int
+	counts   map[string]uint64
+	voters   map[string]string // Eleitor -> transação do primeiro voto válido
+}
+
+func newVoteAudit(cryptoService services.CryptographyService, validationService services.VotingValidationService, options Options) *voteAudit {
+	return &voteAudit{
+		cryptoService:     cryptoService,
+		validationService: validationService,
+		options:           options,
+		elections:         make(map[string]*electionTally),
+		discrepancies:     []Discrepancy{},
+	}
+}
+
+// run percorre os blocos em ordem; alturas sem bloco já foram relatadas e são puladas
+func (v *voteAudit) run(ctx context.Context, blocks []*archive.ArchivedBlock) error {
+	for height, archived := range blocks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if archived == nil {
+			continue
+		}
+
+		block, err := archived.Block()
+		if err != nil {
+			continue
+		}
+
+		for _, tx := range block.GetTransactions() {
+			switch tx.GetType() {
+			case entities.ElectionTransaction:
+				v.registerElection(tx, uint64(height))
+			case entities.VoteTransaction:
+				v.auditVote(ctx, tx, uint64(height))
+			}
+		}
+	}
+
+	return nil
+}
+
+// registerElection registra a eleição; versões posteriores (mudanças de status) substituem a anterior
+func (v *voteAudit) registerElection(tx *entities.Transaction, height uint64) {
+	election := &entities.Election{}
+	if err := election.FromBytes(tx.GetData()); err != nil {
+		return
+	}
+
+	electionID := election.GetID().String()
+	if tally, exists := v.elections[electionID]; exists {
+		tally.election = election
+		return
+	}
+
+	v.elections[electionID] = &electionTally{
+		election: election,
+		height:   height,
+		counts:   make(map[string]uint64),
+		voters:   make(map[string]string),
+	}
+	v.order = append(v.order, electionID)
+}
+
+// auditVote revalida um voto e o soma à apuração se for válido
+func (v *voteAudit) auditVote(ctx context.Context, tx *entities.Transaction, height uint64) {
+	txHash := tx.GetHash().String()
+
+	vote := &entities.Vote{}
+	if err := vote.FromBytes(tx.GetData()); err != nil {
+		// Sem o voto não há como saber a eleição: relatado em qualquer filtro
+		v.summary.Total++
+		v.summary.Invalid++
+		v.discrepancies = append(v.discrepancies, Discrepancy{
+			Kind:   KindInvalidVote,
+			Height: uint64Ptr(height),
+			TxHash: txHash,
+			Detail: fmt.Sprintf("vote does not decode: %v", err),
+		})
+		return
+	}
+
+	if !v.options.ElectionID.IsEmpty() && !vote.GetElectionID().Equals(v.options.ElectionID) {
+		return
+	}
+
+	v.summary.Total++
+	if vote.IsAnonymous() {
+		v.summary.Anonymous++
+	}
+
+	electionID := vote.GetElectionID().String()
+	tally := v.elections[electionID]
+
+	var problems []string
+	if tally == nil {
+		problems = append(problems, "election not registered before the vote")
+	} else if err := v.validationService.ValidateVoteForAudit(ctx, vote, tally.election); err != nil {
+		problems = append(problems, err.Error())
+	}
+	problems = append(problems, v.checkIntegrity(ctx, vote, tx)...)
+
+	if tally != nil {
+		tally.total++
+	}
+
+	if len(problems) == 0 && tally != nil && !vote.IsAnonymous() {
+		voter := vote.GetVoterID().String()
+		if first, voted := tally.voters[voter]; voted {
+			v.reject(tally, height, electionID, txHash, KindDuplicateVote, fmt.Sprintf("voter %s already voted in transaction %s", voter, first))
+			return
+		}
+		tally.voters[voter] = txHash
+	}
+
+	if len(problems) > 0 {
+		v.reject(tally, height, electionID, txHash, KindInvalidVote, joinDetails(problems))
+		return
+	}
+
+	v.summary.Valid++
+	tally.valid++
+	tally.counts[vote.GetCandidateID()]++
+}
+
+// reject registra um voto que não entra na apuração
+func (v *voteAudit) reject(tally *electionTally, height uint64, electionID, txHash, kind, detail string) {
+	v.summary.Invalid++
+	if tally != nil {
+		tally.invalid++
+	}
+	v.discrepancies = append(v.discrepancies, Discrepancy{
+		Kind:       kind,
+		Height:     uint64Ptr(height),
+		ElectionID: electionID,
+		TxHash:     txHash,
+		Detail:     detail,
+	})
+}
+
+// checkIntegrity confere o ID, o remetente e as assinaturas do voto. As assinaturas só
+// são verificadas contra a chave do eleitor quando ela é conhecida (Options.VoterKeys):
+// a cadeia não registra as chaves dos eleitores.
+func (v *voteAudit) checkIntegrity(ctx context.Context, vote *entities.Vote, tx *entities.Transaction) []string {
+	var problems []string
+
+	// ID do voto é o hash dos dados assinados
+	signedData, err := vote.ToBytes()
+	if err != nil {
+		return append(problems, fmt.Sprintf("failed to serialize vote: %v", err))
+	}
+	if !v.cryptoService.HashTransaction(ctx, signedData).Equals(vote.GetID()) {
+		problems = append(problems, "vote ID does not match its content")
+	}
+
+	if !vote.IsAnonymous() {
+		if !vote.GetVoterID().Equals(tx.GetFrom()) {
+			problems = append(problems, fmt.Sprintf("voter %s differs from transaction sender %s", vote.GetVoterID().String(), tx.GetFrom().String()))
+		}
+		if vote.GetSignature().IsEmpty() {
+			problems = append(problems, "missing signature for non-anonymous vote")
+		}
+	}
+	if tx.GetSignature().IsEmpty() {
+		problems = append(problems, "missing transaction signature")
+	}
+
+	publicKey, known := v.options.VoterKeys[tx.GetFrom().String()]
+	if !known {
+		v.summary.SignaturesUnverified++
+		return problems
+	}
+
+	verified := true
+	if !vote.GetSignature().IsEmpty() {
+		// Assinatura do voto cobre os dados sem assinatura
+		unsigned := vote.Copy()
+		unsigned.SetSignature(valueobjects.EmptySignature())
+		unsignedData, err := unsigned.ToBytes()
+		if err == nil {
+			valid, verifyErr := v.cryptoService.Verify(ctx, unsignedData, vote.GetSignature(), publicKey)
+			err = verifyErr
+			if err == nil && !valid {
+				err = fmt.Errorf("does not match the key of %s", tx.GetFrom().String())
+			}
+		}
+		if err != nil {
+			verified = false
+			problems = append(problems, fmt.Sprintf("vote signature verification failed: %v", err))
+		}
+	}
+
+	if !tx.GetSignature().IsEmpty() {
+		// Assinatura da transação cobre o voto completo
+		txData, err := vote.ToBytesWithID()
+		if err == nil {
+			valid, verifyErr := v.cryptoService.Verify(ctx, txData, tx.GetSignature(), publicKey)
+			err = verifyErr
+			if err == nil && !valid {
+				err = fmt.Errorf("does not match the key of %s", tx.GetFrom().String())
+			}
+		}
+		if err != nil {
+			verified = false
+			problems = append(problems, fmt.Sprintf("transaction signature verification failed: %v", err))
+		}
+	}
+
+	if verified {
+		v.summary.SignaturesVerified++
+	}
+	return problems
+}
+
+// reports retorna a apuração recalculada das eleições auditadas, na ordem da cadeia
+func (v *voteAudit) reports() []ElectionReport {
+	reports := make([]ElectionReport, 0, len(v.order))
+
+	for _, electionID := range v.order {
+		if !v.options.ElectionID.IsEmpty() && electionID != v.options.ElectionID.String() {
+			continue
+		}
+
+		tally := v.elections[electionID]
+		report := ElectionReport{
+			ElectionID:   electionID,
+			Title:        tally.election.GetTitle(),
+			Height:       tally.height,
+			TotalVotes:   tally.total,
+			ValidVotes:   tally.valid,
+			InvalidVotes: tally.invalid,
+			Results:      make([]CandidateTally, 0, len(tally.election.GetCandidates())),
+		}
+		for _, candidate := range tally.election.GetCandidates() {
+			report.Results = append(report.Results, CandidateTally{
+				CandidateID: candidate.ID,
+				Name:        candidate.Name,
+				Votes:       tally.counts[candidate.ID],
+			})
+		}
+		reports = append(reports, report)
+	}
+
+	return reports
+}
+
+// joinDetails junta os problemas encontrados em um detalhe
+func joinDetails(details []string) string {
+	return strings.Join(details, "; ")
+}
+
+// sortedKeys retorna as chaves do mapa em ordem alfabética
+func sortedKeys(values map[string]uint64) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
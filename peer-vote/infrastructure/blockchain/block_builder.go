@@ -197,6 +197,15 @@ func (bb *BlockBuilder) ValidateBlockSignature(ctx context.Context, block *entit
 	return nil
 }
 
+// SigningPayload retorna os dados do cabeçalho cobertos pela assinatura do validador
+func (bb *BlockBuilder) SigningPayload(ctx context.Context, block *entities.Block) ([]byte, error) {
+	if block == nil {
+		return nil, errors.New("block is nil")
+	}
+
+	return bb.serializeBlockForSigning(ctx, block)
+}
+
 // validateTransactions valida uma lista de transações
 func (bb *BlockBuilder) validateTransactions(ctx context.Context, transactions []*entities.Transaction) ([]*entities.Transaction, error) {
 	var validTransactions []*entities.Transaction
@@ -51,3 +51,15 @@ func (ba *BlockchainAdapter) ValidateChain(ctx context.Context) error {
 func (ba *BlockchainAdapter) GetLatestBlock(ctx context.Context) (*entities.Block, error) {
 	return ba.chainManager.GetLatestBlock(ctx)
 }
+
+func (ba *BlockchainAdapter) GetFinalizedHeight(ctx context.Context) (uint64, bool) {
+	return ba.chainManager.GetFinalizedHeight(ctx)
+}
+
+func (ba *BlockchainAdapter) WaitForTransaction(ctx context.Context, txHash valueobjects.Hash) (valueobjects.Hash, uint64, error) {
+	location, err := ba.chainManager.WaitForTransaction(ctx, txHash)
+	if err != nil {
+		return valueobjects.EmptyHash(), 0, err
+	}
+	return location.BlockHash, location.BlockIndex, nil
+}
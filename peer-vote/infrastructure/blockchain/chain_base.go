@@ -0,0 +1,170 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// ChainBase descreve o estado confiável (snapshot) a partir do qual a cadeia local começa.
+// O nó guarda apenas o bloco âncora na altura Height e os blocos seguintes; as eleições
+// registradas até a âncora (inclusive) e suas apurações vêm do snapshot.
+type ChainBase struct {
+	Height      uint64
+	Hash        valueobjects.Hash
+	GenesisHash valueobjects.Hash
+	Elections   []BaseElection // Em ordem de posição na cadeia
+}
+
+// BaseElection é uma eleição registrada até a base com a apuração acumulada até ela
+type BaseElection struct {
+	Election   *entities.Election
+	Position   repositories.ChainPosition // Posição da transação ELECTION na cadeia original
+	Results    map[string]uint64          // Votos válidos por candidato
+	TotalVotes uint64
+	Voters     []string // Remetentes dos votos, ordenados
+}
+
+// InitializeFromBase inicia uma cadeia vazia a partir do bloco âncora de um snapshot já
+// verificado. A âncora é marcada como final: nenhuma reorganização pode desfazê-la.
+// Os listeners de blocos não são notificados; os subsistemas derivados devem ser
+// reconstruídos a partir da base.
+func (cm *ChainManager) InitializeFromBase(ctx context.Context, anchor *entities.Block, base *ChainBase) error {
+	if anchor == nil || base == nil {
+		return errors.New("anchor block and chain base are required")
+	}
+
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if cm.latestBlock != nil {
+		return errors.New("chain is not empty")
+	}
+	if anchor.GetIndex() != base.Height {
+		return fmt.Errorf("anchor block index %d does not match base height %d", anchor.GetIndex(), base.Height)
+	}
+
+	hash := cm.calculateBlockHash(ctx, anchor)
+	if !hash.Equals(base.Hash) {
+		return fmt.Errorf("anchor block hash %s does not match base hash %s", hash.String(), base.Hash.String())
+	}
+
+	if err := cm.blockBuilder.ValidateBlock(ctx, anchor); err != nil {
+		return fmt.Errorf("anchor block validation failed: %w", err)
+	}
+
+	if err := cm.repository.SaveBlock(ctx, anchor); err != nil {
+		return fmt.Errorf("failed to save anchor block: %w", err)
+	}
+
+	elections := make([]BaseElection, len(base.Elections))
+	copy(elections, base.Elections)
+	sort.SliceStable(elections, func(i, j int) bool {
+		return elections[j].Position.Follows(elections[i].Position, repositories.SortAscending)
+	})
+
+	cm.base = &ChainBase{
+		Height:      base.Height,
+		Hash:        base.Hash,
+		GenesisHash: base.GenesisHash,
+		Elections:   elections,
+	}
+	cm.latestBlock = anchor
+	cm.chainHeight = anchor.GetIndex()
+	cm.finalizedHeight = anchor.GetIndex()
+	cm.finalizedHash = hash
+	cm.hasFinalized = true
+	cm.indexBlock(ctx, anchor)
+
+	cm.logger.InfoContext(ctx, "chain initialized from snapshot base",
+		logging.Height(base.Height), "hash", hash.String(), "elections", len(elections))
+	return nil
+}
+
+// GetBase retorna a base da cadeia (false quando a cadeia começa no gênesis)
+func (cm *ChainManager) GetBase() (*ChainBase, bool) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	return cm.base, cm.base != nil
+}
+
+// GetBaseHeight retorna a primeira altura disponível no repositório local (0 sem base)
+func (cm *ChainManager) GetBaseHeight() uint64 {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	return cm.baseHeight()
+}
+
+// baseHeight implementa GetBaseHeight (deve ser chamado com lock)
+func (cm *ChainManager) baseHeight() uint64 {
+	if cm.base == nil {
+		return 0
+	}
+	return cm.base.Height
+}
+
+// GetGenesisHash retorna o hash do bloco gênesis, inclusive quando a cadeia começa em uma base
+func (cm *ChainManager) GetGenesisHash(ctx context.Context) (valueobjects.Hash, error) {
+	cm.mu.RLock()
+	base := cm.base
+	cm.mu.RUnlock()
+
+	if base != nil {
+		return base.GenesisHash, nil
+	}
+
+	genesis, err := cm.repository.GetBlockByIndex(ctx, 0)
+	if err != nil {
+		return valueobjects.EmptyHash(), fmt.Errorf("failed to get genesis block: %w", err)
+	}
+	return cm.calculateBlockHash(ctx, genesis), nil
+}
+
+// GetBaseElection retorna a eleição registrada até a base, com a apuração acumulada
+func (cm *ChainManager) GetBaseElection(electionID valueobjects.Hash) (*BaseElection, bool) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	if cm.base == nil {
+		return nil, false
+	}
+	for i := range cm.base.Elections {
+		if cm.base.Elections[i].Election.GetID().Equals(electionID) {
+			return &cm.base.Elections[i], true
+		}
+	}
+	return nil, false
+}
+
+// baseElections retorna as eleições da base na ordem pedida, a partir da posição after (exclusive)
+func (cm *ChainManager) baseElections(after *repositories.ChainPosition, order repositories.SortOrder) []BaseElection {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	if cm.base == nil {
+		return nil
+	}
+
+	result := make([]BaseElection, 0, len(cm.base.Elections))
+	for i := range cm.base.Elections {
+		index := i
+		if order == repositories.SortDescending {
+			index = len(cm.base.Elections) - 1 - i
+		}
+
+		entry := cm.base.Elections[index]
+		if after != nil && !entry.Position.Follows(*after, order) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
@@ -4,14 +4,29 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
 
 	"github.com/matscats/peer-vote/peer-vote/domain/entities"
 	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
 	"github.com/matscats/peer-vote/peer-vote/domain/services"
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
 )
 
+// ErrUnknownParent indica que o bloco não se conecta a nenhum bloco conhecido
+var ErrUnknownParent = errors.New("block parent is unknown")
+
+// BlockListener é notificado quando um bloco passa a fazer parte da cadeia canônica
+type BlockListener func(ctx context.Context, block *entities.Block)
+
+// TransactionLocation indica onde uma transação foi incluída na cadeia canônica
+type TransactionLocation struct {
+	BlockIndex uint64
+	BlockHash  valueobjects.Hash
+	Position   int // Posição da transação no bloco
+}
+
 // ChainManager gerencia a cadeia de blocos
 type ChainManager struct {
 	repository    repositories.BlockchainRepository
@@ -22,11 +37,34 @@ type ChainManager struct {
 	latestBlock   *entities.Block
 	chainHeight   uint64
 	
+	// Ramificações concorrentes e regras PoA para escolha de fork
+	forkTree       *ForkTree
+	authority      BlockAuthority
+	reorgListeners []ReorgListener
+	
+	// Blocos aplicados à cadeia canônica aguardando notificação fora do lock
+	blockListeners []BlockListener
+	appended       []*entities.Block
+	
+	// Finalidade: blocos até esta altura não podem ser reorganizados
+	finalizedHeight uint64
+	finalizedHash   valueobjects.Hash
+	hasFinalized    bool
+	
+	// Índice de transações da cadeia canônica e clientes aguardando inclusão
+	txIndex   map[string]TransactionLocation
+	txWaiters map[string][]chan TransactionLocation
+	
+	// Base confiável quando a cadeia foi iniciada a partir de um snapshot (nil = gênesis)
+	base *ChainBase
+	
 	// Mutex para operações thread-safe
 	mu sync.RWMutex
 	
 	// Configurações
 	maxReorgDepth int // Profundidade máxima para reorganização
+	
+	logger *slog.Logger
 }
 
 // NewChainManager cria um novo gerenciador de cadeia
@@ -37,10 +75,94 @@ func NewChainManager(repository repositories.BlockchainRepository, cryptoService
 		repository:    repository,
 		blockBuilder:  blockBuilder,
 		cryptoService: cryptoService,
+		forkTree:      NewForkTree(),
+		txIndex:       make(map[string]TransactionLocation),
+		txWaiters:     make(map[string][]chan TransactionLocation),
 		maxReorgDepth: 100, // Máximo de 100 blocos para reorganização
+		logger:        logging.Component("chain"),
 	}
 }
 
+// SetLogger define o logger do gerenciador de cadeia
+func (cm *ChainManager) SetLogger(logger *slog.Logger) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	cm.logger = logger
+}
+
+// SetBlockAuthority define as regras PoA usadas para validar e comparar ramificações
+func (cm *ChainManager) SetBlockAuthority(authority BlockAuthority) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	cm.authority = authority
+}
+
+// AddReorgListener registra um subsistema a ser notificado após reorganizações
+func (cm *ChainManager) AddReorgListener(listener ReorgListener) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	cm.reorgListeners = append(cm.reorgListeners, listener)
+}
+
+// AddBlockListener registra um subsistema a ser notificado a cada bloco aplicado à cadeia canônica
+func (cm *ChainManager) AddBlockListener(listener BlockListener) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	cm.blockListeners = append(cm.blockListeners, listener)
+}
+
+// MarkFinalized marca como final o bloco canônico na altura informada (e seus ancestrais)
+func (cm *ChainManager) MarkFinalized(ctx context.Context, index uint64, hash valueobjects.Hash) error {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if cm.hasFinalized && index <= cm.finalizedHeight {
+		return nil // Finalidade é monotônica
+	}
+
+	if !cm.isCanonical(ctx, index, hash) {
+		return fmt.Errorf("block %s at height %d is not in the canonical chain", hash.String(), index)
+	}
+
+	cm.finalizedHeight = index
+	cm.finalizedHash = hash
+	cm.hasFinalized = true
+
+	// Ramificações abaixo da altura final nunca serão escolhidas
+	cm.forkTree.PruneBelow(index)
+
+	cm.logger.DebugContext(ctx, "block finalized", logging.Height(index), "hash", hash.String())
+	return nil
+}
+
+// GetFinalizedHeight retorna a altura do último bloco final (false se nenhum bloco é final)
+func (cm *ChainManager) GetFinalizedHeight(ctx context.Context) (uint64, bool) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	return cm.finalizedHeight, cm.hasFinalized
+}
+
+// GetFinalizedHash retorna o hash do último bloco final
+func (cm *ChainManager) GetFinalizedHash(ctx context.Context) (valueobjects.Hash, bool) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	return cm.finalizedHash, cm.hasFinalized
+}
+
+// GetMaxReorgDepth retorna a profundidade máxima permitida para reorganizações
+func (cm *ChainManager) GetMaxReorgDepth() int {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	return cm.maxReorgDepth
+}
+
 // Initialize inicializa o gerenciador de cadeia
 func (cm *ChainManager) Initialize(ctx context.Context) error {
 	cm.mu.Lock()
@@ -59,7 +181,21 @@ func (cm *ChainManager) Initialize(ctx context.Context) error {
 	cm.chainHeight = latestBlock.GetIndex()
 
 	// Validar integridade da cadeia
-	return cm.validateChainIntegrity(ctx)
+	if err := cm.validateChainIntegrity(ctx); err != nil {
+		return err
+	}
+
+	// Reconstruir o índice de transações
+	cm.txIndex = make(map[string]TransactionLocation)
+	for index := cm.baseHeight(); index <= cm.chainHeight; index++ {
+		block, err := cm.repository.GetBlockByIndex(ctx, index)
+		if err != nil {
+			return fmt.Errorf("failed to index block %d: %w", index, err)
+		}
+		cm.indexBlock(ctx, block)
+	}
+
+	return nil
 }
 
 // AddBlock adiciona um novo bloco à cadeia
@@ -69,8 +205,16 @@ func (cm *ChainManager) AddBlock(ctx context.Context, block *entities.Block) err
 	}
 
 	cm.mu.Lock()
-	defer cm.mu.Unlock()
+	err := cm.addBlock(ctx, block)
+	appended, listeners := cm.takeAppended()
+	cm.mu.Unlock()
+
+	cm.notifyBlockListeners(ctx, listeners, appended)
+	return err
+}
 
+// addBlock implementa AddBlock (deve ser chamado com lock)
+func (cm *ChainManager) addBlock(ctx context.Context, block *entities.Block) error {
 	// Validar o bloco
 	if err := cm.blockBuilder.ValidateBlock(ctx, block); err != nil {
 		return fmt.Errorf("block validation failed: %w", err)
@@ -81,6 +225,11 @@ func (cm *ChainManager) AddBlock(ctx context.Context, block *entities.Block) err
 		return fmt.Errorf("block connection validation failed: %w", err)
 	}
 
+	return cm.appendBlock(ctx, block)
+}
+
+// appendBlock salva um bloco já validado no topo da cadeia (deve ser chamado com lock)
+func (cm *ChainManager) appendBlock(ctx context.Context, block *entities.Block) error {
 	// Salvar o bloco
 	if err := cm.repository.SaveBlock(ctx, block); err != nil {
 		return fmt.Errorf("failed to save block: %w", err)
@@ -89,10 +238,204 @@ func (cm *ChainManager) AddBlock(ctx context.Context, block *entities.Block) err
 	// Atualizar cache
 	cm.latestBlock = block
 	cm.chainHeight = block.GetIndex()
+	cm.appended = append(cm.appended, block)
+	cm.indexBlock(ctx, block)
+
+	// Ramificações abaixo da profundidade máxima não podem mais vencer
+	if cm.chainHeight > uint64(cm.maxReorgDepth) {
+		cm.forkTree.PruneBelow(cm.chainHeight - uint64(cm.maxReorgDepth))
+	}
+
+	return nil
+}
+
+// ProcessBlock processa um bloco recebido de outro nó.
+// Blocos que estendem o topo são adicionados diretamente; blocos de ramificações
+// concorrentes são guardados na árvore de forks e, se a ramificação vencer a regra
+// de escolha de fork, a cadeia é reorganizada. Retorna ErrUnknownParent quando o
+// bloco não se conecta a nenhum bloco conhecido.
+func (cm *ChainManager) ProcessBlock(ctx context.Context, block *entities.Block) error {
+	if block == nil {
+		return errors.New("block is nil")
+	}
+
+	cm.mu.Lock()
+	event, err := cm.processBlock(ctx, block)
+	listeners := cm.reorgListeners
+	appended, blockListeners := cm.takeAppended()
+	cm.mu.Unlock()
+
+	cm.notifyBlockListeners(ctx, blockListeners, appended)
+
+	if err != nil {
+		return err
+	}
+
+	// Notificar fora do lock: os subsistemas podem consultar a cadeia
+	if event != nil {
+		cm.logger.WarnContext(ctx, "chain reorganized",
+			"common_ancestor", event.CommonAncestor,
+			"removed", len(event.Removed),
+			"added", len(event.Added),
+			"new_tip", event.NewTip.String(),
+		)
+		for _, listener := range listeners {
+			listener(ctx, event)
+		}
+	}
 
 	return nil
 }
 
+// processBlock implementa ProcessBlock (deve ser chamado com lock)
+func (cm *ChainManager) processBlock(ctx context.Context, block *entities.Block) (*ReorgEvent, error) {
+	// Validar estrutura, hashes das transações e Merkle root
+	if err := cm.blockBuilder.ValidateBlock(ctx, block); err != nil {
+		return nil, fmt.Errorf("block validation failed: %w", err)
+	}
+
+	// Cadeia vazia ou bloco estendendo o topo: caminho normal
+	if cm.latestBlock == nil || cm.extendsTip(ctx, block) {
+		if err := cm.validateBlockConnection(ctx, block); err != nil {
+			return nil, fmt.Errorf("block connection validation failed: %w", err)
+		}
+		return nil, cm.appendBlock(ctx, block)
+	}
+
+	hash := cm.calculateBlockHash(ctx, block)
+
+	// Bloco já conhecido (canônico ou em ramificação)
+	if cm.forkTree.Contains(hash) || cm.isCanonical(ctx, block.GetIndex(), hash) {
+		return nil, nil
+	}
+
+	if cm.authority == nil {
+		return nil, errors.New("block authority not configured, cannot evaluate forks")
+	}
+
+	// Blocos finais não podem ser substituídos
+	if cm.hasFinalized && block.GetIndex() <= cm.finalizedHeight {
+		return nil, fmt.Errorf("fork at height %d conflicts with finalized height %d", block.GetIndex(), cm.finalizedHeight)
+	}
+
+	// Ramificações não podem reescrever além da profundidade máxima
+	if block.GetIndex() == 0 || block.GetIndex()+uint64(cm.maxReorgDepth) <= cm.chainHeight {
+		return nil, fmt.Errorf("fork at height %d is deeper than max reorg depth %d", block.GetIndex(), cm.maxReorgDepth)
+	}
+
+	// O pai deve estar na cadeia canônica ou na árvore de forks
+	parentIndex := block.GetIndex() - 1
+	if !cm.forkTree.Contains(block.GetPreviousHash()) && !cm.isCanonical(ctx, parentIndex, block.GetPreviousHash()) {
+		return nil, ErrUnknownParent
+	}
+
+	// Blocos de ramificações passam pela verificação de autoridade PoA
+	if err := cm.authority.VerifyBlockAuthority(ctx, block); err != nil {
+		return nil, fmt.Errorf("fork block authority verification failed: %w", err)
+	}
+
+	cm.forkTree.Add(block, hash)
+	cm.logger.DebugContext(ctx, "fork block stored", logging.Height(block.GetIndex()), "hash", hash.String(), "validator", block.GetValidator().String())
+
+	// Avaliar as ramificações que passam pelo novo bloco (ele pode conectar descendentes já conhecidos)
+	for _, tip := range cm.forkTree.Tips(hash) {
+		event, err := cm.applyForkChoice(ctx, tip)
+		if err != nil || event != nil {
+			return event, err
+		}
+	}
+
+	return nil, nil
+}
+
+// takeAppended retorna e limpa os blocos aplicados desde a última notificação (deve ser chamado com lock)
+func (cm *ChainManager) takeAppended() ([]*entities.Block, []BlockListener) {
+	appended := cm.appended
+	cm.appended = nil
+	return appended, cm.blockListeners
+}
+
+// notifyBlockListeners notifica os blocos aplicados (deve ser chamado sem lock)
+func (cm *ChainManager) notifyBlockListeners(ctx context.Context, listeners []BlockListener, blocks []*entities.Block) {
+	for _, block := range blocks {
+		cm.logger.DebugContext(ctx, "block applied",
+			logging.Height(block.GetIndex()),
+			"validator", block.GetValidator().String(),
+			"transactions", len(block.GetTransactions()),
+		)
+		for _, listener := range listeners {
+			listener(ctx, block)
+		}
+	}
+}
+
+// extendsTip verifica se o bloco se encadeia ao topo atual (deve ser chamado com lock)
+func (cm *ChainManager) extendsTip(ctx context.Context, block *entities.Block) bool {
+	return block.GetIndex() == cm.latestBlock.GetIndex()+1 &&
+		block.GetPreviousHash().Equals(cm.calculateBlockHash(ctx, cm.latestBlock))
+}
+
+// isCanonical verifica se o hash corresponde ao bloco canônico na altura (deve ser chamado com lock)
+func (cm *ChainManager) isCanonical(ctx context.Context, index uint64, hash valueobjects.Hash) bool {
+	if index > cm.chainHeight {
+		return false
+	}
+
+	block, err := cm.repository.GetBlockByIndex(ctx, index)
+	if err != nil {
+		return false
+	}
+
+	return cm.calculateBlockHash(ctx, block).Equals(hash)
+}
+
+// applyForkChoice compara a ramificação que termina em tipHash com a cadeia canônica
+// e reorganiza se ela for preferida (deve ser chamado com lock)
+func (cm *ChainManager) applyForkChoice(ctx context.Context, tipHash valueobjects.Hash) (*ReorgEvent, error) {
+	branch, branchHashes, ancestorHash := cm.forkTree.Branch(tipHash)
+	if len(branch) == 0 {
+		return nil, nil
+	}
+
+	ancestorIndex := branch[0].GetIndex() - 1
+	if !cm.isCanonical(ctx, ancestorIndex, ancestorHash) {
+		return nil, nil // Ramificação ainda desconectada da cadeia canônica
+	}
+
+	// Nunca reorganizar abaixo da altura final
+	if cm.hasFinalized && ancestorIndex < cm.finalizedHeight {
+		return nil, nil
+	}
+
+	// Blocos canônicos que seriam desfeitos
+	current := make([]*entities.Block, 0)
+	if cm.chainHeight > ancestorIndex {
+		blocks, err := cm.repository.GetBlockRange(ctx, ancestorIndex+1, cm.chainHeight)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load canonical branch: %w", err)
+		}
+		current = blocks
+	}
+
+	currentTip := cm.calculateBlockHash(ctx, cm.latestBlock)
+	if !cm.scoreBranch(ctx, branch, tipHash).betterThan(cm.scoreBranch(ctx, current, currentTip)) {
+		return nil, nil // Cadeia canônica continua preferida
+	}
+
+	return cm.reorganizeChain(ctx, ancestorIndex, current, branch, branchHashes)
+}
+
+// scoreBranch calcula o peso de uma ramificação para a escolha de fork (deve ser chamado com lock)
+func (cm *ChainManager) scoreBranch(ctx context.Context, blocks []*entities.Block, tipHash valueobjects.Hash) branchScore {
+	score := branchScore{length: len(blocks), tipHash: tipHash}
+	for _, block := range blocks {
+		if cm.authority.IsInTurn(ctx, block.GetValidator(), block.GetIndex()) {
+			score.inTurn++
+		}
+	}
+	return score
+}
+
 // GetLatestBlock retorna o último bloco da cadeia
 func (cm *ChainManager) GetLatestBlock(ctx context.Context) (*entities.Block, error) {
 	cm.mu.RLock()
@@ -135,6 +478,42 @@ func (cm *ChainManager) ValidateChain(ctx context.Context) error {
 	return cm.validateChainIntegrity(ctx)
 }
 
+// ValidateTip valida o último bloco armazenado e sua ligação com o bloco anterior.
+// Retorna o bloco validado, ou nil quando a cadeia ainda está vazia.
+func (cm *ChainManager) ValidateTip(ctx context.Context) (*entities.Block, error) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	tip, err := cm.repository.GetLatestBlock(ctx)
+	if err != nil {
+		if cm.latestBlock == nil {
+			return nil, nil // Cadeia vazia
+		}
+		return nil, fmt.Errorf("failed to read chain tip: %w", err)
+	}
+
+	tipHash := cm.calculateBlockHash(ctx, tip)
+	if cm.latestBlock != nil && !tipHash.Equals(cm.calculateBlockHash(ctx, cm.latestBlock)) {
+		return nil, fmt.Errorf("stored tip %d does not match the canonical tip %d", tip.GetIndex(), cm.latestBlock.GetIndex())
+	}
+
+	if err := cm.blockBuilder.ValidateBlock(ctx, tip); err != nil {
+		return nil, fmt.Errorf("tip block %d is invalid: %w", tip.GetIndex(), err)
+	}
+
+	if tip.GetIndex() > cm.baseHeight() {
+		previous, err := cm.repository.GetBlockByIndex(ctx, tip.GetIndex()-1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block at index %d: %w", tip.GetIndex()-1, err)
+		}
+		if !tip.GetPreviousHash().Equals(cm.calculateBlockHash(ctx, previous)) {
+			return nil, fmt.Errorf("tip block %d has invalid previous hash", tip.GetIndex())
+		}
+	}
+
+	return tip, nil
+}
+
 // CreateGenesisBlock cria e adiciona o bloco gênesis
 func (cm *ChainManager) CreateGenesisBlock(ctx context.Context, genesisTransactions []*entities.Transaction, validator valueobjects.NodeID, privateKey *services.PrivateKey) error {
 	cm.mu.Lock()
@@ -169,6 +548,7 @@ func (cm *ChainManager) CreateGenesisBlock(ctx context.Context, genesisTransacti
 	// Atualizar cache
 	cm.latestBlock = genesisBlock
 	cm.chainHeight = 0
+	cm.indexBlock(ctx, genesisBlock)
 
 	return nil
 }
@@ -203,29 +583,11 @@ func (cm *ChainManager) ProposeBlock(ctx context.Context, transactions []*entiti
 
 // HandleFork lida com situações de fork na cadeia
 func (cm *ChainManager) HandleFork(ctx context.Context, alternativeBlock *entities.Block) error {
-	cm.mu.Lock()
-	defer cm.mu.Unlock()
-
 	if alternativeBlock == nil {
 		return errors.New("alternative block is nil")
 	}
 
-	// Validar o bloco alternativo
-	if err := cm.blockBuilder.ValidateBlock(ctx, alternativeBlock); err != nil {
-		return fmt.Errorf("alternative block validation failed: %w", err)
-	}
-
-	// Determinar se devemos reorganizar a cadeia
-	shouldReorg, err := cm.shouldReorganize(ctx, alternativeBlock)
-	if err != nil {
-		return fmt.Errorf("failed to determine reorganization: %w", err)
-	}
-
-	if shouldReorg {
-		return cm.reorganizeChain(ctx, alternativeBlock)
-	}
-
-	return nil
+	return cm.ProcessBlock(ctx, alternativeBlock)
 }
 
 // validateBlockConnection verifica se um bloco se conecta corretamente à cadeia
@@ -267,10 +629,10 @@ func (cm *ChainManager) validateChainIntegrity(ctx context.Context) error {
 		return nil // Cadeia vazia é válida
 	}
 
-	// Validar do gênesis até o último bloco
+	// Validar do gênesis (ou da base do snapshot) até o último bloco
 	var previousBlock *entities.Block
 	
-	for i := uint64(0); i <= height; i++ {
+	for i := cm.baseHeight(); i <= height; i++ {
 		block, err := cm.repository.GetBlockByIndex(ctx, i)
 		if err != nil {
 			return fmt.Errorf("failed to get block at index %d: %w", i, err)
@@ -318,108 +680,169 @@ func (cm *ChainManager) serializeBlockForHashing(ctx context.Context, block *ent
 	return cm.blockBuilder.serializeBlock(ctx, block)
 }
 
-// shouldReorganize determina se a cadeia deve ser reorganizada
-func (cm *ChainManager) shouldReorganize(ctx context.Context, alternativeBlock *entities.Block) (bool, error) {
-	if alternativeBlock == nil {
-		return false, errors.New("alternative block is nil")
-	}
-	
-	// Verificar se o bloco alternativo é válido
-	if err := cm.blockBuilder.ValidateBlock(ctx, alternativeBlock); err != nil {
-		return false, fmt.Errorf("alternative block is invalid: %w", err)
+// reorganizeChain desfaz os blocos canônicos acima do ancestral comum e aplica a
+// ramificação vencedora. Os blocos desfeitos permanecem na árvore de forks como
+// ramificação concorrente. Em caso de falha a cadeia original é restaurada.
+// Deve ser chamado com lock.
+func (cm *ChainManager) reorganizeChain(ctx context.Context, ancestorIndex uint64, oldBranch, newBranch []*entities.Block, newHashes []valueobjects.Hash) (*ReorgEvent, error) {
+	if len(newBranch) == 0 {
+		return nil, errors.New("new branch is empty")
 	}
-	
-	// Verificar se temos um bloco atual para comparar
-	if cm.latestBlock == nil {
-		return true, nil // Se não temos bloco, aceitar qualquer bloco válido
-	}
-	
-	// Verificar se o bloco alternativo tem o mesmo índice
-	if alternativeBlock.GetIndex() != cm.latestBlock.GetIndex() {
-		return false, nil // Índices diferentes, não reorganizar
-	}
-	
-	// Verificar se o bloco alternativo tem o mesmo hash anterior
-	if !alternativeBlock.GetPreviousHash().Equals(cm.latestBlock.GetPreviousHash()) {
-		return false, nil // Hash anterior diferente, não reorganizar
+
+	ancestor, err := cm.repository.GetBlockByIndex(ctx, ancestorIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get common ancestor: %w", err)
 	}
-	
-	// Critério de reorganização: bloco mais antigo (produzido primeiro)
-	// Em PoA, o primeiro bloco válido produzido deve ser aceito
-	if alternativeBlock.GetTimestamp().Before(cm.latestBlock.GetTimestamp()) {
-		return true, nil
+
+	oldTip := cm.calculateBlockHash(ctx, cm.latestBlock)
+	appendedMark := len(cm.appended)
+
+	// Rollback: remover do topo até o ancestral comum
+	if err := cm.rollbackTo(ctx, ancestor, oldBranch); err != nil {
+		return nil, err
 	}
-	
-	// Se os timestamps são iguais, usar o hash como critério de desempate
-	if alternativeBlock.GetTimestamp().Equal(cm.latestBlock.GetTimestamp()) {
-		altHash := cm.calculateBlockHash(ctx, alternativeBlock)
-		currentHash := cm.calculateBlockHash(ctx, cm.latestBlock)
-		
-		// Usar comparação lexicográfica dos hashes
-		return altHash.String() < currentHash.String(), nil
+
+	// Replay: aplicar a nova ramificação em ordem
+	for i, block := range newBranch {
+		err := cm.validateBlockConnection(ctx, block)
+		if err == nil {
+			err = cm.appendBlock(ctx, block)
+		}
+		if err != nil {
+			// Restaurar a cadeia original
+			if restoreErr := cm.restoreChain(ctx, ancestor, newBranch[:i], oldBranch); restoreErr != nil {
+				return nil, fmt.Errorf("failed to apply block %d of new branch: %w; restoring original chain failed: %w", block.GetIndex(), err, restoreErr)
+			}
+			// A cadeia voltou ao estado anterior: nada novo a notificar
+			cm.appended = cm.appended[:appendedMark]
+			return nil, fmt.Errorf("failed to apply block %d of new branch: %w", block.GetIndex(), err)
+		}
+		cm.forkTree.Remove(newHashes[i])
 	}
-	
-	return false, nil
+
+	return &ReorgEvent{
+		CommonAncestor: ancestorIndex,
+		OldTip:         oldTip,
+		NewTip:         newHashes[len(newHashes)-1],
+		Removed:        oldBranch,
+		Added:          newBranch,
+	}, nil
 }
 
-// reorganizeChain reorganiza a cadeia para uma alternativa
-func (cm *ChainManager) reorganizeChain(ctx context.Context, alternativeBlock *entities.Block) error {
-	if alternativeBlock == nil {
-		return errors.New("alternative block is nil")
+// restoreChain desfaz os blocos já aplicados da nova ramificação e reaplica a ramificação
+// original após uma reorganização com falha (deve ser chamado com lock)
+func (cm *ChainManager) restoreChain(ctx context.Context, ancestor *entities.Block, applied, oldBranch []*entities.Block) error {
+	if err := cm.rollbackTo(ctx, ancestor, applied); err != nil {
+		return fmt.Errorf("failed to roll back new branch: %w", err)
 	}
-	
-	// Implementação básica de reorganização
-	// Esta é uma versão simplificada que apenas substitui o último bloco
-	
-	// Verificar se o bloco alternativo é válido
-	if err := cm.blockBuilder.ValidateBlock(ctx, alternativeBlock); err != nil {
-		return fmt.Errorf("alternative block is invalid: %w", err)
-	}
-	
-	// Verificar se o bloco alternativo tem o mesmo índice que o atual
-	currentHeight := cm.chainHeight
-	if alternativeBlock.GetIndex() != currentHeight {
-		return fmt.Errorf("alternative block index %d does not match current height %d", 
-			alternativeBlock.GetIndex(), currentHeight)
+
+	for _, oldBlock := range oldBranch {
+		if err := cm.appendBlock(ctx, oldBlock); err != nil {
+			return fmt.Errorf("failed to reapply block %d: %w", oldBlock.GetIndex(), err)
+		}
+		cm.forkTree.Remove(cm.calculateBlockHash(ctx, oldBlock))
 	}
-	
-	// Obter o bloco atual
-	currentBlock, err := cm.repository.GetBlockByIndex(ctx, currentHeight)
-	if err != nil {
-		return fmt.Errorf("failed to get current block: %w", err)
+
+	return nil
+}
+
+// rollbackTo remove os blocos informados (acima do ancestral) do repositório,
+// do mais recente para o mais antigo, guardando-os na árvore de forks (deve ser chamado com lock)
+func (cm *ChainManager) rollbackTo(ctx context.Context, ancestor *entities.Block, blocks []*entities.Block) error {
+	for i := len(blocks) - 1; i >= 0; i-- {
+		block := blocks[i]
+
+		// O repositório indexa blocos pelo seu próprio hash
+		repoHash, err := cm.repository.GetBlockHash(ctx, block.GetIndex())
+		if err != nil {
+			return fmt.Errorf("failed to get block hash at index %d: %w", block.GetIndex(), err)
+		}
+
+		if err := cm.repository.DeleteBlock(ctx, repoHash); err != nil {
+			return fmt.Errorf("failed to remove block %d: %w", block.GetIndex(), err)
+		}
+		cm.unindexBlock(block)
+
+		cm.forkTree.Add(block, cm.calculateBlockHash(ctx, block))
 	}
-	
-	// Verificar se o bloco alternativo tem o mesmo hash anterior
-	if !alternativeBlock.GetPreviousHash().Equals(currentBlock.GetPreviousHash()) {
-		return fmt.Errorf("alternative block has different previous hash")
+
+	// Atualizar cache
+	cm.latestBlock = ancestor
+	cm.chainHeight = ancestor.GetIndex()
+
+	return nil
+}
+
+// GetTransactionLocation retorna onde a transação foi incluída na cadeia canônica
+func (cm *ChainManager) GetTransactionLocation(ctx context.Context, txHash valueobjects.Hash) (TransactionLocation, bool) {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	location, exists := cm.txIndex[txHash.String()]
+	return location, exists
+}
+
+// WaitForTransaction bloqueia até a transação ser incluída na cadeia canônica ou o contexto expirar
+func (cm *ChainManager) WaitForTransaction(ctx context.Context, txHash valueobjects.Hash) (TransactionLocation, error) {
+	key := txHash.String()
+
+	cm.mu.Lock()
+	if location, exists := cm.txIndex[key]; exists {
+		cm.mu.Unlock()
+		return location, nil
+	}
+	waiter := make(chan TransactionLocation, 1)
+	cm.txWaiters[key] = append(cm.txWaiters[key], waiter)
+	cm.mu.Unlock()
+
+	select {
+	case location := <-waiter:
+		return location, nil
+	case <-ctx.Done():
+		cm.mu.Lock()
+		waiters := cm.txWaiters[key]
+		for i, w := range waiters {
+			if w == waiter {
+				cm.txWaiters[key] = append(waiters[:i], waiters[i+1:]...)
+				break
+			}
+		}
+		if len(cm.txWaiters[key]) == 0 {
+			delete(cm.txWaiters, key)
+		}
+		cm.mu.Unlock()
+		return TransactionLocation{}, fmt.Errorf("transaction %s not included: %w", key, ctx.Err())
 	}
-	
-	// Comparar "peso" dos blocos (por simplicidade, usar timestamp)
-	// Em uma implementação real, seria baseado em dificuldade acumulada
-	if alternativeBlock.GetTimestamp().Before(currentBlock.GetTimestamp()) {
-		// Bloco alternativo é "melhor" (mais antigo = produzido primeiro)
-		
-		// Remover o bloco atual
-		currentBlockHash := cm.calculateBlockHash(ctx, currentBlock)
-		if err := cm.repository.DeleteBlock(ctx, currentBlockHash); err != nil {
-			return fmt.Errorf("failed to remove current block: %w", err)
+}
+
+// indexBlock indexa as transações de um bloco canônico e acorda quem as aguarda (deve ser chamado com lock)
+func (cm *ChainManager) indexBlock(ctx context.Context, block *entities.Block) {
+	blockHash := cm.calculateBlockHash(ctx, block)
+
+	for position, tx := range block.GetTransactions() {
+		key := tx.GetHash().String()
+		location := TransactionLocation{
+			BlockIndex: block.GetIndex(),
+			BlockHash:  blockHash,
+			Position:   position,
 		}
-		
-		// Adicionar o bloco alternativo
-		if err := cm.repository.SaveBlock(ctx, alternativeBlock); err != nil {
-			// Tentar restaurar o bloco original em caso de erro
-			cm.repository.SaveBlock(ctx, currentBlock)
-			return fmt.Errorf("failed to save alternative block: %w", err)
+		cm.txIndex[key] = location
+
+		for _, waiter := range cm.txWaiters[key] {
+			waiter <- location // Canal com buffer, um único envio por espera
+		}
+		delete(cm.txWaiters, key)
+	}
+}
+
+// unindexBlock remove do índice as transações de um bloco desfeito (deve ser chamado com lock)
+func (cm *ChainManager) unindexBlock(block *entities.Block) {
+	for _, tx := range block.GetTransactions() {
+		key := tx.GetHash().String()
+		if location, exists := cm.txIndex[key]; exists && location.BlockIndex == block.GetIndex() {
+			delete(cm.txIndex, key)
 		}
-		
-		// Atualizar cache
-		cm.latestBlock = alternativeBlock
-		
-		return nil
 	}
-	
-	// Bloco atual é melhor, não reorganizar
-	return fmt.Errorf("current block is better than alternative, no reorganization needed")
 }
 
 // GetBlockRange retorna uma faixa de blocos
@@ -482,95 +905,53 @@ func (cm *ChainManager) VerifyTransactionInclusion(ctx context.Context, blockHas
 
 // GetElectionFromBlockchain busca uma eleição específica na blockchain
 func (cm *ChainManager) GetElectionFromBlockchain(ctx context.Context, electionID valueobjects.Hash) (*entities.Election, error) {
-	cm.mu.RLock()
-	defer cm.mu.RUnlock()
-
-	height, err := cm.GetChainHeight(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get chain height: %w", err)
-	}
-
-	// Procurar nos blocos (do mais recente para o mais antigo)
-	for i := height; ; i-- {
-		block, err := cm.repository.GetBlockByIndex(ctx, i)
-		if err != nil {
-			if i == 0 {
-				break
-			}
-			continue
+	var found *entities.Election
+
+	// Procurar nos blocos com eleições (do mais recente para o mais antigo)
+	err := cm.ScanTransactions(ctx, entities.ElectionTransaction, nil, repositories.SortDescending, func(_ repositories.ChainPosition, tx *entities.Transaction) bool {
+		// Deserializar eleição
+		election := &entities.Election{}
+		if err := election.FromBytes(tx.GetData()); err != nil {
+			return true
 		}
 
-		// Verificar transações do tipo ELECTION
-		for _, tx := range block.GetTransactions() {
-			if tx.GetType() == entities.ElectionTransaction {
-				// Deserializar eleição
-				election := &entities.Election{}
-				if err := election.FromBytes(tx.GetData()); err != nil {
-					continue
-				}
-
-				// Verificar se é a eleição procurada
-				if election.GetID().Equals(electionID) {
-					return election, nil
-				}
-			}
+		// Verificar se é a eleição procurada
+		if election.GetID().Equals(electionID) {
+			found = election
+			return false
 		}
-
-		if i == 0 {
-			break
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		// Eleições registradas até a base do snapshot
+		if entry, exists := cm.GetBaseElection(electionID); exists {
+			return entry.Election, nil
 		}
+		return nil, fmt.Errorf("election not found in blockchain")
 	}
 
-	return nil, fmt.Errorf("election not found in blockchain")
+	return found, nil
 }
 
 // GetAllElectionsFromBlockchain retorna todas as eleições da blockchain
 func (cm *ChainManager) GetAllElectionsFromBlockchain(ctx context.Context) ([]*entities.Election, error) {
-	cm.mu.RLock()
-	defer cm.mu.RUnlock()
-
-	var elections []*entities.Election
-
-	height, err := cm.GetChainHeight(ctx)
+	page, err := cm.QueryElections(ctx, ElectionQuery{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get chain height: %w", err)
-	}
-
-	// Procurar em todos os blocos
-	for i := uint64(0); i <= height; i++ {
-		block, err := cm.repository.GetBlockByIndex(ctx, i)
-		if err != nil {
-			continue
-		}
-
-		// Extrair eleições
-		for _, tx := range block.GetTransactions() {
-			if tx.GetType() == entities.ElectionTransaction {
-				election := &entities.Election{}
-				if err := election.FromBytes(tx.GetData()); err != nil {
-					continue
-				}
-				elections = append(elections, election)
-			}
-		}
+		return nil, err
 	}
 
-	return elections, nil
+	return page.Elections, nil
 }
 
 // GetActiveElectionsFromBlockchain retorna eleições ativas da blockchain
 func (cm *ChainManager) GetActiveElectionsFromBlockchain(ctx context.Context) ([]*entities.Election, error) {
-	allElections, err := cm.GetAllElectionsFromBlockchain(ctx)
+	page, err := cm.QueryElections(ctx, ElectionQuery{ActiveOnly: true})
 	if err != nil {
 		return nil, err
 	}
 
-	var activeElections []*entities.Election
-	for _, election := range allElections {
-		if election.IsActive() {
-			activeElections = append(activeElections, election)
-		}
-	}
-
-	return activeElections, nil
+	return page.Elections, nil
 }
@@ -0,0 +1,269 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/persistence"
+)
+
+// failingRepository falha ao salvar um bloco específico (simula erro de disco durante a reorganização)
+type failingRepository struct {
+	repositories.BlockchainRepository
+	failOn *entities.Block
+}
+
+func (r *failingRepository) SaveBlock(ctx context.Context, block *entities.Block) error {
+	if block == r.failOn {
+		return errors.New("disk full")
+	}
+	return r.BlockchainRepository.SaveBlock(ctx, block)
+}
+
+// rotatingAuthority considera da vez o validador height % len(validators)
+type rotatingAuthority struct {
+	validators []valueobjects.NodeID
+}
+
+func (a *rotatingAuthority) VerifyBlockAuthority(ctx context.Context, block *entities.Block) error {
+	return nil
+}
+
+func (a *rotatingAuthority) IsInTurn(ctx context.Context, validator valueobjects.NodeID, height uint64) bool {
+	return a.validators[height%uint64(len(a.validators))].Equals(validator)
+}
+
+// testChain é uma cadeia em memória com dois validadores alternando a vez (A nas alturas pares)
+type testChain struct {
+	cryptoService services.CryptographyService
+	repository    *failingRepository
+	chain         *ChainManager
+	nodeIDs       []valueobjects.NodeID
+	keyPairs      []*services.KeyPair
+	genesis       *entities.Block
+	reorgs        []*ReorgEvent
+	nonce         byte
+}
+
+func newTestChain(t *testing.T) *testChain {
+	t.Helper()
+	ctx := context.Background()
+
+	cryptoService := crypto.NewECDSAService()
+	repository := &failingRepository{BlockchainRepository: persistence.NewMemoryBlockchainRepository(cryptoService)}
+	c := &testChain{
+		cryptoService: cryptoService,
+		repository:    repository,
+		chain:         NewChainManager(repository, cryptoService),
+	}
+
+	for i := 0; i < 2; i++ {
+		keyPair, err := cryptoService.GenerateKeyPair(ctx)
+		if err != nil {
+			t.Fatalf("generate key pair: %v", err)
+		}
+		c.keyPairs = append(c.keyPairs, keyPair)
+		c.nodeIDs = append(c.nodeIDs, cryptoService.GenerateNodeID(ctx, keyPair.PublicKey))
+	}
+
+	c.chain.SetBlockAuthority(&rotatingAuthority{validators: c.nodeIDs})
+	c.chain.AddReorgListener(func(ctx context.Context, event *ReorgEvent) {
+		c.reorgs = append(c.reorgs, event)
+	})
+
+	if err := c.chain.CreateGenesisBlock(ctx, []*entities.Transaction{c.transaction(0)}, c.nodeIDs[0], c.keyPairs[0].PrivateKey); err != nil {
+		t.Fatalf("create genesis block: %v", err)
+	}
+	genesis, err := c.chain.GetBlockByIndex(ctx, 0)
+	if err != nil {
+		t.Fatalf("get genesis block: %v", err)
+	}
+	c.genesis = genesis
+
+	return c
+}
+
+// transaction cria uma transação única do validador i
+func (c *testChain) transaction(i int) *entities.Transaction {
+	c.nonce++
+	tx := entities.NewTransaction(entities.VoteTransaction, c.nodeIDs[i], c.nodeIDs[i], []byte{c.nonce})
+	tx.SetHash(c.cryptoService.HashTransaction(context.Background(), tx.ToBytes()))
+	return tx
+}
+
+// block cria um bloco filho de parent assinado pelo validador i, sem adicioná-lo à cadeia
+func (c *testChain) block(t *testing.T, parent *entities.Block, i int) *entities.Block {
+	t.Helper()
+	ctx := context.Background()
+
+	block, err := c.chain.blockBuilder.BuildBlock(ctx, parent.GetIndex()+1, c.chain.CalculateBlockHash(ctx, parent),
+		[]*entities.Transaction{c.transaction(i)}, c.nodeIDs[i])
+	if err != nil {
+		t.Fatalf("build block: %v", err)
+	}
+	if err := c.chain.blockBuilder.SignBlock(ctx, block, c.keyPairs[i].PrivateKey); err != nil {
+		t.Fatalf("sign block: %v", err)
+	}
+	return block
+}
+
+// extend adiciona à cadeia canônica um bloco assinado pelo validador i
+func (c *testChain) extend(t *testing.T, parent *entities.Block, i int) *entities.Block {
+	t.Helper()
+
+	block := c.block(t, parent, i)
+	if err := c.chain.AddBlock(context.Background(), block); err != nil {
+		t.Fatalf("add block: %v", err)
+	}
+	return block
+}
+
+// requireTip verifica o topo da cadeia canônica
+func (c *testChain) requireTip(t *testing.T, want *entities.Block) {
+	t.Helper()
+	ctx := context.Background()
+
+	tip, err := c.chain.GetLatestBlock(ctx)
+	if err != nil {
+		t.Fatalf("get latest block: %v", err)
+	}
+	if !c.chain.CalculateBlockHash(ctx, tip).Equals(c.chain.CalculateBlockHash(ctx, want)) {
+		t.Fatalf("tip = block %d %s, want block %d %s", tip.GetIndex(), c.chain.CalculateBlockHash(ctx, tip).String(),
+			want.GetIndex(), c.chain.CalculateBlockHash(ctx, want).String())
+	}
+}
+
+func TestForkChoicePrefersInTurnBranch(t *testing.T) {
+	c := newTestChain(t)
+	ctx := context.Background()
+
+	// Altura 1 é a vez de B; o bloco canônico é de A
+	outOfTurn := c.extend(t, c.genesis, 0)
+	inTurn := c.block(t, c.genesis, 1)
+
+	if err := c.chain.ProcessBlock(ctx, inTurn); err != nil {
+		t.Fatalf("ProcessBlock: %v", err)
+	}
+	c.requireTip(t, inTurn)
+
+	if len(c.reorgs) != 1 {
+		t.Fatalf("reorg events = %d, want 1", len(c.reorgs))
+	}
+	event := c.reorgs[0]
+	if event.CommonAncestor != 0 || len(event.Removed) != 1 || event.Removed[0] != outOfTurn || len(event.Added) != 1 || event.Added[0] != inTurn {
+		t.Errorf("reorg event = %+v, want %d replaced by the in-turn block", event, outOfTurn.GetIndex())
+	}
+
+	// Transações do bloco desfeito saem do índice
+	if _, found := c.chain.GetTransactionLocation(ctx, outOfTurn.GetTransactions()[0].GetHash()); found {
+		t.Errorf("transaction of removed block still indexed")
+	}
+	if location, found := c.chain.GetTransactionLocation(ctx, inTurn.GetTransactions()[0].GetHash()); !found || location.BlockIndex != 1 {
+		t.Errorf("transaction of added block location = %+v, %v", location, found)
+	}
+}
+
+func TestForkChoiceKeepsCanonicalBranchWhenNotBetter(t *testing.T) {
+	c := newTestChain(t)
+	ctx := context.Background()
+
+	// Canônica: B na altura 1 (da vez) e A na altura 2 (da vez)
+	first := c.extend(t, c.genesis, 1)
+	second := c.extend(t, first, 0)
+
+	// Ramificação mais curta e com menos blocos da vez
+	fork := c.block(t, c.genesis, 0)
+	if err := c.chain.ProcessBlock(ctx, fork); err != nil {
+		t.Fatalf("ProcessBlock: %v", err)
+	}
+
+	c.requireTip(t, second)
+	if len(c.reorgs) != 0 {
+		t.Errorf("unexpected reorg: %+v", c.reorgs[0])
+	}
+}
+
+func TestForkChoicePrefersLongerBranchOnInTurnTie(t *testing.T) {
+	c := newTestChain(t)
+	ctx := context.Background()
+
+	// Canônica: um bloco fora da vez; ramificação: dois blocos, também nenhum da vez
+	c.extend(t, c.genesis, 0)
+	forkFirst := c.block(t, c.genesis, 0)
+	forkSecond := c.block(t, forkFirst, 1)
+
+	for _, block := range []*entities.Block{forkFirst, forkSecond} {
+		if err := c.chain.ProcessBlock(ctx, block); err != nil {
+			t.Fatalf("ProcessBlock(%d): %v", block.GetIndex(), err)
+		}
+	}
+
+	c.requireTip(t, forkSecond)
+}
+
+func TestForkChoiceRejectsForksBelowFinality(t *testing.T) {
+	c := newTestChain(t)
+	ctx := context.Background()
+
+	first := c.extend(t, c.genesis, 0)
+	if err := c.chain.MarkFinalized(ctx, 1, c.chain.CalculateBlockHash(ctx, first)); err != nil {
+		t.Fatalf("MarkFinalized: %v", err)
+	}
+
+	if err := c.chain.ProcessBlock(ctx, c.block(t, c.genesis, 1)); err == nil {
+		t.Fatalf("fork below the finalized height accepted")
+	}
+	c.requireTip(t, first)
+}
+
+func TestReorgRestoresOriginalChainWhenNewBranchFails(t *testing.T) {
+	c := newTestChain(t)
+	ctx := context.Background()
+
+	// Canônica sem blocos da vez: A na altura 1 e B na altura 2
+	first := c.extend(t, c.genesis, 0)
+	second := c.extend(t, first, 1)
+
+	// Ramificação com um bloco da vez na altura 2, cujo salvamento falha no meio da reorganização
+	forkFirst := c.block(t, c.genesis, 0)
+	forkSecond := c.block(t, forkFirst, 0)
+	c.repository.failOn = forkSecond
+
+	if err := c.chain.ProcessBlock(ctx, forkFirst); err != nil {
+		t.Fatalf("ProcessBlock(fork 1): %v", err)
+	}
+	if err := c.chain.ProcessBlock(ctx, forkSecond); err == nil {
+		t.Fatalf("reorg with a failing block succeeded")
+	}
+
+	c.requireTip(t, second)
+	for index, want := range []*entities.Block{c.genesis, first, second} {
+		block, err := c.chain.GetBlockByIndex(ctx, uint64(index))
+		if err != nil || !c.chain.CalculateBlockHash(ctx, block).Equals(c.chain.CalculateBlockHash(ctx, want)) {
+			t.Fatalf("block %d not restored (err: %v)", index, err)
+		}
+	}
+	if location, found := c.chain.GetTransactionLocation(ctx, first.GetTransactions()[0].GetHash()); !found || location.BlockIndex != 1 {
+		t.Errorf("restored block transaction location = %+v, %v", location, found)
+	}
+	if _, found := c.chain.GetTransactionLocation(ctx, forkFirst.GetTransactions()[0].GetHash()); found {
+		t.Errorf("transaction of rolled back fork block still indexed")
+	}
+	if len(c.reorgs) != 0 {
+		t.Errorf("failed reorg notified listeners")
+	}
+
+	// Sem a falha, a mesma ramificação vence ao ser reavaliada por um novo bloco
+	c.repository.failOn = nil
+	forkThird := c.block(t, forkSecond, 1)
+	if err := c.chain.ProcessBlock(ctx, forkThird); err != nil {
+		t.Fatalf("ProcessBlock(fork 3): %v", err)
+	}
+	c.requireTip(t, forkThird)
+}
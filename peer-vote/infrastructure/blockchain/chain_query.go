@@ -0,0 +1,186 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// scanBatchSize é o número de blocos lidos do repositório por consulta durante varreduras
+const scanBatchSize = 256
+
+// TransactionVisitor recebe cada transação encontrada por ScanTransactions.
+// Retornar false interrompe a varredura.
+type TransactionVisitor func(position repositories.ChainPosition, tx *entities.Transaction) bool
+
+// ElectionQuery filtra e pagina as eleições registradas na cadeia
+type ElectionQuery struct {
+	ActiveOnly bool                        // Apenas eleições em votação (Election.IsActive)
+	Status     entities.ElectionStatus     // Status efetivo (Election.EffectiveStatus)
+	CreatedBy  valueobjects.NodeID         // Apenas eleições deste criador
+	From       valueobjects.Timestamp      // Período de votação termina após From
+	To         valueobjects.Timestamp      // Período de votação começa antes de To
+	After      *repositories.ChainPosition // Cursor: posição da última eleição da página anterior
+	Order      repositories.SortOrder      // Padrão: ordem da cadeia
+	Limit      int                         // 0 = sem limite
+}
+
+// Matches verifica se a eleição atende aos filtros da consulta
+func (q ElectionQuery) Matches(election *entities.Election, now valueobjects.Timestamp) bool {
+	if q.ActiveOnly && !election.IsActive() {
+		return false
+	}
+	if q.Status != "" && election.EffectiveStatus(now) != q.Status {
+		return false
+	}
+	if !q.CreatedBy.IsEmpty() && !election.GetCreatedBy().Equals(q.CreatedBy) {
+		return false
+	}
+	if !q.From.IsZero() && election.GetEndTime().Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && election.GetStartTime().After(q.To) {
+		return false
+	}
+	return true
+}
+
+// ElectionPage é uma página do resultado de QueryElections
+type ElectionPage struct {
+	Elections []*entities.Election
+	// NextCursor é a posição da última eleição da página quando há mais resultados
+	NextCursor *repositories.ChainPosition
+}
+
+// QueryBlocks consulta blocos da cadeia canônica (ver repositories.BlockQuery)
+func (cm *ChainManager) QueryBlocks(ctx context.Context, query repositories.BlockQuery) (*repositories.BlockPage, error) {
+	return cm.repository.QueryBlocks(ctx, query)
+}
+
+// ScanTransactions percorre as transações do tipo txType na ordem pedida, a partir da
+// posição after (exclusive). Apenas os blocos que contêm o tipo são lidos, pelo índice
+// do repositório. Em uma cadeia iniciada por snapshot, as transações até a base (inclusive
+// as do bloco âncora) já estão resumidas na ChainBase e não são visitadas.
+func (cm *ChainManager) ScanTransactions(ctx context.Context, txType entities.TransactionType, after *repositories.ChainPosition, order repositories.SortOrder, visit TransactionVisitor) error {
+	_, hasBase := cm.GetBase()
+	baseHeight := cm.GetBaseHeight()
+
+	query := repositories.BlockQuery{
+		TxType: txType,
+		Order:  order,
+		Limit:  scanBatchSize,
+	}
+	if after != nil {
+		height := after.Height
+		if order == repositories.SortDescending {
+			query.ToHeight = &height
+		} else {
+			query.FromHeight = &height
+		}
+	}
+
+	for {
+		page, err := cm.repository.QueryBlocks(ctx, query)
+		if err != nil {
+			return fmt.Errorf("failed to query blocks: %w", err)
+		}
+
+		for _, block := range page.Blocks {
+			if hasBase && block.GetIndex() <= baseHeight {
+				continue
+			}
+
+			transactions := block.GetTransactions()
+			for i := range transactions {
+				position := i
+				if order == repositories.SortDescending {
+					position = len(transactions) - 1 - i
+				}
+
+				tx := transactions[position]
+				if tx.GetType() != txType {
+					continue
+				}
+
+				chainPosition := repositories.ChainPosition{Height: block.GetIndex(), Index: position}
+				if after != nil && !chainPosition.Follows(*after, order) {
+					continue
+				}
+				if !visit(chainPosition, tx) {
+					return nil
+				}
+			}
+		}
+
+		if !page.HasMore || len(page.Blocks) == 0 {
+			return nil
+		}
+		last := page.Blocks[len(page.Blocks)-1].GetIndex()
+		query.After = &last
+	}
+}
+
+// QueryElections consulta as eleições registradas na cadeia com filtros, ordem e cursor
+func (cm *ChainManager) QueryElections(ctx context.Context, query ElectionQuery) (*ElectionPage, error) {
+	if query.Limit < 0 {
+		return nil, fmt.Errorf("limit cannot be negative")
+	}
+
+	now := valueobjects.Now()
+	page := &ElectionPage{Elections: make([]*entities.Election, 0)}
+	var last repositories.ChainPosition
+
+	// add inclui a eleição na página; retorna false quando a página está completa
+	add := func(position repositories.ChainPosition, election *entities.Election) bool {
+		if !query.Matches(election, now) {
+			return true
+		}
+
+		if query.Limit > 0 && len(page.Elections) == query.Limit {
+			page.NextCursor = &last
+			return false
+		}
+		page.Elections = append(page.Elections, election)
+		last = position
+		return true
+	}
+
+	// Eleições da base do snapshot vêm antes das registradas nos blocos locais
+	baseElections := cm.baseElections(query.After, query.Order)
+	if query.Order != repositories.SortDescending {
+		for _, entry := range baseElections {
+			if !add(entry.Position, entry.Election) {
+				return page, nil
+			}
+		}
+	}
+
+	complete := false
+	err := cm.ScanTransactions(ctx, entities.ElectionTransaction, query.After, query.Order, func(position repositories.ChainPosition, tx *entities.Transaction) bool {
+		election := &entities.Election{}
+		if err := election.FromBytes(tx.GetData()); err != nil {
+			return true
+		}
+		if !add(position, election) {
+			complete = true
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Order == repositories.SortDescending && !complete {
+		for _, entry := range baseElections {
+			if !add(entry.Position, entry.Election) {
+				break
+			}
+		}
+	}
+
+	return page, nil
+}
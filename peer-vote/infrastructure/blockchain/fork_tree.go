@@ -0,0 +1,194 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// BlockAuthority fornece as regras de autoridade PoA necessárias para avaliar forks
+// Aplica DIP: o ChainManager depende desta abstração, não do gerenciador de validadores
+type BlockAuthority interface {
+	// VerifyBlockAuthority verifica se o bloco foi assinado por um validador autorizado na sua altura
+	VerifyBlockAuthority(ctx context.Context, block *entities.Block) error
+
+	// IsInTurn verifica se o validador era o validador da vez na altura informada
+	IsInTurn(ctx context.Context, validator valueobjects.NodeID, height uint64) bool
+}
+
+// ReorgEvent descreve uma reorganização da cadeia
+type ReorgEvent struct {
+	CommonAncestor uint64            // Índice do último bloco comum às duas ramificações
+	OldTip         valueobjects.Hash // Topo da cadeia antes da reorganização
+	NewTip         valueobjects.Hash // Topo da cadeia após a reorganização
+	Removed        []*entities.Block // Blocos desfeitos (em ordem crescente de índice)
+	Added          []*entities.Block // Blocos aplicados (em ordem crescente de índice)
+}
+
+// ReorgListener é notificado após cada reorganização (índices, apurações, mempool)
+type ReorgListener func(ctx context.Context, event *ReorgEvent)
+
+// forkNode representa um bloco de uma ramificação não canônica
+type forkNode struct {
+	block      *entities.Block
+	hash       valueobjects.Hash
+	parentHash string
+}
+
+// ForkTree mantém blocos de ramificações concorrentes que não estão na cadeia canônica
+type ForkTree struct {
+	nodes    map[string]*forkNode   // hash -> nó
+	children map[string][]string    // hash do pai -> hashes dos filhos
+	byIndex  map[uint64][]string    // índice -> hashes (para poda)
+}
+
+// NewForkTree cria uma nova árvore de forks vazia
+func NewForkTree() *ForkTree {
+	return &ForkTree{
+		nodes:    make(map[string]*forkNode),
+		children: make(map[string][]string),
+		byIndex:  make(map[uint64][]string),
+	}
+}
+
+// Add adiciona um bloco à árvore (ignorado se já existir)
+func (ft *ForkTree) Add(block *entities.Block, hash valueobjects.Hash) {
+	hashStr := hash.String()
+	if _, exists := ft.nodes[hashStr]; exists {
+		return
+	}
+
+	parentHash := block.GetPreviousHash().String()
+	ft.nodes[hashStr] = &forkNode{block: block, hash: hash, parentHash: parentHash}
+	ft.children[parentHash] = append(ft.children[parentHash], hashStr)
+	ft.byIndex[block.GetIndex()] = append(ft.byIndex[block.GetIndex()], hashStr)
+}
+
+// Contains verifica se um bloco está na árvore
+func (ft *ForkTree) Contains(hash valueobjects.Hash) bool {
+	_, exists := ft.nodes[hash.String()]
+	return exists
+}
+
+// Get retorna um bloco da árvore
+func (ft *ForkTree) Get(hash valueobjects.Hash) (*entities.Block, bool) {
+	node, exists := ft.nodes[hash.String()]
+	if !exists {
+		return nil, false
+	}
+	return node.block, true
+}
+
+// Remove remove um bloco da árvore (sem remover descendentes)
+func (ft *ForkTree) Remove(hash valueobjects.Hash) {
+	hashStr := hash.String()
+	node, exists := ft.nodes[hashStr]
+	if !exists {
+		return
+	}
+
+	delete(ft.nodes, hashStr)
+	ft.children[node.parentHash] = removeHash(ft.children[node.parentHash], hashStr)
+	if len(ft.children[node.parentHash]) == 0 {
+		delete(ft.children, node.parentHash)
+	}
+
+	index := node.block.GetIndex()
+	ft.byIndex[index] = removeHash(ft.byIndex[index], hashStr)
+	if len(ft.byIndex[index]) == 0 {
+		delete(ft.byIndex, index)
+	}
+}
+
+// Branch retorna a ramificação que termina em tipHash, do bloco mais antigo ao topo.
+// A ramificação para no primeiro ancestral que não está na árvore; o hash desse
+// ancestral é retornado para que o chamador o localize na cadeia canônica.
+func (ft *ForkTree) Branch(tipHash valueobjects.Hash) ([]*entities.Block, []valueobjects.Hash, valueobjects.Hash) {
+	var blocks []*entities.Block
+	var hashes []valueobjects.Hash
+
+	current := tipHash
+	for {
+		node, exists := ft.nodes[current.String()]
+		if !exists {
+			break
+		}
+		blocks = append([]*entities.Block{node.block}, blocks...)
+		hashes = append([]valueobjects.Hash{node.hash}, hashes...)
+		current = node.block.GetPreviousHash()
+	}
+
+	return blocks, hashes, current
+}
+
+// Tips retorna os blocos da árvore que não possuem filhos (topos das ramificações)
+// descendentes do bloco informado, incluindo ele próprio se não tiver filhos
+func (ft *ForkTree) Tips(fromHash valueobjects.Hash) []valueobjects.Hash {
+	var tips []valueobjects.Hash
+
+	stack := []string{fromHash.String()}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		children := ft.children[current]
+		if len(children) == 0 {
+			if node, exists := ft.nodes[current]; exists {
+				tips = append(tips, node.hash)
+			}
+			continue
+		}
+		stack = append(stack, children...)
+	}
+
+	return tips
+}
+
+// PruneBelow remove blocos com índice menor ou igual ao informado
+func (ft *ForkTree) PruneBelow(index uint64) {
+	for blockIndex, hashes := range ft.byIndex {
+		if blockIndex > index {
+			continue
+		}
+		for _, hashStr := range append([]string(nil), hashes...) {
+			if node, exists := ft.nodes[hashStr]; exists {
+				ft.Remove(node.hash)
+			}
+		}
+	}
+}
+
+// Size retorna o número de blocos na árvore
+func (ft *ForkTree) Size() int {
+	return len(ft.nodes)
+}
+
+// removeHash remove um hash de uma lista
+func removeHash(hashes []string, target string) []string {
+	for i, hash := range hashes {
+		if hash == target {
+			return append(hashes[:i], hashes[i+1:]...)
+		}
+	}
+	return hashes
+}
+
+// branchScore resume o peso de uma ramificação para a regra de escolha de fork
+type branchScore struct {
+	inTurn  int               // Blocos assinados pelo validador da vez
+	length  int               // Número de blocos após o ancestral comum
+	tipHash valueobjects.Hash // Hash do topo (desempate)
+}
+
+// betterThan aplica a regra de escolha de fork PoA: mais blocos in-turn,
+// depois ramificação mais longa e, por fim, menor hash do topo
+func (s branchScore) betterThan(other branchScore) bool {
+	if s.inTurn != other.inTurn {
+		return s.inTurn > other.inTurn
+	}
+	if s.length != other.length {
+		return s.length > other.length
+	}
+	return s.tipHash.String() < other.tipHash.String()
+}
@@ -58,6 +58,26 @@ func NewMerkleTree(data [][]byte) (*MerkleTree, error) {
 	}, nil
 }
 
+// MerkleRootFromHashes calcula a raiz a partir dos hashes das folhas (hashes das transações).
+// Equivale à raiz de NewMerkleTree quando os hashes são SHA-256 dos dados.
+func MerkleRootFromHashes(hashes []valueobjects.Hash) (valueobjects.Hash, error) {
+	if len(hashes) == 0 {
+		return valueobjects.EmptyHash(), errors.New("cannot calculate merkle root without hashes")
+	}
+
+	leaves := make([]*MerkleNode, len(hashes))
+	for i, hash := range hashes {
+		leaves[i] = &MerkleNode{Hash: hash}
+	}
+
+	root, err := buildTree(leaves)
+	if err != nil {
+		return valueobjects.EmptyHash(), fmt.Errorf("failed to build merkle tree: %w", err)
+	}
+
+	return root.Hash, nil
+}
+
 // buildTree constrói a árvore recursivamente
 func buildTree(nodes []*MerkleNode) (*MerkleNode, error) {
 	if len(nodes) == 0 {
@@ -0,0 +1,148 @@
+package certification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+)
+
+// Identificação do formato do certificado de resultado
+const (
+	Format  = "peer-vote-result-certificate"
+	Version = 1
+)
+
+// Certificate é o registro à prova de adulteração do resultado de uma eleição: o documento
+// canônico, as assinaturas dos validadores gravadas na cadeia e o conjunto de validadores
+// vigente no topo apurado, com o qual as assinaturas são conferidas sem acesso à rede.
+type Certificate struct {
+	Format       string                   `json:"format"`
+	Version      int                      `json:"version"`
+	ElectionID   string                   `json:"election_id"`
+	DocumentHash string                   `json:"document_hash"`
+	Document     *entities.ResultDocument `json:"document"`
+	Proposer     string                   `json:"proposer"`
+	ProposalTx   string                   `json:"proposal_tx"`
+	ProposedAt   uint64                   `json:"proposed_at"` // Altura do bloco com a proposta
+	Validators   []archive.ValidatorKey   `json:"validators"`  // Conjunto vigente em Document.HeadHeight
+	Required     int                      `json:"required"`    // Assinaturas necessárias (mais de 2/3)
+	Signatures   []Signature              `json:"signatures"`
+	Certified    bool                     `json:"certified"`
+	CertifiedAt  uint64                   `json:"certified_at,omitempty"` // Altura em que o limiar foi atingido
+}
+
+// Signature é a assinatura de um validador sobre o documento e a transação que a registrou
+type Signature struct {
+	Validator string `json:"validator"`
+	Signature string `json:"signature"`
+	TxHash    string `json:"tx_hash"`
+	Height    uint64 `json:"height"`
+}
+
+// RequiredSignatures retorna quantas assinaturas de um conjunto de validadores certificam
+// um resultado: mais de 2/3, o mesmo limiar dos snapshots de estado e do consenso BFT
+func RequiredSignatures(validators int) int {
+	return validators*2/3 + 1
+}
+
+// HashDocument calcula o hash da serialização canônica do documento
+func HashDocument(ctx context.Context, cryptoService services.CryptographyService, document *entities.ResultDocument) (valueobjects.Hash, error) {
+	data, err := document.CanonicalBytes()
+	if err != nil {
+		return valueobjects.EmptyHash(), fmt.Errorf("failed to serialize result document: %w", err)
+	}
+	return cryptoService.Hash(ctx, data), nil
+}
+
+// NewProposalTransaction assina um documento de resultado e o empacota em uma transação CERTIFICATE
+func NewProposalTransaction(ctx context.Context, cryptoService services.CryptographyService, document *entities.ResultDocument, signer valueobjects.NodeID, privateKey *services.PrivateKey) (*entities.Transaction, valueobjects.Hash, error) {
+	documentHash, err := HashDocument(ctx, cryptoService, document)
+	if err != nil {
+		return nil, valueobjects.EmptyHash(), err
+	}
+
+	tx, err := newCertificateTransaction(ctx, cryptoService, &entities.CertificateTxData{
+		Kind:         entities.CertificateTxProposal,
+		ElectionID:   document.ElectionID,
+		DocumentHash: documentHash.String(),
+		Document:     document,
+	}, signer, privateKey)
+	if err != nil {
+		return nil, valueobjects.EmptyHash(), err
+	}
+
+	return tx, documentHash, nil
+}
+
+// NewEndorsementTransaction assina um documento já proposto e empacota a co-assinatura
+func NewEndorsementTransaction(ctx context.Context, cryptoService services.CryptographyService, electionID, documentHash string, signer valueobjects.NodeID, privateKey *services.PrivateKey) (*entities.Transaction, error) {
+	return newCertificateTransaction(ctx, cryptoService, &entities.CertificateTxData{
+		Kind:         entities.CertificateTxEndorsement,
+		ElectionID:   electionID,
+		DocumentHash: documentHash,
+	}, signer, privateKey)
+}
+
+// newCertificateTransaction assina o documento e cria a transação CERTIFICATE com hash e assinatura
+func newCertificateTransaction(ctx context.Context, cryptoService services.CryptographyService, txData *entities.CertificateTxData, signer valueobjects.NodeID, privateKey *services.PrivateKey) (*entities.Transaction, error) {
+	signature, err := cryptoService.Sign(ctx, entities.CertificateSigningBytes(txData.ElectionID, txData.DocumentHash), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign result document: %w", err)
+	}
+
+	txData.Signer = signer.String()
+	txData.Timestamp = time.Now().Unix()
+	txData.Signature = signature.String()
+
+	data, err := json.Marshal(txData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize certificate transaction: %w", err)
+	}
+
+	tx := entities.NewTransaction(entities.CertificateTransaction, signer, signer, data)
+	txHash := cryptoService.HashTransaction(ctx, data)
+	tx.SetHash(txHash)
+	tx.SetID(txHash)
+
+	txSignature, err := cryptoService.Sign(ctx, data, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	tx.SetSignature(txSignature)
+
+	return tx, nil
+}
+
+// Read lê um certificado e confere o formato e a versão
+func Read(r io.Reader) (*Certificate, error) {
+	var certificate Certificate
+	if err := json.NewDecoder(r).Decode(&certificate); err != nil {
+		return nil, fmt.Errorf("invalid result certificate: %w", err)
+	}
+
+	if certificate.Format != Format {
+		return nil, fmt.Errorf("unknown certificate format %q (expected %q)", certificate.Format, Format)
+	}
+	if certificate.Version != Version {
+		return nil, fmt.Errorf("unsupported certificate version %d (expected %d)", certificate.Version, Version)
+	}
+	if certificate.Document == nil {
+		return nil, fmt.Errorf("result certificate has no document")
+	}
+
+	return &certificate, nil
+}
+
+// Write grava o certificado como JSON indentado
+func Write(w io.Writer, certificate *Certificate) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(certificate)
+}
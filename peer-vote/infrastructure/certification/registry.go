@@ -0,0 +1,420 @@
+package certification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// Proposal identifica um documento de resultado recém-proposto na cadeia
+type Proposal struct {
+	ElectionID   string
+	DocumentHash string
+	Document     *entities.ResultDocument
+	Proposer     string
+	Height       uint64
+}
+
+// ProposalHandler é notificado de propostas em blocos novos (não em reconstruções),
+// para que o nó recalcule o documento e o co-assine
+type ProposalHandler func(ctx context.Context, proposal Proposal)
+
+// Registry acompanha os certificados de resultado gravados na cadeia canônica.
+// Propostas e co-assinaturas são transações CERTIFICATE; um documento é certificado
+// quando assinado por mais de 2/3 dos validadores vigentes no topo que ele apura.
+// O estado é derivado apenas dos blocos canônicos e reconstruído após reorganizações.
+// Nós iniciados a partir de um snapshot só conhecem certificados gravados após a base.
+type Registry struct {
+	validatorManager *consensus.ValidatorManager
+	chainManager     *blockchain.ChainManager
+	cryptoService    services.CryptographyService
+	logger           *slog.Logger
+
+	elections map[string]*electionRecord
+
+	// Última altura processada
+	processedHeight uint64
+	hasProcessed    bool
+
+	proposalHandler ProposalHandler
+
+	// Mutex para operações thread-safe
+	mu sync.Mutex
+}
+
+// electionRecord reúne os documentos propostos para uma eleição
+type electionRecord struct {
+	documents []*documentRecord // Em ordem de inclusão
+	certified *documentRecord   // Primeiro documento a atingir o limiar
+}
+
+// documentRecord é um documento proposto e suas assinaturas
+type documentRecord struct {
+	hash        string
+	document    *entities.ResultDocument
+	proposer    string
+	proposalTx  string
+	proposedAt  uint64
+	electorate  map[string]*services.PublicKey // Validadores vigentes em document.HeadHeight
+	signatures  []Signature
+	signed      map[string]bool
+	certified   bool
+	certifiedAt uint64
+}
+
+// NewRegistry cria o registro de certificados e o registra na cadeia
+func NewRegistry(
+	validatorManager *consensus.ValidatorManager,
+	chainManager *blockchain.ChainManager,
+	cryptoService services.CryptographyService,
+) *Registry {
+	registry := &Registry{
+		validatorManager: validatorManager,
+		chainManager:     chainManager,
+		cryptoService:    cryptoService,
+		logger:           logging.Component("certification"),
+		elections:        make(map[string]*electionRecord),
+	}
+
+	chainManager.AddBlockListener(registry.handleBlock)
+	chainManager.AddReorgListener(registry.handleReorg)
+
+	return registry
+}
+
+// SetLogger define o logger do registro
+func (r *Registry) SetLogger(logger *slog.Logger) {
+	r.logger = logger
+}
+
+// SetProposalHandler define quem é notificado de novas propostas
+func (r *Registry) SetProposalHandler(handler ProposalHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.proposalHandler = handler
+}
+
+// Rebuild reconstrói os certificados reprocessando a cadeia canônica desde o gênesis (ou a base)
+func (r *Registry) Rebuild(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.rebuild(ctx)
+}
+
+// GetCertificate retorna o certificado da eleição: o documento certificado ou, enquanto
+// nenhum atingiu o limiar, o proposto mais recentemente com as assinaturas coletadas
+func (r *Registry) GetCertificate(electionID string) (*Certificate, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, exists := r.elections[electionID]
+	if !exists || len(record.documents) == 0 {
+		return nil, false
+	}
+
+	document := record.certified
+	if document == nil {
+		document = record.documents[len(record.documents)-1]
+	}
+	return document.certificate(), true
+}
+
+// FindDocument retorna o certificado de um documento específico de uma eleição
+func (r *Registry) FindDocument(electionID, documentHash string) (*Certificate, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, exists := r.elections[electionID]
+	if !exists {
+		return nil, false
+	}
+	for _, document := range record.documents {
+		if document.hash == documentHash {
+			return document.certificate(), true
+		}
+	}
+	return nil, false
+}
+
+// certificate converte o registro no certificado servido e verificado offline
+func (d *documentRecord) certificate() *Certificate {
+	signatures := make([]Signature, len(d.signatures))
+	copy(signatures, d.signatures)
+
+	return &Certificate{
+		Format:       Format,
+		Version:      Version,
+		ElectionID:   d.document.ElectionID,
+		DocumentHash: d.hash,
+		Document:     d.document,
+		Proposer:     d.proposer,
+		ProposalTx:   d.proposalTx,
+		ProposedAt:   d.proposedAt,
+		Validators:   archive.NewValidatorKeys(d.electorate),
+		Required:     RequiredSignatures(len(d.electorate)),
+		Signatures:   signatures,
+		Certified:    d.certified,
+		CertifiedAt:  d.certifiedAt,
+	}
+}
+
+// handleBlock processa um bloco aplicado à cadeia canônica
+func (r *Registry) handleBlock(ctx context.Context, block *entities.Block) {
+	r.mu.Lock()
+
+	var proposals []Proposal
+	index := block.GetIndex()
+
+	switch {
+	case !r.hasProcessed && index == 0, r.hasProcessed && index == r.processedHeight+1:
+		proposals = r.processBlock(ctx, block)
+	case r.hasProcessed && index <= r.processedHeight:
+		// Bloco já processado (ou parte de uma reorganização, tratada pelo listener de reorg)
+	default:
+		if err := r.rebuild(ctx); err != nil {
+			r.logger.ErrorContext(ctx, "failed to rebuild result certificates", logging.Err(err))
+		}
+	}
+
+	handler := r.proposalHandler
+	r.mu.Unlock()
+
+	if handler != nil {
+		for _, proposal := range proposals {
+			handler(ctx, proposal)
+		}
+	}
+}
+
+// handleReorg reconstrói os certificados após uma reorganização
+func (r *Registry) handleReorg(ctx context.Context, event *blockchain.ReorgEvent) {
+	if err := r.Rebuild(ctx); err != nil {
+		r.logger.ErrorContext(ctx, "failed to rebuild result certificates after reorg", logging.Err(err))
+	}
+}
+
+// rebuild reprocessa a cadeia canônica (deve ser chamado com lock)
+func (r *Registry) rebuild(ctx context.Context) error {
+	r.elections = make(map[string]*electionRecord)
+	r.hasProcessed = false
+	r.processedHeight = 0
+
+	if _, err := r.chainManager.GetLatestBlock(ctx); err != nil {
+		// Cadeia ainda vazia
+		return nil
+	}
+	height, err := r.chainManager.GetChainHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain height: %w", err)
+	}
+
+	start := uint64(0)
+	if _, hasBase := r.chainManager.GetBase(); hasBase {
+		r.processedHeight = r.chainManager.GetBaseHeight()
+		r.hasProcessed = true
+		start = r.processedHeight + 1
+	}
+
+	for index := start; index <= height; index++ {
+		block, err := r.chainManager.GetBlockByIndex(ctx, index)
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", index, err)
+		}
+		r.processBlock(ctx, block)
+	}
+
+	return nil
+}
+
+// processBlock aplica as transações de certificação de um bloco e retorna as novas
+// propostas aceitas (deve ser chamado com lock)
+func (r *Registry) processBlock(ctx context.Context, block *entities.Block) []Proposal {
+	height := block.GetIndex()
+	var proposals []Proposal
+
+	for _, tx := range block.GetTransactions() {
+		if tx.GetType() != entities.CertificateTransaction {
+			continue
+		}
+
+		txData, err := entities.ParseCertificateTransaction(tx.GetData())
+		if err == nil {
+			if txData.Kind == entities.CertificateTxProposal {
+				var proposal *Proposal
+				proposal, err = r.processProposal(ctx, tx.GetHash(), txData, height)
+				if proposal != nil {
+					proposals = append(proposals, *proposal)
+				}
+			} else {
+				err = r.processEndorsement(ctx, tx.GetHash(), txData, height)
+			}
+		}
+
+		if err != nil {
+			r.logger.WarnContext(ctx, "ignoring certificate transaction",
+				logging.TxHash(tx.GetHash()), logging.Height(height), logging.Err(err))
+		}
+	}
+
+	r.processedHeight = height
+	r.hasProcessed = true
+
+	return proposals
+}
+
+// processProposal registra um documento proposto (deve ser chamado com lock)
+func (r *Registry) processProposal(ctx context.Context, txHash valueobjects.Hash, txData *entities.CertificateTxData, height uint64) (*Proposal, error) {
+	document := txData.Document
+	if err := document.Validate(); err != nil {
+		return nil, err
+	}
+
+	documentHash, err := HashDocument(ctx, r.cryptoService, document)
+	if err != nil {
+		return nil, err
+	}
+	if documentHash.String() != txData.DocumentHash {
+		return nil, fmt.Errorf("document hash mismatch: computed %s, declared %s", documentHash.String(), txData.DocumentHash)
+	}
+
+	// O topo apurado precisa ser um bloco canônico anterior à proposta
+	if document.HeadHeight >= height {
+		return nil, fmt.Errorf("head height %d is not before inclusion height %d", document.HeadHeight, height)
+	}
+	head, err := r.chainManager.GetBlockByIndex(ctx, document.HeadHeight)
+	if err != nil {
+		return nil, fmt.Errorf("head block %d not available: %w", document.HeadHeight, err)
+	}
+	if hash := r.chainManager.CalculateBlockHash(ctx, head); hash.String() != document.HeadHash {
+		return nil, fmt.Errorf("head hash %s is not the canonical block %d", document.HeadHash, document.HeadHeight)
+	}
+
+	record := r.elections[txData.ElectionID]
+	if record == nil {
+		record = &electionRecord{}
+		r.elections[txData.ElectionID] = record
+	}
+	if record.certified != nil {
+		return nil, errors.New("election results already certified")
+	}
+	for _, existing := range record.documents {
+		if existing.hash == txData.DocumentHash {
+			return nil, errors.New("duplicate result document")
+		}
+	}
+
+	electorate := r.validatorManager.GetValidatorSetAtHeight(ctx, document.HeadHeight)
+	if err := r.verifySigner(ctx, electorate, txData); err != nil {
+		return nil, err
+	}
+
+	pending := &documentRecord{
+		hash:       txData.DocumentHash,
+		document:   document,
+		proposer:   txData.Signer,
+		proposalTx: txHash.String(),
+		proposedAt: height,
+		electorate: electorate,
+		signed:     make(map[string]bool),
+	}
+	record.documents = append(record.documents, pending)
+	r.addSignature(ctx, record, pending, txHash, txData, height)
+
+	return &Proposal{
+		ElectionID:   txData.ElectionID,
+		DocumentHash: txData.DocumentHash,
+		Document:     document,
+		Proposer:     txData.Signer,
+		Height:       height,
+	}, nil
+}
+
+// processEndorsement registra a co-assinatura de um documento proposto (deve ser chamado com lock)
+func (r *Registry) processEndorsement(ctx context.Context, txHash valueobjects.Hash, txData *entities.CertificateTxData, height uint64) error {
+	record, exists := r.elections[txData.ElectionID]
+	if !exists {
+		return errors.New("unknown result document")
+	}
+
+	var pending *documentRecord
+	for _, document := range record.documents {
+		if document.hash == txData.DocumentHash {
+			pending = document
+			break
+		}
+	}
+	if pending == nil {
+		return errors.New("unknown result document")
+	}
+	if pending.signed[txData.Signer] {
+		return errors.New("duplicate endorsement")
+	}
+
+	if err := r.verifySigner(ctx, pending.electorate, txData); err != nil {
+		return err
+	}
+
+	r.addSignature(ctx, record, pending, txHash, txData, height)
+	return nil
+}
+
+// addSignature adiciona uma assinatura verificada e certifica o documento ao atingir o limiar
+// (deve ser chamado com lock)
+func (r *Registry) addSignature(ctx context.Context, record *electionRecord, document *documentRecord, txHash valueobjects.Hash, txData *entities.CertificateTxData, height uint64) {
+	document.signed[txData.Signer] = true
+	document.signatures = append(document.signatures, Signature{
+		Validator: txData.Signer,
+		Signature: txData.Signature,
+		TxHash:    txHash.String(),
+		Height:    height,
+	})
+
+	if document.certified || len(document.signatures) < RequiredSignatures(len(document.electorate)) {
+		return
+	}
+
+	document.certified = true
+	document.certifiedAt = height
+	if record.certified == nil {
+		record.certified = document
+	}
+
+	r.logger.InfoContext(ctx, "election results certified",
+		"election_id", document.document.ElectionID, "document_hash", document.hash,
+		"signatures", len(document.signatures), "validators", len(document.electorate), logging.Height(height))
+}
+
+// verifySigner verifica se o signatário pertence ao conjunto e se a assinatura é válida
+func (r *Registry) verifySigner(ctx context.Context, electorate map[string]*services.PublicKey, txData *entities.CertificateTxData) error {
+	publicKey, exists := electorate[txData.Signer]
+	if !exists || publicKey == nil {
+		return fmt.Errorf("signer %s is not a validator at the certified height", txData.Signer)
+	}
+
+	signature, err := valueobjects.NewSignatureFromString(txData.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	valid, err := r.cryptoService.Verify(ctx, entities.CertificateSigningBytes(txData.ElectionID, txData.DocumentHash), signature, publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+	if !valid {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package certification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+)
+
+// Verificações aplicadas pelo Verify, na ordem em que aparecem no relatório
+const (
+	CheckFormat       = "format"
+	CheckDocument     = "document"
+	CheckDocumentHash = "document_hash"
+	CheckValidators   = "validators"
+	CheckSignatures   = "signatures"
+	CheckThreshold    = "threshold"
+	CheckAnchor       = "chain_anchor"
+)
+
+// Anchor é a referência externa contra a qual o certificado é conferido: sem ela, o conjunto
+// de validadores declarado no próprio certificado é a única âncora de confiança
+type Anchor struct {
+	Validators []archive.ValidatorKey // Conjunto vigente na altura do topo apurado
+	HeadHash   string                 // Hash do bloco nessa altura
+}
+
+// NewArchiveAnchor extrai de um arquivo da cadeia o conjunto de validadores e o hash do bloco
+// na altura informada. O arquivo deve ser verificado antes (archive.Verify).
+func NewArchiveAnchor(chainArchive *archive.Archive, height uint64) (*Anchor, error) {
+	if height >= uint64(len(chainArchive.Blocks)) || chainArchive.Blocks[height] == nil {
+		return nil, fmt.Errorf("chain archive does not contain block %d", height)
+	}
+	return &Anchor{
+		Validators: chainArchive.ValidatorSetAt(height),
+		HeadHash:   chainArchive.Blocks[height].Hash,
+	}, nil
+}
+
+// VerificationReport é o resultado da verificação offline de um certificado
+type VerificationReport struct {
+	ElectionID    string        `json:"election_id"`
+	ElectionTitle string        `json:"election_title"`
+	DocumentHash  string        `json:"document_hash"`
+	HeadHeight    uint64        `json:"head_height"`
+	HeadHash      string        `json:"head_hash"`
+	TotalVotes    uint64        `json:"total_votes"`
+	Validators    int           `json:"validators"`
+	Required      int           `json:"required"`
+	Signers       []string      `json:"signers"`
+	Anchored      bool          `json:"anchored"` // Conferido contra um arquivo da cadeia
+	Checks        []CheckResult `json:"checks"`
+	Valid         bool          `json:"valid"`
+	VerifiedAt    int64         `json:"verified_at"`
+}
+
+// CheckResult é o resultado de uma verificação
+type CheckResult struct {
+	Name  string `json:"name"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// record registra o resultado de uma verificação
+func (r *VerificationReport) record(check string, err error) bool {
+	result := CheckResult{Name: check, OK: err == nil}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	r.Checks = append(r.Checks, result)
+	return err == nil
+}
+
+// Verify verifica um certificado sem acesso à rede: consistência do documento, hash
+// canônico, chaves dos validadores, cada assinatura e o limiar de mais de 2/3. Com uma
+// âncora, o conjunto de validadores e o topo apurado também são conferidos contra ela.
+func Verify(ctx context.Context, cryptoService services.CryptographyService, certificate *Certificate, anchor *Anchor) *VerificationReport {
+	report := &VerificationReport{
+		ElectionID:   certificate.ElectionID,
+		DocumentHash: certificate.DocumentHash,
+		Signers:      make([]string, 0, len(certificate.Signatures)),
+		Anchored:     anchor != nil,
+	}
+
+	var err error
+	if certificate.Format != Format || certificate.Version != Version {
+		err = fmt.Errorf("unsupported certificate format %q version %d", certificate.Format, certificate.Version)
+	}
+	report.record(CheckFormat, err)
+
+	document := certificate.Document
+	if document == nil {
+		report.record(CheckDocument, errors.New("certificate has no document"))
+		return finishReport(report)
+	}
+	report.ElectionTitle = document.Election.Title
+	report.HeadHeight = document.HeadHeight
+	report.HeadHash = document.HeadHash
+	report.TotalVotes = document.TotalVotes
+
+	err = document.Validate()
+	if err == nil && document.ElectionID != certificate.ElectionID {
+		err = errors.New("document election ID does not match certificate")
+	}
+	report.record(CheckDocument, err)
+
+	documentHash, err := HashDocument(ctx, cryptoService, document)
+	if err == nil && documentHash.String() != certificate.DocumentHash {
+		err = fmt.Errorf("recomputed hash %s does not match declared %s", documentHash.String(), certificate.DocumentHash)
+	}
+	report.record(CheckDocumentHash, err)
+
+	validators := verifyValidators(ctx, cryptoService, certificate.Validators, report)
+	report.Validators = len(validators)
+	report.Required = RequiredSignatures(len(validators))
+
+	verifySignatures(ctx, cryptoService, certificate, validators, report)
+
+	err = nil
+	if len(validators) == 0 || len(report.Signers) < report.Required {
+		err = fmt.Errorf("signed by %d of %d validators, %d required", len(report.Signers), len(validators), report.Required)
+	}
+	report.record(CheckThreshold, err)
+
+	if anchor != nil {
+		report.record(CheckAnchor, verifyAnchor(certificate, anchor))
+	}
+
+	return finishReport(report)
+}
+
+// verifyValidators decodifica o conjunto declarado e confere os NodeIDs derivados das chaves
+func verifyValidators(ctx context.Context, cryptoService services.CryptographyService, keys []archive.ValidatorKey, report *VerificationReport) map[string]*services.PublicKey {
+	validators, err := archive.DecodeValidatorKeys(keys)
+	if err == nil && len(validators) != len(keys) {
+		err = errors.New("duplicate validator in certificate")
+	}
+	if err == nil && len(validators) == 0 {
+		err = errors.New("certificate has no validators")
+	}
+	if err == nil {
+		for nodeID, publicKey := range validators {
+			if !cryptoService.GenerateNodeID(ctx, publicKey).Equals(valueobjects.NewNodeID(nodeID)) {
+				err = fmt.Errorf("node ID %s does not match public key", nodeID)
+				break
+			}
+		}
+	}
+
+	if !report.record(CheckValidators, err) {
+		return map[string]*services.PublicKey{}
+	}
+	return validators
+}
+
+// verifySignatures confere cada assinatura contra o conjunto declarado; uma assinatura
+// inválida ou repetida reprova o certificado mesmo que as demais atinjam o limiar
+func verifySignatures(ctx context.Context, cryptoService services.CryptographyService, certificate *Certificate, validators map[string]*services.PublicKey, report *VerificationReport) {
+	message := entities.CertificateSigningBytes(certificate.ElectionID, certificate.DocumentHash)
+	signed := make(map[string]bool)
+	var failures []string
+
+	for _, entry := range certificate.Signatures {
+		publicKey, exists := validators[entry.Validator]
+		switch {
+		case !exists:
+			failures = append(failures, fmt.Sprintf("%s is not in the validator set", entry.Validator))
+			continue
+		case signed[entry.Validator]:
+			failures = append(failures, fmt.Sprintf("duplicate signature from %s", entry.Validator))
+			continue
+		}
+
+		signature, err := valueobjects.NewSignatureFromString(entry.Signature)
+		if err == nil {
+			var valid bool
+			valid, err = cryptoService.Verify(ctx, message, signature, publicKey)
+			if err == nil && !valid {
+				err = errors.New("signature does not verify")
+			}
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", entry.Validator, err))
+			continue
+		}
+
+		signed[entry.Validator] = true
+		report.Signers = append(report.Signers, entry.Validator)
+	}
+
+	var err error
+	if len(failures) > 0 {
+		err = errors.New(strings.Join(failures, "; "))
+	}
+	report.record(CheckSignatures, err)
+}
+
+// verifyAnchor compara o conjunto de validadores e o topo apurado com a âncora externa
+func verifyAnchor(certificate *Certificate, anchor *Anchor) error {
+	if certificate.Document.HeadHash != anchor.HeadHash {
+		return fmt.Errorf("head hash %s differs from block %d in the chain (%s)",
+			certificate.Document.HeadHash, certificate.Document.HeadHeight, anchor.HeadHash)
+	}
+
+	declared, err := archive.DecodeValidatorKeys(certificate.Validators)
+	if err != nil {
+		return err
+	}
+	expected, err := archive.DecodeValidatorKeys(anchor.Validators)
+	if err != nil {
+		return err
+	}
+	if !archive.SameValidatorSet(declared, expected) {
+		return fmt.Errorf("validator set differs from the chain at height %d", certificate.Document.HeadHeight)
+	}
+	return nil
+}
+
+// finishReport define o resultado final do relatório
+func finishReport(report *VerificationReport) *VerificationReport {
+	report.Valid = len(report.Checks) > 0
+	for _, check := range report.Checks {
+		if !check.OK {
+			report.Valid = false
+		}
+	}
+	report.VerifiedAt = time.Now().Unix()
+	return report
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/client"
+)
+
+var (
+	// Flags de acesso à API REST de um nó
+	apiURL   string
+	apiToken string
+)
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&apiURL, "api", client.DefaultBaseURL, "endereço da API REST do nó")
+	rootCmd.PersistentFlags().StringVar(&apiToken, "token", os.Getenv("PEER_VOTE_TOKEN"), "JWT ou token administrativo da API (padrão: $PEER_VOTE_TOKEN)")
+}
+
+// newAPIClient cria o cliente da API REST a partir das flags globais
+func newAPIClient() *client.Client {
+	var opts []client.Option
+	if apiToken != "" {
+		opts = append(opts, client.WithToken(apiToken))
+	}
+	return client.NewClient(apiURL, opts...)
+}
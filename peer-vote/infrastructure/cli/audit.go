@@ -0,0 +1,302 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/auditor"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags do comando audit cross-check
+	auditNodes     []string
+	auditArchives  []string
+	auditElection  string
+	auditHeight    int64
+	auditVoterKeys string
+)
+
+// auditCmd agrupa os comandos de auditoria
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Audita os votos de eleições",
+	Long: `Comandos de auditoria executados contra a API REST de um nó (--api).
+
+Exemplos:
+  peer-vote audit run <election-id>
+  peer-vote audit run <election-id> --output json > auditoria.json
+  peer-vote audit cross-check --node http://a:8080 --node http://b:8080 --node http://c:8080`,
+}
+
+// auditRunCmd audita uma eleição
+var auditRunCmd = &cobra.Command{
+	Use:   "run <election-id>",
+	Short: "Audita todos os votos de uma eleição",
+	Long: `Revalida os votos de uma eleição no nó e lista os votos inválidos.
+
+Código de saída: 0 se a auditoria passou, 2 se encontrou votos inválidos
+ou falhas de integridade, 1 em erros de execução.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runAuditRunCommand,
+}
+
+// auditCrossCheckCmd audita a cadeia de vários nós sem confiar em nenhum deles
+var auditCrossCheckCmd = &cobra.Command{
+	Use:   "cross-check",
+	Short: "Auditoria independente: confronta a cadeia de vários nós e recalcula a apuração",
+	Long: `Auditoria de observador, executada fora dos nós auditados. Busca a cadeia completa
+em cada nó (--node, endpoints públicos /chain/manifest e /chain/blocks) e em arquivos
+exportados com chain export (--archive), e então:
+
+  - confere se as fontes concordam bloco a bloco, relatando blocos ausentes,
+    inválidos ou divergentes da maioria por fonte;
+  - reverifica a cadeia da maioria a partir do gênese (hashes, Merkle, assinaturas
+    dos blocos e histórico de validadores), como chain verify;
+  - revalida cada voto (eleição registrada, candidato, ID, remetente, assinaturas e
+    votos repetidos do mesmo eleitor) e recalcula a apuração;
+  - compara a apuração recalculada com a informada por cada nó.
+
+A cadeia não registra as chaves dos eleitores: as assinaturas dos votos são conferidas
+contra as chaves de --voter-keys (lista JSON de {node_id, public_key}, o formato de
+vote keygen --output json); sem a chave, apenas a presença das assinaturas é conferida.
+
+Código de saída: 0 se nenhuma discrepância foi encontrada, 2 caso contrário,
+1 em erros de execução (ex: nenhuma fonte respondeu).`,
+	Args: cobra.NoArgs,
+	Run:  runAuditCrossCheckCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditRunCmd, auditCrossCheckCmd)
+	addOutputFlag(auditCmd)
+
+	auditCrossCheckCmd.Flags().StringArrayVar(&auditNodes, "node", nil, "endereço da API REST de um nó (repetível)")
+	auditCrossCheckCmd.Flags().StringArrayVar(&auditArchives, "archive", nil, "arquivo exportado com chain export (repetível)")
+	auditCrossCheckCmd.Flags().StringVar(&auditElection, "election", "", "audita apenas os votos desta eleição")
+	auditCrossCheckCmd.Flags().Int64Var(&auditHeight, "height", -1, "altura auditada (padrão: a maior declarada pelas fontes)")
+	auditCrossCheckCmd.Flags().StringVar(&auditVoterKeys, "voter-keys", "", "arquivo JSON com as chaves públicas dos eleitores")
+}
+
+// auditReport é o resultado de audit run: resumo de todos os votos e os votos inválidos
+type auditReport struct {
+	ElectionID    string                        `json:"election_id"`
+	ElectionTitle string                        `json:"election_title"`
+	AuditPassed   bool                          `json:"audit_passed"`
+	Message       string                        `json:"message"`
+	Summary       usecases.ElectionAuditSummary `json:"summary"`
+	InvalidVotes  []usecases.VoteAuditResult    `json:"invalid_votes"`
+}
+
+// Passed indica se a auditoria não encontrou falhas
+func (r *auditReport) Passed() bool {
+	return r.AuditPassed && r.Summary.InvalidVotes == 0
+}
+
+func runAuditRunCommand(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	apiClient := newAPIClient()
+
+	// Percorrer todas as páginas de votos inválidos
+	invalid := false
+	options := client.AuditVotesOptions{PageOptions: client.PageOptions{Limit: 500}, Valid: &invalid}
+	report := &auditReport{InvalidVotes: []usecases.VoteAuditResult{}}
+	for {
+		response, err := apiClient.AuditVotes(ctx, args[0], options)
+		if err != nil {
+			exitWithError("Erro ao auditar eleição", err)
+		}
+
+		report.ElectionID = response.ElectionID.String()
+		report.ElectionTitle = response.ElectionTitle
+		report.AuditPassed = response.AuditPassed
+		report.Message = response.Message
+		report.Summary = response.Summary
+		report.InvalidVotes = append(report.InvalidVotes, response.AuditResults...)
+
+		if !response.HasMore {
+			break
+		}
+		options.Cursor = response.NextCursor
+	}
+
+	printOutput(report, func(w *tabwriter.Writer) {
+		result := "✅ PASSOU"
+		if !report.Passed() {
+			result = "❌ FALHOU"
+		}
+		fmt.Fprintf(w, "Eleição:\t%s (%s)\n", report.ElectionTitle, report.ElectionID)
+		fmt.Fprintf(w, "Resultado:\t%s\n", result)
+		fmt.Fprintf(w, "Votos:\t%d (válidos %d, inválidos %d, anônimos %d)\n",
+			report.Summary.TotalVotes, report.Summary.ValidVotes, report.Summary.InvalidVotes, report.Summary.AnonymousVotes)
+		fmt.Fprintf(w, "Integridade:\t%.1f%%\n", report.Summary.IntegrityScore)
+		fmt.Fprintf(w, "Mensagem:\t%s\n", report.Message)
+
+		if len(report.Summary.CandidateResults) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "CANDIDATO\tVOTOS VÁLIDOS")
+			candidates := make([]string, 0, len(report.Summary.CandidateResults))
+			for candidateID := range report.Summary.CandidateResults {
+				candidates = append(candidates, candidateID)
+			}
+			sort.Strings(candidates)
+			for _, candidateID := range candidates {
+				fmt.Fprintf(w, "%s\t%d\n", candidateID, report.Summary.CandidateResults[candidateID])
+			}
+		}
+
+		if len(report.InvalidVotes) > 0 {
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, "VOTO INVÁLIDO\tBLOCO\tCANDIDATO\tERROS")
+			for _, vote := range report.InvalidVotes {
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", vote.VoteID, vote.BlockHeight, vote.CandidateID, strings.Join(vote.Errors, "; "))
+			}
+		}
+	})
+
+	if !report.Passed() {
+		os.Exit(exitAuditFailed)
+	}
+}
+
+func runAuditCrossCheckCommand(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	cryptoService := crypto.NewECDSAService()
+
+	if len(auditNodes) == 0 && len(auditArchives) == 0 {
+		exitWithError("Erro na auditoria", fmt.Errorf("at least one --node or --archive is required"))
+	}
+
+	var sources []auditor.Source
+	for _, node := range auditNodes {
+		var opts []client.Option
+		if apiToken != "" {
+			opts = append(opts, client.WithToken(apiToken))
+		}
+		sources = append(sources, auditor.NewRESTSource(client.NewClient(node, opts...)))
+	}
+	for _, path := range auditArchives {
+		chainArchive, _ := readChainArchive(path)
+		sources = append(sources, auditor.NewArchiveSource(path, chainArchive))
+	}
+
+	var options auditor.Options
+	if auditElection != "" {
+		electionID, err := valueobjects.NewHashFromString(auditElection)
+		if err != nil {
+			exitWithError("ID de eleição inválido", err)
+		}
+		options.ElectionID = electionID
+	}
+	if auditHeight >= 0 {
+		height := uint64(auditHeight)
+		options.Height = &height
+	}
+	if auditVoterKeys != "" {
+		options.VoterKeys = readVoterKeys(ctx, auditVoterKeys, cryptoService)
+	}
+
+	report, err := auditor.NewAuditor(cryptoService, services.NewVotingValidator(nil), sources...).Run(ctx, options)
+	if err != nil {
+		exitWithError("Erro na auditoria", err)
+	}
+
+	printOutput(report, func(w *tabwriter.Writer) {
+		printCrossCheckReport(w, report)
+	})
+
+	if !report.Passed {
+		os.Exit(exitAuditFailed)
+	}
+}
+
+// readVoterKeys lê o arquivo de chaves públicas dos eleitores
+func readVoterKeys(ctx context.Context, path string, cryptoService services.CryptographyService) map[string]*services.PublicKey {
+	file, err := os.Open(path)
+	if err != nil {
+		exitWithError("Erro ao ler chaves dos eleitores", err)
+	}
+	defer file.Close()
+
+	voterKeys, err := auditor.LoadVoterKeys(ctx, file, cryptoService)
+	if err != nil {
+		exitWithError("Erro ao ler chaves dos eleitores", err)
+	}
+	return voterKeys
+}
+
+// printCrossCheckReport escreve o relatório da auditoria independente
+func printCrossCheckReport(w *tabwriter.Writer, report *auditor.Report) {
+	result := "✅ PASSOU"
+	if !report.Passed {
+		result = "❌ FALHOU"
+	}
+	chain := "✅ válida"
+	if !report.Chain.Valid {
+		chain = "❌ inválida"
+	}
+	fmt.Fprintf(w, "Resultado:\t%s (%d discrepâncias)\n", result, len(report.Discrepancies))
+	fmt.Fprintf(w, "Altura auditada:\t%d\n", report.Height)
+	fmt.Fprintf(w, "Gênese:\t%s\n", valueOrDash(report.GenesisHash))
+	fmt.Fprintf(w, "Topo:\t%s\n", valueOrDash(report.HeadHash))
+	fmt.Fprintf(w, "Cadeia da maioria:\t%s\n", chain)
+	fmt.Fprintf(w, "Votos:\t%d (válidos %d, inválidos %d, anônimos %d)\n",
+		report.Votes.Total, report.Votes.Valid, report.Votes.Invalid, report.Votes.Anonymous)
+	fmt.Fprintf(w, "Assinaturas de votos:\t%d conferidas, %d sem chave do eleitor\n",
+		report.Votes.SignaturesVerified, report.Votes.SignaturesUnverified)
+
+	fmt.Fprintln(w, "\nFONTE\tNÓ\tALTURA\tBASE\tCONCORDAM\tAUSENTES\tDIVERGENTES")
+	for _, source := range report.Sources {
+		if !source.Available {
+			fmt.Fprintf(w, "%s\t-\t-\t-\t-\t-\t❌ %s\n", source.Name, source.Error)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%d\n", source.Name, valueOrDash(source.NodeID),
+			source.Height, source.BaseHeight, source.BlocksAgreed, source.MissingBlocks, source.DivergentBlocks)
+	}
+
+	for _, election := range report.Elections {
+		fmt.Fprintf(w, "\nEleição:\t%s (%s)\n", election.Title, election.ElectionID)
+		fmt.Fprintf(w, "Votos:\t%d (válidos %d, inválidos %d)\n", election.TotalVotes, election.ValidVotes, election.InvalidVotes)
+		fmt.Fprintln(w, "CANDIDATO\tVOTOS RECALCULADOS")
+		for _, result := range election.Results {
+			fmt.Fprintf(w, "%s (%s)\t%d\n", result.Name, result.CandidateID, result.Votes)
+		}
+		for _, node := range election.Nodes {
+			status := "✅ confere"
+			switch {
+			case node.Error != "":
+				status = "- " + node.Error
+			case !node.Matches:
+				status = "❌ diverge"
+			}
+			fmt.Fprintf(w, "Apuração de %s:\t%s\n", node.Source, status)
+		}
+	}
+
+	if len(report.Discrepancies) > 0 {
+		fmt.Fprintln(w, "\n❌ Discrepâncias")
+		fmt.Fprintln(w, "TIPO\tFONTE\tBLOCOS\tTRANSAÇÃO\tDETALHE")
+		for _, discrepancy := range report.Discrepancies {
+			blocks := "-"
+			if discrepancy.Height != nil {
+				blocks = fmt.Sprintf("%d", *discrepancy.Height)
+				if discrepancy.ToHeight != nil {
+					blocks = fmt.Sprintf("%d-%d", *discrepancy.Height, *discrepancy.ToHeight)
+				}
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", discrepancy.Kind, valueOrDash(discrepancy.Source),
+				blocks, valueOrDash(discrepancy.TxHash), discrepancy.Detail)
+		}
+	}
+}
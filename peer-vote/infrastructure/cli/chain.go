@@ -0,0 +1,378 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/client"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/handlers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags do comando chain
+	chainLimit     int
+	chainCursor    string
+	chainOrder     string
+	chainValidator string
+	chainTxType    string
+	chainFrom      int64
+	chainTo        int64
+	chainFile      string
+)
+
+// chainCmd agrupa os comandos da blockchain
+var chainCmd = &cobra.Command{
+	Use:   "chain",
+	Short: "Consulta e valida a blockchain de um nó",
+	Long: `Comandos da blockchain executados contra a API REST de um nó (--api).
+
+Exemplos:
+  peer-vote chain blocks --order desc --limit 20
+  peer-vote chain blocks --tx-type VOTE --from 100 --to 200
+  peer-vote chain block 42
+  peer-vote chain block latest
+  peer-vote chain validate
+  peer-vote chain export --file eleicao-2026.chain.json
+  peer-vote chain verify --file eleicao-2026.chain.json
+  peer-vote chain import --file eleicao-2026.chain.json
+  peer-vote chain snapshots`,
+}
+
+// chainBlocksCmd lista blocos
+var chainBlocksCmd = &cobra.Command{
+	Use:   "blocks",
+	Short: "Lista blocos",
+	Args:  cobra.NoArgs,
+	Run:   runChainBlocksCommand,
+}
+
+// chainBlockCmd mostra um bloco
+var chainBlockCmd = &cobra.Command{
+	Use:   "block <altura|hash|latest>",
+	Short: "Mostra um bloco pela altura, pelo hash ou o último",
+	Args:  cobra.ExactArgs(1),
+	Run:   runChainBlockCommand,
+}
+
+// chainValidateCmd valida a cadeia no nó
+var chainValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Valida a integridade da cadeia no nó",
+	Long: `Pede ao nó que revalide a cadeia completa.
+
+Código de saída: 0 se a cadeia é válida, 2 se é inválida, 1 em erros de execução.`,
+	Args: cobra.NoArgs,
+	Run:  runChainValidateCommand,
+}
+
+// chainExportCmd exporta a cadeia para um arquivo
+var chainExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exporta a cadeia completa para um arquivo",
+	Long: `Salva um arquivo autodescritivo com o gênese, todos os blocos e o histórico do
+conjunto de validadores, para retenção legal e verificação futura sem rede.
+Exige o token de administrador (--token).`,
+	Args: cobra.NoArgs,
+	Run:  runChainExportCommand,
+}
+
+// chainImportCmd inicializa um nó a partir de um arquivo
+var chainImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Inicializa a cadeia de um nó a partir de um arquivo",
+	Long: `Envia um arquivo exportado com "chain export" ao nó (--api), que o verifica
+antes de aplicar os blocos. Um nó sem validadores configurados adota o conjunto
+gênese do arquivo; blocos já presentes com o mesmo hash são ignorados.
+Exige o token de administrador (--token).
+
+Código de saída: 0 se importado, 2 se o arquivo é inválido, 1 em erros de execução.`,
+	Args: cobra.NoArgs,
+	Run:  runChainImportCommand,
+}
+
+// chainVerifyCmd verifica um arquivo sem acesso à rede
+var chainVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Verifica um arquivo de cadeia sem acesso à rede",
+	Long: `Reaplica todos os blocos do arquivo a partir do gênese, localmente:
+- Hash de cada bloco e encadeamento com o anterior
+- BlockBuilder.ValidateBlock (transações, raiz de Merkle, timestamp e tamanho)
+- Raiz de Merkle recalculada de forma independente
+- Assinatura de cada bloco pelo validador autorizado na altura, com o conjunto
+  de validadores derivado das transações VALIDATOR a partir do conjunto gênese
+- Histórico de validadores e resumo declarados no arquivo
+
+Código de saída: 0 se o arquivo é válido, 2 se é inválido, 1 em erros de execução.`,
+	Args: cobra.NoArgs,
+	Run:  runChainVerifyCommand,
+}
+
+// chainSnapshotsCmd lista os snapshots de estado do nó
+var chainSnapshotsCmd = &cobra.Command{
+	Use:   "snapshots",
+	Short: "Lista os snapshots de estado mantidos pelo nó",
+	Long: `Mostra os snapshots capturados nas alturas de checkpoint (eleições, apurações,
+conjunto de validadores e penalidades) e os validadores que os assinaram.
+Um snapshot é confiável quando assinado por mais de 2/3 do conjunto gênese do nó;
+só snapshots confiáveis são usados para iniciar nós novos.
+Exige o token de administrador (--token).`,
+	Args: cobra.NoArgs,
+	Run:  runChainSnapshotsCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(chainCmd)
+	chainCmd.AddCommand(chainBlocksCmd, chainBlockCmd, chainValidateCmd, chainExportCmd, chainImportCmd, chainVerifyCmd, chainSnapshotsCmd)
+	addOutputFlag(chainCmd)
+
+	chainBlocksCmd.Flags().IntVar(&chainLimit, "limit", 50, "blocos por página")
+	chainBlocksCmd.Flags().StringVar(&chainCursor, "cursor", "", "cursor da próxima página")
+	chainBlocksCmd.Flags().StringVar(&chainOrder, "order", "asc", "ordem pela altura (asc ou desc)")
+	chainBlocksCmd.Flags().StringVar(&chainValidator, "validator", "", "filtrar pelo NodeID do validador")
+	chainBlocksCmd.Flags().StringVar(&chainTxType, "tx-type", "", "apenas blocos com transações do tipo (VOTE, ELECTION, VALIDATOR, EVIDENCE, CERTIFICATE)")
+	chainBlocksCmd.Flags().Int64Var(&chainFrom, "from", -1, "altura inicial")
+	chainBlocksCmd.Flags().Int64Var(&chainTo, "to", -1, "altura final")
+
+	chainExportCmd.Flags().StringVarP(&chainFile, "file", "f", "", "arquivo de destino")
+	chainImportCmd.Flags().StringVarP(&chainFile, "file", "f", "", "arquivo exportado com chain export")
+	chainVerifyCmd.Flags().StringVarP(&chainFile, "file", "f", "", "arquivo exportado com chain export")
+	for _, cmd := range []*cobra.Command{chainExportCmd, chainImportCmd, chainVerifyCmd} {
+		cmd.MarkFlagRequired("file")
+	}
+}
+
+func runChainBlocksCommand(cmd *cobra.Command, args []string) {
+	options := client.ListBlocksOptions{
+		PageOptions: client.PageOptions{Limit: chainLimit, Cursor: chainCursor, Order: chainOrder},
+		Validator:   chainValidator,
+		TxType:      chainTxType,
+	}
+	if chainFrom >= 0 {
+		from := uint64(chainFrom)
+		options.FromHeight = &from
+	}
+	if chainTo >= 0 {
+		to := uint64(chainTo)
+		options.ToHeight = &to
+	}
+
+	response, err := newAPIClient().ListBlocks(context.Background(), options)
+	if err != nil {
+		exitWithError("Erro ao listar blocos", err)
+	}
+
+	printOutput(response, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "ALTURA\tHASH\tVALIDADOR\tTRANSAÇÕES\tDATA")
+		for _, block := range response.Blocks {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n", block.Index, block.Hash, block.Validator, block.Transactions, formatUnix(block.Timestamp))
+		}
+	})
+	printNextPage(response.HasMore, response.NextCursor)
+}
+
+func runChainBlockCommand(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	apiClient := newAPIClient()
+
+	var block *handlers.BlockResponse
+	var err error
+	if args[0] == "latest" {
+		block, err = apiClient.GetLatestBlock(ctx)
+	} else if index, parseErr := strconv.ParseUint(args[0], 10, 64); parseErr == nil {
+		block, err = apiClient.GetBlock(ctx, index)
+	} else {
+		block, err = apiClient.GetBlockByHash(ctx, args[0])
+	}
+	if err != nil {
+		exitWithError("Erro ao obter bloco", err)
+	}
+
+	printOutput(block, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "Altura:\t%d\n", block.Index)
+		fmt.Fprintf(w, "Hash:\t%s\n", block.Hash)
+		fmt.Fprintf(w, "Anterior:\t%s\n", block.PreviousHash)
+		fmt.Fprintf(w, "Data:\t%s\n", formatUnix(block.Timestamp))
+		fmt.Fprintf(w, "Merkle root:\t%s\n", block.MerkleRoot)
+		fmt.Fprintf(w, "Validador:\t%s\n", block.Validator)
+		fmt.Fprintf(w, "Assinatura:\t%s\n", block.Signature)
+		fmt.Fprintf(w, "Transações:\t%d\n", block.Transactions)
+	})
+}
+
+func runChainValidateCommand(cmd *cobra.Command, args []string) {
+	response, err := newAPIClient().ValidateChain(context.Background())
+	if err != nil {
+		exitWithError("Erro ao validar cadeia", err)
+	}
+
+	printOutput(response, func(w *tabwriter.Writer) {
+		result := "✅ válida"
+		if !response.IsValid {
+			result = "❌ inválida"
+		}
+		fmt.Fprintf(w, "Cadeia:\t%s\n", result)
+		fmt.Fprintf(w, "Mensagem:\t%s\n", response.Message)
+	})
+
+	if !response.IsValid {
+		os.Exit(exitAuditFailed)
+	}
+}
+
+func runChainExportCommand(cmd *cobra.Command, args []string) {
+	chainArchive, err := newAPIClient().GetChainArchive(context.Background())
+	if err != nil {
+		exitWithError("Erro ao exportar cadeia", err)
+	}
+
+	var buffer bytes.Buffer
+	if err := archive.Write(&buffer, chainArchive); err != nil {
+		exitWithError("Erro ao serializar arquivo da cadeia", err)
+	}
+	if err := os.WriteFile(chainFile, buffer.Bytes(), 0644); err != nil {
+		exitWithError("Erro ao salvar arquivo da cadeia", err)
+	}
+
+	summary := chainExportSummary{
+		File:          chainFile,
+		ArchiveSHA256: fmt.Sprintf("%x", sha256.Sum256(buffer.Bytes())),
+		Chain:         chainArchive.Chain,
+		Blocks:        len(chainArchive.Blocks),
+	}
+	printOutput(summary, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "✅ Cadeia exportada:\t%s\n", summary.File)
+		fmt.Fprintf(w, "SHA-256 do arquivo:\t%s\n", summary.ArchiveSHA256)
+		fmt.Fprintf(w, "Altura:\t%d (%d blocos, %d transações)\n", summary.Chain.Height, summary.Blocks, summary.Chain.Transactions)
+		fmt.Fprintf(w, "Gênese:\t%s\n", summary.Chain.GenesisHash)
+		fmt.Fprintf(w, "Topo:\t%s\n", summary.Chain.HeadHash)
+		fmt.Fprintf(w, "Finalizado até:\t%d\n", summary.Chain.FinalizedHeight)
+	})
+}
+
+func runChainSnapshotsCommand(cmd *cobra.Command, args []string) {
+	response, err := newAPIClient().ListSnapshots(context.Background())
+	if err != nil {
+		exitWithError("Erro ao listar snapshots", err)
+	}
+
+	printOutput(response, func(w *tabwriter.Writer) {
+		if !response.Enabled {
+			fmt.Fprintln(w, "Snapshots desabilitados no nó (blockchain.snapshot.enabled)")
+			return
+		}
+		fmt.Fprintf(w, "Intervalo:\t%d blocos\n", response.Interval)
+		fmt.Fprintln(w, "ALTURA\tBLOCO\tESTADO\tASSINATURAS\tCONFIÁVEL")
+		for _, snapshot := range response.Snapshots {
+			trusted := "não"
+			if snapshot.Trusted {
+				trusted = "sim"
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\n", snapshot.Height, snapshot.BlockHash, snapshot.StateHash, len(snapshot.Signers), trusted)
+		}
+	})
+}
+
+// chainExportSummary identifica o arquivo gerado pelo chain export
+type chainExportSummary struct {
+	File          string               `json:"file"`
+	ArchiveSHA256 string               `json:"archive_sha256"`
+	Chain         archive.ChainSummary `json:"chain"`
+	Blocks        int                  `json:"blocks"`
+}
+
+func runChainImportCommand(cmd *cobra.Command, args []string) {
+	chainArchive, _ := readChainArchive(chainFile)
+
+	response, err := newAPIClient().ImportChain(context.Background(), chainArchive)
+	if err != nil {
+		exitWithError("Erro ao importar cadeia", err)
+	}
+
+	printOutput(response, func(w *tabwriter.Writer) {
+		printChainReport(w, response.Report)
+		if result := response.Result; result != nil {
+			fmt.Fprintln(w, "\n📥 Importação")
+			fmt.Fprintf(w, "Blocos importados:\t%d\n", result.Imported)
+			fmt.Fprintf(w, "Blocos já presentes:\t%d\n", result.Skipped)
+			fmt.Fprintf(w, "Validadores gênese adotados:\t%d\n", result.AdoptedValidators)
+			fmt.Fprintf(w, "Altura do nó:\t%d\n", result.Height)
+			fmt.Fprintf(w, "Topo do nó:\t%s\n", result.HeadHash)
+		}
+	})
+
+	if !response.Report.Valid {
+		os.Exit(exitAuditFailed)
+	}
+}
+
+func runChainVerifyCommand(cmd *cobra.Command, args []string) {
+	chainArchive, data := readChainArchive(chainFile)
+
+	report := archive.Verify(context.Background(), chainArchive, crypto.NewECDSAService())
+	report.ArchiveSHA256 = fmt.Sprintf("%x", sha256.Sum256(data))
+
+	printOutput(report, func(w *tabwriter.Writer) {
+		printChainReport(w, report)
+	})
+
+	if !report.Valid {
+		os.Exit(exitAuditFailed)
+	}
+}
+
+// readChainArchive lê um arquivo de cadeia, retornando também o conteúdo bruto para o SHA-256
+func readChainArchive(path string) (*archive.Archive, []byte) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		exitWithError("Erro ao ler arquivo da cadeia", err)
+	}
+
+	chainArchive, err := archive.Read(bytes.NewReader(data))
+	if err != nil {
+		exitWithError("Erro ao ler arquivo da cadeia", err)
+	}
+	return chainArchive, data
+}
+
+// printChainReport escreve o relatório de verificação de um arquivo de cadeia
+func printChainReport(w *tabwriter.Writer, report *archive.VerificationReport) {
+	result := "✅ válido"
+	if !report.Valid {
+		result = "❌ inválido"
+	}
+	fmt.Fprintf(w, "Arquivo:\t%s\n", result)
+	if report.ArchiveSHA256 != "" {
+		fmt.Fprintf(w, "SHA-256:\t%s\n", report.ArchiveSHA256)
+	}
+	fmt.Fprintf(w, "Formato:\t%s v%d\n", report.Format, report.Version)
+	fmt.Fprintf(w, "Exportado por:\t%s em %s\n", valueOrDash(report.ExportedBy), formatUnix(report.CreatedAt))
+	fmt.Fprintf(w, "Altura:\t%d (%d blocos, %d transações)\n", report.Height, report.Blocks, report.Transactions)
+	fmt.Fprintf(w, "Gênese:\t%s\n", valueOrDash(report.GenesisHash))
+	fmt.Fprintf(w, "Topo:\t%s\n", valueOrDash(report.HeadHash))
+	fmt.Fprintf(w, "Validadores gênese:\t%d (%d alterações)\n", report.GenesisValidators, report.ValidatorEpochs)
+	fmt.Fprintf(w, "Verificado em:\t%s\n", formatUnix(report.VerifiedAt))
+
+	fmt.Fprintln(w, "\nVERIFICAÇÃO\tOK\tFALHAS")
+	for _, check := range report.Checks {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", check.Name, check.Passed, check.Failed)
+	}
+
+	if len(report.Failures) > 0 {
+		fmt.Fprintln(w, "\n❌ Falhas")
+		for _, failure := range report.Failures {
+			location := "arquivo"
+			if failure.Height != nil {
+				location = fmt.Sprintf("bloco %d", *failure.Height)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", location, failure.Check, failure.Error)
+		}
+	}
+}
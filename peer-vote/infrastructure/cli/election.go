@@ -0,0 +1,586 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/certification"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/client"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/handlers"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// Flags do comando election
+	electionFile      string
+	electionStatus    string
+	electionActive    bool
+	electionCreatedBy string
+	electionLimit     int
+	electionCursor    string
+
+	// Flags de election create com modelo e CSV de candidatos
+	electionTemplate    string
+	electionTitle       string
+	electionDescription string
+	electionStart       string
+	electionEnd         string
+	electionCandidates  string
+
+	// Flags dos comandos de certificado
+	certificateFile  string
+	certificateChain string
+)
+
+// electionCmd agrupa os comandos de eleições
+var electionCmd = &cobra.Command{
+	Use:   "election",
+	Short: "Cria, consulta e encerra eleições em um nó",
+	Long: `Comandos de eleições executados contra a API REST de um nó (--api).
+
+Exemplos:
+  peer-vote election create --file eleicao.yaml
+  peer-vote election create --template conselho --title "Conselho 2026" --start 2026-03-01T09:00:00Z --candidates candidatos.csv
+  peer-vote election template list
+  peer-vote election candidates candidatos.csv
+  peer-vote election list --status ACTIVE
+  peer-vote election show <election-id>
+  peer-vote election results <election-id> --output json
+  peer-vote election close <election-id>
+  peer-vote election certify <election-id>
+  peer-vote election certificate <election-id> --file resultado.cert.json
+  peer-vote election verify-certificate --file resultado.cert.json --chain cadeia.json`,
+}
+
+// electionCreateCmd cria uma eleição a partir de um arquivo
+var electionCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Cria uma eleição a partir de um arquivo YAML ou JSON ou de um modelo",
+	Long: `Cria uma eleição descrita em um arquivo YAML ou JSON:
+
+  title: Eleição do Conselho
+  description: Mandato 2025-2026
+  start_time: 2025-06-01T09:00:00Z   # RFC3339
+  end_time: 2025-06-01T18:00:00Z
+  allow_anonymous: false
+  max_votes_per_voter: 1
+  candidates:
+    - id: alice
+      name: Alice
+      description: Chapa 1
+    - id: bob
+      name: Bob
+
+created_by é opcional: sem ele é usado --node-id ou o NodeID do token.
+
+Com --candidates, os candidatos vêm de um CSV (cabeçalho id,name[,description]) validado
+antes do envio; veja election candidates.
+
+Com --template, as regras (anonimato, votos por eleitor e faixa de duração) vêm do modelo
+do nó e a eleição é descrita por --title, --description, --start e --end; sem --end, a
+eleição dura a duração padrão do modelo. A duração também deve respeitar
+voting.validation.min/max_election_duration do nó.`,
+	Args: cobra.NoArgs,
+	Run:  runElectionCreateCommand,
+}
+
+// electionListCmd lista eleições
+var electionListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lista eleições",
+	Args:  cobra.NoArgs,
+	Run:   runElectionListCommand,
+}
+
+// electionShowCmd mostra uma eleição
+var electionShowCmd = &cobra.Command{
+	Use:   "show <election-id>",
+	Short: "Mostra os detalhes de uma eleição",
+	Args:  cobra.ExactArgs(1),
+	Run:   runElectionShowCommand,
+}
+
+// electionResultsCmd mostra os resultados de uma eleição
+var electionResultsCmd = &cobra.Command{
+	Use:   "results <election-id>",
+	Short: "Mostra os resultados de uma eleição",
+	Args:  cobra.ExactArgs(1),
+	Run:   runElectionResultsCommand,
+}
+
+// electionCloseCmd encerra uma eleição
+var electionCloseCmd = &cobra.Command{
+	Use:   "close <election-id>",
+	Short: "Encerra uma eleição (apenas o criador)",
+	Args:  cobra.ExactArgs(1),
+	Run:   runElectionCloseCommand,
+}
+
+// electionCertifyCmd propõe o certificado de resultado de uma eleição encerrada
+var electionCertifyCmd = &cobra.Command{
+	Use:   "certify <election-id>",
+	Short: "Propõe o certificado de resultado de uma eleição encerrada",
+	Long: `Pede ao nó (validador) que apure a eleição encerrada no topo atual da cadeia e registre
+o documento de resultado assinado: definição da eleição, apuração final, intervalo de
+blocos, hash do topo e resumo da auditoria. Os demais validadores recalculam o documento
+e o co-assinam na cadeia; com mais de 2/3 das assinaturas o resultado está certificado.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runElectionCertifyCommand,
+}
+
+// electionCertificateCmd obtém o certificado de resultado
+var electionCertificateCmd = &cobra.Command{
+	Use:   "certificate <election-id>",
+	Short: "Mostra o certificado de resultado e suas assinaturas",
+	Long: `Mostra o certificado de resultado registrado na cadeia. Com --file, salva o
+certificado para verificação offline (election verify-certificate).`,
+	Args: cobra.ExactArgs(1),
+	Run:  runElectionCertificateCommand,
+}
+
+// electionVerifyCertificateCmd verifica um certificado sem acesso à rede
+var electionVerifyCertificateCmd = &cobra.Command{
+	Use:   "verify-certificate",
+	Short: "Verifica um certificado de resultado sem acesso à rede",
+	Long: `Verifica localmente um certificado salvo com election certificate --file:
+- Consistência do documento (apuração, intervalo de blocos e auditoria)
+- Hash canônico do documento
+- NodeIDs derivados das chaves dos validadores declarados
+- Cada assinatura, e mais de 2/3 do conjunto de validadores
+
+Sem --chain, o conjunto de validadores declarado no certificado é a âncora de confiança.
+Com --chain (arquivo de chain export), o arquivo é verificado e o hash do topo apurado e
+o conjunto de validadores nessa altura são conferidos contra a cadeia.
+
+Código de saída: 0 se o certificado é válido, 2 se é inválido, 1 em erros de execução.`,
+	Args: cobra.NoArgs,
+	Run:  runElectionVerifyCertificateCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(electionCmd)
+	electionCmd.AddCommand(electionCreateCmd, electionListCmd, electionShowCmd, electionResultsCmd, electionCloseCmd,
+		electionCertifyCmd, electionCertificateCmd, electionVerifyCertificateCmd)
+	addOutputFlag(electionCmd)
+
+	electionCreateCmd.Flags().StringVarP(&electionFile, "file", "f", "", "arquivo YAML ou JSON da eleição")
+	electionCreateCmd.Flags().StringVar(&electionCandidates, "candidates", "", "CSV de candidatos (substitui os do arquivo)")
+	electionCreateCmd.Flags().StringVar(&electionTemplate, "template", "", "criar com as regras de um modelo do nó")
+	electionCreateCmd.Flags().StringVar(&electionTitle, "title", "", "título da eleição (com --template)")
+	electionCreateCmd.Flags().StringVar(&electionDescription, "description", "", "descrição da eleição (com --template)")
+	electionCreateCmd.Flags().StringVar(&electionStart, "start", "", "início em RFC3339 (com --template)")
+	electionCreateCmd.Flags().StringVar(&electionEnd, "end", "", "fim em RFC3339 (com --template; padrão: duração do modelo)")
+	electionCreateCmd.MarkFlagsMutuallyExclusive("file", "template")
+	electionCreateCmd.MarkFlagsOneRequired("file", "template")
+
+	electionListCmd.Flags().StringVar(&electionStatus, "status", "", "filtrar por status efetivo (PENDING, ACTIVE, CLOSED, CANCELLED)")
+	electionListCmd.Flags().BoolVar(&electionActive, "active", false, "apenas eleições em votação")
+	electionListCmd.Flags().StringVar(&electionCreatedBy, "created-by", "", "filtrar pelo NodeID do criador")
+	electionListCmd.Flags().IntVar(&electionLimit, "limit", 50, "eleições por página")
+	electionListCmd.Flags().StringVar(&electionCursor, "cursor", "", "cursor da próxima página")
+
+	electionCertificateCmd.Flags().StringVarP(&certificateFile, "file", "f", "", "salvar o certificado no arquivo")
+
+	electionVerifyCertificateCmd.Flags().StringVarP(&certificateFile, "file", "f", "", "arquivo do certificado")
+	electionVerifyCertificateCmd.Flags().StringVar(&certificateChain, "chain", "", "arquivo da cadeia (chain export) usado como âncora")
+	electionVerifyCertificateCmd.MarkFlagRequired("file")
+}
+
+// electionSpec é o formato do arquivo aceito por election create
+type electionSpec struct {
+	Title            string          `yaml:"title"`
+	Description      string          `yaml:"description"`
+	StartTime        string          `yaml:"start_time"`
+	EndTime          string          `yaml:"end_time"`
+	CreatedBy        string          `yaml:"created_by"`
+	AllowAnonymous   bool            `yaml:"allow_anonymous"`
+	MaxVotesPerVoter int             `yaml:"max_votes_per_voter"`
+	Candidates       []candidateSpec `yaml:"candidates"`
+}
+
+// candidateSpec é um candidato no arquivo da eleição
+type candidateSpec struct {
+	ID          string `yaml:"id"`
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// loadElectionSpec lê o arquivo da eleição (JSON é um subconjunto de YAML)
+func loadElectionSpec(path string) (*handlers.CreateElectionRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec electionSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("invalid election file: %w", err)
+	}
+
+	if spec.Title == "" {
+		return nil, fmt.Errorf("title is required")
+	}
+	if len(spec.Candidates) < 2 && electionCandidates == "" {
+		return nil, fmt.Errorf("at least 2 candidates are required")
+	}
+	for _, field := range []struct{ name, value string }{{"start_time", spec.StartTime}, {"end_time", spec.EndTime}} {
+		if _, err := time.Parse(time.RFC3339, field.value); err != nil {
+			return nil, fmt.Errorf("%s must be RFC3339: %w", field.name, err)
+		}
+	}
+
+	request := &handlers.CreateElectionRequest{
+		Title:            spec.Title,
+		Description:      spec.Description,
+		StartTime:        spec.StartTime,
+		EndTime:          spec.EndTime,
+		CreatedBy:        spec.CreatedBy,
+		AllowAnonymous:   spec.AllowAnonymous,
+		MaxVotesPerVoter: spec.MaxVotesPerVoter,
+	}
+	if request.CreatedBy == "" {
+		request.CreatedBy = nodeID
+	}
+	if request.MaxVotesPerVoter == 0 {
+		request.MaxVotesPerVoter = 1
+	}
+	for _, candidate := range spec.Candidates {
+		request.Candidates = append(request.Candidates, entities.Candidate{
+			ID:          candidate.ID,
+			Name:        candidate.Name,
+			Description: candidate.Description,
+		})
+	}
+
+	return request, nil
+}
+
+// loadCandidatesFlag lê o CSV de --candidates; problemas por linha encerram o comando
+func loadCandidatesFlag() []entities.Candidate {
+	result, err := loadCandidatesCSV(electionCandidates)
+	if err != nil {
+		exitWithError("Erro ao ler candidatos", err)
+	}
+	if !result.Valid {
+		printOutput(result, func(w *tabwriter.Writer) {
+			printCandidateImport(w, result)
+		})
+		os.Exit(exitAuditFailed)
+	}
+	return result.Candidates
+}
+
+// templateElectionRequest monta a eleição criada com --template a partir das flags
+func templateElectionRequest() (*handlers.CreateElectionFromTemplateRequest, error) {
+	if electionTitle == "" {
+		return nil, fmt.Errorf("--title is required with --template")
+	}
+	if electionCandidates == "" {
+		return nil, fmt.Errorf("--candidates is required with --template")
+	}
+	if _, err := time.Parse(time.RFC3339, electionStart); err != nil {
+		return nil, fmt.Errorf("--start must be RFC3339: %w", err)
+	}
+	if electionEnd != "" {
+		if _, err := time.Parse(time.RFC3339, electionEnd); err != nil {
+			return nil, fmt.Errorf("--end must be RFC3339: %w", err)
+		}
+	}
+
+	return &handlers.CreateElectionFromTemplateRequest{
+		Title:       electionTitle,
+		Description: electionDescription,
+		StartTime:   electionStart,
+		EndTime:     electionEnd,
+		CreatedBy:   nodeID,
+	}, nil
+}
+
+func runElectionCreateCommand(cmd *cobra.Command, args []string) {
+	var response *usecases.CreateElectionResponse
+	if electionTemplate != "" {
+		request, err := templateElectionRequest()
+		if err != nil {
+			exitWithError("Erro nos parâmetros da eleição", err)
+		}
+		request.Candidates = loadCandidatesFlag()
+
+		response, err = newAPIClient().CreateElectionFromTemplate(context.Background(), electionTemplate, request)
+		if err != nil {
+			exitWithError("Erro ao criar eleição", err)
+		}
+	} else {
+		request, err := loadElectionSpec(electionFile)
+		if err != nil {
+			exitWithError("Erro ao ler arquivo da eleição", err)
+		}
+		if electionCandidates != "" {
+			request.Candidates = loadCandidatesFlag()
+		}
+
+		response, err = newAPIClient().CreateElection(context.Background(), request)
+		if err != nil {
+			exitWithError("Erro ao criar eleição", err)
+		}
+	}
+
+	printOutput(response, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "ID:\t%s\n", response.Election.GetID().String())
+		fmt.Fprintf(w, "Título:\t%s\n", response.Election.GetTitle())
+		fmt.Fprintf(w, "Transação:\t%s\n", response.TransactionHash.String())
+		fmt.Fprintf(w, "Na blockchain:\t%t\n", response.InBlockchain)
+		fmt.Fprintf(w, "Mensagem:\t%s\n", response.Message)
+	})
+}
+
+func runElectionListCommand(cmd *cobra.Command, args []string) {
+	response, err := newAPIClient().ListElections(context.Background(), client.ListElectionsOptions{
+		PageOptions: client.PageOptions{Limit: electionLimit, Cursor: electionCursor},
+		ActiveOnly:  electionActive,
+		Status:      electionStatus,
+		CreatedBy:   electionCreatedBy,
+	})
+	if err != nil {
+		exitWithError("Erro ao listar eleições", err)
+	}
+
+	now := valueobjects.Now()
+	printOutput(response, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "ID\tTÍTULO\tSTATUS\tINÍCIO\tFIM")
+		for _, election := range response.Elections {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				election.GetID().String(),
+				election.GetTitle(),
+				election.EffectiveStatus(now),
+				formatTimestamp(election.GetStartTime()),
+				formatTimestamp(election.GetEndTime()),
+			)
+		}
+	})
+	printNextPage(response.HasMore, response.NextCursor)
+}
+
+func runElectionShowCommand(cmd *cobra.Command, args []string) {
+	response, err := newAPIClient().GetElection(context.Background(), args[0])
+	if err != nil {
+		exitWithError("Erro ao obter eleição", err)
+	}
+
+	election := response.Election
+	printOutput(response, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "ID:\t%s\n", election.GetID().String())
+		fmt.Fprintf(w, "Título:\t%s\n", election.GetTitle())
+		fmt.Fprintf(w, "Descrição:\t%s\n", election.GetDescription())
+		fmt.Fprintf(w, "Status:\t%s\n", election.EffectiveStatus(valueobjects.Now()))
+		fmt.Fprintf(w, "Início:\t%s\n", formatTimestamp(election.GetStartTime()))
+		fmt.Fprintf(w, "Fim:\t%s\n", formatTimestamp(election.GetEndTime()))
+		fmt.Fprintf(w, "Criada por:\t%s\n", election.GetCreatedBy().String())
+		fmt.Fprintf(w, "Voto anônimo:\t%t\n", election.AllowsAnonymousVoting())
+		fmt.Fprintf(w, "Votos por eleitor:\t%d\n", election.GetMaxVotesPerVoter())
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "CANDIDATO\tNOME\tDESCRIÇÃO")
+		for _, candidate := range election.GetCandidates() {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", candidate.ID, candidate.Name, candidate.Description)
+		}
+	})
+}
+
+func runElectionResultsCommand(cmd *cobra.Command, args []string) {
+	// Resultados contados diretamente dos votos na blockchain
+	response, err := newAPIClient().CountVotes(context.Background(), args[0])
+	if err != nil {
+		exitWithError("Erro ao obter resultados", err)
+	}
+
+	printOutput(response, func(w *tabwriter.Writer) {
+		printVoteCount(w, response)
+	})
+}
+
+// printVoteCount escreve os candidatos ordenados por votos e o vencedor
+func printVoteCount(w *tabwriter.Writer, response *usecases.CountVotesResponse) {
+	results := append([]usecases.CandidateResult(nil), response.Results...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].VoteCount > results[j].VoteCount
+	})
+
+	fmt.Fprintf(w, "Eleição:\t%s\n", response.ElectionTitle)
+	fmt.Fprintf(w, "Total de votos:\t%d\n", response.TotalVotes)
+	switch {
+	case response.IsTie:
+		fmt.Fprintf(w, "Vencedor:\tempate\n")
+	case response.Winner != nil:
+		fmt.Fprintf(w, "Vencedor:\t%s (%s)\n", response.Winner.CandidateName, response.Winner.CandidateID)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "CANDIDATO\tNOME\tVOTOS\t%")
+	for _, result := range results {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%.1f\n", result.CandidateID, result.CandidateName, result.VoteCount, result.Percentage)
+	}
+}
+
+func runElectionCloseCommand(cmd *cobra.Command, args []string) {
+	response, err := newAPIClient().UpdateElectionStatus(context.Background(), args[0], &handlers.UpdateElectionStatusRequest{
+		NewStatus: string(entities.ElectionClosed),
+		UpdatedBy: nodeID,
+	})
+	if err != nil {
+		exitWithError("Erro ao encerrar eleição", err)
+	}
+
+	printOutput(response, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "ID:\t%s\n", response.Election.GetID().String())
+		fmt.Fprintf(w, "Atualizada:\t%t\n", response.Updated)
+		fmt.Fprintf(w, "Mensagem:\t%s\n", response.Message)
+	})
+}
+
+func runElectionCertifyCommand(cmd *cobra.Command, args []string) {
+	response, err := newAPIClient().CertifyResults(context.Background(), args[0])
+	if err != nil {
+		exitWithError("Erro ao certificar resultado", err)
+	}
+
+	printOutput(response, func(w *tabwriter.Writer) {
+		document := response.Document
+		fmt.Fprintf(w, "Eleição:\t%s (%s)\n", document.Election.Title, response.ElectionID.String())
+		fmt.Fprintf(w, "Documento:\t%s\n", response.DocumentHash)
+		fmt.Fprintf(w, "Topo apurado:\t%d (%s)\n", document.HeadHeight, document.HeadHash)
+		fmt.Fprintf(w, "Blocos:\t%d-%d\n", document.Blocks.First, document.Blocks.Last)
+		fmt.Fprintf(w, "Total de votos:\t%d\n", document.TotalVotes)
+		fmt.Fprintf(w, "Assinaturas necessárias:\t%d de %d\n", response.Required, response.Validators)
+		if !response.TransactionHash.IsEmpty() {
+			fmt.Fprintf(w, "Transação:\t%s\n", response.TransactionHash.String())
+		}
+		fmt.Fprintf(w, "Mensagem:\t%s\n", response.Message)
+	})
+}
+
+func runElectionCertificateCommand(cmd *cobra.Command, args []string) {
+	certificate, err := newAPIClient().GetCertificate(context.Background(), args[0])
+	if err != nil {
+		exitWithError("Erro ao obter certificado", err)
+	}
+
+	if certificateFile != "" {
+		var buffer bytes.Buffer
+		if err := certification.Write(&buffer, certificate); err != nil {
+			exitWithError("Erro ao serializar certificado", err)
+		}
+		if err := os.WriteFile(certificateFile, buffer.Bytes(), 0644); err != nil {
+			exitWithError("Erro ao salvar certificado", err)
+		}
+	}
+
+	printOutput(certificate, func(w *tabwriter.Writer) {
+		if certificateFile != "" {
+			fmt.Fprintf(w, "✅ Certificado salvo:\t%s\n", certificateFile)
+		}
+		printCertificate(w, certificate)
+	})
+}
+
+// printCertificate escreve o documento de resultado e as assinaturas de um certificado
+func printCertificate(w *tabwriter.Writer, certificate *certification.Certificate) {
+	document := certificate.Document
+	status := "⏳ aguardando assinaturas"
+	if certificate.Certified {
+		status = fmt.Sprintf("✅ certificado no bloco %d", certificate.CertifiedAt)
+	}
+
+	fmt.Fprintf(w, "Eleição:\t%s (%s)\n", document.Election.Title, certificate.ElectionID)
+	fmt.Fprintf(w, "Status:\t%s\n", status)
+	fmt.Fprintf(w, "Documento:\t%s\n", certificate.DocumentHash)
+	fmt.Fprintf(w, "Proposto por:\t%s no bloco %d\n", certificate.Proposer, certificate.ProposedAt)
+	fmt.Fprintf(w, "Topo apurado:\t%d (%s)\n", document.HeadHeight, document.HeadHash)
+	fmt.Fprintf(w, "Blocos:\t%d-%d\n", document.Blocks.First, document.Blocks.Last)
+	fmt.Fprintf(w, "Auditoria:\t%d votos, %d válidos, %d inválidos (integridade %.1f%%)\n",
+		document.Audit.TotalVotes, document.Audit.ValidVotes, document.Audit.InvalidVotes, document.Audit.IntegrityScore)
+	fmt.Fprintf(w, "Assinaturas:\t%d de %d (%d necessárias)\n", len(certificate.Signatures), len(certificate.Validators), certificate.Required)
+
+	fmt.Fprintln(w, "\nCANDIDATO\tNOME\tVOTOS")
+	for _, entry := range document.Tally {
+		fmt.Fprintf(w, "%s\t%s\t%d\n", entry.CandidateID, entry.Name, entry.Votes)
+	}
+
+	fmt.Fprintln(w, "\nVALIDADOR\tBLOCO\tTRANSAÇÃO")
+	for _, signature := range certificate.Signatures {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", signature.Validator, signature.Height, signature.TxHash)
+	}
+}
+
+func runElectionVerifyCertificateCommand(cmd *cobra.Command, args []string) {
+	file, err := os.Open(certificateFile)
+	if err != nil {
+		exitWithError("Erro ao ler certificado", err)
+	}
+	certificate, err := certification.Read(file)
+	file.Close()
+	if err != nil {
+		exitWithError("Erro ao ler certificado", err)
+	}
+
+	ctx := context.Background()
+	cryptoService := crypto.NewECDSAService()
+
+	// A âncora só é usada depois que o arquivo da cadeia passa na própria verificação
+	var anchor *certification.Anchor
+	if certificateChain != "" {
+		chainArchive, _ := readChainArchive(certificateChain)
+		if chainReport := archive.Verify(ctx, chainArchive, cryptoService); !chainReport.Valid {
+			printOutput(chainReport, func(w *tabwriter.Writer) {
+				printChainReport(w, chainReport)
+			})
+			os.Exit(exitAuditFailed)
+		}
+		anchor, err = certification.NewArchiveAnchor(chainArchive, certificate.Document.HeadHeight)
+		if err != nil {
+			exitWithError("Erro ao ler arquivo da cadeia", err)
+		}
+	}
+
+	report := certification.Verify(ctx, cryptoService, certificate, anchor)
+
+	printOutput(report, func(w *tabwriter.Writer) {
+		result := "✅ válido"
+		if !report.Valid {
+			result = "❌ inválido"
+		}
+		anchored := "conjunto declarado no certificado"
+		if report.Anchored {
+			anchored = "arquivo da cadeia " + certificateChain
+		}
+		fmt.Fprintf(w, "Certificado:\t%s\n", result)
+		fmt.Fprintf(w, "Eleição:\t%s (%s)\n", valueOrDash(report.ElectionTitle), report.ElectionID)
+		fmt.Fprintf(w, "Documento:\t%s\n", report.DocumentHash)
+		fmt.Fprintf(w, "Topo apurado:\t%d (%s)\n", report.HeadHeight, valueOrDash(report.HeadHash))
+		fmt.Fprintf(w, "Total de votos:\t%d\n", report.TotalVotes)
+		fmt.Fprintf(w, "Assinaturas válidas:\t%d de %d (%d necessárias)\n", len(report.Signers), report.Validators, report.Required)
+		fmt.Fprintf(w, "Âncora:\t%s\n", anchored)
+		fmt.Fprintf(w, "Verificado em:\t%s\n", formatUnix(report.VerifiedAt))
+
+		fmt.Fprintln(w, "\nVERIFICAÇÃO\tRESULTADO\tERRO")
+		for _, check := range report.Checks {
+			status := "ok"
+			if !check.OK {
+				status = "falhou"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", check.Name, status, check.Error)
+		}
+	})
+
+	if !report.Valid {
+		os.Exit(exitAuditFailed)
+	}
+}
@@ -0,0 +1,338 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// Flags dos comandos de modelos e candidatos
+	templateFile      string
+	candidatesOffline bool
+)
+
+// electionTemplateCmd agrupa os comandos de modelos de eleição
+var electionTemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Gerencia os modelos de eleição mantidos pelo nó",
+	Long: `Modelos de eleição guardam as regras reaproveitadas na criação de eleições: tipo de
+cédula, duração, anonimato e votos por eleitor. Ficam no diretório de dados do nó
+(election_templates.json) e não são registrados na cadeia.
+
+Exemplos:
+  peer-vote election template create --file conselho.yaml
+  peer-vote election template list
+  peer-vote election template show conselho
+  peer-vote election create --template conselho --title "Conselho 2026" \
+    --start 2026-03-01T09:00:00Z --candidates candidatos.csv`,
+}
+
+// electionTemplateCreateCmd cria um modelo a partir de um arquivo
+var electionTemplateCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Cria um modelo de eleição a partir de um arquivo YAML ou JSON",
+	Long: `Cria um modelo descrito em um arquivo YAML ou JSON:
+
+  name: conselho
+  description: Eleição anual do conselho
+  ballot_type: single_choice      # ou multiple_choice (max_votes_per_voter >= 2)
+  duration_minutes: 480           # duração quando a eleição não informa o fim
+  min_duration_minutes: 240       # faixa aceita (0 não limita)
+  max_duration_minutes: 1440
+  allow_anonymous: false
+  max_votes_per_voter: 1
+
+As durações devem ser compatíveis com voting.validation.min/max_election_duration do nó.`,
+	Args: cobra.NoArgs,
+	Run:  runElectionTemplateCreateCommand,
+}
+
+// electionTemplateUpdateCmd substitui as regras de um modelo
+var electionTemplateUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Substitui as regras de um modelo (mesmo arquivo de template create)",
+	Args:  cobra.NoArgs,
+	Run:   runElectionTemplateUpdateCommand,
+}
+
+// electionTemplateListCmd lista os modelos
+var electionTemplateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lista os modelos de eleição do nó",
+	Args:  cobra.NoArgs,
+	Run:   runElectionTemplateListCommand,
+}
+
+// electionTemplateShowCmd mostra um modelo
+var electionTemplateShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Mostra as regras de um modelo",
+	Args:  cobra.ExactArgs(1),
+	Run:   runElectionTemplateShowCommand,
+}
+
+// electionTemplateDeleteCmd remove um modelo
+var electionTemplateDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Remove um modelo (eleições já criadas não são afetadas)",
+	Args:  cobra.ExactArgs(1),
+	Run:   runElectionTemplateDeleteCommand,
+}
+
+// electionCandidatesCmd valida um CSV de candidatos
+var electionCandidatesCmd = &cobra.Command{
+	Use:   "candidates <file.csv>",
+	Short: "Valida um CSV de candidatos antes de criar a eleição",
+	Long: `Valida um CSV de candidatos com cabeçalho id,name[,description] (colunas em qualquer
+ordem) pelas regras de eleição: ID e nome obrigatórios, IDs e nomes únicos e ao menos
+2 candidatos. Os problemas são informados por linha do arquivo.
+
+  id,name,description
+  alice,Alice Souza,Chapa 1
+  bob,Bob Lima,Chapa 2
+
+Por padrão o arquivo é validado pelo nó (--api); com --offline, localmente.
+
+Código de saída: 0 se o arquivo é válido, 2 se é inválido, 1 em erros de execução.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runElectionCandidatesCommand,
+}
+
+func init() {
+	electionCmd.AddCommand(electionTemplateCmd, electionCandidatesCmd)
+	electionTemplateCmd.AddCommand(electionTemplateCreateCmd, electionTemplateUpdateCmd, electionTemplateListCmd,
+		electionTemplateShowCmd, electionTemplateDeleteCmd)
+
+	for _, cmd := range []*cobra.Command{electionTemplateCreateCmd, electionTemplateUpdateCmd} {
+		cmd.Flags().StringVarP(&templateFile, "file", "f", "", "arquivo YAML ou JSON do modelo")
+		cmd.MarkFlagRequired("file")
+	}
+
+	electionCandidatesCmd.Flags().BoolVar(&candidatesOffline, "offline", false, "validar localmente, sem consultar o nó")
+}
+
+// templateSpec é o formato do arquivo aceito por election template create/update
+type templateSpec struct {
+	Name               string `yaml:"name"`
+	Description        string `yaml:"description"`
+	BallotType         string `yaml:"ballot_type"`
+	DurationMinutes    int    `yaml:"duration_minutes"`
+	MinDurationMinutes int    `yaml:"min_duration_minutes"`
+	MaxDurationMinutes int    `yaml:"max_duration_minutes"`
+	AllowAnonymous     bool   `yaml:"allow_anonymous"`
+	MaxVotesPerVoter   int    `yaml:"max_votes_per_voter"`
+	CreatedBy          string `yaml:"created_by"`
+}
+
+// loadTemplateSpec lê o arquivo do modelo (JSON é um subconjunto de YAML)
+func loadTemplateSpec(path string) (*entities.ElectionTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec templateSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("invalid template file: %w", err)
+	}
+
+	template := &entities.ElectionTemplate{
+		Name:               spec.Name,
+		Description:        spec.Description,
+		BallotType:         entities.BallotType(spec.BallotType),
+		DurationMinutes:    spec.DurationMinutes,
+		MinDurationMinutes: spec.MinDurationMinutes,
+		MaxDurationMinutes: spec.MaxDurationMinutes,
+		AllowAnonymous:     spec.AllowAnonymous,
+		MaxVotesPerVoter:   spec.MaxVotesPerVoter,
+		CreatedBy:          spec.CreatedBy,
+	}
+	if template.CreatedBy == "" {
+		template.CreatedBy = nodeID
+	}
+
+	// Erros de regra são informados antes de consultar o nó
+	template.Normalize()
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// loadCandidatesCSV lê e valida localmente um CSV de candidatos
+func loadCandidatesCSV(path string) (*usecases.CandidateImport, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return usecases.ImportCandidatesCSV(file)
+}
+
+func runElectionTemplateCreateCommand(cmd *cobra.Command, args []string) {
+	template, err := loadTemplateSpec(templateFile)
+	if err != nil {
+		exitWithError("Erro ao ler arquivo do modelo", err)
+	}
+
+	created, err := newAPIClient().CreateElectionTemplate(context.Background(), template)
+	if err != nil {
+		exitWithError("Erro ao criar modelo", err)
+	}
+
+	printOutput(created, func(w *tabwriter.Writer) {
+		printElectionTemplate(w, created)
+	})
+}
+
+func runElectionTemplateUpdateCommand(cmd *cobra.Command, args []string) {
+	template, err := loadTemplateSpec(templateFile)
+	if err != nil {
+		exitWithError("Erro ao ler arquivo do modelo", err)
+	}
+
+	updated, err := newAPIClient().UpdateElectionTemplate(context.Background(), template)
+	if err != nil {
+		exitWithError("Erro ao atualizar modelo", err)
+	}
+
+	printOutput(updated, func(w *tabwriter.Writer) {
+		printElectionTemplate(w, updated)
+	})
+}
+
+func runElectionTemplateListCommand(cmd *cobra.Command, args []string) {
+	response, err := newAPIClient().ListElectionTemplates(context.Background())
+	if err != nil {
+		exitWithError("Erro ao listar modelos", err)
+	}
+
+	printOutput(response, func(w *tabwriter.Writer) {
+		fmt.Fprintln(w, "NOME\tCÉDULA\tVOTOS POR ELEITOR\tANÔNIMO\tDURAÇÃO\tFAIXA")
+		for _, template := range response.Templates {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%t\t%s\t%s\n",
+				template.Name,
+				template.BallotType,
+				template.MaxVotesPerVoter,
+				template.AllowAnonymous,
+				formatTemplateMinutes(template.DurationMinutes),
+				formatTemplateRange(template),
+			)
+		}
+	})
+}
+
+func runElectionTemplateShowCommand(cmd *cobra.Command, args []string) {
+	template, err := newAPIClient().GetElectionTemplate(context.Background(), args[0])
+	if err != nil {
+		exitWithError("Erro ao obter modelo", err)
+	}
+
+	printOutput(template, func(w *tabwriter.Writer) {
+		printElectionTemplate(w, template)
+	})
+}
+
+func runElectionTemplateDeleteCommand(cmd *cobra.Command, args []string) {
+	if err := newAPIClient().DeleteElectionTemplate(context.Background(), args[0]); err != nil {
+		exitWithError("Erro ao remover modelo", err)
+	}
+
+	response := map[string]string{"deleted": args[0]}
+	printOutput(response, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "✅ Modelo removido:\t%s\n", args[0])
+	})
+}
+
+func runElectionCandidatesCommand(cmd *cobra.Command, args []string) {
+	var result *usecases.CandidateImport
+	var err error
+	if candidatesOffline {
+		result, err = loadCandidatesCSV(args[0])
+	} else {
+		var data []byte
+		if data, err = os.ReadFile(args[0]); err == nil {
+			result, err = newAPIClient().ImportCandidates(context.Background(), string(data))
+		}
+	}
+	if err != nil {
+		exitWithError("Erro ao validar candidatos", err)
+	}
+
+	printOutput(result, func(w *tabwriter.Writer) {
+		printCandidateImport(w, result)
+	})
+
+	if !result.Valid {
+		os.Exit(exitAuditFailed)
+	}
+}
+
+// printElectionTemplate escreve as regras de um modelo
+func printElectionTemplate(w *tabwriter.Writer, template *entities.ElectionTemplate) {
+	fmt.Fprintf(w, "Nome:\t%s\n", template.Name)
+	if template.Description != "" {
+		fmt.Fprintf(w, "Descrição:\t%s\n", template.Description)
+	}
+	fmt.Fprintf(w, "Cédula:\t%s\n", template.BallotType)
+	fmt.Fprintf(w, "Votos por eleitor:\t%d\n", template.MaxVotesPerVoter)
+	fmt.Fprintf(w, "Voto anônimo:\t%t\n", template.AllowAnonymous)
+	fmt.Fprintf(w, "Duração padrão:\t%s\n", formatTemplateMinutes(template.DurationMinutes))
+	fmt.Fprintf(w, "Faixa de duração:\t%s\n", formatTemplateRange(template))
+	if template.CreatedBy != "" {
+		fmt.Fprintf(w, "Criado por:\t%s\n", template.CreatedBy)
+	}
+	fmt.Fprintf(w, "Criado em:\t%s\n", formatUnix(template.CreatedAt))
+	fmt.Fprintf(w, "Atualizado em:\t%s\n", formatUnix(template.UpdatedAt))
+}
+
+// printCandidateImport escreve os candidatos aceitos e os problemas por linha
+func printCandidateImport(w *tabwriter.Writer, result *usecases.CandidateImport) {
+	if result.Valid {
+		fmt.Fprintf(w, "✅ %d candidatos válidos\n", len(result.Candidates))
+	} else {
+		fmt.Fprintf(w, "❌ %d problemas encontrados\n", len(result.Errors))
+	}
+
+	fmt.Fprintln(w, "\nCANDIDATO\tNOME\tDESCRIÇÃO")
+	for _, candidate := range result.Candidates {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", candidate.ID, candidate.Name, candidate.Description)
+	}
+
+	if len(result.Errors) > 0 {
+		fmt.Fprintln(w, "\nLINHA\tPROBLEMA")
+		for _, importErr := range result.Errors {
+			line := "-"
+			if importErr.Line > 0 {
+				line = fmt.Sprintf("%d", importErr.Line)
+			}
+			fmt.Fprintf(w, "%s\t%s\n", line, importErr.Message)
+		}
+	}
+}
+
+// formatTemplateMinutes formata uma duração do modelo em minutos (0 = sem valor)
+func formatTemplateMinutes(minutes int) string {
+	if minutes == 0 {
+		return "-"
+	}
+	return (time.Duration(minutes) * time.Minute).String()
+}
+
+// formatTemplateRange formata a faixa de duração aceita pelo modelo
+func formatTemplateRange(template *entities.ElectionTemplate) string {
+	if template.MinDurationMinutes == 0 && template.MaxDurationMinutes == 0 {
+		return "-"
+	}
+	return formatTemplateMinutes(template.MinDurationMinutes) + " a " + formatTemplateMinutes(template.MaxDurationMinutes)
+}
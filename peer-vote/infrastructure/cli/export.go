@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/report"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/client"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags do comando export
+	exportFormat    string
+	exportFile      string
+	exportCandidate string
+	exportValid     string
+	exportOrder     string
+)
+
+// exportCmd agrupa as exportações de resultados e auditorias
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exporta resultados, auditorias e relatórios de eleições",
+	Long: `Exportações executadas contra a API REST de um nó (--api). O formato é negociado
+com o nó pelo cabeçalho Accept; a saída vai para a saída padrão ou para --file.
+
+Exemplos:
+  peer-vote export results <election-id> --format csv --file resultado.csv
+  peer-vote export audit <election-id> --format csv --file votos.csv
+  peer-vote export audit <election-id> --format ndjson --valid false
+  peer-vote export report <election-id> --file relatorio.md`,
+}
+
+// exportResultsCmd exporta a apuração por candidato
+var exportResultsCmd = &cobra.Command{
+	Use:   "results <election-id>",
+	Short: "Exporta a apuração por candidato (csv, json ou markdown)",
+	Args:  cobra.ExactArgs(1),
+	Run:   runExportResultsCommand,
+}
+
+// exportAuditCmd exporta os votos auditados
+var exportAuditCmd = &cobra.Command{
+	Use:   "audit <election-id>",
+	Short: "Exporta os votos auditados, um por linha (csv, ndjson ou markdown)",
+	Long: `Exporta todos os votos da eleição com hash da transação, bloco, horário, candidato,
+validade e erros da auditoria. O nó envia as linhas em streaming, sem paginação,
+o que permite exportar eleições grandes.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runExportAuditCommand,
+}
+
+// exportReportCmd exporta o relatório legível da eleição
+var exportReportCmd = &cobra.Command{
+	Use:   "report <election-id>",
+	Short: "Exporta o relatório da eleição (markdown ou json)",
+	Long: `Exporta o relatório da eleição: dados da votação, apuração por candidato com o
+vencedor, resumo da auditoria e a lista de votos inválidos com os erros encontrados.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runExportReportCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.AddCommand(exportResultsCmd, exportAuditCmd, exportReportCmd)
+	exportCmd.PersistentFlags().StringVarP(&exportFile, "file", "f", "", "arquivo de saída (padrão: saída padrão)")
+
+	exportResultsCmd.Flags().StringVar(&exportFormat, "format", "csv", "formato: csv, json ou markdown")
+
+	exportAuditCmd.Flags().StringVar(&exportFormat, "format", "csv", "formato: csv, ndjson ou markdown")
+	exportAuditCmd.Flags().StringVar(&exportCandidate, "candidate", "", "apenas votos no candidato")
+	exportAuditCmd.Flags().StringVar(&exportValid, "valid", "", "apenas votos válidos (true) ou inválidos (false)")
+	exportAuditCmd.Flags().StringVar(&exportOrder, "order", "", "ordem pela posição na cadeia (asc ou desc)")
+
+	exportReportCmd.Flags().StringVar(&exportFormat, "format", "markdown", "formato: markdown ou json")
+}
+
+func runExportResultsCommand(cmd *cobra.Command, args []string) {
+	format := parseExportFormat()
+	runExport(func(w io.Writer) error {
+		return newAPIClient().ExportResults(context.Background(), args[0], format, w)
+	})
+}
+
+func runExportAuditCommand(cmd *cobra.Command, args []string) {
+	format := parseExportFormat()
+
+	options := client.AuditVotesOptions{
+		PageOptions: client.PageOptions{Order: exportOrder},
+		CandidateID: exportCandidate,
+	}
+	if exportValid != "" {
+		valid, err := strconv.ParseBool(exportValid)
+		if err != nil {
+			exitWithError("Valor inválido para --valid", err)
+		}
+		options.Valid = &valid
+	}
+
+	runExport(func(w io.Writer) error {
+		return newAPIClient().ExportAudit(context.Background(), args[0], options, format, w)
+	})
+}
+
+func runExportReportCommand(cmd *cobra.Command, args []string) {
+	format := parseExportFormat()
+	runExport(func(w io.Writer) error {
+		return newAPIClient().ExportReport(context.Background(), args[0], format, w)
+	})
+}
+
+// parseExportFormat valida --format
+func parseExportFormat() report.Format {
+	format, err := report.ParseFormat(exportFormat)
+	if err != nil {
+		exitWithError("Formato inválido", err)
+	}
+	return format
+}
+
+// runExport escreve a exportação na saída padrão ou em --file (removido se a exportação falhar)
+func runExport(export func(w io.Writer) error) {
+	if exportFile == "" {
+		if err := export(os.Stdout); err != nil {
+			exitWithError("Erro ao exportar", err)
+		}
+		return
+	}
+
+	file, err := os.Create(exportFile)
+	if err != nil {
+		exitWithError("Erro ao criar arquivo", err)
+	}
+	err = export(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(exportFile)
+		exitWithError("Erro ao exportar", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "✅ Exportado: %s\n", exportFile)
+}
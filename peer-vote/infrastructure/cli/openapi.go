@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/health"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags do comando openapi
+	openAPIOffline bool
+	openAPIOutput  string
+)
+
+// openAPICmd exporta a especificação OpenAPI 3 da API REST
+var openAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Exporta a especificação OpenAPI 3 da API REST",
+	Long: `Obtém a especificação OpenAPI 3 servida pelo nó em --api (/api/v1/openapi.json)
+ou, com --offline, gera a especificação das rotas desta versão sem consultar um nó.
+
+Exemplos:
+  peer-vote openapi --api http://localhost:8080
+  peer-vote openapi --offline --output docs/openapi.json`,
+	Run: runOpenAPICommand,
+}
+
+func init() {
+	rootCmd.AddCommand(openAPICmd)
+
+	openAPICmd.Flags().BoolVar(&openAPIOffline, "offline", false, "gerar a especificação localmente, sem consultar o nó")
+	openAPICmd.Flags().StringVarP(&openAPIOutput, "output", "o", "", "arquivo de saída (padrão: stdout)")
+}
+
+func runOpenAPICommand(cmd *cobra.Command, args []string) {
+	var spec []byte
+	if openAPIOffline {
+		// Servidor sem dependências: apenas as rotas são necessárias
+		spec = rest.NewServer(nil, &rest.Dependencies{
+			Health: health.NewChecker(health.DefaultConfig(), health.Sources{}),
+		}).OpenAPISpec()
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		raw, err := newAPIClient().GetOpenAPISpec(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Erro ao obter especificação: %v\n", err)
+			os.Exit(1)
+		}
+
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, raw, "", "  "); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Especificação inválida: %v\n", err)
+			os.Exit(1)
+		}
+		spec = indented.Bytes()
+	}
+	spec = append(spec, '\n')
+
+	if openAPIOutput == "" {
+		os.Stdout.Write(spec)
+		return
+	}
+	if err := os.WriteFile(openAPIOutput, spec, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Erro ao gravar %s: %v\n", openAPIOutput, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Especificação gravada em %s\n", openAPIOutput)
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/spf13/cobra"
+)
+
+// Formatos de saída dos comandos cliente
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// exitAuditFailed é o código de saída quando uma verificação (auditoria, validação) falha,
+// distinto do código 1 usado para erros de execução
+const exitAuditFailed = 2
+
+var (
+	// Flag --output dos comandos cliente
+	outputFormat string
+)
+
+// addOutputFlag registra --output no grupo de comandos e valida o formato antes de executá-los
+func addOutputFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&outputFormat, "output", outputTable, "formato de saída: table ou json")
+	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if outputFormat != outputTable && outputFormat != outputJSON {
+			return fmt.Errorf("invalid output format %q (use table or json)", outputFormat)
+		}
+		return nil
+	}
+}
+
+// printOutput escreve v como JSON ou chama table para a saída tabular
+func printOutput(v interface{}, table func(w *tabwriter.Writer)) {
+	if outputFormat == outputJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(v); err != nil {
+			exitWithError("Erro ao serializar resposta", err)
+		}
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	table(w)
+	w.Flush()
+}
+
+// exitWithError encerra o comando com a mensagem de erro padrão da CLI
+func exitWithError(message string, err error) {
+	fmt.Fprintf(os.Stderr, "❌ %s: %v\n", message, err)
+	os.Exit(1)
+}
+
+// printNextPage indica como continuar uma listagem paginada (apenas na saída tabular)
+func printNextPage(hasMore bool, nextCursor string) {
+	if hasMore && outputFormat == outputTable {
+		fmt.Fprintf(os.Stderr, "… mais resultados: use --cursor %s\n", nextCursor)
+	}
+}
+
+// formatTimestamp formata timestamps da API na hora local
+func formatTimestamp(ts valueobjects.Timestamp) string {
+	if ts.IsZero() {
+		return "-"
+	}
+	return ts.Time().Local().Format("2006-01-02 15:04:05")
+}
+
+// formatUnix formata segundos Unix na hora local
+func formatUnix(seconds int64) string {
+	if seconds == 0 {
+		return "-"
+	}
+	return time.Unix(seconds, 0).Local().Format("2006-01-02 15:04:05")
+}
@@ -51,7 +51,7 @@ func init() {
 func initConfig() {
 	if configFile != "" {
 		// Usar arquivo de configuração especificado pela flag
-		fmt.Printf("📄 Usando arquivo de configuração: %s\n", configFile)
+		fmt.Fprintf(os.Stderr, "📄 Usando arquivo de configuração: %s\n", configFile)
 	} else {
 		// Procurar por arquivo de configuração no diretório home
 		home, err := os.UserHomeDir()
@@ -60,11 +60,11 @@ func initConfig() {
 		configPath := home + "/.peer-vote.yaml"
 		if _, err := os.Stat(configPath); err == nil {
 			configFile = configPath
-			fmt.Printf("📄 Arquivo de configuração encontrado: %s\n", configFile)
+			fmt.Fprintf(os.Stderr, "📄 Arquivo de configuração encontrado: %s\n", configFile)
 		}
 	}
 
 	if verbose {
-		fmt.Println("🔍 Modo verboso ativado")
+		fmt.Fprintln(os.Stderr, "🔍 Modo verboso ativado")
 	}
 }
@@ -4,18 +4,30 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/matscats/peer-vote/peer-vote/application/usecases"
 	"github.com/matscats/peer-vote/peer-vote/domain/services"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/certification"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/config"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/events"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/health"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/metrics"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/network"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/persistence"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/auth"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/handlers"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/snapshot"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +38,7 @@ var (
 	p2pPort     int
 	enableRest  bool
 	enableP2P   bool
+	dataDir     string
 )
 
 // startCmd representa o comando start
@@ -49,6 +62,7 @@ func init() {
 	startCmd.Flags().IntVar(&p2pPort, "p2p-port", 9000, "porta da rede P2P")
 	startCmd.Flags().BoolVar(&enableRest, "enable-rest", true, "habilitar servidor REST API")
 	startCmd.Flags().BoolVar(&enableP2P, "enable-p2p", true, "habilitar rede P2P")
+	startCmd.Flags().StringVar(&dataDir, "data-dir", "./data", "diretório de dados do nó")
 }
 
 func runStartCommand(cmd *cobra.Command, args []string) {
@@ -62,6 +76,13 @@ func runStartCommand(cmd *cobra.Command, args []string) {
 		fmt.Printf("   - REST API: %s:%d (habilitado: %v)\n", restHost, restPort, enableRest)
 		fmt.Printf("   - P2P: porta %d (habilitado: %v)\n", p2pPort, enableP2P)
 		fmt.Printf("   - Node ID: %s\n", nodeID)
+		fmt.Printf("   - Diretório de dados: %s\n", dataDir)
+	}
+
+	// Carregar configuração (padrões quando nenhum arquivo é informado)
+	cfg, err := config.Load(configFile)
+	if err != nil {
+		log.Fatalf("❌ Erro ao carregar configuração: %v", err)
 	}
 
 	// 1. Inicializar serviços base
@@ -81,23 +102,167 @@ func runStartCommand(cmd *cobra.Command, args []string) {
 	}
 	
 	myNodeID := cryptoService.GenerateNodeID(ctx, keyPair.PublicKey)
+
+	// Logs estruturados (logging); todos os registros levam o Node ID
+	logManager, err := logging.NewManager(logging.Config{
+		Level:      cfg.Logging.Level,
+		Format:     cfg.Logging.EffectiveFormat(),
+		Output:     cfg.Logging.Output,
+		Components: cfg.Logging.Components,
+	}, myNodeID)
+	if err != nil {
+		log.Fatalf("❌ Erro ao configurar logs: %v", err)
+	}
+	defer logManager.Close()
+	slog.SetDefault(logManager.Logger("node"))
+	if verbose {
+		logManager.SetLevel("", slog.LevelDebug)
+	}
+	chainManager.SetLogger(logManager.Logger("chain"))
 	
-	// Serviços de consenso
+	// Serviços de consenso (engine selecionado na configuração)
 	validatorManager := consensus.NewValidatorManager()
-	poaEngine := consensus.NewPoAEngine(validatorManager, chainManager, cryptoService, myNodeID, keyPair.PrivateKey, nil)
+	var poaEngine *consensus.PoAEngine
+	var bftEngine *consensus.BFTEngine
+	var consensusService consensus.Engine
+	
+	switch cfg.Consensus.Engine {
+	case config.ConsensusEngineBFT:
+		bftEngine = consensus.NewBFTEngine(validatorManager, chainManager, cryptoService, myNodeID, keyPair.PrivateKey, nil, newBFTConfig(cfg))
+		bftEngine.SetLogger(logManager.Logger("consensus"))
+		consensusService = bftEngine
+	default:
+		poaEngine = consensus.NewPoAEngine(validatorManager, chainManager, cryptoService, myNodeID, keyPair.PrivateKey, nil)
+		poaEngine.SetLogger(logManager.Logger("consensus"))
+		consensusService = consensus.NewConsensusAdapter(poaEngine)
+	}
+	fmt.Printf("⚖️  Consenso: %s\n", cfg.Consensus.Engine)
+	
+	// Pool de transações pendentes do engine selecionado
+	var mempool *consensus.Mempool
+	if bftEngine != nil {
+		mempool = bftEngine.GetMempool()
+	} else {
+		mempool = poaEngine.GetMempool()
+	}
+	mempool.SetConfig(newMempoolConfig(cfg))
+	if err := mempool.Rebuild(ctx); err != nil {
+		log.Printf("⚠️  Erro ao carregar transações incluídas no mempool: %v", err)
+	}
+	
+	// Alterações do conjunto de validadores aprovadas na cadeia
+	validatorGovernance := consensus.NewValidatorGovernance(validatorManager, chainManager, cryptoService)
+	validatorGovernance.SetLogger(logManager.Logger("governance"))
+	if err := validatorGovernance.Rebuild(ctx); err != nil {
+		log.Printf("⚠️  Erro ao reconstruir conjunto de validadores: %v", err)
+	}
+	
+	// Penalidades derivadas da cadeia (slots perdidos, assinatura dupla e propostas inválidas)
+	penaltySystem := consensus.NewPenaltySystem(validatorManager)
+	doubleSignDetector := consensus.NewDoubleSignDetector(validatorManager, chainManager, cryptoService)
+	doubleSignDetector.SetLogger(logManager.Logger("penalties"))
+	penaltyLedger := consensus.NewPenaltyLedger(validatorManager, chainManager, penaltySystem, doubleSignDetector)
+	penaltyLedger.SetLogger(logManager.Logger("penalties"))
+	// No BFT um bloco decidido em round > 0 vem do proposer seguinte, não do validador da vez
+	penaltyLedger.SetSlotAccounting(bftEngine == nil)
+	if err := penaltyLedger.Replay(ctx); err != nil {
+		log.Printf("⚠️  Erro ao recalcular penalidades: %v", err)
+	}
+	
+	// Certificados de resultado (propostas e co-assinaturas registradas na cadeia)
+	certificateRegistry := certification.NewRegistry(validatorManager, chainManager, cryptoService)
+	certificateRegistry.SetLogger(logManager.Logger("certification"))
+	if err := certificateRegistry.Rebuild(ctx); err != nil {
+		log.Printf("⚠️  Erro ao carregar certificados de resultado: %v", err)
+	}
+	
+	// Eventos da cadeia para assinantes da API (blocos, transações, eleições, apurações e reorganizações)
+	eventHub := events.NewEventHub(chainManager, nil)
+	eventHub.SetLogger(logManager.Logger("events"))
+	if err := eventHub.Rebuild(ctx); err != nil {
+		log.Printf("⚠️  Erro ao carregar estado das eleições para eventos: %v", err)
+	}
+	mempool.AddTxListener(eventHub.HandleTransaction)
+	if bftEngine != nil {
+		bftEngine.SetCallbacks(eventHub.HandleBlock, nil)
+	} else {
+		poaEngine.SetCallbacks(eventHub.HandleBlock, nil)
+	}
+	eventHub.Start(ctx)
+	
+	// Snapshots de estado nas alturas de checkpoint, servidos aos peers e usados no bootstrap
+	var snapshotManager *snapshot.Manager
+	if cfg.Blockchain.Snapshot.Enabled {
+		snapshotManager = snapshot.NewManager(snapshot.Target{
+			Chain:      chainManager,
+			Governance: validatorGovernance,
+			Ledger:     penaltyLedger,
+			Mempool:    mempool,
+			Events:     eventHub,
+		}, validatorManager, cryptoService, newSnapshotConfig(cfg))
+		snapshotManager.SetLogger(logManager.Logger("snapshot"))
+		snapshotManager.SetNodeKey(myNodeID, keyPair.PrivateKey)
+		snapshotManager.Start(ctx)
+	}
 	
 	// Serviços de domínio
 	validationService := services.NewVotingValidator(nil)
 	
 	// Criar adapters para respeitar arquitetura hexagonal
 	blockchainService := blockchain.NewBlockchainAdapter(chainManager)
-	consensusService := consensus.NewConsensusAdapter(poaEngine)
 	
 	// Casos de uso
 	createElectionUseCase := usecases.NewCreateElectionUseCase(cryptoService, validationService, blockchainService, consensusService)
+	// Modelos de eleição mantidos no diretório de dados do nó
+	templateRepository, err := persistence.NewFileElectionTemplateRepository(filepath.Join(dataDir, "election_templates.json"))
+	if err != nil {
+		log.Fatalf("❌ Erro ao carregar modelos de eleição: %v", err)
+	}
+	electionTemplateUseCase := usecases.NewElectionTemplateUseCase(templateRepository, createElectionUseCase)
 	manageElectionUseCase := usecases.NewManageElectionUseCase(validationService, chainManager)
 	submitVoteUseCase := usecases.NewSubmitVoteUseCase(blockchainService, consensusService, cryptoService, validationService)
 	auditVotesUseCase := usecases.NewAuditVotesUseCase(chainManager, cryptoService, validationService)
+	consensusManagerUseCase := usecases.NewConsensusManagerUseCase(consensusService, validatorManager, penaltySystem, validatorGovernance, chainManager, cryptoService)
+	consensusManagerUseCase.SetNodeKey(myNodeID, keyPair.PrivateKey)
+	createElectionUseCase.SetNodeKey(keyPair.PrivateKey)
+	createElectionUseCase.SetDurationLimits(newElectionDurationLimits(cfg))
+	transactionStatusUseCase := usecases.NewTransactionStatusUseCase(chainManager, mempool)
+	certifyResultsUseCase := usecases.NewCertifyResultsUseCase(auditVotesUseCase, chainManager, validatorManager, certificateRegistry, consensusService, cryptoService)
+	certifyResultsUseCase.SetNodeKey(myNodeID, keyPair.PrivateKey)
+	createElectionUseCase.SetLogger(logManager.Logger("elections"))
+	electionTemplateUseCase.SetLogger(logManager.Logger("elections"))
+	manageElectionUseCase.SetLogger(logManager.Logger("elections"))
+	submitVoteUseCase.SetLogger(logManager.Logger("votes"))
+	consensusManagerUseCase.SetLogger(logManager.Logger("consensus-admin"))
+	// O consenso iniciado pela API vive no contexto do nó, não no da requisição. Cada início
+	// deriva dele um contexto e um canal de parada próprios, então o consenso pode ser parado
+	// e reiniciado sem reaproveitar um canal já fechado.
+	consensusManagerUseCase.SetRunContext(ctx)
+	certifyResultsUseCase.SetLogger(logManager.Logger("certification"))
+
+	// Métricas Prometheus (monitoring.metrics)
+	var nodeMetrics *metrics.Metrics
+	if cfg.Monitoring.Metrics.Enabled {
+		nodeMetrics = metrics.NewMetrics(metrics.Sources{
+			Chain:      chainManager,
+			Mempool:    mempool,
+			Validators: validatorManager,
+		})
+		chainManager.AddBlockListener(nodeMetrics.ObserveBlock)
+		submitVoteUseCase.SetVoteObserver(nodeMetrics.ObserveVote)
+	}
+
+	// Verificações de liveness e readiness (monitoring.health)
+	var healthChecker *health.Checker
+	if cfg.Monitoring.Health.Enabled {
+		healthChecker = health.NewChecker(newHealthConfig(cfg), health.Sources{
+			Repository: blockchainRepo,
+			Chain:      chainManager,
+			Consensus:  consensusService,
+			Mempool:    mempool,
+		})
+		healthChecker.SetLogger(logManager.Logger("health"))
+	}
 
 	// Serviço P2P (se habilitado)
 	var p2pService *network.P2PService
@@ -111,6 +276,7 @@ func runStartCommand(cmd *cobra.Command, args []string) {
 			EnableMDNS:      true,
 			EnableDHT:       true,
 			Namespace:       "peer-vote",
+			SyncCheckpointPath: filepath.Join(dataDir, "sync_checkpoint.json"),
 		}
 		
 		p2pService, err = network.NewP2PService(chainManager, poaEngine, cryptoService, p2pConfig)
@@ -118,6 +284,28 @@ func runStartCommand(cmd *cobra.Command, args []string) {
 			log.Fatalf("❌ Erro ao criar serviço P2P: %v", err)
 		}
 		
+		// Consenso passa a propagar blocos, votos e atestações pela rede P2P
+		if bftEngine != nil {
+			p2pService.SetValidatorManager(validatorManager)
+			bftEngine.SetNetworkService(network.NewNetworkAdapter(p2pService))
+		} else {
+			poaEngine.SetNetworkService(network.NewNetworkAdapter(p2pService))
+		}
+		p2pService.SetLogger(logManager.Logger("p2p"))
+		p2pService.SetSyncLogger(logManager.Logger("sync"))
+		p2pService.SetDoubleSignDetector(doubleSignDetector)
+		p2pService.AddOnBlockReceived(eventHub.HandleBlock)
+		if snapshotManager != nil {
+			p2pService.SetSnapshotManager(snapshotManager)
+		}
+		if nodeMetrics != nil {
+			p2pService.SetMessageObserver(nodeMetrics.ObserveMessage)
+			nodeMetrics.SetNetworkSource(p2pService)
+		}
+		if healthChecker != nil {
+			healthChecker.SetNetworkSource(p2pService)
+		}
+		
 		// Iniciar P2P service
 		if err := p2pService.Start(ctx); err != nil {
 			log.Fatalf("❌ Erro ao iniciar P2P: %v", err)
@@ -126,6 +314,22 @@ func runStartCommand(cmd *cobra.Command, args []string) {
 		fmt.Printf("✅ Rede P2P iniciada: %s\n", myNodeID.String()[:16]+"...")
 	}
 
+	// Servidor de métricas; falha ao escutar não derruba o nó
+	var metricsServer *metrics.Server
+	if nodeMetrics != nil {
+		metricsServer = metrics.NewServer(cfg.Monitoring.Metrics.Address, nodeMetrics)
+		metricsServer.SetLogger(logManager.Logger("metrics"))
+		go func() {
+			if err := metricsServer.Start(ctx); err != nil {
+				log.Printf("⚠️  Servidor de métricas indisponível: %v", err)
+			}
+		}()
+	}
+
+	if healthChecker != nil {
+		go healthChecker.Run(ctx, time.Duration(cfg.Monitoring.Health.Interval)*time.Second)
+	}
+
 	fmt.Println("✅ Serviços inicializados com sucesso")
 
 	// 2. Inicializar servidor REST (se habilitado)
@@ -133,25 +337,51 @@ func runStartCommand(cmd *cobra.Command, args []string) {
 	if enableRest {
 		fmt.Printf("🌐 Iniciando servidor REST API em %s:%d...\n", restHost, restPort)
 		
+		authConfig, err := newAuthConfig(cfg)
+		if err != nil {
+			log.Fatalf("❌ Erro na configuração de autenticação: %v", err)
+		}
+		if authConfig.Enabled {
+			fmt.Println("🔐 Autenticação da API habilitada (JWT, requisições assinadas e papéis)")
+		}
+
 		restConfig := &rest.ServerConfig{
-			Host: restHost,
-			Port: restPort,
+			Host:       restHost,
+			Port:       restPort,
+			Auth:       authConfig,
+			HealthPath: cfg.Monitoring.Health.Endpoint,
 		}
 
 		var networkService services.NetworkService
+		var peerStatus handlers.PeerStatusSource
 		if p2pService != nil {
 			networkService = network.NewNetworkAdapter(p2pService)
+			peerStatus = p2pService
 		}
 
 		deps := &rest.Dependencies{
-			CreateElectionUseCase: createElectionUseCase,
-			ManageElectionUseCase: manageElectionUseCase,
-			SubmitVoteUseCase:     submitVoteUseCase,
-			AuditVotesUseCase:     auditVotesUseCase,
-			BlockchainRepository:  blockchainRepo,
-			NetworkService:        networkService,
-			ChainManager:          chainManager,
-			CryptoService:         cryptoService,
+			CreateElectionUseCase:    createElectionUseCase,
+			ElectionTemplateUseCase:  electionTemplateUseCase,
+			ManageElectionUseCase:    manageElectionUseCase,
+			SubmitVoteUseCase:        submitVoteUseCase,
+			AuditVotesUseCase:        auditVotesUseCase,
+			ConsensusManagerUseCase:  consensusManagerUseCase,
+			TransactionStatusUseCase: transactionStatusUseCase,
+			CertifyResultsUseCase:    certifyResultsUseCase,
+			Mempool:                  mempool,
+			EventHub:                 eventHub,
+			BlockchainRepository:     blockchainRepo,
+			NetworkService:           networkService,
+			PeerStatus:               peerStatus,
+			NodeID:                   myNodeID,
+			ChainManager:             chainManager,
+			CryptoService:            cryptoService,
+			ValidatorManager:         validatorManager,
+			Snapshots:                snapshotManager,
+			Metrics:                  nodeMetrics,
+			Logger:                   logManager.Logger("rest"),
+			LogLevels:                logManager,
+			Health:                   healthChecker,
 		}
 
 		restServer = rest.NewServer(restConfig, deps)
@@ -180,16 +410,25 @@ func runStartCommand(cmd *cobra.Command, args []string) {
 		fmt.Printf("📡 REST API: %s\n", restServer.GetAddress())
 		fmt.Printf("📖 Documentação: %s/\n", restServer.GetAddress())
 		fmt.Printf("ℹ️  Info da API: %s/api/v1/info\n", restServer.GetAddress())
+		if healthChecker != nil {
+			fmt.Printf("❤️  Health: %s%s/live e %s/ready\n", restServer.GetAddress(), cfg.Monitoring.Health.Endpoint, cfg.Monitoring.Health.Endpoint)
+		}
 	}
 	
 	if enableP2P && p2pService != nil {
 		fmt.Printf("🔗 P2P: porta %d (Node ID: %s)\n", p2pPort, myNodeID.String()[:16]+"...")
 	}
 	
+	if metricsServer != nil {
+		fmt.Printf("📊 Métricas: http://%s%s\n", cfg.Monitoring.Metrics.Address, metrics.Path)
+	}
+	
 	fmt.Println("\n💡 Comandos úteis:")
-	fmt.Println("   peer-vote status    - Verificar status do nó")
-	fmt.Println("   peer-vote vote      - Submeter um voto")
-	fmt.Println("   Ctrl+C              - Parar o nó")
+	fmt.Println("   peer-vote status         - Verificar status do nó")
+	fmt.Println("   peer-vote election list  - Listar eleições")
+	fmt.Println("   peer-vote vote cast      - Assinar e submeter um voto")
+	fmt.Println("   peer-vote audit run      - Auditar uma eleição")
+	fmt.Println("   Ctrl+C                   - Parar o nó")
 
 	// 5. Aguardar sinal de interrupção
 	sigChan := make(chan os.Signal, 1)
@@ -213,6 +452,12 @@ func runStartCommand(cmd *cobra.Command, args []string) {
 		}
 	}
 	
+	if metricsServer != nil {
+		if err := metricsServer.Stop(); err != nil {
+			log.Printf("❌ Erro ao parar servidor de métricas: %v", err)
+		}
+	}
+	
 	if p2pService != nil {
 		if err := p2pService.Stop(ctx); err != nil {
 			log.Printf("❌ Erro ao parar P2P: %v", err)
@@ -221,3 +466,91 @@ func runStartCommand(cmd *cobra.Command, args []string) {
 
 	fmt.Println("✅ Nó Peer-Vote parado com sucesso!")
 }
+
+// newHealthConfig converte os limites das verificações de saúde
+func newHealthConfig(cfg *config.Config) health.Config {
+	return health.Config{
+		BlockTime:       time.Duration(cfg.Blockchain.Block.BlockTime) * time.Second,
+		MaxMissedBlocks: cfg.Monitoring.Health.MaxMissedBlocks,
+		MaxSyncLag:      cfg.Monitoring.Health.MaxSyncLag,
+		MinPeers:        cfg.Monitoring.Health.MinPeers,
+	}
+}
+
+// newBFTConfig converte os timeouts da configuração para o motor BFT
+func newBFTConfig(cfg *config.Config) *consensus.BFTConfig {
+	bftConfig := consensus.DefaultBFTConfig()
+	
+	if cfg.Consensus.BFT.ProposeTimeoutMs > 0 {
+		bftConfig.ProposeTimeout = time.Duration(cfg.Consensus.BFT.ProposeTimeoutMs) * time.Millisecond
+	}
+	if cfg.Consensus.BFT.PrevoteTimeoutMs > 0 {
+		bftConfig.PrevoteTimeout = time.Duration(cfg.Consensus.BFT.PrevoteTimeoutMs) * time.Millisecond
+	}
+	if cfg.Consensus.BFT.PrecommitTimeoutMs > 0 {
+		bftConfig.PrecommitTimeout = time.Duration(cfg.Consensus.BFT.PrecommitTimeoutMs) * time.Millisecond
+	}
+	if cfg.Consensus.BFT.TimeoutDeltaMs > 0 {
+		bftConfig.TimeoutDelta = time.Duration(cfg.Consensus.BFT.TimeoutDeltaMs) * time.Millisecond
+	}
+	
+	return bftConfig
+}
+
+// newMempoolConfig converte os limites do mempool da configuração
+func newMempoolConfig(cfg *config.Config) *consensus.MempoolConfig {
+	return &consensus.MempoolConfig{
+		MaxTransactions: cfg.Mempool.MaxTransactions,
+		MaxBytes:        cfg.Mempool.MaxBytes,
+		MaxPerSender:    cfg.Mempool.MaxPerSender,
+		TTL:             time.Duration(cfg.Mempool.TTL) * time.Second,
+	}
+}
+
+// newSnapshotConfig converte as configurações de snapshots de estado
+func newSnapshotConfig(cfg *config.Config) *snapshot.Config {
+	snapshotConfig := snapshot.DefaultConfig()
+	snapshotConfig.Interval = cfg.Blockchain.Snapshot.Interval
+	snapshotConfig.Keep = cfg.Blockchain.Snapshot.Keep
+	snapshotConfig.Bootstrap = cfg.Blockchain.Snapshot.Bootstrap
+	return snapshotConfig
+}
+
+// newElectionDurationLimits converte os limites de duração das eleições (voting.validation)
+func newElectionDurationLimits(cfg *config.Config) usecases.ElectionDurationLimits {
+	return usecases.ElectionDurationLimits{
+		Min: time.Duration(cfg.Voting.Validation.MinElectionDuration) * time.Minute,
+		Max: time.Duration(cfg.Voting.Validation.MaxElectionDuration) * time.Hour,
+	}
+}
+
+// newAuthConfig converte as credenciais da API da configuração
+func newAuthConfig(cfg *config.Config) (*auth.Config, error) {
+	authConfig := &auth.Config{
+		Enabled:      cfg.API.Auth.Enabled,
+		AdminToken:   cfg.API.Auth.AdminToken,
+		JWTSecret:    cfg.API.Auth.JWTSecret,
+		MaxClockSkew: time.Duration(cfg.API.Auth.SignatureMaxSkew) * time.Second,
+		NodeRoles:    make(map[string][]auth.Role),
+	}
+
+	if cfg.API.Auth.JWTPublicKey != "" {
+		publicKey, err := auth.LoadECDSAPublicKey(cfg.API.Auth.JWTPublicKey)
+		if err != nil {
+			return nil, err
+		}
+		authConfig.JWTPublicKey = publicKey
+	}
+
+	for roleName, nodeIDs := range cfg.API.Auth.Roles {
+		role, err := auth.ParseRole(roleName)
+		if err != nil {
+			return nil, err
+		}
+		for _, nodeID := range nodeIDs {
+			authConfig.NodeRoles[nodeID] = append(authConfig.NodeRoles[nodeID], role)
+		}
+	}
+
+	return authConfig, nil
+}
@@ -3,169 +3,198 @@ package cli
 import (
 	"context"
 	"fmt"
-
-	"github.com/matscats/peer-vote/peer-vote/application/usecases"
-	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/handlers"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// Flags do comando status
-	showElections bool
-	showNetwork   bool
-	showAll       bool
+	statusWatch    bool
+	statusInterval time.Duration
 )
 
 // statusCmd representa o comando status
 var statusCmd = &cobra.Command{
 	Use:   "status",
-	Short: "Mostra o status do sistema Peer-Vote",
-	Long: `Exibe informações sobre o status atual do sistema incluindo:
-- Eleições ativas
-- Status da rede P2P
-- Status da blockchain
-- Estatísticas gerais
+	Short: "Mostra o status de um nó Peer-Vote em execução",
+	Long: `Consulta o status consolidado de um nó (--api) pelo endpoint administrativo
+/api/v1/admin/status, que exige o token de administrador (--token):
+- Altura da cadeia, hash do topo e último bloco final
+- Estado da sincronização com os peers
+- Peers conectados com a latência medida por ping
+- Round do consenso e proposer atual
+- Ocupação do mempool
+- Resumo das eleições
 
 Exemplos:
-  peer-vote status                    # Status geral
-  peer-vote status --elections        # Apenas eleições
-  peer-vote status --network          # Apenas rede
-  peer-vote status --all              # Informações detalhadas`,
-	Run: runStatusCommand,
+  peer-vote status --token <admin-token>
+  peer-vote status --watch --interval 5s
+  peer-vote status --output json`,
+	Args: cobra.NoArgs,
+	Run:  runStatusCommand,
 }
 
 func init() {
 	rootCmd.AddCommand(statusCmd)
+	addOutputFlag(statusCmd)
 
 	// Flags do comando status
-	statusCmd.Flags().BoolVar(&showElections, "elections", false, "mostrar apenas status das eleições")
-	statusCmd.Flags().BoolVar(&showNetwork, "network", false, "mostrar apenas status da rede")
-	statusCmd.Flags().BoolVar(&showAll, "all", false, "mostrar informações detalhadas")
+	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "atualizar o status continuamente até Ctrl+C")
+	statusCmd.Flags().DurationVar(&statusInterval, "interval", 2*time.Second, "intervalo entre atualizações no modo --watch")
 }
 
 func runStatusCommand(cmd *cobra.Command, args []string) {
-	ctx := context.Background()
+	apiClient := newAPIClient()
 
-	fmt.Println("📊 Status do Sistema Peer-Vote")
-	fmt.Println("==============================")
+	if !statusWatch {
+		status, err := apiClient.GetAdminStatus(context.Background())
+		if err != nil {
+			exitWithError("Erro ao obter status do nó", err)
+		}
+		printStatus(status)
+		return
+	}
 
-	// Inicializar serviços
-	validationService := services.NewVotingValidator(nil)
+	if statusInterval <= 0 {
+		exitWithError("Intervalo inválido", fmt.Errorf("--interval must be positive"))
+	}
 
-	manageElectionUseCase := usecases.NewManageElectionUseCase(validationService, nil)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Mostrar status das eleições (padrão ou se solicitado)
-	if !showNetwork || showElections || showAll {
-		showElectionStatus(ctx, manageElectionUseCase)
-	}
+	ticker := time.NewTicker(statusInterval)
+	defer ticker.Stop()
 
-	// Mostrar status da rede (se solicitado)
-	if showNetwork || showAll {
-		showNetworkStatus()
-	}
+	for {
+		// Falhas temporárias não encerram o modo watch: o nó pode estar reiniciando
+		requestCtx, cancel := context.WithTimeout(ctx, statusInterval+handlers.PingTimeout)
+		status, err := apiClient.GetAdminStatus(requestCtx)
+		cancel()
 
-	// Mostrar status da blockchain (se solicitado)
-	if showAll {
-		showBlockchainStatus()
-	}
+		if outputFormat == outputTable {
+			fmt.Print("\033[H\033[2J")
+			fmt.Printf("Atualizado às %s (a cada %s, Ctrl+C para sair)\n\n", time.Now().Format("15:04:05"), statusInterval)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Erro ao obter status do nó: %v\n", err)
+		} else {
+			printStatus(status)
+		}
 
-	// Mostrar estatísticas gerais
-	if !showElections && !showNetwork || showAll {
-		showGeneralStats(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
 }
 
-func showElectionStatus(ctx context.Context, manageElectionUseCase *usecases.ManageElectionUseCase) {
-	fmt.Println("\n🗳️  Eleições")
-	fmt.Println("------------")
-
-	// Listar todas as eleições
-	listRequest := &usecases.ListElectionsRequest{}
-	response, err := manageElectionUseCase.ListElections(ctx, listRequest)
-	if err != nil {
-		fmt.Printf("❌ Erro ao obter eleições: %v\n", err)
-		return
-	}
+// printStatus escreve o status consolidado do nó
+func printStatus(status *handlers.NodeOverviewResponse) {
+	printOutput(status, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "📊 Nó:\t%s\n", status.NodeID)
+
+		if chain := status.Chain; chain != nil {
+			fmt.Fprintln(w, "\n⛓️  Cadeia")
+			fmt.Fprintf(w, "Altura:\t%d\n", chain.Height)
+			fmt.Fprintf(w, "Topo:\t%s\n", valueOrDash(chain.HeadHash))
+			fmt.Fprintf(w, "Último bloco:\t%s\n", formatUnix(chain.HeadTime))
+			fmt.Fprintf(w, "Finalizado até:\t%d\n", chain.FinalizedHeight)
+		}
 
-	if len(response.Elections) == 0 {
-		fmt.Println("📭 Nenhuma eleição encontrada")
-		return
-	}
+		fmt.Fprintln(w, "\n🔄 Sincronização")
+		if sync := status.Sync; sync != nil {
+			state := "✅ sincronizado"
+			if sync.Syncing {
+				state = fmt.Sprintf("⏳ sincronizando (%s, %d/%d, %.1f%%)", sync.Phase, sync.CurrentHeight, sync.TargetHeight, sync.Progress)
+			}
+			fmt.Fprintf(w, "Estado:\t%s\n", state)
+			fmt.Fprintf(w, "Peers de sincronização:\t%d\n", sync.SyncPeers)
+			fmt.Fprintf(w, "Última sincronização:\t%s\n", formatUnix(sync.LastSyncTime))
+		} else {
+			fmt.Fprintln(w, "Estado:\t⚠️  rede P2P desabilitada")
+		}
 
-	fmt.Printf("📋 Total de eleições: %d\n", response.Count)
+		fmt.Fprintf(w, "\n🌐 Peers (%d)\n", len(status.Peers))
+		if len(status.Peers) > 0 {
+			fmt.Fprintln(w, "PEER\tLATÊNCIA\tALTURA")
+			for _, peer := range status.Peers {
+				latency := fmt.Sprintf("%.1f ms", peer.LatencyMs)
+				if peer.PingError != "" {
+					latency = "sem resposta"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%d\n", peer.PeerID, latency, peer.ChainHeight)
+			}
+		}
 
-	// Mostrar eleições ativas
-	activeRequest := &usecases.ListElectionsRequest{ActiveOnly: true}
-	activeResponse, err := manageElectionUseCase.ListElections(ctx, activeRequest)
-	if err == nil {
-		fmt.Printf("🟢 Eleições ativas: %d\n", activeResponse.Count)
-	}
+		if consensus := status.Consensus; consensus != nil {
+			fmt.Fprintln(w, "\n⚖️  Consenso")
+			fmt.Fprintf(w, "Em execução:\t%t\n", consensus.IsRunning)
+			fmt.Fprintf(w, "Round:\t%d (restam %s)\n", consensus.Round, time.Duration(consensus.TimeRemainingMs)*time.Millisecond)
+			fmt.Fprintf(w, "Proposer:\t%s\n", valueOrDash(consensus.CurrentProposer))
+			fmt.Fprintf(w, "Da vez na altura %d:\t%s\n", consensus.NextHeight, valueOrDash(consensus.InTurnValidator))
+			fmt.Fprintf(w, "Validadores ativos:\t%d\n", consensus.ActiveValidators)
+		}
 
-	if showAll {
-		fmt.Println("\n📝 Detalhes das eleições:")
-		for i, election := range response.Elections {
-			if i >= 5 && !showAll { // Limitar a 5 se não for --all
-				fmt.Printf("   ... e mais %d eleições\n", len(response.Elections)-5)
-				break
+		if mempool := status.Mempool; mempool != nil {
+			fmt.Fprintln(w, "\n📥 Mempool")
+			fmt.Fprintf(w, "Transações:\t%d/%d (%d bytes, %d remetentes)\n", mempool.Count, mempool.MaxTransactions, mempool.Bytes, mempool.Senders)
+			if len(mempool.ByType) > 0 {
+				fmt.Fprintf(w, "Por tipo:\t%s\n", formatCounts(mempool.ByType))
 			}
+		}
 
-			status := "🔴"
-			switch election.GetStatus() {
-			case "ACTIVE":
-				status = "🟢"
-			case "PENDING":
-				status = "🟡"
-			case "CLOSED":
-				status = "⚫"
+		if elections := status.Elections; elections != nil {
+			fmt.Fprintln(w, "\n🗳️  Eleições")
+			fmt.Fprintf(w, "Total:\t%d\n", elections.Total)
+			if len(elections.ByStatus) > 0 {
+				fmt.Fprintf(w, "Por status:\t%s\n", formatCounts(elections.ByStatus))
 			}
+			for _, election := range elections.Active {
+				fmt.Fprintf(w, "🟢 %s\t%s (até %s)\n", election.ID, election.Title, formatUnix(election.EndTime))
+			}
+		}
 
-			fmt.Printf("   %s %s\n", status, election.GetTitle())
-			fmt.Printf("      ID: %s\n", election.GetID().String()[:16]+"...")
-			fmt.Printf("      Status: %s\n", election.GetStatus())
-			fmt.Printf("      Candidatos: %d\n", len(election.GetCandidates()))
+		if len(status.Errors) > 0 {
+			fmt.Fprintln(w, "\n⚠️  Erros")
+			for _, section := range sortedKeys(status.Errors) {
+				fmt.Fprintf(w, "%s:\t%s\n", section, status.Errors[section])
+			}
 		}
-	}
+	})
 }
 
-func showNetworkStatus() {
-	fmt.Println("\n🌐 Rede P2P")
-	fmt.Println("-----------")
-	fmt.Println("⚠️  Integração P2P não implementada ainda")
-	fmt.Println("📡 Status: Offline")
-	fmt.Println("🔗 Peers conectados: 0")
-	fmt.Println("🔍 Peers descobertos: 0")
+// formatCounts formata contagens como "A=1, B=2" em ordem alfabética
+func formatCounts(counts map[string]int) string {
+	parts := make([]string, 0, len(counts))
+	for _, key := range sortedKeys(counts) {
+		parts = append(parts, fmt.Sprintf("%s=%d", key, counts[key]))
+	}
+	return strings.Join(parts, ", ")
 }
 
-func showBlockchainStatus() {
-	fmt.Println("\n⛓️  Blockchain")
-	fmt.Println("-------------")
-	fmt.Println("⚠️  Integração Blockchain não implementada ainda")
-	fmt.Println("📏 Altura da cadeia: 0")
-	fmt.Println("🔗 Último bloco: N/A")
-	fmt.Println("✅ Cadeia válida: N/A")
+// sortedKeys retorna as chaves do mapa em ordem alfabética
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
 }
 
-func showGeneralStats(ctx context.Context) {
-	fmt.Println("\n📈 Estatísticas Gerais")
-	fmt.Println("---------------------")
-
-	// Estatísticas agora são obtidas da blockchain
-	fmt.Printf("🗳️  Total de eleições: N/A (consulte a blockchain)\n")
-	fmt.Printf("📊 Total de votos: N/A (consulte a blockchain)\n")
-	fmt.Printf("🎭 Votos anônimos: N/A (consulte a blockchain)\n")
-
-	// Status dos serviços
-	fmt.Println("\n🔧 Serviços")
-	fmt.Println("-----------")
-	fmt.Println("✅ Blockchain: Online")
-	fmt.Println("✅ Consenso PoA: Online")
-	fmt.Println("✅ Serviço de criptografia: Online")
-	fmt.Println("✅ Serviço de validação: Online")
-	fmt.Println("⚠️  Rede P2P: Offline")
-	fmt.Println("⚠️  Blockchain: Offline")
-
-	fmt.Println("\n💡 Dicas:")
-	fmt.Println("   - Use 'peer-vote start' para iniciar um nó completo")
-	fmt.Println("   - Use 'peer-vote vote --help' para submeter um voto")
-	fmt.Println("   - Use 'peer-vote status --all' para informações detalhadas")
+// valueOrDash substitui valores vazios por "-" na saída tabular
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
 }
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/handlers"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Flags do comando vote
+	voteKeyFile   string
+	voteElection  string
+	voteCandidate string
+	voteAnonymous bool
+)
+
+// voteCmd agrupa os comandos de votação
+var voteCmd = &cobra.Command{
+	Use:   "vote",
+	Short: "Gera chaves de eleitor e submete votos assinados localmente",
+	Long: `Comandos de votação executados contra a API REST de um nó (--api).
+
+O voto é assinado na própria máquina com a chave do eleitor: a chave privada
+nunca é enviada ao nó, que apenas verifica as assinaturas. O NodeID do eleitor
+é derivado da chave pública.
+
+Exemplos:
+  peer-vote vote keygen --key eleitor.pem
+  peer-vote vote cast --key eleitor.pem --election <election-id> --candidate alice`,
+}
+
+// voteKeygenCmd gera a chave de um eleitor
+var voteKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Gera um par de chaves ECDSA P-256 para o eleitor",
+	Args:  cobra.NoArgs,
+	Run:   runVoteKeygenCommand,
+}
+
+// voteCastCmd assina e submete um voto
+var voteCastCmd = &cobra.Command{
+	Use:   "cast",
+	Short: "Assina localmente e submete um voto",
+	Args:  cobra.NoArgs,
+	Run:   runVoteCastCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(voteCmd)
+	voteCmd.AddCommand(voteKeygenCmd, voteCastCmd)
+	addOutputFlag(voteCmd)
+
+	voteCmd.PersistentFlags().StringVar(&voteKeyFile, "key", "", "arquivo PEM da chave privada do eleitor")
+
+	voteCastCmd.Flags().StringVar(&voteElection, "election", "", "ID da eleição")
+	voteCastCmd.Flags().StringVar(&voteCandidate, "candidate", "", "ID do candidato")
+	voteCastCmd.Flags().BoolVar(&voteAnonymous, "anonymous", false, "voto anônimo (se a eleição permitir)")
+	voteCastCmd.MarkFlagRequired("election")
+	voteCastCmd.MarkFlagRequired("candidate")
+}
+
+// voterKeyInfo identifica a chave de um eleitor
+type voterKeyInfo struct {
+	KeyFile   string `json:"key_file"`
+	NodeID    string `json:"node_id"`
+	PublicKey string `json:"public_key"`
+}
+
+func runVoteKeygenCommand(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	if voteKeyFile == "" {
+		exitWithError("Erro ao gerar chave", fmt.Errorf("--key is required"))
+	}
+	if _, err := os.Stat(voteKeyFile); err == nil {
+		exitWithError("Erro ao gerar chave", fmt.Errorf("%s already exists", voteKeyFile))
+	}
+
+	cryptoService := crypto.NewECDSAService()
+	keyPair, err := cryptoService.GenerateKeyPair(ctx)
+	if err != nil {
+		exitWithError("Erro ao gerar chave", err)
+	}
+	if err := cryptoService.SaveKeyPair(ctx, keyPair, voteKeyFile); err != nil {
+		exitWithError("Erro ao salvar chave", err)
+	}
+	if err := os.Chmod(voteKeyFile, 0600); err != nil {
+		exitWithError("Erro ao proteger chave", err)
+	}
+
+	info := voterKeyInfo{
+		KeyFile:   voteKeyFile,
+		NodeID:    cryptoService.GenerateNodeID(ctx, keyPair.PublicKey).String(),
+		PublicKey: hex.EncodeToString(keyPair.PublicKey.ToBytes()),
+	}
+	printOutput(info, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "Chave:\t%s\n", info.KeyFile)
+		fmt.Fprintf(w, "NodeID:\t%s\n", info.NodeID)
+		fmt.Fprintf(w, "Chave pública:\t%s\n", info.PublicKey)
+	})
+}
+
+func runVoteCastCommand(cmd *cobra.Command, args []string) {
+	ctx := context.Background()
+	if voteKeyFile == "" {
+		exitWithError("Erro ao submeter voto", fmt.Errorf("--key is required"))
+	}
+
+	electionID, err := valueobjects.NewHashFromString(voteElection)
+	if err != nil {
+		exitWithError("ID de eleição inválido", err)
+	}
+
+	cryptoService := crypto.NewECDSAService()
+	keyPair, err := cryptoService.LoadKeyPair(ctx, voteKeyFile)
+	if err != nil {
+		exitWithError("Erro ao carregar chave", err)
+	}
+	voterID := cryptoService.GenerateNodeID(ctx, keyPair.PublicKey)
+
+	// Assinar o voto e a transação localmente
+	vote := entities.NewVote(electionID, voterID, voteCandidate, voteAnonymous)
+	txSignature, err := usecases.SignVote(ctx, cryptoService, vote, keyPair.PrivateKey)
+	if err != nil {
+		exitWithError("Erro ao assinar voto", err)
+	}
+
+	response, err := newAPIClient().SubmitVote(ctx, &handlers.SubmitVoteRequest{
+		ElectionID:           voteElection,
+		VoterID:              voterID.String(),
+		CandidateID:          voteCandidate,
+		IsAnonymous:          voteAnonymous,
+		SignedVote:           vote,
+		TransactionSignature: txSignature.String(),
+		PublicKey:            hex.EncodeToString(keyPair.PublicKey.ToBytes()),
+	})
+	if err != nil {
+		exitWithError("Erro ao submeter voto", err)
+	}
+
+	printOutput(response, func(w *tabwriter.Writer) {
+		fmt.Fprintf(w, "Voto:\t%s\n", response.VoteID)
+		fmt.Fprintf(w, "Eleitor:\t%s\n", voterID.String())
+		fmt.Fprintf(w, "Transação:\t%s\n", response.TransactionHash.String())
+		if response.InBlockchain {
+			fmt.Fprintf(w, "Bloco:\t%d (%s)\n", response.BlockHeight, response.BlockHash.String())
+			fmt.Fprintf(w, "Final:\t%t\n", response.IsFinal)
+		}
+		fmt.Fprintf(w, "Mensagem:\t%s\n", response.Message)
+	})
+}
@@ -0,0 +1,321 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+	"gopkg.in/yaml.v3"
+)
+
+// Engines de consenso suportados
+const (
+	ConsensusEnginePoA = "poa"
+	ConsensusEngineBFT = "bft"
+)
+
+// Config representa o arquivo de configuração do nó (configs/config.yaml).
+// Apenas as seções usadas pelo nó são mapeadas; chaves ausentes mantêm o valor padrão.
+type Config struct {
+	Blockchain BlockchainConfig `yaml:"blockchain"`
+	Consensus  ConsensusConfig  `yaml:"consensus"`
+	Mempool    MempoolConfig    `yaml:"mempool"`
+	Voting     VotingConfig     `yaml:"voting"`
+	API        APIConfig        `yaml:"api"`
+	Logging    LoggingConfig    `yaml:"logging"`
+	Monitoring MonitoringConfig `yaml:"monitoring"`
+}
+
+// BlockchainConfig contém as configurações da cadeia
+type BlockchainConfig struct {
+	Block    BlockConfig    `yaml:"block"`
+	Snapshot SnapshotConfig `yaml:"snapshot"`
+}
+
+// BlockConfig contém as configurações de produção de blocos
+type BlockConfig struct {
+	BlockTime int `yaml:"block_time"` // segundos entre blocos
+}
+
+// SnapshotConfig contém as configurações de snapshots de estado
+type SnapshotConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	Interval  uint64 `yaml:"interval"`  // Blocos entre checkpoints
+	Keep      int    `yaml:"keep"`      // Snapshots mantidos pelo nó
+	Bootstrap bool   `yaml:"bootstrap"` // Iniciar um nó vazio a partir de um snapshot confiável dos peers
+}
+
+// ConsensusConfig contém as configurações de consenso
+type ConsensusConfig struct {
+	Enabled    bool             `yaml:"enabled"`
+	Engine     string           `yaml:"engine"`
+	RoundRobin RoundRobinConfig `yaml:"round_robin"`
+	BFT        BFTConfig        `yaml:"bft"`
+}
+
+// RoundRobinConfig contém as configurações do Round Robin
+type RoundRobinConfig struct {
+	ValidatorTimeout int `yaml:"validator_timeout"` // segundos
+	MaxMissedRounds  int `yaml:"max_missed_rounds"`
+}
+
+// BFTConfig contém os timeouts do consenso BFT
+type BFTConfig struct {
+	ProposeTimeoutMs   int `yaml:"propose_timeout_ms"`
+	PrevoteTimeoutMs   int `yaml:"prevote_timeout_ms"`
+	PrecommitTimeoutMs int `yaml:"precommit_timeout_ms"`
+	TimeoutDeltaMs     int `yaml:"timeout_delta_ms"`
+}
+
+// MempoolConfig contém os limites do pool de transações pendentes
+type MempoolConfig struct {
+	MaxTransactions int `yaml:"max_transactions"`
+	MaxBytes        int `yaml:"max_bytes"`
+	MaxPerSender    int `yaml:"max_per_sender"`
+	TTL             int `yaml:"ttl"` // segundos
+}
+
+// VotingConfig contém as configurações do serviço de votação
+type VotingConfig struct {
+	Validation VotingValidationConfig `yaml:"validation"`
+}
+
+// VotingValidationConfig contém os limites aplicados na criação de eleições (0 desabilita)
+type VotingValidationConfig struct {
+	MaxElectionDuration int `yaml:"max_election_duration"` // horas
+	MinElectionDuration int `yaml:"min_election_duration"` // minutos
+}
+
+// APIConfig contém as configurações da API REST
+type APIConfig struct {
+	Auth APIAuthConfig `yaml:"auth"`
+}
+
+// APIAuthConfig contém as configurações de autenticação da API
+type APIAuthConfig struct {
+	// Exige autenticação e aplica a autorização por papéis em todas as rotas
+	Enabled bool `yaml:"enabled"`
+	// Segredo dos tokens JWT HS256 (vazio desabilita HS256)
+	JWTSecret string `yaml:"jwt_secret"`
+	// Arquivo PEM com a chave pública P-256 dos tokens JWT ES256 (vazio desabilita ES256)
+	JWTPublicKey string `yaml:"jwt_public_key"`
+	// Token exigido pelos endpoints administrativos (vazio desabilita as mutações)
+	AdminToken string `yaml:"admin_token"`
+	// Janela de validade de requisições assinadas com a chave do nó (segundos)
+	SignatureMaxSkew int `yaml:"signature_max_skew"`
+	// Papéis atribuídos a NodeIDs em requisições assinadas (papel -> NodeIDs); demais são voter
+	Roles map[string][]string `yaml:"roles"`
+}
+
+// LoggingConfig contém as configurações de log
+type LoggingConfig struct {
+	Level      string            `yaml:"level"`      // debug, info, warn ou error
+	Format     string            `yaml:"format"`     // json ou text
+	Output     string            `yaml:"output"`     // stdout, stderr ou caminho de arquivo
+	Structured bool              `yaml:"structured"` // Força o formato json
+	Components map[string]string `yaml:"components"` // Nível inicial por componente (ex: p2p: debug)
+}
+
+// EffectiveFormat retorna o formato de saída considerando a opção structured
+func (c LoggingConfig) EffectiveFormat() string {
+	if c.Structured {
+		return logging.FormatJSON
+	}
+	return c.Format
+}
+
+// MonitoringConfig contém as configurações de monitoramento
+type MonitoringConfig struct {
+	Metrics MetricsConfig `yaml:"metrics"`
+	Health  HealthConfig  `yaml:"health"`
+}
+
+// MetricsConfig contém as configurações do servidor de métricas Prometheus
+type MetricsConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Address string `yaml:"address"` // host:porta do endpoint /metrics
+}
+
+// HealthConfig contém as configurações das verificações de liveness e readiness
+type HealthConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	Endpoint        string `yaml:"endpoint"`          // Prefixo de <endpoint>/live e <endpoint>/ready
+	Interval        int    `yaml:"interval"`          // segundos entre verificações registradas no log (0 desabilita)
+	MinPeers        int    `yaml:"min_peers"`         // Peers mínimos para o nó estar pronto
+	MaxSyncLag      uint64 `yaml:"max_sync_lag"`      // Blocos de atraso tolerados em relação aos peers
+	MaxMissedBlocks int    `yaml:"max_missed_blocks"` // Intervalos de bloco sem produção (com transações pendentes) até o consenso ser considerado parado
+}
+
+// placeholderJWTSecret é o segredo de exemplo de configs/config.yaml
+const placeholderJWTSecret = "your-secret-key-here"
+
+// Default retorna a configuração padrão
+func Default() *Config {
+	return &Config{
+		Blockchain: BlockchainConfig{
+			Block: BlockConfig{
+				BlockTime: 10,
+			},
+			Snapshot: SnapshotConfig{
+				Enabled:   true,
+				Interval:  1000,
+				Keep:      3,
+				Bootstrap: true,
+			},
+		},
+		Consensus: ConsensusConfig{
+			Enabled: true,
+			Engine:  ConsensusEnginePoA,
+			RoundRobin: RoundRobinConfig{
+				ValidatorTimeout: 30,
+				MaxMissedRounds:  3,
+			},
+			BFT: BFTConfig{
+				ProposeTimeoutMs:   3000,
+				PrevoteTimeoutMs:   1000,
+				PrecommitTimeoutMs: 1000,
+				TimeoutDeltaMs:     500,
+			},
+		},
+		Mempool: MempoolConfig{
+			MaxTransactions: 10000,
+			MaxBytes:        33554432,
+			MaxPerSender:    256,
+			TTL:             1800,
+		},
+		Voting: VotingConfig{
+			Validation: VotingValidationConfig{
+				MaxElectionDuration: 168,
+				MinElectionDuration: 60,
+			},
+		},
+		API: APIConfig{
+			Auth: APIAuthConfig{
+				SignatureMaxSkew: 300,
+			},
+		},
+		Logging: LoggingConfig{
+			Level:  "info",
+			Format: logging.FormatJSON,
+			Output: "stdout",
+		},
+		Monitoring: MonitoringConfig{
+			Metrics: MetricsConfig{
+				Enabled: true,
+				Address: "0.0.0.0:9090",
+			},
+			Health: HealthConfig{
+				Enabled:         true,
+				Endpoint:        "/health",
+				Interval:        30,
+				MinPeers:        0,
+				MaxSyncLag:      10,
+				MaxMissedBlocks: 3,
+			},
+		},
+	}
+}
+
+// Load lê o arquivo de configuração; caminho vazio retorna a configuração padrão
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+// Validate verifica se os valores da configuração são aceitáveis
+func (c *Config) Validate() error {
+	switch c.Consensus.Engine {
+	case ConsensusEnginePoA, ConsensusEngineBFT:
+	default:
+		return fmt.Errorf("unknown consensus engine %q (expected %q or %q)", c.Consensus.Engine, ConsensusEnginePoA, ConsensusEngineBFT)
+	}
+
+	if c.Mempool.MaxTransactions < 0 || c.Mempool.MaxBytes < 0 || c.Mempool.MaxPerSender < 0 || c.Mempool.TTL < 0 {
+		return fmt.Errorf("mempool limits cannot be negative")
+	}
+
+	validation := c.Voting.Validation
+	if validation.MaxElectionDuration < 0 || validation.MinElectionDuration < 0 {
+		return fmt.Errorf("voting.validation election durations cannot be negative")
+	}
+	if validation.MaxElectionDuration > 0 && validation.MinElectionDuration > validation.MaxElectionDuration*60 {
+		return fmt.Errorf("voting.validation.min_election_duration (minutes) exceeds max_election_duration (hours)")
+	}
+
+	if c.API.Auth.Enabled {
+		if c.API.Auth.JWTSecret == placeholderJWTSecret {
+			return fmt.Errorf("api.auth.jwt_secret still has the example value; set a secret or leave it empty")
+		}
+		if c.API.Auth.SignatureMaxSkew < 0 {
+			return fmt.Errorf("api.auth.signature_max_skew cannot be negative")
+		}
+		for role := range c.API.Auth.Roles {
+			switch strings.ReplaceAll(role, "_", "-") {
+			case "admin", "election-officer", "voter", "observer":
+			default:
+				return fmt.Errorf("unknown role %q in api.auth.roles", role)
+			}
+		}
+	}
+
+	if _, err := logging.ParseLevel(c.Logging.Level); err != nil {
+		return fmt.Errorf("logging.level: %w", err)
+	}
+	for component, level := range c.Logging.Components {
+		if _, err := logging.ParseLevel(level); err != nil {
+			return fmt.Errorf("logging.components.%s: %w", component, err)
+		}
+	}
+	switch c.Logging.EffectiveFormat() {
+	case logging.FormatJSON, logging.FormatText:
+	default:
+		return fmt.Errorf("unknown logging.format %q (expected %q or %q)", c.Logging.Format, logging.FormatJSON, logging.FormatText)
+	}
+
+	if c.Monitoring.Metrics.Enabled && c.Monitoring.Metrics.Address == "" {
+		return fmt.Errorf("monitoring.metrics.address is required when metrics are enabled")
+	}
+
+	if c.Blockchain.Block.BlockTime <= 0 {
+		return fmt.Errorf("blockchain.block.block_time must be positive")
+	}
+
+	if c.Blockchain.Snapshot.Enabled {
+		if c.Blockchain.Snapshot.Interval == 0 {
+			return fmt.Errorf("blockchain.snapshot.interval must be positive")
+		}
+		if c.Blockchain.Snapshot.Keep <= 0 {
+			return fmt.Errorf("blockchain.snapshot.keep must be positive")
+		}
+	}
+
+	if c.Monitoring.Health.Enabled {
+		endpoint := c.Monitoring.Health.Endpoint
+		if !strings.HasPrefix(endpoint, "/") || endpoint == "/" || strings.HasPrefix(endpoint, "/api/") {
+			return fmt.Errorf("monitoring.health.endpoint must be a path like /health outside /api/")
+		}
+		if c.Monitoring.Health.Interval < 0 || c.Monitoring.Health.MinPeers < 0 || c.Monitoring.Health.MaxMissedBlocks < 0 {
+			return fmt.Errorf("monitoring.health limits cannot be negative")
+		}
+	}
+
+	return nil
+}
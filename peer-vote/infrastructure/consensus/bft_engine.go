@@ -0,0 +1,1166 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// BFTStep representa a etapa de um round do consenso BFT
+type BFTStep int
+
+const (
+	// StepPropose aguardando a proposta do round
+	StepPropose BFTStep = iota
+	// StepPrevote prevote enviado, aguardando 2/3 de prevotes
+	StepPrevote
+	// StepPrecommit precommit enviado, aguardando 2/3 de precommits
+	StepPrecommit
+	// StepCommit bloco decidido na altura atual
+	StepCommit
+)
+
+// String retorna o nome da etapa
+func (s BFTStep) String() string {
+	switch s {
+	case StepPropose:
+		return "PROPOSE"
+	case StepPrevote:
+		return "PREVOTE"
+	case StepPrecommit:
+		return "PRECOMMIT"
+	case StepCommit:
+		return "COMMIT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// BFTConfig contém os timeouts do consenso BFT
+type BFTConfig struct {
+	ProposeTimeout   time.Duration // Tempo de espera pela proposta
+	PrevoteTimeout   time.Duration // Tempo de espera após 2/3 de prevotes divergentes
+	PrecommitTimeout time.Duration // Tempo de espera após 2/3 de precommits divergentes
+	TimeoutDelta     time.Duration // Acréscimo de timeout a cada round sem decisão
+	MaxTxPerBlock    int
+}
+
+// DefaultBFTConfig retorna a configuração padrão do consenso BFT
+func DefaultBFTConfig() *BFTConfig {
+	return &BFTConfig{
+		ProposeTimeout:   time.Second * 3,
+		PrevoteTimeout:   time.Second,
+		PrecommitTimeout: time.Second,
+		TimeoutDelta:     time.Millisecond * 500,
+		MaxTxPerBlock:    1000,
+	}
+}
+
+// BFTRoundState contém o estado atual do consenso BFT
+type BFTRoundState struct {
+	Height      uint64
+	Round       uint64
+	Step        BFTStep
+	Proposer    valueobjects.NodeID
+	LockedRound int64
+	ValidRound  int64
+}
+
+// bftTimeout identifica um timeout agendado
+type bftTimeout struct {
+	height uint64
+	round  uint64
+	step   BFTStep
+}
+
+// voteSet agrupa os votos de uma etapa de um round
+type voteSet struct {
+	votes map[string]*entities.ConsensusVote // validador -> voto
+}
+
+func newVoteSet() *voteSet {
+	return &voteSet{
+		votes: make(map[string]*entities.ConsensusVote),
+	}
+}
+
+// add registra o voto; retorna false se o validador já votou neste round
+func (vs *voteSet) add(vote *entities.ConsensusVote) bool {
+	validator := vote.GetValidator().String()
+	if _, exists := vs.votes[validator]; exists {
+		return false
+	}
+	vs.votes[validator] = vote
+	return true
+}
+
+// count conta os votos no hash dados por validadores do eleitorado
+func (vs *voteSet) count(hash valueobjects.Hash, electorate map[string]*services.PublicKey) int {
+	count := 0
+	for validator, vote := range vs.votes {
+		if _, eligible := electorate[validator]; eligible && vote.GetBlockHash().Equals(hash) {
+			count++
+		}
+	}
+	return count
+}
+
+// total conta os votos dados por validadores do eleitorado
+func (vs *voteSet) total(electorate map[string]*services.PublicKey) int {
+	count := 0
+	for validator := range vs.votes {
+		if _, eligible := electorate[validator]; eligible {
+			count++
+		}
+	}
+	return count
+}
+
+// BFTEngine implementa consenso BFT no estilo Tendermint/IBFT (propose, prevote, precommit).
+// Um bloco só é adicionado à cadeia com precommits de mais de 2/3 dos validadores,
+// de forma que um proposer malicioso não consegue impor nem censurar blocos sozinho:
+// se a proposta não chegar a tempo, os validadores mudam de round e o próximo proposer
+// da sequência do RoundRobinScheduler assume.
+type BFTEngine struct {
+	validatorManager *ValidatorManager
+	roundRobin       *RoundRobinScheduler
+	chainManager     *blockchain.ChainManager
+	cryptoService    services.CryptographyService
+	blockBuilder     *blockchain.BlockBuilder
+	authority        *BlockAuthority
+	networkService   services.NetworkService
+
+	myNodeID     valueobjects.NodeID
+	myPrivateKey *services.PrivateKey
+	config       *BFTConfig
+
+	// Pool de transações pendentes
+	mempool *Mempool
+
+	// Estado do round
+	isRunning   bool
+	height      uint64
+	round       uint64
+	step        BFTStep
+	proposer    valueobjects.NodeID
+	proposed    bool
+	lockedBlock *entities.Block
+	lockedHash  valueobjects.Hash
+	lockedRound int64
+	validBlock  *entities.Block
+	validHash   valueobjects.Hash
+	validRound  int64
+	ownBlock    *entities.Block // Bloco já assinado por este nó na altura atual
+
+	// Mensagens da altura atual, por round
+	proposals  map[uint64]*entities.ConsensusProposal
+	prevotes   map[uint64]*voteSet
+	precommits map[uint64]*voteSet
+	polSeen    map[uint64]bool
+	timeouts   map[bftTimeout]bool
+
+	lastBlockTime valueobjects.Timestamp
+	stopChan      chan struct{}
+	cancelRun     context.CancelFunc
+
+	// Mutex para operações thread-safe
+	mu sync.Mutex
+
+	// Callbacks para eventos
+	onBlockCommitted func(*entities.Block)
+	onConsensusError func(error)
+
+	logger *slog.Logger
+}
+
+// NewBFTEngine cria um novo motor de consenso BFT
+func NewBFTEngine(
+	validatorManager *ValidatorManager,
+	chainManager *blockchain.ChainManager,
+	cryptoService services.CryptographyService,
+	myNodeID valueobjects.NodeID,
+	myPrivateKey *services.PrivateKey,
+	networkService services.NetworkService,
+	config *BFTConfig,
+) *BFTEngine {
+	if config == nil {
+		config = DefaultBFTConfig()
+	}
+
+	engine := &BFTEngine{
+		validatorManager: validatorManager,
+		roundRobin:       NewRoundRobinScheduler(validatorManager),
+		chainManager:     chainManager,
+		cryptoService:    cryptoService,
+		blockBuilder:     blockchain.NewBlockBuilder(cryptoService),
+		authority:        NewBlockAuthority(validatorManager, cryptoService),
+		myNodeID:         myNodeID,
+		myPrivateKey:     myPrivateKey,
+		config:           config,
+		mempool:          NewMempool(chainManager, DefaultMempoolConfig()),
+		lockedRound:      -1,
+		validRound:       -1,
+		stopChan:         make(chan struct{}),
+		logger:           logging.Component("consensus"),
+	}
+	engine.resetHeightState()
+
+	// Regras de autoridade para blocos recebidos fora do protocolo (sync e gossip)
+	chainManager.SetBlockAuthority(engine.authority)
+
+	if networkService != nil {
+		engine.SetNetworkService(networkService)
+	}
+
+	return engine
+}
+
+// SetLogger define o logger do motor de consenso
+func (bft *BFTEngine) SetLogger(logger *slog.Logger) {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	bft.logger = logger
+}
+
+// SetNetworkService define o serviço de rede e registra os handlers de consenso
+func (bft *BFTEngine) SetNetworkService(networkService services.NetworkService) {
+	bft.networkService = networkService
+	if networkService == nil {
+		return
+	}
+
+	networkService.RegisterTransactionHandler(bft.handleIncomingTransaction)
+	networkService.RegisterProposalHandler(bft.handleIncomingProposal)
+	networkService.RegisterConsensusVoteHandler(bft.handleIncomingVote)
+}
+
+// SetCallbacks define callbacks para eventos
+func (bft *BFTEngine) SetCallbacks(onBlockCommitted func(*entities.Block), onConsensusError func(error)) {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+	bft.onBlockCommitted = onBlockCommitted
+	bft.onConsensusError = onConsensusError
+}
+
+// StartConsensus inicia o processo de consenso
+func (bft *BFTEngine) StartConsensus(ctx context.Context) error {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	if bft.isRunning {
+		return errors.New("consensus already running")
+	}
+
+	isValidator, err := bft.validatorManager.IsValidator(ctx, bft.myNodeID)
+	if err != nil {
+		return fmt.Errorf("failed to check validator status: %w", err)
+	}
+
+	if !isValidator {
+		return fmt.Errorf("node %s is not an authorized validator", bft.myNodeID.ShortString())
+	}
+
+	chainHeight, err := bft.chainManager.GetChainHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain height: %w", err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	stop := make(chan struct{})
+	bft.stopChan = stop
+	bft.cancelRun = cancel
+
+	bft.isRunning = true
+	bft.startHeight(runCtx, chainHeight+1)
+
+	go bft.consensusLoop(runCtx, stop)
+
+	return nil
+}
+
+// StopConsensus para o processo de consenso
+func (bft *BFTEngine) StopConsensus(ctx context.Context) error {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	if !bft.isRunning {
+		return errors.New("consensus not running")
+	}
+
+	bft.isRunning = false
+	bft.cancelRun()
+	close(bft.stopChan)
+
+	return nil
+}
+
+// AddTransaction adiciona uma transação local ao pool e a propaga para os demais validadores
+func (bft *BFTEngine) AddTransaction(ctx context.Context, tx *entities.Transaction) error {
+	if err := bft.addTransaction(ctx, tx); err != nil {
+		return err
+	}
+
+	// Qualquer validador pode ser o proposer da próxima altura
+	if bft.networkService != nil {
+		if err := bft.networkService.BroadcastTransaction(ctx, tx); err != nil {
+			bft.logger.WarnContext(ctx, "failed to broadcast transaction", logging.TxHash(tx.GetID()), logging.Err(err))
+		}
+	}
+
+	return nil
+}
+
+// addTransaction adiciona uma transação ao pool de pendentes
+func (bft *BFTEngine) addTransaction(ctx context.Context, tx *entities.Transaction) error {
+	if tx == nil {
+		return errors.New("transaction is nil")
+	}
+
+	if !tx.IsValid() {
+		return errors.New("invalid transaction")
+	}
+
+	if err := bft.mempool.Add(tx); err != nil {
+		return err
+	}
+
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	// Havendo trabalho, o round precisa progredir mesmo sem proposta
+	if bft.isRunning {
+		bft.tryPropose(ctx, nil)
+		bft.scheduleProposeTimeout()
+	}
+
+	return nil
+}
+
+// ProposeBlock propõe um bloco construído externamente no round atual
+func (bft *BFTEngine) ProposeBlock(ctx context.Context, block *entities.Block) error {
+	if block == nil {
+		return errors.New("block is nil")
+	}
+
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	if !bft.isRunning {
+		return errors.New("consensus not running")
+	}
+
+	if !bft.proposer.Equals(bft.myNodeID) || bft.step != StepPropose || bft.proposed {
+		return errors.New("not my turn to propose block")
+	}
+
+	if block.GetIndex() != bft.height {
+		return fmt.Errorf("block index %d does not match consensus height %d", block.GetIndex(), bft.height)
+	}
+
+	if !bft.tryPropose(ctx, block) {
+		return errors.New("failed to propose block")
+	}
+
+	return nil
+}
+
+// ValidateBlock valida a estrutura e a autoria de um bloco
+func (bft *BFTEngine) ValidateBlock(ctx context.Context, block *entities.Block) error {
+	if block == nil {
+		return errors.New("block is nil")
+	}
+
+	if err := bft.blockBuilder.ValidateBlock(ctx, block); err != nil {
+		return fmt.Errorf("block validation failed: %w", err)
+	}
+
+	return bft.authority.VerifyBlockAuthority(ctx, block)
+}
+
+// GetCurrentValidator retorna o proposer do round atual
+func (bft *BFTEngine) GetCurrentValidator(ctx context.Context) (valueobjects.NodeID, error) {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	if bft.proposer.IsEmpty() {
+		return valueobjects.EmptyNodeID(), errors.New("no current validator set")
+	}
+
+	return bft.proposer, nil
+}
+
+// GetNextValidator retorna o proposer do próximo round
+func (bft *BFTEngine) GetNextValidator(ctx context.Context) (valueobjects.NodeID, error) {
+	bft.mu.Lock()
+	height, round := bft.height, bft.round
+	bft.mu.Unlock()
+
+	return bft.roundRobin.SelectProposer(ctx, height, round+1)
+}
+
+// IsValidator verifica se um nó é um validador autorizado
+func (bft *BFTEngine) IsValidator(ctx context.Context, nodeID valueobjects.NodeID) (bool, error) {
+	return bft.validatorManager.IsValidator(ctx, nodeID)
+}
+
+// AddValidator adiciona um novo validador à lista
+func (bft *BFTEngine) AddValidator(ctx context.Context, nodeID valueobjects.NodeID) error {
+	// Nota: sem chave pública, como no ConsensusAdapter
+	return bft.validatorManager.AddValidator(ctx, nodeID, nil)
+}
+
+// RemoveValidator remove um validador da lista
+func (bft *BFTEngine) RemoveValidator(ctx context.Context, nodeID valueobjects.NodeID) error {
+	return bft.validatorManager.RemoveValidator(ctx, nodeID)
+}
+
+// GetValidators retorna a lista de todos os validadores
+func (bft *BFTEngine) GetValidators(ctx context.Context) ([]valueobjects.NodeID, error) {
+	validators, err := bft.validatorManager.GetAllValidators(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeIDs := make([]valueobjects.NodeID, len(validators))
+	for i, validator := range validators {
+		nodeIDs[i] = validator.NodeID
+	}
+
+	return nodeIDs, nil
+}
+
+// GetValidatorCount retorna o número de validadores
+func (bft *BFTEngine) GetValidatorCount(ctx context.Context) (int, error) {
+	return bft.validatorManager.GetValidatorCount(ctx)
+}
+
+// IsMyTurn verifica se este nó é o proposer do round atual
+func (bft *BFTEngine) IsMyTurn(ctx context.Context) (bool, error) {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	return bft.proposer.Equals(bft.myNodeID), nil
+}
+
+// GetCurrentRound retorna o round atual na altura em consenso
+func (bft *BFTEngine) GetCurrentRound(ctx context.Context) (uint64, error) {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	return bft.round, nil
+}
+
+// AdvanceRound avança para o próximo round
+func (bft *BFTEngine) AdvanceRound(ctx context.Context) error {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	if !bft.isRunning {
+		return errors.New("consensus not running")
+	}
+
+	bft.startRound(ctx, bft.round+1, "manual advance")
+	bft.evaluate(ctx)
+
+	return nil
+}
+
+// HandleTimeout trata o timeout do proposer atual votando nil no round
+func (bft *BFTEngine) HandleTimeout(ctx context.Context, validator valueobjects.NodeID) error {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	if !bft.proposer.Equals(validator) {
+		return fmt.Errorf("timeout for non-current validator: %s", validator.ShortString())
+	}
+
+	bft.handleTimeout(ctx, bftTimeout{height: bft.height, round: bft.round, step: StepPropose})
+	return nil
+}
+
+// GetConsensusStatus retorna o status atual do consenso
+func (bft *BFTEngine) GetConsensusStatus(ctx context.Context) (services.ConsensusStatus, error) {
+	validatorCount, _ := bft.validatorManager.GetValidatorCount(ctx)
+
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	return services.ConsensusStatus{
+		IsRunning:        bft.isRunning,
+		CurrentValidator: bft.proposer,
+		CurrentRound:     bft.round,
+		ValidatorCount:   validatorCount,
+		LastBlockTime:    bft.lastBlockTime,
+	}, nil
+}
+
+// GetRoundState retorna o estado do round em andamento
+func (bft *BFTEngine) GetRoundState() *BFTRoundState {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	return &BFTRoundState{
+		Height:      bft.height,
+		Round:       bft.round,
+		Step:        bft.step,
+		Proposer:    bft.proposer,
+		LockedRound: bft.lockedRound,
+		ValidRound:  bft.validRound,
+	}
+}
+
+// GetRoundRobinScheduler retorna o scheduler usado na seleção de proposers
+func (bft *BFTEngine) GetRoundRobinScheduler() *RoundRobinScheduler {
+	return bft.roundRobin
+}
+
+// GetValidatorManager retorna o gerenciador de validadores
+func (bft *BFTEngine) GetValidatorManager() *ValidatorManager {
+	return bft.validatorManager
+}
+
+// GetMempool retorna o pool de transações pendentes
+func (bft *BFTEngine) GetMempool() *Mempool {
+	return bft.mempool
+}
+
+// GetPendingTransactionCount retorna o número de transações pendentes
+func (bft *BFTEngine) GetPendingTransactionCount() int {
+	return bft.mempool.Size()
+}
+
+// consensusLoop acompanha a cadeia: blocos recebidos por sincronização avançam a altura
+func (bft *BFTEngine) consensusLoop(ctx context.Context, stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Millisecond * 500)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-stop:
+			return
+		case <-ticker.C:
+			bft.mu.Lock()
+			bft.catchUp(ctx)
+			bft.tryPropose(ctx, nil)
+			bft.mu.Unlock()
+		}
+	}
+}
+
+// catchUp avança para a próxima altura se a cadeia cresceu por outro caminho (deve ser chamado com lock)
+func (bft *BFTEngine) catchUp(ctx context.Context) {
+	chainHeight, err := bft.chainManager.GetChainHeight(ctx)
+	if err != nil {
+		return
+	}
+
+	if chainHeight+1 > bft.height {
+		bft.startHeight(ctx, chainHeight+1)
+	}
+}
+
+// resetHeightState limpa o estado de uma altura (deve ser chamado com lock)
+func (bft *BFTEngine) resetHeightState() {
+	bft.round = 0
+	bft.step = StepPropose
+	bft.proposed = false
+	bft.lockedBlock = nil
+	bft.lockedHash = valueobjects.EmptyHash()
+	bft.lockedRound = -1
+	bft.validBlock = nil
+	bft.validHash = valueobjects.EmptyHash()
+	bft.validRound = -1
+	bft.ownBlock = nil
+	bft.proposals = make(map[uint64]*entities.ConsensusProposal)
+	bft.prevotes = make(map[uint64]*voteSet)
+	bft.precommits = make(map[uint64]*voteSet)
+	bft.polSeen = make(map[uint64]bool)
+	bft.timeouts = make(map[bftTimeout]bool)
+}
+
+// startHeight inicia o consenso de uma nova altura (deve ser chamado com lock)
+func (bft *BFTEngine) startHeight(ctx context.Context, height uint64) {
+	bft.height = height
+	bft.resetHeightState()
+	bft.startRound(ctx, 0, "new height")
+}
+
+// startRound inicia um round na altura atual (deve ser chamado com lock)
+func (bft *BFTEngine) startRound(ctx context.Context, round uint64, reason string) {
+	bft.round = round
+	bft.step = StepPropose
+	bft.proposed = false
+
+	proposer, err := bft.roundRobin.SelectProposer(ctx, bft.height, round)
+	if err != nil {
+		bft.reportError(fmt.Errorf("failed to select proposer: %w", err))
+		return
+	}
+	bft.proposer = proposer
+
+	// Proposer do round vem da sequência do Round Robin
+	bft.roundRobin.MoveToRound(ctx, round, proposer, reason)
+
+	bft.tryPropose(ctx, nil)
+
+	// Rounds após uma falha sempre correm contra o relógio; no round 0, sempre que já houver
+	// transações pendentes (as que sobraram da altura anterior não chegam por AddTransaction
+	// e, com o proposer fora do ar, a altura travaria)
+	if round > 0 || bft.mempool.Size() > 0 {
+		bft.scheduleProposeTimeout()
+	}
+}
+
+// tryPropose propõe um bloco se este nó for o proposer do round (deve ser chamado com lock)
+func (bft *BFTEngine) tryPropose(ctx context.Context, block *entities.Block) bool {
+	if !bft.isRunning || bft.step != StepPropose || bft.proposed || !bft.proposer.Equals(bft.myNodeID) {
+		return false
+	}
+
+	polRound := int64(-1)
+	if block == nil {
+		if bft.validBlock != nil {
+			// Bloco com 2/3 de prevotes em round anterior deve ser reproposto
+			block = bft.validBlock
+			polRound = bft.validRound
+		} else if bft.ownBlock != nil {
+			// Nunca assinar dois blocos diferentes na mesma altura (assinatura dupla)
+			block = bft.ownBlock
+		} else {
+			selectedTxs := bft.mempool.Select(bft.config.MaxTxPerBlock)
+			if len(selectedTxs) == 0 {
+				return false // Sem transações, sem bloco
+			}
+
+			var err error
+			block, err = bft.chainManager.ProposeBlock(ctx, selectedTxs, bft.myNodeID, bft.myPrivateKey)
+			if err != nil {
+				bft.reportError(fmt.Errorf("failed to propose block: %w", err))
+				return false
+			}
+			bft.ownBlock = block
+		}
+	}
+
+	blockHash := bft.chainManager.CalculateBlockHash(ctx, block)
+	proposal := entities.NewConsensusProposal(bft.height, bft.round, polRound, block, blockHash, bft.myNodeID)
+
+	signature, err := bft.cryptoService.Sign(ctx, proposal.SigningBytes(), bft.myPrivateKey)
+	if err != nil {
+		bft.reportError(fmt.Errorf("failed to sign proposal: %w", err))
+		return false
+	}
+	proposal.SetSignature(signature)
+
+	bft.proposed = true
+	bft.proposals[bft.round] = proposal
+
+	if bft.networkService != nil {
+		if err := bft.networkService.BroadcastProposal(ctx, proposal); err != nil {
+			bft.logger.WarnContext(ctx, "failed to broadcast proposal", logging.Height(bft.height), "round", bft.round, logging.Err(err))
+		}
+	}
+
+	bft.evaluate(ctx)
+	return true
+}
+
+// handleIncomingTransaction recebe transações via P2P
+func (bft *BFTEngine) handleIncomingTransaction(ctx context.Context, tx *entities.Transaction, fromPeer valueobjects.NodeID) error {
+	if err := bft.addTransaction(ctx, tx); err != nil {
+		return nil // Duplicatas não são erro crítico
+	}
+	return nil
+}
+
+// handleIncomingProposal recebe propostas via P2P
+func (bft *BFTEngine) handleIncomingProposal(ctx context.Context, proposal *entities.ConsensusProposal, fromPeer valueobjects.NodeID) error {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	if !bft.isRunning {
+		return nil
+	}
+
+	bft.catchUp(ctx)
+
+	isNew, err := bft.addProposal(ctx, proposal)
+	if err != nil {
+		bft.logger.WarnContext(ctx, "proposal rejected", logging.Height(proposal.GetHeight()), "round", proposal.GetRound(),
+			"proposer", proposal.GetProposer().String(), logging.Err(err))
+		return err
+	}
+
+	// Repassar apenas mensagens novas evita loops de gossip
+	if isNew && bft.networkService != nil {
+		bft.networkService.BroadcastProposal(ctx, proposal)
+	}
+
+	bft.scheduleProposeTimeout()
+
+	bft.evaluate(ctx)
+	return nil
+}
+
+// handleIncomingVote recebe prevotes/precommits via P2P
+func (bft *BFTEngine) handleIncomingVote(ctx context.Context, vote *entities.ConsensusVote, fromPeer valueobjects.NodeID) error {
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+
+	if !bft.isRunning {
+		return nil
+	}
+
+	bft.catchUp(ctx)
+
+	isNew, err := bft.addVote(ctx, vote)
+	if err != nil {
+		bft.logger.WarnContext(ctx, "consensus vote rejected", "vote_type", vote.GetType(), logging.Height(vote.GetHeight()),
+			"validator", vote.GetValidator().String(), logging.Err(err))
+		return err
+	}
+
+	if isNew && bft.networkService != nil {
+		bft.networkService.BroadcastConsensusVote(ctx, vote)
+	}
+
+	// Outros validadores já estão votando nesta altura
+	if isNew {
+		bft.scheduleProposeTimeout()
+	}
+
+	bft.evaluate(ctx)
+	return nil
+}
+
+// addProposal verifica e registra uma proposta da altura atual (deve ser chamado com lock)
+func (bft *BFTEngine) addProposal(ctx context.Context, proposal *entities.ConsensusProposal) (bool, error) {
+	if proposal == nil || !proposal.IsValid() {
+		return false, errors.New("invalid proposal")
+	}
+
+	if proposal.GetHeight() != bft.height {
+		return false, nil // Altura passada ou futura (a sincronização cobre o atraso)
+	}
+
+	if _, exists := bft.proposals[proposal.GetRound()]; exists {
+		return false, nil
+	}
+
+	expectedProposer, err := bft.roundRobin.SelectProposer(ctx, proposal.GetHeight(), proposal.GetRound())
+	if err != nil {
+		return false, err
+	}
+	if !proposal.GetProposer().Equals(expectedProposer) {
+		return false, fmt.Errorf("proposer %s is not the proposer of round %d", proposal.GetProposer().ShortString(), proposal.GetRound())
+	}
+
+	if err := bft.verifySignature(ctx, proposal.GetProposer(), proposal.GetHeight(), proposal.SigningBytes(), proposal.GetSignature()); err != nil {
+		return false, fmt.Errorf("invalid proposal signature: %w", err)
+	}
+
+	if err := bft.validateProposedBlock(ctx, proposal); err != nil {
+		return false, err
+	}
+
+	bft.proposals[proposal.GetRound()] = proposal
+	return true, nil
+}
+
+// validateProposedBlock verifica se o bloco proposto estende a cadeia local (deve ser chamado com lock)
+func (bft *BFTEngine) validateProposedBlock(ctx context.Context, proposal *entities.ConsensusProposal) error {
+	block := proposal.GetBlock()
+
+	if block.GetIndex() != proposal.GetHeight() {
+		return fmt.Errorf("block index %d does not match proposal height %d", block.GetIndex(), proposal.GetHeight())
+	}
+
+	if !bft.chainManager.CalculateBlockHash(ctx, block).Equals(proposal.GetBlockHash()) {
+		return errors.New("proposal block hash does not match block")
+	}
+
+	// Blocos novos são assinados pelo próprio proposer; blocos repropostos mantêm o autor original
+	if proposal.GetPOLRound() < 0 && !block.GetValidator().Equals(proposal.GetProposer()) {
+		return errors.New("new block must be signed by the proposer")
+	}
+
+	latestBlock, err := bft.chainManager.GetLatestBlock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block: %w", err)
+	}
+	if !block.GetPreviousHash().Equals(bft.chainManager.CalculateBlockHash(ctx, latestBlock)) {
+		return errors.New("proposed block does not extend the local chain")
+	}
+
+	return bft.ValidateBlock(ctx, block)
+}
+
+// addVote verifica e registra um voto da altura atual (deve ser chamado com lock)
+func (bft *BFTEngine) addVote(ctx context.Context, vote *entities.ConsensusVote) (bool, error) {
+	if vote == nil || !vote.IsValid() {
+		return false, errors.New("invalid vote")
+	}
+
+	if vote.GetHeight() != bft.height {
+		return false, nil
+	}
+
+	electorate, err := bft.electorate(ctx, vote.GetHeight())
+	if err != nil {
+		return false, err
+	}
+	if _, eligible := electorate[vote.GetValidator().String()]; !eligible {
+		return false, fmt.Errorf("validator %s is not eligible to vote at height %d", vote.GetValidator().ShortString(), vote.GetHeight())
+	}
+
+	if err := bft.verifySignature(ctx, vote.GetValidator(), vote.GetHeight(), vote.SigningBytes(), vote.GetSignature()); err != nil {
+		return false, fmt.Errorf("invalid vote signature: %w", err)
+	}
+
+	return bft.votesFor(vote.GetType(), vote.GetRound()).add(vote), nil
+}
+
+// verifySignature verifica a assinatura de um validador autorizado na altura
+func (bft *BFTEngine) verifySignature(ctx context.Context, validator valueobjects.NodeID, height uint64, data []byte, signature valueobjects.Signature) error {
+	publicKey, err := bft.validatorManager.GetValidatorPublicKeyAtHeight(ctx, validator, height)
+	if err != nil {
+		return fmt.Errorf("validator %s not authorized at height %d: %w", validator.ShortString(), height, err)
+	}
+
+	valid, err := bft.cryptoService.Verify(ctx, data, signature, publicKey)
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("signature verification failed")
+	}
+
+	return nil
+}
+
+// votesFor retorna o conjunto de votos de uma etapa e round (deve ser chamado com lock)
+func (bft *BFTEngine) votesFor(voteType entities.ConsensusVoteType, round uint64) *voteSet {
+	votes := bft.prevotes
+	if voteType == entities.VotePrecommit {
+		votes = bft.precommits
+	}
+
+	set, exists := votes[round]
+	if !exists {
+		set = newVoteSet()
+		votes[round] = set
+	}
+	return set
+}
+
+// electorate retorna os validadores que votam na altura: o conjunto vigente nela sem os banidos.
+// Os votos aceitos, a contagem e o quórum vêm todos deste conjunto.
+func (bft *BFTEngine) electorate(ctx context.Context, height uint64) (map[string]*services.PublicKey, error) {
+	electorate := bft.validatorManager.GetEligibleValidatorsAtHeight(ctx, height)
+	if len(electorate) == 0 {
+		return nil, fmt.Errorf("no eligible validators at height %d", height)
+	}
+	return electorate, nil
+}
+
+// quorum retorna o número de votos necessário (mais de 2/3 do eleitorado)
+func quorum(electorate map[string]*services.PublicKey) int {
+	return len(electorate)*2/3 + 1
+}
+
+// evaluate aplica as regras do protocolo até não haver mais transições (deve ser chamado com lock)
+func (bft *BFTEngine) evaluate(ctx context.Context) {
+	for bft.isRunning && bft.step != StepCommit {
+		if !bft.evaluateOnce(ctx) {
+			return
+		}
+	}
+}
+
+// evaluateOnce aplica a primeira regra habilitada; retorna true se o estado mudou
+func (bft *BFTEngine) evaluateOnce(ctx context.Context) bool {
+	electorate, err := bft.electorate(ctx, bft.height)
+	if err != nil {
+		// Sem eleitorado não há quórum possível: nenhuma transição
+		bft.logger.WarnContext(ctx, "consensus electorate unavailable", logging.Height(bft.height), logging.Err(err))
+		return false
+	}
+	required := quorum(electorate)
+
+	// Decisão: 2/3 de precommits para uma proposta em qualquer round
+	for round, proposal := range bft.proposals {
+		if set, ok := bft.precommits[round]; ok && set.count(proposal.GetBlockHash(), electorate) >= required {
+			bft.commit(ctx, proposal)
+			return false
+		}
+	}
+
+	// Mais de 1/3 dos validadores em um round à frente: acompanhar
+	faulty := (len(electorate) - 1) / 3
+	for round := range bft.roundsAhead() {
+		if bft.participants(round, electorate) > faulty {
+			bft.startRound(ctx, round, "round skip")
+			return true
+		}
+	}
+
+	proposal := bft.proposals[bft.round]
+	prevotes := bft.votesFor(entities.VotePrevote, bft.round)
+	precommits := bft.votesFor(entities.VotePrecommit, bft.round)
+
+	// Prevote sobre a proposta do round
+	if bft.step == StepPropose && proposal != nil {
+		hash := proposal.GetBlockHash()
+		polRound := proposal.GetPOLRound()
+
+		if polRound < 0 {
+			if bft.lockedRound < 0 || bft.lockedHash.Equals(hash) {
+				bft.castVote(ctx, entities.VotePrevote, hash)
+			} else {
+				bft.castVote(ctx, entities.VotePrevote, valueobjects.EmptyHash())
+			}
+			bft.step = StepPrevote
+			return true
+		}
+
+		if uint64(polRound) < bft.round {
+			if set, ok := bft.prevotes[uint64(polRound)]; ok && set.count(hash, electorate) >= required {
+				if bft.lockedRound <= polRound || bft.lockedHash.Equals(hash) {
+					bft.castVote(ctx, entities.VotePrevote, hash)
+				} else {
+					bft.castVote(ctx, entities.VotePrevote, valueobjects.EmptyHash())
+				}
+				bft.step = StepPrevote
+				return true
+			}
+		}
+	}
+
+	// 2/3 de prevotes para a proposta: lock e precommit
+	if bft.step >= StepPrevote && proposal != nil && !bft.polSeen[bft.round] &&
+		prevotes.count(proposal.GetBlockHash(), electorate) >= required {
+		bft.polSeen[bft.round] = true
+
+		if bft.step == StepPrevote {
+			bft.lockedBlock = proposal.GetBlock()
+			bft.lockedHash = proposal.GetBlockHash()
+			bft.lockedRound = int64(bft.round)
+			bft.castVote(ctx, entities.VotePrecommit, proposal.GetBlockHash())
+			bft.step = StepPrecommit
+		}
+
+		bft.validBlock = proposal.GetBlock()
+		bft.validHash = proposal.GetBlockHash()
+		bft.validRound = int64(bft.round)
+		return true
+	}
+
+	// 2/3 de prevotes nil: precommit nil
+	if bft.step == StepPrevote && prevotes.count(valueobjects.EmptyHash(), electorate) >= required {
+		bft.castVote(ctx, entities.VotePrecommit, valueobjects.EmptyHash())
+		bft.step = StepPrecommit
+		return true
+	}
+
+	// 2/3 de prevotes divergentes: aguardar um pouco antes de desistir
+	if bft.step == StepPrevote && prevotes.total(electorate) >= required {
+		bft.scheduleTimeout(StepPrevote, bft.config.PrevoteTimeout)
+	}
+
+	// 2/3 de precommits sem decisão: próximo round após o timeout
+	if precommits.total(electorate) >= required {
+		bft.scheduleTimeout(StepPrecommit, bft.config.PrecommitTimeout)
+	}
+
+	return false
+}
+
+// roundsAhead retorna os rounds futuros com mensagens recebidas (deve ser chamado com lock)
+func (bft *BFTEngine) roundsAhead() map[uint64]bool {
+	rounds := make(map[uint64]bool)
+	for round := range bft.prevotes {
+		if round > bft.round {
+			rounds[round] = true
+		}
+	}
+	for round := range bft.precommits {
+		if round > bft.round {
+			rounds[round] = true
+		}
+	}
+	return rounds
+}
+
+// participants conta validadores distintos do eleitorado com votos em um round (deve ser chamado com lock)
+func (bft *BFTEngine) participants(round uint64, electorate map[string]*services.PublicKey) int {
+	seen := make(map[string]bool)
+	for _, votes := range []map[uint64]*voteSet{bft.prevotes, bft.precommits} {
+		if set, ok := votes[round]; ok {
+			for validator := range set.votes {
+				if _, eligible := electorate[validator]; eligible {
+					seen[validator] = true
+				}
+			}
+		}
+	}
+	return len(seen)
+}
+
+// castVote assina, registra e propaga um voto deste nó (deve ser chamado com lock)
+func (bft *BFTEngine) castVote(ctx context.Context, voteType entities.ConsensusVoteType, hash valueobjects.Hash) {
+	if bft.myPrivateKey == nil {
+		return
+	}
+
+	vote := entities.NewConsensusVote(voteType, bft.height, bft.round, hash, bft.myNodeID)
+
+	signature, err := bft.cryptoService.Sign(ctx, vote.SigningBytes(), bft.myPrivateKey)
+	if err != nil {
+		bft.reportError(fmt.Errorf("failed to sign %s: %w", voteType, err))
+		return
+	}
+	vote.SetSignature(signature)
+
+	// Mesma admissão dos votos recebidos: um nó banido não vota
+	if _, err := bft.addVote(ctx, vote); err != nil {
+		bft.logger.DebugContext(ctx, "own consensus vote not cast", "vote_type", voteType, logging.Height(bft.height), logging.Err(err))
+		return
+	}
+
+	if bft.networkService != nil {
+		if err := bft.networkService.BroadcastConsensusVote(ctx, vote); err != nil {
+			bft.logger.WarnContext(ctx, "failed to broadcast consensus vote", "vote_type", voteType, logging.Height(bft.height), "round", bft.round, logging.Err(err))
+		}
+	}
+}
+
+// commit adiciona o bloco decidido à cadeia e inicia a próxima altura (deve ser chamado com lock)
+func (bft *BFTEngine) commit(ctx context.Context, proposal *entities.ConsensusProposal) {
+	block := proposal.GetBlock()
+	bft.step = StepCommit
+
+	if err := bft.chainManager.AddBlock(ctx, block); err != nil {
+		// O bloco pode já ter chegado por gossip/sincronização
+		chainHeight, heightErr := bft.chainManager.GetChainHeight(ctx)
+		if heightErr != nil || chainHeight < block.GetIndex() {
+			bft.reportError(fmt.Errorf("failed to commit block %d: %w", block.GetIndex(), err))
+			return
+		}
+	}
+
+	// Precommits de 2/3 tornam o bloco final imediatamente
+	if err := bft.chainManager.MarkFinalized(ctx, block.GetIndex(), proposal.GetBlockHash()); err != nil {
+		bft.logger.WarnContext(ctx, "could not mark block as final", logging.Height(block.GetIndex()), logging.Err(err))
+	}
+
+	bft.lastBlockTime = valueobjects.Now()
+
+	if bft.networkService != nil {
+		// Nós que não participam do consenso recebem o bloco e a finalidade por gossip
+		if err := bft.networkService.BroadcastBlock(ctx, block); err != nil {
+			bft.logger.WarnContext(ctx, "failed to broadcast block", logging.Height(block.GetIndex()), logging.Err(err))
+		}
+		bft.broadcastAttestation(ctx, block.GetIndex(), proposal.GetBlockHash())
+	}
+
+	bft.logger.InfoContext(ctx, "block committed", logging.Height(block.GetIndex()), "round", proposal.GetRound(), "transactions", len(block.GetTransactions()))
+
+	if bft.onBlockCommitted != nil {
+		bft.onBlockCommitted(block)
+	}
+
+	bft.startHeight(ctx, block.GetIndex()+1)
+}
+
+// broadcastAttestation propaga a atestação do bloco decidido, se este nó for validador
+func (bft *BFTEngine) broadcastAttestation(ctx context.Context, height uint64, hash valueobjects.Hash) {
+	if bft.myPrivateKey == nil {
+		return
+	}
+
+	attestation, err := NewSignedAttestation(ctx, bft.cryptoService, hash, height, bft.myNodeID, bft.myPrivateKey)
+	if err != nil {
+		bft.logger.ErrorContext(ctx, "failed to create attestation", logging.Height(height), logging.Err(err))
+		return
+	}
+
+	if err := bft.networkService.BroadcastAttestation(ctx, attestation); err != nil {
+		bft.logger.WarnContext(ctx, "failed to broadcast attestation", logging.Height(height), logging.Err(err))
+	}
+}
+
+// scheduleProposeTimeout agenda o timeout da proposta do round atual (deve ser chamado com lock)
+func (bft *BFTEngine) scheduleProposeTimeout() {
+	if bft.step == StepPropose {
+		bft.scheduleTimeout(StepPropose, bft.config.ProposeTimeout)
+	}
+}
+
+// scheduleTimeout agenda um timeout uma única vez por etapa e round (deve ser chamado com lock)
+func (bft *BFTEngine) scheduleTimeout(step BFTStep, base time.Duration) {
+	timeout := bftTimeout{height: bft.height, round: bft.round, step: step}
+	if bft.timeouts[timeout] {
+		return
+	}
+	bft.timeouts[timeout] = true
+
+	// Timeouts crescem a cada round para tolerar redes mais lentas
+	duration := base + time.Duration(bft.round)*bft.config.TimeoutDelta
+
+	time.AfterFunc(duration, func() {
+		bft.mu.Lock()
+		defer bft.mu.Unlock()
+
+		if !bft.isRunning {
+			return
+		}
+		bft.handleTimeout(context.Background(), timeout)
+	})
+}
+
+// handleTimeout aplica a transição de timeout se o round ainda for o mesmo (deve ser chamado com lock)
+func (bft *BFTEngine) handleTimeout(ctx context.Context, timeout bftTimeout) {
+	if timeout.height != bft.height || timeout.round != bft.round {
+		return
+	}
+
+	switch timeout.step {
+	case StepPropose:
+		if bft.step == StepPropose {
+			bft.castVote(ctx, entities.VotePrevote, valueobjects.EmptyHash())
+			bft.step = StepPrevote
+		}
+	case StepPrevote:
+		if bft.step == StepPrevote {
+			bft.castVote(ctx, entities.VotePrecommit, valueobjects.EmptyHash())
+			bft.step = StepPrecommit
+		}
+	case StepPrecommit:
+		if bft.step != StepCommit {
+			// Round sem decisão: próximo proposer da sequência Round Robin
+			bft.startRound(ctx, bft.round+1, "round timeout")
+		}
+	}
+
+	bft.evaluate(ctx)
+}
+
+// reportError notifica erros de consenso (deve ser chamado com lock)
+func (bft *BFTEngine) reportError(err error) {
+	bft.logger.Error("consensus error", logging.Height(bft.height), "round", bft.round, logging.Err(err))
+	if bft.onConsensusError != nil {
+		bft.onConsensusError(err)
+	}
+}
@@ -0,0 +1,200 @@
+package consensus
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// newTestBFT cria o motor BFT do validador 0 parado na altura 1, sem rede
+func newTestBFT(t *testing.T, chain *testChain) *BFTEngine {
+	t.Helper()
+
+	bft := NewBFTEngine(chain.validatorManager, chain.chainManager, chain.cryptoService, chain.nodeIDs[0], chain.keyPairs[0].PrivateKey, nil, DefaultBFTConfig())
+	bft.height = 1
+	bft.resetHeightState()
+	return bft
+}
+
+// signedVote cria um voto assinado pelo validador i
+func (c *testChain) signedVote(t *testing.T, i int, voteType entities.ConsensusVoteType, height, round uint64, hash valueobjects.Hash) *entities.ConsensusVote {
+	t.Helper()
+
+	vote := entities.NewConsensusVote(voteType, height, round, hash, c.nodeIDs[i])
+	signature, err := c.cryptoService.Sign(context.Background(), vote.SigningBytes(), c.keyPairs[i].PrivateKey)
+	if err != nil {
+		t.Fatalf("sign vote: %v", err)
+	}
+	vote.SetSignature(signature)
+	return vote
+}
+
+func TestBFTQuorumIsMoreThanTwoThirds(t *testing.T) {
+	for validators, want := range map[int]int{1: 1, 3: 3, 4: 3, 5: 4, 7: 5, 10: 7} {
+		electorate := newTestChain(t, validators).validatorManager.GetEligibleValidatorsAtHeight(context.Background(), 1)
+		if got := quorum(electorate); got != want {
+			t.Errorf("quorum(%d validators) = %d, want %d", validators, got, want)
+		}
+	}
+}
+
+func TestBFTRejectsVotesOutsideTheElectorate(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 4)
+	bft := newTestBFT(t, chain)
+	hash := valueobjects.NewHash([]byte("block"))
+
+	if err := chain.validatorManager.SetValidatorStatus(ctx, chain.nodeIDs[3], ValidatorBanned); err != nil {
+		t.Fatalf("ban validator: %v", err)
+	}
+
+	if added, err := bft.addVote(ctx, chain.signedVote(t, 1, entities.VotePrevote, 1, 0, hash)); !added || err != nil {
+		t.Fatalf("vote from eligible validator: added=%v err=%v", added, err)
+	}
+	if _, err := bft.addVote(ctx, chain.signedVote(t, 3, entities.VotePrevote, 1, 0, hash)); err == nil {
+		t.Fatalf("vote from banned validator accepted")
+	}
+
+	outsider := newTestChain(t, 1)
+	vote := entities.NewConsensusVote(entities.VotePrevote, 1, 0, hash, outsider.nodeIDs[0])
+	signature, _ := outsider.cryptoService.Sign(ctx, vote.SigningBytes(), outsider.keyPairs[0].PrivateKey)
+	vote.SetSignature(signature)
+	if _, err := bft.addVote(ctx, vote); err == nil {
+		t.Fatalf("vote from non-validator accepted")
+	}
+
+	if got := bft.votesFor(entities.VotePrevote, 0).total(chain.validatorManager.GetEligibleValidatorsAtHeight(ctx, 1)); got != 1 {
+		t.Errorf("counted prevotes = %d, want 1", got)
+	}
+}
+
+func TestBFTVotesOfBannedValidatorLeaveTheCount(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 4)
+	bft := newTestBFT(t, chain)
+	hash := valueobjects.NewHash([]byte("block"))
+
+	for i := 1; i < 4; i++ {
+		if _, err := bft.addVote(ctx, chain.signedVote(t, i, entities.VotePrecommit, 1, 0, hash)); err != nil {
+			t.Fatalf("add vote %d: %v", i, err)
+		}
+	}
+
+	// Banido depois de votar: some do denominador e da contagem ao mesmo tempo
+	if err := chain.validatorManager.SetValidatorStatus(ctx, chain.nodeIDs[3], ValidatorBanned); err != nil {
+		t.Fatalf("ban validator: %v", err)
+	}
+	electorate, err := bft.electorate(ctx, 1)
+	if err != nil {
+		t.Fatalf("electorate: %v", err)
+	}
+
+	if got := bft.votesFor(entities.VotePrecommit, 0).count(hash, electorate); got != 2 || quorum(electorate) != 3 {
+		t.Errorf("count = %d with quorum %d, want 2 of 3 required", got, quorum(electorate))
+	}
+}
+
+func TestBFTFailsClosedWithoutElectorate(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 2)
+	bft := newTestBFT(t, chain)
+
+	for _, nodeID := range chain.nodeIDs {
+		if err := chain.validatorManager.SetValidatorStatus(ctx, nodeID, ValidatorBanned); err != nil {
+			t.Fatalf("ban validator: %v", err)
+		}
+	}
+
+	_, err := bft.addVote(ctx, chain.signedVote(t, 1, entities.VotePrevote, 1, 0, valueobjects.EmptyHash()))
+	if err == nil || !strings.Contains(err.Error(), "no eligible validators") {
+		t.Fatalf("addVote error = %v, want no eligible validators", err)
+	}
+	if bft.evaluateOnce(ctx) {
+		t.Errorf("state changed without an electorate")
+	}
+}
+
+// runTestBFT marca o motor como em execução sem iniciar o loop, com timeouts longos o
+// suficiente para que só o teste dispare transições de timeout
+func runTestBFT(t *testing.T, bft *BFTEngine) {
+	t.Helper()
+
+	bft.config.ProposeTimeout = time.Hour
+	bft.config.PrevoteTimeout = time.Hour
+	bft.config.PrecommitTimeout = time.Hour
+	bft.isRunning = true
+	t.Cleanup(func() {
+		bft.mu.Lock()
+		bft.isRunning = false
+		bft.mu.Unlock()
+	})
+}
+
+func TestBFTRoundChangesAfterNilPrecommits(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 4)
+	bft := newTestBFT(t, chain)
+	runTestBFT(t, bft)
+
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+	bft.startRound(ctx, 0, "test")
+	firstProposer := bft.proposer
+
+	for i := 1; i < 4; i++ {
+		if _, err := bft.addVote(ctx, chain.signedVote(t, i, entities.VotePrecommit, 1, 0, valueobjects.EmptyHash())); err != nil {
+			t.Fatalf("add vote %d: %v", i, err)
+		}
+	}
+	bft.evaluate(ctx)
+	if bft.round != 0 || !bft.timeouts[bftTimeout{height: 1, round: 0, step: StepPrecommit}] {
+		t.Fatalf("round = %d, precommit timeout scheduled = %v; want round 0 waiting for the timeout",
+			bft.round, bft.timeouts[bftTimeout{height: 1, round: 0, step: StepPrecommit}])
+	}
+
+	bft.handleTimeout(ctx, bftTimeout{height: 1, round: 0, step: StepPrecommit})
+	if bft.round != 1 || bft.step != StepPropose {
+		t.Fatalf("round = %d step = %s after timeout, want round 1 propose", bft.round, bft.step)
+	}
+	if bft.proposer.Equals(firstProposer) {
+		t.Errorf("proposer %s kept after round change", bft.proposer.ShortString())
+	}
+
+	// Timeout atrasado de um round anterior não muda mais nada
+	bft.handleTimeout(ctx, bftTimeout{height: 1, round: 0, step: StepPrecommit})
+	if bft.round != 1 {
+		t.Errorf("stale timeout moved to round %d", bft.round)
+	}
+}
+
+func TestBFTSkipsToRoundWithMoreThanOneThirdParticipation(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 4)
+	bft := newTestBFT(t, chain)
+	runTestBFT(t, bft)
+
+	bft.mu.Lock()
+	defer bft.mu.Unlock()
+	bft.startRound(ctx, 0, "test")
+
+	// Um validador à frente pode ser bizantino: não basta
+	if _, err := bft.addVote(ctx, chain.signedVote(t, 1, entities.VotePrevote, 1, 3, valueobjects.EmptyHash())); err != nil {
+		t.Fatalf("add vote: %v", err)
+	}
+	bft.evaluate(ctx)
+	if bft.round != 0 {
+		t.Fatalf("skipped to round %d with a single validator ahead", bft.round)
+	}
+
+	if _, err := bft.addVote(ctx, chain.signedVote(t, 2, entities.VotePrecommit, 1, 3, valueobjects.EmptyHash())); err != nil {
+		t.Fatalf("add vote: %v", err)
+	}
+	bft.evaluate(ctx)
+	if bft.round != 3 {
+		t.Errorf("round = %d, want 3 after two validators voted there", bft.round)
+	}
+}
@@ -0,0 +1,50 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+)
+
+// BlockAuthority implementa blockchain.BlockAuthority usando o conjunto de validadores PoA
+type BlockAuthority struct {
+	validatorManager *ValidatorManager
+	blockBuilder     *blockchain.BlockBuilder
+}
+
+// NewBlockAuthority cria as regras de autoridade PoA para o ChainManager
+func NewBlockAuthority(validatorManager *ValidatorManager, cryptoService services.CryptographyService) *BlockAuthority {
+	return &BlockAuthority{
+		validatorManager: validatorManager,
+		blockBuilder:     blockchain.NewBlockBuilder(cryptoService),
+	}
+}
+
+// VerifyBlockAuthority verifica se o bloco foi assinado por um validador autorizado na sua altura
+func (ba *BlockAuthority) VerifyBlockAuthority(ctx context.Context, block *entities.Block) error {
+	if block == nil {
+		return errors.New("block is nil")
+	}
+
+	publicKey, err := ba.validatorManager.GetValidatorPublicKeyAtHeight(ctx, block.GetValidator(), block.GetIndex())
+	if err != nil {
+		return fmt.Errorf("validator %s not authorized at height %d: %w", block.GetValidator().ShortString(), block.GetIndex(), err)
+	}
+
+	return ba.blockBuilder.ValidateBlockSignature(ctx, block, publicKey)
+}
+
+// IsInTurn verifica se o validador era o validador da vez na altura informada
+func (ba *BlockAuthority) IsInTurn(ctx context.Context, validator valueobjects.NodeID, height uint64) bool {
+	inTurn, err := ba.validatorManager.GetInTurnValidator(ctx, height)
+	if err != nil {
+		return false
+	}
+
+	return inTurn.Equals(validator)
+}
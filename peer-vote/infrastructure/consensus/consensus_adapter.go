@@ -2,19 +2,32 @@ package consensus
 
 import (
 	"context"
+	"time"
 
 	"github.com/matscats/peer-vote/peer-vote/domain/entities"
 	"github.com/matscats/peer-vote/peer-vote/domain/services"
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
 )
 
+// Engine é o motor de consenso administrado pelo nó, implementado pelo BFTEngine e, através
+// do ConsensusAdapter, pelo PoAEngine
+type Engine interface {
+	services.ConsensusService
+
+	// GetRoundRobinScheduler retorna o escalonador de proposers do motor
+	GetRoundRobinScheduler() *RoundRobinScheduler
+
+	// GetPendingTransactionCount retorna o número de transações no pool
+	GetPendingTransactionCount() int
+}
+
 // ConsensusAdapter adapta PoAEngine para implementar services.ConsensusService
 type ConsensusAdapter struct {
 	poaEngine *PoAEngine
 }
 
 // NewConsensusAdapter cria um novo adapter para consenso
-func NewConsensusAdapter(poaEngine *PoAEngine) services.ConsensusService {
+func NewConsensusAdapter(poaEngine *PoAEngine) Engine {
 	return &ConsensusAdapter{
 		poaEngine: poaEngine,
 	}
@@ -105,3 +118,23 @@ func (ca *ConsensusAdapter) GetConsensusStatus(ctx context.Context) (services.Co
 		LastBlockTime:    status.LastBlockTime,
 	}, nil
 }
+
+// GetRoundRobinScheduler retorna o escalonador do motor PoA
+func (ca *ConsensusAdapter) GetRoundRobinScheduler() *RoundRobinScheduler {
+	return ca.poaEngine.GetRoundRobinScheduler()
+}
+
+// GetPendingTransactionCount retorna o número de transações pendentes do motor PoA
+func (ca *ConsensusAdapter) GetPendingTransactionCount() int {
+	return ca.poaEngine.GetPendingTransactionCount()
+}
+
+// BroadcastTransaction propaga a transação (o AddTransaction do PoA apenas a adiciona ao pool)
+func (ca *ConsensusAdapter) BroadcastTransaction(ctx context.Context, tx *entities.Transaction) error {
+	return ca.poaEngine.BroadcastTransaction(ctx, tx)
+}
+
+// SetConfiguration define o intervalo e os limites de transações dos blocos do PoA
+func (ca *ConsensusAdapter) SetConfiguration(blockInterval time.Duration, minTxPerBlock, maxTxPerBlock int) {
+	ca.poaEngine.SetConfiguration(blockInterval, minTxPerBlock, maxTxPerBlock)
+}
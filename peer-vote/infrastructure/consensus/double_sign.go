@@ -0,0 +1,341 @@
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// DoubleSignDetector detecta validadores que assinam dois blocos diferentes na mesma altura
+// ou propostas estruturalmente inválidas. Guarda o cabeçalho assinado de cada (validador,
+// altura) recente; ao encontrar um conflito produz uma evidência verificável, que é incluída
+// na cadeia como transação. As penalidades são aplicadas pelo PenaltyLedger quando o bloco
+// com a evidência entra na cadeia canônica.
+type DoubleSignDetector struct {
+	validatorManager *ValidatorManager
+	chainManager     *blockchain.ChainManager
+	cryptoService    services.CryptographyService
+	blockBuilder     *blockchain.BlockBuilder
+
+	// Cabeçalhos assinados por altura e validador
+	headers map[uint64]map[string]*entities.SignedHeader
+
+	// Evidências já reportadas por este nó
+	reported map[string]bool
+
+	// Quantidade de alturas recentes mantidas em memória
+	window uint64
+
+	logger *slog.Logger
+
+	// Mutex para operações thread-safe
+	mu sync.Mutex
+}
+
+// Evidence representa uma evidência verificável de mau comportamento de um validador
+type Evidence interface {
+	GetValidator() valueobjects.NodeID
+	GetHeight() uint64
+	Key() string
+	ToBytes() ([]byte, error)
+}
+
+// signedHeaderFields são os campos do cabeçalho assinado usados na verificação de evidências
+type signedHeaderFields struct {
+	Index        uint64 `json:"index"`
+	Validator    string `json:"validator"`
+	MerkleRoot   string `json:"merkle_root"`
+	Transactions []struct {
+		Hash string `json:"hash"`
+	} `json:"transactions"`
+}
+
+// NewDoubleSignDetector cria um novo detector de assinatura dupla
+func NewDoubleSignDetector(
+	validatorManager *ValidatorManager,
+	chainManager *blockchain.ChainManager,
+	cryptoService services.CryptographyService,
+) *DoubleSignDetector {
+	return &DoubleSignDetector{
+		validatorManager: validatorManager,
+		chainManager:     chainManager,
+		cryptoService:    cryptoService,
+		blockBuilder:     blockchain.NewBlockBuilder(cryptoService),
+		headers:          make(map[uint64]map[string]*entities.SignedHeader),
+		reported:         make(map[string]bool),
+		window:           uint64(chainManager.GetMaxReorgDepth()),
+		logger:           logging.Component("penalties"),
+	}
+}
+
+// SetLogger define o logger do detector
+func (d *DoubleSignDetector) SetLogger(logger *slog.Logger) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.logger = logger
+}
+
+// Observe registra o cabeçalho assinado de um bloco (recebido por gossip, sync ou
+// resolução de fork) e retorna uma evidência se o validador já tiver assinado outro
+// bloco na mesma altura. Retorna nil quando não há conflito ou ele já foi reportado.
+func (d *DoubleSignDetector) Observe(ctx context.Context, block *entities.Block) (*entities.DoubleSignEvidence, error) {
+	header, err := d.signedHeader(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apenas cabeçalhos com assinatura válida podem incriminar um validador
+	if err := d.verifyHeader(ctx, block.GetValidator(), block.GetIndex(), header); err != nil {
+		return nil, err
+	}
+
+	// O bloco canônico nesta altura também conta como cabeçalho conhecido
+	canonical := d.canonicalHeader(ctx, block.GetIndex(), block.GetValidator())
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	height := block.GetIndex()
+	validatorKey := block.GetValidator().String()
+
+	byValidator, exists := d.headers[height]
+	if !exists {
+		byValidator = make(map[string]*entities.SignedHeader)
+		d.headers[height] = byValidator
+	}
+
+	known := byValidator[validatorKey]
+	if known == nil {
+		known = canonical
+	}
+
+	if known == nil {
+		byValidator[validatorKey] = header
+		d.prune(height)
+		return nil, nil
+	}
+
+	if bytes.Equal(known.GetPayload(), header.GetPayload()) {
+		byValidator[validatorKey] = known
+		return nil, nil
+	}
+
+	evidence := entities.NewDoubleSignEvidence(block.GetValidator(), height, known, header)
+	if d.reported[evidence.Key()] {
+		return nil, nil
+	}
+	d.reported[evidence.Key()] = true
+
+	d.logger.WarnContext(ctx, "double sign detected", "validator", block.GetValidator().String(), logging.Height(height))
+
+	return evidence, nil
+}
+
+// VerifyEvidence verifica se a evidência comprova a assinatura dupla
+func (d *DoubleSignDetector) VerifyEvidence(ctx context.Context, evidence *entities.DoubleSignEvidence) error {
+	if evidence == nil || !evidence.IsValid() {
+		return errors.New("evidence is incomplete or headers are identical")
+	}
+
+	for _, header := range []*entities.SignedHeader{evidence.GetFirst(), evidence.GetSecond()} {
+		if _, err := d.verifyEvidenceHeader(ctx, evidence.GetValidator(), evidence.GetHeight(), header); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CheckProposal verifica se um bloco com assinatura válida é estruturalmente inválido e,
+// nesse caso, retorna uma evidência de proposta inválida. Retorna nil para blocos corretos
+// ou já reportados.
+func (d *DoubleSignDetector) CheckProposal(ctx context.Context, block *entities.Block) (*entities.InvalidBlockEvidence, error) {
+	header, err := d.signedHeader(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := d.verifyEvidenceHeader(ctx, block.GetValidator(), block.GetIndex(), header)
+	if err != nil {
+		return nil, err
+	}
+
+	fault := headerFault(fields)
+	if fault == "" {
+		return nil, nil
+	}
+
+	evidence := entities.NewInvalidBlockEvidence(block.GetValidator(), block.GetIndex(), header, fault)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.reported[evidence.Key()] {
+		return nil, nil
+	}
+	d.reported[evidence.Key()] = true
+
+	d.logger.WarnContext(ctx, "invalid proposal detected", "validator", block.GetValidator().String(), "fault", fault, logging.Height(block.GetIndex()))
+
+	return evidence, nil
+}
+
+// VerifyInvalidBlockEvidence verifica se a evidência comprova uma proposta inválida
+func (d *DoubleSignDetector) VerifyInvalidBlockEvidence(ctx context.Context, evidence *entities.InvalidBlockEvidence) error {
+	if evidence == nil || !evidence.IsValid() {
+		return errors.New("evidence is incomplete")
+	}
+
+	fields, err := d.verifyEvidenceHeader(ctx, evidence.GetValidator(), evidence.GetHeight(), evidence.GetHeader())
+	if err != nil {
+		return err
+	}
+
+	// A falha é recalculada a partir do cabeçalho, independentemente do motivo informado
+	if headerFault(fields) == "" {
+		return errors.New("signed header is structurally valid")
+	}
+
+	return nil
+}
+
+// NewEvidenceTransaction empacota uma evidência verificada em uma transação.
+// O hash depende apenas da evidência, então reportes de nós diferentes são deduplicados.
+func NewEvidenceTransaction(ctx context.Context, cryptoService services.CryptographyService, evidence Evidence, reporter valueobjects.NodeID) (*entities.Transaction, error) {
+	data, err := evidence.ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize evidence: %w", err)
+	}
+
+	tx := entities.NewTransaction(entities.EvidenceTransaction, reporter, evidence.GetValidator(), data)
+	txHash := cryptoService.HashTransaction(ctx, data)
+	tx.SetHash(txHash)
+	tx.SetID(txHash)
+
+	return tx, nil
+}
+
+// signedHeader extrai os dados assinados de um bloco
+func (d *DoubleSignDetector) signedHeader(ctx context.Context, block *entities.Block) (*entities.SignedHeader, error) {
+	if block == nil {
+		return nil, errors.New("block is nil")
+	}
+
+	if block.GetSignature().IsEmpty() {
+		return nil, errors.New("block has no signature")
+	}
+
+	payload, err := d.blockBuilder.SigningPayload(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewSignedHeader(payload, block.GetSignature()), nil
+}
+
+// canonicalHeader retorna o cabeçalho assinado do bloco canônico se ele for do mesmo validador
+func (d *DoubleSignDetector) canonicalHeader(ctx context.Context, height uint64, validator valueobjects.NodeID) *entities.SignedHeader {
+	block, err := d.chainManager.GetBlockByIndex(ctx, height)
+	if err != nil || !block.GetValidator().Equals(validator) {
+		return nil
+	}
+
+	header, err := d.signedHeader(ctx, block)
+	if err != nil {
+		return nil
+	}
+
+	return header
+}
+
+// verifyEvidenceHeader confere altura e validador declarados no cabeçalho e verifica sua assinatura
+func (d *DoubleSignDetector) verifyEvidenceHeader(ctx context.Context, validator valueobjects.NodeID, height uint64, header *entities.SignedHeader) (*signedHeaderFields, error) {
+	var fields signedHeaderFields
+	if err := json.Unmarshal(header.GetPayload(), &fields); err != nil {
+		return nil, fmt.Errorf("invalid signed header: %w", err)
+	}
+
+	if fields.Index != height || fields.Validator != validator.String() {
+		return nil, errors.New("signed header does not match evidence height and validator")
+	}
+
+	if err := d.verifyHeader(ctx, validator, height, header); err != nil {
+		return nil, err
+	}
+
+	return &fields, nil
+}
+
+// headerFault retorna a falha estrutural de um cabeçalho assinado, ou vazio se ele for consistente.
+// Só considera regras verificáveis sem os dados das transações.
+func headerFault(fields *signedHeaderFields) string {
+	if len(fields.Transactions) == 0 {
+		return "no transactions"
+	}
+
+	hashes := make([]valueobjects.Hash, 0, len(fields.Transactions))
+	seen := make(map[string]bool)
+
+	for _, tx := range fields.Transactions {
+		hash, err := valueobjects.NewHashFromString(tx.Hash)
+		if err != nil || hash.IsEmpty() {
+			return "invalid transaction hash"
+		}
+
+		if seen[hash.String()] {
+			return "duplicate transaction"
+		}
+		seen[hash.String()] = true
+
+		hashes = append(hashes, hash)
+	}
+
+	merkleRoot, err := blockchain.MerkleRootFromHashes(hashes)
+	if err != nil || merkleRoot.String() != fields.MerkleRoot {
+		return "merkle root mismatch"
+	}
+
+	return ""
+}
+
+// verifyHeader verifica a assinatura do validador sobre um cabeçalho
+func (d *DoubleSignDetector) verifyHeader(ctx context.Context, validator valueobjects.NodeID, height uint64, header *entities.SignedHeader) error {
+	publicKey, err := d.validatorManager.GetValidatorPublicKeyAtHeight(ctx, validator, height)
+	if err != nil {
+		return fmt.Errorf("validator %s not authorized at height %d: %w", validator.ShortString(), height, err)
+	}
+
+	valid, err := d.cryptoService.Verify(ctx, header.GetPayload(), header.GetSignature(), publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to verify header signature: %w", err)
+	}
+
+	if !valid {
+		return errors.New("invalid header signature")
+	}
+
+	return nil
+}
+
+// prune descarta cabeçalhos fora da janela de alturas recentes (deve ser chamado com lock)
+func (d *DoubleSignDetector) prune(latest uint64) {
+	if latest <= d.window {
+		return
+	}
+
+	for height := range d.headers {
+		if height+d.window < latest {
+			delete(d.headers, height)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+)
+
+// proposeBlock cria, sem adicionar à cadeia, um bloco do validador i sobre o topo atual
+func (c *testChain) proposeBlock(t *testing.T, i int) *entities.Block {
+	t.Helper()
+
+	block, err := c.chainManager.ProposeBlock(context.Background(), []*entities.Transaction{c.newTransaction(t, i)},
+		c.nodeIDs[i], c.keyPairs[i].PrivateKey)
+	if err != nil {
+		t.Fatalf("propose block: %v", err)
+	}
+	return block
+}
+
+func TestDoubleSignDetectsConflictingBlocks(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 2)
+	chain.sealGenesis(t)
+	detector := NewDoubleSignDetector(chain.validatorManager, chain.chainManager, chain.cryptoService)
+
+	first := chain.proposeBlock(t, 0)
+	second := chain.proposeBlock(t, 0)
+
+	for _, block := range []*entities.Block{first, first, chain.proposeBlock(t, 1)} {
+		if evidence, err := detector.Observe(ctx, block); err != nil || evidence != nil {
+			t.Fatalf("Observe = %v, %v; want no evidence", evidence, err)
+		}
+	}
+
+	evidence, err := detector.Observe(ctx, second)
+	if err != nil || evidence == nil {
+		t.Fatalf("Observe(conflicting) = %v, %v; want evidence", evidence, err)
+	}
+	if !evidence.GetValidator().Equals(chain.nodeIDs[0]) || evidence.GetHeight() != 1 {
+		t.Errorf("evidence against %s at %d, want validator 0 at 1", evidence.GetValidator().ShortString(), evidence.GetHeight())
+	}
+	if err := detector.VerifyEvidence(ctx, evidence); err != nil {
+		t.Errorf("VerifyEvidence: %v", err)
+	}
+
+	// Cada conflito é reportado uma única vez
+	if evidence, err := detector.Observe(ctx, second); err != nil || evidence != nil {
+		t.Errorf("Observe(reported) = %v, %v; want no evidence", evidence, err)
+	}
+}
+
+func TestDoubleSignComparesAgainstCanonicalBlock(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 1)
+	chain.sealGenesis(t)
+	detector := NewDoubleSignDetector(chain.validatorManager, chain.chainManager, chain.cryptoService)
+
+	conflicting := chain.proposeBlock(t, 0)
+	chain.appendBlock(t, 0)
+
+	if evidence, err := detector.Observe(ctx, conflicting); err != nil || evidence == nil {
+		t.Fatalf("Observe = %v, %v; want evidence against the canonical block", evidence, err)
+	}
+}
+
+func TestDoubleSignRejectsForgedEvidence(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 2)
+	chain.sealGenesis(t)
+	detector := NewDoubleSignDetector(chain.validatorManager, chain.chainManager, chain.cryptoService)
+
+	first := chain.proposeBlock(t, 0)
+	second := chain.proposeBlock(t, 0)
+	header, err := detector.signedHeader(ctx, first)
+	if err != nil {
+		t.Fatalf("signedHeader: %v", err)
+	}
+	other, err := detector.signedHeader(ctx, second)
+	if err != nil {
+		t.Fatalf("signedHeader: %v", err)
+	}
+
+	// Cabeçalhos idênticos não provam nada
+	if err := detector.VerifyEvidence(ctx, entities.NewDoubleSignEvidence(chain.nodeIDs[0], 1, header, header)); err == nil {
+		t.Errorf("evidence with identical headers accepted")
+	}
+	// Cabeçalhos atribuídos a outro validador
+	if err := detector.VerifyEvidence(ctx, entities.NewDoubleSignEvidence(chain.nodeIDs[1], 1, header, other)); err == nil {
+		t.Errorf("evidence against another validator accepted")
+	}
+	// Assinatura que não corresponde ao cabeçalho
+	forged := entities.NewSignedHeader(other.GetPayload(), header.GetSignature())
+	if err := detector.VerifyEvidence(ctx, entities.NewDoubleSignEvidence(chain.nodeIDs[0], 1, header, forged)); err == nil {
+		t.Errorf("evidence with a forged signature accepted")
+	}
+	// Altura diferente da assinada
+	if err := detector.VerifyEvidence(ctx, entities.NewDoubleSignEvidence(chain.nodeIDs[0], 2, header, other)); err == nil {
+		t.Errorf("evidence at another height accepted")
+	}
+}
+
+func TestDoubleSignAcceptsWellFormedProposals(t *testing.T) {
+	chain := newTestChain(t, 1)
+	chain.sealGenesis(t)
+	detector := NewDoubleSignDetector(chain.validatorManager, chain.chainManager, chain.cryptoService)
+
+	if evidence, err := detector.CheckProposal(context.Background(), chain.proposeBlock(t, 0)); err != nil || evidence != nil {
+		t.Errorf("CheckProposal = %v, %v; want no evidence", evidence, err)
+	}
+}
@@ -0,0 +1,248 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+)
+
+// FinalityTracker coleta atestações de validadores e finaliza blocos com mais de 2/3 dos votos
+type FinalityTracker struct {
+	validatorManager *ValidatorManager
+	chainManager     *blockchain.ChainManager
+	cryptoService    services.CryptographyService
+
+	// hash do bloco -> validador -> atestação
+	attestations map[string]map[string]*entities.Attestation
+	// hash do bloco -> altura
+	heights map[string]uint64
+	// altura -> validador -> hash atestado (detecta atestações conflitantes)
+	votesByHeight map[uint64]map[string]string
+
+	// Callback chamado quando um novo bloco se torna final
+	onFinalized func(height uint64, hash valueobjects.Hash)
+
+	mu sync.Mutex
+}
+
+// NewFinalityTracker cria um novo rastreador de finalidade
+func NewFinalityTracker(
+	validatorManager *ValidatorManager,
+	chainManager *blockchain.ChainManager,
+	cryptoService services.CryptographyService,
+) *FinalityTracker {
+	return &FinalityTracker{
+		validatorManager: validatorManager,
+		chainManager:     chainManager,
+		cryptoService:    cryptoService,
+		attestations:     make(map[string]map[string]*entities.Attestation),
+		heights:          make(map[string]uint64),
+		votesByHeight:    make(map[uint64]map[string]string),
+	}
+}
+
+// NewSignedAttestation cria e assina uma atestação para o bloco informado
+func NewSignedAttestation(
+	ctx context.Context,
+	cryptoService services.CryptographyService,
+	blockHash valueobjects.Hash,
+	height uint64,
+	validator valueobjects.NodeID,
+	privateKey *services.PrivateKey,
+) (*entities.Attestation, error) {
+	if privateKey == nil {
+		return nil, errors.New("private key is required to sign attestation")
+	}
+
+	attestation := entities.NewAttestation(blockHash, height, validator)
+
+	signature, err := cryptoService.Sign(ctx, attestation.SigningBytes(), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign attestation: %w", err)
+	}
+	attestation.SetSignature(signature)
+
+	return attestation, nil
+}
+
+// SetOnFinalized define callback para blocos finalizados
+func (ft *FinalityTracker) SetOnFinalized(callback func(height uint64, hash valueobjects.Hash)) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+	ft.onFinalized = callback
+}
+
+// AddAttestation verifica e registra uma atestação. Retorna true se a atestação é nova.
+// Atestações para blocos ainda desconhecidos são mantidas até o bloco chegar.
+func (ft *FinalityTracker) AddAttestation(ctx context.Context, attestation *entities.Attestation) (bool, error) {
+	if attestation == nil || !attestation.IsValid() {
+		return false, errors.New("invalid attestation")
+	}
+
+	// Atestações abaixo da altura final não alteram mais nada
+	if finalizedHeight, ok := ft.chainManager.GetFinalizedHeight(ctx); ok && attestation.GetHeight() <= finalizedHeight {
+		return false, nil
+	}
+
+	publicKey, err := ft.validatorManager.GetValidatorPublicKeyAtHeight(ctx, attestation.GetValidator(), attestation.GetHeight())
+	if err != nil {
+		return false, fmt.Errorf("validator %s not authorized at height %d: %w",
+			attestation.GetValidator().ShortString(), attestation.GetHeight(), err)
+	}
+
+	valid, err := ft.cryptoService.Verify(ctx, attestation.SigningBytes(), attestation.GetSignature(), publicKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify attestation signature: %w", err)
+	}
+	if !valid {
+		return false, errors.New("invalid attestation signature")
+	}
+
+	hashStr := attestation.GetBlockHash().String()
+	validatorStr := attestation.GetValidator().String()
+
+	ft.mu.Lock()
+
+	// Um validador só pode atestar um bloco por altura
+	votes, exists := ft.votesByHeight[attestation.GetHeight()]
+	if !exists {
+		votes = make(map[string]string)
+		ft.votesByHeight[attestation.GetHeight()] = votes
+	}
+	if previous, voted := votes[validatorStr]; voted {
+		ft.mu.Unlock()
+		if previous != hashStr {
+			return false, fmt.Errorf("validator %s attested conflicting blocks at height %d",
+				attestation.GetValidator().ShortString(), attestation.GetHeight())
+		}
+		return false, nil // Duplicata
+	}
+	votes[validatorStr] = hashStr
+
+	byValidator, exists := ft.attestations[hashStr]
+	if !exists {
+		byValidator = make(map[string]*entities.Attestation)
+		ft.attestations[hashStr] = byValidator
+	}
+	byValidator[validatorStr] = attestation
+	ft.heights[hashStr] = attestation.GetHeight()
+
+	ft.mu.Unlock()
+
+	ft.tryFinalize(ctx, attestation.GetBlockHash(), attestation.GetHeight())
+
+	return true, nil
+}
+
+// Reevaluate tenta finalizar blocos cujas atestações chegaram antes do próprio bloco
+func (ft *FinalityTracker) Reevaluate(ctx context.Context) {
+	type candidate struct {
+		hash   valueobjects.Hash
+		height uint64
+	}
+
+	ft.mu.Lock()
+	candidates := make([]candidate, 0, len(ft.heights))
+	for hashStr, height := range ft.heights {
+		hash, err := valueobjects.NewHashFromString(hashStr)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, candidate{hash: hash, height: height})
+	}
+	ft.mu.Unlock()
+
+	// Tentar primeiro os blocos mais altos: finalizar um bloco finaliza seus ancestrais
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].height > candidates[j].height
+	})
+
+	for _, c := range candidates {
+		if ft.tryFinalize(ctx, c.hash, c.height) {
+			return
+		}
+	}
+}
+
+// GetAttestationCount retorna o número de atestações recebidas para um bloco
+func (ft *FinalityTracker) GetAttestationCount(blockHash valueobjects.Hash) int {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	return len(ft.attestations[blockHash.String()])
+}
+
+// tryFinalize marca o bloco como final se atingiu o quórum e está na cadeia canônica
+func (ft *FinalityTracker) tryFinalize(ctx context.Context, blockHash valueobjects.Hash, height uint64) bool {
+	if !ft.hasQuorum(ctx, blockHash) {
+		return false
+	}
+
+	if finalizedHeight, ok := ft.chainManager.GetFinalizedHeight(ctx); ok && height <= finalizedHeight {
+		return false
+	}
+
+	// Bloco ainda desconhecido ou fora da cadeia canônica: aguardar
+	if err := ft.chainManager.MarkFinalized(ctx, height, blockHash); err != nil {
+		return false
+	}
+
+	ft.pruneBelow(height)
+
+	ft.mu.Lock()
+	callback := ft.onFinalized
+	ft.mu.Unlock()
+
+	if callback != nil {
+		callback(height, blockHash)
+	}
+
+	return true
+}
+
+// hasQuorum verifica se o bloco possui atestações de mais de 2/3 dos validadores ativos
+func (ft *FinalityTracker) hasQuorum(ctx context.Context, blockHash valueobjects.Hash) bool {
+	activeValidators, err := ft.validatorManager.GetActiveValidators(ctx)
+	if err != nil || len(activeValidators) == 0 {
+		return false
+	}
+
+	ft.mu.Lock()
+	attestations := ft.attestations[blockHash.String()]
+	count := 0
+	for _, validator := range activeValidators {
+		// Apenas validadores ativos contam para o quórum
+		if _, attested := attestations[validator.NodeID.String()]; attested {
+			count++
+		}
+	}
+	ft.mu.Unlock()
+
+	return count*3 > len(activeValidators)*2
+}
+
+// pruneBelow descarta atestações de alturas já finalizadas
+func (ft *FinalityTracker) pruneBelow(height uint64) {
+	ft.mu.Lock()
+	defer ft.mu.Unlock()
+
+	for hashStr, blockHeight := range ft.heights {
+		if blockHeight <= height {
+			delete(ft.heights, hashStr)
+			delete(ft.attestations, hashStr)
+		}
+	}
+
+	for blockHeight := range ft.votesByHeight {
+		if blockHeight <= height {
+			delete(ft.votesByHeight, blockHeight)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// attest registra a atestação do validador i para o bloco
+func (c *testChain) attest(t *testing.T, tracker *FinalityTracker, i int, block *entities.Block) error {
+	t.Helper()
+	ctx := context.Background()
+
+	attestation, err := NewSignedAttestation(ctx, c.cryptoService, c.chainManager.CalculateBlockHash(ctx, block),
+		block.GetIndex(), c.nodeIDs[i], c.keyPairs[i].PrivateKey)
+	if err != nil {
+		t.Fatalf("sign attestation: %v", err)
+	}
+	_, err = tracker.AddAttestation(ctx, attestation)
+	return err
+}
+
+// finalizedHeight retorna a altura final da cadeia, ou -1 se nenhum bloco foi finalizado
+func (c *testChain) finalizedHeight() int {
+	height, ok := c.chainManager.GetFinalizedHeight(context.Background())
+	if !ok {
+		return -1
+	}
+	return int(height)
+}
+
+func TestFinalityRequiresMoreThanTwoThirds(t *testing.T) {
+	for validators, required := range map[int]int{1: 1, 3: 3, 4: 3, 6: 5, 7: 5} {
+		chain := newTestChain(t, validators)
+		chain.sealGenesis(t)
+		block := chain.appendBlock(t, 0)
+		tracker := NewFinalityTracker(chain.validatorManager, chain.chainManager, chain.cryptoService)
+
+		for i := 0; i < required; i++ {
+			if chain.finalizedHeight() == 1 {
+				t.Fatalf("%d validators: finalized with %d attestations, want %d", validators, i, required)
+			}
+			if err := chain.attest(t, tracker, i, block); err != nil {
+				t.Fatalf("%d validators: attestation %d: %v", validators, i, err)
+			}
+		}
+		if chain.finalizedHeight() != 1 {
+			t.Errorf("%d validators: not finalized with %d attestations", validators, required)
+		}
+	}
+}
+
+func TestFinalityIgnoresInactiveValidators(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 4)
+	chain.sealGenesis(t)
+	block := chain.appendBlock(t, 0)
+	tracker := NewFinalityTracker(chain.validatorManager, chain.chainManager, chain.cryptoService)
+
+	for i := 1; i < 4; i++ {
+		if err := chain.attest(t, tracker, i, block); err != nil {
+			t.Fatalf("attestation %d: %v", i, err)
+		}
+	}
+	if chain.finalizedHeight() != 1 {
+		t.Fatalf("not finalized with 3 of 4 attestations")
+	}
+
+	// Com um dos atestantes desativado, 2 de 3 ativos não bastam
+	chain = newTestChain(t, 4)
+	chain.sealGenesis(t)
+	block = chain.appendBlock(t, 0)
+	tracker = NewFinalityTracker(chain.validatorManager, chain.chainManager, chain.cryptoService)
+	for i := 1; i < 4; i++ {
+		if err := chain.attest(t, tracker, i, block); err != nil {
+			t.Fatalf("attestation %d: %v", i, err)
+		}
+		if i == 1 {
+			if err := chain.validatorManager.SetValidatorStatus(ctx, chain.nodeIDs[1], ValidatorInactive); err != nil {
+				t.Fatalf("deactivate validator: %v", err)
+			}
+		}
+	}
+	if chain.finalizedHeight() != -1 {
+		t.Errorf("finalized with an inactive validator's attestation counted")
+	}
+}
+
+func TestFinalityRejectsConflictingAttestations(t *testing.T) {
+	chain := newTestChain(t, 4)
+	chain.sealGenesis(t)
+	block := chain.appendBlock(t, 0)
+	tracker := NewFinalityTracker(chain.validatorManager, chain.chainManager, chain.cryptoService)
+
+	if err := chain.attest(t, tracker, 1, block); err != nil {
+		t.Fatalf("attestation: %v", err)
+	}
+	// Repetir a mesma atestação não é erro nem conta duas vezes
+	if err := chain.attest(t, tracker, 1, block); err != nil {
+		t.Fatalf("duplicate attestation: %v", err)
+	}
+	if got := tracker.GetAttestationCount(chain.chainManager.CalculateBlockHash(context.Background(), block)); got != 1 {
+		t.Errorf("attestation count = %d, want 1", got)
+	}
+
+	conflicting, err := NewSignedAttestation(context.Background(), chain.cryptoService, valueobjects.NewHash([]byte("other block")),
+		block.GetIndex(), chain.nodeIDs[1], chain.keyPairs[1].PrivateKey)
+	if err != nil {
+		t.Fatalf("sign attestation: %v", err)
+	}
+	if _, err := tracker.AddAttestation(context.Background(), conflicting); err == nil {
+		t.Errorf("conflicting attestation at the same height accepted")
+	}
+}
+
+func TestFinalityWaitsForUnknownBlock(t *testing.T) {
+	chain := newTestChain(t, 1)
+	chain.sealGenesis(t)
+	tracker := NewFinalityTracker(chain.validatorManager, chain.chainManager, chain.cryptoService)
+
+	// Bloco produzido em outra cadeia com o mesmo gênesis ainda não chegou a esta
+	block := chain.proposeBlock(t, 0)
+	if err := chain.attest(t, tracker, 0, block); err != nil {
+		t.Fatalf("attestation: %v", err)
+	}
+	if chain.finalizedHeight() == 1 {
+		t.Fatalf("unknown block finalized")
+	}
+
+	if err := chain.chainManager.AddBlock(context.Background(), block); err != nil {
+		t.Fatalf("add block: %v", err)
+	}
+	tracker.Reevaluate(context.Background())
+	if chain.finalizedHeight() != 1 {
+		t.Errorf("block not finalized after arriving")
+	}
+}
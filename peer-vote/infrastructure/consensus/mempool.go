@@ -0,0 +1,475 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+)
+
+// Erros retornados pelo mempool
+var (
+	ErrTxAlreadyKnown    = errors.New("transaction already in pool")
+	ErrTxAlreadyIncluded = errors.New("transaction already included in the chain")
+	ErrTxExpired         = errors.New("transaction expired")
+	ErrTxTooLarge        = errors.New("transaction exceeds pool size limit")
+	ErrMempoolFull       = errors.New("transaction pool is full")
+	ErrSenderLimit       = errors.New("sender has too many pending transactions")
+)
+
+// mempoolTxOverhead estima os bytes de cabeçalho (IDs, hash, assinatura) além dos dados
+const mempoolTxOverhead = 256
+
+// maxRejectedHistory limita quantas rejeições recentes ficam disponíveis para consulta
+const maxRejectedHistory = 10000
+
+// MempoolConfig contém os limites do pool de transações pendentes
+type MempoolConfig struct {
+	MaxTransactions int           // Número máximo de transações no pool
+	MaxBytes        int           // Tamanho máximo somado das transações
+	MaxPerSender    int           // Transações pendentes por remetente
+	TTL             time.Duration // Tempo máximo de permanência no pool
+}
+
+// DefaultMempoolConfig retorna a configuração padrão do mempool
+func DefaultMempoolConfig() *MempoolConfig {
+	return &MempoolConfig{
+		MaxTransactions: 10000,
+		MaxBytes:        32 * 1024 * 1024,
+		MaxPerSender:    256,
+		TTL:             30 * time.Minute,
+	}
+}
+
+// TxListener é notificado quando uma transação é aceita no pool
+type TxListener func(tx *entities.Transaction)
+
+// MempoolEntry descreve uma transação pendente
+type MempoolEntry struct {
+	Transaction *entities.Transaction
+	Size        int
+	Priority    int
+	AddedAt     time.Time
+	ExpiresAt   time.Time
+	seq         uint64
+}
+
+// MempoolStats resume a ocupação do mempool
+type MempoolStats struct {
+	Count           int
+	Bytes           int
+	MaxTransactions int
+	MaxBytes        int
+	MaxPerSender    int
+	TTL             time.Duration
+	Senders         int
+	ByType          map[entities.TransactionType]int
+}
+
+// Mempool é o pool de transações pendentes compartilhado pela submissão local e pelo gossip.
+// Deduplica por hash, limita quantidade, bytes e transações por remetente, expira transações
+// antigas, remove as incluídas em blocos canônicos e reinjeta as desfeitas por reorganizações.
+// Transações já incluídas na cadeia canônica são rejeitadas (proteção contra replay) consultando
+// o índice de transações do ChainManager, que acompanha a cadeia canônica inclusive em reorganizações.
+type Mempool struct {
+	chainManager *blockchain.ChainManager
+	config       *MempoolConfig
+
+	entries    map[string]*MempoolEntry
+	bySender   map[string]int
+	totalBytes int
+	seq        uint64
+
+	// Rejeições, descartes e expirações recentes (hash -> motivo), em ordem de ocorrência
+	rejected      map[string]string
+	rejectedOrder []string
+
+	// Notificados a cada transação aceita no pool
+	txListeners []TxListener
+
+	// Mutex para operações thread-safe
+	mu sync.Mutex
+}
+
+// NewMempool cria o mempool e o registra na cadeia
+func NewMempool(chainManager *blockchain.ChainManager, config *MempoolConfig) *Mempool {
+	if config == nil {
+		config = DefaultMempoolConfig()
+	}
+
+	mempool := &Mempool{
+		chainManager: chainManager,
+		config:       config,
+		entries:      make(map[string]*MempoolEntry),
+		bySender:     make(map[string]int),
+		rejected:     make(map[string]string),
+	}
+
+	chainManager.AddBlockListener(mempool.handleBlock)
+	chainManager.AddReorgListener(mempool.handleReorg)
+
+	return mempool
+}
+
+// SetConfig altera os limites do mempool (valores não positivos mantêm o atual)
+func (m *Mempool) SetConfig(config *MempoolConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	updated := *m.config
+	if config.MaxTransactions > 0 {
+		updated.MaxTransactions = config.MaxTransactions
+	}
+	if config.MaxBytes > 0 {
+		updated.MaxBytes = config.MaxBytes
+	}
+	if config.MaxPerSender > 0 {
+		updated.MaxPerSender = config.MaxPerSender
+	}
+	if config.TTL > 0 {
+		updated.TTL = config.TTL
+	}
+	m.config = &updated
+}
+
+// AddTxListener registra um subsistema a ser notificado a cada transação aceita no pool
+func (m *Mempool) AddTxListener(listener TxListener) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.txListeners = append(m.txListeners, listener)
+}
+
+// Rebuild descarta as transações pendentes que já constam na cadeia canônica, após a cadeia
+// ser carregada ou restaurada de um snapshot. Em uma cadeia iniciada por snapshot, apenas os
+// blocos a partir da base estão indexados; transações anteriores são rejeitadas pelo TTL.
+func (m *Mempool) Rebuild(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, entry := range m.entries {
+		if _, included := m.chainManager.GetTransactionLocation(ctx, entry.Transaction.GetHash()); included {
+			m.remove(key)
+		}
+	}
+
+	return nil
+}
+
+// Add adiciona uma transação ao pool
+func (m *Mempool) Add(tx *entities.Transaction) error {
+	if tx == nil {
+		return errors.New("transaction is nil")
+	}
+
+	m.mu.Lock()
+
+	// Transações mais antigas que o TTL não voltam ao pool por gossip
+	if time.Since(tx.GetTimestamp().Time()) > m.config.TTL {
+		m.reject(tx.GetHash().String(), ErrTxExpired.Error())
+		m.mu.Unlock()
+		return ErrTxExpired
+	}
+
+	err := m.add(tx, time.Now())
+	switch err {
+	case nil, ErrTxAlreadyKnown, ErrTxAlreadyIncluded:
+	default:
+		m.reject(tx.GetHash().String(), err.Error())
+	}
+
+	listeners := m.txListeners
+	m.mu.Unlock()
+
+	// Notificar fora do lock
+	if err == nil {
+		for _, listener := range listeners {
+			listener(tx)
+		}
+	}
+
+	return err
+}
+
+// add valida os limites e insere a transação (deve ser chamado com lock)
+func (m *Mempool) add(tx *entities.Transaction, now time.Time) error {
+	key := tx.GetHash().String()
+	if _, exists := m.entries[key]; exists {
+		return ErrTxAlreadyKnown
+	}
+	if _, included := m.chainManager.GetTransactionLocation(context.Background(), tx.GetHash()); included {
+		return ErrTxAlreadyIncluded
+	}
+
+	m.pruneExpired(now)
+
+	sender := tx.GetFrom().String()
+	if m.bySender[sender] >= m.config.MaxPerSender {
+		return ErrSenderLimit
+	}
+
+	entry := &MempoolEntry{
+		Transaction: tx,
+		Size:        len(tx.GetData()) + mempoolTxOverhead,
+		Priority:    transactionPriority(tx.GetType()),
+		AddedAt:     now,
+		ExpiresAt:   now.Add(m.config.TTL),
+	}
+	if entry.Size > m.config.MaxBytes {
+		return ErrTxTooLarge
+	}
+
+	// Pool cheio: descartar transações de menor prioridade, se houver
+	for len(m.entries) >= m.config.MaxTransactions || m.totalBytes+entry.Size > m.config.MaxBytes {
+		victim := m.lowestPriority()
+		if victim == nil || victim.Priority >= entry.Priority {
+			return ErrMempoolFull
+		}
+		victimKey := victim.Transaction.GetHash().String()
+		m.remove(victimKey)
+		m.reject(victimKey, "evicted by a higher priority transaction (pool full)")
+	}
+
+	m.seq++
+	entry.seq = m.seq
+	m.entries[key] = entry
+	m.bySender[sender]++
+	m.totalBytes += entry.Size
+
+	return nil
+}
+
+// Select retorna até maxCount transações por ordem de prioridade e chegada, sem removê-las
+func (m *Mempool) Select(maxCount int) []*entities.Transaction {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pruneExpired(time.Now())
+
+	entries := m.sorted()
+	if maxCount > 0 && len(entries) > maxCount {
+		entries = entries[:maxCount]
+	}
+
+	transactions := make([]*entities.Transaction, len(entries))
+	for i, entry := range entries {
+		transactions[i] = entry.Transaction
+	}
+
+	return transactions
+}
+
+// Entries retorna as transações pendentes por ordem de prioridade e chegada
+func (m *Mempool) Entries() []MempoolEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pruneExpired(time.Now())
+
+	sorted := m.sorted()
+	entries := make([]MempoolEntry, len(sorted))
+	for i, entry := range sorted {
+		entries[i] = *entry
+	}
+
+	return entries
+}
+
+// Contains verifica se a transação está pendente no pool
+func (m *Mempool) Contains(hash valueobjects.Hash) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, exists := m.entries[hash.String()]
+	return exists
+}
+
+// Get retorna a entrada de uma transação pendente
+func (m *Mempool) Get(hash valueobjects.Hash) (MempoolEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, exists := m.entries[hash.String()]
+	if !exists {
+		return MempoolEntry{}, false
+	}
+	return *entry, true
+}
+
+// GetRejection retorna o motivo pelo qual a transação foi rejeitada, descartada ou expirou
+func (m *Mempool) GetRejection(hash valueobjects.Hash) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pruneExpired(time.Now())
+
+	reason, exists := m.rejected[hash.String()]
+	return reason, exists
+}
+
+// Size retorna o número de transações pendentes
+func (m *Mempool) Size() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pruneExpired(time.Now())
+	return len(m.entries)
+}
+
+// Stats retorna a ocupação atual do pool
+func (m *Mempool) Stats() MempoolStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pruneExpired(time.Now())
+
+	byType := make(map[entities.TransactionType]int)
+	for _, entry := range m.entries {
+		byType[entry.Transaction.GetType()]++
+	}
+
+	return MempoolStats{
+		Count:           len(m.entries),
+		Bytes:           m.totalBytes,
+		MaxTransactions: m.config.MaxTransactions,
+		MaxBytes:        m.config.MaxBytes,
+		MaxPerSender:    m.config.MaxPerSender,
+		TTL:             m.config.TTL,
+		Senders:         len(m.bySender),
+		ByType:          byType,
+	}
+}
+
+// Remove descarta transações do pool
+func (m *Mempool) Remove(hashes ...valueobjects.Hash) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, hash := range hashes {
+		m.remove(hash.String())
+	}
+}
+
+// Clear esvazia o pool de transações pendentes
+func (m *Mempool) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[string]*MempoolEntry)
+	m.bySender = make(map[string]int)
+	m.totalBytes = 0
+}
+
+// handleBlock remove do pool as transações incluídas em um bloco canônico
+func (m *Mempool) handleBlock(ctx context.Context, block *entities.Block) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, tx := range block.GetTransactions() {
+		m.remove(tx.GetHash().String())
+	}
+}
+
+// handleReorg devolve ao pool as transações de blocos desfeitos. As que a nova ramificação
+// também incluiu já constam no índice da cadeia e são recusadas por add.
+func (m *Mempool) handleReorg(ctx context.Context, event *blockchain.ReorgEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, block := range event.Removed {
+		for _, tx := range block.GetTransactions() {
+			// Pool cheio ou limite do remetente não são erros críticos
+			_ = m.add(tx, now)
+		}
+	}
+}
+
+// remove descarta uma transação pelo hash (deve ser chamado com lock)
+func (m *Mempool) remove(key string) {
+	entry, exists := m.entries[key]
+	if !exists {
+		return
+	}
+
+	sender := entry.Transaction.GetFrom().String()
+	m.bySender[sender]--
+	if m.bySender[sender] <= 0 {
+		delete(m.bySender, sender)
+	}
+	m.totalBytes -= entry.Size
+	delete(m.entries, key)
+}
+
+// pruneExpired descarta transações com TTL vencido (deve ser chamado com lock)
+func (m *Mempool) pruneExpired(now time.Time) {
+	for key, entry := range m.entries {
+		if now.After(entry.ExpiresAt) {
+			m.remove(key)
+			m.reject(key, "expired after pool TTL without being included")
+		}
+	}
+}
+
+// reject registra o motivo da rejeição, mantendo apenas as mais recentes (deve ser chamado com lock)
+func (m *Mempool) reject(key, reason string) {
+	if _, exists := m.rejected[key]; !exists {
+		m.rejectedOrder = append(m.rejectedOrder, key)
+	}
+	m.rejected[key] = reason
+
+	for len(m.rejectedOrder) > maxRejectedHistory {
+		delete(m.rejected, m.rejectedOrder[0])
+		m.rejectedOrder = m.rejectedOrder[1:]
+	}
+}
+
+// lowestPriority retorna a transação a ser descartada primeiro: menor prioridade,
+// e entre as de mesma prioridade a mais recente (deve ser chamado com lock)
+func (m *Mempool) lowestPriority() *MempoolEntry {
+	var victim *MempoolEntry
+	for _, entry := range m.entries {
+		if victim == nil || entry.Priority < victim.Priority ||
+			(entry.Priority == victim.Priority && entry.seq > victim.seq) {
+			victim = entry
+		}
+	}
+	return victim
+}
+
+// sorted retorna as transações por prioridade decrescente e ordem de chegada (deve ser chamado com lock)
+func (m *Mempool) sorted() []*MempoolEntry {
+	entries := make([]*MempoolEntry, 0, len(m.entries))
+	for _, entry := range m.entries {
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Priority != entries[j].Priority {
+			return entries[i].Priority > entries[j].Priority
+		}
+		return entries[i].seq < entries[j].seq
+	})
+
+	return entries
+}
+
+// transactionPriority define a prioridade de inclusão por tipo: evidências e alterações de
+// validadores afetam o consenso; eleições precedem os votos que as referenciam
+func transactionPriority(txType entities.TransactionType) int {
+	switch txType {
+	case entities.EvidenceTransaction:
+		return 3
+	case entities.ValidatorTransaction:
+		return 2
+	case entities.ElectionTransaction:
+		return 1
+	default:
+		return 0
+	}
+}
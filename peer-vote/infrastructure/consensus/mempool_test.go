@@ -0,0 +1,170 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+)
+
+// poolTransaction cria uma transação do validador i com o tipo e os dados informados
+func (c *testChain) poolTransaction(i int, txType entities.TransactionType, data string) *entities.Transaction {
+	tx := entities.NewTransaction(txType, c.nodeIDs[i], c.nodeIDs[i], []byte(data))
+	tx.SetHash(c.cryptoService.HashTransaction(context.Background(), []byte(data)))
+	return tx
+}
+
+func TestMempoolDeduplicatesPendingAndIncludedTransactions(t *testing.T) {
+	chain := newTestChain(t, 1)
+	mempool := NewMempool(chain.chainManager, nil)
+	chain.sealGenesis(t)
+
+	tx := chain.newTransaction(t, 0)
+	if err := mempool.Add(tx); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := mempool.Add(tx); !errors.Is(err, ErrTxAlreadyKnown) {
+		t.Errorf("Add(pending) error = %v, want ErrTxAlreadyKnown", err)
+	}
+
+	// Ao entrar na cadeia a transação sai do pool e não pode ser reenviada
+	chain.appendTransactions(t, tx)
+	if mempool.Contains(tx.GetHash()) {
+		t.Errorf("included transaction still pending")
+	}
+	if err := mempool.Add(tx); !errors.Is(err, ErrTxAlreadyIncluded) {
+		t.Errorf("Add(included) error = %v, want ErrTxAlreadyIncluded", err)
+	}
+}
+
+func TestMempoolEnforcesLimits(t *testing.T) {
+	chain := newTestChain(t, 2)
+	mempool := NewMempool(chain.chainManager, &MempoolConfig{MaxTransactions: 3, MaxBytes: 4096, MaxPerSender: 2, TTL: time.Minute})
+
+	if err := mempool.Add(chain.poolTransaction(0, entities.VoteTransaction, "a")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := mempool.Add(chain.poolTransaction(0, entities.VoteTransaction, "b")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := mempool.Add(chain.poolTransaction(0, entities.VoteTransaction, "c")); !errors.Is(err, ErrSenderLimit) {
+		t.Errorf("Add(third from sender) error = %v, want ErrSenderLimit", err)
+	}
+
+	if err := mempool.Add(chain.poolTransaction(1, entities.VoteTransaction, "d")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	// Pool cheio: mesma prioridade não desaloja ninguém
+	if err := mempool.Add(chain.poolTransaction(1, entities.VoteTransaction, "e")); !errors.Is(err, ErrMempoolFull) {
+		t.Errorf("Add(full) error = %v, want ErrMempoolFull", err)
+	}
+
+	// Prioridade maior desaloja o voto mais recente
+	evidence := chain.poolTransaction(1, entities.EvidenceTransaction, "f")
+	if err := mempool.Add(evidence); err != nil {
+		t.Fatalf("Add(evidence): %v", err)
+	}
+	evicted := chain.poolTransaction(1, entities.VoteTransaction, "d").GetHash()
+	if mempool.Contains(evicted) {
+		t.Errorf("most recent low priority transaction not evicted")
+	}
+	if _, rejected := mempool.GetRejection(evicted); !rejected {
+		t.Errorf("eviction not recorded")
+	}
+	if selected := mempool.Select(1); len(selected) != 1 || !selected[0].GetHash().Equals(evidence.GetHash()) {
+		t.Errorf("Select(1) did not return the evidence first")
+	}
+
+	large := chain.poolTransaction(1, entities.VoteTransaction, string(make([]byte, 4096)))
+	if err := mempool.Add(large); !errors.Is(err, ErrTxTooLarge) {
+		t.Errorf("Add(large) error = %v, want ErrTxTooLarge", err)
+	}
+}
+
+func TestMempoolExpiresTransactions(t *testing.T) {
+	chain := newTestChain(t, 1)
+	mempool := NewMempool(chain.chainManager, &MempoolConfig{MaxTransactions: 10, MaxBytes: 4096, MaxPerSender: 10, TTL: time.Minute})
+
+	stale := chain.poolTransaction(0, entities.VoteTransaction, "stale")
+	stale.SetTimestamp(valueobjects.NewTimestamp(time.Now().Add(-2 * time.Minute)))
+	if err := mempool.Add(stale); !errors.Is(err, ErrTxExpired) {
+		t.Errorf("Add(stale) error = %v, want ErrTxExpired", err)
+	}
+
+	tx := chain.poolTransaction(0, entities.VoteTransaction, "fresh")
+	if err := mempool.Add(tx); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	mempool.mu.Lock()
+	mempool.pruneExpired(time.Now().Add(2 * time.Minute))
+	mempool.mu.Unlock()
+
+	if mempool.Contains(tx.GetHash()) {
+		t.Errorf("transaction kept after its TTL")
+	}
+	if reason, rejected := mempool.GetRejection(tx.GetHash()); !rejected || reason == "" {
+		t.Errorf("expiration not recorded")
+	}
+	if stats := mempool.Stats(); stats.Count != 0 || stats.Bytes != 0 || stats.Senders != 0 {
+		t.Errorf("stats after expiration = %+v, want an empty pool", stats)
+	}
+}
+
+// forkBlock cria, sem adicioná-lo, um bloco do validador 0 sobre parent
+func (c *testChain) forkBlock(t *testing.T, parent *entities.Block, txs ...*entities.Transaction) *entities.Block {
+	t.Helper()
+	ctx := context.Background()
+
+	builder := blockchain.NewBlockBuilder(c.cryptoService)
+	block, err := builder.BuildBlock(ctx, parent.GetIndex()+1, c.chainManager.CalculateBlockHash(ctx, parent), txs, c.nodeIDs[0])
+	if err != nil {
+		t.Fatalf("build block: %v", err)
+	}
+	if err := builder.SignBlock(ctx, block, c.keyPairs[0].PrivateKey); err != nil {
+		t.Fatalf("sign block: %v", err)
+	}
+	return block
+}
+
+func TestMempoolFollowsReorganizations(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 1)
+	mempool := NewMempool(chain.chainManager, nil)
+	chain.chainManager.SetBlockAuthority(NewBlockAuthority(chain.validatorManager, chain.cryptoService))
+	chain.sealGenesis(t)
+	genesis, err := chain.chainManager.GetBlockByIndex(ctx, 0)
+	if err != nil {
+		t.Fatalf("get genesis block: %v", err)
+	}
+
+	orphaned := chain.newTransaction(t, 0)
+	shared := chain.newTransaction(t, 0)
+	chain.appendTransactions(t, orphaned, shared)
+
+	// Ramificação mais longa que inclui apenas uma das transações
+	forkFirst := chain.forkBlock(t, genesis, shared)
+	forkSecond := chain.forkBlock(t, forkFirst, chain.newTransaction(t, 0))
+	for _, block := range []*entities.Block{forkFirst, forkSecond} {
+		if err := chain.chainManager.ProcessBlock(ctx, block); err != nil {
+			t.Fatalf("ProcessBlock(%d): %v", block.GetIndex(), err)
+		}
+	}
+	if height, _ := chain.chainManager.GetChainHeight(ctx); height != 2 {
+		t.Fatalf("height = %d, want the fork adopted", height)
+	}
+
+	if !mempool.Contains(orphaned.GetHash()) {
+		t.Errorf("transaction of the removed block not returned to the pool")
+	}
+	if mempool.Contains(shared.GetHash()) {
+		t.Errorf("transaction included by the new branch returned to the pool")
+	}
+	if err := mempool.Add(shared); !errors.Is(err, ErrTxAlreadyIncluded) {
+		t.Errorf("Add(included by the new branch) error = %v, want ErrTxAlreadyIncluded", err)
+	}
+}
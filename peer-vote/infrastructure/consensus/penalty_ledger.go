@@ -0,0 +1,338 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// PenaltyLedger deriva as penalidades de validadores exclusivamente da cadeia canônica:
+// slots perdidos visíveis na sequência de blocos (bloco assinado por outro validador que não
+// o da vez), evidências de assinatura dupla e de propostas inválidas incluídas em blocos.
+// As penalidades usam o timestamp do bloco, de forma que todos os nós chegam ao mesmo estado,
+// e são recalculadas do gênesis (ou da base do snapshot) após reorganizações.
+// No consenso BFT o autor de um bloco depende do round em que foi decidido, que a cadeia não
+// registra; nesse caso a contagem de slots fica desligada (ver SetSlotAccounting).
+type PenaltyLedger struct {
+	validatorManager *ValidatorManager
+	chainManager     *blockchain.ChainManager
+	penaltySystem    *PenaltySystem
+	detector         *DoubleSignDetector
+
+	// Evidências já aplicadas e slots perdidos consecutivos por validador
+	applied map[string]bool
+	missed  map[string]int
+
+	// Contabilizar slots perdidos pelo validador da vez (desligado no BFT)
+	slotAccounting bool
+
+	// Última altura processada
+	processedHeight uint64
+	hasProcessed    bool
+
+	// Estado herdado de um snapshot quando a cadeia começa em uma base (nil = gênesis)
+	base *penaltyLedgerBase
+
+	logger *slog.Logger
+
+	// Mutex para operações thread-safe
+	mu sync.Mutex
+}
+
+// PenaltyLedgerState é o estado do livro de penalidades após uma altura (usado por snapshots)
+type PenaltyLedgerState struct {
+	Applied []string       `json:"applied"` // Chaves das evidências já aplicadas, ordenadas
+	Missed  map[string]int `json:"missed"`  // Slots perdidos consecutivos por validador
+}
+
+// penaltyLedgerBase é o estado inicial do livro em uma cadeia iniciada por snapshot
+type penaltyLedgerBase struct {
+	height    uint64
+	state     PenaltyLedgerState
+	penalties []*PenaltyRecord
+}
+
+// NewPenaltyLedger cria o livro de penalidades e o registra na cadeia.
+// Deve ser criado depois do ValidatorGovernance para que o conjunto de validadores
+// esteja atualizado quando os blocos forem processados.
+func NewPenaltyLedger(
+	validatorManager *ValidatorManager,
+	chainManager *blockchain.ChainManager,
+	penaltySystem *PenaltySystem,
+	detector *DoubleSignDetector,
+) *PenaltyLedger {
+	ledger := &PenaltyLedger{
+		validatorManager: validatorManager,
+		chainManager:     chainManager,
+		penaltySystem:    penaltySystem,
+		detector:         detector,
+		applied:          make(map[string]bool),
+		missed:           make(map[string]int),
+		slotAccounting:   true,
+		logger:           logging.Component("penalties"),
+	}
+
+	chainManager.AddBlockListener(ledger.handleBlock)
+	chainManager.AddReorgListener(ledger.handleReorg)
+
+	return ledger
+}
+
+// SetLogger define o logger do livro de penalidades
+func (l *PenaltyLedger) SetLogger(logger *slog.Logger) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger = logger
+}
+
+// SetSlotAccounting liga ou desliga a penalização por slots perdidos. Deve ser desligada quando
+// o proposer de uma altura não é fixo (BFT: rounds seguintes trocam o proposer) e chamada antes
+// de Replay, já que todos os nós da rede precisam usar a mesma regra.
+func (l *PenaltyLedger) SetSlotAccounting(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.slotAccounting = enabled
+}
+
+// SlotAccounting informa se a penalização por slots perdidos está ligada
+func (l *PenaltyLedger) SlotAccounting() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.slotAccounting
+}
+
+// Replay recalcula todas as penalidades reprocessando a cadeia canônica desde o gênesis (ou a base)
+func (l *PenaltyLedger) Replay(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.replay(ctx)
+}
+
+// GetState retorna o estado do livro e a última altura processada (false se nenhum bloco foi processado)
+func (l *PenaltyLedger) GetState() (uint64, PenaltyLedgerState, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state := PenaltyLedgerState{
+		Applied: make([]string, 0, len(l.applied)),
+		Missed:  make(map[string]int),
+	}
+	for key := range l.applied {
+		state.Applied = append(state.Applied, key)
+	}
+	sort.Strings(state.Applied)
+	for validatorID, missed := range l.missed {
+		if missed > 0 {
+			state.Missed[validatorID] = missed
+		}
+	}
+
+	return l.processedHeight, state, l.hasProcessed
+}
+
+// SetBase define o estado herdado de um snapshot na altura base e reprocessa os blocos seguintes.
+// Deve ser chamado depois de ChainManager.InitializeFromBase e de ValidatorGovernance.SetBase.
+func (l *PenaltyLedger) SetBase(ctx context.Context, height uint64, state PenaltyLedgerState, penalties []*PenaltyRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.base = &penaltyLedgerBase{height: height, state: state, penalties: penalties}
+	return l.replay(ctx)
+}
+
+// handleBlock processa um bloco aplicado à cadeia canônica
+func (l *PenaltyLedger) handleBlock(ctx context.Context, block *entities.Block) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	index := block.GetIndex()
+
+	switch {
+	case !l.hasProcessed && index == 0, l.hasProcessed && index == l.processedHeight+1:
+		l.processBlock(ctx, block)
+	case l.hasProcessed && index <= l.processedHeight:
+		// Bloco já processado (ou parte de uma reorganização, tratada pelo listener de reorg)
+	default:
+		if err := l.replay(ctx); err != nil {
+			l.logger.ErrorContext(ctx, "failed to replay penalties", logging.Err(err))
+		}
+	}
+}
+
+// handleReorg recalcula as penalidades após uma reorganização
+func (l *PenaltyLedger) handleReorg(ctx context.Context, event *blockchain.ReorgEvent) {
+	if err := l.Replay(ctx); err != nil {
+		l.logger.ErrorContext(ctx, "failed to replay penalties after reorg", logging.Err(err))
+	}
+}
+
+// replay reprocessa a cadeia canônica (deve ser chamado com lock)
+func (l *PenaltyLedger) replay(ctx context.Context) error {
+	l.penaltySystem.Reset(ctx)
+	l.applied = make(map[string]bool)
+	l.missed = make(map[string]int)
+	l.hasProcessed = false
+	l.processedHeight = 0
+
+	start := uint64(0)
+	if l.base != nil {
+		l.penaltySystem.RestorePenalties(ctx, l.base.penalties)
+		for _, key := range l.base.state.Applied {
+			l.applied[key] = true
+		}
+		for validatorID, missed := range l.base.state.Missed {
+			l.missed[validatorID] = missed
+		}
+		l.processedHeight = l.base.height
+		l.hasProcessed = true
+		start = l.base.height + 1
+	}
+
+	height, err := l.chainManager.GetChainHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain height: %w", err)
+	}
+
+	for index := start; index <= height; index++ {
+		block, err := l.chainManager.GetBlockByIndex(ctx, index)
+		if err != nil {
+			if index == 0 {
+				return nil // Cadeia ainda vazia
+			}
+			return fmt.Errorf("failed to get block %d: %w", index, err)
+		}
+
+		l.processBlock(ctx, block)
+	}
+
+	return nil
+}
+
+// processBlock aplica as penalidades comprovadas por um bloco (deve ser chamado com lock)
+func (l *PenaltyLedger) processBlock(ctx context.Context, block *entities.Block) {
+	if block.GetIndex() > 0 && l.slotAccounting {
+		l.processSlot(ctx, block)
+	}
+
+	for _, tx := range block.GetTransactions() {
+		if tx.GetType() != entities.EvidenceTransaction {
+			continue
+		}
+
+		if err := l.processEvidence(ctx, block, tx); err != nil {
+			l.logger.WarnContext(ctx, "ignoring evidence", logging.Height(block.GetIndex()), logging.TxHash(tx.GetHash()), logging.Err(err))
+		}
+	}
+
+	l.processedHeight = block.GetIndex()
+	l.hasProcessed = true
+}
+
+// processSlot contabiliza o slot da altura: se o bloco não foi assinado pelo validador da vez,
+// ele perdeu o slot. Penaliza após maxMissedRounds slots perdidos consecutivos.
+func (l *PenaltyLedger) processSlot(ctx context.Context, block *entities.Block) {
+	height := block.GetIndex()
+	producer := block.GetValidator()
+
+	// Produzir qualquer bloco demonstra que o validador está ativo
+	l.missed[producer.String()] = 0
+
+	inTurn, err := l.validatorManager.GetInTurnValidator(ctx, height)
+	if err != nil || inTurn.Equals(producer) {
+		return
+	}
+
+	l.missed[inTurn.String()]++
+	maxMissed, _, _ := l.validatorManager.GetConfiguration()
+	if l.missed[inTurn.String()] < maxMissed {
+		return
+	}
+	l.missed[inTurn.String()] = 0
+
+	reason := fmt.Sprintf("missed %d consecutive slots up to height %d", maxMissed, height)
+	evidence := map[string]interface{}{
+		"height":      height,
+		"block_index": height,
+		"produced_by": producer.String(),
+	}
+
+	if err := l.penaltySystem.ApplyPenaltyAt(ctx, inTurn, PenaltyMissedRound, reason, evidence, block.GetTimestamp()); err != nil {
+		l.logger.ErrorContext(ctx, "failed to apply missed slot penalty", "validator", inTurn.String(), logging.Height(height), logging.Err(err))
+	}
+}
+
+// processEvidence verifica e aplica uma transação de evidência (deve ser chamado com lock)
+func (l *PenaltyLedger) processEvidence(ctx context.Context, block *entities.Block, tx *entities.Transaction) error {
+	evidenceType, err := entities.ParseEvidenceType(tx.GetData())
+	if err != nil {
+		return fmt.Errorf("malformed evidence: %w", err)
+	}
+
+	var evidence Evidence
+	var penaltyType PenaltyType
+	details := map[string]interface{}{
+		"evidence_tx": tx.GetHash().String(),
+		"block_index": block.GetIndex(),
+	}
+
+	switch evidenceType {
+	case entities.EvidenceDoubleSign:
+		doubleSign := &entities.DoubleSignEvidence{}
+		if err := doubleSign.FromBytes(tx.GetData()); err != nil {
+			return fmt.Errorf("malformed evidence: %w", err)
+		}
+		if err := l.detector.VerifyEvidence(ctx, doubleSign); err != nil {
+			return err
+		}
+
+		evidence = doubleSign
+		penaltyType = PenaltyDoubleSign
+		details["first_signature"] = doubleSign.GetFirst().GetSignature().String()
+		details["second_signature"] = doubleSign.GetSecond().GetSignature().String()
+	case entities.EvidenceInvalidBlock:
+		invalidBlock := &entities.InvalidBlockEvidence{}
+		if err := invalidBlock.FromBytes(tx.GetData()); err != nil {
+			return fmt.Errorf("malformed evidence: %w", err)
+		}
+		if err := l.detector.VerifyInvalidBlockEvidence(ctx, invalidBlock); err != nil {
+			return err
+		}
+
+		evidence = invalidBlock
+		penaltyType = PenaltyInvalidBlock
+		details["signature"] = invalidBlock.GetHeader().GetSignature().String()
+	default:
+		return fmt.Errorf("unsupported evidence type: %s", evidenceType)
+	}
+
+	if l.applied[evidence.Key()] {
+		return nil
+	}
+	l.applied[evidence.Key()] = true
+	details["height"] = evidence.GetHeight()
+
+	var reason string
+	if penaltyType == PenaltyDoubleSign {
+		reason = fmt.Sprintf("signed two different blocks at height %d", evidence.GetHeight())
+	} else {
+		reason = fmt.Sprintf("signed an invalid block at height %d", evidence.GetHeight())
+	}
+
+	if err := l.penaltySystem.ApplyPenaltyAt(ctx, evidence.GetValidator(), penaltyType, reason, details, block.GetTimestamp()); err != nil {
+		return fmt.Errorf("failed to apply penalty: %w", err)
+	}
+
+	l.logger.WarnContext(ctx, "penalty applied",
+		"penalty", penaltyType,
+		"validator", evidence.GetValidator().String(),
+		logging.Height(block.GetIndex()))
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+)
+
+// newTestLedger cria o livro de penalidades da cadeia com penalização após dois slots perdidos
+func newTestLedger(chain *testChain) (*PenaltyLedger, *PenaltySystem) {
+	chain.validatorManager.SetConfiguration(2, 0, 0)
+	penaltySystem := NewPenaltySystem(chain.validatorManager)
+	detector := NewDoubleSignDetector(chain.validatorManager, chain.chainManager, chain.cryptoService)
+	return NewPenaltyLedger(chain.validatorManager, chain.chainManager, penaltySystem, detector), penaltySystem
+}
+
+// appendOutOfTurn adiciona n blocos produzidos sempre pelo validador 0, de forma que o
+// outro validador perde todos os slots em que seria a sua vez
+func appendOutOfTurn(t *testing.T, chain *testChain, n int) {
+	t.Helper()
+
+	for i := 0; i < n; i++ {
+		chain.appendBlock(t, 0)
+	}
+}
+
+func TestPenaltyLedgerPenalizesMissedSlots(t *testing.T) {
+	chain := newTestChain(t, 2)
+	_, penaltySystem := newTestLedger(chain)
+	chain.sealGenesis(t)
+
+	appendOutOfTurn(t, chain, 4)
+
+	penalties, err := penaltySystem.GetValidatorPenalties(context.Background(), chain.nodeIDs[1])
+	if err != nil {
+		t.Fatalf("GetValidatorPenalties: %v", err)
+	}
+	if len(penalties) != 1 || penalties[0].Type != PenaltyMissedRound {
+		t.Fatalf("penalties = %+v, want one missed round penalty", penalties)
+	}
+}
+
+func TestPenaltyLedgerSkipsSlotsWithoutSlotAccounting(t *testing.T) {
+	chain := newTestChain(t, 2)
+	ledger, penaltySystem := newTestLedger(chain)
+	ledger.SetSlotAccounting(false)
+	chain.sealGenesis(t)
+
+	// No BFT, blocos de rounds seguintes vêm de outro proposer sem que o validador da vez falhe
+	appendOutOfTurn(t, chain, 4)
+
+	for _, nodeID := range chain.nodeIDs {
+		penalties, err := penaltySystem.GetValidatorPenalties(context.Background(), nodeID)
+		if err != nil {
+			t.Fatalf("GetValidatorPenalties: %v", err)
+		}
+		if len(penalties) != 0 {
+			t.Errorf("validator %s penalized without slot accounting: %+v", nodeID.ShortString(), penalties)
+		}
+	}
+
+	if _, state, ok := ledger.GetState(); !ok || len(state.Missed) != 0 {
+		t.Errorf("missed slots = %v, want none recorded", state.Missed)
+	}
+}
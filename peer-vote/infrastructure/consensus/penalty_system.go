@@ -3,6 +3,7 @@ package consensus
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -158,6 +159,12 @@ func (ps *PenaltySystem) setupDefaultRules() {
 
 // ApplyPenalty aplica uma penalidade a um validador
 func (ps *PenaltySystem) ApplyPenalty(ctx context.Context, validatorID valueobjects.NodeID, penaltyType PenaltyType, reason string, evidence map[string]interface{}) error {
+	return ps.ApplyPenaltyAt(ctx, validatorID, penaltyType, reason, evidence, valueobjects.Now())
+}
+
+// ApplyPenaltyAt aplica uma penalidade com o instante informado (ex.: timestamp do bloco que
+// contém a evidência), de forma que todos os nós registrem a mesma penalidade
+func (ps *PenaltySystem) ApplyPenaltyAt(ctx context.Context, validatorID valueobjects.NodeID, penaltyType PenaltyType, reason string, evidence map[string]interface{}, appliedAt valueobjects.Timestamp) error {
 	if validatorID.IsEmpty() {
 		return fmt.Errorf("validator ID cannot be empty")
 	}
@@ -178,9 +185,8 @@ func (ps *PenaltySystem) ApplyPenalty(ctx context.Context, validatorID valueobje
 	}
 
 	// Criar registro de penalidade
-	penaltyID := ps.generatePenaltyID(validatorID, penaltyType)
-	now := valueobjects.Now()
-	expiresAt := now.Add(rule.Duration)
+	penaltyID := ps.generatePenaltyID(validatorID, penaltyType, appliedAt)
+	expiresAt := appliedAt.Add(rule.Duration)
 
 	penalty := &PenaltyRecord{
 		ID:          penaltyID,
@@ -188,7 +194,7 @@ func (ps *PenaltySystem) ApplyPenalty(ctx context.Context, validatorID valueobje
 		Type:        penaltyType,
 		Severity:    rule.Severity,
 		Reason:      reason,
-		AppliedAt:   now,
+		AppliedAt:   appliedAt,
 		ExpiresAt:   expiresAt,
 		IsActive:    true,
 		Evidence:    evidence,
@@ -203,23 +209,23 @@ func (ps *PenaltySystem) ApplyPenalty(ctx context.Context, validatorID valueobje
 	}
 	ps.validatorPenalties[validatorIDStr] = append(ps.validatorPenalties[validatorIDStr], penalty)
 
-	// Verificar se deve banir o validador
-	if err := ps.checkForBan(ctx, validatorID, penaltyType); err != nil {
-		return fmt.Errorf("failed to check for ban: %w", err)
-	}
-
 	// Aplicar penalidade no validador
-	if err := ps.validatorManager.PenalizeValidator(ctx, validatorID, reason); err != nil {
+	if err := ps.validatorManager.PenalizeValidatorAt(ctx, validatorID, reason, appliedAt); err != nil {
 		return fmt.Errorf("failed to penalize validator: %w", err)
 	}
 
+	// Verificar se deve banir o validador (depois da penalidade, para não sobrescrever o ban)
+	if err := ps.checkForBan(ctx, validatorID, penaltyType, appliedAt); err != nil {
+		return fmt.Errorf("failed to check for ban: %w", err)
+	}
+
 	// Notificar
 	notification := PenaltyNotification{
 		ValidatorID: validatorID,
 		Type:        penaltyType,
 		Severity:    rule.Severity,
 		Reason:      reason,
-		Timestamp:   now,
+		Timestamp:   appliedAt,
 		Action:      "APPLIED",
 	}
 
@@ -233,14 +239,14 @@ func (ps *PenaltySystem) ApplyPenalty(ctx context.Context, validatorID valueobje
 }
 
 // checkForBan verifica se um validador deve ser banido
-func (ps *PenaltySystem) checkForBan(ctx context.Context, validatorID valueobjects.NodeID, penaltyType PenaltyType) error {
+func (ps *PenaltySystem) checkForBan(ctx context.Context, validatorID valueobjects.NodeID, penaltyType PenaltyType, at valueobjects.Timestamp) error {
 	rule := ps.penaltyRules[penaltyType]
 	if rule.MaxCount <= 0 {
 		return nil // Sem limite
 	}
 
-	// Contar penalidades ativas deste tipo
-	count := ps.countActivePenalties(validatorID, penaltyType)
+	// Contar penalidades ativas deste tipo no instante da penalidade
+	count := ps.countActivePenalties(validatorID, penaltyType, at)
 	
 	if count >= rule.MaxCount {
 		// Banir validador
@@ -249,23 +255,22 @@ func (ps *PenaltySystem) checkForBan(ctx context.Context, validatorID valueobjec
 			return ps.validatorManager.SetValidatorStatus(ctx, validatorID, ValidatorBanned)
 		} else {
 			// Ban temporário
-			return ps.validatorManager.SetValidatorStatus(ctx, validatorID, ValidatorPenalized)
+			return ps.validatorManager.SuspendValidator(ctx, validatorID, at.Add(rule.BanDuration))
 		}
 	}
 
 	return nil
 }
 
-// countActivePenalties conta penalidades ativas de um tipo específico
-func (ps *PenaltySystem) countActivePenalties(validatorID valueobjects.NodeID, penaltyType PenaltyType) int {
+// countActivePenalties conta penalidades de um tipo específico ativas no instante informado
+func (ps *PenaltySystem) countActivePenalties(validatorID valueobjects.NodeID, penaltyType PenaltyType, at valueobjects.Timestamp) int {
 	validatorIDStr := validatorID.String()
 	penalties := ps.validatorPenalties[validatorIDStr]
 	
 	count := 0
-	now := valueobjects.Now()
 	
 	for _, penalty := range penalties {
-		if penalty.Type == penaltyType && penalty.IsActive && now.Before(penalty.ExpiresAt) {
+		if penalty.Type == penaltyType && penalty.IsActive && at.Before(penalty.ExpiresAt) {
 			count++
 		}
 	}
@@ -433,10 +438,75 @@ func (ps *PenaltySystem) GetNotificationChannel() <-chan PenaltyNotification {
 	return ps.penaltyNotifications
 }
 
-// generatePenaltyID gera um ID único para a penalidade
-func (ps *PenaltySystem) generatePenaltyID(validatorID valueobjects.NodeID, penaltyType PenaltyType) string {
-	timestamp := valueobjects.Now().Unix()
-	return fmt.Sprintf("%s-%s-%d", validatorID.ShortString(), penaltyType, timestamp)
+// generatePenaltyID gera um ID único para a penalidade (deve ser chamado com lock)
+func (ps *PenaltySystem) generatePenaltyID(validatorID valueobjects.NodeID, penaltyType PenaltyType, appliedAt valueobjects.Timestamp) string {
+	base := fmt.Sprintf("%s-%s-%d", validatorID.ShortString(), penaltyType, appliedAt.Unix())
+
+	// Penalidades do mesmo tipo no mesmo bloco recebem sufixo sequencial
+	id := base
+	for seq := 2; ps.penalties[id] != nil; seq++ {
+		id = fmt.Sprintf("%s-%d", base, seq)
+	}
+
+	return id
+}
+
+// Reset descarta todos os registros de penalidade (usado ao reprocessar a cadeia)
+func (ps *PenaltySystem) Reset(ctx context.Context) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.penalties = make(map[string]*PenaltyRecord)
+	ps.validatorPenalties = make(map[string][]*PenaltyRecord)
+	ps.validatorManager.ResetPenalties(ctx)
+}
+
+// GetAllPenalties retorna todos os registros de penalidade ordenados por instante de aplicação e ID
+func (ps *PenaltySystem) GetAllPenalties(ctx context.Context) []*PenaltyRecord {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+
+	records := make([]*PenaltyRecord, 0, len(ps.penalties))
+	for _, penalty := range ps.penalties {
+		records = append(records, penalty)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].AppliedAt.Equal(records[j].AppliedAt) {
+			return records[i].AppliedAt.Before(records[j].AppliedAt)
+		}
+		return records[i].ID < records[j].ID
+	})
+
+	return records
+}
+
+// RestorePenalties substitui os registros pelos informados (ex.: estado de um snapshot) e
+// reaplica seus efeitos nos validadores que ainda fazem parte do conjunto vigente
+func (ps *PenaltySystem) RestorePenalties(ctx context.Context, records []*PenaltyRecord) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	ps.penalties = make(map[string]*PenaltyRecord)
+	ps.validatorPenalties = make(map[string][]*PenaltyRecord)
+	ps.validatorManager.ResetPenalties(ctx)
+
+	for _, record := range records {
+		restored := *record
+		ps.penalties[restored.ID] = &restored
+
+		validatorIDStr := restored.ValidatorID.String()
+		ps.validatorPenalties[validatorIDStr] = append(ps.validatorPenalties[validatorIDStr], &restored)
+
+		if _, err := ps.validatorManager.GetValidator(ctx, restored.ValidatorID); err != nil {
+			continue // Validador saiu do conjunto
+		}
+		if err := ps.validatorManager.PenalizeValidatorAt(ctx, restored.ValidatorID, restored.Reason, restored.AppliedAt); err != nil {
+			continue
+		}
+		if _, exists := ps.penaltyRules[restored.Type]; exists {
+			ps.checkForBan(ctx, restored.ValidatorID, restored.Type, restored.AppliedAt)
+		}
+	}
 }
 
 // StartCleanupRoutine inicia a rotina de limpeza automática
@@ -4,7 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -13,6 +13,7 @@ import (
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
 )
 
 // PoAEngine implementa o algoritmo de consenso Proof of Authority
@@ -36,17 +37,22 @@ type PoAEngine struct {
 	myPrivateKey     *services.PrivateKey
 	
 	// Pool de transações pendentes
-	pendingTxs       []*entities.Transaction
-	maxPendingTxs    int
+	mempool          *Mempool
 	
 	// Configurações
 	blockInterval    time.Duration // Intervalo entre blocos
 	minTxPerBlock    int          // Mínimo de transações por bloco
 	maxTxPerBlock    int          // Máximo de transações por bloco
 	
+	// Alturas já atestadas e já assinadas por este nó (um único bloco por altura)
+	attestedHeights  map[uint64]string
+	signedHeights    map[uint64]bool
+	attestMu         sync.Mutex
+	
 	// Canais para comunicação
 	newTxChan        chan *entities.Transaction
-	stopChan         chan struct{}
+	stopChan         chan struct{}      // Recriado a cada início; fechado uma vez na parada
+	cancelRun        context.CancelFunc // Cancela o contexto da execução corrente
 	
 	// Mutex para operações thread-safe
 	mu sync.RWMutex
@@ -54,6 +60,8 @@ type PoAEngine struct {
 	// Callbacks para eventos
 	onBlockProduced  func(*entities.Block)
 	onConsensusError func(error)
+	
+	logger *slog.Logger
 }
 
 // NewPoAEngine cria um novo motor de consenso PoA
@@ -77,15 +85,21 @@ func NewPoAEngine(
 		keyRepository:    nil, // Será definido depois
 		myNodeID:         myNodeID,
 		myPrivateKey:     myPrivateKey,
-		pendingTxs:       make([]*entities.Transaction, 0),
-		maxPendingTxs:    10000,
+		mempool:          NewMempool(chainManager, DefaultMempoolConfig()),
 		blockInterval:    time.Second * 2,
 		minTxPerBlock:    1,
 		maxTxPerBlock:    1000,
+		attestedHeights:  make(map[uint64]string),
+		signedHeights:    make(map[uint64]bool),
 		newTxChan:        make(chan *entities.Transaction, 1000),
 		stopChan:         make(chan struct{}),
+		logger:           logging.Component("consensus"),
 	}
 	
+	// Regras PoA para escolha de fork e notificação de reorganizações
+	chainManager.SetBlockAuthority(NewBlockAuthority(validatorManager, cryptoService))
+	chainManager.AddReorgListener(engine.handleReorg)
+	
 	// Configurar handlers P2P se o serviço estiver disponível
 	if networkService != nil {
 		engine.setupNetworkHandlers()
@@ -94,6 +108,22 @@ func NewPoAEngine(
 	return engine
 }
 
+// SetNetworkService define o serviço de rede quando ele é criado depois do motor
+func (poa *PoAEngine) SetNetworkService(networkService services.NetworkService) {
+	poa.networkService = networkService
+	
+	if networkService != nil {
+		poa.setupNetworkHandlers()
+	}
+}
+
+// SetLogger define o logger do motor de consenso
+func (poa *PoAEngine) SetLogger(logger *slog.Logger) {
+	poa.mu.Lock()
+	defer poa.mu.Unlock()
+	poa.logger = logger
+}
+
 // SetKeyRepository define o repositório de chaves para validação
 func (poa *PoAEngine) SetKeyRepository(keyRepo crypto.KeyRepository) {
 	poa.mu.Lock()
@@ -120,18 +150,25 @@ func (poa *PoAEngine) StartConsensus(ctx context.Context) error {
 		return fmt.Errorf("node %s is not an authorized validator", poa.myNodeID.ShortString())
 	}
 
+	// O scheduler e as goroutines desta execução encerram junto com runCtx
+	runCtx, cancel := context.WithCancel(ctx)
+
 	// Iniciar Round Robin scheduler
-	if err := poa.roundRobin.Start(ctx); err != nil {
+	if err := poa.roundRobin.Start(runCtx); err != nil {
+		cancel()
 		return fmt.Errorf("failed to start round robin scheduler: %w", err)
 	}
 
+	stop := make(chan struct{})
+	poa.stopChan = stop
+	poa.cancelRun = cancel
 	poa.isRunning = true
 
 	// Iniciar goroutines
-	go poa.consensusLoop(ctx)
-	go poa.transactionProcessor(ctx)
-	go poa.roundMonitor(ctx)
-	go poa.syncLoop(ctx) // Sincronização periódica com peers
+	go poa.consensusLoop(runCtx, stop)
+	go poa.transactionProcessor(runCtx, stop)
+	go poa.roundMonitor(runCtx, stop)
+	go poa.syncLoop(runCtx) // Sincronização periódica com peers
 
 	return nil
 }
@@ -146,6 +183,7 @@ func (poa *PoAEngine) StopConsensus(ctx context.Context) error {
 	}
 
 	poa.isRunning = false
+	poa.cancelRun()
 	close(poa.stopChan)
 
 	return nil
@@ -183,6 +221,9 @@ func (poa *PoAEngine) ProposeBlock(ctx context.Context, block *entities.Block) e
 	// Propagar bloco para todos os peers via P2P real
 	poa.broadcastBlock(ctx, block)
 
+	// Atestar o próprio bloco para a finalidade
+	poa.attestBlock(ctx, block)
+
 	// Notificar produção de bloco
 	if err := poa.roundRobin.NotifyBlockProduced(ctx, poa.myNodeID); err != nil {
 		return fmt.Errorf("failed to notify block production: %w", err)
@@ -278,8 +319,8 @@ func (poa *PoAEngine) ValidateBlock(ctx context.Context, block *entities.Block)
 	// O importante é que seja um validador autorizado (já verificado acima)
 	if !validator.Equals(currentValidator) {
 		// Log informativo em vez de erro crítico para blocos via P2P
-		log.Printf("Block from validator %s received when current validator is %s (normal P2P behavior)", 
-			validator.ShortString(), currentValidator.ShortString())
+		poa.logger.DebugContext(ctx, "block from out-of-turn validator (normal P2P behavior)",
+			logging.Height(block.GetIndex()), "validator", validator.String(), "current_validator", currentValidator.String())
 	}
 
 	return nil
@@ -295,24 +336,11 @@ func (poa *PoAEngine) AddTransaction(ctx context.Context, tx *entities.Transacti
 		return errors.New("invalid transaction")
 	}
 
-	poa.mu.Lock()
-	defer poa.mu.Unlock()
-
-	// Verificar se o pool não está cheio
-	if len(poa.pendingTxs) >= poa.maxPendingTxs {
-		return errors.New("transaction pool is full")
+	// Adicionar ao pool (deduplicação, limites e proteção contra replay)
+	if err := poa.mempool.Add(tx); err != nil {
+		return err
 	}
 
-	// Verificar duplicatas
-	for _, pendingTx := range poa.pendingTxs {
-		if pendingTx.GetHash().Equals(tx.GetHash()) {
-			return errors.New("transaction already in pool")
-		}
-	}
-
-	// Adicionar ao pool
-	poa.pendingTxs = append(poa.pendingTxs, tx)
-
 	// Notificar processador de transações
 	select {
 	case poa.newTxChan <- tx:
@@ -413,7 +441,7 @@ func (poa *PoAEngine) GetConsensusStatus(ctx context.Context) (*services.Consens
 }
 
 // consensusLoop é o loop principal do consenso
-func (poa *PoAEngine) consensusLoop(ctx context.Context) {
+func (poa *PoAEngine) consensusLoop(ctx context.Context, stop <-chan struct{}) {
 	ticker := time.NewTicker(poa.blockInterval)
 	defer ticker.Stop()
 
@@ -421,7 +449,7 @@ func (poa *PoAEngine) consensusLoop(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			return
-		case <-poa.stopChan:
+		case <-stop:
 			return
 		case <-ticker.C:
 			poa.tryProduceBlock(ctx)
@@ -441,22 +469,26 @@ func (poa *PoAEngine) tryProduceBlock(ctx context.Context) {
 	defer poa.mu.Unlock()
 
 	// Verificar se temos transações suficientes
-	if len(poa.pendingTxs) < poa.minTxPerBlock {
+	if poa.mempool.Size() < poa.minTxPerBlock {
 		return
 	}
 
-	// Selecionar transações para o bloco
-	txCount := len(poa.pendingTxs)
-	if txCount > poa.maxTxPerBlock {
-		txCount = poa.maxTxPerBlock
-	}
+	// Selecionar transações para o bloco por prioridade
+	selectedTxs := poa.mempool.Select(poa.maxTxPerBlock)
 
-	selectedTxs := make([]*entities.Transaction, txCount)
-	copy(selectedTxs, poa.pendingTxs[:txCount])
+	// Nunca assinar dois blocos diferentes na mesma altura (seria assinatura dupla,
+	// ex.: após uma reorganização que descartou o bloco produzido por este nó)
+	chainHeight, err := poa.chainManager.GetChainHeight(ctx)
+	if err != nil || !poa.claimSigningHeight(chainHeight+1) {
+		return
+	}
 
 	// Propor bloco
 	block, err := poa.chainManager.ProposeBlock(ctx, selectedTxs, poa.myNodeID, poa.myPrivateKey)
 	if err != nil {
+		// Nenhum bloco foi assinado; a altura volta a ficar disponível
+		poa.releaseSigningHeight(chainHeight + 1)
+		poa.logger.ErrorContext(ctx, "failed to propose block", logging.Height(chainHeight+1), logging.Err(err))
 		if poa.onConsensusError != nil {
 			poa.onConsensusError(fmt.Errorf("failed to propose block: %w", err))
 		}
@@ -465,17 +497,19 @@ func (poa *PoAEngine) tryProduceBlock(ctx context.Context) {
 
 	// Adicionar bloco à cadeia
 	if err := poa.chainManager.AddBlock(ctx, block); err != nil {
+		poa.logger.ErrorContext(ctx, "failed to add produced block", logging.Height(block.GetIndex()), logging.Err(err))
 		if poa.onConsensusError != nil {
 			poa.onConsensusError(fmt.Errorf("failed to add block: %w", err))
 		}
 		return
 	}
+	poa.logger.InfoContext(ctx, "block produced", logging.Height(block.GetIndex()), "transactions", len(block.GetTransactions()))
 
 	// Propagar bloco para todos os peers via P2P real
 	poa.broadcastBlock(ctx, block)
 
-	// Remover transações processadas do pool
-	poa.pendingTxs = poa.pendingTxs[txCount:]
+	// Atestar o próprio bloco para a finalidade
+	poa.attestBlock(ctx, block)
 
 	// Notificar produção de bloco
 	poa.roundRobin.NotifyBlockProduced(ctx, poa.myNodeID)
@@ -487,12 +521,12 @@ func (poa *PoAEngine) tryProduceBlock(ctx context.Context) {
 }
 
 // transactionProcessor processa transações recebidas
-func (poa *PoAEngine) transactionProcessor(ctx context.Context) {
+func (poa *PoAEngine) transactionProcessor(ctx context.Context, stop <-chan struct{}) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-poa.stopChan:
+		case <-stop:
 			return
 		case tx := <-poa.newTxChan:
 			// Transação já foi adicionada ao pool, apenas processa se necessário
@@ -502,14 +536,14 @@ func (poa *PoAEngine) transactionProcessor(ctx context.Context) {
 }
 
 // roundMonitor monitora mudanças de round
-func (poa *PoAEngine) roundMonitor(ctx context.Context) {
+func (poa *PoAEngine) roundMonitor(ctx context.Context, stop <-chan struct{}) {
 	roundChangeChan := poa.roundRobin.GetRoundChangeChannel()
 	
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		case <-poa.stopChan:
+		case <-stop:
 			return
 		case event := <-roundChangeChan:
 			poa.handleRoundChange(ctx, event)
@@ -527,6 +561,92 @@ func (poa *PoAEngine) handleRoundChange(ctx context.Context, event RoundChangeEv
 	poa.validatorManager.CleanupExpiredPenalties(ctx)
 }
 
+// handleReorg atesta a nova ramificação canônica (o mempool reinjeta as transações desfeitas)
+func (poa *PoAEngine) handleReorg(ctx context.Context, event *blockchain.ReorgEvent) {
+	// Blocos da nova ramificação canônica ainda não atestados por este nó
+	for _, block := range event.Added {
+		poa.attestBlock(ctx, block)
+	}
+	
+	poa.logger.InfoContext(ctx, "attested reorganized branch",
+		"common_ancestor", event.CommonAncestor, "removed", len(event.Removed), "added", len(event.Added))
+}
+
+// attestBlock assina e propaga uma atestação para um bloco canônico, se este nó for validador.
+// Nunca atesta dois blocos diferentes na mesma altura.
+func (poa *PoAEngine) attestBlock(ctx context.Context, block *entities.Block) {
+	if poa.networkService == nil || poa.myPrivateKey == nil {
+		return
+	}
+	
+	isValidator, err := poa.validatorManager.IsValidator(ctx, poa.myNodeID)
+	if err != nil || !isValidator {
+		return
+	}
+	
+	// Atestar apenas blocos que fazem parte da cadeia canônica local
+	blockHash := poa.chainManager.CalculateBlockHash(ctx, block)
+	canonical, err := poa.chainManager.GetBlockByIndex(ctx, block.GetIndex())
+	if err != nil || !poa.chainManager.CalculateBlockHash(ctx, canonical).Equals(blockHash) {
+		return
+	}
+	
+	poa.attestMu.Lock()
+	if _, attested := poa.attestedHeights[block.GetIndex()]; attested {
+		poa.attestMu.Unlock()
+		return
+	}
+	poa.attestedHeights[block.GetIndex()] = blockHash.String()
+	
+	// Descartar registros antigos, abaixo da profundidade máxima de reorganização
+	maxDepth := uint64(poa.chainManager.GetMaxReorgDepth())
+	for height := range poa.attestedHeights {
+		if height+maxDepth < block.GetIndex() {
+			delete(poa.attestedHeights, height)
+		}
+	}
+	poa.attestMu.Unlock()
+	
+	attestation, err := NewSignedAttestation(ctx, poa.cryptoService, blockHash, block.GetIndex(), poa.myNodeID, poa.myPrivateKey)
+	if err != nil {
+		poa.logger.ErrorContext(ctx, "failed to create attestation", logging.Height(block.GetIndex()), logging.Err(err))
+		return
+	}
+	
+	if err := poa.networkService.BroadcastAttestation(ctx, attestation); err != nil {
+		poa.logger.WarnContext(ctx, "failed to broadcast attestation", logging.Height(block.GetIndex()), logging.Err(err))
+	}
+}
+
+// claimSigningHeight reserva a altura para assinatura; retorna false se este nó já assinou um bloco nela
+func (poa *PoAEngine) claimSigningHeight(height uint64) bool {
+	poa.attestMu.Lock()
+	defer poa.attestMu.Unlock()
+	
+	if poa.signedHeights[height] {
+		return false
+	}
+	poa.signedHeights[height] = true
+	
+	// Descartar registros antigos, abaixo da profundidade máxima de reorganização
+	maxDepth := uint64(poa.chainManager.GetMaxReorgDepth())
+	for signed := range poa.signedHeights {
+		if signed+maxDepth < height {
+			delete(poa.signedHeights, signed)
+		}
+	}
+	
+	return true
+}
+
+// releaseSigningHeight libera uma altura reservada cujo bloco não chegou a ser assinado
+func (poa *PoAEngine) releaseSigningHeight(height uint64) {
+	poa.attestMu.Lock()
+	defer poa.attestMu.Unlock()
+
+	delete(poa.signedHeights, height)
+}
+
 // validateProposedBlock valida um bloco proposto internamente
 func (poa *PoAEngine) validateProposedBlock(ctx context.Context, block *entities.Block) error {
 	// Usar validação padrão
@@ -566,20 +686,24 @@ func (poa *PoAEngine) GetRoundRobinScheduler() *RoundRobinScheduler {
 	return poa.roundRobin
 }
 
+// GetValidatorManager retorna o gerenciador de validadores usado pelo motor
+func (poa *PoAEngine) GetValidatorManager() *ValidatorManager {
+	return poa.validatorManager
+}
+
+// GetMempool retorna o pool de transações pendentes
+func (poa *PoAEngine) GetMempool() *Mempool {
+	return poa.mempool
+}
+
 // GetPendingTransactionCount retorna o número de transações pendentes
 func (poa *PoAEngine) GetPendingTransactionCount() int {
-	poa.mu.RLock()
-	defer poa.mu.RUnlock()
-
-	return len(poa.pendingTxs)
+	return poa.mempool.Size()
 }
 
 // ClearPendingTransactions limpa o pool de transações pendentes
 func (poa *PoAEngine) ClearPendingTransactions() {
-	poa.mu.Lock()
-	defer poa.mu.Unlock()
-
-	poa.pendingTxs = make([]*entities.Transaction, 0)
+	poa.mempool.Clear()
 }
 
 // serializeBlockForValidation serializa um bloco para validação de assinatura
@@ -629,13 +753,13 @@ func (poa *PoAEngine) setupNetworkHandlers() {
 // Aplica SRP: responsabilidade única de propagar blocos
 func (poa *PoAEngine) broadcastBlock(ctx context.Context, block *entities.Block) {
 	if poa.networkService == nil {
-		log.Printf("Warning: NetworkService not available, block not broadcasted")
+		poa.logger.WarnContext(ctx, "network service not available, block not broadcast", logging.Height(block.GetIndex()))
 		return
 	}
 	
 	// Usar P2P real para propagar o bloco
 	if err := poa.networkService.BroadcastBlock(ctx, block); err != nil {
-		log.Printf("Error broadcasting block %d: %v", block.GetIndex(), err)
+		poa.logger.WarnContext(ctx, "failed to broadcast block", logging.Height(block.GetIndex()), logging.Err(err))
 		if poa.onConsensusError != nil {
 			poa.onConsensusError(fmt.Errorf("failed to broadcast block: %w", err))
 		}
@@ -666,18 +790,22 @@ func (poa *PoAEngine) handleIncomingBlock(ctx context.Context, block *entities.B
 	
 	// Validar o bloco recebido
 	if err := poa.ValidateBlock(ctx, block); err != nil {
-		log.Printf("Invalid block received from peer %s: %v", fromPeer.String(), err)
+		poa.logger.WarnContext(ctx, "invalid block received", logging.Height(block.GetIndex()), logging.Peer(fromPeer), logging.Err(err))
 		return fmt.Errorf("invalid block from peer: %w", err)
 	}
 	
-	// Tentar adicionar o bloco à cadeia
-	if err := poa.chainManager.AddBlock(ctx, block); err != nil {
+	// Tentar adicionar o bloco à cadeia (ou à árvore de forks, se for de outra ramificação)
+	if err := poa.chainManager.ProcessBlock(ctx, block); err != nil {
 		// Bloco pode já existir ou ser inválido - não é erro crítico
-		log.Printf("Could not add block %d from peer %s: %v", block.GetIndex(), fromPeer.String(), err)
+		poa.logger.DebugContext(ctx, "block from peer not added", logging.Height(block.GetIndex()), logging.Peer(fromPeer), logging.Err(err))
 		return nil
 	}
 	
-	log.Printf("Successfully added block %d from peer %s via P2P", block.GetIndex(), fromPeer.String())
+	// Atestar o bloco aceito para a finalidade
+	poa.attestBlock(ctx, block)
+	
+	poa.logger.InfoContext(ctx, "block from peer added", logging.Height(block.GetIndex()), logging.Peer(fromPeer),
+		"validator", block.GetValidator().String(), "transactions", len(block.GetTransactions()))
 	return nil
 }
 
@@ -690,11 +818,11 @@ func (poa *PoAEngine) handleIncomingTransaction(ctx context.Context, tx *entitie
 	
 	// Adicionar transação ao pool se válida
 	if err := poa.AddTransaction(ctx, tx); err != nil {
-		log.Printf("Could not add transaction %s from peer %s: %v", tx.GetID().String(), fromPeer.String(), err)
+		poa.logger.DebugContext(ctx, "transaction from peer not added", logging.TxHash(tx.GetID()), logging.Peer(fromPeer), logging.Err(err))
 		return nil // Não é erro crítico
 	}
 	
-	log.Printf("Successfully added transaction %s from peer %s via P2P", tx.GetID().String(), fromPeer.String())
+	poa.logger.DebugContext(ctx, "transaction from peer added", logging.TxHash(tx.GetID()), logging.Peer(fromPeer))
 	return nil
 }
 
@@ -702,13 +830,13 @@ func (poa *PoAEngine) handleIncomingTransaction(ctx context.Context, tx *entitie
 // Aplica SRP: responsabilidade única de propagar transações
 func (poa *PoAEngine) BroadcastTransaction(ctx context.Context, tx *entities.Transaction) error {
 	if poa.networkService == nil {
-		log.Printf("Warning: NetworkService not available, transaction not broadcasted")
+		poa.logger.WarnContext(ctx, "network service not available, transaction not broadcast", logging.TxHash(tx.GetID()))
 		return fmt.Errorf("network service not available")
 	}
 	
 	// Usar P2P real para propagar a transação
 	if err := poa.networkService.BroadcastTransaction(ctx, tx); err != nil {
-		log.Printf("Error broadcasting transaction %s: %v", tx.GetID().String(), err)
+		poa.logger.WarnContext(ctx, "failed to broadcast transaction", logging.TxHash(tx.GetID()), logging.Err(err))
 		return fmt.Errorf("failed to broadcast transaction: %w", err)
 	}
 	
@@ -0,0 +1,37 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPoAReleasesSigningHeightWhenProposalFails(t *testing.T) {
+	chain := newTestChain(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	poa := NewPoAEngine(chain.validatorManager, chain.chainManager, chain.cryptoService, chain.nodeIDs[0], chain.keyPairs[0].PrivateKey, nil)
+	if err := poa.roundRobin.Start(ctx); err != nil {
+		t.Fatalf("start scheduler: %v", err)
+	}
+	if err := poa.AddTransaction(ctx, chain.newTransaction(t, 0)); err != nil {
+		t.Fatalf("add transaction: %v", err)
+	}
+
+	// Sem gênesis a proposta falha antes da assinatura
+	poa.tryProduceBlock(ctx)
+	if height, _ := chain.chainManager.GetChainHeight(ctx); height != 0 {
+		t.Fatalf("height = %d after failed proposal, want 0", height)
+	}
+
+	chain.sealGenesis(t)
+	poa.tryProduceBlock(ctx)
+	if height, _ := chain.chainManager.GetChainHeight(ctx); height != 1 {
+		t.Fatalf("height = %d, want block 1 produced after the failed attempt", height)
+	}
+
+	// Um bloco realmente assinado mantém a reserva
+	if poa.claimSigningHeight(1) {
+		t.Errorf("height 1 claimable again after signing a block")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -394,3 +395,66 @@ func (rr *RoundRobinScheduler) NotifyBlockProduced(ctx context.Context, validato
 	// Avançar round após produção de bloco
 	return rr.advanceRoundInternal(ctx, "block produced")
 }
+
+// SelectProposer retorna o proposer determinístico de um round de consenso BFT.
+// Usa todos os validadores autorizados em ordem estável, para que todos os nós
+// cheguem ao mesmo resultado independentemente da atividade observada localmente.
+func (rr *RoundRobinScheduler) SelectProposer(ctx context.Context, height, round uint64) (valueobjects.NodeID, error) {
+	validators, err := rr.validatorManager.GetAllValidators(ctx)
+	if err != nil {
+		return valueobjects.EmptyNodeID(), fmt.Errorf("failed to get validators: %w", err)
+	}
+
+	ids := make([]string, 0, len(validators))
+	for _, validator := range validators {
+		if validator.Status == ValidatorBanned {
+			continue
+		}
+		ids = append(ids, validator.NodeID.String())
+	}
+
+	if len(ids) == 0 {
+		return valueobjects.EmptyNodeID(), errors.New("no validators available")
+	}
+
+	sort.Strings(ids)
+	return valueobjects.NewNodeID(ids[(height+round)%uint64(len(ids))]), nil
+}
+
+// MoveToRound posiciona o scheduler em um round definido externamente (consenso BFT)
+func (rr *RoundRobinScheduler) MoveToRound(ctx context.Context, round uint64, proposer valueobjects.NodeID, reason string) {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	if err := rr.refreshActiveValidators(ctx); err != nil {
+		return
+	}
+
+	oldRound := rr.currentRound
+	oldValidator := rr.currentValidator
+
+	rr.currentRound = round
+	rr.currentValidator = proposer
+	rr.roundStartTime = valueobjects.Now()
+	for i, nodeID := range rr.activeValidators {
+		if nodeID.Equals(proposer) {
+			rr.currentIndex = i
+			break
+		}
+	}
+
+	event := RoundChangeEvent{
+		OldRound:     oldRound,
+		NewRound:     round,
+		OldValidator: oldValidator,
+		NewValidator: proposer,
+		Timestamp:    rr.roundStartTime,
+		Reason:       reason,
+	}
+
+	select {
+	case rr.roundChangeChan <- event:
+	default:
+		// Canal cheio, pular notificação
+	}
+}
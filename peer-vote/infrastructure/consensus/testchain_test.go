@@ -0,0 +1,94 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/persistence"
+)
+
+// testChain é uma cadeia em memória com um conjunto de validadores
+type testChain struct {
+	cryptoService    services.CryptographyService
+	chainManager     *blockchain.ChainManager
+	validatorManager *ValidatorManager
+	nodeIDs          []valueobjects.NodeID
+	keyPairs         []*services.KeyPair
+}
+
+// newTestChain cria uma cadeia em memória, sem gênesis, com n validadores
+func newTestChain(t *testing.T, n int) *testChain {
+	t.Helper()
+	ctx := context.Background()
+
+	cryptoService := crypto.NewECDSAService()
+	chain := &testChain{
+		cryptoService:    cryptoService,
+		chainManager:     blockchain.NewChainManager(persistence.NewMemoryBlockchainRepository(cryptoService), cryptoService),
+		validatorManager: NewValidatorManager(),
+	}
+
+	for i := 0; i < n; i++ {
+		keyPair, err := cryptoService.GenerateKeyPair(ctx)
+		if err != nil {
+			t.Fatalf("generate key pair: %v", err)
+		}
+		nodeID := cryptoService.GenerateNodeID(ctx, keyPair.PublicKey)
+		if err := chain.validatorManager.AddValidator(ctx, nodeID, keyPair.PublicKey); err != nil {
+			t.Fatalf("add validator: %v", err)
+		}
+		chain.nodeIDs = append(chain.nodeIDs, nodeID)
+		chain.keyPairs = append(chain.keyPairs, keyPair)
+	}
+
+	return chain
+}
+
+// newTransaction cria uma transação assinada válida pelo validador i
+func (c *testChain) newTransaction(t *testing.T, i int) *entities.Transaction {
+	t.Helper()
+	ctx := context.Background()
+
+	candidate, err := c.cryptoService.GenerateKeyPair(ctx)
+	if err != nil {
+		t.Fatalf("generate candidate key pair: %v", err)
+	}
+	proposal := entities.NewValidatorProposal(entities.ValidatorActionAdd, c.cryptoService.GenerateNodeID(ctx, candidate.PublicKey),
+		candidate.PublicKey.ToBytes(), candidate.PublicKey.Curve, 100, c.nodeIDs[i])
+	tx, err := NewValidatorProposalTransaction(ctx, c.cryptoService, proposal, c.keyPairs[i].PrivateKey)
+	if err != nil {
+		t.Fatalf("create transaction: %v", err)
+	}
+	return tx
+}
+
+// sealGenesis cria o bloco gênesis com uma transação do primeiro validador
+func (c *testChain) sealGenesis(t *testing.T) {
+	t.Helper()
+
+	err := c.chainManager.CreateGenesisBlock(context.Background(), []*entities.Transaction{c.newTransaction(t, 0)},
+		c.nodeIDs[0], c.keyPairs[0].PrivateKey)
+	if err != nil {
+		t.Fatalf("create genesis block: %v", err)
+	}
+}
+
+// appendBlock adiciona à cadeia um bloco com uma transação, assinado pelo validador i
+func (c *testChain) appendBlock(t *testing.T, i int) *entities.Block {
+	t.Helper()
+	ctx := context.Background()
+
+	block, err := c.chainManager.ProposeBlock(ctx, []*entities.Transaction{c.newTransaction(t, i)}, c.nodeIDs[i], c.keyPairs[i].PrivateKey)
+	if err != nil {
+		t.Fatalf("propose block: %v", err)
+	}
+	if err := c.chainManager.AddBlock(ctx, block); err != nil {
+		t.Fatalf("add block %d: %v", block.GetIndex(), err)
+	}
+	return block
+}
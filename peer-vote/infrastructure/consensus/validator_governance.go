@@ -0,0 +1,518 @@
+package consensus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// ValidatorGovernance aplica alterações do conjunto de validadores decididas na cadeia.
+// Propostas e aprovações são transações VALIDATOR; uma proposta é aceita quando a maioria
+// dos validadores vigentes na altura em que entrou na cadeia a assina, e passa a valer na
+// altura efetiva informada. O estado é derivado apenas dos blocos canônicos, então todos os
+// nós chegam ao mesmo conjunto e ele é reconstruído a partir do gênesis (ou da base de um
+// snapshot) após reorganizações.
+type ValidatorGovernance struct {
+	validatorManager *ValidatorManager
+	chainManager     *blockchain.ChainManager
+	cryptoService    services.CryptographyService
+
+	// Propostas pendentes (por hash da transação) e alterações aprovadas em ordem de aprovação
+	proposals map[string]*pendingValidatorProposal
+	changes   []approvedValidatorChange
+
+	// Última altura processada
+	processedHeight uint64
+	hasProcessed    bool
+
+	// Épocas herdadas de um snapshot quando a cadeia começa em baseHeight
+	baseEpochs []ValidatorSetEpoch
+	baseHeight uint64
+	hasBase    bool
+
+	logger *slog.Logger
+
+	// Mutex para operações thread-safe
+	mu sync.Mutex
+}
+
+// pendingValidatorProposal representa uma proposta aguardando aprovações
+type pendingValidatorProposal struct {
+	id         valueobjects.Hash
+	proposal   *entities.ValidatorProposal
+	electorate map[string]*services.PublicKey // Validadores vigentes na inclusão da proposta
+	approvals  map[string]bool
+	height     uint64
+}
+
+// approvedValidatorChange representa uma alteração aprovada
+type approvedValidatorChange struct {
+	proposal   *entities.ValidatorProposal
+	publicKey  *services.PublicKey
+	approvedAt uint64
+	sequence   int
+}
+
+// ValidatorProposalInfo contém informações de uma proposta pendente
+type ValidatorProposalInfo struct {
+	ProposalID      valueobjects.Hash
+	Action          entities.ValidatorAction
+	NodeID          valueobjects.NodeID
+	EffectiveHeight uint64
+	Proposer        valueobjects.NodeID
+	IncludedAt      uint64
+	Approvals       []valueobjects.NodeID
+	Required        int
+}
+
+// NewValidatorGovernance cria o gerenciador de governança e o registra na cadeia
+func NewValidatorGovernance(
+	validatorManager *ValidatorManager,
+	chainManager *blockchain.ChainManager,
+	cryptoService services.CryptographyService,
+) *ValidatorGovernance {
+	governance := &ValidatorGovernance{
+		validatorManager: validatorManager,
+		chainManager:     chainManager,
+		cryptoService:    cryptoService,
+		proposals:        make(map[string]*pendingValidatorProposal),
+		changes:          make([]approvedValidatorChange, 0),
+		logger:           logging.Component("governance"),
+	}
+
+	chainManager.AddBlockListener(governance.handleBlock)
+	chainManager.AddReorgListener(governance.handleReorg)
+
+	return governance
+}
+
+// SetLogger define o logger da governança de validadores
+func (g *ValidatorGovernance) SetLogger(logger *slog.Logger) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.logger = logger
+}
+
+// Rebuild reconstrói o conjunto de validadores reprocessando a cadeia canônica desde o gênesis (ou a base)
+func (g *ValidatorGovernance) Rebuild(ctx context.Context) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.rebuild(ctx)
+}
+
+// SetBase define as épocas do conjunto de validadores herdadas de um snapshot na altura base
+// e reprocessa os blocos seguintes. Deve ser chamado depois de ChainManager.InitializeFromBase.
+func (g *ValidatorGovernance) SetBase(ctx context.Context, height uint64, epochs []ValidatorSetEpoch) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.baseEpochs = make([]ValidatorSetEpoch, len(epochs))
+	for i, epoch := range epochs {
+		g.baseEpochs[i] = ValidatorSetEpoch{FromHeight: epoch.FromHeight, Validators: copyValidatorSet(epoch.Validators)}
+	}
+	g.baseHeight = height
+	g.hasBase = true
+
+	return g.rebuild(ctx)
+}
+
+// GetPendingProposals retorna as propostas que ainda não atingiram a maioria
+func (g *ValidatorGovernance) GetPendingProposals(ctx context.Context) []ValidatorProposalInfo {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	result := make([]ValidatorProposalInfo, 0, len(g.proposals))
+	for _, pending := range g.proposals {
+		approvals := make([]valueobjects.NodeID, 0, len(pending.approvals))
+		for id := range pending.approvals {
+			approvals = append(approvals, valueobjects.NewNodeID(id))
+		}
+		sort.Slice(approvals, func(i, j int) bool {
+			return approvals[i].String() < approvals[j].String()
+		})
+
+		result = append(result, ValidatorProposalInfo{
+			ProposalID:      pending.id,
+			Action:          pending.proposal.GetAction(),
+			NodeID:          pending.proposal.GetNodeID(),
+			EffectiveHeight: pending.proposal.GetEffectiveHeight(),
+			Proposer:        pending.proposal.GetProposer(),
+			IncludedAt:      pending.height,
+			Approvals:       approvals,
+			Required:        len(pending.electorate)/2 + 1,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].IncludedAt < result[j].IncludedAt
+	})
+
+	return result
+}
+
+// NewValidatorProposalTransaction assina uma proposta e a empacota em uma transação
+func NewValidatorProposalTransaction(ctx context.Context, cryptoService services.CryptographyService, proposal *entities.ValidatorProposal, privateKey *services.PrivateKey) (*entities.Transaction, error) {
+	signature, err := cryptoService.Sign(ctx, proposal.SigningBytes(), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign validator proposal: %w", err)
+	}
+	proposal.SetSignature(signature)
+
+	data, err := proposal.ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize validator proposal: %w", err)
+	}
+
+	return newValidatorTransaction(ctx, cryptoService, proposal.GetProposer(), proposal.GetNodeID(), data, privateKey)
+}
+
+// NewValidatorApprovalTransaction assina uma aprovação e a empacota em uma transação
+func NewValidatorApprovalTransaction(ctx context.Context, cryptoService services.CryptographyService, approval *entities.ValidatorApproval, privateKey *services.PrivateKey) (*entities.Transaction, error) {
+	signature, err := cryptoService.Sign(ctx, approval.SigningBytes(), privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign validator approval: %w", err)
+	}
+	approval.SetSignature(signature)
+
+	data, err := approval.ToBytes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize validator approval: %w", err)
+	}
+
+	return newValidatorTransaction(ctx, cryptoService, approval.GetValidator(), approval.GetValidator(), data, privateKey)
+}
+
+// newValidatorTransaction cria a transação VALIDATOR com hash e assinatura
+func newValidatorTransaction(ctx context.Context, cryptoService services.CryptographyService, from, to valueobjects.NodeID, data []byte, privateKey *services.PrivateKey) (*entities.Transaction, error) {
+	tx := entities.NewTransaction(entities.ValidatorTransaction, from, to, data)
+	txHash := cryptoService.HashTransaction(ctx, data)
+	tx.SetHash(txHash)
+	tx.SetID(txHash)
+
+	signature, err := cryptoService.Sign(ctx, data, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	tx.SetSignature(signature)
+
+	return tx, nil
+}
+
+// handleBlock processa um bloco aplicado à cadeia canônica
+func (g *ValidatorGovernance) handleBlock(ctx context.Context, block *entities.Block) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	index := block.GetIndex()
+
+	switch {
+	case !g.hasProcessed && index == 0, g.hasProcessed && index == g.processedHeight+1:
+		g.advance(ctx, block)
+	case g.hasProcessed && index <= g.processedHeight:
+		// Bloco já processado (ou parte de uma reorganização, tratada pelo listener de reorg)
+	default:
+		if err := g.rebuild(ctx); err != nil {
+			g.logger.ErrorContext(ctx, "failed to rebuild validator set", logging.Err(err))
+		}
+	}
+}
+
+// handleReorg reconstrói o conjunto de validadores após uma reorganização
+func (g *ValidatorGovernance) handleReorg(ctx context.Context, event *blockchain.ReorgEvent) {
+	if err := g.Rebuild(ctx); err != nil {
+		g.logger.ErrorContext(ctx, "failed to rebuild validator set after reorg", logging.Err(err))
+	}
+}
+
+// rebuild reprocessa a cadeia canônica (deve ser chamado com lock)
+func (g *ValidatorGovernance) rebuild(ctx context.Context) error {
+	g.proposals = make(map[string]*pendingValidatorProposal)
+	g.changes = make([]approvedValidatorChange, 0)
+	g.hasProcessed = false
+	g.processedHeight = 0
+	g.validatorManager.SetValidatorSchedule(nil)
+
+	height, err := g.chainManager.GetChainHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get chain height: %w", err)
+	}
+
+	start := uint64(0)
+	if g.hasBase {
+		g.processedHeight = g.baseHeight
+		g.hasProcessed = true
+		start = g.baseHeight + 1
+	}
+
+	for index := start; index <= height; index++ {
+		block, err := g.chainManager.GetBlockByIndex(ctx, index)
+		if err != nil {
+			if index == 0 {
+				// Cadeia ainda vazia
+				g.apply(ctx, 0)
+				return nil
+			}
+			return fmt.Errorf("failed to get block %d: %w", index, err)
+		}
+
+		g.advance(ctx, block)
+	}
+
+	// Base do snapshot no topo da cadeia: nenhum bloco a reprocessar
+	if start > height {
+		g.apply(ctx, height)
+	}
+
+	return nil
+}
+
+// advance processa um bloco e ativa o conjunto vigente para a altura seguinte, para que as
+// propostas do próximo bloco sejam julgadas pelo conjunto daquela altura tanto no
+// recebimento de blocos quanto na reconstrução (deve ser chamado com lock)
+func (g *ValidatorGovernance) advance(ctx context.Context, block *entities.Block) {
+	g.processBlock(ctx, block)
+	g.apply(ctx, block.GetIndex())
+}
+
+// processBlock aplica as transações de validador de um bloco (deve ser chamado com lock)
+func (g *ValidatorGovernance) processBlock(ctx context.Context, block *entities.Block) {
+	height := block.GetIndex()
+
+	// Propostas cuja altura efetiva já passou não podem mais ser aprovadas
+	for id, pending := range g.proposals {
+		if pending.proposal.GetEffectiveHeight() <= height {
+			g.logger.InfoContext(ctx, "validator proposal expired without majority", "proposal", id, logging.Height(height))
+			delete(g.proposals, id)
+		}
+	}
+
+	for _, tx := range block.GetTransactions() {
+		if tx.GetType() != entities.ValidatorTransaction {
+			continue
+		}
+
+		proposal, approval, err := entities.ParseValidatorTransaction(tx.GetData())
+		if err != nil {
+			g.logger.WarnContext(ctx, "ignoring malformed validator transaction", logging.Height(height), logging.TxHash(tx.GetHash()), logging.Err(err))
+			continue
+		}
+
+		if proposal != nil {
+			err = g.processProposal(ctx, tx.GetHash(), proposal, height)
+		} else {
+			err = g.processApproval(ctx, approval, height)
+		}
+
+		if err != nil {
+			g.logger.WarnContext(ctx, "ignoring validator transaction", logging.Height(height), logging.TxHash(tx.GetHash()), logging.Err(err))
+		}
+	}
+
+	g.processedHeight = height
+	g.hasProcessed = true
+}
+
+// processProposal registra uma proposta incluída na cadeia (deve ser chamado com lock)
+func (g *ValidatorGovernance) processProposal(ctx context.Context, id valueobjects.Hash, proposal *entities.ValidatorProposal, height uint64) error {
+	if !proposal.IsValid() {
+		return errors.New("invalid validator proposal")
+	}
+
+	if _, exists := g.proposals[id.String()]; exists {
+		return errors.New("duplicate validator proposal")
+	}
+
+	if proposal.GetEffectiveHeight() <= height {
+		return fmt.Errorf("effective height %d is not after inclusion height %d", proposal.GetEffectiveHeight(), height)
+	}
+
+	electorate := g.validatorManager.GetValidatorSetAtHeight(ctx, height)
+	if err := g.verifySigner(ctx, electorate, proposal.GetProposer(), proposal.SigningBytes(), proposal.GetSignature()); err != nil {
+		return err
+	}
+
+	_, isMember := electorate[proposal.GetNodeID().String()]
+
+	switch proposal.GetAction() {
+	case entities.ValidatorActionAdd:
+		if isMember {
+			return fmt.Errorf("node %s is already a validator", proposal.GetNodeID().ShortString())
+		}
+
+		publicKey, err := proposalPublicKey(proposal)
+		if err != nil {
+			return err
+		}
+
+		// O NodeID deve corresponder à chave proposta
+		if !g.cryptoService.GenerateNodeID(ctx, publicKey).Equals(proposal.GetNodeID()) {
+			return errors.New("node ID does not match proposed public key")
+		}
+	case entities.ValidatorActionRemove:
+		if !isMember {
+			return fmt.Errorf("node %s is not a validator", proposal.GetNodeID().ShortString())
+		}
+
+		if len(electorate) <= 1 {
+			return errors.New("cannot remove the last validator")
+		}
+	}
+
+	pending := &pendingValidatorProposal{
+		id:         id,
+		proposal:   proposal,
+		electorate: electorate,
+		approvals:  map[string]bool{proposal.GetProposer().String(): true},
+		height:     height,
+	}
+
+	g.proposals[id.String()] = pending
+	g.checkQuorum(pending, height)
+
+	return nil
+}
+
+// processApproval registra a aprovação de uma proposta pendente (deve ser chamado com lock)
+func (g *ValidatorGovernance) processApproval(ctx context.Context, approval *entities.ValidatorApproval, height uint64) error {
+	if !approval.IsValid() {
+		return errors.New("invalid validator approval")
+	}
+
+	pending, exists := g.proposals[approval.GetProposalID().String()]
+	if !exists {
+		return errors.New("unknown or already decided proposal")
+	}
+
+	signer := approval.GetValidator().String()
+	if pending.approvals[signer] {
+		return errors.New("duplicate approval")
+	}
+
+	if err := g.verifySigner(ctx, pending.electorate, approval.GetValidator(), approval.SigningBytes(), approval.GetSignature()); err != nil {
+		return err
+	}
+
+	pending.approvals[signer] = true
+	g.checkQuorum(pending, height)
+
+	return nil
+}
+
+// checkQuorum aprova a proposta quando a maioria do eleitorado a assinou (deve ser chamado com lock)
+func (g *ValidatorGovernance) checkQuorum(pending *pendingValidatorProposal, height uint64) {
+	if len(pending.approvals)*2 <= len(pending.electorate) {
+		return
+	}
+
+	var publicKey *services.PublicKey
+	if pending.proposal.GetAction() == entities.ValidatorActionAdd {
+		publicKey, _ = proposalPublicKey(pending.proposal)
+	}
+
+	g.changes = append(g.changes, approvedValidatorChange{
+		proposal:   pending.proposal,
+		publicKey:  publicKey,
+		approvedAt: height,
+		sequence:   len(g.changes),
+	})
+	delete(g.proposals, pending.id.String())
+
+	g.logger.Info("validator change approved",
+		"action", pending.proposal.GetAction(),
+		"validator", pending.proposal.GetNodeID().String(),
+		"effective_height", pending.proposal.GetEffectiveHeight(),
+		"approvals", len(pending.approvals),
+		"electorate", len(pending.electorate),
+		logging.Height(height))
+}
+
+// proposalPublicKey reconstrói a chave pública de uma proposta de adição
+func proposalPublicKey(proposal *entities.ValidatorProposal) (*services.PublicKey, error) {
+	publicKey := &services.PublicKey{}
+	if err := publicKey.FromBytes(proposal.GetPublicKey(), proposal.GetCurve()); err != nil || !publicKey.IsValid() {
+		return nil, errors.New("invalid public key in proposal")
+	}
+
+	return publicKey, nil
+}
+
+// verifySigner verifica se o signatário pertence ao eleitorado e se a assinatura é válida
+func (g *ValidatorGovernance) verifySigner(ctx context.Context, electorate map[string]*services.PublicKey, signer valueobjects.NodeID, data []byte, signature valueobjects.Signature) error {
+	publicKey, exists := electorate[signer.String()]
+	if !exists || publicKey == nil {
+		return fmt.Errorf("signer %s is not a validator", signer.ShortString())
+	}
+
+	valid, err := g.cryptoService.Verify(ctx, data, signature, publicKey)
+	if err != nil {
+		return fmt.Errorf("failed to verify signature: %w", err)
+	}
+
+	if !valid {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
+
+// apply recalcula as épocas a partir das alterações aprovadas e ativa o conjunto
+// vigente para a próxima altura (deve ser chamado com lock)
+func (g *ValidatorGovernance) apply(ctx context.Context, height uint64) {
+	changes := make([]approvedValidatorChange, len(g.changes))
+	copy(changes, g.changes)
+	sort.SliceStable(changes, func(i, j int) bool {
+		a, b := changes[i].proposal.GetEffectiveHeight(), changes[j].proposal.GetEffectiveHeight()
+		if a != b {
+			return a < b
+		}
+		return changes[i].sequence < changes[j].sequence
+	})
+
+	current := g.validatorManager.GetGenesisValidatorSet(ctx)
+	epochs := make([]ValidatorSetEpoch, 0, len(g.baseEpochs)+len(changes))
+
+	// Alterações anteriores à base vêm do snapshot
+	for _, epoch := range g.baseEpochs {
+		current = copyValidatorSet(epoch.Validators)
+		epochs = append(epochs, ValidatorSetEpoch{FromHeight: epoch.FromHeight, Validators: current})
+	}
+
+	for _, change := range changes {
+		next := copyValidatorSet(current)
+		nodeID := change.proposal.GetNodeID().String()
+
+		switch change.proposal.GetAction() {
+		case entities.ValidatorActionAdd:
+			if _, exists := next[nodeID]; exists {
+				continue
+			}
+			next[nodeID] = change.publicKey
+		case entities.ValidatorActionRemove:
+			if _, exists := next[nodeID]; !exists || len(next) <= 1 {
+				continue
+			}
+			delete(next, nodeID)
+		}
+
+		fromHeight := change.proposal.GetEffectiveHeight()
+		if len(epochs) > 0 && epochs[len(epochs)-1].FromHeight == fromHeight {
+			epochs[len(epochs)-1].Validators = next
+		} else {
+			epochs = append(epochs, ValidatorSetEpoch{FromHeight: fromHeight, Validators: next})
+		}
+		current = next
+	}
+
+	g.validatorManager.SetValidatorSchedule(epochs)
+	g.validatorManager.ActivateHeight(ctx, height+1)
+}
@@ -0,0 +1,146 @@
+package consensus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// appendTransactions adiciona à cadeia um bloco do validador 0 com as transações informadas
+func (c *testChain) appendTransactions(t *testing.T, txs ...*entities.Transaction) {
+	t.Helper()
+	ctx := context.Background()
+
+	block, err := c.chainManager.ProposeBlock(ctx, txs, c.nodeIDs[0], c.keyPairs[0].PrivateKey)
+	if err != nil {
+		t.Fatalf("propose block: %v", err)
+	}
+	if err := c.chainManager.AddBlock(ctx, block); err != nil {
+		t.Fatalf("add block %d: %v", block.GetIndex(), err)
+	}
+}
+
+// proposeAddition cria a transação do validador i propondo um novo validador a partir de effectiveHeight
+func (c *testChain) proposeAddition(t *testing.T, i int, effectiveHeight uint64) (*entities.Transaction, valueobjects.NodeID, *services.KeyPair) {
+	t.Helper()
+	ctx := context.Background()
+
+	candidate, err := c.cryptoService.GenerateKeyPair(ctx)
+	if err != nil {
+		t.Fatalf("generate candidate key pair: %v", err)
+	}
+	nodeID := c.cryptoService.GenerateNodeID(ctx, candidate.PublicKey)
+	proposal := entities.NewValidatorProposal(entities.ValidatorActionAdd, nodeID, candidate.PublicKey.ToBytes(),
+		candidate.PublicKey.Curve, effectiveHeight, c.nodeIDs[i])
+	tx, err := NewValidatorProposalTransaction(ctx, c.cryptoService, proposal, c.keyPairs[i].PrivateKey)
+	if err != nil {
+		t.Fatalf("create proposal: %v", err)
+	}
+	return tx, nodeID, candidate
+}
+
+// approve cria a transação de aprovação da proposta assinada com a chave informada
+func (c *testChain) approve(t *testing.T, proposal *entities.Transaction, validator valueobjects.NodeID, privateKey *services.PrivateKey) *entities.Transaction {
+	t.Helper()
+
+	tx, err := NewValidatorApprovalTransaction(context.Background(), c.cryptoService,
+		entities.NewValidatorApproval(proposal.GetHash(), validator), privateKey)
+	if err != nil {
+		t.Fatalf("create approval: %v", err)
+	}
+	return tx
+}
+
+func TestGovernanceApprovesWithMajorityAtEffectiveHeight(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 4)
+	governance := NewValidatorGovernance(chain.validatorManager, chain.chainManager, chain.cryptoService)
+	chain.sealGenesis(t)
+
+	proposal, candidate, _ := chain.proposeAddition(t, 0, 5)
+	chain.appendTransactions(t, proposal)
+
+	// Proponente e mais um: 2 de 4 não é maioria
+	chain.appendTransactions(t, chain.approve(t, proposal, chain.nodeIDs[1], chain.keyPairs[1].PrivateKey))
+	if pending := governance.GetPendingProposals(ctx); len(pending) != 2 {
+		t.Fatalf("pending proposals = %d, want the genesis proposal and the new one", len(pending))
+	}
+
+	chain.appendTransactions(t, chain.approve(t, proposal, chain.nodeIDs[2], chain.keyPairs[2].PrivateKey))
+	for _, pending := range governance.GetPendingProposals(ctx) {
+		if pending.ProposalID.Equals(proposal.GetHash()) {
+			t.Fatalf("proposal still pending with 3 of 4 approvals")
+		}
+	}
+
+	// A alteração só vale a partir da altura efetiva
+	if _, member := chain.validatorManager.GetValidatorSetAtHeight(ctx, 4)[candidate.String()]; member {
+		t.Errorf("candidate is a validator before the effective height")
+	}
+	if _, member := chain.validatorManager.GetValidatorSetAtHeight(ctx, 5)[candidate.String()]; !member {
+		t.Errorf("candidate is not a validator at the effective height")
+	}
+
+	// Reconstruir a partir da cadeia chega às mesmas épocas
+	schedule := chain.validatorManager.GetValidatorSchedule(ctx)
+	if err := governance.Rebuild(ctx); err != nil {
+		t.Fatalf("Rebuild: %v", err)
+	}
+	rebuilt := chain.validatorManager.GetValidatorSchedule(ctx)
+	if len(schedule) != 1 || len(rebuilt) != 1 || rebuilt[0].FromHeight != 5 || len(rebuilt[0].Validators) != 5 {
+		t.Errorf("schedule = %+v, rebuilt = %+v; want one epoch of 5 validators from height 5", schedule, rebuilt)
+	}
+}
+
+func TestGovernanceIgnoresApprovalsOutsideTheElectorate(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 4)
+	governance := NewValidatorGovernance(chain.validatorManager, chain.chainManager, chain.cryptoService)
+	chain.sealGenesis(t)
+
+	proposal, candidate, candidateKeys := chain.proposeAddition(t, 0, 10)
+	chain.appendTransactions(t, proposal)
+
+	// O próprio candidato e aprovações repetidas não contam
+	chain.appendTransactions(t,
+		chain.approve(t, proposal, candidate, candidateKeys.PrivateKey),
+		chain.approve(t, proposal, chain.nodeIDs[0], chain.keyPairs[0].PrivateKey))
+	// Aprovação em nome de outro validador
+	chain.appendTransactions(t, chain.approve(t, proposal, chain.nodeIDs[1], chain.keyPairs[3].PrivateKey))
+
+	for _, pending := range governance.GetPendingProposals(ctx) {
+		if pending.ProposalID.Equals(proposal.GetHash()) {
+			if len(pending.Approvals) != 1 {
+				t.Errorf("approvals = %v, want only the proposer", pending.Approvals)
+			}
+			return
+		}
+	}
+	t.Fatalf("proposal no longer pending")
+}
+
+func TestGovernanceExpiresProposalsAtEffectiveHeight(t *testing.T) {
+	ctx := context.Background()
+	chain := newTestChain(t, 4)
+	governance := NewValidatorGovernance(chain.validatorManager, chain.chainManager, chain.cryptoService)
+	chain.sealGenesis(t)
+
+	proposal, candidate, _ := chain.proposeAddition(t, 0, 3)
+	chain.appendTransactions(t, proposal)
+	chain.appendTransactions(t, chain.approve(t, proposal, chain.nodeIDs[1], chain.keyPairs[1].PrivateKey))
+
+	// Aprovação que chegaria à maioria só na altura efetiva
+	chain.appendTransactions(t, chain.approve(t, proposal, chain.nodeIDs[2], chain.keyPairs[2].PrivateKey))
+
+	for _, pending := range governance.GetPendingProposals(ctx) {
+		if pending.ProposalID.Equals(proposal.GetHash()) {
+			t.Errorf("proposal still pending after its effective height")
+		}
+	}
+	if _, member := chain.validatorManager.GetValidatorSetAtHeight(ctx, 4)[candidate.String()]; member {
+		t.Errorf("expired proposal changed the validator set")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -47,15 +48,26 @@ type ValidatorManager struct {
 	penaltyDuration  time.Duration         // Duração da penalidade
 	maxPenalties     int                   // Máximo de penalidades antes de ban
 	
+	// Conjunto inicial configurado localmente (gênesis) e alterações aprovadas na cadeia
+	genesisKeys map[string]*services.PublicKey
+	schedule    []ValidatorSetEpoch
+	
 	// Mutex para operações thread-safe
 	mu sync.RWMutex
 }
 
+// ValidatorSetEpoch representa o conjunto de validadores vigente a partir de uma altura
+type ValidatorSetEpoch struct {
+	FromHeight uint64
+	Validators map[string]*services.PublicKey // NodeID -> chave pública
+}
+
 // NewValidatorManager cria um novo gerenciador de validadores
 func NewValidatorManager() *ValidatorManager {
 	return &ValidatorManager{
 		validators:      make(map[string]*Validator),
 		validatorOrder:  make([]valueobjects.NodeID, 0),
+		genesisKeys:     make(map[string]*services.PublicKey),
 		currentIndex:    0,
 		maxMissedRounds: 3,                    // 3 rounds perdidos = penalidade
 		penaltyDuration: time.Hour * 24,       // 24 horas de penalidade
@@ -98,6 +110,9 @@ func (vm *ValidatorManager) AddValidator(ctx context.Context, nodeID valueobject
 	// Adicionar aos mapas
 	vm.validators[nodeIDStr] = validator
 	vm.validatorOrder = append(vm.validatorOrder, nodeID)
+	
+	// Validadores configurados localmente formam o conjunto gênesis
+	vm.genesisKeys[nodeIDStr] = publicKey
 
 	return nil
 }
@@ -120,6 +135,7 @@ func (vm *ValidatorManager) RemoveValidator(ctx context.Context, nodeID valueobj
 
 	// Remover do mapa
 	delete(vm.validators, nodeIDStr)
+	delete(vm.genesisKeys, nodeIDStr)
 
 	// Remover da ordem
 	for i, id := range vm.validatorOrder {
@@ -229,16 +245,12 @@ func (vm *ValidatorManager) UpdateValidatorActivity(ctx context.Context, nodeID
 
 	validator.TotalRounds++
 	
+	// Apenas estatística local: penalidades por slots perdidos são derivadas da cadeia (PenaltyLedger)
 	if active {
 		validator.LastActiveAt = valueobjects.Now()
 		validator.MissedRounds = 0 // Reset contador de rounds perdidos
 	} else {
 		validator.MissedRounds++
-		
-		// Verificar se deve ser penalizado
-		if validator.MissedRounds >= vm.maxMissedRounds {
-			vm.penalizeValidator(validator)
-		}
 	}
 
 	return nil
@@ -246,6 +258,26 @@ func (vm *ValidatorManager) UpdateValidatorActivity(ctx context.Context, nodeID
 
 // PenalizeValidator aplica penalidade a um validador
 func (vm *ValidatorManager) PenalizeValidator(ctx context.Context, nodeID valueobjects.NodeID, reason string) error {
+	return vm.PenalizeValidatorAt(ctx, nodeID, reason, valueobjects.Now())
+}
+
+// PenalizeValidatorAt aplica penalidade a um validador a partir do instante informado
+// (timestamp do bloco com a evidência), para que todos os nós calculem a mesma expiração
+func (vm *ValidatorManager) PenalizeValidatorAt(ctx context.Context, nodeID valueobjects.NodeID, reason string, at valueobjects.Timestamp) error {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	validator, exists := vm.validators[nodeID.String()]
+	if !exists {
+		return fmt.Errorf("validator %s not found", nodeID.ShortString())
+	}
+
+	vm.penalizeValidator(validator, at)
+	return nil
+}
+
+// SuspendValidator mantém o validador penalizado até o instante informado
+func (vm *ValidatorManager) SuspendValidator(ctx context.Context, nodeID valueobjects.NodeID, until valueobjects.Timestamp) error {
 	vm.mu.Lock()
 	defer vm.mu.Unlock()
 
@@ -254,12 +286,32 @@ func (vm *ValidatorManager) PenalizeValidator(ctx context.Context, nodeID valueo
 		return fmt.Errorf("validator %s not found", nodeID.ShortString())
 	}
 
-	vm.penalizeValidator(validator)
+	if validator.Status == ValidatorBanned {
+		return nil
+	}
+
+	validator.Status = ValidatorPenalized
+	if until.After(validator.PenaltyExpiry) {
+		validator.PenaltyExpiry = until
+	}
+
 	return nil
 }
 
+// ResetPenalties limpa o estado de penalidade de todos os validadores (usado ao reprocessar a cadeia)
+func (vm *ValidatorManager) ResetPenalties(ctx context.Context) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	for _, validator := range vm.validators {
+		validator.Status = ValidatorActive
+		validator.PenaltyCount = 0
+		validator.PenaltyExpiry = valueobjects.Timestamp{}
+	}
+}
+
 // penalizeValidator aplica penalidade interna (deve ser chamado com lock)
-func (vm *ValidatorManager) penalizeValidator(validator *Validator) {
+func (vm *ValidatorManager) penalizeValidator(validator *Validator, at valueobjects.Timestamp) {
 	validator.PenaltyCount++
 	validator.MissedRounds = 0 // Reset contador
 	
@@ -269,7 +321,7 @@ func (vm *ValidatorManager) penalizeValidator(validator *Validator) {
 	} else {
 		// Penalidade temporária
 		validator.Status = ValidatorPenalized
-		validator.PenaltyExpiry = valueobjects.Now().Add(vm.penaltyDuration)
+		validator.PenaltyExpiry = at.Add(vm.penaltyDuration)
 	}
 }
 
@@ -329,6 +381,163 @@ func (vm *ValidatorManager) GetValidatorPublicKey(ctx context.Context, nodeID va
 	return validator.PublicKey, nil
 }
 
+// GetValidatorPublicKeyAtHeight retorna a chave pública de um validador autorizado na altura informada
+func (vm *ValidatorManager) GetValidatorPublicKeyAtHeight(ctx context.Context, nodeID valueobjects.NodeID, height uint64) (*services.PublicKey, error) {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
+	publicKey, exists := vm.validatorSetAt(height)[nodeID.String()]
+	if !exists {
+		return nil, fmt.Errorf("validator %s not in validator set at height %d", nodeID.ShortString(), height)
+	}
+
+	if publicKey == nil {
+		return nil, fmt.Errorf("validator %s has no public key", nodeID.ShortString())
+	}
+
+	return publicKey, nil
+}
+
+// GetValidatorSetAtHeight retorna o conjunto de validadores vigente na altura informada
+func (vm *ValidatorManager) GetValidatorSetAtHeight(ctx context.Context, height uint64) map[string]*services.PublicKey {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
+	return copyValidatorSet(vm.validatorSetAt(height))
+}
+
+// GetEligibleValidatorsAtHeight retorna o conjunto vigente na altura sem os validadores banidos
+func (vm *ValidatorManager) GetEligibleValidatorsAtHeight(ctx context.Context, height uint64) map[string]*services.PublicKey {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
+	eligible := copyValidatorSet(vm.validatorSetAt(height))
+	for id := range eligible {
+		if validator, exists := vm.validators[id]; exists && validator.Status == ValidatorBanned {
+			delete(eligible, id)
+		}
+	}
+	return eligible
+}
+
+// GetGenesisValidatorSet retorna o conjunto de validadores configurado localmente
+func (vm *ValidatorManager) GetGenesisValidatorSet(ctx context.Context) map[string]*services.PublicKey {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
+	return copyValidatorSet(vm.genesisKeys)
+}
+
+// SetValidatorSchedule substitui as alterações do conjunto de validadores derivadas da cadeia.
+// As épocas devem estar em ordem crescente de altura.
+func (vm *ValidatorManager) SetValidatorSchedule(epochs []ValidatorSetEpoch) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	vm.schedule = epochs
+}
+
+// GetValidatorSchedule retorna uma cópia das alterações do conjunto de validadores derivadas da cadeia
+func (vm *ValidatorManager) GetValidatorSchedule(ctx context.Context) []ValidatorSetEpoch {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
+	epochs := make([]ValidatorSetEpoch, len(vm.schedule))
+	for i, epoch := range vm.schedule {
+		epochs[i] = ValidatorSetEpoch{FromHeight: epoch.FromHeight, Validators: copyValidatorSet(epoch.Validators)}
+	}
+	return epochs
+}
+
+// ActivateHeight atualiza os validadores ativos para o conjunto vigente na altura informada
+// (normalmente a próxima altura a ser produzida). Estatísticas dos validadores mantidos são preservadas.
+func (vm *ValidatorManager) ActivateHeight(ctx context.Context, height uint64) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+
+	set := vm.validatorSetAt(height)
+
+	// Remover validadores que saíram do conjunto
+	order := make([]valueobjects.NodeID, 0, len(set))
+	for _, id := range vm.validatorOrder {
+		if _, exists := set[id.String()]; exists {
+			order = append(order, id)
+		} else {
+			delete(vm.validators, id.String())
+		}
+	}
+
+	// Adicionar validadores que entraram no conjunto, em ordem determinística
+	added := make([]string, 0)
+	for id := range set {
+		if _, exists := vm.validators[id]; !exists {
+			added = append(added, id)
+		}
+	}
+	sort.Strings(added)
+
+	now := valueobjects.Now()
+	for _, id := range added {
+		nodeID := valueobjects.NewNodeID(id)
+		vm.validators[id] = &Validator{
+			NodeID:       nodeID,
+			PublicKey:    set[id],
+			Status:       ValidatorActive,
+			AddedAt:      now,
+			LastActiveAt: now,
+		}
+		order = append(order, nodeID)
+	}
+
+	vm.validatorOrder = order
+	if vm.currentIndex >= len(vm.validatorOrder) {
+		vm.currentIndex = 0
+	}
+}
+
+// validatorSetAt retorna o conjunto vigente na altura (deve ser chamado com lock)
+func (vm *ValidatorManager) validatorSetAt(height uint64) map[string]*services.PublicKey {
+	set := vm.genesisKeys
+	for _, epoch := range vm.schedule {
+		if epoch.FromHeight > height {
+			break
+		}
+		set = epoch.Validators
+	}
+	return set
+}
+
+// copyValidatorSet copia um conjunto de validadores
+func copyValidatorSet(set map[string]*services.PublicKey) map[string]*services.PublicKey {
+	result := make(map[string]*services.PublicKey, len(set))
+	for id, publicKey := range set {
+		result[id] = publicKey
+	}
+	return result
+}
+
+// GetInTurnValidator retorna o validador da vez para uma altura.
+// Usa a ordem lexicográfica dos IDs de todos os validadores autorizados, de forma que
+// todos os nós cheguem ao mesmo resultado independentemente da ordem de cadastro.
+func (vm *ValidatorManager) GetInTurnValidator(ctx context.Context, height uint64) (valueobjects.NodeID, error) {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+
+	// Conjunto vigente na altura, para que a ordem acompanhe as alterações aprovadas na cadeia
+	set := vm.validatorSetAt(height)
+	if len(set) == 0 {
+		return valueobjects.EmptyNodeID(), errors.New("no validators configured")
+	}
+
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return valueobjects.NewNodeID(ids[height%uint64(len(ids))]), nil
+}
+
 // GetValidatorStats retorna estatísticas de um validador
 func (vm *ValidatorManager) GetValidatorStats(ctx context.Context, nodeID valueobjects.NodeID) (*ValidatorStats, error) {
 	vm.mu.RLock()
@@ -0,0 +1,699 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// EventType identifica o tipo de evento publicado para os assinantes
+type EventType string
+
+const (
+	// EventBlock novo bloco na cadeia canônica
+	EventBlock EventType = "block"
+	// EventTransaction nova transação aceita no mempool
+	EventTransaction EventType = "transaction"
+	// EventElectionStatus mudança de status de uma eleição (criação, início, encerramento)
+	EventElectionStatus EventType = "election_status"
+	// EventTally apuração parcial atualizada de uma eleição
+	EventTally EventType = "tally"
+	// EventReorg reorganização da cadeia canônica
+	EventReorg EventType = "reorg"
+)
+
+// ErrReplayTooLarge indica que a retomada pediria mais blocos do que o limite configurado
+var ErrReplayTooLarge = errors.New("requested resume height is too far behind the chain tip")
+
+// ErrEventExpired indica que o evento de retomada já saiu do histórico do hub ou é de outra execução do nó
+var ErrEventExpired = errors.New("requested event is no longer available, resume with from_height")
+
+// Event representa um evento entregue aos assinantes.
+// Height é a altura da cadeia associada ao evento; uma altura pode gerar vários eventos.
+// Sequence é o número do evento no hub, estritamente crescente, e serve de cursor exato
+// para retomada (zero nos eventos reconstruídos para o histórico de uma assinatura).
+type Event struct {
+	Type      EventType
+	Height    uint64
+	Sequence  uint64
+	Timestamp time.Time
+	Data      interface{}
+
+	// Eleições afetadas pelo evento (usado no filtro por eleição)
+	elections []string
+}
+
+// BlockEventData descreve um bloco aplicado à cadeia canônica
+type BlockEventData struct {
+	Index            uint64   `json:"index"`
+	Hash             string   `json:"hash"`
+	PreviousHash     string   `json:"previous_hash"`
+	Timestamp        int64    `json:"timestamp"`
+	Validator        string   `json:"validator"`
+	TransactionCount int      `json:"transaction_count"`
+	Transactions     []string `json:"transactions"`
+	Elections        []string `json:"elections,omitempty"`
+}
+
+// TransactionEventData descreve uma transação aceita no mempool
+type TransactionEventData struct {
+	Hash       string `json:"hash"`
+	Type       string `json:"type"`
+	From       string `json:"from"`
+	Timestamp  int64  `json:"timestamp"`
+	ElectionID string `json:"election_id,omitempty"`
+}
+
+// ElectionStatusEventData descreve a mudança de status de uma eleição
+type ElectionStatusEventData struct {
+	ElectionID     string `json:"election_id"`
+	Title          string `json:"title"`
+	PreviousStatus string `json:"previous_status,omitempty"` // Vazio na criação
+	Status         string `json:"status"`
+	StartTime      int64  `json:"start_time"`
+	EndTime        int64  `json:"end_time"`
+}
+
+// TallyEventData descreve a apuração parcial de uma eleição na cadeia canônica
+type TallyEventData struct {
+	ElectionID string            `json:"election_id"`
+	Results    map[string]uint64 `json:"results"`
+	TotalVotes uint64            `json:"total_votes"`
+}
+
+// ReorgEventData descreve uma reorganização da cadeia
+type ReorgEventData struct {
+	CommonAncestor uint64   `json:"common_ancestor"`
+	OldTip         string   `json:"old_tip"`
+	NewTip         string   `json:"new_tip"`
+	Removed        []string `json:"removed"`
+	Added          []string `json:"added"`
+}
+
+// EventHubConfig contém os limites do hub de eventos
+type EventHubConfig struct {
+	SubscriberBuffer int           // Eventos enfileirados por assinante antes de desconectá-lo
+	MaxReplayBlocks  int           // Blocos reenviados ao retomar a partir de uma altura
+	ReplayEvents     int           // Eventos recentes mantidos para retomar a partir de um evento
+	StatusInterval   time.Duration // Intervalo de verificação de status das eleições e da cadeia
+}
+
+// DefaultEventHubConfig retorna a configuração padrão do hub de eventos
+func DefaultEventHubConfig() *EventHubConfig {
+	return &EventHubConfig{
+		SubscriberBuffer: 256,
+		MaxReplayBlocks:  1000,
+		ReplayEvents:     4096,
+		StatusInterval:   time.Second,
+	}
+}
+
+// electionState guarda o status efetivo e a apuração de uma eleição
+type electionState struct {
+	election   *entities.Election
+	status     entities.ElectionStatus
+	results    map[string]uint64
+	totalVotes uint64
+}
+
+// Subscription representa um assinante do hub de eventos
+type Subscription struct {
+	id         uint64
+	electionID string
+	events     chan Event
+	hub        *EventHub
+
+	// Sequência do último evento publicado antes da assinatura
+	cursor uint64
+}
+
+// Events retorna o canal de eventos (fechado quando a assinatura termina)
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Cursor retorna a sequência do último evento publicado antes da assinatura; os eventos
+// ao vivo da assinatura vêm depois dele
+func (s *Subscription) Cursor() uint64 {
+	return s.cursor
+}
+
+// Close cancela a assinatura
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s.id)
+}
+
+// matches verifica se o evento passa pelo filtro de eleição da assinatura
+func (s *Subscription) matches(event Event) bool {
+	if s.electionID == "" || event.Type == EventReorg {
+		return true
+	}
+	for _, electionID := range event.elections {
+		if electionID == s.electionID {
+			return true
+		}
+	}
+	return false
+}
+
+// EventHub distribui a atividade da cadeia para assinantes (SSE e WebSocket).
+// Os blocos são lidos da cadeia canônica em ordem de altura sempre que um gatilho
+// (bloco produzido, bloco recebido por P2P ou verificação periódica) é disparado,
+// de forma que blocos aplicados pela sincronização também são publicados.
+// Status e apuração das eleições são derivados das transações dos blocos.
+type EventHub struct {
+	chainManager *blockchain.ChainManager
+	config       *EventHubConfig
+
+	subscribers map[uint64]*Subscription
+	nextID      uint64
+
+	// Sequência do último evento publicado e os eventos recentes, em ordem, para retomada
+	sequence uint64
+	recent   []Event
+
+	// Último bloco publicado
+	lastHeight uint64
+	lastHash   valueobjects.Hash
+	hasLast    bool
+
+	elections map[string]*electionState
+
+	logger *slog.Logger
+
+	// Mutex para operações thread-safe
+	mu sync.Mutex
+}
+
+// NewEventHub cria o hub de eventos e o registra para reorganizações da cadeia
+func NewEventHub(chainManager *blockchain.ChainManager, config *EventHubConfig) *EventHub {
+	if config == nil {
+		config = DefaultEventHubConfig()
+	}
+
+	hub := &EventHub{
+		chainManager: chainManager,
+		config:       config,
+		subscribers:  make(map[uint64]*Subscription),
+		elections:    make(map[string]*electionState),
+		logger:       logging.Component("events"),
+		// Iniciar pelo horário faz os ids de execuções anteriores do nó ficarem abaixo
+		// dos desta, sendo recusados em vez de apontarem para outros eventos
+		sequence: uint64(time.Now().UnixMicro()),
+	}
+
+	chainManager.AddReorgListener(hub.handleReorg)
+
+	return hub
+}
+
+// SetLogger define o logger do hub de eventos
+func (h *EventHub) SetLogger(logger *slog.Logger) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.logger = logger
+}
+
+// Rebuild carrega o estado das eleições a partir da cadeia canônica sem publicar eventos
+func (h *EventHub) Rebuild(ctx context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	height, err := h.chainManager.GetChainHeight(ctx)
+	if err != nil {
+		return err
+	}
+
+	h.rebuild(ctx, height)
+	return nil
+}
+
+// Start inicia a verificação periódica de novos blocos e de mudanças de status das eleições
+func (h *EventHub) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(h.config.StatusInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				h.mu.Lock()
+				h.catchUp(ctx)
+				h.refreshStatuses()
+				h.mu.Unlock()
+			}
+		}
+	}()
+}
+
+// HandleBlock publica os blocos canônicos ainda não publicados até o bloco informado.
+// Usado como callback de bloco produzido (consenso) e de bloco recebido (P2P).
+func (h *EventHub) HandleBlock(block *entities.Block) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.catchUp(context.Background())
+}
+
+// HandleTransaction publica uma transação aceita no mempool
+func (h *EventHub) HandleTransaction(tx *entities.Transaction) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	data := TransactionEventData{
+		Hash:      tx.GetHash().String(),
+		Type:      string(tx.GetType()),
+		From:      tx.GetFrom().String(),
+		Timestamp: tx.GetTimestamp().Unix(),
+	}
+
+	var elections []string
+	if electionID, ok := transactionElection(tx); ok {
+		data.ElectionID = electionID
+		elections = []string{electionID}
+	}
+
+	h.publish(Event{
+		Type:      EventTransaction,
+		Height:    h.lastHeight,
+		Timestamp: time.Now(),
+		Data:      data,
+		elections: elections,
+	})
+}
+
+// Subscribe registra um assinante, opcionalmente filtrado por eleição.
+// Com fromHeight, retorna também os blocos canônicos desde essa altura (inclusive)
+// até o último bloco publicado; eventos ao vivo só chegam após esse histórico.
+// Assinaturas filtradas por eleição recebem ainda o status e a apuração atuais.
+func (h *EventHub) Subscribe(ctx context.Context, electionID string, fromHeight *uint64) (*Subscription, []Event, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscription := h.newSubscription(electionID)
+
+	var backlog []Event
+	if fromHeight != nil && h.hasLast && *fromHeight <= h.lastHeight {
+		if h.lastHeight-*fromHeight >= uint64(h.config.MaxReplayBlocks) {
+			return nil, nil, ErrReplayTooLarge
+		}
+
+		for index := *fromHeight; index <= h.lastHeight; index++ {
+			block, err := h.chainManager.GetBlockByIndex(ctx, index)
+			if err != nil {
+				continue
+			}
+			if event := h.blockEvent(ctx, block); subscription.matches(event) {
+				backlog = append(backlog, event)
+			}
+		}
+	}
+
+	if state, exists := h.elections[electionID]; exists {
+		backlog = append(backlog, h.statusEvent(state, ""), h.tallyEvent(electionID, state))
+	}
+
+	h.subscribers[subscription.id] = subscription
+
+	return subscription, backlog, nil
+}
+
+// SubscribeAfter registra um assinante que retoma após o evento de sequência informada
+// (exclusive): o histórico são os eventos publicados depois dele, na ordem original.
+// Retorna ErrEventExpired se o evento não estiver mais entre os recentes.
+func (h *EventHub) SubscribeAfter(electionID string, sequence uint64) (*Subscription, []Event, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if sequence > h.sequence {
+		return nil, nil, ErrEventExpired
+	}
+	if sequence < h.sequence && (len(h.recent) == 0 || h.recent[0].Sequence > sequence+1) {
+		return nil, nil, ErrEventExpired
+	}
+
+	subscription := h.newSubscription(electionID)
+
+	var backlog []Event
+	for _, event := range h.recent {
+		if event.Sequence > sequence && subscription.matches(event) {
+			backlog = append(backlog, event)
+		}
+	}
+
+	h.subscribers[subscription.id] = subscription
+
+	return subscription, backlog, nil
+}
+
+// newSubscription cria um assinante posicionado após o último evento publicado (deve ser chamado com lock)
+func (h *EventHub) newSubscription(electionID string) *Subscription {
+	subscription := &Subscription{
+		id:         h.nextID,
+		electionID: electionID,
+		events:     make(chan Event, h.config.SubscriberBuffer),
+		hub:        h,
+		cursor:     h.sequence,
+	}
+	h.nextID++
+
+	return subscription
+}
+
+// SubscriberCount retorna o número de assinantes conectados
+func (h *EventHub) SubscriberCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return len(h.subscribers)
+}
+
+// unsubscribe remove o assinante e fecha seu canal
+func (h *EventHub) unsubscribe(id uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.removeSubscriber(id)
+}
+
+// removeSubscriber remove o assinante (deve ser chamado com lock)
+func (h *EventHub) removeSubscriber(id uint64) {
+	if subscription, exists := h.subscribers[id]; exists {
+		delete(h.subscribers, id)
+		close(subscription.events)
+	}
+}
+
+// publish numera o evento, guarda-o entre os recentes e o entrega aos assinantes
+// interessados (deve ser chamado com lock)
+func (h *EventHub) publish(event Event) {
+	h.sequence++
+	event.Sequence = h.sequence
+
+	h.recent = append(h.recent, event)
+	if excess := len(h.recent) - h.config.ReplayEvents; excess > 0 {
+		h.recent = h.recent[excess:]
+	}
+
+	for id, subscription := range h.subscribers {
+		if !subscription.matches(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			// Canal cheio, desconectar o assinante lento (o cliente retoma pelo último evento)
+			h.logger.Warn("event subscriber too slow, disconnecting", "subscriber", id)
+			h.removeSubscriber(id)
+		}
+	}
+}
+
+// handleReorg publica a reorganização, recalcula o estado das eleições até o ancestral
+// comum e republica os blocos da nova ramificação
+func (h *EventHub) handleReorg(ctx context.Context, event *blockchain.ReorgEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	data := ReorgEventData{
+		CommonAncestor: event.CommonAncestor,
+		OldTip:         event.OldTip.String(),
+		NewTip:         event.NewTip.String(),
+		Removed:        make([]string, len(event.Removed)),
+		Added:          make([]string, len(event.Added)),
+	}
+
+	// Eleições cuja apuração pode ter mudado com os blocos desfeitos
+	touched := make(map[string]bool)
+	for i, block := range event.Removed {
+		data.Removed[i] = h.chainManager.CalculateBlockHash(ctx, block).String()
+		for _, tx := range block.GetTransactions() {
+			if electionID, ok := transactionElection(tx); ok {
+				touched[electionID] = true
+			}
+		}
+	}
+	for i, block := range event.Added {
+		data.Added[i] = h.chainManager.CalculateBlockHash(ctx, block).String()
+	}
+
+	h.publish(Event{
+		Type:      EventReorg,
+		Height:    event.CommonAncestor,
+		Timestamp: time.Now(),
+		Data:      data,
+	})
+
+	h.rebuild(ctx, event.CommonAncestor)
+	h.catchUp(ctx)
+
+	for electionID := range touched {
+		if state, exists := h.elections[electionID]; exists {
+			h.publish(h.tallyEvent(electionID, state))
+		}
+	}
+}
+
+// rebuild recalcula o estado das eleições até a altura informada (deve ser chamado com lock)
+func (h *EventHub) rebuild(ctx context.Context, height uint64) {
+	h.elections = make(map[string]*electionState)
+	h.hasLast = false
+	h.lastHeight = 0
+	h.lastHash = valueobjects.Hash{}
+
+	// Cadeia iniciada por snapshot: eleições e apurações até a base vêm do snapshot
+	start := uint64(0)
+	if base, ok := h.chainManager.GetBase(); ok {
+		now := valueobjects.Now()
+		for _, entry := range base.Elections {
+			results := make(map[string]uint64, len(entry.Results))
+			for candidateID, count := range entry.Results {
+				results[candidateID] = count
+			}
+			h.elections[entry.Election.GetID().String()] = &electionState{
+				election:   entry.Election,
+				status:     entry.Election.EffectiveStatus(now),
+				results:    results,
+				totalVotes: entry.TotalVotes,
+			}
+		}
+		h.lastHeight = base.Height
+		h.lastHash = base.Hash
+		h.hasLast = true
+		start = base.Height + 1
+	}
+
+	for index := start; index <= height; index++ {
+		block, err := h.chainManager.GetBlockByIndex(ctx, index)
+		if err != nil {
+			break
+		}
+		h.applyBlock(block, false)
+		h.lastHeight = index
+		h.lastHash = h.chainManager.CalculateBlockHash(ctx, block)
+		h.hasLast = true
+	}
+}
+
+// catchUp publica os blocos canônicos acima do último publicado (deve ser chamado com lock)
+func (h *EventHub) catchUp(ctx context.Context) {
+	height, err := h.chainManager.GetChainHeight(ctx)
+	if err != nil {
+		return
+	}
+
+	next := uint64(0)
+	if h.hasLast {
+		// Reorganização em andamento: aguardar o listener de reorg
+		current, err := h.chainManager.GetBlockByIndex(ctx, h.lastHeight)
+		if err != nil || !h.chainManager.CalculateBlockHash(ctx, current).Equals(h.lastHash) {
+			return
+		}
+		next = h.lastHeight + 1
+	}
+
+	for index := next; index <= height; index++ {
+		block, err := h.chainManager.GetBlockByIndex(ctx, index)
+		if err != nil {
+			return
+		}
+
+		event := h.blockEvent(ctx, block)
+		h.publish(event)
+
+		changed := h.applyBlock(block, true)
+		h.lastHeight = index
+		h.lastHash = h.chainManager.CalculateBlockHash(ctx, block)
+		h.hasLast = true
+
+		for _, electionID := range changed {
+			h.publish(h.tallyEvent(electionID, h.elections[electionID]))
+		}
+	}
+}
+
+// applyBlock aplica eleições e votos do bloco ao estado, publicando as mudanças de status se notify.
+// Retorna as eleições cuja apuração mudou (deve ser chamado com lock).
+func (h *EventHub) applyBlock(block *entities.Block, notify bool) []string {
+	var changed []string
+	counted := make(map[string]bool)
+
+	for _, tx := range block.GetTransactions() {
+		switch tx.GetType() {
+		case entities.ElectionTransaction:
+			election := &entities.Election{}
+			if err := election.FromBytes(tx.GetData()); err != nil {
+				continue
+			}
+
+			electionID := election.GetID().String()
+			state, exists := h.elections[electionID]
+			if !exists {
+				state = &electionState{results: make(map[string]uint64)}
+				h.elections[electionID] = state
+			}
+
+			previous := state.status
+			state.election = election
+			state.status = election.EffectiveStatus(valueobjects.Now())
+			if notify && state.status != previous {
+				h.publish(h.statusEvent(state, previous))
+			}
+
+		case entities.VoteTransaction:
+			vote := &entities.Vote{}
+			if err := vote.FromBytes(tx.GetData()); err != nil || !vote.IsValid() {
+				continue
+			}
+
+			electionID := vote.GetElectionID().String()
+			state, exists := h.elections[electionID]
+			if !exists {
+				continue
+			}
+
+			state.results[vote.GetCandidateID()]++
+			state.totalVotes++
+			if !counted[electionID] {
+				counted[electionID] = true
+				changed = append(changed, electionID)
+			}
+		}
+	}
+
+	return changed
+}
+
+// refreshStatuses publica as eleições que iniciaram ou encerraram pelo horário (deve ser chamado com lock)
+func (h *EventHub) refreshStatuses() {
+	now := valueobjects.Now()
+	for _, state := range h.elections {
+		status := state.election.EffectiveStatus(now)
+		if status == state.status {
+			continue
+		}
+
+		previous := state.status
+		state.status = status
+		h.publish(h.statusEvent(state, previous))
+	}
+}
+
+// blockEvent monta o evento de um bloco
+func (h *EventHub) blockEvent(ctx context.Context, block *entities.Block) Event {
+	transactions := block.GetTransactions()
+	data := BlockEventData{
+		Index:            block.GetIndex(),
+		Hash:             h.chainManager.CalculateBlockHash(ctx, block).String(),
+		PreviousHash:     block.GetPreviousHash().String(),
+		Timestamp:        block.GetTimestamp().Unix(),
+		Validator:        block.GetValidator().String(),
+		TransactionCount: len(transactions),
+		Transactions:     make([]string, len(transactions)),
+	}
+
+	seen := make(map[string]bool)
+	for i, tx := range transactions {
+		data.Transactions[i] = tx.GetHash().String()
+		if electionID, ok := transactionElection(tx); ok && !seen[electionID] {
+			seen[electionID] = true
+			data.Elections = append(data.Elections, electionID)
+		}
+	}
+
+	return Event{
+		Type:      EventBlock,
+		Height:    block.GetIndex(),
+		Timestamp: time.Now(),
+		Data:      data,
+		elections: data.Elections,
+	}
+}
+
+// statusEvent monta o evento de status de uma eleição
+func (h *EventHub) statusEvent(state *electionState, previous entities.ElectionStatus) Event {
+	electionID := state.election.GetID().String()
+	return Event{
+		Type:      EventElectionStatus,
+		Height:    h.lastHeight,
+		Timestamp: time.Now(),
+		Data: ElectionStatusEventData{
+			ElectionID:     electionID,
+			Title:          state.election.GetTitle(),
+			PreviousStatus: string(previous),
+			Status:         string(state.status),
+			StartTime:      state.election.GetStartTime().Unix(),
+			EndTime:        state.election.GetEndTime().Unix(),
+		},
+		elections: []string{electionID},
+	}
+}
+
+// tallyEvent monta o evento de apuração de uma eleição
+func (h *EventHub) tallyEvent(electionID string, state *electionState) Event {
+	results := make(map[string]uint64, len(state.results))
+	for candidateID, count := range state.results {
+		results[candidateID] = count
+	}
+
+	return Event{
+		Type:      EventTally,
+		Height:    h.lastHeight,
+		Timestamp: time.Now(),
+		Data: TallyEventData{
+			ElectionID: electionID,
+			Results:    results,
+			TotalVotes: state.totalVotes,
+		},
+		elections: []string{electionID},
+	}
+}
+
+// transactionElection retorna a eleição à qual uma transação de eleição ou de voto se refere
+func transactionElection(tx *entities.Transaction) (string, bool) {
+	switch tx.GetType() {
+	case entities.ElectionTransaction:
+		election := &entities.Election{}
+		if err := election.FromBytes(tx.GetData()); err != nil {
+			return "", false
+		}
+		return election.GetID().String(), true
+	case entities.VoteTransaction:
+		vote := &entities.Vote{}
+		if err := vote.FromBytes(tx.GetData()); err != nil {
+			return "", false
+		}
+		return vote.GetElectionID().String(), true
+	}
+	return "", false
+}
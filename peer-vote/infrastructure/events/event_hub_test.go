@@ -0,0 +1,134 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/persistence"
+)
+
+// newTestHub cria um hub sobre uma cadeia vazia, mantendo até replayEvents eventos recentes
+func newTestHub(replayEvents int) *EventHub {
+	cryptoService := crypto.NewECDSAService()
+	chainManager := blockchain.NewChainManager(persistence.NewMemoryBlockchainRepository(cryptoService), cryptoService)
+
+	return NewEventHub(chainManager, &EventHubConfig{
+		SubscriberBuffer: 64,
+		MaxReplayBlocks:  10,
+		ReplayEvents:     replayEvents,
+		StatusInterval:   time.Second,
+	})
+}
+
+// publishTransactions publica n eventos de transação no hub
+func publishTransactions(hub *EventHub, n int) {
+	for i := 0; i < n; i++ {
+		hub.HandleTransaction(entities.NewTransaction(entities.VoteTransaction, valueobjects.NodeID{}, valueobjects.NodeID{}, []byte{byte(i)}))
+	}
+}
+
+// receive lê n eventos da assinatura
+func receive(t *testing.T, subscription *Subscription, n int) []Event {
+	t.Helper()
+
+	received := make([]Event, 0, n)
+	for len(received) < n {
+		select {
+		case event := <-subscription.Events():
+			received = append(received, event)
+		case <-time.After(time.Second):
+			t.Fatalf("received %d events, want %d", len(received), n)
+		}
+	}
+	return received
+}
+
+func TestEventSequenceIsStrictlyIncreasing(t *testing.T) {
+	hub := newTestHub(16)
+
+	subscription, _, err := hub.Subscribe(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer subscription.Close()
+
+	publishTransactions(hub, 3)
+	received := receive(t, subscription, 3)
+
+	previous := subscription.Cursor()
+	for _, event := range received {
+		// Vários eventos na mesma altura ainda têm sequências distintas
+		if event.Sequence <= previous {
+			t.Fatalf("sequence %d does not follow %d", event.Sequence, previous)
+		}
+		previous = event.Sequence
+	}
+}
+
+func TestSubscribeAfterReplaysExclusive(t *testing.T) {
+	hub := newTestHub(16)
+
+	live, _, err := hub.Subscribe(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer live.Close()
+
+	publishTransactions(hub, 4)
+	published := receive(t, live, 4)
+
+	resumed, backlog, err := hub.SubscribeAfter("", published[1].Sequence)
+	if err != nil {
+		t.Fatalf("SubscribeAfter: %v", err)
+	}
+	defer resumed.Close()
+
+	if len(backlog) != 2 || backlog[0].Sequence != published[2].Sequence || backlog[1].Sequence != published[3].Sequence {
+		t.Fatalf("backlog = %+v, want the two events after %d", backlog, published[1].Sequence)
+	}
+
+	// Posicionado no último evento: nada a repetir
+	_, backlog, err = hub.SubscribeAfter("", published[3].Sequence)
+	if err != nil || len(backlog) != 0 {
+		t.Fatalf("SubscribeAfter(last) = %d events, %v; want none", len(backlog), err)
+	}
+}
+
+func TestSubscribeAfterRejectsUnknownEvents(t *testing.T) {
+	hub := newTestHub(2)
+
+	live, _, err := hub.Subscribe(context.Background(), "", nil)
+	if err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	defer live.Close()
+
+	publishTransactions(hub, 4)
+	published := receive(t, live, 4)
+
+	// Fora dos eventos recentes
+	if _, _, err := hub.SubscribeAfter("", published[0].Sequence); !errors.Is(err, ErrEventExpired) {
+		t.Errorf("SubscribeAfter(evicted) error = %v, want ErrEventExpired", err)
+	}
+	// O mais antigo mantido ainda permite retomar após o anterior a ele
+	if _, backlog, err := hub.SubscribeAfter("", published[1].Sequence); err != nil || len(backlog) != 2 {
+		t.Errorf("SubscribeAfter(oldest kept) = %d events, %v; want 2", len(backlog), err)
+	}
+	// Id ainda não emitido
+	if _, _, err := hub.SubscribeAfter("", published[3].Sequence+1); !errors.Is(err, ErrEventExpired) {
+		t.Errorf("SubscribeAfter(future) error = %v, want ErrEventExpired", err)
+	}
+
+	// Ids de uma execução anterior do nó não apontam para eventos da nova
+	restarted := newTestHub(2)
+	publishTransactions(restarted, 1)
+	if _, _, err := restarted.SubscribeAfter("", published[2].Sequence); !errors.Is(err, ErrEventExpired) {
+		t.Errorf("SubscribeAfter(previous run) error = %v, want ErrEventExpired", err)
+	}
+}
@@ -0,0 +1,222 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/network"
+)
+
+// RepositorySource fornece acesso ao armazenamento da cadeia (ex: repositories.BlockchainRepository)
+type RepositorySource interface {
+	GetBlockHeight(ctx context.Context) (uint64, error)
+}
+
+// ChainSource fornece a cadeia canônica (ex: blockchain.ChainManager)
+type ChainSource interface {
+	GetChainHeight(ctx context.Context) (uint64, error)
+	ValidateTip(ctx context.Context) (*entities.Block, error)
+	CalculateBlockHash(ctx context.Context, block *entities.Block) valueobjects.Hash
+}
+
+// ConsensusSource fornece o estado do consenso (ex: services.ConsensusService)
+type ConsensusSource interface {
+	GetConsensusStatus(ctx context.Context) (services.ConsensusStatus, error)
+}
+
+// MempoolSource fornece as transações pendentes (ex: consensus.Mempool)
+type MempoolSource interface {
+	Stats() consensus.MempoolStats
+}
+
+// NetworkSource fornece peers e o status de cadeia informado por eles (ex: network.P2PService)
+type NetworkSource interface {
+	GetPeerCount() (int, error)
+	GetSyncStats() *network.SyncStats
+	GetSyncPeers() map[peer.ID]*network.SyncPeerInfo
+}
+
+// Sources são os componentes verificados. Campos nil marcam a verificação como skipped.
+type Sources struct {
+	Repository RepositorySource
+	Chain      ChainSource
+	Consensus  ConsensusSource
+	Mempool    MempoolSource
+	Network    NetworkSource
+}
+
+// checkRepository verifica se o armazenamento da cadeia responde
+func (c *Checker) checkRepository(ctx context.Context) ComponentResult {
+	if c.sources.Repository == nil {
+		return ComponentResult{Status: StatusSkipped, Message: "repository not configured"}
+	}
+
+	height, err := c.sources.Repository.GetBlockHeight(ctx)
+	if err != nil {
+		return ComponentResult{Status: StatusDown, Message: fmt.Sprintf("repository not accessible: %v", err)}
+	}
+
+	return ComponentResult{
+		Status:  StatusUp,
+		Details: map[string]interface{}{"height": height},
+	}
+}
+
+// checkChain valida o último bloco e sua ligação com o anterior
+func (c *Checker) checkChain(ctx context.Context) ComponentResult {
+	if c.sources.Chain == nil {
+		return ComponentResult{Status: StatusSkipped, Message: "chain not configured"}
+	}
+
+	tip, err := c.sources.Chain.ValidateTip(ctx)
+	if err != nil {
+		return ComponentResult{Status: StatusDown, Message: fmt.Sprintf("invalid chain tip: %v", err)}
+	}
+	if tip == nil {
+		return ComponentResult{Status: StatusDegraded, Message: "chain has no blocks"}
+	}
+
+	return ComponentResult{
+		Status: StatusUp,
+		Details: map[string]interface{}{
+			"height":    tip.GetIndex(),
+			"tip_hash":  c.sources.Chain.CalculateBlockHash(ctx, tip).String(),
+			"validator": tip.GetValidator().String(),
+		},
+	}
+}
+
+// checkSync compara a altura local com a maior altura informada pelos peers (ChainStatusResponse)
+func (c *Checker) checkSync(ctx context.Context) ComponentResult {
+	if c.sources.Network == nil {
+		return ComponentResult{Status: StatusSkipped, Message: "p2p disabled"}
+	}
+	if c.sources.Chain == nil {
+		return ComponentResult{Status: StatusSkipped, Message: "chain not configured"}
+	}
+
+	height, err := c.sources.Chain.GetChainHeight(ctx)
+	if err != nil {
+		return ComponentResult{Status: StatusDown, Message: fmt.Sprintf("failed to get chain height: %v", err)}
+	}
+
+	var bestPeerHeight uint64
+	reporting := 0
+	for _, peerInfo := range c.sources.Network.GetSyncPeers() {
+		if !peerInfo.IsReliable {
+			continue
+		}
+		reporting++
+		if peerInfo.ChainHeight > bestPeerHeight {
+			bestPeerHeight = peerInfo.ChainHeight
+		}
+	}
+
+	var lag uint64
+	if bestPeerHeight > height {
+		lag = bestPeerHeight - height
+	}
+
+	details := map[string]interface{}{
+		"height":           height,
+		"best_peer_height": bestPeerHeight,
+		"lag_blocks":       lag,
+		"max_lag_blocks":   c.config.MaxSyncLag,
+		"peers_reporting":  reporting,
+	}
+	if stats := c.sources.Network.GetSyncStats(); stats != nil {
+		details["syncing"] = stats.IsSyncing
+		details["phase"] = string(stats.Phase)
+	}
+
+	switch {
+	case reporting == 0:
+		return ComponentResult{Status: StatusUp, Message: "no peer chain status available", Details: details}
+	case lag > c.config.MaxSyncLag:
+		return ComponentResult{Status: StatusDown, Message: fmt.Sprintf("node is %d blocks behind peers", lag), Details: details}
+	case lag > 0:
+		return ComponentResult{Status: StatusDegraded, Message: fmt.Sprintf("node is %d blocks behind peers", lag), Details: details}
+	default:
+		return ComponentResult{Status: StatusUp, Details: details}
+	}
+}
+
+// checkConsensus verifica se o loop de consenso continua produzindo blocos.
+// Sem transações pendentes não há blocos a produzir, então a idade do último bloco só
+// conta quando o mempool tem transações esperando.
+func (c *Checker) checkConsensus(ctx context.Context) ComponentResult {
+	if c.sources.Consensus == nil {
+		return ComponentResult{Status: StatusSkipped, Message: "consensus not configured"}
+	}
+
+	status, err := c.sources.Consensus.GetConsensusStatus(ctx)
+	if err != nil {
+		return ComponentResult{Status: StatusDown, Message: fmt.Sprintf("failed to get consensus status: %v", err)}
+	}
+	if !status.IsRunning {
+		return ComponentResult{Status: StatusSkipped, Message: "consensus not running"}
+	}
+
+	pending := 0
+	if c.sources.Mempool != nil {
+		pending = c.sources.Mempool.Stats().Count
+	}
+
+	maxAge := c.config.BlockTime * time.Duration(c.config.MaxMissedBlocks)
+	details := map[string]interface{}{
+		"round":                status.CurrentRound,
+		"proposer":             status.CurrentValidator.String(),
+		"validators":           status.ValidatorCount,
+		"pending_transactions": pending,
+		"max_block_age_s":      int64(maxAge.Seconds()),
+	}
+
+	if status.LastBlockTime.IsZero() {
+		return ComponentResult{Status: StatusUp, Message: "no blocks produced yet", Details: details}
+	}
+
+	age := time.Since(status.LastBlockTime.Time())
+	details["last_block_age_s"] = int64(age.Seconds())
+
+	if pending > 0 && age > maxAge {
+		return ComponentResult{
+			Status:  StatusDown,
+			Message: fmt.Sprintf("consensus loop stalled: last block %s ago with %d pending transactions", age.Truncate(time.Second), pending),
+			Details: details,
+		}
+	}
+
+	return ComponentResult{Status: StatusUp, Details: details}
+}
+
+// checkPeers verifica a quantidade de peers conectados
+func (c *Checker) checkPeers(ctx context.Context) ComponentResult {
+	if c.sources.Network == nil {
+		return ComponentResult{Status: StatusSkipped, Message: "p2p disabled"}
+	}
+
+	count, err := c.sources.Network.GetPeerCount()
+	if err != nil {
+		return ComponentResult{Status: StatusDown, Message: fmt.Sprintf("failed to get peer count: %v", err)}
+	}
+
+	details := map[string]interface{}{
+		"connected": count,
+		"min_peers": c.config.MinPeers,
+	}
+
+	switch {
+	case count < c.config.MinPeers:
+		return ComponentResult{Status: StatusDown, Message: fmt.Sprintf("%d peers connected, %d required", count, c.config.MinPeers), Details: details}
+	case count == 0:
+		return ComponentResult{Status: StatusDegraded, Message: "no peers connected", Details: details}
+	default:
+		return ComponentResult{Status: StatusUp, Details: details}
+	}
+}
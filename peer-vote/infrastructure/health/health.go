@@ -0,0 +1,253 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// Status representa o estado de um componente ou do nó
+type Status string
+
+const (
+	StatusUp       Status = "up"       // Componente saudável
+	StatusDegraded Status = "degraded" // Funcional, mas fora do ideal (não falha a verificação)
+	StatusDown     Status = "down"     // Falha: a verificação responde 503
+	StatusSkipped  Status = "skipped"  // Componente desabilitado neste nó
+)
+
+// Nomes das verificações
+const (
+	CheckRepository = "repository"
+	CheckChain      = "chain"
+	CheckSync       = "sync"
+	CheckConsensus  = "consensus"
+	CheckPeers      = "peers"
+)
+
+// checkTimeout limita cada verificação individual
+const checkTimeout = 3 * time.Second
+
+// ComponentResult é o resultado da verificação de um componente
+type ComponentResult struct {
+	Status     Status                 `json:"status"`
+	Message    string                 `json:"message,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	DurationMs int64                  `json:"duration_ms"`
+}
+
+// Report é o resultado agregado de uma verificação (liveness ou readiness)
+type Report struct {
+	Status    Status                     `json:"status"`
+	Checks    map[string]ComponentResult `json:"checks"`
+	CheckedAt int64                      `json:"checked_at"`
+}
+
+// Healthy indica se nenhum componente está down
+func (r *Report) Healthy() bool {
+	return r.Status != StatusDown
+}
+
+// Failing retorna os componentes down em ordem alfabética
+func (r *Report) Failing() []string {
+	var failing []string
+	for name, result := range r.Checks {
+		if result.Status == StatusDown {
+			failing = append(failing, name)
+		}
+	}
+	sort.Strings(failing)
+	return failing
+}
+
+// Config define os limites das verificações
+type Config struct {
+	// Tempo esperado entre blocos (blockchain.block.block_time)
+	BlockTime time.Duration
+	// Blocos perdidos tolerados antes de considerar o loop de consenso parado
+	// (idade do último bloco > BlockTime * MaxMissedBlocks com transações pendentes)
+	MaxMissedBlocks int
+	// Blocos de atraso em relação ao melhor peer antes de o nó deixar de estar pronto
+	MaxSyncLag uint64
+	// Peers conectados mínimos para o nó estar pronto
+	MinPeers int
+}
+
+// DefaultConfig retorna os limites padrão
+func DefaultConfig() Config {
+	return Config{
+		BlockTime:       10 * time.Second,
+		MaxMissedBlocks: 3,
+		MaxSyncLag:      10,
+		MinPeers:        0,
+	}
+}
+
+// Checker verifica a saúde dos componentes do nó.
+// Liveness cobre o que só se resolve reiniciando o processo (repositório e loop de consenso);
+// readiness cobre também a validade da cadeia, a sincronização e os peers.
+type Checker struct {
+	config  Config
+	sources Sources
+	logger  *slog.Logger
+
+	mu         sync.Mutex
+	lastStatus Status
+}
+
+// NewChecker cria o verificador de saúde
+func NewChecker(config Config, sources Sources) *Checker {
+	defaults := DefaultConfig()
+	if config.BlockTime <= 0 {
+		config.BlockTime = defaults.BlockTime
+	}
+	if config.MaxMissedBlocks <= 0 {
+		config.MaxMissedBlocks = defaults.MaxMissedBlocks
+	}
+
+	return &Checker{
+		config:  config,
+		sources: sources,
+		logger:  logging.Component("health"),
+	}
+}
+
+// SetLogger define o logger do verificador
+func (c *Checker) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+}
+
+// SetNetworkSource define a fonte de peers e sincronização quando a rede P2P é criada
+// depois do verificador (defina antes de servir as verificações)
+func (c *Checker) SetNetworkSource(source NetworkSource) {
+	c.sources.Network = source
+}
+
+// Live verifica se o processo está funcionando (repositório acessível e consenso não travado)
+func (c *Checker) Live(ctx context.Context) *Report {
+	return c.run(ctx, map[string]checkFunc{
+		CheckRepository: c.checkRepository,
+		CheckConsensus:  c.checkConsensus,
+	})
+}
+
+// Ready verifica se o nó está apto a receber tráfego
+func (c *Checker) Ready(ctx context.Context) *Report {
+	return c.run(ctx, map[string]checkFunc{
+		CheckRepository: c.checkRepository,
+		CheckChain:      c.checkChain,
+		CheckSync:       c.checkSync,
+		CheckConsensus:  c.checkConsensus,
+		CheckPeers:      c.checkPeers,
+	})
+}
+
+// Run verifica a readiness a cada intervalo e registra as mudanças de estado até o contexto ser cancelado
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.observe(ctx, c.Ready(ctx))
+		}
+	}
+}
+
+// observe registra a transição de estado da readiness
+func (c *Checker) observe(ctx context.Context, report *Report) {
+	c.mu.Lock()
+	previous := c.lastStatus
+	c.lastStatus = report.Status
+	c.mu.Unlock()
+
+	if previous == report.Status {
+		return
+	}
+
+	attrs := []any{"status", string(report.Status)}
+	if previous != "" {
+		attrs = append(attrs, "previous", string(previous))
+	}
+	if failing := report.Failing(); len(failing) > 0 {
+		attrs = append(attrs, "failing", strings.Join(failing, ","))
+	}
+	if report.Healthy() {
+		c.logger.InfoContext(ctx, "readiness changed", attrs...)
+	} else {
+		c.logger.WarnContext(ctx, "readiness changed", attrs...)
+	}
+}
+
+// checkFunc verifica um componente
+type checkFunc func(ctx context.Context) ComponentResult
+
+// run executa as verificações em paralelo e agrega o resultado
+func (c *Checker) run(ctx context.Context, checks map[string]checkFunc) *Report {
+	report := &Report{
+		Status:    StatusUp,
+		Checks:    make(map[string]ComponentResult, len(checks)),
+		CheckedAt: time.Now().Unix(),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check checkFunc) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			start := time.Now()
+			result := runCheck(checkCtx, check)
+			result.DurationMs = time.Since(start).Milliseconds()
+
+			mu.Lock()
+			report.Checks[name] = result
+			mu.Unlock()
+		}(name, check)
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		switch result.Status {
+		case StatusDown:
+			report.Status = StatusDown
+		case StatusDegraded:
+			if report.Status == StatusUp {
+				report.Status = StatusDegraded
+			}
+		}
+	}
+
+	return report
+}
+
+// runCheck executa a verificação respeitando o timeout do contexto
+func runCheck(ctx context.Context, check checkFunc) ComponentResult {
+	done := make(chan ComponentResult, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	select {
+	case result := <-done:
+		return result
+	case <-ctx.Done():
+		return ComponentResult{Status: StatusDown, Message: "check timed out"}
+	}
+}
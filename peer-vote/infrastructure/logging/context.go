@@ -0,0 +1,69 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// contextKey é o tipo das chaves de contexto do pacote
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	attrsKey
+)
+
+// WithRequestID associa o ID da requisição ao contexto; os registros feitos com
+// esse contexto (InfoContext, etc.) passam a levar request_id
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestID retorna o ID da requisição associado ao contexto
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
+
+// WithAttrs associa campos de correlação ao contexto (ex: Height, TxHash)
+func WithAttrs(ctx context.Context, attrs ...slog.Attr) context.Context {
+	existing, _ := ctx.Value(attrsKey).([]slog.Attr)
+	merged := make([]slog.Attr, 0, len(existing)+len(attrs))
+	merged = append(merged, existing...)
+	merged = append(merged, attrs...)
+	return context.WithValue(ctx, attrsKey, merged)
+}
+
+// componentHandler aplica o nível do componente e adiciona os campos de correlação do contexto
+type componentHandler struct {
+	next  slog.Handler
+	level *slog.LevelVar
+}
+
+// Enabled implementa slog.Handler
+func (h *componentHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle implementa slog.Handler
+func (h *componentHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if requestID := RequestID(ctx); requestID != "" {
+			record.AddAttrs(slog.String(KeyRequestID, requestID))
+		}
+		if attrs, ok := ctx.Value(attrsKey).([]slog.Attr); ok {
+			record.AddAttrs(attrs...)
+		}
+	}
+	return h.next.Handle(ctx, record)
+}
+
+// WithAttrs implementa slog.Handler
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &componentHandler{next: h.next.WithAttrs(attrs), level: h.level}
+}
+
+// WithGroup implementa slog.Handler
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	return &componentHandler{next: h.next.WithGroup(name), level: h.level}
+}
@@ -0,0 +1,235 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// Chaves de correlação presentes nos registros
+const (
+	KeyComponent = "component"
+	KeyNodeID    = "node_id"
+	KeyHeight    = "height"
+	KeyTxHash    = "tx_hash"
+	KeyRequestID = "request_id"
+	KeyPeer      = "peer"
+	KeyError     = "error"
+)
+
+// Formatos de saída suportados
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+// Config define nível, formato e destino dos logs
+type Config struct {
+	Level      string            // debug, info, warn ou error
+	Format     string            // json ou text
+	Output     string            // stdout, stderr ou caminho de arquivo
+	Components map[string]string // Nível inicial por componente
+}
+
+// Manager cria os loggers dos componentes sobre um único destino e mantém o nível
+// de cada componente, ajustável em tempo de execução
+type Manager struct {
+	handler      slog.Handler
+	output       io.Closer
+	defaultLevel slog.Level
+
+	mu     sync.RWMutex
+	levels map[string]*slog.LevelVar
+}
+
+// NewManager cria o gerenciador de logs. Todos os registros levam o NodeID do nó.
+func NewManager(config Config, nodeID valueobjects.NodeID) (*Manager, error) {
+	defaultLevel, err := ParseLevel(config.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	writer, closer, err := openOutput(config.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	// O destino aceita tudo; o filtro fica no nível de cada componente
+	options := &slog.HandlerOptions{Level: slog.LevelDebug}
+	var handler slog.Handler
+	switch strings.ToLower(config.Format) {
+	case "", FormatJSON:
+		handler = slog.NewJSONHandler(writer, options)
+	case FormatText:
+		handler = slog.NewTextHandler(writer, options)
+	default:
+		return nil, fmt.Errorf("unknown log format: %s", config.Format)
+	}
+	if !nodeID.IsEmpty() {
+		handler = handler.WithAttrs([]slog.Attr{slog.String(KeyNodeID, nodeID.String())})
+	}
+
+	m := &Manager{
+		handler:      handler,
+		output:       closer,
+		defaultLevel: defaultLevel,
+		levels:       make(map[string]*slog.LevelVar),
+	}
+	for component, levelName := range config.Components {
+		level, err := ParseLevel(levelName)
+		if err != nil {
+			return nil, fmt.Errorf("component %s: %w", component, err)
+		}
+		m.levelVar(component).Set(level)
+	}
+
+	return m, nil
+}
+
+// Logger retorna o logger do componente (ex: "p2p", "consensus", "rest")
+func (m *Manager) Logger(component string) *slog.Logger {
+	return slog.New(&componentHandler{
+		next:  m.handler.WithAttrs([]slog.Attr{slog.String(KeyComponent, component)}),
+		level: m.levelVar(component),
+	})
+}
+
+// SetLevel altera o nível de um componente já registrado.
+// Componente vazio ou "*" altera o nível padrão e o de todos os componentes.
+func (m *Manager) SetLevel(component string, level slog.Level) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if component == "" || component == "*" {
+		m.defaultLevel = level
+		for _, levelVar := range m.levels {
+			levelVar.Set(level)
+		}
+		return nil
+	}
+
+	levelVar, exists := m.levels[component]
+	if !exists {
+		return fmt.Errorf("unknown log component: %s", component)
+	}
+	levelVar.Set(level)
+	return nil
+}
+
+// Levels retorna o nível padrão e o nível atual de cada componente
+func (m *Manager) Levels() (string, map[string]string) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	levels := make(map[string]string, len(m.levels))
+	for component, levelVar := range m.levels {
+		levels[component] = LevelName(levelVar.Level())
+	}
+	return LevelName(m.defaultLevel), levels
+}
+
+// Components retorna os componentes registrados em ordem alfabética
+func (m *Manager) Components() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	components := make([]string, 0, len(m.levels))
+	for component := range m.levels {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+	return components
+}
+
+// Close fecha o arquivo de saída, se houver
+func (m *Manager) Close() error {
+	if m.output == nil {
+		return nil
+	}
+	return m.output.Close()
+}
+
+// levelVar retorna (criando com o nível padrão) o nível do componente
+func (m *Manager) levelVar(component string) *slog.LevelVar {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	levelVar, exists := m.levels[component]
+	if !exists {
+		levelVar = &slog.LevelVar{}
+		levelVar.Set(m.defaultLevel)
+		m.levels[component] = levelVar
+	}
+	return levelVar
+}
+
+// openOutput abre o destino dos logs
+func openOutput(output string) (io.Writer, io.Closer, error) {
+	switch output {
+	case "", "stdout":
+		return os.Stdout, nil, nil
+	case "stderr":
+		return os.Stderr, nil, nil
+	default:
+		file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open log output: %w", err)
+		}
+		return file, file, nil
+	}
+}
+
+// ParseLevel converte debug/info/warn/error em slog.Level (vazio = info)
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level: %s", name)
+	}
+}
+
+// LevelName retorna o nome do nível no formato aceito por ParseLevel
+func LevelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
+
+// Component retorna o logger padrão do processo identificado pelo componente.
+// Usado pelos componentes até que um logger seja injetado.
+func Component(component string) *slog.Logger {
+	return slog.Default().With(KeyComponent, component)
+}
+
+// Campos de correlação
+
+// Height identifica a altura de um bloco
+func Height(height uint64) slog.Attr {
+	return slog.Uint64(KeyHeight, height)
+}
+
+// TxHash identifica uma transação
+func TxHash(hash valueobjects.Hash) slog.Attr {
+	return slog.String(KeyTxHash, hash.String())
+}
+
+// Peer identifica um peer da rede P2P
+func Peer(peerID fmt.Stringer) slog.Attr {
+	return slog.String(KeyPeer, peerID.String())
+}
+
+// Err registra um erro
+func Err(err error) slog.Attr {
+	return slog.Any(KeyError, err)
+}
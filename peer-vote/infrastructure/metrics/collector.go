@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/network"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// collectTimeout limita a leitura do estado dos componentes durante uma coleta
+const collectTimeout = 5 * time.Second
+
+// ChainSource fornece a altura da cadeia (ex: blockchain.ChainManager)
+type ChainSource interface {
+	GetChainHeight(ctx context.Context) (uint64, error)
+	GetFinalizedHeight(ctx context.Context) (uint64, bool)
+}
+
+// MempoolSource fornece as estatísticas do pool de transações (ex: consensus.Mempool)
+type MempoolSource interface {
+	Stats() consensus.MempoolStats
+}
+
+// ValidatorSource fornece o estado dos validadores (ex: consensus.ValidatorManager)
+type ValidatorSource interface {
+	GetAllValidators(ctx context.Context) ([]*consensus.Validator, error)
+}
+
+// NetworkSource fornece peers e progresso da sincronização (ex: network.P2PService)
+type NetworkSource interface {
+	GetPeerCount() (int, error)
+	GetSyncStats() *network.SyncStats
+}
+
+// Sources são os componentes lidos a cada coleta. Campos nil são ignorados.
+type Sources struct {
+	Chain      ChainSource
+	Mempool    MempoolSource
+	Validators ValidatorSource
+	Network    NetworkSource
+}
+
+// stateCollector exporta o estado atual dos componentes no momento da coleta
+type stateCollector struct {
+	sources Sources
+
+	chainHeight      *prometheus.Desc
+	finalizedHeight  *prometheus.Desc
+	mempoolTxs       *prometheus.Desc
+	mempoolBytes     *prometheus.Desc
+	mempoolByType    *prometheus.Desc
+	peers            *prometheus.Desc
+	syncing          *prometheus.Desc
+	syncLag          *prometheus.Desc
+	syncFailures     *prometheus.Desc
+	validatorMissed  *prometheus.Desc
+	validatorRounds  *prometheus.Desc
+	validatorPenalty *prometheus.Desc
+}
+
+// newStateCollector cria o coletor de estado
+func newStateCollector(sources Sources) *stateCollector {
+	return &stateCollector{
+		sources:          sources,
+		chainHeight:      newDesc("chain_height", "Altura do último bloco da cadeia canônica.", nil),
+		finalizedHeight:  newDesc("chain_finalized_height", "Altura do último bloco finalizado.", nil),
+		mempoolTxs:       newDesc("mempool_transactions", "Transações pendentes no mempool.", nil),
+		mempoolBytes:     newDesc("mempool_bytes", "Tamanho serializado das transações pendentes.", nil),
+		mempoolByType:    newDesc("mempool_transactions_by_type", "Transações pendentes por tipo.", []string{"type"}),
+		peers:            newDesc("p2p_peers", "Peers conectados.", nil),
+		syncing:          newDesc("sync_in_progress", "1 enquanto a sincronização com a rede está em andamento.", nil),
+		syncLag:          newDesc("sync_lag_blocks", "Blocos que faltam para alcançar a altura alvo da sincronização.", nil),
+		syncFailures:     newDesc("sync_failures", "Falhas de sincronização acumuladas.", nil),
+		validatorMissed:  newDesc("validator_missed_rounds", "Rounds perdidos por validador.", []string{"validator"}),
+		validatorRounds:  newDesc("validator_rounds", "Rounds atribuídos por validador.", []string{"validator"}),
+		validatorPenalty: newDesc("validator_penalties", "Penalidades aplicadas por validador.", []string{"validator"}),
+	}
+}
+
+// newDesc cria a descrição de uma métrica do nó
+func newDesc(name, help string, labels []string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, "", name), help, labels, nil)
+}
+
+// Describe implementa prometheus.Collector
+func (c *stateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.chainHeight
+	ch <- c.finalizedHeight
+	ch <- c.mempoolTxs
+	ch <- c.mempoolBytes
+	ch <- c.mempoolByType
+	ch <- c.peers
+	ch <- c.syncing
+	ch <- c.syncLag
+	ch <- c.syncFailures
+	ch <- c.validatorMissed
+	ch <- c.validatorRounds
+	ch <- c.validatorPenalty
+}
+
+// Collect implementa prometheus.Collector
+func (c *stateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+
+	if c.sources.Chain != nil {
+		if height, err := c.sources.Chain.GetChainHeight(ctx); err == nil {
+			ch <- gauge(c.chainHeight, float64(height))
+		}
+		if finalized, ok := c.sources.Chain.GetFinalizedHeight(ctx); ok {
+			ch <- gauge(c.finalizedHeight, float64(finalized))
+		}
+	}
+
+	if c.sources.Mempool != nil {
+		stats := c.sources.Mempool.Stats()
+		ch <- gauge(c.mempoolTxs, float64(stats.Count))
+		ch <- gauge(c.mempoolBytes, float64(stats.Bytes))
+		for txType, count := range stats.ByType {
+			ch <- gauge(c.mempoolByType, float64(count), string(txType))
+		}
+	}
+
+	if c.sources.Network != nil {
+		if count, err := c.sources.Network.GetPeerCount(); err == nil {
+			ch <- gauge(c.peers, float64(count))
+		}
+		if stats := c.sources.Network.GetSyncStats(); stats != nil {
+			syncing := 0.0
+			if stats.IsSyncing {
+				syncing = 1
+			}
+			lag := 0.0
+			if stats.TargetHeight > stats.CurrentHeight {
+				lag = float64(stats.TargetHeight - stats.CurrentHeight)
+			}
+			ch <- gauge(c.syncing, syncing)
+			ch <- gauge(c.syncLag, lag)
+			ch <- gauge(c.syncFailures, float64(stats.FailureCount))
+		}
+	}
+
+	if c.sources.Validators != nil {
+		if validators, err := c.sources.Validators.GetAllValidators(ctx); err == nil {
+			for _, validator := range validators {
+				nodeID := validator.NodeID.String()
+				ch <- gauge(c.validatorMissed, float64(validator.MissedRounds), nodeID)
+				ch <- gauge(c.validatorRounds, float64(validator.TotalRounds), nodeID)
+				ch <- gauge(c.validatorPenalty, float64(validator.PenaltyCount), nodeID)
+			}
+		}
+	}
+}
+
+// gauge cria uma amostra constante de gauge
+func gauge(desc *prometheus.Desc, value float64, labels ...string) prometheus.Metric {
+	return prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value, labels...)
+}
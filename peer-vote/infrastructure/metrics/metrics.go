@@ -0,0 +1,150 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/network"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+// namespace é o prefixo de todas as métricas do nó
+const namespace = "peervote"
+
+// Metrics reúne as métricas exportadas pelo nó no formato Prometheus.
+// Contadores e histogramas são alimentados pelos hooks dos componentes (Observe*);
+// os valores de estado (altura, mempool, peers, sincronização e validadores) são lidos
+// das Sources no momento da coleta.
+type Metrics struct {
+	registry *prometheus.Registry
+	state    *stateCollector
+
+	blockProduction *prometheus.HistogramVec
+	blockDelay      prometheus.Histogram
+	messages        *prometheus.CounterVec
+	httpDuration    *prometheus.HistogramVec
+	votesAccepted   prometheus.Counter
+	votesRejected   *prometheus.CounterVec
+
+	// Timestamp do último bloco observado, para o intervalo de produção
+	lastBlockMu   sync.Mutex
+	lastBlockTime valueobjects.Timestamp
+	lastBlockIdx  uint64
+}
+
+// NewMetrics cria o registro de métricas do nó
+func NewMetrics(sources Sources) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		state:    newStateCollector(sources),
+		blockProduction: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "block_production_seconds",
+			Help:      "Intervalo entre o timestamp de um bloco e o do bloco anterior, por validador.",
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60, 120},
+		}, []string{"validator"}),
+		blockDelay: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "block_commit_delay_seconds",
+			Help:      "Atraso entre o timestamp do bloco e sua aplicação na cadeia local.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+		}),
+		messages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "p2p_messages_total",
+			Help:      "Mensagens P2P recebidas (in) e enviadas (out) por tipo.",
+		}, []string{"direction", "type"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Latência das requisições da API REST por rota.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "code"}),
+		votesAccepted: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "votes_accepted_total",
+			Help:      "Votos aceitos no pool de transações.",
+		}),
+		votesRejected: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "votes_rejected_total",
+			Help:      "Votos rejeitados por motivo.",
+		}, []string{"reason"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.blockProduction,
+		m.blockDelay,
+		m.messages,
+		m.httpDuration,
+		m.votesAccepted,
+		m.votesRejected,
+		m.state,
+	)
+
+	return m
+}
+
+// Registry retorna o registro Prometheus com todas as métricas do nó
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// SetNetworkSource define a fonte de peers e sincronização quando a rede P2P é criada
+// depois das métricas (defina antes de servir as métricas)
+func (m *Metrics) SetNetworkSource(source NetworkSource) {
+	m.state.sources.Network = source
+}
+
+// ObserveBlock registra a produção de um bloco aplicado à cadeia canônica
+// (compatível com blockchain.BlockListener)
+func (m *Metrics) ObserveBlock(ctx context.Context, block *entities.Block) {
+	timestamp := block.GetTimestamp()
+	m.blockDelay.Observe(nonNegative(time.Since(timestamp.Time())))
+
+	m.lastBlockMu.Lock()
+	defer m.lastBlockMu.Unlock()
+
+	if !m.lastBlockTime.IsZero() && block.GetIndex() == m.lastBlockIdx+1 {
+		m.blockProduction.WithLabelValues(block.GetValidator().String()).Observe(nonNegative(timestamp.Sub(m.lastBlockTime)))
+	}
+	m.lastBlockTime = timestamp
+	m.lastBlockIdx = block.GetIndex()
+}
+
+// ObserveMessage contabiliza uma mensagem P2P recebida ou enviada
+// (compatível com network.MessageObserver)
+func (m *Metrics) ObserveMessage(direction network.MessageDirection, msgType network.MessageType) {
+	m.messages.WithLabelValues(string(direction), string(msgType)).Inc()
+}
+
+// ObserveHTTPRequest registra a latência de uma requisição REST pelo template da rota
+func (m *Metrics) ObserveHTTPRequest(method, route string, code int, duration time.Duration) {
+	m.httpDuration.WithLabelValues(method, route, strconv.Itoa(code)).Observe(duration.Seconds())
+}
+
+// ObserveVote contabiliza o resultado de uma submissão de voto
+// (compatível com usecases.VoteObserver)
+func (m *Metrics) ObserveVote(outcome usecases.VoteOutcome) {
+	if outcome == usecases.VoteAccepted {
+		m.votesAccepted.Inc()
+		return
+	}
+	m.votesRejected.WithLabelValues(string(outcome)).Inc()
+}
+
+// nonNegative converte a duração em segundos, descartando valores negativos (relógios dessincronizados)
+func nonNegative(d time.Duration) float64 {
+	if d < 0 {
+		return 0
+	}
+	return d.Seconds()
+}
@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path é o caminho do endpoint de métricas
+const Path = "/metrics"
+
+// Server expõe as métricas no formato texto do Prometheus
+type Server struct {
+	address    string
+	httpServer *http.Server
+	logger     *slog.Logger
+}
+
+// NewServer cria o servidor de métricas no endereço informado (host:porta)
+func NewServer(address string, m *Metrics) *Server {
+	mux := http.NewServeMux()
+	mux.Handle(Path, promhttp.HandlerFor(m.Registry(), promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	}))
+
+	return &Server{
+		address: address,
+		httpServer: &http.Server{
+			Addr:              address,
+			Handler:           mux,
+			ReadHeaderTimeout: 5 * time.Second,
+		},
+		logger: logging.Component("metrics"),
+	}
+}
+
+// SetLogger define o logger do servidor de métricas
+func (s *Server) SetLogger(logger *slog.Logger) {
+	s.logger = logger
+}
+
+// Start inicia o servidor e bloqueia até o contexto ser cancelado
+func (s *Server) Start(ctx context.Context) error {
+	s.logger.InfoContext(ctx, "starting metrics server", "address", s.address, "path", Path)
+
+	errChan := make(chan error, 1)
+	go func() {
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errChan <- fmt.Errorf("failed to start metrics server: %w", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		return s.Stop()
+	case err := <-errChan:
+		return err
+	}
+}
+
+// Stop para o servidor de métricas
+func (s *Server) Stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown metrics server: %w", err)
+	}
+	return nil
+}
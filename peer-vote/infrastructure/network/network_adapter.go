@@ -55,6 +55,21 @@ func (na *NetworkAdapter) BroadcastTransaction(ctx context.Context, tx *entities
 	return na.p2pService.BroadcastTransaction(ctx, tx)
 }
 
+// BroadcastAttestation transmite a atestação de um bloco por um validador
+func (na *NetworkAdapter) BroadcastAttestation(ctx context.Context, attestation *entities.Attestation) error {
+	return na.p2pService.BroadcastAttestation(ctx, attestation)
+}
+
+// BroadcastProposal transmite a proposta de bloco de um round de consenso
+func (na *NetworkAdapter) BroadcastProposal(ctx context.Context, proposal *entities.ConsensusProposal) error {
+	return na.p2pService.BroadcastProposal(ctx, proposal)
+}
+
+// BroadcastConsensusVote transmite um prevote ou precommit
+func (na *NetworkAdapter) BroadcastConsensusVote(ctx context.Context, vote *entities.ConsensusVote) error {
+	return na.p2pService.BroadcastConsensusVote(ctx, vote)
+}
+
 // SendBlockToPeer envia um bloco para um peer específico
 func (na *NetworkAdapter) SendBlockToPeer(ctx context.Context, peerID valueobjects.NodeID, block *entities.Block) error {
 	// Por enquanto usa broadcast - pode ser otimizado depois
@@ -152,6 +167,20 @@ func (na *NetworkAdapter) RegisterTransactionHandler(handler services.Transactio
 	})
 }
 
+// RegisterProposalHandler registra um handler para propostas de consenso recebidas
+func (na *NetworkAdapter) RegisterProposalHandler(handler services.ProposalHandler) {
+	na.p2pService.SetOnProposalReceived(func(proposal *entities.ConsensusProposal, fromPeer valueobjects.NodeID) {
+		handler(context.Background(), proposal, fromPeer)
+	})
+}
+
+// RegisterConsensusVoteHandler registra um handler para votos de consenso recebidos
+func (na *NetworkAdapter) RegisterConsensusVoteHandler(handler services.ConsensusVoteHandler) {
+	na.p2pService.SetOnConsensusVoteReceived(func(vote *entities.ConsensusVote, fromPeer valueobjects.NodeID) {
+		handler(context.Background(), vote, fromPeer)
+	})
+}
+
 // GetNetworkStatus retorna o status da rede
 func (na *NetworkAdapter) GetNetworkStatus(ctx context.Context) (services.NetworkStatus, error) {
 	stats, err := na.p2pService.GetStats(ctx)
@@ -3,8 +3,9 @@ package network
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"sync"
 	"time"
 
@@ -16,6 +17,8 @@ import (
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/snapshot"
 )
 
 // P2PService integra todos os componentes P2P
@@ -30,6 +33,12 @@ type P2PService struct {
 	consensusEngine *consensus.PoAEngine
 	cryptoService   services.CryptographyService
 	
+	// Finalidade por atestações de validadores (nil enquanto não houver conjunto de validadores)
+	finalityTracker *consensus.FinalityTracker
+	
+	// Detecção de assinatura dupla (nil desabilita)
+	doubleSignDetector *consensus.DoubleSignDetector
+	
 	// Estado
 	isRunning       bool
 	nodeID          valueobjects.NodeID
@@ -48,6 +57,10 @@ type P2PService struct {
 	onPeerDisconnected func(peer.ID)
 	onBlockReceived    func(*entities.Block)
 	onTxReceived       func(*entities.Transaction)
+	onProposalReceived func(*entities.ConsensusProposal, valueobjects.NodeID)
+	onVoteReceived     func(*entities.ConsensusVote, valueobjects.NodeID)
+	
+	logger *slog.Logger
 }
 
 // P2PConfig contém configurações para o serviço P2P
@@ -58,6 +71,9 @@ type P2PConfig struct {
 	EnableMDNS      bool
 	EnableDHT       bool
 	Namespace       string
+	
+	// Arquivo usado para retomar a sincronização após reinício (vazio desabilita)
+	SyncCheckpointPath string
 }
 
 // P2PStats contém estatísticas do serviço P2P
@@ -136,7 +152,12 @@ func NewP2PService(
 	protocolManager := NewProtocolManager(host)
 	
 	// Criar serviço de sincronização
-	syncService := NewSyncService(chainManager, protocolManager, host)
+	syncService := NewSyncService(chainManager, protocolManager, host, cryptoService)
+	syncService.SetCheckpointPath(config.SyncCheckpointPath)
+	if consensusEngine != nil {
+		// Cabeçalhos são verificados contra o conjunto de validadores do consenso
+		syncService.SetValidatorManager(consensusEngine.GetValidatorManager())
+	}
 	
 	p2pService := &P2PService{
 		host:            host,
@@ -149,6 +170,11 @@ func NewP2PService(
 		nodeID:          host.GetNodeID(),
 		config:          config,
 		stats:           &P2PStats{StartTime: time.Now()},
+		logger:          logging.Component("p2p"),
+	}
+	
+	if consensusEngine != nil {
+		p2pService.finalityTracker = consensus.NewFinalityTracker(consensusEngine.GetValidatorManager(), chainManager, cryptoService)
 	}
 	
 	// Configurar callbacks e integrações
@@ -198,6 +224,7 @@ func (p2p *P2PService) Start(ctx context.Context) error {
 	p2p.isRunning = true
 	p2p.stats.StartTime = time.Now()
 	
+	p2p.logger.InfoContext(ctx, "p2p service started", "peer_id", p2p.host.GetPeerID().String(), "addresses", p2p.host.GetMultiAddresses())
 	return nil
 }
 
@@ -257,6 +284,8 @@ func (p2p *P2PService) handlePeerConnected(peerID peer.ID) {
 	p2p.stats.ConnectedPeers++
 	p2p.mu.Unlock()
 	
+	p2p.logger.Info("peer connected", logging.Peer(peerID))
+	
 	if p2p.onPeerConnected != nil {
 		p2p.onPeerConnected(peerID)
 	}
@@ -269,6 +298,8 @@ func (p2p *P2PService) handlePeerDisconnected(peerID peer.ID) {
 	}
 	p2p.mu.Unlock()
 	
+	p2p.logger.Info("peer disconnected", logging.Peer(peerID))
+	
 	if p2p.onPeerDisconnected != nil {
 		p2p.onPeerDisconnected(peerID)
 	}
@@ -293,10 +324,13 @@ func (p2p *P2PService) handleSyncComplete(blocksAdded int) {
 	p2p.stats.BlocksReceived += blocksAdded
 	p2p.stats.LastSyncTime = time.Now()
 	p2p.mu.Unlock()
+	
+	// Atestações podem ter chegado antes dos blocos sincronizados
+	p2p.reevaluateFinality(context.Background())
 }
 
 func (p2p *P2PService) handleSyncError(err error) {
-	// Log do erro de sincronização
+	// Falha de sincronização (registrada pelo SyncService)
 	_ = err
 }
 
@@ -335,18 +369,20 @@ func (p2p *P2PService) handleBlockGossip(peerID peer.ID, msg *BlockGossipMessage
 		return fmt.Errorf("failed to deserialize block: %w", err)
 	}
 
-	// CORREÇÃO: Verificar se o bloco já existe antes de tentar validar
 	ctx := context.Background()
-	existingBlock, err := p2p.chainManager.GetBlockByIndex(ctx, block.GetIndex())
-	if err == nil && existingBlock != nil {
-		// Bloco já existe na blockchain, não precisa revalidar
-		return nil
-	}
-	
-	// Tentar adicionar à cadeia apenas se não existir
-	if err := p2p.chainManager.AddBlock(ctx, block); err != nil {
+	p2p.observeBlock(ctx, block)
+
+	// Adicionar à cadeia ou à árvore de forks (blocos já conhecidos são ignorados)
+	if err := p2p.chainManager.ProcessBlock(ctx, block); err != nil {
+		if errors.Is(err, blockchain.ErrUnknownParent) {
+			// Ramificação divergente: buscar ancestrais com o peer que enviou o bloco
+			go p2p.syncService.ResolveFork(context.Background(), peerID, block.GetIndex())
+			return nil
+		}
+		
 		// Log mais informativo em vez de silenciar
-		log.Printf("Block %d from P2P already processed or invalid (normal behavior): %v", block.GetIndex(), err)
+		p2p.logger.Debug("gossiped block already processed or invalid (normal behavior)",
+			logging.Height(block.GetIndex()), logging.Peer(peerID), logging.Err(err))
 		return nil
 	}
 	
@@ -354,6 +390,8 @@ func (p2p *P2PService) handleBlockGossip(peerID peer.ID, msg *BlockGossipMessage
 	p2p.stats.BlocksReceived++
 	p2p.mu.Unlock()
 	
+	p2p.reevaluateFinality(ctx)
+	
 	if p2p.onBlockReceived != nil {
 		p2p.onBlockReceived(block)
 	}
@@ -362,11 +400,101 @@ func (p2p *P2PService) handleBlockGossip(peerID peer.ID, msg *BlockGossipMessage
 }
 
 func (p2p *P2PService) handleConsensusMessage(peerID peer.ID, msgType MessageType, data json.RawMessage) error {
-	// Implementação de mensagens de consenso
-	// Por enquanto, apenas log
-	_ = peerID
-	_ = msgType
-	_ = data
+	switch msgType {
+	case MsgConsensusVote:
+		return p2p.handleAttestation(peerID, data)
+	case MsgConsensusProposal:
+		return p2p.handleProposal(peerID, data)
+	case MsgConsensusPrevote:
+		return p2p.handleRoundVote(peerID, entities.VotePrevote, data)
+	case MsgConsensusPrecommit:
+		return p2p.handleRoundVote(peerID, entities.VotePrecommit, data)
+	default:
+		return nil
+	}
+}
+
+// handleProposal entrega uma proposta de consenso BFT ao motor registrado
+func (p2p *P2PService) handleProposal(peerID peer.ID, data json.RawMessage) error {
+	p2p.mu.RLock()
+	callback := p2p.onProposalReceived
+	p2p.mu.RUnlock()
+	
+	if callback == nil {
+		return nil // Nenhum motor BFT neste nó
+	}
+	
+	var msg ConsensusProposalMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("failed to decode proposal: %w", err)
+	}
+	
+	proposal, err := deserializeProposal(&msg)
+	if err != nil {
+		return err
+	}
+	
+	// O bloco proposto também é assinado pelo proposer
+	p2p.observeBlock(context.Background(), proposal.GetBlock())
+	
+	callback(proposal, valueobjects.NewNodeID(peerID.String()))
+	return nil
+}
+
+// handleRoundVote entrega um prevote/precommit ao motor registrado
+func (p2p *P2PService) handleRoundVote(peerID peer.ID, voteType entities.ConsensusVoteType, data json.RawMessage) error {
+	p2p.mu.RLock()
+	callback := p2p.onVoteReceived
+	p2p.mu.RUnlock()
+	
+	if callback == nil {
+		return nil
+	}
+	
+	var msg ConsensusRoundVoteMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("failed to decode consensus vote: %w", err)
+	}
+	
+	vote, err := deserializeConsensusVote(voteType, &msg)
+	if err != nil {
+		return err
+	}
+	
+	callback(vote, valueobjects.NewNodeID(peerID.String()))
+	return nil
+}
+
+// handleAttestation registra a atestação recebida e a repassa aos peers se for nova
+func (p2p *P2PService) handleAttestation(peerID peer.ID, data json.RawMessage) error {
+	tracker := p2p.getFinalityTracker()
+	if tracker == nil {
+		return nil // Nó sem conjunto de validadores não acompanha finalidade
+	}
+	
+	var msg ConsensusVoteMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return fmt.Errorf("failed to decode consensus vote: %w", err)
+	}
+	
+	attestation := &entities.Attestation{}
+	if err := attestation.FromBytes(msg.Attestation); err != nil {
+		return fmt.Errorf("failed to decode attestation: %w", err)
+	}
+	
+	ctx := context.Background()
+	isNew, err := tracker.AddAttestation(ctx, attestation)
+	if err != nil {
+		p2p.logger.WarnContext(ctx, "attestation rejected", logging.Height(attestation.GetHeight()), logging.Peer(peerID), logging.Err(err))
+		return err
+	}
+	
+	// Repassar apenas atestações novas evita loops de gossip
+	if isNew {
+		if err := p2p.protocolManager.GossipAttestation(ctx, attestation); err != nil {
+			return fmt.Errorf("failed to relay attestation: %w", err)
+		}
+	}
 	
 	return nil
 }
@@ -399,6 +527,42 @@ func (p2p *P2PService) BroadcastBlock(ctx context.Context, block *entities.Block
 	return nil
 }
 
+// BroadcastAttestation registra localmente e propaga a atestação de um bloco
+func (p2p *P2PService) BroadcastAttestation(ctx context.Context, attestation *entities.Attestation) error {
+	if tracker := p2p.getFinalityTracker(); tracker != nil {
+		if _, err := tracker.AddAttestation(ctx, attestation); err != nil {
+			return fmt.Errorf("failed to record attestation: %w", err)
+		}
+	}
+	
+	if err := p2p.protocolManager.GossipAttestation(ctx, attestation); err != nil {
+		return fmt.Errorf("failed to gossip attestation: %w", err)
+	}
+	
+	return nil
+}
+
+// BroadcastProposal propaga a proposta de bloco de um round de consenso
+func (p2p *P2PService) BroadcastProposal(ctx context.Context, proposal *entities.ConsensusProposal) error {
+	if err := p2p.protocolManager.GossipProposal(ctx, proposal); err != nil {
+		return fmt.Errorf("failed to gossip proposal: %w", err)
+	}
+	return nil
+}
+
+// BroadcastConsensusVote propaga um prevote ou precommit
+func (p2p *P2PService) BroadcastConsensusVote(ctx context.Context, vote *entities.ConsensusVote) error {
+	if err := p2p.protocolManager.GossipConsensusVote(ctx, vote); err != nil {
+		return fmt.Errorf("failed to gossip consensus vote: %w", err)
+	}
+	return nil
+}
+
+// GetFinalizedHeight retorna a altura do último bloco final
+func (p2p *P2PService) GetFinalizedHeight(ctx context.Context) (uint64, bool) {
+	return p2p.chainManager.GetFinalizedHeight(ctx)
+}
+
 // ConnectToPeer conecta a um peer específico
 func (p2p *P2PService) ConnectToPeer(ctx context.Context, peerAddr string) error {
 	return p2p.host.Connect(ctx, peerAddr)
@@ -479,6 +643,20 @@ func (p2p *P2PService) SetOnBlockReceived(callback func(*entities.Block)) {
 	p2p.onBlockReceived = callback
 }
 
+// AddOnBlockReceived encadeia um callback aos blocos recebidos, preservando o já definido
+func (p2p *P2PService) AddOnBlockReceived(callback func(*entities.Block)) {
+	p2p.mu.Lock()
+	defer p2p.mu.Unlock()
+
+	previous := p2p.onBlockReceived
+	p2p.onBlockReceived = func(block *entities.Block) {
+		if previous != nil {
+			previous(block)
+		}
+		callback(block)
+	}
+}
+
 // SetOnTxReceived define callback para transações recebidas
 func (p2p *P2PService) SetOnTxReceived(callback func(*entities.Transaction)) {
 	p2p.mu.Lock()
@@ -486,6 +664,146 @@ func (p2p *P2PService) SetOnTxReceived(callback func(*entities.Transaction)) {
 	p2p.onTxReceived = callback
 }
 
+// SetOnProposalReceived define callback para propostas de consenso recebidas
+func (p2p *P2PService) SetOnProposalReceived(callback func(*entities.ConsensusProposal, valueobjects.NodeID)) {
+	p2p.mu.Lock()
+	defer p2p.mu.Unlock()
+	p2p.onProposalReceived = callback
+}
+
+// SetOnConsensusVoteReceived define callback para votos de consenso recebidos
+func (p2p *P2PService) SetOnConsensusVoteReceived(callback func(*entities.ConsensusVote, valueobjects.NodeID)) {
+	p2p.mu.Lock()
+	defer p2p.mu.Unlock()
+	p2p.onVoteReceived = callback
+}
+
+// SetValidatorManager define o conjunto de validadores usado na verificação de cabeçalhos
+func (p2p *P2PService) SetValidatorManager(validatorManager *consensus.ValidatorManager) {
+	p2p.syncService.SetValidatorManager(validatorManager)
+	
+	// As mesmas regras PoA são usadas para avaliar ramificações concorrentes
+	p2p.chainManager.SetBlockAuthority(consensus.NewBlockAuthority(validatorManager, p2p.cryptoService))
+	
+	// Quórum de finalidade é calculado sobre o mesmo conjunto de validadores
+	p2p.mu.Lock()
+	p2p.finalityTracker = consensus.NewFinalityTracker(validatorManager, p2p.chainManager, p2p.cryptoService)
+	p2p.mu.Unlock()
+}
+
+// SetDoubleSignDetector define o detector de assinatura dupla aplicado aos blocos recebidos
+// por gossip, sincronização, resolução de fork e propostas de consenso
+func (p2p *P2PService) SetDoubleSignDetector(detector *consensus.DoubleSignDetector) {
+	p2p.mu.Lock()
+	p2p.doubleSignDetector = detector
+	p2p.mu.Unlock()
+	
+	p2p.syncService.SetBlockObserver(p2p.observeBlock)
+}
+
+// SetSnapshotManager habilita o protocolo de snapshots: ofertas e downloads servidos aos
+// peers, gossip das assinaturas dos validadores e bootstrap de um nó vazio
+func (p2p *P2PService) SetSnapshotManager(manager *snapshot.Manager) {
+	p2p.syncService.SetSnapshotManager(manager)
+	manager.SetBroadcaster(p2p.protocolManager.GossipSnapshotSignature)
+	
+	p2p.protocolManager.SetSnapshotSignatureHandler(func(peerID peer.ID, msg *SnapshotSignatureMessage) error {
+		ctx := context.Background()
+		
+		added, err := manager.HandleSignature(ctx, msg.Signature)
+		if err != nil {
+			p2p.logger.DebugContext(ctx, "rejected snapshot signature", logging.Peer(peerID), logging.Err(err))
+			return err
+		}
+		
+		// Repassar assinaturas novas para que alcancem validadores sem conexão direta
+		if added {
+			return p2p.protocolManager.GossipSnapshotSignature(ctx, msg.Signature)
+		}
+		return nil
+	})
+}
+
+// SetLogger define o logger do serviço P2P
+func (p2p *P2PService) SetLogger(logger *slog.Logger) {
+	p2p.mu.Lock()
+	defer p2p.mu.Unlock()
+	
+	p2p.logger = logger
+}
+
+// SetSyncLogger define o logger do serviço de sincronização
+func (p2p *P2PService) SetSyncLogger(logger *slog.Logger) {
+	p2p.syncService.SetLogger(logger)
+}
+
+// SetMessageObserver define o observador das mensagens P2P recebidas e enviadas (defina antes de Start)
+func (p2p *P2PService) SetMessageObserver(observer MessageObserver) {
+	p2p.protocolManager.SetMessageObserver(observer)
+}
+
+// observeBlock registra o cabeçalho assinado do bloco e reporta evidências de assinatura dupla
+// ou de proposta inválida
+func (p2p *P2PService) observeBlock(ctx context.Context, block *entities.Block) {
+	p2p.mu.RLock()
+	detector := p2p.doubleSignDetector
+	p2p.mu.RUnlock()
+	
+	if detector == nil || block == nil {
+		return
+	}
+	
+	// Blocos sem assinatura válida são tratados pelas validações normais
+	if evidence, err := detector.Observe(ctx, block); err == nil && evidence != nil {
+		if err := p2p.submitEvidence(ctx, evidence); err != nil {
+			p2p.logger.ErrorContext(ctx, "failed to submit double sign evidence", "validator", evidence.GetValidator().String(), logging.Err(err))
+		}
+	}
+	
+	if evidence, err := detector.CheckProposal(ctx, block); err == nil && evidence != nil {
+		if err := p2p.submitEvidence(ctx, evidence); err != nil {
+			p2p.logger.ErrorContext(ctx, "failed to submit invalid block evidence", "validator", evidence.GetValidator().String(), logging.Err(err))
+		}
+	}
+}
+
+// submitEvidence envia a evidência ao consenso local e a propaga para os peers
+func (p2p *P2PService) submitEvidence(ctx context.Context, evidence consensus.Evidence) error {
+	tx, err := consensus.NewEvidenceTransaction(ctx, p2p.cryptoService, evidence, p2p.nodeID)
+	if err != nil {
+		return err
+	}
+	
+	p2p.mu.RLock()
+	onTxReceived := p2p.onTxReceived
+	p2p.mu.RUnlock()
+	
+	// Entregar ao motor de consenso local (PoA diretamente, BFT pelo handler registrado)
+	if p2p.consensusEngine != nil {
+		if err := p2p.consensusEngine.AddTransaction(ctx, tx); err != nil {
+			p2p.logger.WarnContext(ctx, "evidence transaction not added to local pool", logging.TxHash(tx.GetID()), logging.Err(err))
+		}
+	} else if onTxReceived != nil {
+		onTxReceived(tx)
+	}
+	
+	return p2p.BroadcastTransaction(ctx, tx)
+}
+
+// getFinalityTracker retorna o rastreador de finalidade atual
+func (p2p *P2PService) getFinalityTracker() *consensus.FinalityTracker {
+	p2p.mu.RLock()
+	defer p2p.mu.RUnlock()
+	return p2p.finalityTracker
+}
+
+// reevaluateFinality tenta finalizar blocos com atestações recebidas antes do bloco
+func (p2p *P2PService) reevaluateFinality(ctx context.Context) {
+	if tracker := p2p.getFinalityTracker(); tracker != nil {
+		tracker.Reevaluate(ctx)
+	}
+}
+
 // Ping envia ping para um peer
 func (p2p *P2PService) Ping(ctx context.Context, peerID peer.ID) (time.Duration, error) {
 	return p2p.protocolManager.Ping(ctx, peerID)
@@ -514,96 +832,11 @@ func (p2p *P2PService) SetCallbacks(
 // Métodos auxiliares de serialização/deserialização
 
 func (p2p *P2PService) deserializeTransaction(serialized *SerializedTransaction) (*entities.Transaction, error) {
-	// Implementação simplificada - em produção seria mais robusta
-	from := valueobjects.NewNodeID(serialized.From)
-	to := valueobjects.NewNodeID(serialized.To)
-	
-	tx := entities.NewTransaction(
-		entities.TransactionType(serialized.Type),
-		from,
-		to,
-		[]byte(serialized.Data),
-	)
-	
-	// Definir campos adicionais
-	if serialized.Hash != "" {
-		hash, err := valueobjects.NewHashFromString(serialized.Hash)
-		if err == nil {
-			tx.SetHash(hash)
-		}
-	}
-	
-	if serialized.ID != "" {
-		id, err := valueobjects.NewHashFromString(serialized.ID)
-		if err == nil {
-			tx.SetID(id)
-		}
-	}
-	
-	if serialized.Signature != "" {
-		sig, err := valueobjects.NewSignatureFromString(serialized.Signature)
-		if err == nil {
-			tx.SetSignature(sig)
-		}
-	}
-	
-	return tx, nil
+	return deserializeTransaction(serialized)
 }
 
 func (p2p *P2PService) deserializeBlock(serialized *SerializedBlock) (*entities.Block, error) {
-	if serialized == nil {
-		return nil, fmt.Errorf("serialized block is nil")
-	}
-	
-	// Converter campos básicos
-	previousHash, err := valueobjects.NewHashFromString(serialized.PreviousHash)
-	if err != nil {
-		return nil, fmt.Errorf("invalid previous hash: %w", err)
-	}
-	
-	merkleRoot, err := valueobjects.NewHashFromString(serialized.MerkleRoot)
-	if err != nil {
-		return nil, fmt.Errorf("invalid merkle root: %w", err)
-	}
-	
-	validator := valueobjects.NewNodeID(serialized.Validator)
-	
-	// Deserializar transações
-	transactions := make([]*entities.Transaction, len(serialized.Transactions))
-	for i, serializedTx := range serialized.Transactions {
-		tx, err := p2p.deserializeTransaction(serializedTx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to deserialize transaction %d: %w", i, err)
-		}
-		transactions[i] = tx
-	}
-	
-	// Criar bloco usando o BlockBuilder
-	blockBuilder := blockchain.NewBlockBuilder(p2p.cryptoService)
-	block, err := blockBuilder.BuildBlock(
-		context.Background(),
-		serialized.Index,
-		previousHash,
-		transactions,
-		validator,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to build block: %w", err)
-	}
-	
-	// Definir merkle root (se diferente do calculado)
-	block.SetMerkleRoot(merkleRoot)
-	
-	// Definir assinatura se presente
-	if serialized.Signature != "" {
-		signature, err := valueobjects.NewSignatureFromString(serialized.Signature)
-		if err != nil {
-			return nil, fmt.Errorf("invalid signature: %w", err)
-		}
-		block.SetSignature(signature)
-	}
-	
-	return block, nil
+	return deserializeBlock(serialized)
 }
 
 // GetDiscoveryStats retorna estatísticas de descoberta
@@ -616,3 +849,7 @@ func (p2p *P2PService) GetSyncStats() *SyncStats {
 	return p2p.syncService.GetSyncStats()
 }
 
+// GetSyncPeers retorna o status de cadeia informado por cada peer de sincronização
+func (p2p *P2PService) GetSyncPeers() map[peer.ID]*SyncPeerInfo {
+	return p2p.syncService.GetSyncPeers()
+}
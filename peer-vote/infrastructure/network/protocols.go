@@ -13,6 +13,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/protocol"
 
 	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/snapshot"
 )
 
 // Definições de protocolos
@@ -25,6 +26,8 @@ const (
 	ProtocolConsensus protocol.ID = "/peer-vote/consensus/1.0.0"
 	// ProtocolPing protocolo para ping/pong
 	ProtocolPing protocol.ID = "/peer-vote/ping/1.0.0"
+	// ProtocolSnapshot protocolo para oferta, download e assinatura de snapshots de estado
+	ProtocolSnapshot protocol.ID = "/peer-vote/snapshot/1.0.0"
 )
 
 // MessageType define tipos de mensagens
@@ -38,6 +41,8 @@ const (
 	MsgBlockRangeResp  MessageType = "BLOCK_RANGE_RESPONSE"
 	MsgChainStatusReq  MessageType = "CHAIN_STATUS_REQUEST"
 	MsgChainStatusResp MessageType = "CHAIN_STATUS_RESPONSE"
+	MsgHeaderRangeReq  MessageType = "HEADER_RANGE_REQUEST"
+	MsgHeaderRangeResp MessageType = "HEADER_RANGE_RESPONSE"
 	
 	// Mensagens de gossip
 	MsgTxGossip        MessageType = "TX_GOSSIP"
@@ -46,6 +51,15 @@ const (
 	// Mensagens de consenso
 	MsgConsensusProposal MessageType = "CONSENSUS_PROPOSAL"
 	MsgConsensusVote     MessageType = "CONSENSUS_VOTE"
+	MsgConsensusPrevote  MessageType = "CONSENSUS_PREVOTE"
+	MsgConsensusPrecommit MessageType = "CONSENSUS_PRECOMMIT"
+	
+	// Mensagens de snapshot
+	MsgSnapshotListReq   MessageType = "SNAPSHOT_LIST_REQUEST"
+	MsgSnapshotListResp  MessageType = "SNAPSHOT_LIST_RESPONSE"
+	MsgSnapshotReq       MessageType = "SNAPSHOT_REQUEST"
+	MsgSnapshotResp      MessageType = "SNAPSHOT_RESPONSE"
+	MsgSnapshotSignature MessageType = "SNAPSHOT_SIGNATURE"
 	
 	// Mensagens de controle
 	MsgPing            MessageType = "PING"
@@ -53,6 +67,32 @@ const (
 	MsgError           MessageType = "ERROR"
 )
 
+// IsKnown verifica se o tipo de mensagem faz parte do protocolo
+func (t MessageType) IsKnown() bool {
+	switch t {
+	case MsgBlockRequest, MsgBlockResponse, MsgBlockRangeReq, MsgBlockRangeResp,
+		MsgChainStatusReq, MsgChainStatusResp, MsgHeaderRangeReq, MsgHeaderRangeResp,
+		MsgTxGossip, MsgBlockGossip,
+		MsgConsensusProposal, MsgConsensusVote, MsgConsensusPrevote, MsgConsensusPrecommit,
+		MsgSnapshotListReq, MsgSnapshotListResp, MsgSnapshotReq, MsgSnapshotResp, MsgSnapshotSignature,
+		MsgPing, MsgPong, MsgError:
+		return true
+	default:
+		return false
+	}
+}
+
+// MessageDirection indica se uma mensagem P2P foi recebida ou enviada
+type MessageDirection string
+
+const (
+	MessageInbound  MessageDirection = "in"
+	MessageOutbound MessageDirection = "out"
+)
+
+// MessageObserver é notificado a cada mensagem P2P recebida ou enviada (ex: métricas)
+type MessageObserver func(direction MessageDirection, msgType MessageType)
+
 // Message representa uma mensagem P2P
 type Message struct {
 	Type      MessageType     `json:"type"`
@@ -89,6 +129,20 @@ type BlockRangeResponse struct {
 	ErrorMsg string             `json:"error_msg,omitempty"`
 }
 
+// HeaderRangeRequest requisição de faixa de cabeçalhos (sincronização headers-first)
+type HeaderRangeRequest struct {
+	StartIndex uint64 `json:"start_index"`
+	EndIndex   uint64 `json:"end_index"`
+	MaxHeaders int    `json:"max_headers"`
+}
+
+// HeaderRangeResponse resposta com faixa de cabeçalhos
+type HeaderRangeResponse struct {
+	Headers  []*SerializedHeader `json:"headers"`
+	HasMore  bool                `json:"has_more"`
+	ErrorMsg string              `json:"error_msg,omitempty"`
+}
+
 // ChainStatusRequest requisição de status da cadeia
 type ChainStatusRequest struct {
 	// Vazio por enquanto
@@ -103,6 +157,34 @@ type ChainStatusResponse struct {
 	LastBlockTime int64  `json:"last_block_time"`
 }
 
+// SnapshotListRequest requisição dos snapshots disponíveis em um peer
+type SnapshotListRequest struct {
+	// Vazio por enquanto
+}
+
+// SnapshotListResponse resposta com as ofertas de snapshot (sem o estado)
+type SnapshotListResponse struct {
+	Offers   []snapshot.Offer `json:"offers"`
+	ErrorMsg string           `json:"error_msg,omitempty"`
+}
+
+// SnapshotRequest requisição do snapshot completo em uma altura
+type SnapshotRequest struct {
+	Height uint64 `json:"height"`
+}
+
+// SnapshotResponse resposta com o snapshot completo
+type SnapshotResponse struct {
+	Snapshot *snapshot.Snapshot `json:"snapshot,omitempty"`
+	Found    bool               `json:"found"`
+	ErrorMsg string             `json:"error_msg,omitempty"`
+}
+
+// SnapshotSignatureMessage gossip da assinatura de um validador sobre um snapshot
+type SnapshotSignatureMessage struct {
+	Signature snapshot.Signature `json:"signature"`
+}
+
 // TxGossipMessage mensagem de gossip de transação
 type TxGossipMessage struct {
 	Transaction *SerializedTransaction `json:"transaction"`
@@ -117,6 +199,31 @@ type BlockGossipMessage struct {
 	SeenBy []string         `json:"seen_by"`
 }
 
+// ConsensusVoteMessage mensagem de voto de consenso (atestação de bloco)
+type ConsensusVoteMessage struct {
+	Attestation json.RawMessage `json:"attestation"`
+}
+
+// ConsensusProposalMessage mensagem de proposta de bloco do consenso BFT
+type ConsensusProposalMessage struct {
+	Height    uint64           `json:"height"`
+	Round     uint64           `json:"round"`
+	POLRound  int64            `json:"pol_round"`
+	Block     *SerializedBlock `json:"block"`
+	BlockHash string           `json:"block_hash"`
+	Proposer  string           `json:"proposer"`
+	Signature string           `json:"signature"`
+}
+
+// ConsensusRoundVoteMessage mensagem de prevote/precommit do consenso BFT
+type ConsensusRoundVoteMessage struct {
+	Height    uint64 `json:"height"`
+	Round     uint64 `json:"round"`
+	BlockHash string `json:"block_hash"`
+	Validator string `json:"validator"`
+	Signature string `json:"signature"`
+}
+
 // SerializedBlock representa um bloco serializado
 type SerializedBlock struct {
 	Index        uint64                    `json:"index"`
@@ -124,10 +231,25 @@ type SerializedBlock struct {
 	Timestamp    int64                     `json:"timestamp"`
 	MerkleRoot   string                    `json:"merkle_root"`
 	Validator    string                    `json:"validator"`
+	Nonce        uint64                    `json:"nonce"`
 	Signature    string                    `json:"signature"`
 	Transactions []*SerializedTransaction  `json:"transactions"`
 }
 
+// SerializedHeader representa o cabeçalho de um bloco serializado.
+// Inclui o resumo das transações (sem o campo data), que faz parte dos dados assinados
+// pelo validador, permitindo verificar assinatura e encadeamento sem baixar os corpos.
+type SerializedHeader struct {
+	Index        uint64                   `json:"index"`
+	PreviousHash string                   `json:"previous_hash"`
+	Timestamp    int64                    `json:"timestamp"`
+	MerkleRoot   string                   `json:"merkle_root"`
+	Validator    string                   `json:"validator"`
+	Nonce        uint64                   `json:"nonce"`
+	Signature    string                   `json:"signature"`
+	Transactions []*SerializedTransaction `json:"transactions"`
+}
+
 // SerializedTransaction representa uma transação serializada
 type SerializedTransaction struct {
 	ID        string `json:"id"`
@@ -168,9 +290,16 @@ type ProtocolManager struct {
 	blockRequestHandler    func(peer.ID, *BlockRequest) (*BlockResponse, error)
 	blockRangeHandler      func(peer.ID, *BlockRangeRequest) (*BlockRangeResponse, error)
 	chainStatusHandler     func(peer.ID, *ChainStatusRequest) (*ChainStatusResponse, error)
+	headerRangeHandler     func(peer.ID, *HeaderRangeRequest) (*HeaderRangeResponse, error)
 	txGossipHandler        func(peer.ID, *TxGossipMessage) error
 	blockGossipHandler     func(peer.ID, *BlockGossipMessage) error
 	consensusHandler       func(peer.ID, MessageType, json.RawMessage) error
+	snapshotListHandler    func(peer.ID, *SnapshotListRequest) (*SnapshotListResponse, error)
+	snapshotHandler        func(peer.ID, *SnapshotRequest) (*SnapshotResponse, error)
+	snapshotSigHandler     func(peer.ID, *SnapshotSignatureMessage) error
+	
+	// Observador de mensagens recebidas e enviadas
+	messageObserver MessageObserver
 	
 	// Cache de mensagens vistas (para evitar loops)
 	seenMessages map[string]time.Time
@@ -207,6 +336,7 @@ func (pm *ProtocolManager) registerProtocolHandlers() {
 	pm.host.RegisterProtocol(ProtocolTxGossip, pm.handleTxGossip)
 	pm.host.RegisterProtocol(ProtocolConsensus, pm.handleConsensus)
 	pm.host.RegisterProtocol(ProtocolPing, pm.handlePing)
+	pm.host.RegisterProtocol(ProtocolSnapshot, pm.handleSnapshot)
 }
 
 // handleBlockSync lida com protocolo de sincronização de blocos
@@ -232,6 +362,8 @@ func (pm *ProtocolManager) handleBlockSync(stream network.Stream) {
 		pm.handleBlockRangeRequest(peerID, msg, writer)
 	case MsgChainStatusReq:
 		pm.handleChainStatusRequest(peerID, msg, writer)
+	case MsgHeaderRangeReq:
+		pm.handleHeaderRangeRequest(peerID, msg, writer)
 	case MsgBlockGossip:
 		// CORREÇÃO: Tratar gossip de blocos no protocolo BlockSync
 		pm.handleBlockGossipMessage(peerID, msg)
@@ -327,6 +459,40 @@ func (pm *ProtocolManager) handleConsensus(stream network.Stream) {
 	}
 }
 
+// handleSnapshot lida com protocolo de snapshots de estado
+func (pm *ProtocolManager) handleSnapshot(stream network.Stream) {
+	defer stream.Close()
+	
+	reader := bufio.NewReader(stream)
+	writer := bufio.NewWriter(stream)
+	
+	// Ler mensagem
+	msg, err := pm.readMessage(reader)
+	if err != nil {
+		pm.sendError(writer, 400, "Failed to read message", err.Error())
+		return
+	}
+	
+	peerID := stream.Conn().RemotePeer()
+	
+	switch msg.Type {
+	case MsgSnapshotListReq:
+		pm.handleSnapshotListRequest(peerID, msg, writer)
+	case MsgSnapshotReq:
+		pm.handleSnapshotRequest(peerID, msg, writer)
+	case MsgSnapshotSignature:
+		var sigMsg SnapshotSignatureMessage
+		if err := json.Unmarshal(msg.Data, &sigMsg); err != nil {
+			return
+		}
+		if pm.snapshotSigHandler != nil {
+			pm.snapshotSigHandler(peerID, &sigMsg)
+		}
+	default:
+		pm.sendError(writer, 400, "Unknown message type", string(msg.Type))
+	}
+}
+
 // handlePing lida com protocolo de ping
 func (pm *ProtocolManager) handlePing(stream network.Stream) {
 	defer stream.Close()
@@ -433,6 +599,185 @@ func (pm *ProtocolManager) SendChainStatusRequest(ctx context.Context, peerID pe
 	return &response, nil
 }
 
+// SendBlockRangeRequest envia requisição de faixa de blocos completos
+func (pm *ProtocolManager) SendBlockRangeRequest(ctx context.Context, peerID peer.ID, startIndex, endIndex uint64, maxBlocks int) (*BlockRangeResponse, error) {
+	stream, err := pm.host.NewStream(ctx, peerID, ProtocolBlockSync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stream: %w", err)
+	}
+	defer stream.Close()
+	
+	writer := bufio.NewWriter(stream)
+	reader := bufio.NewReader(stream)
+	
+	// Enviar requisição
+	req := BlockRangeRequest{
+		StartIndex: startIndex,
+		EndIndex:   endIndex,
+		MaxBlocks:  maxBlocks,
+	}
+	if err := pm.sendMessage(writer, MsgBlockRangeReq, req, ""); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	
+	// Ler resposta
+	msg, err := pm.readMessage(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	
+	if msg.Type == MsgError {
+		var errMsg ErrorMessage
+		json.Unmarshal(msg.Data, &errMsg)
+		return nil, fmt.Errorf("peer error: %s", errMsg.Message)
+	}
+	
+	if msg.Type != MsgBlockRangeResp {
+		return nil, fmt.Errorf("unexpected response type: %s", msg.Type)
+	}
+	
+	var response BlockRangeResponse
+	if err := json.Unmarshal(msg.Data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	
+	if response.ErrorMsg != "" {
+		return nil, fmt.Errorf("peer error: %s", response.ErrorMsg)
+	}
+	
+	return &response, nil
+}
+
+// SendHeaderRangeRequest envia requisição de faixa de cabeçalhos
+func (pm *ProtocolManager) SendHeaderRangeRequest(ctx context.Context, peerID peer.ID, startIndex, endIndex uint64, maxHeaders int) (*HeaderRangeResponse, error) {
+	stream, err := pm.host.NewStream(ctx, peerID, ProtocolBlockSync)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stream: %w", err)
+	}
+	defer stream.Close()
+	
+	writer := bufio.NewWriter(stream)
+	reader := bufio.NewReader(stream)
+	
+	// Enviar requisição
+	req := HeaderRangeRequest{
+		StartIndex: startIndex,
+		EndIndex:   endIndex,
+		MaxHeaders: maxHeaders,
+	}
+	if err := pm.sendMessage(writer, MsgHeaderRangeReq, req, ""); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	
+	// Ler resposta
+	msg, err := pm.readMessage(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	
+	if msg.Type == MsgError {
+		var errMsg ErrorMessage
+		json.Unmarshal(msg.Data, &errMsg)
+		return nil, fmt.Errorf("peer error: %s", errMsg.Message)
+	}
+	
+	if msg.Type != MsgHeaderRangeResp {
+		return nil, fmt.Errorf("unexpected response type: %s", msg.Type)
+	}
+	
+	var response HeaderRangeResponse
+	if err := json.Unmarshal(msg.Data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	
+	if response.ErrorMsg != "" {
+		return nil, fmt.Errorf("peer error: %s", response.ErrorMsg)
+	}
+	
+	return &response, nil
+}
+
+// SendSnapshotListRequest pede a um peer as ofertas de snapshot disponíveis
+func (pm *ProtocolManager) SendSnapshotListRequest(ctx context.Context, peerID peer.ID) (*SnapshotListResponse, error) {
+	stream, err := pm.host.NewStream(ctx, peerID, ProtocolSnapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stream: %w", err)
+	}
+	defer stream.Close()
+	
+	writer := bufio.NewWriter(stream)
+	reader := bufio.NewReader(stream)
+	
+	if err := pm.sendMessage(writer, MsgSnapshotListReq, SnapshotListRequest{}, ""); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	
+	msg, err := pm.readMessage(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	
+	if msg.Type == MsgError {
+		var errMsg ErrorMessage
+		json.Unmarshal(msg.Data, &errMsg)
+		return nil, fmt.Errorf("peer error: %s", errMsg.Message)
+	}
+	
+	if msg.Type != MsgSnapshotListResp {
+		return nil, fmt.Errorf("unexpected response type: %s", msg.Type)
+	}
+	
+	var response SnapshotListResponse
+	if err := json.Unmarshal(msg.Data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	
+	return &response, nil
+}
+
+// SendSnapshotRequest baixa de um peer o snapshot completo na altura informada
+func (pm *ProtocolManager) SendSnapshotRequest(ctx context.Context, peerID peer.ID, height uint64) (*SnapshotResponse, error) {
+	stream, err := pm.host.NewStream(ctx, peerID, ProtocolSnapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stream: %w", err)
+	}
+	defer stream.Close()
+	
+	writer := bufio.NewWriter(stream)
+	reader := bufio.NewReader(stream)
+	
+	if err := pm.sendMessage(writer, MsgSnapshotReq, SnapshotRequest{Height: height}, ""); err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	
+	msg, err := pm.readMessage(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	
+	if msg.Type == MsgError {
+		var errMsg ErrorMessage
+		json.Unmarshal(msg.Data, &errMsg)
+		return nil, fmt.Errorf("peer error: %s", errMsg.Message)
+	}
+	
+	if msg.Type != MsgSnapshotResp {
+		return nil, fmt.Errorf("unexpected response type: %s", msg.Type)
+	}
+	
+	var response SnapshotResponse
+	if err := json.Unmarshal(msg.Data, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	
+	return &response, nil
+}
+
+// GossipSnapshotSignature propaga a assinatura de um validador sobre um snapshot
+func (pm *ProtocolManager) GossipSnapshotSignature(ctx context.Context, signature snapshot.Signature) error {
+	return pm.broadcastGossip(ctx, MsgSnapshotSignature, SnapshotSignatureMessage{Signature: signature})
+}
+
 // GossipTransaction propaga uma transação via gossip
 func (pm *ProtocolManager) GossipTransaction(ctx context.Context, tx *entities.Transaction) error {
 	serializedTx := pm.serializeTransaction(tx)
@@ -459,6 +804,49 @@ func (pm *ProtocolManager) GossipBlock(ctx context.Context, block *entities.Bloc
 	return pm.broadcastGossip(ctx, MsgBlockGossip, gossipMsg)
 }
 
+// GossipAttestation propaga a atestação de um validador para os peers
+func (pm *ProtocolManager) GossipAttestation(ctx context.Context, attestation *entities.Attestation) error {
+	data, err := attestation.ToBytes()
+	if err != nil {
+		return fmt.Errorf("failed to serialize attestation: %w", err)
+	}
+	
+	return pm.broadcastGossip(ctx, MsgConsensusVote, ConsensusVoteMessage{Attestation: data})
+}
+
+// GossipProposal propaga a proposta de bloco de um round de consenso
+func (pm *ProtocolManager) GossipProposal(ctx context.Context, proposal *entities.ConsensusProposal) error {
+	msg := ConsensusProposalMessage{
+		Height:    proposal.GetHeight(),
+		Round:     proposal.GetRound(),
+		POLRound:  proposal.GetPOLRound(),
+		Block:     serializeBlock(proposal.GetBlock()),
+		BlockHash: proposal.GetBlockHash().String(),
+		Proposer:  proposal.GetProposer().String(),
+		Signature: proposal.GetSignature().String(),
+	}
+	
+	return pm.broadcastGossip(ctx, MsgConsensusProposal, msg)
+}
+
+// GossipConsensusVote propaga um prevote ou precommit
+func (pm *ProtocolManager) GossipConsensusVote(ctx context.Context, vote *entities.ConsensusVote) error {
+	msgType := MsgConsensusPrevote
+	if vote.GetType() == entities.VotePrecommit {
+		msgType = MsgConsensusPrecommit
+	}
+	
+	msg := ConsensusRoundVoteMessage{
+		Height:    vote.GetHeight(),
+		Round:     vote.GetRound(),
+		BlockHash: vote.GetBlockHash().String(),
+		Validator: vote.GetValidator().String(),
+		Signature: vote.GetSignature().String(),
+	}
+	
+	return pm.broadcastGossip(ctx, msgType, msg)
+}
+
 // Ping envia ping para um peer
 func (pm *ProtocolManager) Ping(ctx context.Context, peerID peer.ID) (time.Duration, error) {
 	stream, err := pm.host.NewStream(ctx, peerID, ProtocolPing)
@@ -509,6 +897,7 @@ func (pm *ProtocolManager) readMessage(reader *bufio.Reader) (*Message, error) {
 		return nil, err
 	}
 	
+	pm.observeMessage(MessageInbound, msg.Type)
 	return &msg, nil
 }
 
@@ -538,7 +927,24 @@ func (pm *ProtocolManager) sendMessage(writer *bufio.Writer, msgType MessageType
 		return err
 	}
 	
-	return writer.Flush()
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	
+	pm.observeMessage(MessageOutbound, msgType)
+	return nil
+}
+
+// observeMessage notifica o observador de mensagens, se configurado.
+// Tipos desconhecidos recebidos de peers são agrupados em UNKNOWN.
+func (pm *ProtocolManager) observeMessage(direction MessageDirection, msgType MessageType) {
+	if pm.messageObserver == nil {
+		return
+	}
+	if !msgType.IsKnown() {
+		msgType = "UNKNOWN"
+	}
+	pm.messageObserver(direction, msgType)
 }
 
 func (pm *ProtocolManager) sendError(writer *bufio.Writer, code int, message, details string) {
@@ -585,33 +991,11 @@ func (pm *ProtocolManager) cleanupSeenMessages() {
 // Métodos de serialização
 
 func (pm *ProtocolManager) serializeBlock(block *entities.Block) *SerializedBlock {
-	transactions := make([]*SerializedTransaction, len(block.GetTransactions()))
-	for i, tx := range block.GetTransactions() {
-		transactions[i] = pm.serializeTransaction(tx)
-	}
-	
-	return &SerializedBlock{
-		Index:        block.GetIndex(),
-		PreviousHash: block.GetPreviousHash().String(),
-		Timestamp:    block.GetTimestamp().Unix(),
-		MerkleRoot:   block.GetMerkleRoot().String(),
-		Validator:    block.GetValidator().String(),
-		Signature:    block.GetSignature().String(),
-		Transactions: transactions,
-	}
+	return serializeBlock(block)
 }
 
 func (pm *ProtocolManager) serializeTransaction(tx *entities.Transaction) *SerializedTransaction {
-	return &SerializedTransaction{
-		ID:        tx.GetID().String(),
-		Type:      string(tx.GetType()),
-		From:      tx.GetFrom().String(),
-		To:        tx.GetTo().String(),
-		Data:      string(tx.GetData()), // Simplificado
-		Timestamp: tx.GetTimestamp().Unix(),
-		Signature: tx.GetSignature().String(),
-		Hash:      tx.GetHash().String(),
-	}
+	return serializeTransaction(tx)
 }
 
 // Setters para handlers
@@ -628,6 +1012,10 @@ func (pm *ProtocolManager) SetChainStatusHandler(handler func(peer.ID, *ChainSta
 	pm.chainStatusHandler = handler
 }
 
+func (pm *ProtocolManager) SetHeaderRangeHandler(handler func(peer.ID, *HeaderRangeRequest) (*HeaderRangeResponse, error)) {
+	pm.headerRangeHandler = handler
+}
+
 func (pm *ProtocolManager) SetTxGossipHandler(handler func(peer.ID, *TxGossipMessage) error) {
 	pm.txGossipHandler = handler
 }
@@ -636,10 +1024,27 @@ func (pm *ProtocolManager) SetBlockGossipHandler(handler func(peer.ID, *BlockGos
 	pm.blockGossipHandler = handler
 }
 
+// SetMessageObserver define o observador das mensagens recebidas e enviadas
+func (pm *ProtocolManager) SetMessageObserver(observer MessageObserver) {
+	pm.messageObserver = observer
+}
+
 func (pm *ProtocolManager) SetConsensusHandler(handler func(peer.ID, MessageType, json.RawMessage) error) {
 	pm.consensusHandler = handler
 }
 
+func (pm *ProtocolManager) SetSnapshotListHandler(handler func(peer.ID, *SnapshotListRequest) (*SnapshotListResponse, error)) {
+	pm.snapshotListHandler = handler
+}
+
+func (pm *ProtocolManager) SetSnapshotHandler(handler func(peer.ID, *SnapshotRequest) (*SnapshotResponse, error)) {
+	pm.snapshotHandler = handler
+}
+
+func (pm *ProtocolManager) SetSnapshotSignatureHandler(handler func(peer.ID, *SnapshotSignatureMessage) error) {
+	pm.snapshotSigHandler = handler
+}
+
 // Métodos de handler internos
 
 func (pm *ProtocolManager) handleBlockRequest(peerID peer.ID, msg *Message, writer *bufio.Writer) {
@@ -705,6 +1110,69 @@ func (pm *ProtocolManager) handleChainStatusRequest(peerID peer.ID, msg *Message
 	pm.sendMessage(writer, MsgChainStatusResp, response, msg.RequestID)
 }
 
+func (pm *ProtocolManager) handleHeaderRangeRequest(peerID peer.ID, msg *Message, writer *bufio.Writer) {
+	if pm.headerRangeHandler == nil {
+		pm.sendError(writer, 501, "Header range handler not implemented", "")
+		return
+	}
+	
+	var req HeaderRangeRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		pm.sendError(writer, 400, "Invalid request format", err.Error())
+		return
+	}
+	
+	response, err := pm.headerRangeHandler(peerID, &req)
+	if err != nil {
+		pm.sendError(writer, 500, "Handler error", err.Error())
+		return
+	}
+	
+	pm.sendMessage(writer, MsgHeaderRangeResp, response, msg.RequestID)
+}
+
+func (pm *ProtocolManager) handleSnapshotListRequest(peerID peer.ID, msg *Message, writer *bufio.Writer) {
+	if pm.snapshotListHandler == nil {
+		pm.sendError(writer, 501, "Snapshot list handler not implemented", "")
+		return
+	}
+	
+	var req SnapshotListRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		pm.sendError(writer, 400, "Invalid request format", err.Error())
+		return
+	}
+	
+	response, err := pm.snapshotListHandler(peerID, &req)
+	if err != nil {
+		pm.sendError(writer, 500, "Handler error", err.Error())
+		return
+	}
+	
+	pm.sendMessage(writer, MsgSnapshotListResp, response, msg.RequestID)
+}
+
+func (pm *ProtocolManager) handleSnapshotRequest(peerID peer.ID, msg *Message, writer *bufio.Writer) {
+	if pm.snapshotHandler == nil {
+		pm.sendError(writer, 501, "Snapshot handler not implemented", "")
+		return
+	}
+	
+	var req SnapshotRequest
+	if err := json.Unmarshal(msg.Data, &req); err != nil {
+		pm.sendError(writer, 400, "Invalid request format", err.Error())
+		return
+	}
+	
+	response, err := pm.snapshotHandler(peerID, &req)
+	if err != nil {
+		pm.sendError(writer, 500, "Handler error", err.Error())
+		return
+	}
+	
+	pm.sendMessage(writer, MsgSnapshotResp, response, msg.RequestID)
+}
+
 func (pm *ProtocolManager) broadcastGossip(ctx context.Context, msgType MessageType, data interface{}) error {
 	peers := pm.host.GetConnectedPeers()
 	
@@ -715,6 +1183,10 @@ func (pm *ProtocolManager) broadcastGossip(ctx context.Context, msgType MessageT
 		protocolID = ProtocolBlockSync // Usar protocolo de blocos para gossip de blocos
 	case MsgTxGossip:
 		protocolID = ProtocolTxGossip  // Usar protocolo de transações para gossip de transações
+	case MsgConsensusProposal, MsgConsensusVote, MsgConsensusPrevote, MsgConsensusPrecommit:
+		protocolID = ProtocolConsensus // Mensagens de consenso têm protocolo próprio
+	case MsgSnapshotSignature:
+		protocolID = ProtocolSnapshot
 	default:
 		protocolID = ProtocolTxGossip  // Fallback para protocolo de transações
 	}
@@ -774,7 +1246,9 @@ func (pm *ProtocolManager) propagateGossip(msg *Message, excludePeer peer.ID) {
 				msgBytes, _ := json.Marshal(msg)
 				msgBytes = append(msgBytes, '\n')
 				writer.Write(msgBytes)
-				writer.Flush()
+				if writer.Flush() == nil {
+					pm.observeMessage(MessageOutbound, msg.Type)
+				}
 			}(peerID)
 		}
 	}
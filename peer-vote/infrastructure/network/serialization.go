@@ -0,0 +1,241 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// Funções de (de)serialização compartilhadas pelos componentes de rede.
+// A reconstrução preserva timestamp, nonce e assinatura originais, de forma que o
+// hash calculado localmente seja idêntico ao hash calculado pelo nó de origem.
+
+// serializeBlock converte um bloco para o formato de transmissão
+func serializeBlock(block *entities.Block) *SerializedBlock {
+	transactions := make([]*SerializedTransaction, len(block.GetTransactions()))
+	for i, tx := range block.GetTransactions() {
+		transactions[i] = serializeTransaction(tx)
+	}
+
+	return &SerializedBlock{
+		Index:        block.GetIndex(),
+		PreviousHash: block.GetPreviousHash().String(),
+		Timestamp:    block.GetTimestamp().Unix(),
+		MerkleRoot:   block.GetMerkleRoot().String(),
+		Validator:    block.GetValidator().String(),
+		Nonce:        block.GetNonce(),
+		Signature:    block.GetSignature().String(),
+		Transactions: transactions,
+	}
+}
+
+// serializeHeader converte o cabeçalho de um bloco para o formato de transmissão
+func serializeHeader(block *entities.Block) *SerializedHeader {
+	transactions := make([]*SerializedTransaction, len(block.GetTransactions()))
+	for i, tx := range block.GetTransactions() {
+		summary := serializeTransaction(tx)
+		summary.Data = "" // Cabeçalhos não carregam os dados das transações
+		transactions[i] = summary
+	}
+
+	return &SerializedHeader{
+		Index:        block.GetIndex(),
+		PreviousHash: block.GetPreviousHash().String(),
+		Timestamp:    block.GetTimestamp().Unix(),
+		MerkleRoot:   block.GetMerkleRoot().String(),
+		Validator:    block.GetValidator().String(),
+		Nonce:        block.GetNonce(),
+		Signature:    block.GetSignature().String(),
+		Transactions: transactions,
+	}
+}
+
+// serializeTransaction converte uma transação para o formato de transmissão
+func serializeTransaction(tx *entities.Transaction) *SerializedTransaction {
+	return &SerializedTransaction{
+		ID:        tx.GetID().String(),
+		Type:      string(tx.GetType()),
+		From:      tx.GetFrom().String(),
+		To:        tx.GetTo().String(),
+		Data:      string(tx.GetData()), // Simplificado
+		Timestamp: tx.GetTimestamp().Unix(),
+		Signature: tx.GetSignature().String(),
+		Hash:      tx.GetHash().String(),
+	}
+}
+
+// deserializeBlock reconstrói um bloco a partir do formato de transmissão
+func deserializeBlock(serialized *SerializedBlock) (*entities.Block, error) {
+	if serialized == nil {
+		return nil, fmt.Errorf("serialized block is nil")
+	}
+
+	transactions := make([]*entities.Transaction, len(serialized.Transactions))
+	for i, serializedTx := range serialized.Transactions {
+		tx, err := deserializeTransaction(serializedTx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize transaction %d: %w", i, err)
+		}
+		transactions[i] = tx
+	}
+
+	return restoreBlock(
+		serialized.Index,
+		serialized.PreviousHash,
+		serialized.Timestamp,
+		serialized.MerkleRoot,
+		serialized.Validator,
+		serialized.Nonce,
+		serialized.Signature,
+		transactions,
+	)
+}
+
+// deserializeHeader reconstrói um bloco contendo apenas o resumo das transações.
+// O resultado serve para verificar assinatura e encadeamento, mas não para ser
+// adicionado à cadeia (as transações não possuem dados).
+func deserializeHeader(serialized *SerializedHeader) (*entities.Block, error) {
+	if serialized == nil {
+		return nil, fmt.Errorf("serialized header is nil")
+	}
+
+	transactions := make([]*entities.Transaction, len(serialized.Transactions))
+	for i, serializedTx := range serialized.Transactions {
+		tx, err := deserializeTransaction(serializedTx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize transaction summary %d: %w", i, err)
+		}
+		transactions[i] = tx
+	}
+
+	return restoreBlock(
+		serialized.Index,
+		serialized.PreviousHash,
+		serialized.Timestamp,
+		serialized.MerkleRoot,
+		serialized.Validator,
+		serialized.Nonce,
+		serialized.Signature,
+		transactions,
+	)
+}
+
+// restoreBlock reconstrói um bloco a partir dos campos do cabeçalho
+func restoreBlock(index uint64, previousHashStr string, timestamp int64, merkleRootStr, validatorStr string, nonce uint64, signatureStr string, transactions []*entities.Transaction) (*entities.Block, error) {
+	previousHash, err := valueobjects.NewHashFromString(previousHashStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid previous hash: %w", err)
+	}
+
+	merkleRoot, err := valueobjects.NewHashFromString(merkleRootStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid merkle root: %w", err)
+	}
+
+	block := entities.NewBlock(index, previousHash, transactions, valueobjects.NewNodeID(validatorStr))
+	block.SetTimestamp(valueobjects.Unix(timestamp, 0))
+	block.SetNonce(nonce)
+	block.SetMerkleRoot(merkleRoot)
+
+	if signatureStr != "" {
+		signature, err := valueobjects.NewSignatureFromString(signatureStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature: %w", err)
+		}
+		block.SetSignature(signature)
+	}
+
+	return block, nil
+}
+
+// deserializeTransaction reconstrói uma transação a partir do formato de transmissão
+func deserializeTransaction(serialized *SerializedTransaction) (*entities.Transaction, error) {
+	if serialized == nil {
+		return nil, fmt.Errorf("serialized transaction is nil")
+	}
+
+	tx := entities.NewTransaction(
+		entities.TransactionType(serialized.Type),
+		valueobjects.NewNodeID(serialized.From),
+		valueobjects.NewNodeID(serialized.To),
+		[]byte(serialized.Data),
+	)
+
+	tx.SetTimestamp(valueobjects.Unix(serialized.Timestamp, 0))
+
+	if serialized.ID != "" {
+		id, err := valueobjects.NewHashFromString(serialized.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction id: %w", err)
+		}
+		tx.SetID(id)
+	}
+
+	if serialized.Hash != "" {
+		hash, err := valueobjects.NewHashFromString(serialized.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction hash: %w", err)
+		}
+		tx.SetHash(hash)
+	}
+
+	if serialized.Signature != "" {
+		sig, err := valueobjects.NewSignatureFromString(serialized.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("invalid transaction signature: %w", err)
+		}
+		tx.SetSignature(sig)
+	}
+
+	return tx, nil
+}
+
+// deserializeProposal reconstrói uma proposta de consenso a partir do formato de transmissão
+func deserializeProposal(msg *ConsensusProposalMessage) (*entities.ConsensusProposal, error) {
+	if msg == nil || msg.Block == nil {
+		return nil, fmt.Errorf("serialized proposal is empty")
+	}
+
+	block, err := deserializeBlock(msg.Block)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposed block: %w", err)
+	}
+
+	blockHash, err := valueobjects.NewHashFromString(msg.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal block hash: %w", err)
+	}
+
+	proposal := entities.NewConsensusProposal(msg.Height, msg.Round, msg.POLRound, block, blockHash, valueobjects.NewNodeID(msg.Proposer))
+
+	sig, err := valueobjects.NewSignatureFromString(msg.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proposal signature: %w", err)
+	}
+	proposal.SetSignature(sig)
+
+	return proposal, nil
+}
+
+// deserializeConsensusVote reconstrói um prevote/precommit a partir do formato de transmissão
+func deserializeConsensusVote(voteType entities.ConsensusVoteType, msg *ConsensusRoundVoteMessage) (*entities.ConsensusVote, error) {
+	if msg == nil {
+		return nil, fmt.Errorf("serialized vote is nil")
+	}
+
+	blockHash, err := valueobjects.NewHashFromString(msg.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vote block hash: %w", err)
+	}
+
+	vote := entities.NewConsensusVote(voteType, msg.Height, msg.Round, blockHash, valueobjects.NewNodeID(msg.Validator))
+
+	sig, err := valueobjects.NewSignatureFromString(msg.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid vote signature: %w", err)
+	}
+	vote.SetSignature(sig)
+
+	return vote, nil
+}
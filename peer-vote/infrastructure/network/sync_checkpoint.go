@@ -0,0 +1,127 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// syncCheckpoint representa o progresso persistido da sincronização headers-first
+type syncCheckpoint struct {
+	TargetHeight uint64              `json:"target_height"`
+	GenesisHash  string              `json:"genesis_hash"`
+	Headers      []*SerializedHeader `json:"headers"`
+	SavedAt      int64               `json:"saved_at"`
+}
+
+// saveCheckpoint persiste os cabeçalhos verificados que ainda aguardam corpo
+func (ss *SyncService) saveCheckpoint() {
+	ss.mu.RLock()
+	path := ss.checkpointPath
+	checkpoint := &syncCheckpoint{
+		TargetHeight: ss.targetHeight,
+		GenesisHash:  ss.expectedGenesis,
+		Headers:      make([]*SerializedHeader, len(ss.verifiedHeaders)),
+		SavedAt:      time.Now().Unix(),
+	}
+	for i, vh := range ss.verifiedHeaders {
+		checkpoint.Headers[i] = vh.header
+	}
+	ss.mu.RUnlock()
+
+	if path == "" {
+		return // Persistência desabilitada
+	}
+
+	if err := writeSyncCheckpoint(path, checkpoint); err != nil && ss.onSyncError != nil {
+		ss.onSyncError(fmt.Errorf("failed to save sync checkpoint: %w", err))
+	}
+}
+
+// restoreCheckpoint carrega o progresso salvo uma única vez, reverificando os cabeçalhos
+func (ss *SyncService) restoreCheckpoint(ctx context.Context) {
+	ss.mu.Lock()
+	if ss.checkpointLoaded || ss.checkpointPath == "" {
+		ss.mu.Unlock()
+		return
+	}
+	ss.checkpointLoaded = true
+	path := ss.checkpointPath
+	ss.mu.Unlock()
+
+	checkpoint, err := readSyncCheckpoint(path)
+	if err != nil {
+		if !os.IsNotExist(err) && ss.onSyncError != nil {
+			ss.onSyncError(fmt.Errorf("failed to load sync checkpoint: %w", err))
+		}
+		return
+	}
+
+	// Checkpoint de outra cadeia não pode ser aproveitado
+	if checkpoint.GenesisHash != ss.getExpectedGenesis() {
+		return
+	}
+
+	// Os cabeçalhos do arquivo não são confiáveis: verificar novamente a partir do topo local
+	anchorIndex, previousHash, hasAnchor := ss.headerAnchor(ctx)
+	restored := make([]*verifiedHeader, 0, len(checkpoint.Headers))
+
+	for _, header := range checkpoint.Headers {
+		if header == nil || (hasAnchor && header.Index <= anchorIndex) {
+			continue
+		}
+
+		hash, err := ss.verifyHeader(ctx, header, previousHash)
+		if err != nil {
+			break // Manter apenas o prefixo válido
+		}
+
+		restored = append(restored, &verifiedHeader{header: header, hash: hash})
+		previousHash = hash
+	}
+
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+
+	if len(ss.verifiedHeaders) == 0 {
+		ss.verifiedHeaders = restored
+		ss.headersVerified += uint64(len(restored))
+	}
+}
+
+// writeSyncCheckpoint grava o checkpoint de forma atômica (arquivo temporário + rename)
+func writeSyncCheckpoint(path string, checkpoint *syncCheckpoint) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create checkpoint directory: %w", err)
+	}
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// readSyncCheckpoint lê um checkpoint salvo anteriormente
+func readSyncCheckpoint(path string) (*syncCheckpoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint syncCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checkpoint: %w", err)
+	}
+
+	return &checkpoint, nil
+}
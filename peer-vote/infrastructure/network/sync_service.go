@@ -2,7 +2,10 @@ package network
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sort"
 	"sync"
 	"time"
 
@@ -12,25 +15,55 @@ import (
 	"github.com/matscats/peer-vote/peer-vote/domain/services"
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
-	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/snapshot"
 )
 
 // SyncService gerencia sincronização de blockchain entre peers
+// A sincronização segue a estratégia headers-first: primeiro a cadeia de cabeçalhos
+// é baixada de vários peers em paralelo e verificada (encadeamento e assinaturas dos
+// validadores), depois os corpos são baixados concorrentemente e conferidos contra
+// os cabeçalhos já verificados antes de serem adicionados à cadeia.
 type SyncService struct {
-	chainManager    *blockchain.ChainManager
-	protocolManager *ProtocolManager
-	host            *LibP2PHost
+	chainManager     *blockchain.ChainManager
+	protocolManager  *ProtocolManager
+	host             *LibP2PHost
+	blockBuilder     *blockchain.BlockBuilder
+	validatorManager *consensus.ValidatorManager
+	
+	// Snapshots de estado servidos aos peers e usados para iniciar um nó vazio (nil desabilita)
+	snapshotManager *snapshot.Manager
 	
 	// Estado da sincronização
 	isSyncing       bool
 	syncPeers       map[peer.ID]*SyncPeerInfo
 	lastSyncTime    time.Time
+	expectedGenesis string
+	
+	// Cabeçalhos verificados aguardando download dos corpos (ordenados por índice)
+	verifiedHeaders   []*verifiedHeader
+	checkpointPath    string
+	checkpointLoaded  bool
+	
+	// Progresso e estatísticas
+	phase           SyncPhase
+	targetHeight    uint64
+	headersVerified uint64
+	bodiesApplied   uint64
+	blocksReceived  int
+	syncAttempts    int
+	failureCount    int
+	totalLatency    time.Duration
+	latencySamples  int
 	
 	// Configurações
-	syncInterval    time.Duration
-	maxSyncPeers    int
-	blockBatchSize  int
-	syncTimeout     time.Duration
+	syncInterval     time.Duration
+	maxSyncPeers     int
+	blockBatchSize   int
+	headerBatchSize  int
+	syncTimeout      time.Duration
+	maxFetchAttempts int
 	
 	// Canais
 	syncRequestChan chan SyncRequest
@@ -43,13 +76,46 @@ type SyncService struct {
 	onSyncStart     func()
 	onSyncComplete  func(blocksAdded int)
 	onSyncError     func(error)
+	
+	// Observador de cabeçalhos assinados (detecção de assinatura dupla)
+	blockObserver func(context.Context, *entities.Block)
+	
+	logger *slog.Logger
 }
 
+// SyncPhase representa a fase atual da sincronização
+type SyncPhase string
+
+const (
+	// SyncPhaseIdle nenhuma sincronização em andamento
+	SyncPhaseIdle SyncPhase = "IDLE"
+	// SyncPhaseHeaders baixando e verificando cabeçalhos
+	SyncPhaseHeaders SyncPhase = "HEADERS"
+	// SyncPhaseBodies baixando e aplicando corpos dos blocos
+	SyncPhaseBodies SyncPhase = "BODIES"
+)
+
+// verifiedHeader representa um cabeçalho já verificado e seu hash
+type verifiedHeader struct {
+	header *SerializedHeader
+	hash   valueobjects.Hash
+}
+
+// syncRange representa uma faixa contígua de alturas a ser baixada
+type syncRange struct {
+	start uint64
+	end   uint64
+}
+
+// syncFetchFunc baixa uma faixa de um peer específico
+type syncFetchFunc func(ctx context.Context, peerID peer.ID, r syncRange) error
+
 // SyncPeerInfo contém informações sobre um peer de sincronização
 type SyncPeerInfo struct {
 	PeerID        peer.ID
 	ChainHeight   uint64
 	LatestHash    string
+	GenesisHash   string
 	LastContact   time.Time
 	IsReliable    bool
 	SyncAttempts  int
@@ -73,21 +139,36 @@ type SyncStats struct {
 	SyncAttempts    int
 	FailureCount    int
 	AverageLatency  time.Duration
+	
+	// Progresso da sincronização headers-first
+	Phase           SyncPhase
+	CurrentHeight   uint64
+	TargetHeight    uint64
+	HeadersVerified uint64
+	BodiesApplied   uint64
+	PendingHeaders  int
+	Progress        float64 // Percentual (0-100) da altura alvo já alcançada
 }
 
 // NewSyncService cria um novo serviço de sincronização
-func NewSyncService(chainManager *blockchain.ChainManager, protocolManager *ProtocolManager, host *LibP2PHost) *SyncService {
+func NewSyncService(chainManager *blockchain.ChainManager, protocolManager *ProtocolManager, host *LibP2PHost, cryptoService services.CryptographyService) *SyncService {
 	ss := &SyncService{
-		chainManager:    chainManager,
-		protocolManager: protocolManager,
-		host:            host,
-		syncPeers:       make(map[peer.ID]*SyncPeerInfo),
-		syncInterval:    time.Second * 30,
-		maxSyncPeers:    5,
-		blockBatchSize:  50,
-		syncTimeout:     time.Second * 60,
-		syncRequestChan: make(chan SyncRequest, 100),
-		stopChan:        make(chan struct{}),
+		chainManager:     chainManager,
+		protocolManager:  protocolManager,
+		host:             host,
+		blockBuilder:     blockchain.NewBlockBuilder(cryptoService),
+		syncPeers:        make(map[peer.ID]*SyncPeerInfo),
+		verifiedHeaders:  make([]*verifiedHeader, 0),
+		phase:            SyncPhaseIdle,
+		syncInterval:     time.Second * 30,
+		maxSyncPeers:     5,
+		blockBatchSize:   50,
+		headerBatchSize:  500,
+		syncTimeout:      time.Second * 60,
+		maxFetchAttempts: 3,
+		syncRequestChan:  make(chan SyncRequest, 100),
+		stopChan:         make(chan struct{}),
+		logger:           logging.Component("sync"),
 	}
 	
 	// Configurar handlers de protocolo
@@ -96,6 +177,14 @@ func NewSyncService(chainManager *blockchain.ChainManager, protocolManager *Prot
 	return ss
 }
 
+// SetLogger define o logger do serviço de sincronização
+func (ss *SyncService) SetLogger(logger *slog.Logger) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	
+	ss.logger = logger
+}
+
 // Start inicia o serviço de sincronização
 func (ss *SyncService) Start(ctx context.Context) error {
 	ss.mu.Lock()
@@ -135,6 +224,9 @@ func (ss *SyncService) setupProtocolHandlers() {
 	// Handler para requisições de status da cadeia
 	ss.protocolManager.SetChainStatusHandler(ss.handleChainStatusRequest)
 	
+	// Handler para requisições de faixa de cabeçalhos
+	ss.protocolManager.SetHeaderRangeHandler(ss.handleHeaderRangeRequest)
+	
 	// Handler para gossip de blocos
 	ss.protocolManager.SetBlockGossipHandler(ss.handleBlockGossip)
 }
@@ -158,17 +250,42 @@ func (ss *SyncService) syncLoop(ctx context.Context) {
 
 // performSync executa uma rodada de sincronização
 func (ss *SyncService) performSync(ctx context.Context) {
+	// Descobrir peers e obter status das cadeias
+	if err := ss.discoverSyncPeers(ctx); err != nil {
+		ss.logger.DebugContext(ctx, "failed to discover sync peers", logging.Err(err))
+		if ss.onSyncError != nil {
+			ss.onSyncError(fmt.Errorf("failed to discover sync peers: %w", err))
+		}
+		return
+	}
+	
+	// Nó vazio: tentar iniciar a partir de um snapshot confiável antes de baixar blocos
+	ss.bootstrapFromSnapshot(ctx)
+	
+	// Determinar se precisamos sincronizar
+	needsSync, peers, targetHeight := ss.needsSynchronization(ctx)
+	if !needsSync {
+		return
+	}
+	
+	ss.runSync(ctx, peers, targetHeight)
+}
+
+// runSync executa a sincronização headers-first com os peers informados
+func (ss *SyncService) runSync(ctx context.Context, peers []peer.ID, targetHeight uint64) {
 	ss.mu.Lock()
 	if ss.isSyncing {
 		ss.mu.Unlock()
 		return
 	}
 	ss.isSyncing = true
+	ss.syncAttempts++
 	ss.mu.Unlock()
 	
 	defer func() {
 		ss.mu.Lock()
 		ss.isSyncing = false
+		ss.phase = SyncPhaseIdle
 		ss.lastSyncTime = time.Now()
 		ss.mu.Unlock()
 	}()
@@ -177,29 +294,29 @@ func (ss *SyncService) performSync(ctx context.Context) {
 		ss.onSyncStart()
 	}
 	
-	// Descobrir peers e obter status das cadeias
-	if err := ss.discoverSyncPeers(ctx); err != nil {
-		if ss.onSyncError != nil {
-			ss.onSyncError(fmt.Errorf("failed to discover sync peers: %w", err))
-		}
-		return
-	}
-	
-	// Determinar se precisamos sincronizar
-	needsSync, bestPeer := ss.needsSynchronization(ctx)
-	if !needsSync {
-		return
-	}
+	startHeight, _ := ss.chainManager.GetChainHeight(ctx)
+	ss.logger.InfoContext(ctx, "synchronization started", logging.Height(startHeight), "target_height", targetHeight, "peers", len(peers))
+	started := time.Now()
 	
 	// Executar sincronização
-	blocksAdded, err := ss.synchronizeWithPeer(ctx, bestPeer)
+	blocksAdded, err := ss.synchronizeHeadersFirst(ctx, peers, targetHeight)
 	if err != nil {
+		ss.mu.Lock()
+		ss.failureCount++
+		ss.mu.Unlock()
+		
+		ss.logger.WarnContext(ctx, "synchronization failed", "target_height", targetHeight, "blocks_added", blocksAdded, logging.Err(err))
+		
 		if ss.onSyncError != nil {
 			ss.onSyncError(fmt.Errorf("synchronization failed: %w", err))
 		}
 		return
 	}
 	
+	currentHeight, _ := ss.chainManager.GetChainHeight(ctx)
+	ss.logger.InfoContext(ctx, "synchronization completed", logging.Height(currentHeight),
+		"blocks_added", blocksAdded, "duration_ms", time.Since(started).Milliseconds())
+	
 	if ss.onSyncComplete != nil {
 		ss.onSyncComplete(blocksAdded)
 	}
@@ -222,11 +339,13 @@ func (ss *SyncService) discoverSyncPeers(ctx context.Context) error {
 // updatePeerInfo atualiza informações sobre um peer
 func (ss *SyncService) updatePeerInfo(ctx context.Context, peerID peer.ID) {
 	// Solicitar status da cadeia
+	start := time.Now()
 	status, err := ss.protocolManager.SendChainStatusRequest(ctx, peerID)
 	if err != nil {
 		ss.markPeerUnreliable(peerID)
 		return
 	}
+	ss.recordLatency(time.Since(start))
 	
 	ss.mu.Lock()
 	defer ss.mu.Unlock()
@@ -241,79 +360,441 @@ func (ss *SyncService) updatePeerInfo(ctx context.Context, peerID peer.ID) {
 	
 	peerInfo.ChainHeight = status.Height
 	peerInfo.LatestHash = status.LatestHash
+	peerInfo.GenesisHash = status.GenesisHash
 	peerInfo.LastContact = time.Now()
 	peerInfo.IsReliable = true
 }
 
-// needsSynchronization determina se precisamos sincronizar
-func (ss *SyncService) needsSynchronization(ctx context.Context) (bool, peer.ID) {
-	ss.mu.RLock()
-	defer ss.mu.RUnlock()
-	
-	// Obter altura atual da nossa cadeia
+// needsSynchronization determina se precisamos sincronizar e com quais peers
+func (ss *SyncService) needsSynchronization(ctx context.Context) (bool, []peer.ID, uint64) {
+	// Obter estado atual da nossa cadeia
 	ourHeight, err := ss.chainManager.GetChainHeight(ctx)
 	if err != nil {
-		return false, ""
+		return false, nil, 0
 	}
 	
-	// Encontrar o melhor peer (maior altura)
-	var bestPeer peer.ID
-	var bestHeight uint64
+	ourGenesis := ""
+	hasGenesis := false
+	if genesisHash, err := ss.chainManager.GetGenesisHash(ctx); err == nil {
+		ourGenesis = genesisHash.String()
+		hasGenesis = true
+	}
 	
-	for peerID, peerInfo := range ss.syncPeers {
-		if peerInfo.IsReliable && peerInfo.ChainHeight > bestHeight {
-			bestPeer = peerID
-			bestHeight = peerInfo.ChainHeight
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	
+	// Sem gênesis local, adotar o gênesis anunciado pela maioria dos peers
+	if !hasGenesis {
+		ourGenesis = ss.majorityGenesis()
+	}
+	ss.expectedGenesis = ourGenesis
+	
+	// Selecionar peers confiáveis na mesma cadeia e à nossa frente
+	var candidates []*SyncPeerInfo
+	for _, peerInfo := range ss.syncPeers {
+		if !peerInfo.IsReliable || peerInfo.GenesisHash != ourGenesis {
+			continue
+		}
+		if hasGenesis && peerInfo.ChainHeight <= ourHeight {
+			continue
 		}
+		candidates = append(candidates, peerInfo)
+	}
+	
+	if len(candidates) == 0 {
+		return false, nil, 0
+	}
+	
+	// Preferir os peers com maior altura
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].ChainHeight > candidates[j].ChainHeight
+	})
+	
+	if len(candidates) > ss.maxSyncPeers {
+		candidates = candidates[:ss.maxSyncPeers]
+	}
+	
+	peers := make([]peer.ID, len(candidates))
+	for i, peerInfo := range candidates {
+		peers[i] = peerInfo.PeerID
 	}
 	
-	// Sincronizar se o peer tem mais blocos que nós
-	return bestHeight > ourHeight, bestPeer
+	return true, peers, candidates[0].ChainHeight
 }
 
-// synchronizeWithPeer sincroniza com um peer específico
-func (ss *SyncService) synchronizeWithPeer(ctx context.Context, peerID peer.ID) (int, error) {
-	if peerID == "" {
+// bootstrapFromSnapshot inicia um nó vazio a partir do snapshot confiável mais alto oferecido
+// pelos peers. Ofertas sem quorum do conjunto de validadores confiável são ignoradas; o
+// snapshot baixado é verificado por completo antes de ser restaurado.
+func (ss *SyncService) bootstrapFromSnapshot(ctx context.Context) {
+	ss.mu.RLock()
+	manager := ss.snapshotManager
+	ss.mu.RUnlock()
+	
+	if manager == nil || !manager.NeedsBootstrap(ctx) {
+		return
+	}
+	
+	type snapshotCandidate struct {
+		peerID peer.ID
+		offer  snapshot.Offer
+	}
+	
+	var candidates []snapshotCandidate
+	for _, peerID := range ss.host.GetConnectedPeers() {
+		response, err := ss.protocolManager.SendSnapshotListRequest(ctx, peerID)
+		if err != nil {
+			ss.logger.DebugContext(ctx, "failed to list peer snapshots", logging.Peer(peerID), logging.Err(err))
+			continue
+		}
+		for _, offer := range response.Offers {
+			if err := manager.CheckOffer(ctx, offer); err != nil {
+				ss.logger.DebugContext(ctx, "ignoring untrusted snapshot offer", logging.Peer(peerID), logging.Height(offer.Height), logging.Err(err))
+				continue
+			}
+			candidates = append(candidates, snapshotCandidate{peerID: peerID, offer: offer})
+		}
+	}
+	
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].offer.Height > candidates[j].offer.Height
+	})
+	
+	for _, candidate := range candidates {
+		response, err := ss.protocolManager.SendSnapshotRequest(ctx, candidate.peerID, candidate.offer.Height)
+		if err != nil || !response.Found || response.Snapshot == nil {
+			continue
+		}
+		if response.Snapshot.StateHash != candidate.offer.StateHash {
+			ss.markPeerUnreliable(candidate.peerID)
+			continue
+		}
+		
+		if err := manager.Restore(ctx, response.Snapshot); err != nil {
+			ss.logger.WarnContext(ctx, "failed to restore snapshot", logging.Peer(candidate.peerID),
+				logging.Height(candidate.offer.Height), logging.Err(err))
+			ss.markPeerUnreliable(candidate.peerID)
+			continue
+		}
+		
+		ss.logger.InfoContext(ctx, "bootstrapped from snapshot", logging.Peer(candidate.peerID),
+			logging.Height(candidate.offer.Height), "state_hash", candidate.offer.StateHash)
+		return
+	}
+}
+
+// majorityGenesis retorna o hash gênesis mais anunciado pelos peers (deve ser chamado com lock)
+func (ss *SyncService) majorityGenesis() string {
+	counts := make(map[string]int)
+	best := ""
+	for _, peerInfo := range ss.syncPeers {
+		if !peerInfo.IsReliable || peerInfo.GenesisHash == "" {
+			continue
+		}
+		counts[peerInfo.GenesisHash]++
+		if counts[peerInfo.GenesisHash] > counts[best] ||
+			(counts[peerInfo.GenesisHash] == counts[best] && peerInfo.GenesisHash < best) {
+			best = peerInfo.GenesisHash
+		}
+	}
+	return best
+}
+
+// synchronizeHeadersFirst sincroniza com os peers em duas fases: cabeçalhos e corpos
+func (ss *SyncService) synchronizeHeadersFirst(ctx context.Context, peers []peer.ID, targetHeight uint64) (int, error) {
+	if len(peers) == 0 {
 		return 0, fmt.Errorf("no peer specified")
 	}
 	
-	// Obter altura atual
-	ourHeight, err := ss.chainManager.GetChainHeight(ctx)
+	if ss.validatorManager == nil {
+		return 0, errors.New("validator set not available for header verification")
+	}
+	
+	// Retomar progresso salvo e descartar cabeçalhos que já estão na cadeia
+	ss.restoreCheckpoint(ctx)
+	ss.pruneVerifiedHeaders(ctx)
+	
+	// Fase 1: baixar e verificar a cadeia de cabeçalhos
+	ss.setPhase(SyncPhaseHeaders, targetHeight)
+	if err := ss.downloadAndVerifyHeaders(ctx, peers, targetHeight); err != nil {
+		return 0, fmt.Errorf("header sync failed: %w", err)
+	}
+	
+	// Fase 2: baixar corpos, conferir Merkle roots e aplicar em ordem
+	ss.setPhase(SyncPhaseBodies, targetHeight)
+	blocksAdded, err := ss.downloadAndApplyBodies(ctx, peers)
 	if err != nil {
-		return 0, fmt.Errorf("failed to get chain height: %w", err)
+		return blocksAdded, fmt.Errorf("body sync failed: %w", err)
 	}
 	
-	peerInfo := ss.syncPeers[peerID]
-	if peerInfo == nil {
-		return 0, fmt.Errorf("peer info not found")
+	return blocksAdded, nil
+}
+
+// downloadAndVerifyHeaders baixa cabeçalhos em paralelo e verifica o encadeamento
+func (ss *SyncService) downloadAndVerifyHeaders(ctx context.Context, peers []peer.ID, targetHeight uint64) error {
+	anchorIndex, anchorHash, hasAnchor := ss.headerAnchor(ctx)
+	
+	startIndex := uint64(0)
+	if hasAnchor {
+		startIndex = anchorIndex + 1
 	}
 	
-	blocksAdded := 0
-	currentHeight := ourHeight
+	if startIndex > targetHeight {
+		return nil // Cabeçalhos já verificados até a altura alvo
+	}
+	
+	ranges := splitSyncRange(startIndex, targetHeight, ss.headerBatchSize)
 	
-	// Sincronizar em lotes
-	for currentHeight < peerInfo.ChainHeight {
-		endHeight := currentHeight + uint64(ss.blockBatchSize)
-		if endHeight > peerInfo.ChainHeight {
-			endHeight = peerInfo.ChainHeight
+	var resultsMu sync.Mutex
+	results := make(map[uint64][]*SerializedHeader)
+	sources := make(map[uint64]peer.ID)
+	
+	err := ss.fetchRangesInParallel(ctx, peers, ranges, func(ctx context.Context, peerID peer.ID, r syncRange) error {
+		headers := make([]*SerializedHeader, 0, r.end-r.start+1)
+		
+		for next := r.start; next <= r.end; {
+			requestStart := time.Now()
+			response, err := ss.protocolManager.SendHeaderRangeRequest(ctx, peerID, next, r.end, int(r.end-next+1))
+			if err != nil {
+				return err
+			}
+			ss.recordLatency(time.Since(requestStart))
+			
+			if len(response.Headers) == 0 {
+				return fmt.Errorf("peer returned no headers for range %d-%d", next, r.end)
+			}
+			
+			for _, header := range response.Headers {
+				if header == nil || header.Index != next {
+					return fmt.Errorf("peer returned out-of-order header, expected index %d", next)
+				}
+				headers = append(headers, header)
+				next++
+				if next > r.end {
+					break
+				}
+			}
 		}
 		
-		// Solicitar faixa de blocos
-		blocks, err := ss.requestBlockRange(ctx, peerID, currentHeight+1, endHeight)
+		resultsMu.Lock()
+		results[r.start] = headers
+		sources[r.start] = peerID
+		resultsMu.Unlock()
+		
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	
+	// Se a âncora é o topo local e o primeiro cabeçalho não se encadeia a ela,
+	// o peer está em outra ramificação: resolver via escolha de fork
+	if first := results[ranges[0].start]; hasAnchor && len(first) > 0 && ss.pendingHeaderCount() == 0 &&
+		first[0].PreviousHash != anchorHash.String() {
+		forkTip := targetHeight
+		if limit := anchorIndex + uint64(ss.chainManager.GetMaxReorgDepth()); forkTip > limit {
+			forkTip = limit
+		}
+		if err := ss.ResolveFork(ctx, sources[ranges[0].start], forkTip); err != nil {
+			return fmt.Errorf("chain diverged from peer and fork resolution failed: %w", err)
+		}
+		return nil // A próxima rodada continua a partir do topo escolhido
+	}
+	
+	// Verificar a cadeia de cabeçalhos sequencialmente a partir da âncora
+	previousHash := anchorHash
+	for _, r := range ranges {
+		for _, header := range results[r.start] {
+			hash, err := ss.verifyHeader(ctx, header, previousHash)
+			if err != nil {
+				ss.markPeerUnreliable(sources[r.start])
+				ss.saveCheckpoint()
+				return fmt.Errorf("header %d from peer %s failed verification: %w", header.Index, sources[r.start].String(), err)
+			}
+			
+			ss.appendVerifiedHeader(header, hash)
+			previousHash = hash
+		}
+	}
+	
+	ss.saveCheckpoint()
+	return nil
+}
+
+// ResolveFork busca com um peer os blocos desde a profundidade máxima de
+// reorganização até tipIndex e os processa pelo ChainManager, permitindo que a
+// escolha de fork reconcilie cadeias que divergiram por vários blocos
+func (ss *SyncService) ResolveFork(ctx context.Context, peerID peer.ID, tipIndex uint64) error {
+	depth := uint64(ss.chainManager.GetMaxReorgDepth())
+	
+	startIndex := uint64(1) // O gênesis nunca é reorganizado
+	if tipIndex > depth {
+		startIndex = tipIndex - depth + 1
+	}
+	if startIndex > tipIndex {
+		return nil
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, ss.syncTimeout)
+	defer cancel()
+	
+	for next := startIndex; next <= tipIndex; {
+		response, err := ss.protocolManager.SendBlockRangeRequest(ctx, peerID, next, tipIndex, int(tipIndex-next+1))
 		if err != nil {
 			ss.markPeerUnreliable(peerID)
-			return blocksAdded, fmt.Errorf("failed to request block range: %w", err)
+			return fmt.Errorf("failed to fetch fork branch: %w", err)
 		}
 		
-		// Adicionar blocos à cadeia
-		for _, block := range blocks {
+		if len(response.Blocks) == 0 {
+			return nil
+		}
+		
+		for _, serialized := range response.Blocks {
+			block, err := deserializeBlock(serialized)
+			if err != nil {
+				return fmt.Errorf("failed to deserialize fork block: %w", err)
+			}
+			
+			ss.observeBlock(ctx, block)
+			
+			// Blocos canônicos ou já conhecidos são ignorados pelo ChainManager
+			if err := ss.chainManager.ProcessBlock(ctx, block); err != nil {
+				return fmt.Errorf("fork block %d rejected: %w", block.GetIndex(), err)
+			}
+			
+			next = block.GetIndex() + 1
+		}
+	}
+	
+	return nil
+}
+
+// verifyHeader verifica encadeamento e assinatura de um cabeçalho, retornando seu hash
+func (ss *SyncService) verifyHeader(ctx context.Context, header *SerializedHeader, expectedPreviousHash valueobjects.Hash) (valueobjects.Hash, error) {
+	block, err := deserializeHeader(header)
+	if err != nil {
+		return valueobjects.EmptyHash(), fmt.Errorf("invalid header: %w", err)
+	}
+	
+	// Verificar encadeamento
+	if block.GetIndex() == 0 {
+		if !block.GetPreviousHash().IsEmpty() {
+			return valueobjects.EmptyHash(), errors.New("genesis header must have empty previous hash")
+		}
+	} else if !block.GetPreviousHash().Equals(expectedPreviousHash) {
+		return valueobjects.EmptyHash(), errors.New("previous hash does not match verified chain")
+	}
+	
+	// Verificar se o validador pertence ao conjunto PoA nesta altura
+	publicKey, err := ss.validatorManager.GetValidatorPublicKeyAtHeight(ctx, block.GetValidator(), block.GetIndex())
+	if err != nil {
+		return valueobjects.EmptyHash(), fmt.Errorf("validator %s not authorized at height %d: %w", block.GetValidator().ShortString(), block.GetIndex(), err)
+	}
+	
+	// Verificar assinatura do validador
+	if err := ss.blockBuilder.ValidateBlockSignature(ctx, block, publicKey); err != nil {
+		return valueobjects.EmptyHash(), err
+	}
+	
+	ss.observeBlock(ctx, block)
+	
+	hash := ss.chainManager.CalculateBlockHash(ctx, block)
+	
+	// O gênesis deve ser o mesmo anunciado pelos peers selecionados
+	if block.GetIndex() == 0 && hash.String() != ss.getExpectedGenesis() {
+		return valueobjects.EmptyHash(), errors.New("genesis header does not match expected genesis hash")
+	}
+	
+	return hash, nil
+}
+
+// downloadAndApplyBodies baixa os corpos dos cabeçalhos verificados e os adiciona à cadeia
+func (ss *SyncService) downloadAndApplyBodies(ctx context.Context, peers []peer.ID) (int, error) {
+	blocksAdded := 0
+	windowSize := ss.blockBatchSize * len(peers)
+	
+	for {
+		window := ss.pendingHeaderWindow(windowSize)
+		if len(window) == 0 {
+			return blocksAdded, nil
+		}
+		
+		// Hashes esperados para cada altura da janela (somente leitura durante o download)
+		expectedHashes := make(map[uint64]valueobjects.Hash, len(window))
+		for _, vh := range window {
+			expectedHashes[vh.header.Index] = vh.hash
+		}
+		
+		firstIndex := window[0].header.Index
+		lastIndex := window[len(window)-1].header.Index
+		ranges := splitSyncRange(firstIndex, lastIndex, ss.blockBatchSize)
+		
+		var bodiesMu sync.Mutex
+		bodies := make(map[uint64]*entities.Block, len(window))
+		
+		err := ss.fetchRangesInParallel(ctx, peers, ranges, func(ctx context.Context, peerID peer.ID, r syncRange) error {
+			fetched := make([]*entities.Block, 0, r.end-r.start+1)
+			
+			for next := r.start; next <= r.end; {
+				requestStart := time.Now()
+				response, err := ss.protocolManager.SendBlockRangeRequest(ctx, peerID, next, r.end, int(r.end-next+1))
+				if err != nil {
+					return err
+				}
+				ss.recordLatency(time.Since(requestStart))
+				
+				if len(response.Blocks) == 0 {
+					return fmt.Errorf("peer returned no blocks for range %d-%d", next, r.end)
+				}
+				
+				for _, serialized := range response.Blocks {
+					block, err := deserializeBlock(serialized)
+					if err != nil {
+						return err
+					}
+					
+					if block.GetIndex() != next {
+						return fmt.Errorf("peer returned out-of-order block, expected index %d", next)
+					}
+					
+					if err := ss.verifyBody(ctx, block, expectedHashes[next]); err != nil {
+						return fmt.Errorf("block %d failed verification: %w", next, err)
+					}
+					
+					fetched = append(fetched, block)
+					next++
+					if next > r.end {
+						break
+					}
+				}
+			}
+			
+			bodiesMu.Lock()
+			for _, block := range fetched {
+				bodies[block.GetIndex()] = block
+			}
+			bodiesMu.Unlock()
+			
+			return nil
+		})
+		if err != nil {
+			ss.saveCheckpoint()
+			return blocksAdded, err
+		}
+		
+		// Aplicar os blocos em ordem
+		for _, vh := range window {
+			block := bodies[vh.header.Index]
 			if err := ss.chainManager.AddBlock(ctx, block); err != nil {
+				ss.saveCheckpoint()
 				return blocksAdded, fmt.Errorf("failed to add block %d: %w", block.GetIndex(), err)
 			}
+			
 			blocksAdded++
-			currentHeight = block.GetIndex()
+			ss.recordBodyApplied(vh.header.Index)
 		}
 		
+		ss.saveCheckpoint()
+		
 		// Verificar se devemos parar
 		select {
 		case <-ctx.Done():
@@ -323,22 +804,221 @@ func (ss *SyncService) synchronizeWithPeer(ctx context.Context, peerID peer.ID)
 		default:
 		}
 	}
+}
+
+// verifyBody confere um bloco completo contra o cabeçalho verificado correspondente
+func (ss *SyncService) verifyBody(ctx context.Context, block *entities.Block, expectedHash valueobjects.Hash) error {
+	if expectedHash.IsEmpty() {
+		return errors.New("no verified header for block")
+	}
 	
-	return blocksAdded, nil
+	// O hash cobre os campos assinados e o resumo das transações
+	if !ss.chainManager.CalculateBlockHash(ctx, block).Equals(expectedHash) {
+		return errors.New("block body does not match verified header")
+	}
+	
+	// Recalcular hashes das transações e a Merkle root a partir dos dados recebidos
+	if err := ss.blockBuilder.ValidateBlock(ctx, block); err != nil {
+		return fmt.Errorf("merkle verification failed: %w", err)
+	}
+	
+	return nil
 }
 
-// requestBlockRange solicita uma faixa de blocos de um peer
-func (ss *SyncService) requestBlockRange(ctx context.Context, peerID peer.ID, startHeight, endHeight uint64) ([]*entities.Block, error) {
-	// Criar stream para requisição
-	stream, err := ss.host.NewStream(ctx, peerID, ProtocolBlockSync)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create stream: %w", err)
+// fetchRangesInParallel distribui faixas entre os peers e as baixa concorrentemente.
+// Faixas que falham são redistribuídas para outro peer na tentativa seguinte.
+func (ss *SyncService) fetchRangesInParallel(ctx context.Context, peers []peer.ID, ranges []syncRange, fetch syncFetchFunc) error {
+	pending := ranges
+	semaphore := make(chan struct{}, len(peers)*2)
+	
+	for attempt := 0; attempt < ss.maxFetchAttempts && len(pending) > 0; attempt++ {
+		var wg sync.WaitGroup
+		var failedMu sync.Mutex
+		var failed []syncRange
+		
+		for i, r := range pending {
+			peerID := peers[(i+attempt)%len(peers)]
+			
+			wg.Add(1)
+			go func(peerID peer.ID, r syncRange) {
+				defer wg.Done()
+				
+				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
+				
+				requestCtx, cancel := context.WithTimeout(ctx, ss.syncTimeout)
+				defer cancel()
+				
+				if err := fetch(requestCtx, peerID, r); err != nil {
+					ss.markPeerUnreliable(peerID)
+					
+					failedMu.Lock()
+					failed = append(failed, r)
+					failedMu.Unlock()
+				}
+			}(peerID, r)
+		}
+		
+		wg.Wait()
+		
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		
+		// Manter a ordem original das faixas pendentes
+		sort.Slice(failed, func(i, j int) bool {
+			return failed[i].start < failed[j].start
+		})
+		pending = failed
+	}
+	
+	if len(pending) > 0 {
+		return fmt.Errorf("failed to fetch %d ranges after %d attempts", len(pending), ss.maxFetchAttempts)
+	}
+	
+	return nil
+}
+
+// splitSyncRange divide o intervalo [start, end] em faixas de até batchSize alturas
+func splitSyncRange(start, end uint64, batchSize int) []syncRange {
+	var ranges []syncRange
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	
+	for current := start; current <= end; {
+		rangeEnd := current + uint64(batchSize) - 1
+		if rangeEnd > end {
+			rangeEnd = end
+		}
+		ranges = append(ranges, syncRange{start: current, end: rangeEnd})
+		current = rangeEnd + 1
+	}
+	
+	return ranges
+}
+
+// headerAnchor retorna o ponto a partir do qual novos cabeçalhos devem se encadear
+func (ss *SyncService) headerAnchor(ctx context.Context) (uint64, valueobjects.Hash, bool) {
+	ss.mu.RLock()
+	if n := len(ss.verifiedHeaders); n > 0 {
+		last := ss.verifiedHeaders[n-1]
+		ss.mu.RUnlock()
+		return last.header.Index, last.hash, true
+	}
+	ss.mu.RUnlock()
+	
+	latestBlock, err := ss.chainManager.GetLatestBlock(ctx)
+	if err != nil || latestBlock == nil {
+		return 0, valueobjects.EmptyHash(), false
+	}
+	
+	return latestBlock.GetIndex(), ss.chainManager.CalculateBlockHash(ctx, latestBlock), true
+}
+
+// pruneVerifiedHeaders descarta cabeçalhos já aplicados ou que não se encadeiam à cadeia local
+func (ss *SyncService) pruneVerifiedHeaders(ctx context.Context) {
+	latestBlock, err := ss.chainManager.GetLatestBlock(ctx)
+	
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	
+	if err != nil || latestBlock == nil {
+		// Sem cadeia local, os cabeçalhos precisam começar no gênesis
+		if len(ss.verifiedHeaders) > 0 && ss.verifiedHeaders[0].header.Index != 0 {
+			ss.verifiedHeaders = make([]*verifiedHeader, 0)
+		}
+		return
+	}
+	
+	tipIndex := latestBlock.GetIndex()
+	tipHash := ss.chainManager.CalculateBlockHash(ctx, latestBlock)
+	
+	remaining := make([]*verifiedHeader, 0, len(ss.verifiedHeaders))
+	for _, vh := range ss.verifiedHeaders {
+		if vh.header.Index > tipIndex {
+			remaining = append(remaining, vh)
+		}
+	}
+	
+	// O primeiro cabeçalho restante deve se encadear ao topo da cadeia local
+	if len(remaining) > 0 {
+		first := remaining[0].header
+		if first.Index != tipIndex+1 || first.PreviousHash != tipHash.String() {
+			remaining = make([]*verifiedHeader, 0)
+		}
+	}
+	
+	ss.verifiedHeaders = remaining
+}
+
+// appendVerifiedHeader registra um cabeçalho verificado
+func (ss *SyncService) appendVerifiedHeader(header *SerializedHeader, hash valueobjects.Hash) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	
+	ss.verifiedHeaders = append(ss.verifiedHeaders, &verifiedHeader{header: header, hash: hash})
+	ss.headersVerified++
+}
+
+// pendingHeaderCount retorna o número de cabeçalhos verificados aguardando corpo
+func (ss *SyncService) pendingHeaderCount() int {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	
+	return len(ss.verifiedHeaders)
+}
+
+// pendingHeaderWindow retorna os próximos cabeçalhos verificados aguardando corpo
+func (ss *SyncService) pendingHeaderWindow(size int) []*verifiedHeader {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	
+	if size > len(ss.verifiedHeaders) {
+		size = len(ss.verifiedHeaders)
 	}
-	defer stream.Close()
 	
-	// Implementação simplificada - em produção usaria o ProtocolManager
-	// Por enquanto, retornar erro indicando que precisa ser implementado
-	return nil, fmt.Errorf("block range request not fully implemented yet")
+	window := make([]*verifiedHeader, size)
+	copy(window, ss.verifiedHeaders[:size])
+	return window
+}
+
+// recordBodyApplied remove o cabeçalho aplicado da fila e atualiza estatísticas
+func (ss *SyncService) recordBodyApplied(index uint64) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	
+	if len(ss.verifiedHeaders) > 0 && ss.verifiedHeaders[0].header.Index == index {
+		ss.verifiedHeaders = ss.verifiedHeaders[1:]
+	}
+	ss.bodiesApplied++
+	ss.blocksReceived++
+}
+
+// setPhase atualiza a fase e a altura alvo da sincronização
+func (ss *SyncService) setPhase(phase SyncPhase, targetHeight uint64) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	
+	ss.phase = phase
+	ss.targetHeight = targetHeight
+}
+
+// getExpectedGenesis retorna o hash gênesis esperado para a sincronização atual
+func (ss *SyncService) getExpectedGenesis() string {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	
+	return ss.expectedGenesis
+}
+
+// recordLatency registra a latência de uma requisição de sincronização
+func (ss *SyncService) recordLatency(latency time.Duration) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	
+	ss.totalLatency += latency
+	ss.latencySamples++
 }
 
 // markPeerUnreliable marca um peer como não confiável
@@ -368,8 +1048,30 @@ func (ss *SyncService) requestHandler(ctx context.Context) {
 
 // processRequest processa uma requisição de sincronização
 func (ss *SyncService) processRequest(ctx context.Context, req SyncRequest) {
-	// Implementação de processamento de requisições
-	// Por enquanto, apenas log da requisição
+	if req.PeerID == "" {
+		return
+	}
+	
+	// Obter status atualizado do peer para conhecer gênesis e altura
+	ss.updatePeerInfo(ctx, req.PeerID)
+	
+	ss.mu.Lock()
+	peerInfo, exists := ss.syncPeers[req.PeerID]
+	if !exists || !peerInfo.IsReliable {
+		ss.mu.Unlock()
+		return
+	}
+	
+	targetHeight := peerInfo.ChainHeight
+	if req.EndHeight > 0 && req.EndHeight < targetHeight {
+		targetHeight = req.EndHeight
+	}
+	if ss.expectedGenesis == "" {
+		ss.expectedGenesis = peerInfo.GenesisHash
+	}
+	ss.mu.Unlock()
+	
+	ss.runSync(ctx, []peer.ID{req.PeerID}, targetHeight)
 }
 
 // peerMonitor monitora peers e remove os inativos
@@ -427,7 +1129,7 @@ func (ss *SyncService) handleBlockRequest(peerID peer.ID, req *BlockRequest) (*B
 			}, nil
 		}
 		
-		serializedBlock := ss.serializeBlock(block)
+		serializedBlock := serializeBlock(block)
 		return &BlockResponse{
 			Block: serializedBlock,
 			Found: true,
@@ -444,7 +1146,7 @@ func (ss *SyncService) handleBlockRequest(peerID peer.ID, req *BlockRequest) (*B
 			}, nil
 		}
 		
-		serializedBlock := ss.serializeBlock(block)
+		serializedBlock := serializeBlock(block)
 		return &BlockResponse{
 			Block: serializedBlock,
 			Found: true,
@@ -479,6 +1181,17 @@ func (ss *SyncService) handleBlockRangeRequest(peerID peer.ID, req *BlockRangeRe
 		endIndex = req.StartIndex + uint64(maxBlocks) - 1
 	}
 	
+	// Não ultrapassar a altura local
+	height, err := ss.chainManager.GetChainHeight(ctx)
+	if err != nil || req.StartIndex > height {
+		return &BlockRangeResponse{
+			ErrorMsg: "Requested range is beyond local chain height",
+		}, nil
+	}
+	if endIndex > height {
+		endIndex = height
+	}
+	
 	// Buscar blocos
 	blocks, err := ss.chainManager.GetBlockRange(ctx, req.StartIndex, endIndex)
 	if err != nil {
@@ -490,7 +1203,7 @@ func (ss *SyncService) handleBlockRangeRequest(peerID peer.ID, req *BlockRangeRe
 	// Serializar blocos
 	serializedBlocks := make([]*SerializedBlock, len(blocks))
 	for i, block := range blocks {
-		serializedBlocks[i] = ss.serializeBlock(block)
+		serializedBlocks[i] = serializeBlock(block)
 	}
 	
 	hasMore := req.EndIndex > endIndex
@@ -501,6 +1214,56 @@ func (ss *SyncService) handleBlockRangeRequest(peerID peer.ID, req *BlockRangeRe
 	}, nil
 }
 
+// handleHeaderRangeRequest lida com requisições de faixa de cabeçalhos
+func (ss *SyncService) handleHeaderRangeRequest(peerID peer.ID, req *HeaderRangeRequest) (*HeaderRangeResponse, error) {
+	ctx := context.Background()
+	
+	// Validar requisição
+	if req.StartIndex > req.EndIndex {
+		return &HeaderRangeResponse{
+			ErrorMsg: "Invalid range: start index greater than end index",
+		}, nil
+	}
+	
+	maxHeaders := req.MaxHeaders
+	if maxHeaders <= 0 || maxHeaders > ss.headerBatchSize {
+		maxHeaders = ss.headerBatchSize
+	}
+	
+	// Ajustar range ao limite e à altura local
+	endIndex := req.EndIndex
+	if endIndex > req.StartIndex+uint64(maxHeaders)-1 {
+		endIndex = req.StartIndex + uint64(maxHeaders) - 1
+	}
+	
+	height, err := ss.chainManager.GetChainHeight(ctx)
+	if err != nil || req.StartIndex > height {
+		return &HeaderRangeResponse{
+			ErrorMsg: "Requested range is beyond local chain height",
+		}, nil
+	}
+	if endIndex > height {
+		endIndex = height
+	}
+	
+	blocks, err := ss.chainManager.GetBlockRange(ctx, req.StartIndex, endIndex)
+	if err != nil {
+		return &HeaderRangeResponse{
+			ErrorMsg: fmt.Sprintf("Failed to get header range: %v", err),
+		}, nil
+	}
+	
+	headers := make([]*SerializedHeader, len(blocks))
+	for i, block := range blocks {
+		headers[i] = serializeHeader(block)
+	}
+	
+	return &HeaderRangeResponse{
+		Headers: headers,
+		HasMore: req.EndIndex > endIndex,
+	}, nil
+}
+
 // handleChainStatusRequest lida com requisições de status da cadeia
 func (ss *SyncService) handleChainStatusRequest(peerID peer.ID, req *ChainStatusRequest) (*ChainStatusResponse, error) {
 	ctx := context.Background()
@@ -517,14 +1280,13 @@ func (ss *SyncService) handleChainStatusRequest(peerID peer.ID, req *ChainStatus
 		return nil, fmt.Errorf("failed to get latest block: %w", err)
 	}
 	
-	// Obter bloco gênesis
-	genesisBlock, err := ss.chainManager.GetBlockByIndex(ctx, 0)
+	// Obter hash do gênesis (também disponível quando a cadeia começa em um snapshot)
+	genesisHash, err := ss.chainManager.GetGenesisHash(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get genesis block: %w", err)
+		return nil, err
 	}
 	
-	latestHash := ss.calculateBlockHash(latestBlock)
-	genesisHash := ss.calculateBlockHash(genesisBlock)
+	latestHash := ss.chainManager.CalculateBlockHash(ctx, latestBlock)
 	
 	return &ChainStatusResponse{
 		Height:        height,
@@ -535,181 +1297,54 @@ func (ss *SyncService) handleChainStatusRequest(peerID peer.ID, req *ChainStatus
 	}, nil
 }
 
-// handleBlockGossip lida com gossip de blocos
-func (ss *SyncService) handleBlockGossip(peerID peer.ID, msg *BlockGossipMessage) error {
-	ctx := context.Background()
-	
-	// Deserializar bloco
-	block, err := ss.deserializeBlock(msg.Block)
-	if err != nil {
-		return fmt.Errorf("failed to deserialize block: %w", err)
-	}
-	
-	// Tentar adicionar bloco à cadeia
-	if err := ss.chainManager.AddBlock(ctx, block); err != nil {
-		// Bloco pode já existir ou ser inválido - não é necessariamente um erro
-		return nil
-	}
+// handleSnapshotListRequest lida com requisições das ofertas de snapshot
+func (ss *SyncService) handleSnapshotListRequest(peerID peer.ID, req *SnapshotListRequest) (*SnapshotListResponse, error) {
+	ss.mu.RLock()
+	manager := ss.snapshotManager
+	ss.mu.RUnlock()
 	
-	return nil
-}
-
-// Métodos auxiliares
-
-func (ss *SyncService) serializeBlock(block *entities.Block) *SerializedBlock {
-	transactions := make([]*SerializedTransaction, len(block.GetTransactions()))
-	for i, tx := range block.GetTransactions() {
-		transactions[i] = ss.serializeTransaction(tx)
+	if manager == nil {
+		return &SnapshotListResponse{Offers: []snapshot.Offer{}}, nil
 	}
 	
-	return &SerializedBlock{
-		Index:        block.GetIndex(),
-		PreviousHash: block.GetPreviousHash().String(),
-		Timestamp:    block.GetTimestamp().Unix(),
-		MerkleRoot:   block.GetMerkleRoot().String(),
-		Validator:    block.GetValidator().String(),
-		Signature:    block.GetSignature().String(),
-		Transactions: transactions,
-	}
-}
-
-func (ss *SyncService) serializeTransaction(tx *entities.Transaction) *SerializedTransaction {
-	return &SerializedTransaction{
-		ID:        tx.GetID().String(),
-		Type:      string(tx.GetType()),
-		From:      tx.GetFrom().String(),
-		To:        tx.GetTo().String(),
-		Data:      string(tx.GetData()),
-		Timestamp: tx.GetTimestamp().Unix(),
-		Signature: tx.GetSignature().String(),
-		Hash:      tx.GetHash().String(),
-	}
+	return &SnapshotListResponse{Offers: manager.List()}, nil
 }
 
-func (ss *SyncService) deserializeBlock(serialized *SerializedBlock) (*entities.Block, error) {
-	if serialized == nil {
-		return nil, fmt.Errorf("serialized block is nil")
-	}
-	
-	// Converter campos básicos
-	previousHash, err := valueobjects.NewHashFromString(serialized.PreviousHash)
-	if err != nil {
-		return nil, fmt.Errorf("invalid previous hash: %w", err)
-	}
-	
-	merkleRoot, err := valueobjects.NewHashFromString(serialized.MerkleRoot)
-	if err != nil {
-		return nil, fmt.Errorf("invalid merkle root: %w", err)
-	}
-	
-	validator := valueobjects.NewNodeID(serialized.Validator)
-	
-	// Deserializar transações
-	transactions := make([]*entities.Transaction, len(serialized.Transactions))
-	for i, serializedTx := range serialized.Transactions {
-		tx, err := ss.deserializeTransaction(serializedTx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to deserialize transaction %d: %w", i, err)
-		}
-		transactions[i] = tx
-	}
+// handleSnapshotRequest lida com requisições de um snapshot completo
+func (ss *SyncService) handleSnapshotRequest(peerID peer.ID, req *SnapshotRequest) (*SnapshotResponse, error) {
+	ss.mu.RLock()
+	manager := ss.snapshotManager
+	ss.mu.RUnlock()
 	
-	// Criar bloco usando o BlockBuilder através do ChainManager
-	block, err := ss.chainManager.ProposeBlock(
-		context.Background(),
-		transactions,
-		validator,
-		nil, // Não temos a chave privada aqui
-	)
-	if err != nil {
-		// Se não conseguir propor, criar manualmente
-		blockBuilder := blockchain.NewBlockBuilder(ss.getCryptoService())
-		block, err = blockBuilder.BuildBlock(
-			context.Background(),
-			serialized.Index,
-			previousHash,
-			transactions,
-			validator,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to build block: %w", err)
-		}
+	if manager == nil {
+		return &SnapshotResponse{Found: false, ErrorMsg: "snapshots disabled"}, nil
 	}
 	
-	// Definir campos corretos
-	block.SetMerkleRoot(merkleRoot)
-	
-	// Definir assinatura se presente
-	if serialized.Signature != "" {
-		signature, err := valueobjects.NewSignatureFromString(serialized.Signature)
-		if err != nil {
-			return nil, fmt.Errorf("invalid signature: %w", err)
-		}
-		block.SetSignature(signature)
+	snap, found := manager.Get(req.Height)
+	if !found {
+		return &SnapshotResponse{Found: false, ErrorMsg: "snapshot not found"}, nil
 	}
 	
-	return block, nil
+	return &SnapshotResponse{Snapshot: snap, Found: true}, nil
 }
 
-// deserializeTransaction deserializa uma transação
-func (ss *SyncService) deserializeTransaction(serialized *SerializedTransaction) (*entities.Transaction, error) {
-	if serialized == nil {
-		return nil, fmt.Errorf("serialized transaction is nil")
-	}
-	
-	// Converter campos
-	from := valueobjects.NewNodeID(serialized.From)
-	to := valueobjects.NewNodeID(serialized.To)
-	
-	// Decodificar dados (assumindo base64)
-	data := []byte(serialized.Data) // Simplificado por enquanto
-	
-	tx := entities.NewTransaction(
-		entities.TransactionType(serialized.Type),
-		from,
-		to,
-		data,
-	)
+// handleBlockGossip lida com gossip de blocos
+func (ss *SyncService) handleBlockGossip(peerID peer.ID, msg *BlockGossipMessage) error {
+	ctx := context.Background()
 	
-	// Definir campos adicionais
-	if serialized.ID != "" {
-		id, err := valueobjects.NewHashFromString(serialized.ID)
-		if err == nil {
-			tx.SetID(id)
-		}
+	// Deserializar bloco
+	block, err := deserializeBlock(msg.Block)
+	if err != nil {
+		return fmt.Errorf("failed to deserialize block: %w", err)
 	}
 	
-	if serialized.Signature != "" {
-		sig, err := valueobjects.NewSignatureFromString(serialized.Signature)
-		if err == nil {
-			tx.SetSignature(sig)
-		}
+	// Tentar adicionar bloco à cadeia
+	if err := ss.chainManager.AddBlock(ctx, block); err != nil {
+		// Bloco pode já existir ou ser inválido - não é necessariamente um erro
+		return nil
 	}
 	
-	// Nota: timestamp será o atual do momento da criação
-	// Em uma implementação completa, seria necessário adicionar SetTimestamp à Transaction
-	
-	return tx, nil
-}
-
-// getCryptoService obtém o serviço de criptografia (método auxiliar)
-func (ss *SyncService) getCryptoService() services.CryptographyService {
-	// Por enquanto, criar um novo serviço ECDSA
-	// Em uma implementação real, isso seria injetado
-	return crypto.NewECDSAService()
-}
-
-func (ss *SyncService) calculateBlockHash(block *entities.Block) valueobjects.Hash {
-	// Implementação simplificada
-	data := fmt.Sprintf("%d-%s-%d", 
-		block.GetIndex(), 
-		block.GetMerkleRoot().String(), 
-		block.GetTimestamp().Unix())
-	
-	hash := make([]byte, 32)
-	copy(hash, []byte(data))
-	
-	return valueobjects.NewHash(hash)
+	return nil
 }
 
 // Métodos públicos para controle
@@ -730,15 +1365,38 @@ func (ss *SyncService) RequestSync(peerID peer.ID, startHeight, endHeight uint64
 	}
 }
 
-// GetSyncStats retorna estatísticas de sincronização
+// GetSyncStats retorna estatísticas e progresso da sincronização
 func (ss *SyncService) GetSyncStats() *SyncStats {
+	currentHeight, _ := ss.chainManager.GetChainHeight(context.Background())
+	
 	ss.mu.RLock()
 	defer ss.mu.RUnlock()
 	
+	var averageLatency time.Duration
+	if ss.latencySamples > 0 {
+		averageLatency = ss.totalLatency / time.Duration(ss.latencySamples)
+	}
+	
+	progress := float64(100)
+	if ss.targetHeight > 0 && currentHeight < ss.targetHeight {
+		progress = float64(currentHeight) / float64(ss.targetHeight) * 100
+	}
+	
 	return &SyncStats{
-		IsSyncing:     ss.isSyncing,
-		LastSyncTime:  ss.lastSyncTime,
-		SyncPeerCount: len(ss.syncPeers),
+		IsSyncing:       ss.isSyncing,
+		LastSyncTime:    ss.lastSyncTime,
+		SyncPeerCount:   len(ss.syncPeers),
+		BlocksReceived:  ss.blocksReceived,
+		SyncAttempts:    ss.syncAttempts,
+		FailureCount:    ss.failureCount,
+		AverageLatency:  averageLatency,
+		Phase:           ss.phase,
+		CurrentHeight:   currentHeight,
+		TargetHeight:    ss.targetHeight,
+		HeadersVerified: ss.headersVerified,
+		BodiesApplied:   ss.bodiesApplied,
+		PendingHeaders:  len(ss.verifiedHeaders),
+		Progress:        progress,
 	}
 }
 
@@ -753,6 +1411,7 @@ func (ss *SyncService) GetSyncPeers() map[peer.ID]*SyncPeerInfo {
 			PeerID:       peerInfo.PeerID,
 			ChainHeight:  peerInfo.ChainHeight,
 			LatestHash:   peerInfo.LatestHash,
+			GenesisHash:  peerInfo.GenesisHash,
 			LastContact:  peerInfo.LastContact,
 			IsReliable:   peerInfo.IsReliable,
 			SyncAttempts: peerInfo.SyncAttempts,
@@ -763,6 +1422,52 @@ func (ss *SyncService) GetSyncPeers() map[peer.ID]*SyncPeerInfo {
 	return result
 }
 
+// SetValidatorManager define o conjunto de validadores usado para verificar cabeçalhos
+func (ss *SyncService) SetValidatorManager(validatorManager *consensus.ValidatorManager) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	
+	ss.validatorManager = validatorManager
+}
+
+// SetSnapshotManager define o gerenciador de snapshots servidos aos peers e usado no bootstrap
+func (ss *SyncService) SetSnapshotManager(manager *snapshot.Manager) {
+	ss.mu.Lock()
+	ss.snapshotManager = manager
+	ss.mu.Unlock()
+	
+	ss.protocolManager.SetSnapshotListHandler(ss.handleSnapshotListRequest)
+	ss.protocolManager.SetSnapshotHandler(ss.handleSnapshotRequest)
+}
+
+// SetBlockObserver define o observador dos cabeçalhos assinados recebidos na sincronização
+func (ss *SyncService) SetBlockObserver(observer func(context.Context, *entities.Block)) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	
+	ss.blockObserver = observer
+}
+
+// observeBlock entrega um bloco com assinatura verificada ao observador registrado
+func (ss *SyncService) observeBlock(ctx context.Context, block *entities.Block) {
+	ss.mu.RLock()
+	observer := ss.blockObserver
+	ss.mu.RUnlock()
+	
+	if observer != nil {
+		observer(ctx, block)
+	}
+}
+
+// SetCheckpointPath define o arquivo usado para retomar a sincronização após reinício
+func (ss *SyncService) SetCheckpointPath(path string) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	
+	ss.checkpointPath = path
+	ss.checkpointLoaded = false
+}
+
 // SetCallbacks define callbacks para eventos de sincronização
 func (ss *SyncService) SetCallbacks(onStart func(), onComplete func(int), onError func(error)) {
 	ss.mu.Lock()
@@ -0,0 +1,168 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
+)
+
+// FileElectionTemplateRepository mantém os modelos de eleição em memória e os grava em um
+// arquivo JSON no diretório de dados do nó a cada alteração
+type FileElectionTemplateRepository struct {
+	path      string // Vazio mantém os modelos apenas em memória
+	templates map[string]*entities.ElectionTemplate
+	mu        sync.RWMutex
+}
+
+// NewFileElectionTemplateRepository cria o repositório e carrega os modelos já gravados em path
+func NewFileElectionTemplateRepository(path string) (*FileElectionTemplateRepository, error) {
+	r := &FileElectionTemplateRepository{
+		path:      path,
+		templates: make(map[string]*entities.ElectionTemplate),
+	}
+	if path == "" {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read election templates: %w", err)
+	}
+
+	var templates []*entities.ElectionTemplate
+	if err := json.Unmarshal(data, &templates); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal election templates: %w", err)
+	}
+	for _, template := range templates {
+		if template != nil {
+			r.templates[template.Name] = template
+		}
+	}
+
+	return r, nil
+}
+
+// CreateTemplate salva um modelo novo
+func (r *FileElectionTemplateRepository) CreateTemplate(ctx context.Context, template *entities.ElectionTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.templates[template.Name]; exists {
+		return repositories.ErrTemplateExists
+	}
+	return r.store(template)
+}
+
+// UpdateTemplate substitui um modelo existente
+func (r *FileElectionTemplateRepository) UpdateTemplate(ctx context.Context, template *entities.ElectionTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.templates[template.Name]; !exists {
+		return repositories.ErrTemplateNotFound
+	}
+	return r.store(template)
+}
+
+// GetTemplate recupera uma cópia do modelo pelo nome
+func (r *FileElectionTemplateRepository) GetTemplate(ctx context.Context, name string) (*entities.ElectionTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	template, exists := r.templates[name]
+	if !exists {
+		return nil, repositories.ErrTemplateNotFound
+	}
+	copied := *template
+	return &copied, nil
+}
+
+// ListTemplates lista cópias dos modelos em ordem de nome
+func (r *FileElectionTemplateRepository) ListTemplates(ctx context.Context) ([]*entities.ElectionTemplate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.sorted(), nil
+}
+
+// DeleteTemplate remove um modelo pelo nome
+func (r *FileElectionTemplateRepository) DeleteTemplate(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	template, exists := r.templates[name]
+	if !exists {
+		return repositories.ErrTemplateNotFound
+	}
+
+	delete(r.templates, name)
+	if err := r.save(); err != nil {
+		r.templates[name] = template
+		return err
+	}
+	return nil
+}
+
+// store grava o modelo, restaurando o estado anterior se o arquivo não puder ser salvo
+// (chamado com o lock de escrita)
+func (r *FileElectionTemplateRepository) store(template *entities.ElectionTemplate) error {
+	previous, existed := r.templates[template.Name]
+	copied := *template
+	r.templates[template.Name] = &copied
+
+	if err := r.save(); err != nil {
+		if existed {
+			r.templates[template.Name] = previous
+		} else {
+			delete(r.templates, template.Name)
+		}
+		return err
+	}
+	return nil
+}
+
+// sorted retorna cópias dos modelos ordenadas por nome (chamado com o lock)
+func (r *FileElectionTemplateRepository) sorted() []*entities.ElectionTemplate {
+	templates := make([]*entities.ElectionTemplate, 0, len(r.templates))
+	for _, template := range r.templates {
+		copied := *template
+		templates = append(templates, &copied)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates
+}
+
+// save grava os modelos de forma atômica (arquivo temporário + rename; chamado com o lock)
+func (r *FileElectionTemplateRepository) save() error {
+	if r.path == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("failed to create templates directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(r.sorted(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal election templates: %w", err)
+	}
+
+	tmpPath := r.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write election templates: %w", err)
+	}
+
+	return os.Rename(tmpPath, r.path)
+}
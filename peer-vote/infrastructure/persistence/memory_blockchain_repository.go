@@ -21,6 +21,10 @@ type MemoryBlockchainRepository struct {
 	// Armazenamento por índice
 	blocksByIndex map[uint64]*entities.Block
 	
+	// Índices secundários para consultas (alturas em ordem crescente)
+	heightsByValidator map[string][]uint64
+	heightsByTxType    map[entities.TransactionType][]uint64
+	
 	// Hash do último bloco
 	latestBlockHash valueobjects.Hash
 	
@@ -41,6 +45,9 @@ func NewMemoryBlockchainRepository(cryptoService services.CryptographyService) r
 		blocksByIndex: make(map[uint64]*entities.Block),
 		chainHeight:   0,
 		cryptoService: cryptoService,
+
+		heightsByValidator: make(map[string][]uint64),
+		heightsByTxType:    make(map[entities.TransactionType][]uint64),
 	}
 }
 
@@ -70,9 +77,10 @@ func (r *MemoryBlockchainRepository) SaveBlock(ctx context.Context, block *entit
 	// Salvar o bloco
 	r.blocksByHash[blockHashStr] = block
 	r.blocksByIndex[block.GetIndex()] = block
+	r.indexBlock(block)
 
-	// Atualizar altura da cadeia e último bloco se necessário
-	if block.GetIndex() > r.chainHeight {
+	// Atualizar altura da cadeia e último bloco se necessário (o gênesis também é o último bloco)
+	if block.GetIndex() > r.chainHeight || r.latestBlockHash.IsEmpty() {
 		r.chainHeight = block.GetIndex()
 		r.latestBlockHash = blockHash
 	}
@@ -249,6 +257,7 @@ func (r *MemoryBlockchainRepository) DeleteBlock(ctx context.Context, hash value
 	// Remover dos mapas
 	delete(r.blocksByHash, hashStr)
 	delete(r.blocksByIndex, block.GetIndex())
+	r.unindexBlock(block)
 
 	// Atualizar altura da cadeia se necessário
 	if block.GetIndex() == r.chainHeight {
@@ -290,6 +299,160 @@ func (r *MemoryBlockchainRepository) GetBlocksBefore(ctx context.Context, index
 	return blocks, nil
 }
 
+// QueryBlocks consulta blocos por faixa de altura, validador e tipo de transação.
+// Com filtro de validador ou de tipo, percorre apenas as alturas do índice secundário
+// mais seletivo; sem filtros, percorre a faixa de alturas diretamente.
+func (r *MemoryBlockchainRepository) QueryBlocks(ctx context.Context, query repositories.BlockQuery) (*repositories.BlockPage, error) {
+	if query.Limit < 0 {
+		return nil, errors.New("limit cannot be negative")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	page := &repositories.BlockPage{Blocks: make([]*entities.Block, 0)}
+	if len(r.blocksByIndex) == 0 {
+		return page, nil
+	}
+
+	low, high, ok := query.Bounds(r.chainHeight)
+	if !ok {
+		return page, nil
+	}
+
+	// collect adiciona o bloco à página; retorna false quando a página está completa
+	collect := func(height uint64) bool {
+		block, exists := r.blocksByIndex[height]
+		if !exists || !query.Matches(block) {
+			return true
+		}
+		if query.Limit > 0 && len(page.Blocks) == query.Limit {
+			page.HasMore = true
+			return false
+		}
+		page.Blocks = append(page.Blocks, block)
+		return true
+	}
+
+	candidates, indexed := r.candidateHeights(query)
+	if !indexed {
+		if query.Order == repositories.SortDescending {
+			for height := high; ; height-- {
+				if !collect(height) || height == low {
+					break
+				}
+			}
+		} else {
+			for height := low; ; height++ {
+				if !collect(height) || height == high {
+					break
+				}
+			}
+		}
+		return page, nil
+	}
+
+	start := sort.Search(len(candidates), func(i int) bool { return candidates[i] >= low })
+	end := sort.Search(len(candidates), func(i int) bool { return candidates[i] > high })
+	if query.Order == repositories.SortDescending {
+		for i := end - 1; i >= start; i-- {
+			if !collect(candidates[i]) {
+				break
+			}
+		}
+	} else {
+		for i := start; i < end; i++ {
+			if !collect(candidates[i]) {
+				break
+			}
+		}
+	}
+
+	return page, nil
+}
+
+// candidateHeights escolhe o índice secundário mais seletivo para a consulta
+func (r *MemoryBlockchainRepository) candidateHeights(query repositories.BlockQuery) ([]uint64, bool) {
+	var candidates []uint64
+	indexed := false
+
+	if !query.Validator.IsEmpty() {
+		candidates = r.heightsByValidator[query.Validator.String()]
+		indexed = true
+	}
+	if query.TxType != "" {
+		byType := r.heightsByTxType[query.TxType]
+		if !indexed || len(byType) < len(candidates) {
+			candidates = byType
+		}
+		indexed = true
+	}
+
+	return candidates, indexed
+}
+
+// indexBlock registra o bloco nos índices secundários
+func (r *MemoryBlockchainRepository) indexBlock(block *entities.Block) {
+	height := block.GetIndex()
+
+	validator := block.GetValidator().String()
+	r.heightsByValidator[validator] = insertHeight(r.heightsByValidator[validator], height)
+
+	for txType := range blockTxTypes(block) {
+		r.heightsByTxType[txType] = insertHeight(r.heightsByTxType[txType], height)
+	}
+}
+
+// unindexBlock remove o bloco dos índices secundários
+func (r *MemoryBlockchainRepository) unindexBlock(block *entities.Block) {
+	height := block.GetIndex()
+
+	validator := block.GetValidator().String()
+	if heights := removeHeight(r.heightsByValidator[validator], height); len(heights) > 0 {
+		r.heightsByValidator[validator] = heights
+	} else {
+		delete(r.heightsByValidator, validator)
+	}
+
+	for txType := range blockTxTypes(block) {
+		if heights := removeHeight(r.heightsByTxType[txType], height); len(heights) > 0 {
+			r.heightsByTxType[txType] = heights
+		} else {
+			delete(r.heightsByTxType, txType)
+		}
+	}
+}
+
+// blockTxTypes retorna os tipos de transação presentes no bloco
+func blockTxTypes(block *entities.Block) map[entities.TransactionType]struct{} {
+	types := make(map[entities.TransactionType]struct{})
+	for _, tx := range block.GetTransactions() {
+		types[tx.GetType()] = struct{}{}
+	}
+	return types
+}
+
+// insertHeight insere a altura mantendo a lista ordenada e sem duplicatas
+func insertHeight(heights []uint64, height uint64) []uint64 {
+	i := sort.Search(len(heights), func(i int) bool { return heights[i] >= height })
+	if i < len(heights) && heights[i] == height {
+		return heights
+	}
+	heights = append(heights, 0)
+	copy(heights[i+1:], heights[i:])
+	heights[i] = height
+	return heights
+}
+
+// removeHeight remove a altura da lista ordenada
+func removeHeight(heights []uint64, height uint64) []uint64 {
+	i := sort.Search(len(heights), func(i int) bool { return heights[i] >= height })
+	if i == len(heights) || heights[i] != height {
+		return heights
+	}
+	return append(heights[:i], heights[i+1:]...)
+}
+
 // calculateBlockHash calcula o hash de um bloco usando SHA-256
 func (r *MemoryBlockchainRepository) calculateBlockHash(block *entities.Block) valueobjects.Hash {
 	// Serializar o bloco para cálculo de hash
@@ -337,11 +500,14 @@ func (r *MemoryBlockchainRepository) serializeBlockForHashing(block *entities.Bl
 func (r *MemoryBlockchainRepository) recalculateChainHeight() {
 	maxIndex := uint64(0)
 	var latestHash valueobjects.Hash
+	found := false
 	
 	for index, block := range r.blocksByIndex {
-		if index > maxIndex {
+		// Considerar também o gênesis (índice 0) quando for o único bloco restante
+		if !found || index > maxIndex {
 			maxIndex = index
 			latestHash = r.calculateBlockHash(block)
+			found = true
 		}
 	}
 	
@@ -364,6 +530,8 @@ func (r *MemoryBlockchainRepository) Clear() {
 	
 	r.blocksByHash = make(map[string]*entities.Block)
 	r.blocksByIndex = make(map[uint64]*entities.Block)
+	r.heightsByValidator = make(map[string][]uint64)
+	r.heightsByTxType = make(map[entities.TransactionType][]uint64)
 	r.latestBlockHash = valueobjects.EmptyHash()
 	r.chainHeight = 0
 }
@@ -0,0 +1,136 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+)
+
+// AuditWriter escreve votos auditados em streaming, sem reter a lista
+type AuditWriter interface {
+	// Write escreve a linha de um voto auditado
+	Write(result usecases.VoteAuditResult) error
+	// Flush envia ao destino as linhas em buffer
+	Flush() error
+	// Close conclui a saída (rodapé do formato) e faz o flush final
+	Close() error
+}
+
+// auditCSVHeader são as colunas de um voto auditado
+var auditCSVHeader = []string{"tx_hash", "block_height", "timestamp", "vote_id", "candidate_id", "is_anonymous", "is_valid", "errors"}
+
+// NewAuditWriter cria o escritor de votos auditados no formato pedido (ndjson, csv ou markdown).
+// title é o título da seção Markdown (vazio omite o título).
+func NewAuditWriter(w io.Writer, format Format, title string) (AuditWriter, error) {
+	switch format {
+	case FormatNDJSON:
+		out := bufio.NewWriter(w)
+		return &ndjsonAuditWriter{out: out, encoder: json.NewEncoder(out)}, nil
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(auditCSVHeader); err != nil {
+			return nil, err
+		}
+		return &csvAuditWriter{writer: writer}, nil
+	case FormatMarkdown:
+		out := bufio.NewWriter(w)
+		if title != "" {
+			fmt.Fprintf(out, "## %s\n\n", escapeMarkdown(title))
+		}
+		fmt.Fprintln(out, "| Bloco | Transação | Horário | Candidato | Anônimo | Válido | Erros |")
+		fmt.Fprintln(out, "|------:|-----------|---------|-----------|---------|--------|-------|")
+		return &markdownAuditWriter{out: out}, nil
+	default:
+		return nil, fmt.Errorf("format %s not supported for streamed audit rows", format)
+	}
+}
+
+// ndjsonAuditWriter escreve um VoteAuditResult JSON por linha
+type ndjsonAuditWriter struct {
+	out     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (n *ndjsonAuditWriter) Write(result usecases.VoteAuditResult) error {
+	return n.encoder.Encode(result)
+}
+
+func (n *ndjsonAuditWriter) Flush() error {
+	return n.out.Flush()
+}
+
+func (n *ndjsonAuditWriter) Close() error {
+	return n.out.Flush()
+}
+
+// csvAuditWriter escreve uma linha CSV por voto; os erros são separados por "; "
+type csvAuditWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvAuditWriter) Write(result usecases.VoteAuditResult) error {
+	return c.writer.Write([]string{
+		result.TxHash,
+		strconv.FormatUint(result.BlockHeight, 10),
+		formatTime(result.Timestamp),
+		result.VoteID,
+		result.CandidateID,
+		strconv.FormatBool(result.IsAnonymous),
+		strconv.FormatBool(result.IsValid),
+		strings.Join(result.Errors, "; "),
+	})
+}
+
+func (c *csvAuditWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *csvAuditWriter) Close() error {
+	return c.Flush()
+}
+
+// markdownAuditWriter escreve uma linha de tabela Markdown por voto
+type markdownAuditWriter struct {
+	out  *bufio.Writer
+	rows int
+}
+
+func (m *markdownAuditWriter) Write(result usecases.VoteAuditResult) error {
+	m.rows++
+	_, err := fmt.Fprintf(m.out, "| %d | `%s` | %s | `%s` | %s | %s | %s |\n",
+		result.BlockHeight,
+		result.TxHash,
+		formatTime(result.Timestamp),
+		escapeMarkdown(result.CandidateID),
+		yesNo(result.IsAnonymous),
+		yesNo(result.IsValid),
+		escapeMarkdown(strings.Join(result.Errors, "; ")),
+	)
+	return err
+}
+
+func (m *markdownAuditWriter) Flush() error {
+	return m.out.Flush()
+}
+
+func (m *markdownAuditWriter) Close() error {
+	if m.rows == 0 {
+		fmt.Fprintln(m.out, "\nNenhum voto.")
+	}
+	return m.out.Flush()
+}
+
+// yesNo traduz um booleano para as tabelas Markdown
+func yesNo(value bool) string {
+	if value {
+		return "sim"
+	}
+	return "não"
+}
@@ -0,0 +1,138 @@
+package report
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format identifica um formato de exportação de resultados e auditorias
+type Format string
+
+const (
+	// FormatJSON documento JSON (a resposta usual da API)
+	FormatJSON Format = "json"
+	// FormatNDJSON um objeto JSON por linha, em streaming
+	FormatNDJSON Format = "ndjson"
+	// FormatCSV planilha com cabeçalho, em streaming
+	FormatCSV Format = "csv"
+	// FormatMarkdown relatório legível
+	FormatMarkdown Format = "markdown"
+)
+
+// mediaTypes associa cada formato ao tipo de mídia negociado pelo cabeçalho Accept
+var mediaTypes = map[Format]string{
+	FormatJSON:     "application/json",
+	FormatNDJSON:   "application/x-ndjson",
+	FormatCSV:      "text/csv",
+	FormatMarkdown: "text/markdown",
+}
+
+// MediaType retorna o tipo de mídia do formato (valor de Accept)
+func MediaType(format Format) string {
+	return mediaTypes[format]
+}
+
+// ContentType retorna o Content-Type da resposta no formato
+func ContentType(format Format) string {
+	switch format {
+	case FormatCSV, FormatMarkdown:
+		return mediaTypes[format] + "; charset=utf-8"
+	default:
+		return mediaTypes[format]
+	}
+}
+
+// Extension retorna a extensão de arquivo do formato
+func Extension(format Format) string {
+	if format == FormatMarkdown {
+		return "md"
+	}
+	return string(format)
+}
+
+// ParseFormat converte o nome de um formato (json, ndjson, csv, markdown ou md)
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(name))); format {
+	case FormatJSON, FormatNDJSON, FormatCSV, FormatMarkdown:
+		return format, nil
+	case "md":
+		return FormatMarkdown, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q (use json, ndjson, csv or markdown)", name)
+	}
+}
+
+// Negotiate escolhe, entre os formatos oferecidos, o preferido pelo cabeçalho Accept,
+// respeitando os pesos q. Sem Accept (ou com */*) vale o primeiro formato oferecido;
+// false indica que nenhum formato oferecido é aceito (406).
+func Negotiate(accept string, offered ...Format) (Format, bool) {
+	if len(offered) == 0 {
+		return "", false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return offered[0], true
+	}
+
+	type candidate struct {
+		format Format
+		q      float64
+		order  int
+	}
+	var candidates []candidate
+
+	for order, part := range strings.Split(accept, ",") {
+		mediaRange, q := parseMediaRange(part)
+		if mediaRange == "" || q <= 0 {
+			continue
+		}
+		for _, format := range offered {
+			if matchesMediaRange(mediaRange, mediaTypes[format]) {
+				candidates = append(candidates, candidate{format: format, q: q, order: order})
+				break
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return "", false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].q != candidates[j].q {
+			return candidates[i].q > candidates[j].q
+		}
+		return candidates[i].order < candidates[j].order
+	})
+	return candidates[0].format, true
+}
+
+// parseMediaRange separa o tipo de mídia e o peso q de um item de Accept
+func parseMediaRange(part string) (string, float64) {
+	params := strings.Split(part, ";")
+	mediaRange := strings.ToLower(strings.TrimSpace(params[0]))
+
+	q := 1.0
+	for _, param := range params[1:] {
+		name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if !found || strings.ToLower(strings.TrimSpace(name)) != "q" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return "", 0
+		}
+		q = parsed
+	}
+
+	return mediaRange, q
+}
+
+// matchesMediaRange verifica se o tipo de mídia atende ao intervalo (tipo/subtipo, tipo/* ou */*)
+func matchesMediaRange(mediaRange, mediaType string) bool {
+	if mediaRange == "*/*" || mediaRange == mediaType {
+		return true
+	}
+	prefix, found := strings.CutSuffix(mediaRange, "/*")
+	return found && strings.HasPrefix(mediaType, prefix+"/")
+}
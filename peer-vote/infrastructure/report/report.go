@@ -0,0 +1,71 @@
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+)
+
+// InvalidVotesTitle é o título da seção de votos inválidos do relatório Markdown
+const InvalidVotesTitle = "Votos inválidos"
+
+// WriteReport escreve o cabeçalho do relatório de auditoria: eleição, resumo e apuração.
+// Em JSON é o relatório completo; em Markdown, a seção de votos inválidos vem em seguida,
+// escrita em streaming com NewAuditWriter(w, FormatMarkdown, InvalidVotesTitle).
+func WriteReport(w io.Writer, format Format, report *usecases.AuditReportResponse) error {
+	switch format {
+	case FormatJSON:
+		return json.NewEncoder(w).Encode(report)
+	case FormatMarkdown:
+		return writeReportMarkdown(w, report)
+	default:
+		return fmt.Errorf("format %s not supported for reports", format)
+	}
+}
+
+// writeReportMarkdown escreve o relatório legível da eleição
+func writeReportMarkdown(w io.Writer, report *usecases.AuditReportResponse) error {
+	out := bufio.NewWriter(w)
+	summary := report.Summary
+
+	audit := "aprovada"
+	if !report.AuditPassed {
+		audit = "reprovada"
+	}
+
+	fmt.Fprintf(out, "# Relatório da eleição: %s\n\n", escapeMarkdown(report.ElectionTitle))
+	if report.Description != "" {
+		fmt.Fprintf(out, "%s\n\n", escapeMarkdown(report.Description))
+	}
+	fmt.Fprintf(out, "- Eleição: `%s`\n", report.ElectionID.String())
+	fmt.Fprintf(out, "- Status: %s\n", report.Status)
+	fmt.Fprintf(out, "- Votação: %s a %s\n", formatTime(report.StartTime), formatTime(report.EndTime))
+	fmt.Fprintf(out, "- Altura da cadeia: %d\n", report.ChainHeight)
+	fmt.Fprintf(out, "- Gerado em: %s\n\n", time.Unix(report.GeneratedAt, 0).UTC().Format(time.RFC3339))
+
+	fmt.Fprintln(out, "## Apuração")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "- Votos válidos: %d\n", summary.ValidVotes)
+	writeWinner(out, report.Winner, report.IsTie)
+	fmt.Fprintln(out)
+	writeResultsTable(out, report.Results)
+	fmt.Fprintln(out)
+
+	fmt.Fprintln(out, "## Auditoria")
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "- Resultado: %s (integridade %.2f%%)\n", audit, summary.IntegrityScore)
+	fmt.Fprintf(out, "- Votos na cadeia: %d\n", summary.TotalVotes)
+	fmt.Fprintf(out, "- Válidos: %d\n", summary.ValidVotes)
+	fmt.Fprintf(out, "- Inválidos: %d\n", summary.InvalidVotes)
+	fmt.Fprintf(out, "- Anônimos: %d\n", summary.AnonymousVotes)
+	if summary.SnapshotVotes > 0 {
+		fmt.Fprintf(out, "- Resumidos pelo snapshot de estado: %d\n", summary.SnapshotVotes)
+	}
+	fmt.Fprintln(out)
+
+	return out.Flush()
+}
@@ -0,0 +1,87 @@
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+)
+
+// resultsCSVHeader são as colunas da apuração por candidato
+var resultsCSVHeader = []string{"candidate_id", "candidate_name", "vote_count", "percentage"}
+
+// WriteResults escreve a apuração por candidato no formato pedido (json, csv ou markdown)
+func WriteResults(w io.Writer, format Format, results *usecases.CountVotesResponse) error {
+	switch format {
+	case FormatJSON:
+		return json.NewEncoder(w).Encode(results)
+	case FormatCSV:
+		return writeResultsCSV(w, results.Results)
+	case FormatMarkdown:
+		out := bufio.NewWriter(w)
+		fmt.Fprintf(out, "# Resultado: %s\n\n", escapeMarkdown(results.ElectionTitle))
+		fmt.Fprintf(out, "- Eleição: `%s`\n", results.ElectionID.String())
+		fmt.Fprintf(out, "- Total de votos: %d\n", results.TotalVotes)
+		writeWinner(out, results.Winner, results.IsTie)
+		fmt.Fprintln(out)
+		writeResultsTable(out, results.Results)
+		return out.Flush()
+	default:
+		return fmt.Errorf("format %s not supported for results", format)
+	}
+}
+
+// writeResultsCSV escreve uma linha por candidato, na ordem da eleição
+func writeResultsCSV(w io.Writer, results []usecases.CandidateResult) error {
+	writer := csv.NewWriter(w)
+	writer.Write(resultsCSVHeader)
+	for _, result := range results {
+		writer.Write([]string{
+			result.CandidateID,
+			result.CandidateName,
+			strconv.FormatUint(result.VoteCount, 10),
+			strconv.FormatFloat(result.Percentage, 'f', 2, 64),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeResultsTable escreve a tabela Markdown da apuração por candidato
+func writeResultsTable(w io.Writer, results []usecases.CandidateResult) {
+	fmt.Fprintln(w, "| Candidato | Nome | Votos | % |")
+	fmt.Fprintln(w, "|-----------|------|------:|--:|")
+	for _, result := range results {
+		fmt.Fprintf(w, "| `%s` | %s | %d | %.2f |\n",
+			result.CandidateID, escapeMarkdown(result.CandidateName), result.VoteCount, result.Percentage)
+	}
+}
+
+// writeWinner escreve o vencedor (ou o empate) como item de lista
+func writeWinner(w io.Writer, winner *usecases.CandidateResult, isTie bool) {
+	switch {
+	case isTie:
+		fmt.Fprintln(w, "- Vencedor: empate")
+	case winner != nil:
+		fmt.Fprintf(w, "- Vencedor: %s (`%s`)\n", escapeMarkdown(winner.CandidateName), winner.CandidateID)
+	}
+}
+
+// escapeMarkdown neutraliza quebras de linha e separadores de tabela em textos livres
+func escapeMarkdown(text string) string {
+	return strings.NewReplacer("|", `\|`, "\r", " ", "\n", " ").Replace(text)
+}
+
+// formatTime formata um instante Unix em UTC
+func formatTime(seconds int64) string {
+	if seconds == 0 {
+		return "-"
+	}
+	return time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,215 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// Role define o papel de uma identidade na API
+type Role string
+
+const (
+	// RoleAdmin administra validadores, consenso e todas as demais rotas
+	RoleAdmin Role = "admin"
+	// RoleElectionOfficer cria eleições e altera seu status
+	RoleElectionOfficer Role = "election-officer"
+	// RoleVoter submete votos em nome do próprio NodeID
+	RoleVoter Role = "voter"
+	// RoleObserver apenas consulta a API
+	RoleObserver Role = "observer"
+)
+
+// Métodos de autenticação
+const (
+	MethodAdminToken = "admin_token"
+	MethodJWT        = "jwt"
+	MethodSignature  = "signature"
+)
+
+// Erros de autenticação
+var (
+	ErrMissingCredentials = errors.New("authentication required")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrTokenExpired       = errors.New("token expired")
+	ErrInvalidSignature   = errors.New("invalid request signature")
+	ErrRequestExpired     = errors.New("request timestamp outside the allowed window")
+	ErrReplayedRequest    = errors.New("request signature already used")
+)
+
+// ParseRole converte o nome de um papel (aceita "election_officer" como sinônimo)
+func ParseRole(name string) (Role, error) {
+	switch Role(strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", "-")) {
+	case RoleAdmin:
+		return RoleAdmin, nil
+	case RoleElectionOfficer:
+		return RoleElectionOfficer, nil
+	case RoleVoter:
+		return RoleVoter, nil
+	case RoleObserver:
+		return RoleObserver, nil
+	}
+	return "", fmt.Errorf("unknown role %q", name)
+}
+
+// Identity representa o autor autenticado de uma requisição
+type Identity struct {
+	Subject string
+	NodeID  valueobjects.NodeID // Vazio quando a credencial não está vinculada a um nó
+	Roles   []Role
+	Method  string
+}
+
+// HasRole verifica se a identidade possui algum dos papéis (admin satisfaz qualquer papel)
+func (i *Identity) HasRole(roles ...Role) bool {
+	for _, role := range i.Roles {
+		if role == RoleAdmin {
+			return true
+		}
+		for _, required := range roles {
+			if role == required {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// identityKey é a chave da identidade no contexto da requisição
+type identityKey struct{}
+
+// WithIdentity adiciona a identidade ao contexto
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext obtém a identidade autenticada do contexto
+func FromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok && identity != nil
+}
+
+// Config contém as credenciais aceitas pela API
+type Config struct {
+	Enabled      bool
+	AdminToken   string            // Token estático com papel admin (vazio desabilita)
+	JWTSecret    string            // Segredo HS256 (vazio desabilita)
+	JWTPublicKey *ecdsa.PublicKey  // Chave ES256 (nil desabilita)
+	MaxClockSkew time.Duration     // Janela de validade de requisições assinadas
+	NodeRoles    map[string][]Role // Papéis de NodeIDs em requisições assinadas (padrão: voter)
+}
+
+// Authenticator resolve a identidade de uma requisição a partir do token administrativo,
+// de um JWT (HS256/ES256) ou de uma assinatura feita com a chave de um NodeID
+type Authenticator struct {
+	config     *Config
+	jwt        *JWTVerifier
+	signatures *SignatureVerifier
+}
+
+// NewAuthenticator cria o autenticador da API.
+// Com a autenticação desabilitada, apenas o token administrativo é aceito.
+func NewAuthenticator(config *Config, cryptoService services.CryptographyService) *Authenticator {
+	if config == nil {
+		config = &Config{}
+	}
+
+	authenticator := &Authenticator{config: config}
+	if config.Enabled {
+		if config.JWTSecret != "" || config.JWTPublicKey != nil {
+			authenticator.jwt = NewJWTVerifier([]byte(config.JWTSecret), config.JWTPublicKey)
+		}
+		if cryptoService != nil {
+			authenticator.signatures = NewSignatureVerifier(cryptoService, config.MaxClockSkew)
+		}
+	}
+
+	return authenticator
+}
+
+// Enabled indica se a autorização por papéis está ativa
+func (a *Authenticator) Enabled() bool {
+	return a.config.Enabled
+}
+
+// AdminTokenConfigured indica se há token administrativo configurado
+func (a *Authenticator) AdminTokenConfigured() bool {
+	return a.config.AdminToken != ""
+}
+
+// Authenticate identifica o autor da requisição
+func (a *Authenticator) Authenticate(r *http.Request) (*Identity, error) {
+	// Requisição assinada com a chave do nó
+	if r.Header.Get(HeaderSignature) != "" {
+		if a.signatures == nil {
+			return nil, ErrInvalidSignature
+		}
+		nodeID, err := a.signatures.Verify(r)
+		if err != nil {
+			return nil, err
+		}
+		return &Identity{
+			Subject: nodeID.String(),
+			NodeID:  nodeID,
+			Roles:   a.nodeRoles(nodeID),
+			Method:  MethodSignature,
+		}, nil
+	}
+
+	token := r.Header.Get("X-Admin-Token")
+	if bearer := r.Header.Get("Authorization"); strings.HasPrefix(bearer, "Bearer ") {
+		token = strings.TrimPrefix(bearer, "Bearer ")
+	}
+	// EventSource e WebSocket de navegadores não enviam cabeçalhos: aceitar o token na query em GETs
+	if token == "" && r.Method == http.MethodGet {
+		token = r.URL.Query().Get("access_token")
+	}
+	if token == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	if a.config.AdminToken != "" && subtle.ConstantTimeCompare([]byte(token), []byte(a.config.AdminToken)) == 1 {
+		return &Identity{
+			Subject: "admin",
+			Roles:   []Role{RoleAdmin},
+			Method:  MethodAdminToken,
+		}, nil
+	}
+
+	if a.jwt == nil || strings.Count(token, ".") != 2 {
+		return nil, ErrInvalidToken
+	}
+
+	claims, err := a.jwt.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := claims.ParseRoles()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return &Identity{
+		Subject: claims.Subject,
+		NodeID:  valueobjects.NewNodeID(claims.NodeID),
+		Roles:   roles,
+		Method:  MethodJWT,
+	}, nil
+}
+
+// nodeRoles retorna os papéis configurados para o NodeID (voter quando ausente)
+func (a *Authenticator) nodeRoles(nodeID valueobjects.NodeID) []Role {
+	if roles, exists := a.config.NodeRoles[nodeID.String()]; exists && len(roles) > 0 {
+		return roles
+	}
+	return []Role{RoleVoter}
+}
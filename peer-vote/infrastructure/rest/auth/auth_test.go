@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+)
+
+func TestParseRoleAcceptsSynonyms(t *testing.T) {
+	for name, want := range map[string]Role{"admin": RoleAdmin, " Election_Officer ": RoleElectionOfficer, "VOTER": RoleVoter, "observer": RoleObserver} {
+		if role, err := ParseRole(name); err != nil || role != want {
+			t.Errorf("ParseRole(%q) = %q, %v; want %q", name, role, err, want)
+		}
+	}
+	if _, err := ParseRole("root"); err == nil {
+		t.Errorf("unknown role accepted")
+	}
+}
+
+func TestHasRole(t *testing.T) {
+	voter := &Identity{Roles: []Role{RoleVoter}}
+	if !voter.HasRole(RoleObserver, RoleVoter) || voter.HasRole(RoleElectionOfficer) || voter.HasRole(RoleAdmin) {
+		t.Errorf("voter roles resolved incorrectly")
+	}
+
+	admin := &Identity{Roles: []Role{RoleAdmin}}
+	if !admin.HasRole(RoleElectionOfficer) || !admin.HasRole(RoleVoter) {
+		t.Errorf("admin does not satisfy other roles")
+	}
+
+	if (&Identity{}).HasRole(RoleObserver) {
+		t.Errorf("identity without roles satisfies observer")
+	}
+}
+
+func TestAuthenticateResolvesRoles(t *testing.T) {
+	ctx := context.Background()
+	cryptoService := crypto.NewECDSAService()
+	voterKeys := newKeyPair(t, cryptoService)
+	officerKeys := newKeyPair(t, cryptoService)
+	officer := cryptoService.GenerateNodeID(ctx, officerKeys.PublicKey)
+
+	authenticator := NewAuthenticator(&Config{
+		Enabled:    true,
+		AdminToken: "admin-token",
+		JWTSecret:  "jwt-secret",
+		NodeRoles:  map[string][]Role{officer.String(): {RoleElectionOfficer}},
+	}, cryptoService)
+
+	// Requisições assinadas: voter por padrão, papel configurado para o NodeID
+	for keyPair, want := range map[*services.KeyPair]Role{voterKeys: RoleVoter, officerKeys: RoleElectionOfficer} {
+		identity, err := authenticator.Authenticate(signedRequest(t, cryptoService, keyPair, "{}"))
+		if err != nil {
+			t.Fatalf("Authenticate(signed): %v", err)
+		}
+		if identity.Method != MethodSignature || len(identity.Roles) != 1 || identity.Roles[0] != want {
+			t.Errorf("signed identity = %+v, want role %s", identity, want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/elections", nil)
+	req.Header.Set("X-Admin-Token", "admin-token")
+	if identity, err := authenticator.Authenticate(req); err != nil || !identity.HasRole(RoleAdmin) {
+		t.Errorf("Authenticate(admin token) = %+v, %v", identity, err)
+	}
+
+	token, err := SignHS256(&Claims{Subject: "alice", Roles: []string{"election_officer"}, ExpiresAt: time.Now().Add(time.Hour).Unix()}, []byte("jwt-secret"))
+	if err != nil {
+		t.Fatalf("SignHS256: %v", err)
+	}
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/elections", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	identity, err := authenticator.Authenticate(req)
+	if err != nil || identity.Subject != "alice" || !identity.HasRole(RoleElectionOfficer) || identity.HasRole(RoleAdmin) {
+		t.Errorf("Authenticate(jwt) = %+v, %v", identity, err)
+	}
+
+	// Token sem papel, com segredo errado ou expirado
+	for name, claims := range map[string]*Claims{
+		"no role": {Subject: "bob", ExpiresAt: time.Now().Add(time.Hour).Unix()},
+		"expired": {Subject: "bob", Role: "admin", ExpiresAt: time.Now().Add(-time.Hour).Unix()},
+	} {
+		token, err := SignHS256(claims, []byte("jwt-secret"))
+		if err != nil {
+			t.Fatalf("SignHS256: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/elections", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if _, err := authenticator.Authenticate(req); err == nil {
+			t.Errorf("token with %s accepted", name)
+		}
+	}
+	forged, _ := SignHS256(&Claims{Subject: "mallory", Role: "admin"}, []byte("other-secret"))
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/elections", nil)
+	req.Header.Set("Authorization", "Bearer "+forged)
+	if _, err := authenticator.Authenticate(req); err == nil {
+		t.Errorf("token signed with another secret accepted")
+	}
+
+	if _, err := authenticator.Authenticate(httptest.NewRequest(http.MethodGet, "/api/v1/elections", nil)); !errors.Is(err, ErrMissingCredentials) {
+		t.Errorf("request without credentials error = %v, want ErrMissingCredentials", err)
+	}
+}
+
+func TestAuthenticateWithAuthDisabledOnlyAcceptsAdminToken(t *testing.T) {
+	cryptoService := crypto.NewECDSAService()
+	authenticator := NewAuthenticator(&Config{AdminToken: "admin-token", JWTSecret: "jwt-secret"}, cryptoService)
+
+	if _, err := authenticator.Authenticate(signedRequest(t, cryptoService, newKeyPair(t, cryptoService), "{}")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("signed request error = %v, want ErrInvalidSignature", err)
+	}
+
+	token, _ := SignHS256(&Claims{Subject: "alice", Role: "admin"}, []byte("jwt-secret"))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/status", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if _, err := authenticator.Authenticate(req); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("jwt error = %v, want ErrInvalidToken", err)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/admin/status", nil)
+	req.Header.Set("X-Admin-Token", "admin-token")
+	if identity, err := authenticator.Authenticate(req); err != nil || !identity.HasRole(RoleAdmin) {
+		t.Errorf("Authenticate(admin token) = %+v, %v", identity, err)
+	}
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+)
+
+// Algoritmos de assinatura JWT suportados
+const (
+	AlgHS256 = "HS256"
+	AlgES256 = "ES256"
+)
+
+// jwtLeeway tolera diferenças de relógio na validação de exp e nbf
+const jwtLeeway = 30 * time.Second
+
+// Claims representa as claims reconhecidas nos tokens da API
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Issuer    string   `json:"iss,omitempty"`
+	NodeID    string   `json:"node_id,omitempty"` // Vincula o token a um NodeID (voter_id, created_by)
+	Role      string   `json:"role,omitempty"`
+	Roles     []string `json:"roles,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"`
+	NotBefore int64    `json:"nbf,omitempty"`
+	IssuedAt  int64    `json:"iat,omitempty"`
+}
+
+// ParseRoles retorna os papéis declarados em role e roles
+func (c *Claims) ParseRoles() ([]Role, error) {
+	names := c.Roles
+	if c.Role != "" {
+		names = append([]string{c.Role}, names...)
+	}
+	if len(names) == 0 {
+		return nil, errors.New("token has no role")
+	}
+
+	roles := make([]Role, 0, len(names))
+	for _, name := range names {
+		role, err := ParseRole(name)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, nil
+}
+
+// jwtHeader representa o cabeçalho de um JWT
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+}
+
+// JWTVerifier valida tokens HS256 (segredo compartilhado) e ES256 (chave pública P-256)
+type JWTVerifier struct {
+	secret    []byte
+	publicKey *ecdsa.PublicKey
+}
+
+// NewJWTVerifier cria um verificador; algoritmos sem chave configurada são rejeitados
+func NewJWTVerifier(secret []byte, publicKey *ecdsa.PublicKey) *JWTVerifier {
+	return &JWTVerifier{
+		secret:    secret,
+		publicKey: publicKey,
+	}
+}
+
+// Verify valida assinatura, exp e nbf e retorna as claims do token
+func (v *JWTVerifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	headerBytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var header jwtHeader
+	if err := json.Unmarshal(headerBytes, &header); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	signingInput := []byte(parts[0] + "." + parts[1])
+	switch header.Algorithm {
+	case AlgHS256:
+		if len(v.secret) == 0 {
+			return nil, fmt.Errorf("%w: HS256 not enabled", ErrInvalidToken)
+		}
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write(signingInput)
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return nil, ErrInvalidToken
+		}
+	case AlgES256:
+		if v.publicKey == nil {
+			return nil, fmt.Errorf("%w: ES256 not enabled", ErrInvalidToken)
+		}
+		if len(signature) != 64 {
+			return nil, ErrInvalidToken
+		}
+		hash := sha256.Sum256(signingInput)
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		if !ecdsa.Verify(v.publicKey, hash[:], r, s) {
+			return nil, ErrInvalidToken
+		}
+	default:
+		// Inclui "none": apenas algoritmos explicitamente suportados
+		return nil, fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, header.Algorithm)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	now := time.Now()
+	if claims.ExpiresAt != 0 && now.After(time.Unix(claims.ExpiresAt, 0).Add(jwtLeeway)) {
+		return nil, ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Add(jwtLeeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return nil, fmt.Errorf("%w: token not yet valid", ErrInvalidToken)
+	}
+
+	return &claims, nil
+}
+
+// SignHS256 emite um token HS256 com as claims informadas
+func SignHS256(claims *Claims, secret []byte) (string, error) {
+	if len(secret) == 0 {
+		return "", errors.New("jwt secret is empty")
+	}
+
+	headerBytes, err := json.Marshal(jwtHeader{Algorithm: AlgHS256, Type: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerBytes) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// LoadECDSAPublicKey lê uma chave pública P-256 em PEM (PKIX) para validar tokens ES256
+func LoadECDSAPublicKey(path string) (*ecdsa.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read public key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM public key")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	publicKey, ok := key.(*ecdsa.PublicKey)
+	if !ok || publicKey.Curve.Params().Name != "P-256" {
+		return nil, errors.New("ES256 requires a P-256 ECDSA public key")
+	}
+
+	return publicKey, nil
+}
@@ -0,0 +1,171 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// Cabeçalhos de requisições assinadas com a chave de um NodeID
+const (
+	HeaderNodeID    = "X-Node-ID"
+	HeaderPublicKey = "X-Public-Key" // hex (X || Y), P-256
+	HeaderTimestamp = "X-Timestamp"  // segundos Unix
+	HeaderSignature = "X-Signature"  // hex (r || s) sobre CanonicalRequest
+)
+
+// DefaultMaxClockSkew é a janela padrão de validade de uma requisição assinada
+const DefaultMaxClockSkew = 5 * time.Minute
+
+// maxSignedBodySize limita o corpo lido para verificar a assinatura
+const maxSignedBodySize = 1 << 20
+
+// CanonicalRequest monta os bytes assinados: método, caminho com query, timestamp e hash do corpo
+func CanonicalRequest(method, requestURI string, timestamp int64, body []byte) []byte {
+	bodyHash := sha256.Sum256(body)
+	return []byte(fmt.Sprintf("%s\n%s\n%d\n%s", method, requestURI, timestamp, hex.EncodeToString(bodyHash[:])))
+}
+
+// SignRequest assina a requisição com a chave do nó (usado por clientes da API)
+func SignRequest(ctx context.Context, req *http.Request, body []byte, keyPair *services.KeyPair, cryptoService services.CryptographyService) error {
+	timestamp := time.Now().Unix()
+	signature, err := cryptoService.Sign(ctx, CanonicalRequest(req.Method, req.URL.RequestURI(), timestamp, body), keyPair.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	req.Header.Set(HeaderNodeID, cryptoService.GenerateNodeID(ctx, keyPair.PublicKey).String())
+	req.Header.Set(HeaderPublicKey, hex.EncodeToString(keyPair.PublicKey.ToBytes()))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, hex.EncodeToString(signature.Bytes()))
+	return nil
+}
+
+// SignatureVerifier valida requisições assinadas: a chave pública deve corresponder ao NodeID,
+// o timestamp deve estar dentro da janela e cada requisição assinada só é aceita uma vez
+type SignatureVerifier struct {
+	cryptoService services.CryptographyService
+	maxSkew       time.Duration
+
+	// Requisições já aceitas (hash do NodeID e do conteúdo assinado -> expiração)
+	seen      map[string]time.Time
+	lastPrune time.Time
+
+	// Mutex para operações thread-safe
+	mu sync.Mutex
+}
+
+// NewSignatureVerifier cria um verificador de requisições assinadas
+func NewSignatureVerifier(cryptoService services.CryptographyService, maxSkew time.Duration) *SignatureVerifier {
+	if maxSkew <= 0 {
+		maxSkew = DefaultMaxClockSkew
+	}
+
+	return &SignatureVerifier{
+		cryptoService: cryptoService,
+		maxSkew:       maxSkew,
+		seen:          make(map[string]time.Time),
+	}
+}
+
+// Verify valida a assinatura da requisição e retorna o NodeID comprovado.
+// O corpo é lido e restaurado para os handlers.
+func (v *SignatureVerifier) Verify(r *http.Request) (valueobjects.NodeID, error) {
+	ctx := r.Context()
+
+	timestamp, err := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		return valueobjects.EmptyNodeID(), fmt.Errorf("%w: missing or invalid %s", ErrInvalidSignature, HeaderTimestamp)
+	}
+	now := time.Now()
+	if skew := now.Sub(time.Unix(timestamp, 0)); skew > v.maxSkew || skew < -v.maxSkew {
+		return valueobjects.EmptyNodeID(), ErrRequestExpired
+	}
+
+	keyBytes, err := hex.DecodeString(r.Header.Get(HeaderPublicKey))
+	if err != nil || len(keyBytes) == 0 {
+		return valueobjects.EmptyNodeID(), fmt.Errorf("%w: missing or invalid %s", ErrInvalidSignature, HeaderPublicKey)
+	}
+	publicKey := &services.PublicKey{}
+	if err := publicKey.FromBytes(keyBytes, "P-256"); err != nil {
+		return valueobjects.EmptyNodeID(), fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	// A chave precisa ser a do NodeID declarado
+	nodeID := v.cryptoService.GenerateNodeID(ctx, publicKey)
+	if nodeID.IsEmpty() || nodeID.String() != r.Header.Get(HeaderNodeID) {
+		return valueobjects.EmptyNodeID(), fmt.Errorf("%w: public key does not match %s", ErrInvalidSignature, HeaderNodeID)
+	}
+
+	signatureBytes, err := hex.DecodeString(r.Header.Get(HeaderSignature))
+	if err != nil {
+		return valueobjects.EmptyNodeID(), ErrInvalidSignature
+	}
+
+	var body []byte
+	if r.Body != nil {
+		body, err = io.ReadAll(io.LimitReader(r.Body, maxSignedBodySize+1))
+		if err != nil {
+			return valueobjects.EmptyNodeID(), fmt.Errorf("failed to read request body: %w", err)
+		}
+		if len(body) > maxSignedBodySize {
+			return valueobjects.EmptyNodeID(), fmt.Errorf("%w: body too large", ErrInvalidSignature)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	canonical := CanonicalRequest(r.Method, r.URL.RequestURI(), timestamp, body)
+	valid, err := v.cryptoService.Verify(ctx, canonical, valueobjects.NewSignature(signatureBytes), publicKey)
+	if err != nil || !valid {
+		return valueobjects.EmptyNodeID(), ErrInvalidSignature
+	}
+
+	// O cache usa o conteúdo assinado, não a assinatura: ECDSA é maleável ((r, n-s) também
+	// é válida), então uma assinatura alterada não pode reabrir a mesma requisição
+	if !v.markSeen(replayKey(nodeID, canonical), now) {
+		return valueobjects.EmptyNodeID(), ErrReplayedRequest
+	}
+
+	return nodeID, nil
+}
+
+// replayKey identifica uma requisição assinada pelo NodeID e pelo conteúdo canônico
+func replayKey(nodeID valueobjects.NodeID, canonical []byte) string {
+	hash := sha256.New()
+	hash.Write([]byte(nodeID.String()))
+	hash.Write([]byte{'\n'})
+	hash.Write(canonical)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// markSeen registra a requisição; retorna false se já foi aceita dentro da janela
+func (v *SignatureVerifier) markSeen(key string, now time.Time) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// Assinaturas fora da janela não precisam mais ser lembradas
+	if now.Sub(v.lastPrune) > v.maxSkew {
+		for key, expiresAt := range v.seen {
+			if now.After(expiresAt) {
+				delete(v.seen, key)
+			}
+		}
+		v.lastPrune = now
+	}
+
+	if expiresAt, exists := v.seen[key]; exists && now.Before(expiresAt) {
+		return false
+	}
+	v.seen[key] = now.Add(2 * v.maxSkew)
+	return true
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/elliptic"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+)
+
+// newKeyPair gera um par de chaves de nó
+func newKeyPair(t *testing.T, cryptoService services.CryptographyService) *services.KeyPair {
+	t.Helper()
+
+	keyPair, err := cryptoService.GenerateKeyPair(context.Background())
+	if err != nil {
+		t.Fatalf("generate key pair: %v", err)
+	}
+	return keyPair
+}
+
+// signedRequest cria uma requisição POST assinada com a chave informada
+func signedRequest(t *testing.T, cryptoService services.CryptographyService, keyPair *services.KeyPair, body string) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/votes?wait=true", bytes.NewReader([]byte(body)))
+	if err := SignRequest(context.Background(), req, []byte(body), keyPair, cryptoService); err != nil {
+		t.Fatalf("SignRequest: %v", err)
+	}
+	return req
+}
+
+// resend copia a requisição com os mesmos cabeçalhos e o corpo informado
+func resend(req *http.Request, body string) *http.Request {
+	copied := httptest.NewRequest(req.Method, req.URL.RequestURI(), bytes.NewReader([]byte(body)))
+	copied.Header = req.Header.Clone()
+	return copied
+}
+
+func TestSignatureVerifierRejectsReplays(t *testing.T) {
+	cryptoService := crypto.NewECDSAService()
+	verifier := NewSignatureVerifier(cryptoService, time.Minute)
+	keyPair := newKeyPair(t, cryptoService)
+
+	req := signedRequest(t, cryptoService, keyPair, `{"vote":1}`)
+	nodeID, err := verifier.Verify(req)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !nodeID.Equals(cryptoService.GenerateNodeID(context.Background(), keyPair.PublicKey)) {
+		t.Errorf("node ID = %s, want the signer", nodeID.ShortString())
+	}
+
+	// O corpo lido na verificação continua disponível para os handlers
+	if body, _ := io.ReadAll(req.Body); string(body) != `{"vote":1}` {
+		t.Errorf("body after Verify = %q", body)
+	}
+
+	if _, err := verifier.Verify(resend(req, `{"vote":1}`)); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("replayed request error = %v, want ErrReplayedRequest", err)
+	}
+
+	// Assinatura maleável (r, n-s) do mesmo conteúdo também é replay
+	signature, err := hex.DecodeString(req.Header.Get(HeaderSignature))
+	if err != nil || len(signature)%2 != 0 {
+		t.Fatalf("unexpected signature encoding %q", req.Header.Get(HeaderSignature))
+	}
+	half := len(signature) / 2
+	s := new(big.Int).SetBytes(signature[half:])
+	s.Sub(elliptic.P256().Params().N, s)
+	malleated := append(append([]byte{}, signature[:half]...), s.FillBytes(make([]byte, half))...)
+
+	replay := resend(req, `{"vote":1}`)
+	replay.Header.Set(HeaderSignature, hex.EncodeToString(malleated))
+	if _, err := verifier.Verify(replay); !errors.Is(err, ErrReplayedRequest) {
+		t.Errorf("malleated signature error = %v, want ErrReplayedRequest", err)
+	}
+}
+
+func TestSignatureVerifierRejectsTamperedRequests(t *testing.T) {
+	cryptoService := crypto.NewECDSAService()
+	verifier := NewSignatureVerifier(cryptoService, time.Minute)
+	keyPair := newKeyPair(t, cryptoService)
+
+	req := signedRequest(t, cryptoService, keyPair, `{"vote":1}`)
+	if _, err := verifier.Verify(resend(req, `{"vote":2}`)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("tampered body error = %v, want ErrInvalidSignature", err)
+	}
+
+	// Chave de outro nó declarando o NodeID do signatário
+	impersonation := resend(req, `{"vote":1}`)
+	impersonation.Header.Set(HeaderPublicKey, hex.EncodeToString(newKeyPair(t, cryptoService).PublicKey.ToBytes()))
+	if _, err := verifier.Verify(impersonation); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("mismatched public key error = %v, want ErrInvalidSignature", err)
+	}
+
+	expired := resend(req, `{"vote":1}`)
+	expired.Header.Set(HeaderTimestamp, strconv.FormatInt(time.Now().Add(-2*time.Minute).Unix(), 10))
+	if _, err := verifier.Verify(expired); !errors.Is(err, ErrRequestExpired) {
+		t.Errorf("expired request error = %v, want ErrRequestExpired", err)
+	}
+
+	// As rejeições não consomem a requisição original
+	if _, err := verifier.Verify(resend(req, `{"vote":1}`)); err != nil {
+		t.Errorf("original request rejected after tampered copies: %v", err)
+	}
+}
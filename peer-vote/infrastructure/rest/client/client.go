@@ -0,0 +1,190 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/auth"
+)
+
+// DefaultBaseURL é o endereço padrão da API REST de um nó
+const DefaultBaseURL = "http://localhost:8080"
+
+// maxErrorBodySize limita a mensagem de erro lida da resposta
+const maxErrorBodySize = 4096
+
+// APIError representa uma resposta de erro da API
+type APIError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Message    string
+}
+
+// Error implementa a interface error
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s %s: %d %s: %s", e.Method, e.Path, e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// IsStatus verifica se o erro é uma resposta da API com o status informado
+func IsStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// IsNotFound verifica se o erro é uma resposta 404 da API
+func IsNotFound(err error) bool {
+	return IsStatus(err, http.StatusNotFound)
+}
+
+// Option configura o cliente
+type Option func(*Client)
+
+// WithHTTPClient define o cliente HTTP usado nas requisições
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken autentica as requisições com um JWT ou com o token administrativo (Authorization: Bearer)
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithSigner assina cada requisição com a chave do nó (X-Node-ID, X-Public-Key, X-Timestamp, X-Signature)
+func WithSigner(keyPair *services.KeyPair, cryptoService services.CryptographyService) Option {
+	return func(c *Client) {
+		c.keyPair = keyPair
+		c.cryptoService = cryptoService
+	}
+}
+
+// Client é o cliente tipado da API REST do Peer-Vote.
+// Os tipos de requisição e resposta são os mesmos usados pelos handlers do servidor.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+
+	// Credenciais
+	token         string
+	keyPair       *services.KeyPair
+	cryptoService services.CryptographyService
+}
+
+// NewClient cria um cliente para a API do nó em baseURL (ex: http://localhost:8080)
+func NewClient(baseURL string, opts ...Option) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	client := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	return client
+}
+
+// BaseURL retorna o endereço da API
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// newRequest cria uma requisição autenticada para a API
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values, body interface{}) (*http.Request, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+	}
+
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	switch {
+	case c.keyPair != nil && c.cryptoService != nil:
+		if err := auth.SignRequest(ctx, req, payload, c.keyPair, c.cryptoService); err != nil {
+			return nil, err
+		}
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	return req, nil
+}
+
+// do executa a requisição e decodifica a resposta JSON em out.
+// Status fora de 2xx (e de acceptStatus) viram *APIError.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}, acceptStatus ...int) error {
+	req, err := c.newRequest(ctx, method, path, query, body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp, acceptStatus...); err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: failed to decode response: %w", method, path, err)
+	}
+	return nil
+}
+
+// checkStatus converte respostas de erro em *APIError
+func checkStatus(resp *http.Response, acceptStatus ...int) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	for _, status := range acceptStatus {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return &APIError{
+		Method:     resp.Request.Method,
+		Path:       resp.Request.URL.Path,
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(message)),
+	}
+}
@@ -0,0 +1,622 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/certification"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/health"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/handlers"
+)
+
+// apiPrefix é o prefixo das rotas versionadas
+const apiPrefix = "/api/v1"
+
+// Informações
+
+// GetInfo obtém as informações gerais da API
+func (c *Client) GetInfo(ctx context.Context) (*rest.APIInfoResponse, error) {
+	var response rest.APIInfoResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/info", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetOpenAPISpec obtém a especificação OpenAPI 3 servida pelo nó
+func (c *Client) GetOpenAPISpec(ctx context.Context) (json.RawMessage, error) {
+	var spec json.RawMessage
+	if err := c.do(ctx, http.MethodGet, rest.OpenAPIPath, nil, nil, &spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// Eleições
+
+// PageOptions controla a paginação por cursor das listagens
+type PageOptions struct {
+	Limit  int    // 0 = padrão do servidor
+	Cursor string // NextCursor da página anterior
+	Order  string // "asc" ou "desc" pela posição na cadeia
+}
+
+// values converte as opções em parâmetros de query
+func (p PageOptions) values() url.Values {
+	query := url.Values{}
+	if p.Limit > 0 {
+		query.Set("limit", strconv.Itoa(p.Limit))
+	}
+	if p.Cursor != "" {
+		query.Set("cursor", p.Cursor)
+	}
+	if p.Order != "" {
+		query.Set("order", p.Order)
+	}
+	return query
+}
+
+// ListElectionsOptions filtra e pagina a listagem de eleições
+type ListElectionsOptions struct {
+	PageOptions
+	ActiveOnly bool
+	Status     string // PENDING, ACTIVE, CLOSED ou CANCELLED (status efetivo)
+	CreatedBy  string
+	From       time.Time // Votação termina após From
+	To         time.Time // Votação começa antes de To
+}
+
+// AuditVotesOptions filtra e pagina os votos listados na auditoria
+type AuditVotesOptions struct {
+	PageOptions
+	CandidateID string
+	Valid       *bool
+}
+
+// values converte os filtros e a paginação em parâmetros de query
+func (o AuditVotesOptions) values() url.Values {
+	query := o.PageOptions.values()
+	if o.CandidateID != "" {
+		query.Set("candidate_id", o.CandidateID)
+	}
+	if o.Valid != nil {
+		query.Set("valid", strconv.FormatBool(*o.Valid))
+	}
+	return query
+}
+
+// ListBlocksOptions filtra e pagina a listagem de blocos
+type ListBlocksOptions struct {
+	PageOptions
+	Validator  string
+	FromHeight *uint64
+	ToHeight   *uint64
+	TxType     string // VOTE, ELECTION, VALIDATOR, EVIDENCE ou CERTIFICATE
+}
+
+// CreateElection cria uma eleição (papel election-officer)
+func (c *Client) CreateElection(ctx context.Context, request *handlers.CreateElectionRequest) (*usecases.CreateElectionResponse, error) {
+	var response usecases.CreateElectionResponse
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/elections", nil, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ListElections lista eleições; continue com options.Cursor = response.NextCursor enquanto HasMore
+func (c *Client) ListElections(ctx context.Context, options ListElectionsOptions) (*usecases.ListElectionsResponse, error) {
+	query := options.values()
+	if options.ActiveOnly {
+		query.Set("active", "true")
+	}
+	if options.Status != "" {
+		query.Set("status", options.Status)
+	}
+	if options.CreatedBy != "" {
+		query.Set("created_by", options.CreatedBy)
+	}
+	if !options.From.IsZero() {
+		query.Set("from", strconv.FormatInt(options.From.Unix(), 10))
+	}
+	if !options.To.IsZero() {
+		query.Set("to", strconv.FormatInt(options.To.Unix(), 10))
+	}
+
+	var response usecases.ListElectionsResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/elections", query, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetElection obtém uma eleição
+func (c *Client) GetElection(ctx context.Context, electionID string) (*usecases.GetElectionResponse, error) {
+	var response usecases.GetElectionResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/elections/"+url.PathEscape(electionID), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// UpdateElectionStatus altera o status de uma eleição (papel election-officer)
+func (c *Client) UpdateElectionStatus(ctx context.Context, electionID string, request *handlers.UpdateElectionStatusRequest) (*usecases.UpdateElectionStatusResponse, error) {
+	var response usecases.UpdateElectionStatusResponse
+	if err := c.do(ctx, http.MethodPut, apiPrefix+"/elections/"+url.PathEscape(electionID)+"/status", nil, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetElectionResults obtém os resultados de uma eleição
+func (c *Client) GetElectionResults(ctx context.Context, electionID string) (*usecases.GetElectionResultsResponse, error) {
+	var response usecases.GetElectionResultsResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/elections/"+url.PathEscape(electionID)+"/results", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// CertifyResults propõe o documento de resultado de uma eleição encerrada (papel election-officer)
+func (c *Client) CertifyResults(ctx context.Context, electionID string) (*usecases.CertifyResultsResponse, error) {
+	var response usecases.CertifyResultsResponse
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/elections/"+url.PathEscape(electionID)+"/certify", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetCertificate obtém o certificado de resultado de uma eleição
+func (c *Client) GetCertificate(ctx context.Context, electionID string) (*certification.Certificate, error) {
+	var response certification.Certificate
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/elections/"+url.PathEscape(electionID)+"/certificate", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ImportCandidates valida um CSV de candidatos no nó (papel election-officer)
+func (c *Client) ImportCandidates(ctx context.Context, csv string) (*usecases.CandidateImport, error) {
+	var response usecases.CandidateImport
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/elections/candidates/import", nil, &handlers.ImportCandidatesRequest{CSV: csv}, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Modelos de eleição
+
+// ListElectionTemplates lista os modelos de eleição do nó
+func (c *Client) ListElectionTemplates(ctx context.Context) (*handlers.ElectionTemplateListResponse, error) {
+	var response handlers.ElectionTemplateListResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/election-templates", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetElectionTemplate obtém um modelo de eleição
+func (c *Client) GetElectionTemplate(ctx context.Context, name string) (*entities.ElectionTemplate, error) {
+	var response entities.ElectionTemplate
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/election-templates/"+url.PathEscape(name), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// CreateElectionTemplate cria um modelo de eleição (papel election-officer)
+func (c *Client) CreateElectionTemplate(ctx context.Context, template *entities.ElectionTemplate) (*entities.ElectionTemplate, error) {
+	var response entities.ElectionTemplate
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/election-templates", nil, template, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// UpdateElectionTemplate substitui as regras de um modelo de eleição (papel election-officer)
+func (c *Client) UpdateElectionTemplate(ctx context.Context, template *entities.ElectionTemplate) (*entities.ElectionTemplate, error) {
+	var response entities.ElectionTemplate
+	if err := c.do(ctx, http.MethodPut, apiPrefix+"/election-templates/"+url.PathEscape(template.Name), nil, template, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// DeleteElectionTemplate remove um modelo de eleição (papel election-officer)
+func (c *Client) DeleteElectionTemplate(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, apiPrefix+"/election-templates/"+url.PathEscape(name), nil, nil, nil)
+}
+
+// CreateElectionFromTemplate cria uma eleição com as regras de um modelo (papel election-officer)
+func (c *Client) CreateElectionFromTemplate(ctx context.Context, name string, request *handlers.CreateElectionFromTemplateRequest) (*usecases.CreateElectionResponse, error) {
+	var response usecases.CreateElectionResponse
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/election-templates/"+url.PathEscape(name)+"/elections", nil, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Votos
+
+// SubmitVote submete um voto (papel voter)
+func (c *Client) SubmitVote(ctx context.Context, request *handlers.SubmitVoteRequest) (*usecases.SubmitVoteResponse, error) {
+	var response usecases.SubmitVoteResponse
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/votes", nil, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// AuditVotes audita os votos de uma eleição; o resumo cobre todos os votos e AuditResults é paginado
+func (c *Client) AuditVotes(ctx context.Context, electionID string, options AuditVotesOptions) (*usecases.AuditVotesResponse, error) {
+	var response usecases.AuditVotesResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/votes/audit/"+url.PathEscape(electionID), options.values(), nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// CountVotes conta os votos de uma eleição
+func (c *Client) CountVotes(ctx context.Context, electionID string) (*usecases.CountVotesResponse, error) {
+	var response usecases.CountVotesResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/votes/count/"+url.PathEscape(electionID), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Blockchain
+
+// ListBlocks lista blocos; continue com options.Cursor = response.NextCursor enquanto HasMore
+func (c *Client) ListBlocks(ctx context.Context, options ListBlocksOptions) (*handlers.BlockListResponse, error) {
+	query := options.values()
+	if options.Validator != "" {
+		query.Set("validator", options.Validator)
+	}
+	if options.FromHeight != nil {
+		query.Set("from_height", strconv.FormatUint(*options.FromHeight, 10))
+	}
+	if options.ToHeight != nil {
+		query.Set("to_height", strconv.FormatUint(*options.ToHeight, 10))
+	}
+	if options.TxType != "" {
+		query.Set("tx_type", options.TxType)
+	}
+
+	var response handlers.BlockListResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/blocks", query, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetBlock obtém um bloco pela altura
+func (c *Client) GetBlock(ctx context.Context, index uint64) (*handlers.BlockResponse, error) {
+	var response handlers.BlockResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/blocks/"+strconv.FormatUint(index, 10), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetBlockByHash obtém um bloco pelo hash
+func (c *Client) GetBlockByHash(ctx context.Context, hash string) (*handlers.BlockResponse, error) {
+	var response handlers.BlockResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/blocks/hash/"+url.PathEscape(hash), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetLatestBlock obtém o último bloco da cadeia
+func (c *Client) GetLatestBlock(ctx context.Context) (*handlers.BlockResponse, error) {
+	var response handlers.BlockResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/blocks/latest", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetChainStatus obtém o status da cadeia
+func (c *Client) GetChainStatus(ctx context.Context) (*handlers.ChainStatusResponse, error) {
+	var response handlers.ChainStatusResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/chain/status", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ValidateChain valida a integridade da cadeia no nó
+func (c *Client) ValidateChain(ctx context.Context) (*handlers.ChainValidationResponse, error) {
+	var response handlers.ChainValidationResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/chain/validate", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetChainManifest obtém a altura, os hashes e o conjunto de validadores da cadeia do nó
+func (c *Client) GetChainManifest(ctx context.Context) (*archive.Manifest, error) {
+	var response archive.Manifest
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/chain/manifest", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetArchivedBlock obtém o bloco completo, com os dados das transações
+func (c *Client) GetArchivedBlock(ctx context.Context, index uint64) (*archive.ArchivedBlock, error) {
+	var response archive.ArchivedBlock
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/chain/blocks/"+strconv.FormatUint(index, 10), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Transações
+
+// GetTransactionStatus obtém o estado de uma transação
+func (c *Client) GetTransactionStatus(ctx context.Context, hash string) (*handlers.TransactionStatusResponse, error) {
+	var response handlers.TransactionStatusResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/transactions/"+url.PathEscape(hash), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetMempool obtém a ocupação do mempool e até limit transações pendentes (0 usa o padrão do nó)
+func (c *Client) GetMempool(ctx context.Context, limit int) (*handlers.MempoolResponse, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var response handlers.MempoolResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/mempool", query, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Nós
+
+// GetNodeStatus obtém o status do nó
+func (c *Client) GetNodeStatus(ctx context.Context) (*handlers.NodeStatusResponse, error) {
+	var response handlers.NodeStatusResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/nodes/status", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetNetworkStatus obtém o status da rede
+func (c *Client) GetNetworkStatus(ctx context.Context) (*handlers.NetworkStatusResponse, error) {
+	var response handlers.NetworkStatusResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/nodes/network", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetPeers obtém os peers conectados
+func (c *Client) GetPeers(ctx context.Context) (*handlers.PeerListResponse, error) {
+	var response handlers.PeerListResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/nodes/peers", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Health executa o health check do nó. Um nó não saudável (503) retorna a resposta sem erro.
+func (c *Client) Health(ctx context.Context) (*handlers.HealthResponse, error) {
+	var response handlers.HealthResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/nodes/health", nil, nil, &response, http.StatusServiceUnavailable); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Live executa a verificação de liveness (/health/live). Um nó down (503) retorna o relatório sem erro.
+func (c *Client) Live(ctx context.Context) (*health.Report, error) {
+	var response health.Report
+	if err := c.do(ctx, http.MethodGet, rest.DefaultHealthPath+"/live", nil, nil, &response, http.StatusServiceUnavailable); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Ready executa a verificação de readiness (/health/ready). Um nó down (503) retorna o relatório sem erro.
+func (c *Client) Ready(ctx context.Context) (*health.Report, error) {
+	var response health.Report
+	if err := c.do(ctx, http.MethodGet, rest.DefaultHealthPath+"/ready", nil, nil, &response, http.StatusServiceUnavailable); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Validadores
+
+// ListValidators lista os validadores com suas estatísticas
+func (c *Client) ListValidators(ctx context.Context) (*handlers.ValidatorListResponse, error) {
+	var response handlers.ValidatorListResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/validators", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetValidator obtém um validador
+func (c *Client) GetValidator(ctx context.Context, nodeID string) (*handlers.ValidatorResponse, error) {
+	var response handlers.ValidatorResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/validators/"+url.PathEscape(nodeID), nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetValidatorPenalties obtém as penalidades de um validador
+func (c *Client) GetValidatorPenalties(ctx context.Context, nodeID string, activeOnly bool) (*handlers.ValidatorPenaltiesResponse, error) {
+	query := url.Values{}
+	if activeOnly {
+		query.Set("active", "true")
+	}
+
+	var response handlers.ValidatorPenaltiesResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/validators/"+url.PathEscape(nodeID)+"/penalties", query, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ListValidatorProposals lista as propostas de validador pendentes
+func (c *Client) ListValidatorProposals(ctx context.Context) (*handlers.ValidatorProposalListResponse, error) {
+	var response handlers.ValidatorProposalListResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/validators/proposals", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ProposeValidator propõe a adição de um validador (papel admin)
+func (c *Client) ProposeValidator(ctx context.Context, request *handlers.ProposeValidatorRequest) (*handlers.ValidatorProposalSubmittedResponse, error) {
+	var response handlers.ValidatorProposalSubmittedResponse
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/validators", nil, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ProposeValidatorRemoval propõe a remoção de um validador (papel admin; effectiveHeight 0 usa o padrão)
+func (c *Client) ProposeValidatorRemoval(ctx context.Context, nodeID string, effectiveHeight uint64) (*handlers.ValidatorProposalSubmittedResponse, error) {
+	query := url.Values{}
+	if effectiveHeight > 0 {
+		query.Set("effective_height", strconv.FormatUint(effectiveHeight, 10))
+	}
+
+	var response handlers.ValidatorProposalSubmittedResponse
+	if err := c.do(ctx, http.MethodDelete, apiPrefix+"/validators/"+url.PathEscape(nodeID), query, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ApproveValidatorProposal aprova uma proposta de validador (papel admin)
+func (c *Client) ApproveValidatorProposal(ctx context.Context, proposalID string) (*handlers.ProposalApprovedResponse, error) {
+	var response handlers.ProposalApprovedResponse
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/validators/proposals/"+url.PathEscape(proposalID)+"/approve", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Consenso
+
+// GetConsensusStatus obtém o status do consenso
+func (c *Client) GetConsensusStatus(ctx context.Context) (*handlers.ConsensusStatusResponse, error) {
+	var response handlers.ConsensusStatusResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/consensus/status", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetRound obtém o round atual e o proposer esperado
+func (c *Client) GetRound(ctx context.Context) (*handlers.RoundInfoResponse, error) {
+	var response handlers.RoundInfoResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/consensus/round", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// StartConsensus inicia o consenso (papel admin)
+func (c *Client) StartConsensus(ctx context.Context) (*handlers.ConsensusStartedResponse, error) {
+	var response handlers.ConsensusStartedResponse
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/consensus/start", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// StopConsensus para o consenso (papel admin)
+func (c *Client) StopConsensus(ctx context.Context) (*handlers.OperationResponse, error) {
+	var response handlers.OperationResponse
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/consensus/stop", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ConfigureConsensus altera os parâmetros de produção de blocos (papel admin)
+func (c *Client) ConfigureConsensus(ctx context.Context, request *handlers.ConfigureConsensusPayload) (*handlers.OperationResponse, error) {
+	var response handlers.OperationResponse
+	if err := c.do(ctx, http.MethodPut, apiPrefix+"/consensus/config", nil, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Administração
+
+// GetLogLevels retorna o nível de log padrão e o de cada componente (papel admin)
+func (c *Client) GetLogLevels(ctx context.Context) (*handlers.LogLevelsResponse, error) {
+	var response handlers.LogLevelsResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/admin/log-levels", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// SetLogLevel altera o nível de log de um componente; vazio altera todos (papel admin)
+func (c *Client) SetLogLevel(ctx context.Context, component, level string) (*handlers.LogLevelsResponse, error) {
+	request := &handlers.SetLogLevelPayload{Component: component, Level: level}
+	var response handlers.LogLevelsResponse
+	if err := c.do(ctx, http.MethodPut, apiPrefix+"/admin/log-levels", nil, request, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetAdminStatus obtém o status consolidado do nó, com a latência de cada peer (papel admin)
+func (c *Client) GetAdminStatus(ctx context.Context) (*handlers.NodeOverviewResponse, error) {
+	var response handlers.NodeOverviewResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/admin/status", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// GetChainArchive exporta a cadeia completa com o histórico de validadores (papel admin)
+func (c *Client) GetChainArchive(ctx context.Context) (*archive.Archive, error) {
+	var response archive.Archive
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/admin/chain/export", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ListSnapshots lista os snapshots de estado mantidos pelo nó (papel admin)
+func (c *Client) ListSnapshots(ctx context.Context) (*handlers.SnapshotListResponse, error) {
+	var response handlers.SnapshotListResponse
+	if err := c.do(ctx, http.MethodGet, apiPrefix+"/admin/chain/snapshots", nil, nil, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// ImportChain envia um arquivo de cadeia para o nó (papel admin).
+// Arquivos recusados pela verificação retornam o relatório com Result nil.
+func (c *Client) ImportChain(ctx context.Context, chainArchive *archive.Archive) (*handlers.ChainImportResponse, error) {
+	var response handlers.ChainImportResponse
+	if err := c.do(ctx, http.MethodPost, apiPrefix+"/admin/chain/import", nil, chainArchive, &response, http.StatusUnprocessableEntity); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// EventsOptions filtra a assinatura de eventos
+type EventsOptions struct {
+	ElectionID  string  // Apenas eventos da eleição (reorgs são sempre entregues)
+	FromHeight  *uint64 // Retoma a partir da altura (replay)
+	LastEventID *uint64 // Retoma logo após o evento (EventStream.LastEventID); ignorado com FromHeight
+}
+
+// Event representa um evento recebido da cadeia.
+// Data mantém o JSON original; use DecodeData com o tipo correspondente ao evento.
+type Event struct {
+	Type      string          `json:"type"`
+	Height    uint64          `json:"height"`
+	Sequence  uint64          `json:"sequence,omitempty"`
+	Timestamp int64           `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// DecodeData decodifica os dados do evento (ex: events.BlockEventData)
+func (e *Event) DecodeData(out interface{}) error {
+	return json.Unmarshal(e.Data, out)
+}
+
+// EventStream lê eventos de uma assinatura Server-Sent Events
+type EventStream struct {
+	body        io.ReadCloser
+	reader      *bufio.Reader
+	lastHeight  *uint64
+	lastEventID *uint64
+}
+
+// SubscribeEvents assina os eventos da cadeia via SSE.
+// A conexão é de longa duração: o cliente HTTP não deve ter Timeout (use o contexto para encerrar).
+func (c *Client) SubscribeEvents(ctx context.Context, options EventsOptions) (*EventStream, error) {
+	query := url.Values{}
+	if options.ElectionID != "" {
+		query.Set("election", options.ElectionID)
+	}
+	if options.FromHeight != nil {
+		query.Set("from_height", strconv.FormatUint(*options.FromHeight, 10))
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, apiPrefix+"/events", query, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if options.LastEventID != nil {
+		req.Header.Set("Last-Event-ID", strconv.FormatUint(*options.LastEventID, 10))
+	}
+
+	// Sem o Timeout do cliente padrão, que encerraria a assinatura
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GET %s/events: %w", apiPrefix, err)
+	}
+	if err := checkStatus(resp); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+
+	return &EventStream{
+		body:   resp.Body,
+		reader: bufio.NewReader(resp.Body),
+	}, nil
+}
+
+// Next bloqueia até o próximo evento. Retorna io.EOF quando o servidor encerra a assinatura
+// (ex: cliente lento); retome com LastEventID = LastEventID().
+func (s *EventStream) Next() (*Event, error) {
+	var data strings.Builder
+
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF && data.Len() == 0 {
+				return nil, io.EOF
+			}
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		switch {
+		case line == "":
+			// Fim do evento (sem dados, apenas atualiza a posição de retomada)
+			if data.Len() == 0 {
+				continue
+			}
+			var event Event
+			if err := json.Unmarshal([]byte(data.String()), &event); err != nil {
+				return nil, fmt.Errorf("failed to decode event: %w", err)
+			}
+			height := event.Height
+			s.lastHeight = &height
+			return &event, nil
+		case strings.HasPrefix(line, ":"):
+			// Comentário (keep-alive)
+		case strings.HasPrefix(line, "id:"):
+			id, err := strconv.ParseUint(strings.TrimSpace(strings.TrimPrefix(line, "id:")), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid event id: %w", err)
+			}
+			s.lastEventID = &id
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
+
+// LastHeight retorna a altura do último evento recebido (nil antes do primeiro)
+func (s *EventStream) LastHeight() *uint64 {
+	return s.lastHeight
+}
+
+// LastEventID retorna o id do último evento recebido, usado para retomar a assinatura
+// sem perder nem repetir eventos (nil antes do primeiro)
+func (s *EventStream) LastEventID() *uint64 {
+	return s.lastEventID
+}
+
+// Close encerra a assinatura
+func (s *EventStream) Close() error {
+	return s.body.Close()
+}
@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/report"
+)
+
+// ExportResults escreve em w a apuração por candidato no formato pedido (json, csv ou markdown)
+func (c *Client) ExportResults(ctx context.Context, electionID string, format report.Format, w io.Writer) error {
+	return c.download(ctx, apiPrefix+"/votes/count/"+url.PathEscape(electionID), nil, format, w)
+}
+
+// ExportAudit escreve em w todos os votos auditados que atendem aos filtros (csv, ndjson ou
+// markdown), lidos em streaming; cursor e limite de options não se aplicam
+func (c *Client) ExportAudit(ctx context.Context, electionID string, options AuditVotesOptions, format report.Format, w io.Writer) error {
+	return c.download(ctx, apiPrefix+"/votes/audit/"+url.PathEscape(electionID), options.values(), format, w)
+}
+
+// ExportReport escreve em w o relatório da eleição (markdown ou json)
+func (c *Client) ExportReport(ctx context.Context, electionID string, format report.Format, w io.Writer) error {
+	return c.download(ctx, apiPrefix+"/elections/"+url.PathEscape(electionID)+"/report", nil, format, w)
+}
+
+// download negocia o formato pelo cabeçalho Accept e copia a resposta para w.
+// Exportações de eleições grandes são longas: o Timeout do cliente não se aplica.
+func (c *Client) download(ctx context.Context, path string, query url.Values, format report.Format, w io.Writer) error {
+	req, err := c.newRequest(ctx, http.MethodGet, path, query, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", report.MediaType(format))
+
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("GET %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if err := checkStatus(resp); err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("GET %s: failed to read response: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+)
+
+// ChainArchiveHandler gerencia a exportação e a importação da cadeia completa
+type ChainArchiveHandler struct {
+	nodeID           valueobjects.NodeID
+	chainManager     *blockchain.ChainManager
+	validatorManager *consensus.ValidatorManager
+	cryptoService    services.CryptographyService
+	adminOnly        Middleware
+}
+
+// NewChainArchiveHandler cria um novo handler de arquivo de cadeia.
+// adminOnly protege a exportação, que copia a cadeia inteira, e a importação, que a altera;
+// o manifesto e os blocos completos, lidos um a um pelos auditores externos, são públicos.
+func NewChainArchiveHandler(
+	nodeID valueobjects.NodeID,
+	chainManager *blockchain.ChainManager,
+	validatorManager *consensus.ValidatorManager,
+	cryptoService services.CryptographyService,
+	adminOnly Middleware,
+) *ChainArchiveHandler {
+	return &ChainArchiveHandler{
+		nodeID:           nodeID,
+		chainManager:     chainManager,
+		validatorManager: validatorManager,
+		cryptoService:    cryptoService,
+		adminOnly:        adminOnly,
+	}
+}
+
+// ChainImportResponse representa o resultado da importação com o relatório de verificação
+type ChainImportResponse struct {
+	Report *archive.VerificationReport `json:"report"`
+	Result *archive.ImportResult       `json:"result"`
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *ChainArchiveHandler) RegisterRoutes(router *mux.Router) {
+	router.Handle("/admin/chain/export", h.adminOnly(http.HandlerFunc(h.ExportChain))).Methods("GET")
+	router.Handle("/admin/chain/import", h.adminOnly(http.HandlerFunc(h.ImportChain))).Methods("POST")
+	router.HandleFunc("/chain/manifest", h.GetManifest).Methods("GET")
+	router.HandleFunc("/chain/blocks/{index:[0-9]+}", h.GetArchivedBlock).Methods("GET")
+}
+
+// GetManifest retorna a altura, os hashes e o conjunto de validadores da cadeia do nó
+func (h *ChainArchiveHandler) GetManifest(w http.ResponseWriter, r *http.Request) {
+	if h.chainManager == nil || h.validatorManager == nil {
+		http.Error(w, "Chain archive not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	manifest, err := archive.NewManifest(r.Context(), h.chainManager, h.validatorManager, h.nodeID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to describe chain: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(manifest)
+}
+
+// GetArchivedBlock retorna o bloco completo, com os dados das transações, no formato do arquivo de cadeia
+func (h *ChainArchiveHandler) GetArchivedBlock(w http.ResponseWriter, r *http.Request) {
+	if h.chainManager == nil {
+		http.Error(w, "Chain archive not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	index, err := strconv.ParseUint(mux.Vars(r)["index"], 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid block index", http.StatusBadRequest)
+		return
+	}
+
+	block, err := h.chainManager.GetBlockByIndex(r.Context(), index)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(archive.NewArchivedBlock(block, h.chainManager.CalculateBlockHash(r.Context(), block)))
+}
+
+// ExportChain retorna o arquivo autodescritivo da cadeia (gênese, blocos e histórico de validadores)
+func (h *ChainArchiveHandler) ExportChain(w http.ResponseWriter, r *http.Request) {
+	if h.chainManager == nil || h.validatorManager == nil {
+		http.Error(w, "Chain archive not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	chainArchive, err := archive.Export(r.Context(), h.chainManager, h.validatorManager, h.nodeID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to export chain: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="chain-%s.json"`, chainArchive.Chain.HeadHash))
+	archive.Write(w, chainArchive)
+}
+
+// ImportChain verifica um arquivo de cadeia e inicializa a cadeia local a partir dele.
+// Arquivos que não passam na verificação são recusados com o relatório (422).
+func (h *ChainArchiveHandler) ImportChain(w http.ResponseWriter, r *http.Request) {
+	if h.chainManager == nil || h.validatorManager == nil || h.cryptoService == nil {
+		http.Error(w, "Chain archive not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	chainArchive, err := archive.Read(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := r.Context()
+	response := ChainImportResponse{Report: archive.Verify(ctx, chainArchive, h.cryptoService)}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !response.Report.Valid {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(response)
+		return
+	}
+
+	response.Result, err = archive.Import(ctx, chainArchive, h.chainManager, h.validatorManager)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, archive.ErrConflict) {
+			status = http.StatusConflict
+		}
+		w.Header().Del("Content-Type")
+		http.Error(w, fmt.Sprintf("Failed to import chain: %v", err), status)
+		return
+	}
+
+	json.NewEncoder(w).Encode(response)
+}
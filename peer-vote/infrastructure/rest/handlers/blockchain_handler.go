@@ -37,42 +37,80 @@ type BlockResponse struct {
 	Transactions int    `json:"transaction_count"`
 }
 
+// BlockListResponse representa uma página de blocos
+type BlockListResponse struct {
+	Blocks     []BlockResponse        `json:"blocks"`
+	Total      uint64                 `json:"total"`
+	Limit      int                    `json:"limit"`
+	Order      repositories.SortOrder `json:"order"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+	HasMore    bool                   `json:"has_more"`
+}
+
 // ChainStatusResponse representa o status da blockchain
 type ChainStatusResponse struct {
-	Height      uint64 `json:"height"`
-	LatestBlock string `json:"latest_block_hash"`
-	IsValid     bool   `json:"is_valid"`
-	TotalBlocks uint64 `json:"total_blocks"`
+	Height          uint64 `json:"height"`
+	LatestBlock     string `json:"latest_block_hash"`
+	IsValid         bool   `json:"is_valid"`
+	TotalBlocks     uint64 `json:"total_blocks"`
+	FinalizedHeight uint64 `json:"finalized_height"`
+	FinalizedBlock  string `json:"finalized_block_hash,omitempty"`
+}
+
+// ChainValidationResponse representa o resultado da validação da cadeia
+type ChainValidationResponse struct {
+	IsValid bool   `json:"is_valid"`
+	Message string `json:"message"`
 }
 
 // RegisterRoutes registra as rotas do handler
 func (h *BlockchainHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/blocks", h.GetBlocks).Methods("GET")
-	router.HandleFunc("/blocks/{index}", h.GetBlockByIndex).Methods("GET")
+	router.HandleFunc("/blocks/{index:[0-9]+}", h.GetBlockByIndex).Methods("GET")
 	router.HandleFunc("/blocks/hash/{hash}", h.GetBlock).Methods("GET")
 	router.HandleFunc("/blocks/latest", h.GetLatestBlock).Methods("GET")
 	router.HandleFunc("/chain/status", h.GetChainStatus).Methods("GET")
 	router.HandleFunc("/chain/validate", h.ValidateChain).Methods("GET")
 }
 
-// GetBlocks lista blocos com paginação
+// GetBlocks lista blocos com filtros (validator, from_height, to_height, tx_type),
+// ordem (order=asc|desc) e paginação por cursor (next_cursor da página anterior).
+// offset é aceito como sinônimo de from_height na primeira página.
 func (h *BlockchainHandler) GetBlocks(w http.ResponseWriter, r *http.Request) {
-	// Extrair parâmetros de query para paginação
-	limitStr := r.URL.Query().Get("limit")
-	offsetStr := r.URL.Query().Get("offset")
-
-	limit := 10 // padrão
-	if limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
-		}
+	query := r.URL.Query()
+
+	page, err := parsePageParams(query, 10)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
 	}
 
-	offset := 0 // padrão
-	if offsetStr != "" {
-		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
-			offset = o
-		}
+	blockQuery := repositories.BlockQuery{
+		Order: page.Order,
+		Limit: page.Limit,
+	}
+	fromParam := "from_height"
+	if query.Get(fromParam) == "" {
+		fromParam = "offset" // compatibilidade com a paginação por offset
+	}
+	if blockQuery.FromHeight, err = parseHeightParam(query, fromParam); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if blockQuery.ToHeight, err = parseHeightParam(query, "to_height"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if blockQuery.TxType, err = parseTxType(query.Get("tx_type")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if validator := query.Get("validator"); validator != "" {
+		blockQuery.Validator = valueobjects.NewNodeID(validator)
+	}
+	if page.Cursor != "" {
+		position, _ := repositories.ParseCursor(page.Cursor)
+		blockQuery.After = &position.Height
 	}
 
 	// Obter altura da cadeia
@@ -82,20 +120,14 @@ func (h *BlockchainHandler) GetBlocks(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Calcular range de blocos
-	start := uint64(offset)
-	end := start + uint64(limit)
-	if end > height {
-		end = height
+	result, err := h.blockchainRepo.QueryBlocks(r.Context(), blockQuery)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	var blocks []BlockResponse
-	for i := start; i < end; i++ {
-		block, err := h.blockchainRepo.GetBlockByIndex(r.Context(), i)
-		if err != nil {
-			continue // Pular blocos com erro
-		}
-
+	blocks := make([]BlockResponse, 0, len(result.Blocks))
+	for _, block := range result.Blocks {
 		blockResp := BlockResponse{
 			Index:        block.GetIndex(),
 			Hash:         h.chainManager.CalculateBlockHash(r.Context(), block).String(),
@@ -109,14 +141,21 @@ func (h *BlockchainHandler) GetBlocks(w http.ResponseWriter, r *http.Request) {
 		blocks = append(blocks, blockResp)
 	}
 
+	response := BlockListResponse{
+		Blocks:  blocks,
+		Total:   height,
+		Limit:   page.Limit,
+		Order:   page.Order,
+		HasMore: result.HasMore,
+	}
+	if result.HasMore {
+		last := result.Blocks[len(result.Blocks)-1]
+		response.NextCursor = repositories.ChainPosition{Height: last.GetIndex()}.Cursor()
+	}
+
 	// Retornar resposta
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"blocks": blocks,
-		"total":  height,
-		"limit":  limit,
-		"offset": offset,
-	})
+	json.NewEncoder(w).Encode(response)
 }
 
 // GetBlockByIndex obtém um bloco pelo índice
@@ -235,15 +274,24 @@ func (h *BlockchainHandler) GetChainStatus(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Validar cadeia
-	err = h.blockchainRepo.ValidateChain(r.Context())
+	err = h.chainManager.ValidateChain(r.Context())
 	isValid := err == nil
 
+	// Obter último bloco final
+	finalizedHeight, _ := h.chainManager.GetFinalizedHeight(r.Context())
+	finalizedHash := ""
+	if hash, ok := h.chainManager.GetFinalizedHash(r.Context()); ok {
+		finalizedHash = hash.String()
+	}
+
 	// Criar resposta
 	status := ChainStatusResponse{
-		Height:      height,
-		LatestBlock: latestHash,
-		IsValid:     isValid,
-		TotalBlocks: height,
+		Height:          height,
+		LatestBlock:     latestHash,
+		IsValid:         isValid,
+		TotalBlocks:     height,
+		FinalizedHeight: finalizedHeight,
+		FinalizedBlock:  finalizedHash,
 	}
 
 	// Retornar resposta
@@ -253,18 +301,17 @@ func (h *BlockchainHandler) GetChainStatus(w http.ResponseWriter, r *http.Reques
 
 // ValidateChain valida a integridade da blockchain
 func (h *BlockchainHandler) ValidateChain(w http.ResponseWriter, r *http.Request) {
-	// Validar cadeia
-	err := h.blockchainRepo.ValidateChain(r.Context())
-	isValid := err == nil
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	// Validar cadeia (cadeia inválida é um resultado, não um erro da requisição)
+	response := ChainValidationResponse{
+		IsValid: true,
+		Message: "Chain validation completed",
+	}
+	if err := h.chainManager.ValidateChain(r.Context()); err != nil {
+		response.IsValid = false
+		response.Message = err.Error()
 	}
 
 	// Retornar resposta
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"is_valid": isValid,
-		"message":  "Chain validation completed",
-	})
+	json.NewEncoder(w).Encode(response)
 }
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/certification"
+)
+
+// CertificateHandler gerencia a certificação dos resultados de eleições encerradas
+type CertificateHandler struct {
+	certifyResultsUseCase *usecases.CertifyResultsUseCase
+}
+
+// NewCertificateHandler cria um novo handler de certificados de resultado
+func NewCertificateHandler(certifyResultsUseCase *usecases.CertifyResultsUseCase) *CertificateHandler {
+	return &CertificateHandler{
+		certifyResultsUseCase: certifyResultsUseCase,
+	}
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *CertificateHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/elections/{id}/certify", h.CertifyResults).Methods("POST")
+	router.HandleFunc("/elections/{id}/certificate", h.GetCertificate).Methods("GET")
+}
+
+// CertifyResults propõe (ou co-assina) o documento de resultado de uma eleição encerrada
+func (h *CertificateHandler) CertifyResults(w http.ResponseWriter, r *http.Request) {
+	if h.certifyResultsUseCase == nil {
+		http.Error(w, "Result certification not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	electionID, err := valueobjects.NewHashFromString(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid election ID format", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.certifyResultsUseCase.CertifyResults(r.Context(), &usecases.CertifyResultsRequest{
+		ElectionID: electionID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrElectionNotClosed), errors.Is(err, usecases.ErrAlreadyCertified):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, usecases.ErrNotValidator):
+			http.Error(w, err.Error(), http.StatusForbidden)
+		default:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(response)
+}
+
+// GetCertificate retorna o certificado de resultado com as assinaturas registradas na cadeia
+func (h *CertificateHandler) GetCertificate(w http.ResponseWriter, r *http.Request) {
+	if h.certifyResultsUseCase == nil {
+		http.Error(w, "Result certification not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	electionID, err := valueobjects.NewHashFromString(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid election ID format", http.StatusBadRequest)
+		return
+	}
+
+	certificate, err := h.certifyResultsUseCase.GetCertificate(r.Context(), electionID)
+	if err != nil {
+		if errors.Is(err, usecases.ErrCertificateNotFound) {
+			http.Error(w, "Result certificate not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	certification.Write(w, certificate)
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+)
+
+// ConsensusHandler gerencia endpoints relacionados ao consenso
+type ConsensusHandler struct {
+	consensusManagerUseCase *usecases.ConsensusManagerUseCase
+	adminOnly               Middleware
+}
+
+// NewConsensusHandler cria um novo handler de consenso.
+// adminOnly protege os endpoints que iniciam, param ou configuram o consenso.
+func NewConsensusHandler(consensusManagerUseCase *usecases.ConsensusManagerUseCase, adminOnly Middleware) *ConsensusHandler {
+	return &ConsensusHandler{
+		consensusManagerUseCase: consensusManagerUseCase,
+		adminOnly:               adminOnly,
+	}
+}
+
+// ConsensusStatusResponse representa o status do consenso
+type ConsensusStatusResponse struct {
+	IsRunning        bool                `json:"is_running"`
+	CurrentValidator string              `json:"current_validator"`
+	CurrentRound     uint64              `json:"current_round"`
+	ValidatorCount   int                 `json:"validator_count"`
+	PendingTxCount   int                 `json:"pending_tx_count"`
+	LastBlockTime    int64               `json:"last_block_time"`
+	Validators       []ValidatorResponse `json:"validators"`
+}
+
+// RoundInfoResponse representa o round atual e o proposer esperado
+type RoundInfoResponse struct {
+	Round            uint64 `json:"round"`
+	CurrentProposer  string `json:"current_proposer"`
+	NextHeight       uint64 `json:"next_height"`
+	InTurnValidator  string `json:"in_turn_validator"`
+	StartTime        int64  `json:"start_time"`
+	DurationMs       int64  `json:"duration_ms"`
+	TimeRemainingMs  int64  `json:"time_remaining_ms"`
+	ActiveValidators int    `json:"active_validators"`
+	PendingTxCount   int    `json:"pending_tx_count"`
+}
+
+// ConfigureConsensusPayload representa o payload para configurar o consenso
+type ConfigureConsensusPayload struct {
+	BlockIntervalMs int `json:"block_interval_ms,omitempty"`
+	MinTxPerBlock   int `json:"min_tx_per_block,omitempty"`
+	MaxTxPerBlock   int `json:"max_tx_per_block,omitempty"`
+}
+
+// ConsensusStartedResponse representa a resposta do início do consenso
+type ConsensusStartedResponse struct {
+	Success        bool   `json:"success"`
+	Message        string `json:"message"`
+	ValidatorCount int    `json:"validator_count"`
+	CurrentRound   uint64 `json:"current_round"`
+}
+
+// OperationResponse representa o resultado de uma operação administrativa
+type OperationResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *ConsensusHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/consensus/status", h.GetStatus).Methods("GET")
+	router.HandleFunc("/consensus/round", h.GetRound).Methods("GET")
+
+	// Endpoints administrativos
+	router.Handle("/consensus/start", h.adminOnly(http.HandlerFunc(h.Start))).Methods("POST")
+	router.Handle("/consensus/stop", h.adminOnly(http.HandlerFunc(h.Stop))).Methods("POST")
+	router.Handle("/consensus/config", h.adminOnly(http.HandlerFunc(h.Configure))).Methods("PUT")
+}
+
+// GetStatus obtém o status do consenso com as estatísticas dos validadores
+func (h *ConsensusHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	status, err := h.consensusManagerUseCase.GetConsensusStatus(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	validators := make([]ValidatorResponse, len(status.ValidatorStats))
+	for i, validator := range status.ValidatorStats {
+		validators[i] = toValidatorResponse(validator)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ConsensusStatusResponse{
+		IsRunning:        status.IsRunning,
+		CurrentValidator: status.CurrentValidator.String(),
+		CurrentRound:     status.CurrentRound,
+		ValidatorCount:   status.ValidatorCount,
+		PendingTxCount:   status.PendingTxCount,
+		LastBlockTime:    status.LastBlockTime.Unix(),
+		Validators:       validators,
+	})
+}
+
+// GetRound obtém o round atual do Round Robin e o proposer da próxima altura
+func (h *ConsensusHandler) GetRound(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	round, err := h.consensusManagerUseCase.GetRoundInfo(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(RoundInfoResponse{
+		Round:            round.Round,
+		CurrentProposer:  round.CurrentProposer.String(),
+		NextHeight:       round.NextHeight,
+		InTurnValidator:  round.InTurnValidator.String(),
+		StartTime:        round.StartTime.Unix(),
+		DurationMs:       round.Duration.Milliseconds(),
+		TimeRemainingMs:  round.TimeRemaining.Milliseconds(),
+		ActiveValidators: round.ActiveValidators,
+		PendingTxCount:   round.PendingTxCount,
+	})
+}
+
+// Start inicia o consenso com o conjunto de validadores já configurado
+func (h *ConsensusHandler) Start(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	response, err := h.consensusManagerUseCase.StartConsensus(r.Context(), &usecases.StartConsensusRequest{})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ConsensusStartedResponse{
+		Success:        response.Success,
+		Message:        response.Message,
+		ValidatorCount: response.ValidatorCount,
+		CurrentRound:   response.CurrentRound,
+	})
+}
+
+// Stop para o consenso
+func (h *ConsensusHandler) Stop(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.consensusManagerUseCase.StopConsensus(r.Context()); err != nil {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(OperationResponse{
+		Success: true,
+		Message: "consensus stopped successfully",
+	})
+}
+
+// Configure altera os parâmetros de produção de blocos
+func (h *ConsensusHandler) Configure(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req ConfigureConsensusPayload
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	if req.BlockIntervalMs < 0 || req.MinTxPerBlock < 0 || req.MaxTxPerBlock < 0 ||
+		(req.MaxTxPerBlock > 0 && req.MinTxPerBlock > req.MaxTxPerBlock) {
+		http.Error(w, "Invalid consensus configuration", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.consensusManagerUseCase.ConfigureConsensus(r.Context(), &usecases.ConfigureConsensusRequest{
+		BlockInterval: time.Duration(req.BlockIntervalMs) * time.Millisecond,
+		MinTxPerBlock: req.MinTxPerBlock,
+		MaxTxPerBlock: req.MaxTxPerBlock,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(OperationResponse{
+		Success: response.Success,
+		Message: response.Message,
+	})
+}
@@ -76,8 +76,12 @@ func (h *ElectionHandler) CreateElection(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Converter CreatedBy para NodeID
-	createdBy := valueobjects.NewNodeID(req.CreatedBy)
+	// Converter CreatedBy para NodeID (vinculado à identidade autenticada)
+	createdBy, err := resolveNodeID(r, req.CreatedBy)
+	if err != nil {
+		http.Error(w, "Forbidden: created_by: "+err.Error(), http.StatusForbidden)
+		return
+	}
 
 	// Criar request do caso de uso
 	createRequest := &usecases.CreateElectionRequest{
@@ -104,20 +108,41 @@ func (h *ElectionHandler) CreateElection(w http.ResponseWriter, r *http.Request)
 	json.NewEncoder(w).Encode(response)
 }
 
-// ListElections lista eleições com filtros opcionais
+// ListElections lista eleições com filtros (active, status, created_by, from, to),
+// ordem (order=asc|desc) e paginação por cursor (next_cursor da página anterior)
 func (h *ElectionHandler) ListElections(w http.ResponseWriter, r *http.Request) {
 	// Extrair parâmetros de query
-	activeOnly := r.URL.Query().Get("active") == "true"
-	createdBy := r.URL.Query().Get("created_by")
+	query := r.URL.Query()
+
+	page, err := parsePageParams(query, 50)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// Criar request do caso de uso
 	listRequest := &usecases.ListElectionsRequest{
-		ActiveOnly: activeOnly,
+		ActiveOnly: query.Get("active") == "true",
+		Cursor:     page.Cursor,
+		Order:      page.Order,
+		Limit:      page.Limit,
 	}
 
-	if createdBy != "" {
+	if createdBy := query.Get("created_by"); createdBy != "" {
 		listRequest.CreatedBy = valueobjects.NewNodeID(createdBy)
 	}
+	if listRequest.Status, err = parseElectionStatus(query.Get("status")); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if listRequest.From, err = parseTimeParam(query, "from"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if listRequest.To, err = parseTimeParam(query, "to"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
 	// Executar caso de uso
 	response, err := h.manageElectionUseCase.ListElections(r.Context(), listRequest)
@@ -197,11 +222,18 @@ func (h *ElectionHandler) UpdateElectionStatus(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Autor da alteração (vinculado à identidade autenticada)
+	updatedBy, err := resolveNodeID(r, req.UpdatedBy)
+	if err != nil {
+		http.Error(w, "Forbidden: updated_by: "+err.Error(), http.StatusForbidden)
+		return
+	}
+
 	// Criar request do caso de uso
 	updateRequest := &usecases.UpdateElectionStatusRequest{
 		ElectionID: electionID,
 		NewStatus:  newStatus,
-		UpdatedBy:  valueobjects.NewNodeID(req.UpdatedBy),
+		UpdatedBy:  updatedBy,
 	}
 
 	// Executar caso de uso
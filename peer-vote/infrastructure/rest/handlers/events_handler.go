@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/events"
+)
+
+const (
+	// Intervalo de keep-alive das conexões SSE e de ping das conexões WebSocket
+	streamHeartbeatInterval = 15 * time.Second
+	// Tempo máximo para escrever uma mensagem WebSocket
+	wsWriteWait = 10 * time.Second
+	// Tempo máximo sem pong antes de encerrar a conexão WebSocket
+	wsPongWait = 2 * streamHeartbeatInterval
+)
+
+// EventsHandler gerencia as assinaturas de eventos da cadeia (SSE e WebSocket)
+type EventsHandler struct {
+	eventHub *events.EventHub
+	upgrader websocket.Upgrader
+}
+
+// NewEventsHandler cria um novo handler de eventos
+func NewEventsHandler(eventHub *events.EventHub) *EventsHandler {
+	return &EventsHandler{
+		eventHub: eventHub,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 4096,
+			// Mesma política do CORS da API (qualquer origem)
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// EventResponse representa um evento enviado ao assinante
+type EventResponse struct {
+	Type      string      `json:"type"`
+	Height    uint64      `json:"height"`
+	Sequence  uint64      `json:"sequence,omitempty"` // Ausente nos eventos do histórico reconstruído por altura
+	Timestamp int64       `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// subscriptionParams são os parâmetros de uma assinatura de eventos
+type subscriptionParams struct {
+	electionID    string
+	fromHeight    *uint64 // Retomada pela altura (blocos reconstruídos da cadeia)
+	afterSequence *uint64 // Retomada após um evento (Last-Event-ID)
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *EventsHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/events", h.StreamSSE).Methods("GET")
+	router.HandleFunc("/events/ws", h.StreamWebSocket).Methods("GET")
+}
+
+// StreamSSE transmite eventos como Server-Sent Events.
+// Parâmetros: election (filtra por eleição) e from_height (retoma a partir da altura);
+// o cabeçalho Last-Event-ID retoma logo após o último evento recebido, sem repeti-lo.
+// O id de cada evento é sua sequência no hub; após o histórico inicial é enviado um id
+// sem dados com a posição da assinatura, para que a reconexão não repita o histórico.
+func (h *EventsHandler) StreamSSE(w http.ResponseWriter, r *http.Request) {
+	if h.eventHub == nil {
+		http.Error(w, "Event stream not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	params, err := parseSubscription(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	subscription, backlog, err := h.subscribe(r.Context(), params)
+	if err != nil {
+		writeSubscribeError(w, err)
+		return
+	}
+	defer subscription.Close()
+
+	// A conexão é de longa duração: remover o WriteTimeout do servidor
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	for _, event := range backlog {
+		if err := writeSSEEvent(w, event); err != nil {
+			return
+		}
+	}
+	if len(backlog) > 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n\n", subscription.Cursor()); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-subscription.Events():
+			if !ok {
+				// Assinante desconectado pelo hub (cliente lento)
+				return
+			}
+			if err := writeSSEEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// StreamWebSocket transmite eventos como mensagens JSON em uma conexão WebSocket.
+// Aceita os mesmos parâmetros de StreamSSE (election e from_height).
+func (h *EventsHandler) StreamWebSocket(w http.ResponseWriter, r *http.Request) {
+	if h.eventHub == nil {
+		http.Error(w, "Event stream not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	params, err := parseSubscription(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	subscription, backlog, err := h.subscribe(r.Context(), params)
+	if err != nil {
+		writeSubscribeError(w, err)
+		return
+	}
+	defer subscription.Close()
+
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade já respondeu ao cliente
+		return
+	}
+	defer conn.Close()
+
+	// Leitura apenas para processar pong e fechamento; mensagens do cliente são ignoradas
+	closed := make(chan struct{})
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, event := range backlog {
+		if err := writeWSEvent(conn, event); err != nil {
+			return
+		}
+	}
+
+	ping := time.NewTicker(streamHeartbeatInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case event, ok := <-subscription.Events():
+			if !ok {
+				// Assinante desconectado pelo hub (cliente lento)
+				conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+				conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscriber too slow, resume with from_height"))
+				return
+			}
+			if err := writeWSEvent(conn, event); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// subscribe registra no hub a assinatura descrita pelos parâmetros
+func (h *EventsHandler) subscribe(ctx context.Context, params *subscriptionParams) (*events.Subscription, []events.Event, error) {
+	if params.afterSequence != nil {
+		return h.eventHub.SubscribeAfter(params.electionID, *params.afterSequence)
+	}
+	return h.eventHub.Subscribe(ctx, params.electionID, params.fromHeight)
+}
+
+// parseSubscription extrai o filtro de eleição e a posição de retomada da requisição
+func parseSubscription(r *http.Request) (*subscriptionParams, error) {
+	query := r.URL.Query()
+	params := &subscriptionParams{}
+
+	if electionID := query.Get("election"); electionID != "" {
+		hash, err := valueobjects.NewHashFromString(electionID)
+		if err != nil {
+			return nil, errors.New("Invalid election ID format")
+		}
+		params.electionID = hash.String()
+	}
+
+	if fromHeightStr := query.Get("from_height"); fromHeightStr != "" {
+		fromHeight, err := strconv.ParseUint(fromHeightStr, 10, 64)
+		if err != nil {
+			return nil, errors.New("Invalid from_height")
+		}
+		params.fromHeight = &fromHeight
+		return params, nil
+	}
+
+	// Reconexão SSE: retomar após o último evento recebido
+	if lastEventID := r.Header.Get("Last-Event-ID"); lastEventID != "" {
+		sequence, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			return nil, errors.New("Invalid Last-Event-ID")
+		}
+		params.afterSequence = &sequence
+	}
+
+	return params, nil
+}
+
+// writeSubscribeError responde a uma falha ao registrar a assinatura
+func writeSubscribeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, events.ErrReplayTooLarge) || errors.Is(err, events.ErrEventExpired) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// toEventResponse converte um evento do hub para a resposta da API
+func toEventResponse(event events.Event) EventResponse {
+	return EventResponse{
+		Type:      string(event.Type),
+		Height:    event.Height,
+		Sequence:  event.Sequence,
+		Timestamp: event.Timestamp.Unix(),
+		Data:      event.Data,
+	}
+}
+
+// writeSSEEvent escreve um evento no formato SSE (id = sequência, usado em Last-Event-ID).
+// Eventos do histórico reconstruído não têm sequência e são enviados sem id.
+func writeSSEEvent(w http.ResponseWriter, event events.Event) error {
+	data, err := json.Marshal(toEventResponse(event))
+	if err != nil {
+		return err
+	}
+	if event.Sequence != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.Sequence); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+	return err
+}
+
+// writeWSEvent escreve um evento como mensagem JSON na conexão WebSocket
+func writeWSEvent(conn *websocket.Conn, event events.Event) error {
+	conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return conn.WriteJSON(toEventResponse(event))
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/report"
+)
+
+// exportFlushRows é o intervalo de linhas entre flushes nas exportações em streaming
+const exportFlushRows = 500
+
+// negotiateFormat escolhe o formato da resposta entre os oferecidos (o primeiro é o padrão):
+// o parâmetro format, se presente, ou o cabeçalho Accept. Responde 400 ou 406 e retorna
+// false quando nenhum formato oferecido atende à requisição.
+func negotiateFormat(w http.ResponseWriter, r *http.Request, offered ...report.Format) (report.Format, bool) {
+	w.Header().Add("Vary", "Accept")
+
+	if name := r.URL.Query().Get("format"); name != "" {
+		format, err := report.ParseFormat(name)
+		if err == nil {
+			for _, candidate := range offered {
+				if candidate == format {
+					return format, true
+				}
+			}
+			err = fmt.Errorf("format %s not available for this resource", format)
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return "", false
+	}
+
+	format, ok := report.Negotiate(r.Header.Get("Accept"), offered...)
+	if !ok {
+		http.Error(w, "Not acceptable: "+acceptableTypes(offered), http.StatusNotAcceptable)
+		return "", false
+	}
+	return format, true
+}
+
+// acceptableTypes lista os tipos de mídia oferecidos para a mensagem de 406
+func acceptableTypes(offered []report.Format) string {
+	types := make([]string, len(offered))
+	for i, format := range offered {
+		types[i] = report.MediaType(format)
+	}
+	return strings.Join(types, ", ")
+}
+
+// setExportHeaders define o tipo e o nome do arquivo de uma exportação
+func setExportHeaders(w http.ResponseWriter, format report.Format, kind string, electionID valueobjects.Hash) {
+	w.Header().Set("Content-Type", report.ContentType(format))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s.%s"`, kind, electionID.String(), report.Extension(format)))
+}
+
+// streamAuditRows escreve os votos auditados em streaming, com flush periódico. Erros antes
+// do primeiro flush (e sem corpo já enviado, committed) ainda viram respostas HTTP; depois
+// dele, a resposta é interrompida.
+func streamAuditRows(w http.ResponseWriter, r *http.Request, auditVotesUseCase *usecases.AuditVotesUseCase, request *usecases.AuditVotesRequest, writer report.AuditWriter, committed bool) {
+	// A exportação de eleições grandes pode exceder o WriteTimeout do servidor
+	controller := http.NewResponseController(w)
+	controller.SetWriteDeadline(time.Time{})
+
+	rows := 0
+	flushed := committed
+	err := auditVotesUseCase.StreamAuditResults(r.Context(), request, func(result usecases.VoteAuditResult) error {
+		if err := writer.Write(result); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushRows != 0 {
+			return nil
+		}
+		flushed = true
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		return controller.Flush()
+	})
+	if err != nil {
+		if !flushed {
+			w.Header().Del("Content-Type")
+			w.Header().Del("Content-Disposition")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writer.Close()
+}
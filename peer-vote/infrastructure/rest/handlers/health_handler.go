@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/health"
+)
+
+// HealthChecker executa as verificações de liveness e readiness (ex: health.Checker)
+type HealthChecker interface {
+	Live(ctx context.Context) *health.Report
+	Ready(ctx context.Context) *health.Report
+}
+
+// HealthHandler gerencia os endpoints de liveness e readiness usados por orquestradores
+type HealthHandler struct {
+	checker  HealthChecker
+	basePath string
+}
+
+// NewHealthHandler cria um novo handler de saúde.
+// basePath é o prefixo das rotas (ex: "/health" serve /health/live e /health/ready).
+func NewHealthHandler(checker HealthChecker, basePath string) *HealthHandler {
+	return &HealthHandler{
+		checker:  checker,
+		basePath: basePath,
+	}
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *HealthHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc(h.basePath+"/live", h.Live).Methods("GET")
+	router.HandleFunc(h.basePath+"/ready", h.Ready).Methods("GET")
+}
+
+// Live verifica se o processo está funcionando (503 indica que o nó deve ser reiniciado)
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, h.checker.Live(r.Context()))
+}
+
+// Ready verifica se o nó está apto a receber tráfego (503 indica que deve sair do balanceamento)
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, h.checker.Ready(r.Context()))
+}
+
+// writeHealthReport escreve o relatório com 503 quando algum componente está down
+func writeHealthReport(w http.ResponseWriter, report *health.Report) {
+	statusCode := http.StatusOK
+	if !report.Healthy() {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/auth"
+)
+
+// resolveNodeID vincula o NodeID informado no payload (voter_id, created_by, updated_by)
+// à identidade autenticada: credenciais ligadas a um nó só agem em nome dele, e o campo
+// vazio assume o NodeID da credencial. Sem autenticação, o valor informado é usado.
+func resolveNodeID(r *http.Request, requested string) (valueobjects.NodeID, error) {
+	identity, ok := auth.FromContext(r.Context())
+	if !ok {
+		return valueobjects.NewNodeID(requested), nil
+	}
+
+	if identity.NodeID.IsEmpty() {
+		// Apenas credenciais administrativas sem nó podem agir em nome de outro NodeID
+		if identity.HasRole(auth.RoleAdmin) && requested != "" {
+			return valueobjects.NewNodeID(requested), nil
+		}
+		return valueobjects.EmptyNodeID(), errors.New("credential is not bound to a node ID")
+	}
+
+	if requested != "" && requested != identity.NodeID.String() {
+		return valueobjects.EmptyNodeID(), errors.New("node ID does not match the authenticated identity")
+	}
+
+	return identity.NodeID, nil
+}
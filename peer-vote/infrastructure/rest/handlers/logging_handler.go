@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// LogLevelController consulta e altera os níveis de log dos componentes (ex: logging.Manager)
+type LogLevelController interface {
+	Levels() (string, map[string]string)
+	SetLevel(component string, level slog.Level) error
+}
+
+// LoggingHandler gerencia os endpoints administrativos de log
+type LoggingHandler struct {
+	controller LogLevelController
+	adminOnly  Middleware
+}
+
+// NewLoggingHandler cria um novo handler de log.
+// adminOnly protege a consulta e a alteração dos níveis.
+func NewLoggingHandler(controller LogLevelController, adminOnly Middleware) *LoggingHandler {
+	return &LoggingHandler{
+		controller: controller,
+		adminOnly:  adminOnly,
+	}
+}
+
+// LogLevelsResponse representa os níveis de log do nó
+type LogLevelsResponse struct {
+	Default    string            `json:"default"`
+	Components map[string]string `json:"components"`
+}
+
+// SetLogLevelPayload representa o payload para alterar o nível de log.
+// Component vazio ou "*" altera o nível padrão e o de todos os componentes.
+type SetLogLevelPayload struct {
+	Component string `json:"component,omitempty"`
+	Level     string `json:"level"`
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *LoggingHandler) RegisterRoutes(router *mux.Router) {
+	router.Handle("/admin/log-levels", h.adminOnly(http.HandlerFunc(h.GetLevels))).Methods("GET")
+	router.Handle("/admin/log-levels", h.adminOnly(http.HandlerFunc(h.SetLevel))).Methods("PUT")
+}
+
+// GetLevels retorna o nível de log padrão e o de cada componente
+func (h *LoggingHandler) GetLevels(w http.ResponseWriter, r *http.Request) {
+	if h.controller == nil {
+		http.Error(w, "Logging not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	h.writeLevels(w)
+}
+
+// SetLevel altera o nível de log de um componente em tempo de execução
+func (h *LoggingHandler) SetLevel(w http.ResponseWriter, r *http.Request) {
+	if h.controller == nil {
+		http.Error(w, "Logging not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req SetLogLevelPayload
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	level, err := logging.ParseLevel(req.Level)
+	if err != nil || req.Level == "" {
+		http.Error(w, "Invalid level (use debug, info, warn or error)", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.controller.SetLevel(req.Component, level); err != nil {
+		http.Error(w, "Unknown log component", http.StatusNotFound)
+		return
+	}
+
+	h.writeLevels(w)
+}
+
+// writeLevels escreve os níveis atuais
+func (h *LoggingHandler) writeLevels(w http.ResponseWriter) {
+	defaultLevel, components := h.controller.Levels()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(LogLevelsResponse{
+		Default:    defaultLevel,
+		Components: components,
+	})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+)
+
+// MempoolHandler gerencia endpoints de inspeção do pool de transações pendentes
+type MempoolHandler struct {
+	mempool *consensus.Mempool
+}
+
+// NewMempoolHandler cria um novo handler do mempool
+func NewMempoolHandler(mempool *consensus.Mempool) *MempoolHandler {
+	return &MempoolHandler{
+		mempool: mempool,
+	}
+}
+
+// MempoolTransactionResponse representa uma transação pendente
+type MempoolTransactionResponse struct {
+	Hash      string `json:"hash"`
+	Type      string `json:"type"`
+	From      string `json:"from"`
+	Size      int    `json:"size"`
+	Priority  int    `json:"priority"`
+	Timestamp int64  `json:"timestamp"`
+	AddedAt   int64  `json:"added_at"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+// MempoolResponse representa a ocupação do mempool e suas transações
+type MempoolResponse struct {
+	Count           int                          `json:"count"`
+	Bytes           int                          `json:"bytes"`
+	MaxTransactions int                          `json:"max_transactions"`
+	MaxBytes        int                          `json:"max_bytes"`
+	MaxPerSender    int                          `json:"max_per_sender"`
+	TTLSeconds      int64                        `json:"ttl_seconds"`
+	Senders         int                          `json:"senders"`
+	ByType          map[string]int               `json:"by_type"`
+	Transactions    []MempoolTransactionResponse `json:"transactions"`
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *MempoolHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/mempool", h.GetMempool).Methods("GET")
+}
+
+// GetMempool retorna a ocupação do mempool e as transações pendentes por ordem de inclusão
+func (h *MempoolHandler) GetMempool(w http.ResponseWriter, r *http.Request) {
+	if h.mempool == nil {
+		http.Error(w, "Mempool not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Extrair parâmetros de query
+	limitStr := r.URL.Query().Get("limit")
+	limit := 100 // padrão
+	if limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l >= 0 {
+			limit = l
+		}
+	}
+
+	stats := h.mempool.Stats()
+	entries := h.mempool.Entries()
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	transactions := make([]MempoolTransactionResponse, len(entries))
+	for i, entry := range entries {
+		tx := entry.Transaction
+		transactions[i] = MempoolTransactionResponse{
+			Hash:      tx.GetHash().String(),
+			Type:      string(tx.GetType()),
+			From:      tx.GetFrom().String(),
+			Size:      entry.Size,
+			Priority:  entry.Priority,
+			Timestamp: tx.GetTimestamp().Unix(),
+			AddedAt:   entry.AddedAt.Unix(),
+			ExpiresAt: entry.ExpiresAt.Unix(),
+		}
+	}
+
+	byType := make(map[string]int)
+	for txType, count := range stats.ByType {
+		byType[string(txType)] = count
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(MempoolResponse{
+		Count:           stats.Count,
+		Bytes:           stats.Bytes,
+		MaxTransactions: stats.MaxTransactions,
+		MaxBytes:        stats.MaxBytes,
+		MaxPerSender:    stats.MaxPerSender,
+		TTLSeconds:      int64(stats.TTL.Seconds()),
+		Senders:         stats.Senders,
+		ByType:          byType,
+		Transactions:    transactions,
+	})
+}
@@ -11,6 +11,7 @@ import (
 // NodeHandler gerencia endpoints relacionados aos nós da rede
 type NodeHandler struct {
 	networkService services.NetworkService
+	healthChecker  HealthChecker
 }
 
 // NewNodeHandler cria um novo handler de nós
@@ -38,6 +39,34 @@ type NetworkStatusResponse struct {
 	LastSyncTime    int64  `json:"last_sync_time"`
 }
 
+// PeerResponse representa um peer conectado
+type PeerResponse struct {
+	PeerID    string `json:"peer_id"`
+	Connected bool   `json:"connected"`
+}
+
+// PeerListResponse representa a lista de peers conectados
+type PeerListResponse struct {
+	Peers []PeerResponse `json:"peers"`
+	Count int            `json:"count"`
+}
+
+// HealthResponse representa o resultado do health check
+type HealthResponse struct {
+	Status HealthStatus           `json:"status"`
+	Checks map[string]interface{} `json:"checks"`
+}
+
+// HealthStatus representa o estado geral do nó (healthy ou unhealthy)
+type HealthStatus struct {
+	Overall string `json:"overall"`
+}
+
+// SetHealthChecker faz o health check refletir a readiness dos componentes do nó
+func (h *NodeHandler) SetHealthChecker(checker HealthChecker) {
+	h.healthChecker = checker
+}
+
 // RegisterRoutes registra as rotas do handler
 func (h *NodeHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/nodes/status", h.GetNodeStatus).Methods("GET")
@@ -130,24 +159,29 @@ func (h *NodeHandler) GetPeers(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Converter para formato de resposta
-	peerList := make([]map[string]interface{}, len(peers))
+	peerList := make([]PeerResponse, len(peers))
 	for i, peer := range peers {
-		peerList[i] = map[string]interface{}{
-			"peer_id":   peer.ID.String(),
-			"connected": true,
+		peerList[i] = PeerResponse{
+			PeerID:    peer.ID.String(),
+			Connected: true,
 		}
 	}
 
 	// Retornar resposta
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"peers": peerList,
-		"count": len(peers),
+	json.NewEncoder(w).Encode(PeerListResponse{
+		Peers: peerList,
+		Count: len(peers),
 	})
 }
 
 // HealthCheck verifica a saúde do nó
 func (h *NodeHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
+	if h.healthChecker != nil {
+		h.readinessCheck(w, r)
+		return
+	}
+
 	// Verificações básicas de saúde
 	checks := map[string]interface{}{
 		"network_service": h.networkService != nil,
@@ -172,22 +206,41 @@ func (h *NodeHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 
 	// Definir código de status HTTP
 	statusCode := http.StatusOK
+	overall := "healthy"
 	if !allHealthy {
 		statusCode = http.StatusServiceUnavailable
+		overall = "unhealthy"
 	}
 
 	// Retornar resposta
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(map[string]interface{}{
-		"status": map[string]string{
-			"overall": func() string {
-				if allHealthy {
-					return "healthy"
-				}
-				return "unhealthy"
-			}(),
-		},
-		"checks": checks,
+	json.NewEncoder(w).Encode(HealthResponse{
+		Status: HealthStatus{Overall: overall},
+		Checks: checks,
+	})
+}
+
+// readinessCheck responde o health check com o resultado da readiness de cada componente
+func (h *NodeHandler) readinessCheck(w http.ResponseWriter, r *http.Request) {
+	report := h.healthChecker.Ready(r.Context())
+
+	checks := make(map[string]interface{}, len(report.Checks))
+	for name, result := range report.Checks {
+		checks[name] = result
+	}
+
+	statusCode := http.StatusOK
+	overall := "healthy"
+	if !report.Healthy() {
+		statusCode = http.StatusServiceUnavailable
+		overall = "unhealthy"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(HealthResponse{
+		Status: HealthStatus{Overall: overall},
+		Checks: checks,
 	})
 }
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
+)
+
+// maxPageLimit limita o tamanho de página das listagens
+const maxPageLimit = 1000
+
+// pageParams reúne os parâmetros comuns de paginação (limit, cursor e order)
+type pageParams struct {
+	Limit  int
+	Cursor string
+	Order  repositories.SortOrder
+}
+
+// parsePageParams extrai limit, cursor e order da query; limit acima de maxPageLimit é reduzido
+func parsePageParams(query url.Values, defaultLimit int) (pageParams, error) {
+	params := pageParams{
+		Limit:  defaultLimit,
+		Cursor: query.Get("cursor"),
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 1 {
+			return params, errors.New("Invalid limit")
+		}
+		params.Limit = limit
+	}
+	if params.Limit > maxPageLimit {
+		params.Limit = maxPageLimit
+	}
+
+	if params.Cursor != "" {
+		if _, err := repositories.ParseCursor(params.Cursor); err != nil {
+			return params, errors.New("Invalid cursor")
+		}
+	}
+
+	order, err := repositories.ParseSortOrder(query.Get("order"), repositories.SortAscending)
+	if err != nil {
+		return params, errors.New("Invalid order (use asc or desc)")
+	}
+	params.Order = order
+
+	return params, nil
+}
+
+// parseHeightParam extrai uma altura opcional da query
+func parseHeightParam(query url.Values, name string) (*uint64, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	height, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid %s", name)
+	}
+	return &height, nil
+}
+
+// parseTimeParam extrai um instante opcional da query (Unix em segundos ou RFC 3339)
+func parseTimeParam(query url.Values, name string) (int64, error) {
+	value := query.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return unix, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid %s (use Unix seconds or RFC 3339)", name)
+	}
+	return parsed.Unix(), nil
+}
+
+// parseTxType valida o filtro de tipo de transação
+func parseTxType(value string) (entities.TransactionType, error) {
+	txType := entities.TransactionType(strings.ToUpper(value))
+	switch txType {
+	case "", entities.VoteTransaction, entities.ElectionTransaction, entities.ValidatorTransaction, entities.EvidenceTransaction, entities.CertificateTransaction:
+		return txType, nil
+	default:
+		return "", errors.New("Invalid tx_type")
+	}
+}
+
+// parseElectionStatus valida o filtro de status de eleição
+func parseElectionStatus(value string) (entities.ElectionStatus, error) {
+	status := entities.ElectionStatus(strings.ToUpper(value))
+	switch status {
+	case "", entities.ElectionPending, entities.ElectionActive, entities.ElectionClosed, entities.ElectionCancelled:
+		return status, nil
+	default:
+		return "", errors.New("Invalid status")
+	}
+}
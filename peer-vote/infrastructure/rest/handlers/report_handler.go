@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/report"
+)
+
+// ReportHandler gera o relatório legível de resultado e auditoria de uma eleição
+type ReportHandler struct {
+	auditVotesUseCase *usecases.AuditVotesUseCase
+}
+
+// NewReportHandler cria um novo handler de relatórios
+func NewReportHandler(auditVotesUseCase *usecases.AuditVotesUseCase) *ReportHandler {
+	return &ReportHandler{
+		auditVotesUseCase: auditVotesUseCase,
+	}
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *ReportHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/elections/{id}/report", h.GetReport).Methods("GET")
+}
+
+// GetReport retorna o relatório da eleição: Markdown (padrão) com apuração, resumo da
+// auditoria e os votos inválidos em streaming, ou JSON sem a lista de votos
+func (h *ReportHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	format, ok := negotiateFormat(w, r, report.FormatMarkdown, report.FormatJSON)
+	if !ok {
+		return
+	}
+
+	electionID, err := valueobjects.NewHashFromString(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "Invalid election ID format", http.StatusBadRequest)
+		return
+	}
+
+	// A auditoria percorre todos os votos antes da primeira escrita
+	http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	response, err := h.auditVotesUseCase.AuditReport(r.Context(), electionID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if format == report.FormatJSON {
+		w.Header().Set("Content-Type", "application/json")
+		report.WriteReport(w, format, response)
+		return
+	}
+
+	setExportHeaders(w, format, "report", electionID)
+	if err := report.WriteReport(w, format, response); err != nil || response.Summary.InvalidVotes == 0 {
+		return
+	}
+
+	writer, err := report.NewAuditWriter(w, format, report.InvalidVotesTitle)
+	if err != nil {
+		return
+	}
+	valid := false
+	streamAuditRows(w, r, h.auditVotesUseCase, &usecases.AuditVotesRequest{
+		ElectionID: electionID,
+		Valid:      &valid,
+	}, writer, true)
+}
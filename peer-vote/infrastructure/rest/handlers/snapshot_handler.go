@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/snapshot"
+)
+
+// SnapshotHandler expõe os snapshots de estado mantidos pelo nó
+type SnapshotHandler struct {
+	manager   *snapshot.Manager
+	adminOnly Middleware
+}
+
+// NewSnapshotHandler cria um novo handler de snapshots (manager nil informa que estão desabilitados)
+func NewSnapshotHandler(manager *snapshot.Manager, adminOnly Middleware) *SnapshotHandler {
+	return &SnapshotHandler{
+		manager:   manager,
+		adminOnly: adminOnly,
+	}
+}
+
+// SnapshotListResponse representa os snapshots mantidos pelo nó
+type SnapshotListResponse struct {
+	Enabled   bool           `json:"enabled"`
+	Interval  uint64         `json:"interval,omitempty"`
+	Bootstrap bool           `json:"bootstrap"`
+	Snapshots []SnapshotInfo `json:"snapshots"`
+}
+
+// SnapshotInfo resume um snapshot e seus signatários
+type SnapshotInfo struct {
+	Height    uint64   `json:"height"`
+	BlockHash string   `json:"block_hash"`
+	StateHash string   `json:"state_hash"`
+	Signers   []string `json:"signers"`
+	Trusted   bool     `json:"trusted"` // Assinado por mais de 2/3 do conjunto gênese do nó
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *SnapshotHandler) RegisterRoutes(router *mux.Router) {
+	router.Handle("/admin/chain/snapshots", h.adminOnly(http.HandlerFunc(h.ListSnapshots))).Methods("GET")
+}
+
+// ListSnapshots lista os snapshots mantidos, do mais recente ao mais antigo
+func (h *SnapshotHandler) ListSnapshots(w http.ResponseWriter, r *http.Request) {
+	response := SnapshotListResponse{Snapshots: make([]SnapshotInfo, 0)}
+
+	if h.manager != nil {
+		config := h.manager.GetConfig()
+		response.Enabled = true
+		response.Interval = config.Interval
+		response.Bootstrap = config.Bootstrap
+
+		for _, offer := range h.manager.List() {
+			info := SnapshotInfo{
+				Height:    offer.Height,
+				BlockHash: offer.BlockHash,
+				StateHash: offer.StateHash,
+				Signers:   make([]string, 0, len(offer.Signatures)),
+				Trusted:   h.manager.CheckOffer(r.Context(), offer) == nil,
+			}
+			for _, signature := range offer.Signatures {
+				info.Signers = append(info.Signers, signature.Validator)
+			}
+			response.Snapshots = append(response.Snapshots, info)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
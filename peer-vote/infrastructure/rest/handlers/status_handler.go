@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/network"
+)
+
+// PingTimeout limita o ping de cada peer para que peers lentos não atrasem o status
+const PingTimeout = 2 * time.Second
+
+// PeerStatusSource fornece os peers conectados, a latência e a sincronização (ex: network.P2PService)
+type PeerStatusSource interface {
+	GetConnectedPeers() []peer.ID
+	Ping(ctx context.Context, peerID peer.ID) (time.Duration, error)
+	GetSyncStats() *network.SyncStats
+	GetSyncPeers() map[peer.ID]*network.SyncPeerInfo
+}
+
+// NodeStatusSources são os componentes lidos pelo status do nó. Campos nil omitem a seção.
+type NodeStatusSources struct {
+	NodeID    valueobjects.NodeID
+	Chain     *blockchain.ChainManager
+	Consensus *usecases.ConsensusManagerUseCase
+	Mempool   *consensus.Mempool
+	Elections *usecases.ManageElectionUseCase
+	Peers     PeerStatusSource
+}
+
+// StatusHandler gerencia o endpoint administrativo com o estado consolidado do nó
+type StatusHandler struct {
+	sources   NodeStatusSources
+	adminOnly Middleware
+}
+
+// NewStatusHandler cria um novo handler de status.
+// adminOnly protege o endpoint, que pinga os peers a cada consulta.
+func NewStatusHandler(sources NodeStatusSources, adminOnly Middleware) *StatusHandler {
+	return &StatusHandler{
+		sources:   sources,
+		adminOnly: adminOnly,
+	}
+}
+
+// NodeOverviewResponse representa o estado consolidado do nó.
+// Seções de componentes indisponíveis são omitidas; falhas ao lê-las ficam em Errors.
+type NodeOverviewResponse struct {
+	NodeID    string                     `json:"node_id"`
+	Timestamp int64                      `json:"timestamp"`
+	Chain     *ChainOverviewResponse     `json:"chain,omitempty"`
+	Sync      *SyncOverviewResponse      `json:"sync,omitempty"`
+	Peers     []PeerOverviewResponse     `json:"peers"`
+	Consensus *ConsensusOverviewResponse `json:"consensus,omitempty"`
+	Mempool   *MempoolOverviewResponse   `json:"mempool,omitempty"`
+	Elections *ElectionsOverviewResponse `json:"elections,omitempty"`
+	Errors    map[string]string          `json:"errors,omitempty"`
+}
+
+// ChainOverviewResponse representa a altura e o topo da cadeia
+type ChainOverviewResponse struct {
+	Height          uint64 `json:"height"`
+	HeadHash        string `json:"head_hash"`
+	HeadTime        int64  `json:"head_time"`
+	FinalizedHeight uint64 `json:"finalized_height"`
+}
+
+// SyncOverviewResponse representa o progresso da sincronização com os peers
+type SyncOverviewResponse struct {
+	Syncing       bool    `json:"syncing"`
+	Phase         string  `json:"phase"`
+	CurrentHeight uint64  `json:"current_height"`
+	TargetHeight  uint64  `json:"target_height"`
+	Progress      float64 `json:"progress"`
+	LastSyncTime  int64   `json:"last_sync_time"`
+	SyncPeers     int     `json:"sync_peers"`
+}
+
+// PeerOverviewResponse representa um peer conectado e a latência medida por ping
+type PeerOverviewResponse struct {
+	PeerID      string  `json:"peer_id"`
+	LatencyMs   float64 `json:"latency_ms,omitempty"`
+	PingError   string  `json:"ping_error,omitempty"`
+	ChainHeight uint64  `json:"chain_height"`
+	LatestHash  string  `json:"latest_hash,omitempty"`
+	LastContact int64   `json:"last_contact,omitempty"`
+}
+
+// ConsensusOverviewResponse representa o round atual e o proposer
+type ConsensusOverviewResponse struct {
+	IsRunning        bool   `json:"is_running"`
+	Round            uint64 `json:"round"`
+	CurrentProposer  string `json:"current_proposer"`
+	NextHeight       uint64 `json:"next_height"`
+	InTurnValidator  string `json:"in_turn_validator"`
+	TimeRemainingMs  int64  `json:"time_remaining_ms"`
+	ActiveValidators int    `json:"active_validators"`
+	LastBlockTime    int64  `json:"last_block_time"`
+}
+
+// MempoolOverviewResponse representa a ocupação do mempool
+type MempoolOverviewResponse struct {
+	Count           int            `json:"count"`
+	Bytes           int            `json:"bytes"`
+	MaxTransactions int            `json:"max_transactions"`
+	Senders         int            `json:"senders"`
+	ByType          map[string]int `json:"by_type"`
+}
+
+// ElectionsOverviewResponse representa a contagem de eleições por status efetivo
+type ElectionsOverviewResponse struct {
+	Total    int                      `json:"total"`
+	ByStatus map[string]int           `json:"by_status"`
+	Active   []ActiveElectionResponse `json:"active"`
+}
+
+// ActiveElectionResponse representa uma eleição em votação
+type ActiveElectionResponse struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	EndTime int64  `json:"end_time"`
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *StatusHandler) RegisterRoutes(router *mux.Router) {
+	router.Handle("/admin/status", h.adminOnly(http.HandlerFunc(h.GetStatus))).Methods("GET")
+}
+
+// GetStatus retorna cadeia, sincronização, peers com latência, consenso, mempool e eleições
+func (h *StatusHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	response := NodeOverviewResponse{
+		NodeID:    h.sources.NodeID.String(),
+		Timestamp: time.Now().Unix(),
+		Peers:     []PeerOverviewResponse{},
+		Errors:    make(map[string]string),
+	}
+
+	if h.sources.Chain != nil {
+		chain, err := h.chainOverview(ctx)
+		if err != nil {
+			response.Errors["chain"] = err.Error()
+		}
+		response.Chain = chain
+	}
+
+	if h.sources.Peers != nil {
+		response.Sync = h.syncOverview()
+		response.Peers = h.peersOverview(ctx)
+	}
+
+	if h.sources.Consensus != nil {
+		consensusOverview, err := h.consensusOverview(ctx)
+		if err != nil {
+			response.Errors["consensus"] = err.Error()
+		}
+		response.Consensus = consensusOverview
+	}
+
+	if h.sources.Mempool != nil {
+		response.Mempool = h.mempoolOverview()
+	}
+
+	if h.sources.Elections != nil {
+		elections, err := h.electionsOverview(ctx)
+		if err != nil {
+			response.Errors["elections"] = err.Error()
+		}
+		response.Elections = elections
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// chainOverview lê a altura, o topo e o último bloco final
+func (h *StatusHandler) chainOverview(ctx context.Context) (*ChainOverviewResponse, error) {
+	overview := &ChainOverviewResponse{}
+	overview.FinalizedHeight, _ = h.sources.Chain.GetFinalizedHeight(ctx)
+
+	height, err := h.sources.Chain.GetChainHeight(ctx)
+	if err != nil {
+		return overview, err
+	}
+	overview.Height = height
+
+	head, err := h.sources.Chain.GetLatestBlock(ctx)
+	if err != nil {
+		// Cadeia ainda sem gênese não tem topo
+		if height == 0 {
+			return overview, nil
+		}
+		return overview, err
+	}
+	overview.HeadHash = h.sources.Chain.CalculateBlockHash(ctx, head).String()
+	overview.HeadTime = head.GetTimestamp().Unix()
+
+	return overview, nil
+}
+
+// syncOverview lê o progresso da sincronização headers-first
+func (h *StatusHandler) syncOverview() *SyncOverviewResponse {
+	stats := h.sources.Peers.GetSyncStats()
+	if stats == nil {
+		return nil
+	}
+
+	overview := &SyncOverviewResponse{
+		Syncing:       stats.IsSyncing,
+		Phase:         string(stats.Phase),
+		CurrentHeight: stats.CurrentHeight,
+		TargetHeight:  stats.TargetHeight,
+		Progress:      stats.Progress,
+		SyncPeers:     stats.SyncPeerCount,
+	}
+	if !stats.LastSyncTime.IsZero() {
+		overview.LastSyncTime = stats.LastSyncTime.Unix()
+	}
+
+	return overview
+}
+
+// peersOverview pinga os peers conectados em paralelo e junta a altura informada por eles
+func (h *StatusHandler) peersOverview(ctx context.Context) []PeerOverviewResponse {
+	connected := h.sources.Peers.GetConnectedPeers()
+	syncPeers := h.sources.Peers.GetSyncPeers()
+
+	peers := make([]PeerOverviewResponse, len(connected))
+	var wg sync.WaitGroup
+	for i, peerID := range connected {
+		peers[i].PeerID = peerID.String()
+		if info, ok := syncPeers[peerID]; ok {
+			peers[i].ChainHeight = info.ChainHeight
+			peers[i].LatestHash = info.LatestHash
+			if !info.LastContact.IsZero() {
+				peers[i].LastContact = info.LastContact.Unix()
+			}
+		}
+
+		wg.Add(1)
+		go func(overview *PeerOverviewResponse, peerID peer.ID) {
+			defer wg.Done()
+			pingCtx, cancel := context.WithTimeout(ctx, PingTimeout)
+			defer cancel()
+
+			latency, err := h.sources.Peers.Ping(pingCtx, peerID)
+			if err != nil {
+				overview.PingError = err.Error()
+				return
+			}
+			overview.LatencyMs = float64(latency.Microseconds()) / 1000
+		}(&peers[i], peerID)
+	}
+	wg.Wait()
+
+	sort.Slice(peers, func(i, j int) bool {
+		return peers[i].PeerID < peers[j].PeerID
+	})
+
+	return peers
+}
+
+// consensusOverview lê o round atual e o proposer da próxima altura
+func (h *StatusHandler) consensusOverview(ctx context.Context) (*ConsensusOverviewResponse, error) {
+	status, err := h.sources.Consensus.GetConsensusStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	overview := &ConsensusOverviewResponse{
+		IsRunning:        status.IsRunning,
+		Round:            status.CurrentRound,
+		CurrentProposer:  status.CurrentValidator.String(),
+		ActiveValidators: status.ValidatorCount,
+	}
+	if !status.LastBlockTime.IsZero() {
+		overview.LastBlockTime = status.LastBlockTime.Unix()
+	}
+
+	round, err := h.sources.Consensus.GetRoundInfo(ctx)
+	if err != nil {
+		return overview, err
+	}
+	overview.Round = round.Round
+	overview.CurrentProposer = round.CurrentProposer.String()
+	overview.NextHeight = round.NextHeight
+	overview.InTurnValidator = round.InTurnValidator.String()
+	overview.TimeRemainingMs = round.TimeRemaining.Milliseconds()
+	overview.ActiveValidators = round.ActiveValidators
+
+	return overview, nil
+}
+
+// mempoolOverview lê a ocupação do mempool por tipo de transação
+func (h *StatusHandler) mempoolOverview() *MempoolOverviewResponse {
+	stats := h.sources.Mempool.Stats()
+
+	byType := make(map[string]int)
+	for txType, count := range stats.ByType {
+		byType[string(txType)] = count
+	}
+
+	return &MempoolOverviewResponse{
+		Count:           stats.Count,
+		Bytes:           stats.Bytes,
+		MaxTransactions: stats.MaxTransactions,
+		Senders:         stats.Senders,
+		ByType:          byType,
+	}
+}
+
+// electionsOverview conta as eleições por status efetivo e lista as que estão em votação
+func (h *StatusHandler) electionsOverview(ctx context.Context) (*ElectionsOverviewResponse, error) {
+	response, err := h.sources.Elections.ListElections(ctx, &usecases.ListElectionsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	now := valueobjects.Now()
+	overview := &ElectionsOverviewResponse{
+		Total:    len(response.Elections),
+		ByStatus: make(map[string]int),
+		Active:   []ActiveElectionResponse{},
+	}
+	for _, election := range response.Elections {
+		status := election.EffectiveStatus(now)
+		overview.ByStatus[string(status)]++
+		if status == entities.ElectionActive {
+			overview.Active = append(overview.Active, ActiveElectionResponse{
+				ID:      election.GetID().String(),
+				Title:   election.GetTitle(),
+				EndTime: election.GetEndTime().Unix(),
+			})
+		}
+	}
+
+	return overview, nil
+}
@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
+)
+
+// ElectionTemplateHandler gerencia os modelos de eleição do nó e a importação de candidatos
+type ElectionTemplateHandler struct {
+	templateUseCase *usecases.ElectionTemplateUseCase
+}
+
+// NewElectionTemplateHandler cria um novo handler de modelos de eleição
+func NewElectionTemplateHandler(templateUseCase *usecases.ElectionTemplateUseCase) *ElectionTemplateHandler {
+	return &ElectionTemplateHandler{
+		templateUseCase: templateUseCase,
+	}
+}
+
+// ElectionTemplateListResponse representa a lista de modelos de eleição
+type ElectionTemplateListResponse struct {
+	Templates []*entities.ElectionTemplate `json:"templates"`
+	Count     int                          `json:"count"`
+}
+
+// CreateElectionFromTemplateRequest representa o payload para criar eleição a partir de um
+// modelo. Os candidatos vêm da lista ou de um CSV (candidates_csv), nunca dos dois.
+type CreateElectionFromTemplateRequest struct {
+	Title         string               `json:"title"`
+	Description   string               `json:"description"`
+	Candidates    []entities.Candidate `json:"candidates,omitempty"`
+	CandidatesCSV string               `json:"candidates_csv,omitempty"` // Cabeçalho id,name[,description]
+	StartTime     string               `json:"start_time"`               // RFC3339 format
+	EndTime       string               `json:"end_time,omitempty"`       // RFC3339; omitido usa a duração do modelo
+	CreatedBy     string               `json:"created_by"`
+}
+
+// ImportCandidatesRequest representa o CSV de candidatos a validar
+type ImportCandidatesRequest struct {
+	CSV string `json:"csv"`
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *ElectionTemplateHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/elections/candidates/import", h.ImportCandidates).Methods("POST")
+	router.HandleFunc("/election-templates", h.CreateTemplate).Methods("POST")
+	router.HandleFunc("/election-templates", h.ListTemplates).Methods("GET")
+	router.HandleFunc("/election-templates/{name}", h.GetTemplate).Methods("GET")
+	router.HandleFunc("/election-templates/{name}", h.UpdateTemplate).Methods("PUT")
+	router.HandleFunc("/election-templates/{name}", h.DeleteTemplate).Methods("DELETE")
+	router.HandleFunc("/election-templates/{name}/elections", h.CreateElection).Methods("POST")
+}
+
+// ImportCandidates valida um CSV de candidatos e retorna os candidatos e os problemas por linha
+func (h *ElectionTemplateHandler) ImportCandidates(w http.ResponseWriter, r *http.Request) {
+	var req ImportCandidatesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	result, err := usecases.ImportCandidatesCSV(strings.NewReader(req.CSV))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// CreateTemplate cria um modelo de eleição
+func (h *ElectionTemplateHandler) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var template entities.ElectionTemplate
+	if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	// Criador vinculado à identidade autenticada
+	createdBy, err := resolveNodeID(r, template.CreatedBy)
+	if err != nil {
+		http.Error(w, "Forbidden: created_by: "+err.Error(), http.StatusForbidden)
+		return
+	}
+	template.CreatedBy = createdBy.String()
+
+	created, err := h.templateUseCase.CreateTemplate(r.Context(), &template)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(created)
+}
+
+// ListTemplates lista os modelos de eleição do nó
+func (h *ElectionTemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templateUseCase.ListTemplates(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(&ElectionTemplateListResponse{
+		Templates: templates,
+		Count:     len(templates),
+	})
+}
+
+// GetTemplate retorna um modelo de eleição
+func (h *ElectionTemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	template, err := h.templateUseCase.GetTemplate(r.Context(), mux.Vars(r)["name"])
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(template)
+}
+
+// UpdateTemplate substitui as regras de um modelo de eleição
+func (h *ElectionTemplateHandler) UpdateTemplate(w http.ResponseWriter, r *http.Request) {
+	var template entities.ElectionTemplate
+	if err := json.NewDecoder(r.Body).Decode(&template); err != nil {
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	name := mux.Vars(r)["name"]
+	if template.Name != "" && template.Name != name {
+		http.Error(w, "Template name cannot be changed", http.StatusBadRequest)
+		return
+	}
+	template.Name = name
+
+	updated, err := h.templateUseCase.UpdateTemplate(r.Context(), &template)
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(updated)
+}
+
+// DeleteTemplate remove um modelo de eleição
+func (h *ElectionTemplateHandler) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	if err := h.templateUseCase.DeleteTemplate(r.Context(), mux.Vars(r)["name"]); err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateElection cria uma eleição com as regras do modelo
+func (h *ElectionTemplateHandler) CreateElection(w http.ResponseWriter, r *http.Request) {
+	var req CreateElectionFromTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	startTime, err := time.Parse(time.RFC3339, req.StartTime)
+	if err != nil {
+		http.Error(w, "Invalid start_time format (use RFC3339)", http.StatusBadRequest)
+		return
+	}
+
+	var endTime time.Time
+	if req.EndTime != "" {
+		if endTime, err = time.Parse(time.RFC3339, req.EndTime); err != nil {
+			http.Error(w, "Invalid end_time format (use RFC3339)", http.StatusBadRequest)
+			return
+		}
+	}
+
+	candidates := req.Candidates
+	if req.CandidatesCSV != "" {
+		if len(req.Candidates) > 0 {
+			http.Error(w, "Use either candidates or candidates_csv", http.StatusBadRequest)
+			return
+		}
+		imported, err := usecases.ImportCandidatesCSV(strings.NewReader(req.CandidatesCSV))
+		if err == nil {
+			err = imported.Err()
+		}
+		if err != nil {
+			http.Error(w, "candidates_csv: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		candidates = imported.Candidates
+	}
+
+	// Converter CreatedBy para NodeID (vinculado à identidade autenticada)
+	createdBy, err := resolveNodeID(r, req.CreatedBy)
+	if err != nil {
+		http.Error(w, "Forbidden: created_by: "+err.Error(), http.StatusForbidden)
+		return
+	}
+
+	response, err := h.templateUseCase.CreateElection(r.Context(), &usecases.CreateElectionFromTemplateRequest{
+		TemplateName: mux.Vars(r)["name"],
+		Title:        req.Title,
+		Description:  req.Description,
+		Candidates:   candidates,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		CreatedBy:    createdBy,
+	})
+	if err != nil {
+		writeTemplateError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// writeTemplateError converte os erros dos modelos em status HTTP
+func writeTemplateError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrTemplateNotFound):
+		http.Error(w, "Election template not found", http.StatusNotFound)
+	case errors.Is(err, repositories.ErrTemplateExists):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// TransactionHandler gerencia endpoints de acompanhamento de transações
+type TransactionHandler struct {
+	transactionStatusUseCase *usecases.TransactionStatusUseCase
+}
+
+// NewTransactionHandler cria um novo handler de transações
+func NewTransactionHandler(transactionStatusUseCase *usecases.TransactionStatusUseCase) *TransactionHandler {
+	return &TransactionHandler{
+		transactionStatusUseCase: transactionStatusUseCase,
+	}
+}
+
+// TransactionStatusResponse representa o estado de uma transação
+type TransactionStatusResponse struct {
+	Hash            string  `json:"hash"`
+	Status          string  `json:"status"`
+	Reason          string  `json:"reason,omitempty"`
+	Type            string  `json:"type,omitempty"`
+	From            string  `json:"from,omitempty"`
+	BlockHash       string  `json:"block_hash,omitempty"`
+	BlockHeight     *uint64 `json:"block_height,omitempty"` // Ausente enquanto não incluída
+	Position        *int    `json:"position,omitempty"`
+	Confirmations   uint64  `json:"confirmations,omitempty"`
+	FinalizedHeight uint64  `json:"finalized_height,omitempty"`
+	AddedAt         int64   `json:"added_at,omitempty"`
+	ExpiresAt       int64   `json:"expires_at,omitempty"`
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *TransactionHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/transactions/{hash}", h.GetTransactionStatus).Methods("GET")
+}
+
+// GetTransactionStatus retorna se a transação está pendente, incluída, final ou rejeitada
+func (h *TransactionHandler) GetTransactionStatus(w http.ResponseWriter, r *http.Request) {
+	if h.transactionStatusUseCase == nil {
+		http.Error(w, "Transaction index not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Extrair hash da URL
+	vars := mux.Vars(r)
+	txHash, err := valueobjects.NewHashFromString(vars["hash"])
+	if err != nil {
+		http.Error(w, "Invalid transaction hash format", http.StatusBadRequest)
+		return
+	}
+
+	// Executar caso de uso
+	status, err := h.transactionStatusUseCase.GetStatus(r.Context(), txHash)
+	if err != nil {
+		if errors.Is(err, usecases.ErrTransactionNotFound) {
+			http.Error(w, "Transaction not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := TransactionStatusResponse{
+		Hash:            status.Hash.String(),
+		Status:          string(status.Status),
+		Reason:          status.Reason,
+		Type:            string(status.Type),
+		From:            status.From.String(),
+		Confirmations:   status.Confirmations,
+		FinalizedHeight: status.FinalizedHeight,
+	}
+
+	if !status.BlockHash.IsEmpty() {
+		response.BlockHash = status.BlockHash.String()
+		response.BlockHeight = &status.BlockHeight
+		response.Position = &status.Position
+	}
+
+	if !status.AddedAt.IsZero() {
+		response.AddedAt = status.AddedAt.Unix()
+		response.ExpiresAt = status.ExpiresAt.Unix()
+	}
+
+	// Retornar resposta
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,390 @@
+package handlers
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+)
+
+// Middleware envolve um handler (ex: restrição a administradores)
+type Middleware func(http.Handler) http.Handler
+
+// ValidatorHandler gerencia endpoints relacionados aos validadores
+type ValidatorHandler struct {
+	consensusManagerUseCase *usecases.ConsensusManagerUseCase
+	adminOnly               Middleware
+}
+
+// NewValidatorHandler cria um novo handler de validadores.
+// adminOnly protege os endpoints que alteram o conjunto de validadores ou penalidades.
+func NewValidatorHandler(consensusManagerUseCase *usecases.ConsensusManagerUseCase, adminOnly Middleware) *ValidatorHandler {
+	return &ValidatorHandler{
+		consensusManagerUseCase: consensusManagerUseCase,
+		adminOnly:               adminOnly,
+	}
+}
+
+// ValidatorResponse representa um validador com suas estatísticas
+type ValidatorResponse struct {
+	NodeID       string  `json:"node_id"`
+	Status       string  `json:"status"`
+	TotalRounds  int     `json:"total_rounds"`
+	MissedRounds int     `json:"missed_rounds"`
+	SuccessRate  float64 `json:"success_rate"`
+	PenaltyCount int     `json:"penalty_count"`
+	LastActiveAt int64   `json:"last_active_at"`
+}
+
+// ValidatorListResponse representa a lista de validadores
+type ValidatorListResponse struct {
+	Validators []ValidatorResponse `json:"validators"`
+	Count      int                 `json:"count"`
+}
+
+// ValidatorProposalResponse representa uma proposta de validador pendente
+type ValidatorProposalResponse struct {
+	ProposalID      string   `json:"proposal_id"`
+	Action          string   `json:"action"`
+	NodeID          string   `json:"node_id"`
+	EffectiveHeight uint64   `json:"effective_height"`
+	Proposer        string   `json:"proposer"`
+	IncludedAt      uint64   `json:"included_at"`
+	Approvals       []string `json:"approvals"`
+	Required        int      `json:"required"`
+}
+
+// ValidatorProposalListResponse representa as propostas pendentes
+type ValidatorProposalListResponse struct {
+	Proposals []ValidatorProposalResponse `json:"proposals"`
+	Count     int                         `json:"count"`
+}
+
+// ProposeValidatorRequest representa o payload para propor a adição de um validador.
+// A proposta é assinada com a chave deste nó, que precisa ser um validador atual.
+type ProposeValidatorRequest struct {
+	NodeID          string `json:"node_id"`
+	PublicKey       string `json:"public_key"` // hex (X || Y)
+	Curve           string `json:"curve"`
+	EffectiveHeight uint64 `json:"effective_height,omitempty"`
+}
+
+// ValidatorProposalSubmittedResponse representa a resposta de uma proposta submetida
+type ValidatorProposalSubmittedResponse struct {
+	Success         bool   `json:"success"`
+	Message         string `json:"message"`
+	ProposalID      string `json:"proposal_id"`
+	EffectiveHeight uint64 `json:"effective_height"`
+}
+
+// ProposalApprovedResponse representa a resposta da aprovação de uma proposta
+type ProposalApprovedResponse struct {
+	Success       bool   `json:"success"`
+	Message       string `json:"message"`
+	TransactionID string `json:"transaction_id"`
+}
+
+// PenaltyResponse representa uma penalidade derivada da cadeia
+type PenaltyResponse struct {
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	Severity  int                    `json:"severity"`
+	Reason    string                 `json:"reason"`
+	AppliedAt int64                  `json:"applied_at"`
+	ExpiresAt int64                  `json:"expires_at"`
+	IsActive  bool                   `json:"is_active"`
+	Evidence  map[string]interface{} `json:"evidence,omitempty"`
+}
+
+// ValidatorPenaltiesResponse representa o histórico de penalidades de um validador
+type ValidatorPenaltiesResponse struct {
+	ValidatorID string            `json:"validator_id"`
+	Penalties   []PenaltyResponse `json:"penalties"`
+	TotalCount  int               `json:"total_count"`
+	ActiveCount int               `json:"active_count"`
+}
+
+// RegisterRoutes registra as rotas do handler
+func (h *ValidatorHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/validators", h.ListValidators).Methods("GET")
+	router.HandleFunc("/validators/proposals", h.ListProposals).Methods("GET")
+	router.HandleFunc("/validators/{id}", h.GetValidator).Methods("GET")
+	router.HandleFunc("/validators/{id}/penalties", h.GetValidatorPenalties).Methods("GET")
+
+	// Endpoints administrativos
+	router.Handle("/validators", h.adminOnly(http.HandlerFunc(h.ProposeValidator))).Methods("POST")
+	router.Handle("/validators/{id}", h.adminOnly(http.HandlerFunc(h.ProposeValidatorRemoval))).Methods("DELETE")
+	router.Handle("/validators/proposals/{id}/approve", h.adminOnly(http.HandlerFunc(h.ApproveProposal))).Methods("POST")
+}
+
+// ListValidators lista os validadores com suas estatísticas
+func (h *ValidatorHandler) ListValidators(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	validators, err := h.consensusManagerUseCase.ListValidators(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	response := make([]ValidatorResponse, len(validators))
+	for i, validator := range validators {
+		response[i] = toValidatorResponse(validator)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ValidatorListResponse{
+		Validators: response,
+		Count:      len(response),
+	})
+}
+
+// GetValidator obtém um validador específico
+func (h *ValidatorHandler) GetValidator(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	vars := mux.Vars(r)
+	validatorID := valueobjects.NewNodeID(vars["id"])
+	if validatorID.IsEmpty() {
+		http.Error(w, "Invalid validator ID", http.StatusBadRequest)
+		return
+	}
+
+	validator, err := h.consensusManagerUseCase.GetValidator(r.Context(), validatorID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toValidatorResponse(*validator))
+}
+
+// ListProposals lista as propostas de validador aguardando aprovação
+func (h *ValidatorHandler) ListProposals(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	proposals, err := h.consensusManagerUseCase.GetPendingValidatorProposals(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+
+	response := make([]ValidatorProposalResponse, len(proposals))
+	for i, proposal := range proposals {
+		approvals := make([]string, len(proposal.Approvals))
+		for j, approval := range proposal.Approvals {
+			approvals[j] = approval.String()
+		}
+
+		response[i] = ValidatorProposalResponse{
+			ProposalID:      proposal.ProposalID.String(),
+			Action:          string(proposal.Action),
+			NodeID:          proposal.NodeID.String(),
+			EffectiveHeight: proposal.EffectiveHeight,
+			Proposer:        proposal.Proposer.String(),
+			IncludedAt:      proposal.IncludedAt,
+			Approvals:       approvals,
+			Required:        proposal.Required,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ValidatorProposalListResponse{
+		Proposals: response,
+		Count:     len(response),
+	})
+}
+
+// ProposeValidator propõe a adição de um validador
+func (h *ValidatorHandler) ProposeValidator(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req ProposeValidatorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON payload", http.StatusBadRequest)
+		return
+	}
+
+	keyBytes, err := hex.DecodeString(req.PublicKey)
+	if err != nil {
+		http.Error(w, "Invalid public_key (expected hex)", http.StatusBadRequest)
+		return
+	}
+
+	curve := req.Curve
+	if curve == "" {
+		curve = "P-256"
+	}
+
+	publicKey := &services.PublicKey{}
+	if err := publicKey.FromBytes(keyBytes, curve); err != nil {
+		http.Error(w, "Invalid public_key: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.consensusManagerUseCase.AddValidator(r.Context(), &usecases.AddValidatorRequest{
+		NodeID:          valueobjects.NewNodeID(req.NodeID),
+		PublicKey:       publicKey,
+		EffectiveHeight: req.EffectiveHeight,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(ValidatorProposalSubmittedResponse{
+		Success:         response.Success,
+		Message:         response.Message,
+		ProposalID:      response.ProposalID.String(),
+		EffectiveHeight: response.EffectiveHeight,
+	})
+}
+
+// ProposeValidatorRemoval propõe a remoção de um validador
+func (h *ValidatorHandler) ProposeValidatorRemoval(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	vars := mux.Vars(r)
+	var effectiveHeight uint64
+	if value := r.URL.Query().Get("effective_height"); value != "" {
+		if _, err := fmt.Sscanf(value, "%d", &effectiveHeight); err != nil {
+			http.Error(w, "Invalid effective_height", http.StatusBadRequest)
+			return
+		}
+	}
+
+	response, err := h.consensusManagerUseCase.RemoveValidator(r.Context(), &usecases.RemoveValidatorRequest{
+		NodeID:          valueobjects.NewNodeID(vars["id"]),
+		EffectiveHeight: effectiveHeight,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(ValidatorProposalSubmittedResponse{
+		Success:         response.Success,
+		Message:         response.Message,
+		ProposalID:      response.ProposalID.String(),
+		EffectiveHeight: response.EffectiveHeight,
+	})
+}
+
+// ApproveProposal aprova uma proposta de validador com a chave deste nó
+func (h *ValidatorHandler) ApproveProposal(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	vars := mux.Vars(r)
+	proposalID, err := valueobjects.NewHashFromString(vars["id"])
+	if err != nil {
+		http.Error(w, "Invalid proposal ID format", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.consensusManagerUseCase.ApproveValidatorChange(r.Context(), &usecases.ApproveValidatorChangeRequest{
+		ProposalID: proposalID,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(ProposalApprovedResponse{
+		Success:       response.Success,
+		Message:       response.Message,
+		TransactionID: response.TransactionID.String(),
+	})
+}
+
+// GetValidatorPenalties obtém as penalidades de um validador
+func (h *ValidatorHandler) GetValidatorPenalties(w http.ResponseWriter, r *http.Request) {
+	if h.consensusManagerUseCase == nil {
+		http.Error(w, "Consensus manager not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Extrair ID da URL
+	vars := mux.Vars(r)
+	validatorID := valueobjects.NewNodeID(vars["id"])
+	if validatorID.IsEmpty() {
+		http.Error(w, "Invalid validator ID", http.StatusBadRequest)
+		return
+	}
+
+	// Executar caso de uso
+	response, err := h.consensusManagerUseCase.GetValidatorPenalties(r.Context(), &usecases.GetValidatorPenaltiesRequest{
+		ValidatorID: validatorID,
+		ActiveOnly:  r.URL.Query().Get("active") == "true",
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Converter para resposta
+	penalties := make([]PenaltyResponse, len(response.Penalties))
+	for i, penalty := range response.Penalties {
+		penalties[i] = PenaltyResponse{
+			ID:        penalty.ID,
+			Type:      string(penalty.Type),
+			Severity:  int(penalty.Severity),
+			Reason:    penalty.Reason,
+			AppliedAt: penalty.AppliedAt.Unix(),
+			ExpiresAt: penalty.ExpiresAt.Unix(),
+			IsActive:  penalty.IsActive,
+			Evidence:  penalty.Evidence,
+		}
+	}
+
+	// Retornar resposta
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ValidatorPenaltiesResponse{
+		ValidatorID: response.ValidatorID.String(),
+		Penalties:   penalties,
+		TotalCount:  response.TotalCount,
+		ActiveCount: response.ActiveCount,
+	})
+}
+
+// toValidatorResponse converte as estatísticas do caso de uso para a resposta JSON
+func toValidatorResponse(validator usecases.ValidatorStatusInfo) ValidatorResponse {
+	return ValidatorResponse{
+		NodeID:       validator.NodeID.String(),
+		Status:       string(validator.Status),
+		TotalRounds:  validator.TotalRounds,
+		MissedRounds: validator.MissedRounds,
+		SuccessRate:  validator.SuccessRate,
+		PenaltyCount: validator.PenaltyCount,
+		LastActiveAt: validator.LastActiveAt.Unix(),
+	}
+}
@@ -1,14 +1,20 @@
 package handlers
 
 import (
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
 	"github.com/matscats/peer-vote/peer-vote/domain/services"
 	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/report"
 )
 
 // VoteHandler gerencia endpoints relacionados a votos
@@ -38,6 +44,11 @@ type SubmitVoteRequest struct {
 	CandidateID string `json:"candidate_id"`
 	IsAnonymous bool   `json:"is_anonymous"`
 	PrivateKey  string `json:"private_key"` // Base64 encoded
+
+	// Voto assinado localmente pelo eleitor (a chave privada não sai do cliente)
+	SignedVote           *entities.Vote `json:"signed_vote,omitempty"`
+	TransactionSignature string         `json:"transaction_signature,omitempty"` // Hex
+	PublicKey            string         `json:"public_key,omitempty"`            // Hex P-256 (formato de X-Public-Key)
 }
 
 // RegisterRoutes registra as rotas do handler
@@ -62,32 +73,11 @@ func (h *VoteHandler) SubmitVote(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Converter VoterID para NodeID
-	voterID := valueobjects.NewNodeID(req.VoterID)
-
-	// Converter PrivateKey de string para *services.PrivateKey
-	var privateKey *services.PrivateKey
-	if req.PrivateKey != "" {
-		// Usar o ECDSAService para fazer o parsing
-		if ecdsaService, ok := h.cryptoService.(*crypto.ECDSAService); ok {
-			var err error
-			privateKey, err = ecdsaService.ParsePrivateKeyFromString(req.PrivateKey)
-			if err != nil {
-				http.Error(w, "Invalid private key format", http.StatusBadRequest)
-				return
-			}
-		} else {
-			http.Error(w, "Crypto service does not support key parsing", http.StatusInternalServerError)
-			return
-		}
-	} else {
-		// Gerar chave privada se não fornecida
-		keyPair, err := h.cryptoService.GenerateKeyPair(r.Context())
-		if err != nil {
-			http.Error(w, "Failed to generate key pair", http.StatusInternalServerError)
-			return
-		}
-		privateKey = keyPair.PrivateKey
+	// Converter VoterID para NodeID (vinculado à identidade autenticada)
+	voterID, err := resolveNodeID(r, req.VoterID)
+	if err != nil {
+		http.Error(w, "Forbidden: voter_id: "+err.Error(), http.StatusForbidden)
+		return
 	}
 
 	// Criar request do caso de uso
@@ -96,7 +86,30 @@ func (h *VoteHandler) SubmitVote(w http.ResponseWriter, r *http.Request) {
 		VoterID:     voterID,
 		CandidateID: req.CandidateID,
 		IsAnonymous: req.IsAnonymous,
-		PrivateKey:  privateKey,
+	}
+
+	if req.SignedVote != nil {
+		// Voto assinado pelo cliente: o nó apenas verifica as assinaturas
+		publicKey, err := parsePublicKeyHex(req.PublicKey)
+		if err != nil {
+			http.Error(w, "Invalid public key format", http.StatusBadRequest)
+			return
+		}
+		txSignature, err := valueobjects.NewSignatureFromString(req.TransactionSignature)
+		if err != nil {
+			http.Error(w, "Invalid transaction signature format", http.StatusBadRequest)
+			return
+		}
+		submitRequest.SignedVote = req.SignedVote
+		submitRequest.TransactionSignature = txSignature
+		submitRequest.PublicKey = publicKey
+	} else {
+		privateKey, status, err := h.resolvePrivateKey(r, req.PrivateKey)
+		if err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+		submitRequest.PrivateKey = privateKey
 	}
 
 	// Executar caso de uso
@@ -112,8 +125,52 @@ func (h *VoteHandler) SubmitVote(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// AuditVotes executa auditoria de votos de uma eleição
+// resolvePrivateKey converte a chave privada enviada ou gera uma nova quando ausente
+func (h *VoteHandler) resolvePrivateKey(r *http.Request, keyStr string) (*services.PrivateKey, int, error) {
+	if keyStr == "" {
+		// Gerar chave privada se não fornecida
+		keyPair, err := h.cryptoService.GenerateKeyPair(r.Context())
+		if err != nil {
+			return nil, http.StatusInternalServerError, errors.New("Failed to generate key pair")
+		}
+		return keyPair.PrivateKey, http.StatusOK, nil
+	}
+
+	// Usar o ECDSAService para fazer o parsing
+	ecdsaService, ok := h.cryptoService.(*crypto.ECDSAService)
+	if !ok {
+		return nil, http.StatusInternalServerError, errors.New("Crypto service does not support key parsing")
+	}
+	privateKey, err := ecdsaService.ParsePrivateKeyFromString(keyStr)
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("Invalid private key format")
+	}
+	return privateKey, http.StatusOK, nil
+}
+
+// parsePublicKeyHex converte a chave pública P-256 em hex (PublicKey.ToBytes)
+func parsePublicKeyHex(keyHex string) (*services.PublicKey, error) {
+	keyBytes, err := hex.DecodeString(strings.TrimPrefix(keyHex, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	publicKey := &services.PublicKey{}
+	if err := publicKey.FromBytes(keyBytes, "P-256"); err != nil {
+		return nil, err
+	}
+	return publicKey, nil
+}
+
+// AuditVotes executa auditoria de votos de uma eleição.
+// O resumo cobre todos os votos; audit_results aceita filtros (candidate_id, valid),
+// ordem (order=asc|desc) e paginação por cursor. Com Accept text/csv, application/x-ndjson
+// ou text/markdown, todos os votos filtrados são exportados em streaming, sem paginação.
 func (h *VoteHandler) AuditVotes(w http.ResponseWriter, r *http.Request) {
+	format, ok := negotiateFormat(w, r, report.FormatJSON, report.FormatCSV, report.FormatNDJSON, report.FormatMarkdown)
+	if !ok {
+		return
+	}
+
 	// Extrair ID da URL
 	vars := mux.Vars(r)
 	electionIDStr := vars["election_id"]
@@ -125,9 +182,42 @@ func (h *VoteHandler) AuditVotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Filtros e paginação da listagem de votos auditados
+	query := r.URL.Query()
+	page, err := parsePageParams(query, 100)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Criar request do caso de uso
 	auditRequest := &usecases.AuditVotesRequest{
-		ElectionID: electionID,
+		ElectionID:  electionID,
+		CandidateID: query.Get("candidate_id"),
+		Cursor:      page.Cursor,
+		Order:       page.Order,
+		Limit:       page.Limit,
+	}
+
+	if validStr := query.Get("valid"); validStr != "" {
+		valid, err := strconv.ParseBool(validStr)
+		if err != nil {
+			http.Error(w, "Invalid valid filter (use true or false)", http.StatusBadRequest)
+			return
+		}
+		auditRequest.Valid = &valid
+	}
+
+	if format != report.FormatJSON {
+		setExportHeaders(w, format, "audit", electionID)
+		writer, err := report.NewAuditWriter(w, format, "Auditoria de votos da eleição "+electionID.String())
+		if err != nil {
+			w.Header().Del("Content-Disposition")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		streamAuditRows(w, r, h.auditVotesUseCase, auditRequest, writer, false)
+		return
 	}
 
 	// Executar caso de uso
@@ -142,8 +232,14 @@ func (h *VoteHandler) AuditVotes(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
-// CountVotes executa contagem de votos de uma eleição
+// CountVotes executa contagem de votos de uma eleição.
+// Com Accept text/csv ou text/markdown, a apuração por candidato é exportada no formato.
 func (h *VoteHandler) CountVotes(w http.ResponseWriter, r *http.Request) {
+	format, ok := negotiateFormat(w, r, report.FormatJSON, report.FormatCSV, report.FormatMarkdown)
+	if !ok {
+		return
+	}
+
 	// Extrair ID da URL
 	vars := mux.Vars(r)
 	electionIDStr := vars["election_id"]
@@ -167,6 +263,12 @@ func (h *VoteHandler) CountVotes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if format != report.FormatJSON {
+		setExportHeaders(w, format, "results", electionID)
+		report.WriteResults(w, format, response)
+		return
+	}
+
 	// Retornar resposta
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
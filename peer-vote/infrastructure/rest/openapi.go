@@ -0,0 +1,833 @@
+package rest
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/certification"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/health"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/auth"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/handlers"
+)
+
+// OpenAPIPath é a rota onde a especificação OpenAPI é servida
+const OpenAPIPath = "/api/v1/openapi.json"
+
+// apiTag agrupa operações na especificação e na documentação HTML
+type apiTag struct {
+	Name        string
+	Description string
+}
+
+// apiTags define a ordem dos grupos de operações
+var apiTags = []apiTag{
+	{"Eleições", "Criação, consulta e ciclo de vida de eleições, modelos de eleição e importação de candidatos."},
+	{"Votos", "Submissão, auditoria e contagem de votos."},
+	{"Blockchain", "Blocos e estado da cadeia."},
+	{"Transações", "Acompanhamento de transações e mempool."},
+	{"Eventos", "Assinatura de eventos da cadeia (block, transaction, election_status, tally e reorg). " +
+		"O parâmetro election filtra por eleição; from_height retoma a partir da altura e, no SSE, " +
+		"o cabeçalho Last-Event-ID retoma logo após o último evento recebido."},
+	{"Nós", "Estado do nó e da rede P2P."},
+	{"Validadores", "Conjunto de validadores, propostas e penalidades."},
+	{"Consenso", "Estado e controle do consenso."},
+	{"Administração", "Operação do nó em tempo de execução (níveis de log, status consolidado e arquivo da cadeia)."},
+	{"Informações", "Metadados e documentação da API."},
+}
+
+// apiParameter documenta um parâmetro de query ou de caminho
+type apiParameter struct {
+	Name        string
+	Type        string // string, integer ou boolean
+	Description string
+}
+
+// apiOperation documenta uma rota registrada pelos handlers
+type apiOperation struct {
+	Tag         string
+	Summary     string
+	Description string
+	PathParams  []apiParameter
+	Query       []apiParameter
+	Request     interface{} // Corpo JSON (valor do tipo esperado)
+	Response    interface{} // Corpo JSON da resposta de sucesso
+	Status      int         // Status de sucesso (padrão 200)
+	ContentType string      // Tipo da resposta (padrão application/json)
+}
+
+// Parâmetros de caminho reutilizados
+var (
+	electionIDParam   = apiParameter{"id", "string", "ID da eleição (hash hexadecimal)"}
+	voteElectionParam = apiParameter{"election_id", "string", "ID da eleição (hash hexadecimal)"}
+	validatorIDParam  = apiParameter{"id", "string", "NodeID do validador"}
+	proposalIDParam   = apiParameter{"id", "string", "ID da proposta (hash hexadecimal)"}
+	templateNameParam = apiParameter{"name", "string", "nome do modelo de eleição"}
+)
+
+// formatQueryParam seleciona o formato de exportação sem depender do cabeçalho Accept
+var formatQueryParam = apiParameter{"format", "string", "formato da resposta (prevalece sobre Accept)"}
+
+// apiOperations documenta as rotas da API pela mesma chave de routeRoles ("MÉTODO template").
+// Rotas registradas sem entrada aqui ainda aparecem na especificação, com aviso no log.
+var apiOperations = map[string]apiOperation{
+	// Eleições
+	"POST /api/v1/elections": {
+		Tag: "Eleições", Summary: "Criar eleição",
+		Description: "start_time e end_time em RFC3339. created_by é vinculado ao NodeID da credencial.",
+		Request:     handlers.CreateElectionRequest{}, Response: usecases.CreateElectionResponse{}, Status: http.StatusCreated,
+	},
+	"GET /api/v1/elections": {
+		Tag: "Eleições", Summary: "Listar eleições",
+		Description: "Eleições na ordem da cadeia, paginadas por cursor (padrão 50 por página).",
+		Query: pageQueryParams(
+			apiParameter{"active", "boolean", "apenas eleições em votação"},
+			apiParameter{"status", "string", "status efetivo: PENDING, ACTIVE, CLOSED ou CANCELLED"},
+			apiParameter{"created_by", "string", "filtra pelo NodeID do criador"},
+			apiParameter{"from", "string", "votação termina após o instante (Unix ou RFC 3339)"},
+			apiParameter{"to", "string", "votação começa antes do instante (Unix ou RFC 3339)"},
+		),
+		Response: usecases.ListElectionsResponse{},
+	},
+	"GET /api/v1/elections/{id}": {
+		Tag: "Eleições", Summary: "Obter eleição",
+		PathParams: []apiParameter{electionIDParam},
+		Response:   usecases.GetElectionResponse{},
+	},
+	"PUT /api/v1/elections/{id}/status": {
+		Tag: "Eleições", Summary: "Atualizar status",
+		Description: "new_status: PENDING, ACTIVE, CLOSED ou CANCELLED.",
+		PathParams:  []apiParameter{electionIDParam},
+		Request:     handlers.UpdateElectionStatusRequest{}, Response: usecases.UpdateElectionStatusResponse{},
+	},
+	"GET /api/v1/elections/{id}/results": {
+		Tag: "Eleições", Summary: "Obter resultados",
+		PathParams: []apiParameter{electionIDParam},
+		Response:   usecases.GetElectionResultsResponse{},
+	},
+	"POST /api/v1/elections/{id}/certify": {
+		Tag: "Eleições", Summary: "Certificar resultado",
+		Description: "Apura a eleição encerrada no topo da cadeia e registra o documento de resultado assinado pelo nó " +
+			"(validador). Os demais validadores recalculam o documento e o co-assinam; mais de 2/3 certificam o resultado.",
+		PathParams: []apiParameter{electionIDParam},
+		Response:   usecases.CertifyResultsResponse{}, Status: http.StatusAccepted,
+	},
+	"GET /api/v1/elections/{id}/report": {
+		Tag: "Eleições", Summary: "Relatório da eleição",
+		Description: "Relatório legível com apuração por candidato, resumo da auditoria e votos inválidos (em streaming). " +
+			"Com Accept application/json (ou format=json), o relatório sem a lista de votos (AuditReportResponse).",
+		PathParams:  []apiParameter{electionIDParam},
+		Query:       []apiParameter{formatQueryParam},
+		ContentType: "text/markdown",
+	},
+	"GET /api/v1/elections/{id}/certificate": {
+		Tag: "Eleições", Summary: "Obter certificado de resultado",
+		Description: "Documento de resultado, assinaturas registradas na cadeia e conjunto de validadores. " +
+			"Verificável offline com peer-vote election verify-certificate.",
+		PathParams: []apiParameter{electionIDParam},
+		Response:   certification.Certificate{},
+	},
+	"POST /api/v1/elections/candidates/import": {
+		Tag: "Eleições", Summary: "Validar CSV de candidatos",
+		Description: "CSV com cabeçalho id,name[,description]. Valida as regras de Election.IsValid (ID e nome obrigatórios, " +
+			"IDs únicos, ao menos 2 candidatos) e nomes repetidos; retorna os candidatos aceitos e os problemas por linha.",
+		Request: handlers.ImportCandidatesRequest{}, Response: usecases.CandidateImport{},
+	},
+	"GET /api/v1/election-templates": {
+		Tag: "Eleições", Summary: "Listar modelos de eleição",
+		Description: "Modelos mantidos por este nó, em ordem de nome.",
+		Response:    handlers.ElectionTemplateListResponse{},
+	},
+	"POST /api/v1/election-templates": {
+		Tag: "Eleições", Summary: "Criar modelo de eleição",
+		Description: "ballot_type: single_choice (1 voto por eleitor) ou multiple_choice (max_votes_per_voter >= 2). " +
+			"As durações (minutos) devem ser compatíveis com voting.validation.min/max_election_duration do nó.",
+		Request: entities.ElectionTemplate{}, Response: entities.ElectionTemplate{}, Status: http.StatusCreated,
+	},
+	"GET /api/v1/election-templates/{name}": {
+		Tag: "Eleições", Summary: "Obter modelo de eleição",
+		PathParams: []apiParameter{templateNameParam},
+		Response:   entities.ElectionTemplate{},
+	},
+	"PUT /api/v1/election-templates/{name}": {
+		Tag: "Eleições", Summary: "Atualizar modelo de eleição",
+		Description: "Substitui as regras do modelo; eleições já criadas não são afetadas.",
+		PathParams:  []apiParameter{templateNameParam},
+		Request:     entities.ElectionTemplate{}, Response: entities.ElectionTemplate{},
+	},
+	"DELETE /api/v1/election-templates/{name}": {
+		Tag: "Eleições", Summary: "Remover modelo de eleição",
+		PathParams: []apiParameter{templateNameParam},
+		Status:     http.StatusNoContent,
+	},
+	"POST /api/v1/election-templates/{name}/elections": {
+		Tag: "Eleições", Summary: "Criar eleição a partir de modelo",
+		Description: "Aplica as regras do modelo (anonimato, votos por eleitor e faixa de duração). Sem end_time, a eleição dura " +
+			"duration_minutes do modelo. Candidatos em candidates ou em candidates_csv (mesmo formato da importação).",
+		PathParams: []apiParameter{templateNameParam},
+		Request:    handlers.CreateElectionFromTemplateRequest{}, Response: usecases.CreateElectionResponse{}, Status: http.StatusCreated,
+	},
+
+	// Votos
+	"POST /api/v1/votes": {
+		Tag: "Votos", Summary: "Submeter voto",
+		Description: "voter_id é vinculado ao NodeID da credencial.",
+		Request:     handlers.SubmitVoteRequest{}, Response: usecases.SubmitVoteResponse{}, Status: http.StatusCreated,
+	},
+	"GET /api/v1/votes/audit/{election_id}": {
+		Tag: "Votos", Summary: "Auditar votos",
+		Description: "O resumo cobre todos os votos; audit_results é paginado por cursor (padrão 100 por página). " +
+			"Com Accept text/csv, application/x-ndjson ou text/markdown (ou format=csv|ndjson|markdown), todos os votos " +
+			"filtrados são exportados em streaming, uma linha por voto (tx_hash, bloco, horário, validade e erros), sem paginação.",
+		PathParams: []apiParameter{voteElectionParam},
+		Query: pageQueryParams(
+			apiParameter{"candidate_id", "string", "apenas votos no candidato"},
+			apiParameter{"valid", "boolean", "apenas votos válidos (true) ou inválidos (false)"},
+			formatQueryParam,
+		),
+		Response: usecases.AuditVotesResponse{},
+	},
+	"GET /api/v1/votes/count/{election_id}": {
+		Tag: "Votos", Summary: "Contar votos",
+		Description: "Com Accept text/csv ou text/markdown (ou format=csv|markdown), a apuração por candidato é exportada no formato.",
+		PathParams:  []apiParameter{voteElectionParam},
+		Query:       []apiParameter{formatQueryParam},
+		Response:    usecases.CountVotesResponse{},
+	},
+
+	// Blockchain
+	"GET /api/v1/blocks": {
+		Tag: "Blockchain", Summary: "Listar blocos",
+		Description: "Blocos da cadeia canônica, paginados por cursor (padrão 10 por página).",
+		Query: pageQueryParams(
+			apiParameter{"validator", "string", "apenas blocos propostos pelo validador"},
+			apiParameter{"from_height", "integer", "altura mínima (inclusive)"},
+			apiParameter{"to_height", "integer", "altura máxima (inclusive)"},
+			apiParameter{"tx_type", "string", "apenas blocos com transações do tipo: VOTE, ELECTION, VALIDATOR, EVIDENCE ou CERTIFICATE"},
+			apiParameter{"offset", "integer", "sinônimo de from_height (compatibilidade)"},
+		),
+		Response: handlers.BlockListResponse{},
+	},
+	"GET /api/v1/blocks/{index:[0-9]+}": {
+		Tag: "Blockchain", Summary: "Obter bloco por índice",
+		PathParams: []apiParameter{{"index", "integer", "altura do bloco"}},
+		Response:   handlers.BlockResponse{},
+	},
+	"GET /api/v1/blocks/hash/{hash}": {
+		Tag: "Blockchain", Summary: "Obter bloco por hash",
+		PathParams: []apiParameter{{"hash", "string", "hash do bloco (hexadecimal)"}},
+		Response:   handlers.BlockResponse{},
+	},
+	"GET /api/v1/blocks/latest": {
+		Tag: "Blockchain", Summary: "Último bloco",
+		Response: handlers.BlockResponse{},
+	},
+	"GET /api/v1/chain/status": {
+		Tag: "Blockchain", Summary: "Status da blockchain",
+		Response: handlers.ChainStatusResponse{},
+	},
+	"GET /api/v1/chain/validate": {
+		Tag: "Blockchain", Summary: "Validar a cadeia",
+		Response: handlers.ChainValidationResponse{},
+	},
+	"GET /api/v1/chain/manifest": {
+		Tag: "Blockchain", Summary: "Manifesto da cadeia",
+		Description: "Altura, base (primeira altura servida), hashes do gênese e do topo e o histórico do conjunto de " +
+			"validadores: com os blocos de /chain/blocks/{index}, o suficiente para um auditor externo remontar e verificar a cadeia.",
+		Response: archive.Manifest{},
+	},
+	"GET /api/v1/chain/blocks/{index:[0-9]+}": {
+		Tag: "Blockchain", Summary: "Obter bloco completo por índice",
+		Description: "Bloco com os dados de todas as transações, no formato do arquivo de cadeia. " +
+			"404 para alturas que o nó não armazena (acima do topo ou abaixo da base do snapshot).",
+		PathParams: []apiParameter{{"index", "integer", "altura do bloco"}},
+		Response:   archive.ArchivedBlock{},
+	},
+
+	// Transações
+	"GET /api/v1/transactions/{hash}": {
+		Tag: "Transações", Summary: "Status da transação",
+		Description: "PENDING, INCLUDED, FINALIZED ou REJECTED.",
+		PathParams:  []apiParameter{{"hash", "string", "hash da transação (hexadecimal)"}},
+		Response:    handlers.TransactionStatusResponse{},
+	},
+	"GET /api/v1/mempool": {
+		Tag: "Transações", Summary: "Transações pendentes no mempool",
+		Query:    []apiParameter{{"limit", "integer", "máximo de transações (padrão 100)"}},
+		Response: handlers.MempoolResponse{},
+	},
+
+	// Eventos
+	"GET /api/v1/events": {
+		Tag: "Eventos", Summary: "Assinatura via Server-Sent Events",
+		Description: "Cada evento é enviado com id (sequência, usada em Last-Event-ID), event (tipo) e data (EventResponse em JSON); o histórico de from_height vem sem id, seguido de um id sem dados com a posição da assinatura.",
+		Query:       eventQueryParams,
+		Response:    handlers.EventResponse{}, ContentType: "text/event-stream",
+	},
+	"GET /api/v1/events/ws": {
+		Tag: "Eventos", Summary: "Assinatura via WebSocket",
+		Description: "Cada mensagem é um EventResponse em JSON.",
+		Query:       eventQueryParams,
+		Response:    handlers.EventResponse{}, Status: http.StatusSwitchingProtocols,
+	},
+
+	// Nós
+	"GET /api/v1/nodes/status": {
+		Tag: "Nós", Summary: "Status do nó",
+		Response: handlers.NodeStatusResponse{},
+	},
+	"GET /api/v1/nodes/network": {
+		Tag: "Nós", Summary: "Status da rede",
+		Response: handlers.NetworkStatusResponse{},
+	},
+	"GET /api/v1/nodes/peers": {
+		Tag: "Nós", Summary: "Peers conectados",
+		Response: handlers.PeerListResponse{},
+	},
+	"GET /api/v1/nodes/health": {
+		Tag: "Nós", Summary: "Health check",
+		Description: "Resultado da readiness por componente. Responde 503 com o mesmo corpo quando alguma verificação falha.",
+		Response:    handlers.HealthResponse{},
+	},
+	"GET " + DefaultHealthPath + "/live": {
+		Tag: "Nós", Summary: "Liveness",
+		Description: "Repositório acessível e loop de consenso produzindo blocos. " +
+			"Responde 503 com o mesmo corpo quando algum componente está down (o nó deve ser reiniciado).",
+		Response: health.Report{},
+	},
+	"GET " + DefaultHealthPath + "/ready": {
+		Tag: "Nós", Summary: "Readiness",
+		Description: "Repositório, validade do último bloco, atraso em relação aos peers, consenso e peers conectados. " +
+			"Responde 503 com o mesmo corpo quando algum componente está down.",
+		Response: health.Report{},
+	},
+
+	// Validadores
+	"GET /api/v1/validators": {
+		Tag: "Validadores", Summary: "Listar validadores com estatísticas",
+		Response: handlers.ValidatorListResponse{},
+	},
+	"GET /api/v1/validators/proposals": {
+		Tag: "Validadores", Summary: "Propostas de validador pendentes",
+		Response: handlers.ValidatorProposalListResponse{},
+	},
+	"GET /api/v1/validators/{id}": {
+		Tag: "Validadores", Summary: "Obter validador",
+		PathParams: []apiParameter{validatorIDParam},
+		Response:   handlers.ValidatorResponse{},
+	},
+	"GET /api/v1/validators/{id}/penalties": {
+		Tag: "Validadores", Summary: "Penalidades derivadas da cadeia",
+		PathParams: []apiParameter{validatorIDParam},
+		Query:      []apiParameter{{"active", "boolean", "apenas penalidades ativas"}},
+		Response:   handlers.ValidatorPenaltiesResponse{},
+	},
+	"POST /api/v1/validators": {
+		Tag: "Validadores", Summary: "Propor adição de validador",
+		Request: handlers.ProposeValidatorRequest{}, Response: handlers.ValidatorProposalSubmittedResponse{}, Status: http.StatusAccepted,
+	},
+	"DELETE /api/v1/validators/{id}": {
+		Tag: "Validadores", Summary: "Propor remoção de validador",
+		PathParams: []apiParameter{validatorIDParam},
+		Query:      []apiParameter{{"effective_height", "integer", "altura a partir da qual a remoção vale"}},
+		Response:   handlers.ValidatorProposalSubmittedResponse{}, Status: http.StatusAccepted,
+	},
+	"POST /api/v1/validators/proposals/{id}/approve": {
+		Tag: "Validadores", Summary: "Aprovar proposta",
+		PathParams: []apiParameter{proposalIDParam},
+		Response:   handlers.ProposalApprovedResponse{}, Status: http.StatusAccepted,
+	},
+	// Consenso
+	"GET /api/v1/consensus/status": {
+		Tag: "Consenso", Summary: "Status do consenso",
+		Response: handlers.ConsensusStatusResponse{},
+	},
+	"GET /api/v1/consensus/round": {
+		Tag: "Consenso", Summary: "Round atual e proposer",
+		Response: handlers.RoundInfoResponse{},
+	},
+	"POST /api/v1/consensus/start": {
+		Tag: "Consenso", Summary: "Iniciar consenso",
+		Response: handlers.ConsensusStartedResponse{},
+	},
+	"POST /api/v1/consensus/stop": {
+		Tag: "Consenso", Summary: "Parar consenso",
+		Response: handlers.OperationResponse{},
+	},
+	"PUT /api/v1/consensus/config": {
+		Tag: "Consenso", Summary: "Configurar consenso",
+		Request: handlers.ConfigureConsensusPayload{}, Response: handlers.OperationResponse{},
+	},
+
+	// Administração
+	"GET /api/v1/admin/log-levels": {
+		Tag: "Administração", Summary: "Níveis de log por componente",
+		Response: handlers.LogLevelsResponse{},
+	},
+	"PUT /api/v1/admin/log-levels": {
+		Tag: "Administração", Summary: "Alterar nível de log",
+		Description: "component vazio ou \"*\" altera o nível padrão e o de todos os componentes.",
+		Request:     handlers.SetLogLevelPayload{}, Response: handlers.LogLevelsResponse{},
+	},
+	"GET /api/v1/admin/status": {
+		Tag: "Administração", Summary: "Status consolidado do nó",
+		Description: "Cadeia, sincronização, peers com latência (ping a cada consulta), round e proposer, " +
+			"mempool e eleições. Seções de componentes indisponíveis são omitidas; falhas ao lê-las ficam em errors.",
+		Response: handlers.NodeOverviewResponse{},
+	},
+	"GET /api/v1/admin/chain/export": {
+		Tag: "Administração", Summary: "Exportar a cadeia",
+		Description: "Arquivo autodescritivo com o gênese, todos os blocos e o histórico do conjunto de validadores, " +
+			"verificável sem rede com peer-vote chain verify.",
+		Response: archive.Archive{},
+	},
+	"POST /api/v1/admin/chain/import": {
+		Tag: "Administração", Summary: "Importar a cadeia",
+		Description: "Verifica o arquivo (422 com o relatório se inválido) e inicializa a cadeia local a partir dele. " +
+			"Nós sem validadores configurados adotam o conjunto gênese do arquivo; blocos já presentes com o mesmo hash " +
+			"são ignorados e divergências retornam 409.",
+		Request: archive.Archive{}, Response: handlers.ChainImportResponse{},
+	},
+	"GET /api/v1/admin/chain/snapshots": {
+		Tag: "Administração", Summary: "Snapshots de estado",
+		Description: "Snapshots capturados nas alturas de checkpoint (eleições, apurações, validadores e penalidades), " +
+			"com os validadores que os assinaram. trusted indica assinatura por mais de 2/3 do conjunto gênese do nó.",
+		Response: handlers.SnapshotListResponse{},
+	},
+
+	// Informações
+	"GET /api/v1/info": {
+		Tag: "Informações", Summary: "Informações da API",
+		Response: APIInfoResponse{},
+	},
+	"GET " + OpenAPIPath: {
+		Tag: "Informações", Summary: "Especificação OpenAPI 3 da API",
+	},
+	"GET /": {
+		Tag: "Informações", Summary: "Documentação HTML", ContentType: "text/html",
+	},
+}
+
+// pageQueryParams acrescenta os parâmetros de paginação por cursor aos filtros da listagem
+func pageQueryParams(filters ...apiParameter) []apiParameter {
+	return append([]apiParameter{
+		{"limit", "integer", "itens por página (máximo 1000)"},
+		{"cursor", "string", "next_cursor da página anterior"},
+		{"order", "string", "asc (padrão) ou desc pela posição na cadeia"},
+	}, filters...)
+}
+
+// eventQueryParams são os parâmetros das assinaturas de eventos
+var eventQueryParams = []apiParameter{
+	{"election", "string", "filtra eventos por eleição"},
+	{"from_height", "integer", "retoma a partir da altura (replay)"},
+}
+
+// apiRoute é uma rota registrada no router com sua documentação
+type apiRoute struct {
+	Method    string
+	Template  string // Template do mux (ex: /api/v1/blocks/{index:[0-9]+})
+	Operation apiOperation
+	Roles     []auth.Role
+}
+
+// collectRoutes percorre o router e associa cada rota registrada à sua documentação
+func (s *Server) collectRoutes() ([]apiRoute, []string) {
+	var routes []apiRoute
+	var undocumented []string
+
+	s.router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Subrouters e rotas sem método não são operações
+			return nil
+		}
+
+		for _, method := range methods {
+			key := method + " " + s.operationTemplate(template)
+			operation, exists := apiOperations[key]
+			if !exists {
+				undocumented = append(undocumented, key)
+				operation = apiOperation{Summary: key}
+			}
+			roles := []auth.Role{}
+			if strings.HasPrefix(template, "/api/v1") {
+				roles = s.rolesFor(method, template)
+			}
+			routes = append(routes, apiRoute{
+				Method:    method,
+				Template:  template,
+				Operation: operation,
+				Roles:     roles,
+			})
+		}
+		return nil
+	})
+
+	return routes, undocumented
+}
+
+// operationTemplate normaliza o template das rotas com prefixo configurável para a chave de apiOperations
+func (s *Server) operationTemplate(template string) string {
+	if s.isHealthRoute(template) {
+		return DefaultHealthPath + strings.TrimPrefix(template, s.config.HealthPath)
+	}
+	return template
+}
+
+// buildOpenAPISpec gera a especificação OpenAPI 3 a partir das rotas registradas
+func (s *Server) buildOpenAPISpec() ([]byte, error) {
+	routes, undocumented := s.collectRoutes()
+	for _, key := range undocumented {
+		s.logger.Warn("route is not documented in the OpenAPI spec", "route", key)
+	}
+
+	schemas := newSchemaRegistry()
+	paths := make(map[string]map[string]interface{})
+
+	for _, route := range routes {
+		path, pathParams := openAPIPath(route.Template, route.Operation.PathParams)
+		if paths[path] == nil {
+			paths[path] = make(map[string]interface{})
+		}
+		paths[path][strings.ToLower(route.Method)] = s.openAPIOperation(route, pathParams, schemas)
+	}
+
+	tags := make([]map[string]interface{}, len(apiTags))
+	for i, tag := range apiTags {
+		tags[i] = map[string]interface{}{"name": tag.Name, "description": tag.Description}
+	}
+
+	spec := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Peer-Vote REST API",
+			"version":     "1.0.0",
+			"description": "API REST para sistema de votação descentralizado baseado em blockchain.",
+		},
+		"servers": []map[string]interface{}{{"url": s.GetAddress()}},
+		"tags":    tags,
+		"paths":   paths,
+		"components": map[string]interface{}{
+			"schemas": schemas.components,
+			"securitySchemes": map[string]interface{}{
+				"bearerAuth": map[string]interface{}{
+					"type":         "http",
+					"scheme":       "bearer",
+					"bearerFormat": "JWT",
+					"description":  "JWT HS256 (api.auth.jwt_secret) ou ES256 (api.auth.jwt_public_key) com claims role/roles e node_id.",
+				},
+				"adminToken": map[string]interface{}{
+					"type":        "apiKey",
+					"in":          "header",
+					"name":        "X-Admin-Token",
+					"description": "Token administrativo (api.auth.admin_token); também aceito como Bearer.",
+				},
+				"signedRequest": map[string]interface{}{
+					"type": "apiKey",
+					"in":   "header",
+					"name": auth.HeaderSignature,
+					"description": fmt.Sprintf("Requisição assinada com a chave do nó: %s, %s e %s acompanham %s, "+
+						"assinatura ECDSA (r || s, hex) de MÉTODO\\nURI\\nTIMESTAMP\\nSHA256(corpo).",
+						auth.HeaderNodeID, auth.HeaderPublicKey, auth.HeaderTimestamp, auth.HeaderSignature),
+				},
+			},
+		},
+	}
+
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+// openAPIOperation descreve uma operação no formato OpenAPI
+func (s *Server) openAPIOperation(route apiRoute, pathParams []apiParameter, schemas *schemaRegistry) map[string]interface{} {
+	op := route.Operation
+
+	operation := map[string]interface{}{
+		"summary":     op.Summary,
+		"operationId": operationID(route.Method, route.Template),
+	}
+	if op.Tag != "" {
+		operation["tags"] = []string{op.Tag}
+	}
+
+	description := op.Description
+	if len(route.Roles) > 0 {
+		roles := make([]string, len(route.Roles))
+		for i, role := range route.Roles {
+			roles[i] = string(role)
+		}
+		operation["x-roles"] = roles
+		description = strings.TrimSpace(description + " Papéis: " + joinRoles(route.Roles) + " (com api.auth.enabled).")
+		operation["security"] = []map[string][]string{
+			{"bearerAuth": {}},
+			{"adminToken": {}},
+			{"signedRequest": {}},
+		}
+	} else {
+		// Rota pública
+		operation["security"] = []map[string][]string{}
+	}
+	if description != "" {
+		operation["description"] = description
+	}
+
+	var parameters []map[string]interface{}
+	for _, param := range pathParams {
+		parameters = append(parameters, openAPIParameter(param, "path", true))
+	}
+	for _, param := range op.Query {
+		parameters = append(parameters, openAPIParameter(param, "query", false))
+	}
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if op.Request != nil {
+		operation["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(op.Request))},
+			},
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	contentType := op.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	success := map[string]interface{}{"description": http.StatusText(status)}
+	switch {
+	case op.Response != nil:
+		success["content"] = map[string]interface{}{
+			contentType: map[string]interface{}{"schema": schemas.schemaFor(reflect.TypeOf(op.Response))},
+		}
+	case contentType != "application/json" || route.Template == OpenAPIPath:
+		success["content"] = map[string]interface{}{
+			contentType: map[string]interface{}{"schema": map[string]interface{}{"type": openAPIBodyType(contentType)}},
+		}
+	}
+
+	errorResponse := map[string]interface{}{
+		"description": "Erro (mensagem em texto simples)",
+		"content": map[string]interface{}{
+			"text/plain": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+		},
+	}
+	responses := map[string]interface{}{
+		fmt.Sprintf("%d", status): success,
+		"default":                 errorResponse,
+	}
+	if len(route.Roles) > 0 {
+		responses["401"] = map[string]interface{}{"description": "Credenciais ausentes ou inválidas"}
+		responses["403"] = map[string]interface{}{"description": "Papel insuficiente ou NodeID diferente da credencial"}
+	}
+	operation["responses"] = responses
+
+	return operation
+}
+
+// openAPIBodyType retorna o tipo do corpo de respostas sem schema
+func openAPIBodyType(contentType string) string {
+	if contentType == "application/json" {
+		return "object"
+	}
+	return "string"
+}
+
+// pathVariablePattern captura variáveis do template do mux ({nome} ou {nome:regex})
+var pathVariablePattern = regexp.MustCompile(`\{([^}:]+)(?::([^}]+))?\}`)
+
+// openAPIPath converte o template do mux para o formato OpenAPI e resolve os parâmetros de caminho
+func openAPIPath(template string, documented []apiParameter) (string, []apiParameter) {
+	var params []apiParameter
+	path := pathVariablePattern.ReplaceAllStringFunc(template, func(match string) string {
+		groups := pathVariablePattern.FindStringSubmatch(match)
+		param := apiParameter{Name: groups[1], Type: "string"}
+		for _, doc := range documented {
+			if doc.Name == param.Name {
+				param = doc
+			}
+		}
+		params = append(params, param)
+		return "{" + groups[1] + "}"
+	})
+	return path, params
+}
+
+// openAPIParameter descreve um parâmetro no formato OpenAPI
+func openAPIParameter(param apiParameter, in string, required bool) map[string]interface{} {
+	paramType := param.Type
+	if paramType == "" {
+		paramType = "string"
+	}
+
+	parameter := map[string]interface{}{
+		"name":     param.Name,
+		"in":       in,
+		"required": required,
+		"schema":   map[string]interface{}{"type": paramType},
+	}
+	if param.Description != "" {
+		parameter["description"] = param.Description
+	}
+	return parameter
+}
+
+// operationID gera um identificador estável para a operação (ex: get_api_v1_blocks_index)
+func operationID(method, template string) string {
+	path := pathVariablePattern.ReplaceAllString(template, "$1")
+	id := strings.ToLower(method) + strings.NewReplacer("/", "_", ".", "_", "-", "_").Replace(path)
+	return strings.TrimSuffix(id, "_")
+}
+
+// schemaRegistry gera schemas JSON a partir dos tipos Go das requisições e respostas
+type schemaRegistry struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+}
+
+// newSchemaRegistry cria um registro de schemas vazio
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		components: make(map[string]interface{}),
+		names:      make(map[reflect.Type]string),
+	}
+}
+
+// jsonRepresentations mapeia entidades com MarshalJSON para o tipo que descreve seu JSON
+var jsonRepresentations = map[reflect.Type]reflect.Type{
+	reflect.TypeOf(entities.Election{}): reflect.TypeOf(entities.ElectionData{}),
+	reflect.TypeOf(entities.Vote{}):     reflect.TypeOf(entities.VoteData{}),
+}
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// schemaFor retorna o schema do tipo (structs viram componentes referenciados)
+func (r *schemaRegistry) schemaFor(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if representation, exists := jsonRepresentations[t]; exists {
+		t = representation
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		// Hash e NodeID são serializados como texto (hexadecimal)
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": r.schemaFor(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": r.schemaFor(t.Elem())}
+	case reflect.Struct:
+		return map[string]interface{}{"$ref": "#/components/schemas/" + r.register(t)}
+	}
+
+	// interface{}: qualquer valor JSON
+	return map[string]interface{}{}
+}
+
+// register adiciona o schema de uma struct aos componentes e retorna seu nome
+func (r *schemaRegistry) register(t reflect.Type) string {
+	if name, exists := r.names[t]; exists {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := r.components[name]; taken || name == "" {
+		// Mesmo nome em pacotes diferentes (ex: handlers e usecases)
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+
+	// Reservar o nome antes dos campos para suportar tipos recursivos
+	r.names[t] = name
+	r.components[name] = map[string]interface{}{}
+
+	properties := make(map[string]interface{})
+	var required []string
+	r.collectFields(t, properties, &required)
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		schema["required"] = required
+	}
+	r.components[name] = schema
+
+	return name
+}
+
+// collectFields adiciona os campos JSON da struct (incluindo structs embutidas)
+func (r *schemaRegistry) collectFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			r.collectFields(field.Type, properties, required)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = r.schemaFor(field.Type)
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
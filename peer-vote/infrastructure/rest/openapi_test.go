@@ -0,0 +1,386 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/matscats/peer-vote/peer-vote/application/usecases"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/crypto"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/persistence"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/auth"
+)
+
+const testAdminToken = "openapi-test-token"
+
+// testNode é um nó em memória com um único validador e o servidor REST
+type testNode struct {
+	server       *Server
+	chainManager *blockchain.ChainManager
+	mempool      *consensus.Mempool
+	keyPair      *services.KeyPair
+	validator    valueobjects.NodeID
+	spec         map[string]interface{}
+}
+
+// newTestNode monta as dependências do servidor como o comando start, sem P2P
+func newTestNode(t *testing.T) *testNode {
+	t.Helper()
+	ctx := context.Background()
+
+	cryptoService := crypto.NewECDSAService()
+	blockchainRepo := persistence.NewMemoryBlockchainRepository(cryptoService)
+	chainManager := blockchain.NewChainManager(blockchainRepo, cryptoService)
+
+	keyPair, err := cryptoService.GenerateKeyPair(ctx)
+	if err != nil {
+		t.Fatalf("generate key pair: %v", err)
+	}
+	myNodeID := cryptoService.GenerateNodeID(ctx, keyPair.PublicKey)
+
+	validatorManager := consensus.NewValidatorManager()
+	if err := validatorManager.AddValidator(ctx, myNodeID, keyPair.PublicKey); err != nil {
+		t.Fatalf("add validator: %v", err)
+	}
+	poaEngine := consensus.NewPoAEngine(validatorManager, chainManager, cryptoService, myNodeID, keyPair.PrivateKey, nil)
+	consensusService := consensus.NewConsensusAdapter(poaEngine)
+	mempool := poaEngine.GetMempool()
+
+	validatorGovernance := consensus.NewValidatorGovernance(validatorManager, chainManager, cryptoService)
+	penaltySystem := consensus.NewPenaltySystem(validatorManager)
+
+	validationService := services.NewVotingValidator(nil)
+	blockchainService := blockchain.NewBlockchainAdapter(chainManager)
+
+	createElectionUseCase := usecases.NewCreateElectionUseCase(cryptoService, validationService, blockchainService, consensusService)
+	createElectionUseCase.SetNodeKey(keyPair.PrivateKey)
+	templateRepository, err := persistence.NewFileElectionTemplateRepository(t.TempDir() + "/election_templates.json")
+	if err != nil {
+		t.Fatalf("template repository: %v", err)
+	}
+	consensusManagerUseCase := usecases.NewConsensusManagerUseCase(consensusService, validatorManager, penaltySystem, validatorGovernance, chainManager, cryptoService)
+	consensusManagerUseCase.SetNodeKey(myNodeID, keyPair.PrivateKey)
+
+	server := NewServer(&ServerConfig{
+		Host:       "localhost",
+		Port:       8080,
+		Auth:       &auth.Config{AdminToken: testAdminToken},
+		HealthPath: DefaultHealthPath,
+	}, &Dependencies{
+		CreateElectionUseCase:    createElectionUseCase,
+		ElectionTemplateUseCase:  usecases.NewElectionTemplateUseCase(templateRepository, createElectionUseCase),
+		ManageElectionUseCase:    usecases.NewManageElectionUseCase(validationService, chainManager),
+		SubmitVoteUseCase:        usecases.NewSubmitVoteUseCase(blockchainService, consensusService, cryptoService, validationService),
+		AuditVotesUseCase:        usecases.NewAuditVotesUseCase(chainManager, cryptoService, validationService),
+		ConsensusManagerUseCase:  consensusManagerUseCase,
+		TransactionStatusUseCase: usecases.NewTransactionStatusUseCase(chainManager, mempool),
+		Mempool:                  mempool,
+		BlockchainRepository:     blockchainRepo,
+		NodeID:                   myNodeID,
+		ChainManager:             chainManager,
+		CryptoService:            cryptoService,
+		ValidatorManager:         validatorManager,
+	})
+
+	var spec map[string]interface{}
+	if err := json.Unmarshal(server.OpenAPISpec(), &spec); err != nil {
+		t.Fatalf("OpenAPI spec is not valid JSON: %v", err)
+	}
+
+	return &testNode{
+		server:       server,
+		chainManager: chainManager,
+		mempool:      mempool,
+		keyPair:      keyPair,
+		validator:    myNodeID,
+		spec:         spec,
+	}
+}
+
+// sealGenesis inclui as transações pendentes no bloco gênesis
+func (n *testNode) sealGenesis(t *testing.T) {
+	t.Helper()
+
+	if err := n.chainManager.CreateGenesisBlock(context.Background(), n.mempool.Select(1000), n.validator, n.keyPair.PrivateKey); err != nil {
+		t.Fatalf("create genesis block: %v", err)
+	}
+}
+
+// call executa a requisição no router e valida a resposta com o schema documentado da rota
+func (n *testNode) call(t *testing.T, method, path string, body interface{}) interface{} {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("marshal body: %v", err)
+		}
+		reader = bytes.NewReader(payload)
+	} else {
+		reader = bytes.NewReader(nil)
+	}
+
+	// Sem consenso rodando, escritas não esperam a inclusão em bloco
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(method, path, reader).WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Admin-Token", testAdminToken)
+
+	var match mux.RouteMatch
+	if !n.server.router.Match(req, &match) || match.Route == nil {
+		t.Fatalf("%s %s: no route", method, path)
+	}
+	template, err := match.Route.GetPathTemplate()
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	specPath, _ := openAPIPath(template, nil)
+
+	recorder := httptest.NewRecorder()
+	n.server.router.ServeHTTP(recorder, req)
+
+	operation, ok := lookup(n.spec, "paths", specPath, strings.ToLower(method)).(map[string]interface{})
+	if !ok {
+		t.Fatalf("%s %s: operation not documented", method, specPath)
+	}
+	if recorder.Code >= http.StatusBadRequest {
+		t.Fatalf("%s %s: unexpected status %d (body: %s)", method, path, recorder.Code, strings.TrimSpace(recorder.Body.String()))
+	}
+	responses := operation["responses"].(map[string]interface{})
+	documented, ok := responses[strconv.Itoa(recorder.Code)].(map[string]interface{})
+	if !ok {
+		t.Fatalf("%s %s: status %d not documented", method, path, recorder.Code)
+	}
+
+	schema, ok := lookup(documented, "content", "application/json", "schema").(map[string]interface{})
+	if !ok {
+		if recorder.Body.Len() > 0 {
+			t.Fatalf("%s %s: response body not documented", method, path)
+		}
+		return nil
+	}
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/json") {
+		t.Errorf("%s %s: Content-Type %q, documented application/json", method, path, contentType)
+	}
+
+	decoder := json.NewDecoder(recorder.Body)
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		t.Fatalf("%s %s: invalid JSON response: %v", method, path, err)
+	}
+
+	validator := &schemaValidator{components: lookup(n.spec, "components", "schemas").(map[string]interface{})}
+	validator.validate(schema, value, "$")
+	for _, problem := range validator.problems {
+		t.Errorf("%s %s: %s", method, path, problem)
+	}
+
+	return value
+}
+
+// lookup percorre mapas JSON aninhados
+func lookup(value interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+// schemaValidator confere um valor JSON com o subconjunto de JSON Schema gerado por schemaRegistry.
+// Propriedades não documentadas também são apontadas, para que a especificação acompanhe as respostas.
+type schemaValidator struct {
+	components map[string]interface{}
+	problems   []string
+}
+
+func (v *schemaValidator) fail(path, format string, args ...interface{}) {
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+func (v *schemaValidator) validate(schema map[string]interface{}, value interface{}, path string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		component, ok := v.components[name].(map[string]interface{})
+		if !ok {
+			v.fail(path, "unknown schema %s", ref)
+			return
+		}
+		v.validate(component, value, path)
+		return
+	}
+
+	switch schema["type"] {
+	case nil:
+		// Qualquer valor JSON
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			v.fail(path, "expected object, got %s", jsonKind(value))
+			return
+		}
+		properties, _ := schema["properties"].(map[string]interface{})
+		required, _ := schema["required"].([]interface{})
+		for _, name := range required {
+			if _, exists := object[name.(string)]; !exists {
+				v.fail(path, "missing required property %q", name)
+			}
+		}
+		additional, hasAdditional := schema["additionalProperties"].(map[string]interface{})
+		for name, field := range object {
+			if propertySchema, ok := properties[name].(map[string]interface{}); ok {
+				v.validate(propertySchema, field, path+"."+name)
+			} else if hasAdditional {
+				v.validate(additional, field, path+"."+name)
+			} else {
+				v.fail(path, "undocumented property %q", name)
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			v.fail(path, "expected array, got %s", jsonKind(value))
+			return
+		}
+		items, _ := schema["items"].(map[string]interface{})
+		for i, item := range array {
+			v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			v.fail(path, "expected string, got %s", jsonKind(value))
+			return
+		}
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, text); err != nil {
+				v.fail(path, "invalid date-time %q", text)
+			}
+		}
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok || strings.ContainsAny(number.String(), ".eE") {
+			v.fail(path, "expected integer, got %s", jsonKind(value))
+			return
+		}
+		if _, hasMinimum := schema["minimum"]; hasMinimum && strings.HasPrefix(number.String(), "-") {
+			v.fail(path, "expected non-negative integer, got %s", number)
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			v.fail(path, "expected number, got %s", jsonKind(value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			v.fail(path, "expected boolean, got %s", jsonKind(value))
+		}
+	default:
+		v.fail(path, "unsupported schema type %v", schema["type"])
+	}
+}
+
+// jsonKind descreve o tipo de um valor JSON decodificado
+func jsonKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// TestOpenAPIResponsesMatchSchemas confere as respostas reais das rotas principais com a especificação
+func TestOpenAPIResponsesMatchSchemas(t *testing.T) {
+	node := newTestNode(t)
+	start := time.Now().Add(-time.Minute).UTC()
+
+	// Eleição criada pela API (pendente) e incluída no bloco gênesis
+	created := node.call(t, http.MethodPost, "/api/v1/elections", map[string]interface{}{
+		"title":       "Eleição de teste",
+		"description": "Conferência da especificação",
+		"candidates": []map[string]string{
+			{"id": "a", "name": "Alice"},
+			{"id": "b", "name": "Bob"},
+		},
+		"start_time":          start.Format(time.RFC3339),
+		"end_time":            start.Add(time.Hour).Format(time.RFC3339),
+		"created_by":          node.validator.String(),
+		"max_votes_per_voter": 1,
+	})
+	electionID, _ := lookup(created, "election", "id").(string)
+	txHash, _ := lookup(created, "transaction_hash").(string)
+	if electionID == "" || txHash == "" {
+		t.Fatalf("create election response has no election id or transaction hash: %v", created)
+	}
+	node.sealGenesis(t)
+
+	// Modelos de eleição
+	node.call(t, http.MethodPost, "/api/v1/elections/candidates/import", map[string]string{
+		"csv": "id,name\na,Alice\nb,Bob\n",
+	})
+	node.call(t, http.MethodPost, "/api/v1/election-templates", map[string]interface{}{
+		"name":             "padrao",
+		"duration_minutes": 60,
+	})
+	node.call(t, http.MethodGet, "/api/v1/election-templates", nil)
+	node.call(t, http.MethodGet, "/api/v1/election-templates/padrao", nil)
+
+	routes := []struct {
+		method string
+		path   string
+	}{
+		{http.MethodGet, "/api/v1/info"},
+		{http.MethodGet, "/api/v1/elections"},
+		{http.MethodGet, "/api/v1/elections/" + electionID},
+		{http.MethodGet, "/api/v1/elections/" + electionID + "/results"},
+		{http.MethodGet, "/api/v1/votes/audit/" + electionID},
+		{http.MethodGet, "/api/v1/votes/count/" + electionID},
+		{http.MethodGet, "/api/v1/blocks"},
+		{http.MethodGet, "/api/v1/blocks/0"},
+		{http.MethodGet, "/api/v1/blocks/latest"},
+		{http.MethodGet, "/api/v1/chain/status"},
+		{http.MethodGet, "/api/v1/chain/validate"},
+		{http.MethodGet, "/api/v1/mempool"},
+		{http.MethodGet, "/api/v1/validators"},
+		{http.MethodGet, "/api/v1/validators/" + node.validator.String()},
+		{http.MethodGet, "/api/v1/validators/" + node.validator.String() + "/penalties"},
+		{http.MethodGet, "/api/v1/validators/proposals"},
+		{http.MethodGet, "/api/v1/consensus/status"},
+		{http.MethodGet, "/api/v1/consensus/round"},
+	}
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			node.call(t, route.method, route.path, nil)
+		})
+	}
+
+	// Transação da eleição, já incluída no gênesis
+	node.call(t, http.MethodGet, "/api/v1/transactions/"+txHash, nil)
+}
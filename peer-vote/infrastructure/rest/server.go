@@ -1,19 +1,33 @@
 package rest
 
 import (
+	"bufio"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"log"
+	"html"
+	"log/slog"
+	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/matscats/peer-vote/peer-vote/application/usecases"
 	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
 	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/events"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/health"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/metrics"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/auth"
 	"github.com/matscats/peer-vote/peer-vote/infrastructure/rest/handlers"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/snapshot"
 )
 
 // ServerConfig representa a configuração do servidor REST
@@ -23,6 +37,8 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration `json:"read_timeout"`
 	WriteTimeout time.Duration `json:"write_timeout"`
 	IdleTimeout  time.Duration `json:"idle_timeout"`
+	Auth         *auth.Config  `json:"-"`           // Credenciais aceitas (nil: apenas leitura e rotas abertas)
+	HealthPath   string        `json:"health_path"` // Prefixo de /live e /ready (usado quando há Dependencies.Health)
 }
 
 // DefaultServerConfig retorna configuração padrão do servidor
@@ -33,37 +49,92 @@ func DefaultServerConfig() *ServerConfig {
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
+		HealthPath:   DefaultHealthPath,
 	}
 }
 
+// DefaultHealthPath é o prefixo padrão das verificações de liveness e readiness
+const DefaultHealthPath = "/health"
+
 // Server representa o servidor REST da API
 type Server struct {
-	config     *ServerConfig
-	httpServer *http.Server
-	router     *mux.Router
+	config        *ServerConfig
+	httpServer    *http.Server
+	router        *mux.Router
+	authenticator *auth.Authenticator
+
+	// Papéis exigidos por rota ("MÉTODO template"), aplicados com api.auth.enabled
+	routeRoles map[string][]auth.Role
+
+	// Especificação OpenAPI gerada a partir das rotas registradas
+	openAPISpec []byte
+
+	// Métricas de latência por rota (nil desabilita)
+	metrics *metrics.Metrics
+
+	logger *slog.Logger
 
 	// Handlers
-	electionHandler   *handlers.ElectionHandler
-	voteHandler       *handlers.VoteHandler
-	blockchainHandler *handlers.BlockchainHandler
-	nodeHandler       *handlers.NodeHandler
+	electionHandler    *handlers.ElectionHandler
+	templateHandler    *handlers.ElectionTemplateHandler
+	voteHandler        *handlers.VoteHandler
+	blockchainHandler  *handlers.BlockchainHandler
+	nodeHandler        *handlers.NodeHandler
+	validatorHandler   *handlers.ValidatorHandler
+	consensusHandler   *handlers.ConsensusHandler
+	mempoolHandler     *handlers.MempoolHandler
+	transactionHandler *handlers.TransactionHandler
+	eventsHandler      *handlers.EventsHandler
+	loggingHandler     *handlers.LoggingHandler
+	statusHandler      *handlers.StatusHandler
+	archiveHandler     *handlers.ChainArchiveHandler
+	snapshotHandler    *handlers.SnapshotHandler
+	certificateHandler *handlers.CertificateHandler
+	reportHandler      *handlers.ReportHandler
+	healthHandler      *handlers.HealthHandler
 }
 
 // Dependencies representa as dependências necessárias para o servidor
 type Dependencies struct {
 	// Use Cases
-	CreateElectionUseCase *usecases.CreateElectionUseCase
-	ManageElectionUseCase *usecases.ManageElectionUseCase
-	SubmitVoteUseCase     *usecases.SubmitVoteUseCase
-	AuditVotesUseCase     *usecases.AuditVotesUseCase
+	CreateElectionUseCase    *usecases.CreateElectionUseCase
+	ElectionTemplateUseCase  *usecases.ElectionTemplateUseCase
+	ManageElectionUseCase    *usecases.ManageElectionUseCase
+	SubmitVoteUseCase        *usecases.SubmitVoteUseCase
+	AuditVotesUseCase        *usecases.AuditVotesUseCase
+	ConsensusManagerUseCase  *usecases.ConsensusManagerUseCase
+	TransactionStatusUseCase *usecases.TransactionStatusUseCase
+	CertifyResultsUseCase    *usecases.CertifyResultsUseCase // nil informa que a certificação está indisponível
 
 	// Repositories
 	BlockchainRepository repositories.BlockchainRepository
 
+	// Identidade do nó
+	NodeID valueobjects.NodeID
+
 	// Services
-	NetworkService  services.NetworkService
-	ChainManager    *blockchain.ChainManager
-	CryptoService   services.CryptographyService
+	Mempool        *consensus.Mempool
+	EventHub       *events.EventHub
+	NetworkService services.NetworkService
+	PeerStatus     handlers.PeerStatusSource // Peers com ping e sincronização para /admin/status (nil omite)
+	ChainManager   *blockchain.ChainManager
+	CryptoService  services.CryptographyService
+
+	// Conjunto gênese e histórico de validadores para exportar e importar a cadeia (nil desabilita)
+	ValidatorManager *consensus.ValidatorManager
+
+	// Snapshots de estado mantidos pelo nó (nil informa que estão desabilitados)
+	Snapshots *snapshot.Manager
+
+	// Métricas de latência por rota (nil desabilita)
+	Metrics *metrics.Metrics
+
+	// Logs: logger do servidor e controle dos níveis por componente (nil desabilita o endpoint)
+	Logger    *slog.Logger
+	LogLevels handlers.LogLevelController
+
+	// Verificações de liveness e readiness (nil desabilita <HealthPath>/live e /ready)
+	Health *health.Checker
 }
 
 // NewServer cria um novo servidor REST
@@ -81,6 +152,10 @@ func NewServer(config *ServerConfig, deps *Dependencies) *Server {
 		deps.ManageElectionUseCase,
 	)
 
+	templateHandler := handlers.NewElectionTemplateHandler(
+		deps.ElectionTemplateUseCase,
+	)
+
 	voteHandler := handlers.NewVoteHandler(
 		deps.SubmitVoteUseCase,
 		deps.AuditVotesUseCase,
@@ -96,15 +171,91 @@ func NewServer(config *ServerConfig, deps *Dependencies) *Server {
 		deps.NetworkService,
 	)
 
+	mempoolHandler := handlers.NewMempoolHandler(
+		deps.Mempool,
+	)
+
+	transactionHandler := handlers.NewTransactionHandler(
+		deps.TransactionStatusUseCase,
+	)
+
+	eventsHandler := handlers.NewEventsHandler(
+		deps.EventHub,
+	)
+
+	certificateHandler := handlers.NewCertificateHandler(
+		deps.CertifyResultsUseCase,
+	)
+
+	reportHandler := handlers.NewReportHandler(
+		deps.AuditVotesUseCase,
+	)
+
 	server := &Server{
-		config:            config,
-		router:            router,
-		electionHandler:   electionHandler,
-		voteHandler:       voteHandler,
-		blockchainHandler: blockchainHandler,
-		nodeHandler:       nodeHandler,
+		config:             config,
+		router:             router,
+		authenticator:      auth.NewAuthenticator(config.Auth, deps.CryptoService),
+		metrics:            deps.Metrics,
+		logger:             deps.Logger,
+		electionHandler:    electionHandler,
+		templateHandler:    templateHandler,
+		voteHandler:        voteHandler,
+		blockchainHandler:  blockchainHandler,
+		nodeHandler:        nodeHandler,
+		mempoolHandler:     mempoolHandler,
+		transactionHandler: transactionHandler,
+		eventsHandler:      eventsHandler,
+		certificateHandler: certificateHandler,
+		reportHandler:      reportHandler,
+	}
+
+	if server.logger == nil {
+		server.logger = logging.Component("rest")
 	}
 
+	if deps.Health != nil {
+		if config.HealthPath == "" {
+			config.HealthPath = DefaultHealthPath
+		}
+		server.healthHandler = handlers.NewHealthHandler(deps.Health, config.HealthPath)
+		nodeHandler.SetHealthChecker(deps.Health)
+	}
+
+	// Handlers com endpoints administrativos
+	server.validatorHandler = handlers.NewValidatorHandler(
+		deps.ConsensusManagerUseCase,
+		server.adminOnly,
+	)
+
+	server.consensusHandler = handlers.NewConsensusHandler(
+		deps.ConsensusManagerUseCase,
+		server.adminOnly,
+	)
+
+	server.loggingHandler = handlers.NewLoggingHandler(
+		deps.LogLevels,
+		server.adminOnly,
+	)
+
+	server.statusHandler = handlers.NewStatusHandler(handlers.NodeStatusSources{
+		NodeID:    deps.NodeID,
+		Chain:     deps.ChainManager,
+		Consensus: deps.ConsensusManagerUseCase,
+		Mempool:   deps.Mempool,
+		Elections: deps.ManageElectionUseCase,
+		Peers:     deps.PeerStatus,
+	}, server.adminOnly)
+
+	server.archiveHandler = handlers.NewChainArchiveHandler(
+		deps.NodeID,
+		deps.ChainManager,
+		deps.ValidatorManager,
+		deps.CryptoService,
+		server.adminOnly,
+	)
+
+	server.snapshotHandler = handlers.NewSnapshotHandler(deps.Snapshots, server.adminOnly)
+
 	// Configurar rotas
 	server.setupRoutes()
 
@@ -123,29 +274,88 @@ func NewServer(config *ServerConfig, deps *Dependencies) *Server {
 // setupRoutes configura todas as rotas da API
 func (s *Server) setupRoutes() {
 	// Middleware global
+	s.router.Use(s.requestIDMiddleware)
 	s.router.Use(s.loggingMiddleware)
+	s.router.Use(s.metricsMiddleware)
 	s.router.Use(s.corsMiddleware)
 	s.router.Use(s.contentTypeMiddleware)
 
 	// API versioning
 	api := s.router.PathPrefix("/api/v1").Subrouter()
+	api.Use(s.authMiddleware)
+
+	// Autorização por rota. Rotas ausentes exigem observer para leitura e admin para escrita;
+	// rotas com lista vazia são públicas.
+	s.routeRoles = map[string][]auth.Role{
+		"GET /api/v1/info":         {},
+		"GET /api/v1/nodes/health": {},
+		"GET " + OpenAPIPath:       {},
+
+		"POST /api/v1/elections":                           {auth.RoleElectionOfficer},
+		"PUT /api/v1/elections/{id}/status":                {auth.RoleElectionOfficer},
+		"POST /api/v1/elections/{id}/certify":              {auth.RoleElectionOfficer},
+		"POST /api/v1/elections/candidates/import":         {auth.RoleElectionOfficer},
+		"POST /api/v1/election-templates":                  {auth.RoleElectionOfficer},
+		"PUT /api/v1/election-templates/{name}":            {auth.RoleElectionOfficer},
+		"DELETE /api/v1/election-templates/{name}":         {auth.RoleElectionOfficer},
+		"POST /api/v1/election-templates/{name}/elections": {auth.RoleElectionOfficer},
+		"POST /api/v1/votes":                               {auth.RoleVoter},
+
+		"POST /api/v1/validators":                        {auth.RoleAdmin},
+		"DELETE /api/v1/validators/{id}":                 {auth.RoleAdmin},
+		"POST /api/v1/validators/proposals/{id}/approve": {auth.RoleAdmin},
+		"POST /api/v1/consensus/start":                   {auth.RoleAdmin},
+		"POST /api/v1/consensus/stop":                    {auth.RoleAdmin},
+		"PUT /api/v1/consensus/config":                   {auth.RoleAdmin},
+		"GET /api/v1/admin/log-levels":                   {auth.RoleAdmin},
+		"PUT /api/v1/admin/log-levels":                   {auth.RoleAdmin},
+		"GET /api/v1/admin/status":                       {auth.RoleAdmin},
+		"GET /api/v1/admin/chain/export":                 {auth.RoleAdmin},
+		"POST /api/v1/admin/chain/import":                {auth.RoleAdmin},
+		"GET /api/v1/admin/chain/snapshots":              {auth.RoleAdmin},
+	}
 
 	// Registrar rotas dos handlers
 	s.electionHandler.RegisterRoutes(api)
+	s.templateHandler.RegisterRoutes(api)
+	s.certificateHandler.RegisterRoutes(api)
+	s.reportHandler.RegisterRoutes(api)
 	s.voteHandler.RegisterRoutes(api)
 	s.blockchainHandler.RegisterRoutes(api)
 	s.nodeHandler.RegisterRoutes(api)
+	s.validatorHandler.RegisterRoutes(api)
+	s.consensusHandler.RegisterRoutes(api)
+	s.mempoolHandler.RegisterRoutes(api)
+	s.transactionHandler.RegisterRoutes(api)
+	s.eventsHandler.RegisterRoutes(api)
+	s.loggingHandler.RegisterRoutes(api)
+	s.statusHandler.RegisterRoutes(api)
+	s.archiveHandler.RegisterRoutes(api)
+	s.snapshotHandler.RegisterRoutes(api)
 
 	// Rota de informações da API
 	api.HandleFunc("/info", s.getAPIInfo).Methods("GET")
+	api.HandleFunc("/openapi.json", s.getOpenAPISpec).Methods("GET")
 
 	// Rota de documentação
 	s.router.HandleFunc("/", s.getDocumentation).Methods("GET")
+
+	// Liveness e readiness fora de /api/v1 (sem autenticação) para orquestradores
+	if s.healthHandler != nil {
+		s.healthHandler.RegisterRoutes(s.router)
+	}
+
+	// Especificação gerada depois de todas as rotas registradas
+	spec, err := s.buildOpenAPISpec()
+	if err != nil {
+		s.logger.Error("failed to build OpenAPI spec", logging.Err(err))
+	}
+	s.openAPISpec = spec
 }
 
 // Start inicia o servidor REST
 func (s *Server) Start(ctx context.Context) error {
-	log.Printf("🚀 Starting REST API server on %s:%d", s.config.Host, s.config.Port)
+	s.logger.InfoContext(ctx, "starting REST API server", "address", s.httpServer.Addr)
 
 	// Canal para capturar erros do servidor
 	errChan := make(chan error, 1)
@@ -160,7 +370,7 @@ func (s *Server) Start(ctx context.Context) error {
 	// Aguardar contexto cancelado ou erro
 	select {
 	case <-ctx.Done():
-		log.Println("🛑 Shutting down REST API server...")
+		s.logger.Info("shutting down REST API server")
 		return s.Stop()
 	case err := <-errChan:
 		return err
@@ -176,7 +386,7 @@ func (s *Server) Stop() error {
 		return fmt.Errorf("failed to shutdown server: %w", err)
 	}
 
-	log.Println("✅ REST API server stopped successfully")
+	s.logger.Info("REST API server stopped")
 	return nil
 }
 
@@ -185,23 +395,156 @@ func (s *Server) GetAddress() string {
 	return fmt.Sprintf("http://%s:%d", s.config.Host, s.config.Port)
 }
 
+// OpenAPISpec retorna a especificação OpenAPI 3 (JSON) das rotas registradas
+func (s *Server) OpenAPISpec() []byte {
+	return s.openAPISpec
+}
+
 // Middlewares
 
-// loggingMiddleware adiciona logging das requisições
+// requestIDHeader carrega o ID de correlação da requisição
+const requestIDHeader = "X-Request-ID"
+
+// requestIDMiddleware associa um ID à requisição (o recebido em X-Request-ID ou um novo),
+// devolvido no cabeçalho da resposta e presente em todos os logs feitos com o contexto
+func (s *Server) requestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		w.Header().Set(requestIDHeader, requestID)
+		next.ServeHTTP(w, r.WithContext(logging.WithRequestID(r.Context(), requestID)))
+	})
+}
+
+// validRequestID aceita IDs curtos de caracteres visíveis (evita injeção nos logs)
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > 128 {
+		return false
+	}
+	for _, c := range requestID {
+		if c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID gera um ID aleatório de 16 bytes em hexadecimal
+func newRequestID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// loggingMiddleware registra cada requisição com método, rota, status e duração
 func (s *Server) loggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		next.ServeHTTP(w, r)
-		log.Printf("📡 %s %s - %v", r.Method, r.URL.Path, time.Since(start))
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		route := r.URL.Path
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case s.isHealthRoute(route):
+			// Sondas periódicas de orquestradores (mudanças de estado são registradas pelo health.Checker)
+			level = slog.LevelDebug
+		case recorder.status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		}
+		s.logger.Log(r.Context(), level, "http request",
+			"method", r.Method,
+			"route", route,
+			"path", r.URL.Path,
+			"status", recorder.status,
+			"duration_ms", time.Since(start).Milliseconds(),
+		)
 	})
 }
 
+// metricsMiddleware registra a latência das requisições pelo template da rota.
+// Rotas de streaming (SSE e WebSocket) ficam de fora: a duração é a da assinatura.
+func (s *Server) metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := mux.CurrentRoute(r)
+		if s.metrics == nil || route == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		template, err := route.GetPathTemplate()
+		if err != nil || isStreamingRoute(r.Method+" "+template) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		s.metrics.ObserveHTTPRequest(r.Method, template, recorder.status, time.Since(start))
+	})
+}
+
+// isStreamingRoute verifica se a rota ("MÉTODO template") mantém a conexão aberta
+func isStreamingRoute(key string) bool {
+	operation, exists := apiOperations[key]
+	return exists && (operation.ContentType == "text/event-stream" || operation.Status == http.StatusSwitchingProtocols)
+}
+
+// isHealthRoute indica se o template é uma rota de liveness ou readiness
+func (s *Server) isHealthRoute(template string) bool {
+	return s.healthHandler != nil && strings.HasPrefix(template, s.config.HealthPath+"/")
+}
+
+// statusRecorder guarda o status HTTP escrito pelo handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader registra o status e o repassa
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap expõe o ResponseWriter original (http.ResponseController)
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Flush repassa o flush para respostas em streaming (SSE)
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack repassa o controle da conexão (WebSocket)
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
 // corsMiddleware adiciona headers CORS
 func (s *Server) corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Admin-Token, X-Node-ID, X-Public-Key, X-Timestamp, X-Signature, X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -221,30 +564,177 @@ func (s *Server) contentTypeMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// authMiddleware autentica a requisição e aplica os papéis exigidos pela rota.
+// Com a autenticação desabilitada, as rotas ficam abertas (exceto as administrativas).
+func (s *Server) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authenticator.Enabled() || r.Method == "OPTIONS" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		roles := s.requiredRoles(r)
+		if len(roles) == 0 {
+			// Rota pública
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		identity, ok := s.authenticate(w, r)
+		if !ok {
+			return
+		}
+
+		if !identity.HasRole(roles...) {
+			http.Error(w, fmt.Sprintf("Forbidden: requires role %s", joinRoles(roles)), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	})
+}
+
+// adminOnly restringe um endpoint a identidades com papel admin (token administrativo,
+// JWT ou requisição assinada por um NodeID configurado como admin)
+func (s *Server) adminOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.authenticator.Enabled() && !s.authenticator.AdminTokenConfigured() {
+			http.Error(w, "Admin endpoints are disabled (api.auth.admin_token not set)", http.StatusForbidden)
+			return
+		}
+
+		identity, ok := auth.FromContext(r.Context())
+		if !ok {
+			if identity, ok = s.authenticate(w, r); !ok {
+				return
+			}
+		}
+
+		if !identity.HasRole(auth.RoleAdmin) {
+			http.Error(w, "Admin role required", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), identity)))
+	})
+}
+
+// authenticate resolve a identidade da requisição, respondendo 401 em caso de falha
+func (s *Server) authenticate(w http.ResponseWriter, r *http.Request) (*auth.Identity, bool) {
+	identity, err := s.authenticator.Authenticate(r)
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="peer-vote"`)
+		http.Error(w, "Unauthorized: "+err.Error(), http.StatusUnauthorized)
+		return nil, false
+	}
+	return identity, true
+}
+
+// requiredRoles retorna os papéis exigidos pela rota da requisição
+func (s *Server) requiredRoles(r *http.Request) []auth.Role {
+	template := ""
+	if route := mux.CurrentRoute(r); route != nil {
+		template, _ = route.GetPathTemplate()
+	}
+	return s.rolesFor(r.Method, template)
+}
+
+// rolesFor retorna os papéis exigidos por um método e template de rota
+func (s *Server) rolesFor(method, template string) []auth.Role {
+	if roles, exists := s.routeRoles[method+" "+template]; exists {
+		return roles
+	}
+
+	// Padrão: leitura para qualquer papel, escrita apenas para admin
+	if method == "GET" || method == "HEAD" {
+		return []auth.Role{auth.RoleObserver, auth.RoleVoter, auth.RoleElectionOfficer}
+	}
+	return []auth.Role{auth.RoleAdmin}
+}
+
+// joinRoles formata a lista de papéis para mensagens de erro
+func joinRoles(roles []auth.Role) string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role)
+	}
+	return strings.Join(names, " or ")
+}
+
 // Handlers de informação
 
+// APIInfoResponse representa as informações gerais da API
+type APIInfoResponse struct {
+	Name          string            `json:"name"`
+	Version       string            `json:"version"`
+	Description   string            `json:"description"`
+	Endpoints     map[string]string `json:"endpoints"`
+	Documentation string            `json:"documentation"`
+	OpenAPI       string            `json:"openapi"`
+	Timestamp     int64             `json:"timestamp"`
+}
+
 // getAPIInfo retorna informações sobre a API
 func (s *Server) getAPIInfo(w http.ResponseWriter, r *http.Request) {
-	info := map[string]interface{}{
-		"name":        "Peer-Vote REST API",
-		"version":     "1.0.0",
-		"description": "API REST para sistema de votação descentralizado",
-		"endpoints": map[string]interface{}{
-			"elections": "/api/v1/elections",
-			"votes":     "/api/v1/votes",
-			"blocks":    "/api/v1/blocks",
-			"nodes":     "/api/v1/nodes",
+	info := APIInfoResponse{
+		Name:        "Peer-Vote REST API",
+		Version:     "1.0.0",
+		Description: "API REST para sistema de votação descentralizado",
+		Endpoints: map[string]string{
+			"elections":          "/api/v1/elections",
+			"election_templates": "/api/v1/election-templates",
+			"votes":              "/api/v1/votes",
+			"blocks":             "/api/v1/blocks",
+			"nodes":              "/api/v1/nodes",
+			"validators":         "/api/v1/validators",
+			"consensus":          "/api/v1/consensus",
+			"mempool":            "/api/v1/mempool",
+			"transactions":       "/api/v1/transactions/{hash}",
+			"events":             "/api/v1/events",
+			"events_ws":          "/api/v1/events/ws",
 		},
-		"documentation": "/",
-		"timestamp":     time.Now().Unix(),
+		Documentation: "/",
+		OpenAPI:       OpenAPIPath,
+		Timestamp:     time.Now().Unix(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(info)
 }
 
-// getDocumentation retorna documentação básica da API
+// getOpenAPISpec retorna a especificação OpenAPI 3 das rotas registradas
+func (s *Server) getOpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	if s.openAPISpec == nil {
+		http.Error(w, "OpenAPI spec not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(s.openAPISpec)
+}
+
+// getDocumentation retorna a documentação HTML, gerada a partir das mesmas rotas da especificação OpenAPI
 func (s *Server) getDocumentation(w http.ResponseWriter, r *http.Request) {
+	routes, _ := s.collectRoutes()
+
+	var body strings.Builder
+	for _, tag := range apiTags {
+		fmt.Fprintf(&body, "    <div class=\"endpoint\">\n        <h3>%s</h3>\n", html.EscapeString(tag.Name))
+		for _, route := range routes {
+			if route.Operation.Tag != tag.Name {
+				continue
+			}
+			path, _ := openAPIPath(route.Template, nil)
+			summary := route.Operation.Summary
+			if len(route.Roles) > 0 && !hasReadRoles(route.Roles) {
+				summary += " (" + joinRoles(route.Roles) + ")"
+			}
+			fmt.Fprintf(&body, "        <p><code>%s %s</code> - %s</p>\n",
+				route.Method, html.EscapeString(path), html.EscapeString(summary))
+		}
+		fmt.Fprintf(&body, "        <p>%s</p>\n    </div>\n", html.EscapeString(tag.Description))
+	}
+
 	docs := `
 <!DOCTYPE html>
 <html>
@@ -261,42 +751,19 @@ func (s *Server) getDocumentation(w http.ResponseWriter, r *http.Request) {
 <body>
     <h1>🗳️ Peer-Vote REST API</h1>
     <p>API REST para sistema de votação descentralizado baseado em blockchain.</p>
+    <p>Especificação OpenAPI 3: <code>GET ` + OpenAPIPath + `</code></p>
     
-    <h2>📋 Endpoints Principais</h2>
+    <h2>📋 Endpoints</h2>
     
+` + body.String() + `
     <div class="endpoint">
-        <h3>Eleições</h3>
-        <p><code>GET /api/v1/elections</code> - Listar eleições</p>
-        <p><code>POST /api/v1/elections</code> - Criar eleição</p>
-        <p><code>GET /api/v1/elections/{id}</code> - Obter eleição</p>
-        <p><code>PUT /api/v1/elections/{id}/status</code> - Atualizar status</p>
-        <p><code>GET /api/v1/elections/{id}/results</code> - Obter resultados</p>
+        <h3>Autenticação</h3>
+        <p>Com <code>api.auth.enabled</code>, todas as rotas (exceto <code>/api/v1/info</code>, <code>/api/v1/nodes/health</code> e <code>` + OpenAPIPath + `</code>) exigem credenciais:</p>
+        <p><code>Authorization: Bearer &lt;jwt&gt;</code> - JWT HS256 (<code>api.auth.jwt_secret</code>) ou ES256 (<code>api.auth.jwt_public_key</code>) com claims <code>role</code>/<code>roles</code> e <code>node_id</code></p>
+        <p><code>X-Node-ID</code>, <code>X-Public-Key</code>, <code>X-Timestamp</code>, <code>X-Signature</code> - Requisição assinada com a chave do nó sobre <code>MÉTODO\nURI\nTIMESTAMP\nSHA256(corpo)</code></p>
+        <p>Papéis: <code>admin</code> (tudo), <code>election-officer</code> (criar eleições e alterar status), <code>voter</code> (votar com o próprio NodeID), <code>observer</code> (leitura).</p>
+        <p>Endpoints administrativos também aceitam <code>Authorization: Bearer &lt;api.auth.admin_token&gt;</code>.</p>
     </div>
-    
-    <div class="endpoint">
-        <h3>Votos</h3>
-        <p><code>POST /api/v1/votes</code> - Submeter voto</p>
-        <p><code>GET /api/v1/votes/audit/{election_id}</code> - Auditar votos</p>
-        <p><code>GET /api/v1/votes/count/{election_id}</code> - Contar votos</p>
-    </div>
-    
-    <div class="endpoint">
-        <h3>Blockchain</h3>
-        <p><code>GET /api/v1/blocks</code> - Listar blocos</p>
-        <p><code>GET /api/v1/blocks/{index}</code> - Obter bloco por índice</p>
-        <p><code>GET /api/v1/blocks/latest</code> - Último bloco</p>
-        <p><code>GET /api/v1/chain/status</code> - Status da blockchain</p>
-    </div>
-    
-    <div class="endpoint">
-        <h3>Nós</h3>
-        <p><code>GET /api/v1/nodes/status</code> - Status do nó</p>
-        <p><code>GET /api/v1/nodes/network</code> - Status da rede</p>
-        <p><code>GET /api/v1/nodes/health</code> - Health check</p>
-    </div>
-    
-    <h2>ℹ️ Informações</h2>
-    <p><code>GET /api/v1/info</code> - Informações da API</p>
 </body>
 </html>
     `
@@ -304,3 +771,13 @@ func (s *Server) getDocumentation(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html")
 	w.Write([]byte(docs))
 }
+
+// hasReadRoles indica se os papéis são os padrão de leitura (omitidos na documentação)
+func hasReadRoles(roles []auth.Role) bool {
+	for _, role := range roles {
+		if role == auth.RoleObserver {
+			return true
+		}
+	}
+	return false
+}
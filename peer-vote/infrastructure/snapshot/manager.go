@@ -0,0 +1,506 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/logging"
+)
+
+// Config contém as configurações de snapshots
+type Config struct {
+	Interval     uint64        // Blocos entre checkpoints
+	Keep         int           // Snapshots mantidos pelo nó
+	Bootstrap    bool          // Iniciar um nó vazio a partir de um snapshot confiável
+	PollInterval time.Duration // Intervalo de verificação sem novos blocos
+}
+
+// DefaultConfig retorna a configuração padrão de snapshots
+func DefaultConfig() *Config {
+	return &Config{
+		Interval:     1000,
+		Keep:         3,
+		Bootstrap:    true,
+		PollInterval: 10 * time.Second,
+	}
+}
+
+// Broadcaster envia a assinatura local de um snapshot aos peers
+type Broadcaster func(ctx context.Context, signature Signature) error
+
+// Manager produz snapshots nas alturas de checkpoint, coleta as assinaturas dos
+// validadores e restaura um nó vazio a partir de um snapshot confiável.
+//
+// O estado é capturado por uma réplica que avança apenas com blocos finais (ou mais
+// profundos que a reorganização máxima), de forma que todos os validadores capturem
+// exatamente o mesmo estado em cada checkpoint. Após cada checkpoint a réplica é
+// reiniciada a partir do próprio snapshot, limitando a memória a um intervalo de blocos.
+type Manager struct {
+	target           Target
+	validatorManager *consensus.ValidatorManager
+	cryptoService    services.CryptographyService
+	config           *Config
+	logger           *slog.Logger
+
+	// Chave do nó para assinar snapshots (opcional)
+	nodeID     valueobjects.NodeID
+	privateKey *services.PrivateKey
+
+	broadcaster Broadcaster
+
+	replica   *replica
+	snapshots []*Snapshot            // Em ordem crescente de altura
+	pending   map[uint64][]Signature // Assinaturas recebidas antes do snapshot local
+	notify    chan struct{}
+
+	// advanceMu serializa o avanço da réplica; mu protege os snapshots
+	advanceMu sync.Mutex
+	mu        sync.RWMutex
+}
+
+// NewManager cria o gerenciador de snapshots e o registra na cadeia
+func NewManager(target Target, validatorManager *consensus.ValidatorManager, cryptoService services.CryptographyService, config *Config) *Manager {
+	if config == nil {
+		config = DefaultConfig()
+	}
+	if config.Interval == 0 {
+		config.Interval = DefaultConfig().Interval
+	}
+	if config.Keep <= 0 {
+		config.Keep = DefaultConfig().Keep
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultConfig().PollInterval
+	}
+
+	manager := &Manager{
+		target:           target,
+		validatorManager: validatorManager,
+		cryptoService:    cryptoService,
+		config:           config,
+		logger:           logging.Component("snapshot"),
+		pending:          make(map[uint64][]Signature),
+		notify:           make(chan struct{}, 1),
+	}
+
+	target.Chain.AddBlockListener(func(ctx context.Context, block *entities.Block) {
+		manager.trigger()
+	})
+
+	return manager
+}
+
+// SetLogger define o logger estruturado do gerenciador
+func (m *Manager) SetLogger(logger *slog.Logger) {
+	if logger != nil {
+		m.logger = logger
+	}
+}
+
+// SetNodeKey define a chave usada para assinar os snapshots quando o nó é validador
+func (m *Manager) SetNodeKey(nodeID valueobjects.NodeID, privateKey *services.PrivateKey) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nodeID = nodeID
+	m.privateKey = privateKey
+}
+
+// SetBroadcaster define a função que envia as assinaturas locais aos peers
+func (m *Manager) SetBroadcaster(broadcaster Broadcaster) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.broadcaster = broadcaster
+}
+
+// GetConfig retorna a configuração de snapshots
+func (m *Manager) GetConfig() Config {
+	return *m.config
+}
+
+// Start inicia o avanço da réplica em segundo plano
+func (m *Manager) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(m.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-m.notify:
+			case <-ticker.C:
+			}
+			m.advance(ctx)
+		}
+	}()
+}
+
+// trigger agenda um avanço da réplica sem bloquear quem aplicou o bloco
+func (m *Manager) trigger() {
+	select {
+	case m.notify <- struct{}{}:
+	default:
+	}
+}
+
+// TrustedValidators retorna o conjunto de validadores em que o nó confia para aceitar
+// snapshots: o conjunto gênese configurado localmente
+func (m *Manager) TrustedValidators(ctx context.Context) map[string]*services.PublicKey {
+	return m.validatorManager.GetGenesisValidatorSet(ctx)
+}
+
+// NeedsBootstrap verifica se o nó deve tentar iniciar a partir de um snapshot
+func (m *Manager) NeedsBootstrap(ctx context.Context) bool {
+	if !m.config.Bootstrap {
+		return false
+	}
+	if _, err := m.target.Chain.GetLatestBlock(ctx); err == nil {
+		return false
+	}
+	return len(m.TrustedValidators(ctx)) > 0
+}
+
+// List retorna as ofertas dos snapshots mantidos, do mais recente ao mais antigo
+func (m *Manager) List() []Offer {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	offers := make([]Offer, 0, len(m.snapshots))
+	for i := len(m.snapshots) - 1; i >= 0; i-- {
+		offers = append(offers, m.snapshots[i].Offer())
+	}
+	return offers
+}
+
+// Get retorna o snapshot mantido na altura informada
+func (m *Manager) Get(height uint64) (*Snapshot, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, snapshot := range m.snapshots {
+		if snapshot.Height == height {
+			copied := *snapshot
+			copied.Signatures = snapshot.Offer().Signatures
+			return &copied, true
+		}
+	}
+	return nil, false
+}
+
+// CheckOffer confere se uma oferta tem quorum de assinaturas do conjunto confiável
+func (m *Manager) CheckOffer(ctx context.Context, offer Offer) error {
+	_, err := VerifySignatures(ctx, m.cryptoService, offer, m.TrustedValidators(ctx))
+	return err
+}
+
+// Restore verifica o snapshot contra o conjunto confiável e inicia a cadeia vazia a partir dele
+func (m *Manager) Restore(ctx context.Context, snapshot *Snapshot) error {
+	m.advanceMu.Lock()
+	defer m.advanceMu.Unlock()
+
+	if err := Verify(ctx, m.cryptoService, snapshot, m.TrustedValidators(ctx)); err != nil {
+		return fmt.Errorf("snapshot verification failed: %w", err)
+	}
+	if err := Restore(ctx, snapshot, m.target); err != nil {
+		return err
+	}
+
+	r, err := newReplicaFromSnapshot(ctx, m.cryptoService, snapshot, m.target.Ledger.SlotAccounting())
+	if err != nil {
+		m.logger.WarnContext(ctx, "failed to initialize snapshot replica", logging.Err(err))
+	}
+
+	m.mu.Lock()
+	m.replica = r
+	m.store(snapshot)
+	m.mu.Unlock()
+
+	m.logger.InfoContext(ctx, "node restored from snapshot",
+		logging.Height(snapshot.Height), "state_hash", snapshot.StateHash, "signatures", len(snapshot.Signatures))
+	return nil
+}
+
+// HandleSignature registra a assinatura de um validador recebida de um peer.
+// Retorna true quando a assinatura é nova e deve ser repassada aos demais peers.
+func (m *Manager) HandleSignature(ctx context.Context, signature Signature) (bool, error) {
+	publicKey, err := m.validatorManager.GetValidatorPublicKeyAtHeight(ctx, valueobjects.NewNodeID(signature.Validator), signature.Height)
+	if err != nil {
+		return false, fmt.Errorf("signer %s is not a validator at height %d", signature.Validator, signature.Height)
+	}
+
+	sig, err := valueobjects.NewSignatureFromString(signature.Signature)
+	if err != nil {
+		return false, fmt.Errorf("invalid snapshot signature: %w", err)
+	}
+	valid, err := m.cryptoService.Verify(ctx, SigningBytes(signature.Height, signature.BlockHash, signature.StateHash), sig, publicKey)
+	if err != nil || !valid {
+		return false, errors.New("invalid snapshot signature")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, snapshot := range m.snapshots {
+		if snapshot.Height != signature.Height {
+			continue
+		}
+		if !signature.matches(snapshot.Height, snapshot.BlockHash, snapshot.StateHash) {
+			m.logger.WarnContext(ctx, "validator signed a different snapshot state",
+				logging.Height(signature.Height), "validator", signature.Validator,
+				"state_hash", signature.StateHash, "local_state_hash", snapshot.StateHash)
+			return false, nil
+		}
+		return addSignature(snapshot, signature), nil
+	}
+
+	// Snapshot ainda não capturado localmente: guardar até o checkpoint
+	if len(m.snapshots) > 0 && signature.Height <= m.snapshots[len(m.snapshots)-1].Height {
+		return false, nil
+	}
+	for _, pending := range m.pending[signature.Height] {
+		if pending.Validator == signature.Validator {
+			return false, nil
+		}
+	}
+	m.pending[signature.Height] = append(m.pending[signature.Height], signature)
+	return true, nil
+}
+
+// advance aplica à réplica os blocos que não podem mais ser revertidos e captura os checkpoints
+func (m *Manager) advance(ctx context.Context) {
+	m.advanceMu.Lock()
+	defer m.advanceMu.Unlock()
+
+	settled, ok := m.settledHeight(ctx)
+	if !ok {
+		return
+	}
+
+	r := m.currentReplica(ctx)
+	if r == nil {
+		return
+	}
+
+	next := uint64(0)
+	if height, ok := r.height(ctx); ok {
+		next = height + 1
+	}
+
+	for height := next; height <= settled; height++ {
+		if ctx.Err() != nil {
+			return
+		}
+
+		block, err := m.target.Chain.GetBlockByIndex(ctx, height)
+		if err != nil {
+			m.logger.WarnContext(ctx, "failed to read block for snapshot replica", logging.Height(height), logging.Err(err))
+			return
+		}
+		if err := r.chain.AddBlock(ctx, block); err != nil {
+			// A réplica divergiu da cadeia local: recomeçar do gênese ou do último snapshot
+			m.logger.WarnContext(ctx, "snapshot replica rejected block", logging.Height(height), logging.Err(err))
+			m.mu.Lock()
+			m.replica = nil
+			m.mu.Unlock()
+			return
+		}
+
+		if height > 0 && height%m.config.Interval == 0 {
+			if next := m.checkpoint(ctx, r, height); next != nil {
+				r = next
+			}
+		}
+	}
+}
+
+// settledHeight retorna a maior altura que não pode mais ser revertida
+func (m *Manager) settledHeight(ctx context.Context) (uint64, bool) {
+	latest, err := m.target.Chain.GetLatestBlock(ctx)
+	if err != nil {
+		return 0, false
+	}
+
+	settled, ok := m.target.Chain.GetFinalizedHeight(ctx)
+	depth := uint64(m.target.Chain.GetMaxReorgDepth())
+	if latest.GetIndex() >= depth && (!ok || latest.GetIndex()-depth > settled) {
+		settled, ok = latest.GetIndex()-depth, true
+	}
+	return settled, ok
+}
+
+// currentReplica retorna a réplica, criando-a a partir do gênese quando necessário
+func (m *Manager) currentReplica(ctx context.Context) *replica {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, hasBase := m.target.Chain.GetBase(); hasBase {
+		// Cadeia iniciada por snapshot: a réplica parte do snapshot restaurado
+		return m.replica
+	}
+
+	trusted := m.TrustedValidators(ctx)
+	if len(trusted) == 0 {
+		return nil // Sem conjunto gênese não há como produzir snapshots verificáveis
+	}
+	if m.replica != nil && archive.SameValidatorSet(m.replica.genesis, trusted) {
+		return m.replica
+	}
+
+	r, err := newReplica(ctx, m.cryptoService, trusted, m.target.Ledger.SlotAccounting())
+	if err != nil {
+		m.logger.WarnContext(ctx, "failed to create snapshot replica", logging.Err(err))
+		return nil
+	}
+	m.replica = r
+	return r
+}
+
+// checkpoint captura o snapshot na altura atual da réplica, assina-o se o nó for validador
+// e reinicia a réplica a partir dele. Retorna a nova réplica (nil se nada foi capturado).
+func (m *Manager) checkpoint(ctx context.Context, r *replica, height uint64) *replica {
+	if r.pendingChanges(ctx, height) {
+		m.logger.DebugContext(ctx, "skipping snapshot with pending validator set changes", logging.Height(height))
+		return nil
+	}
+
+	state, err := r.capture(ctx)
+	if err != nil {
+		m.logger.WarnContext(ctx, "failed to capture snapshot state", logging.Height(height), logging.Err(err))
+		return nil
+	}
+	stateHash, err := HashState(ctx, m.cryptoService, state)
+	if err != nil {
+		m.logger.WarnContext(ctx, "failed to hash snapshot state", logging.Height(height), logging.Err(err))
+		return nil
+	}
+
+	snapshot := &Snapshot{
+		Format:     Format,
+		Version:    Version,
+		Height:     height,
+		BlockHash:  state.BlockHash,
+		StateHash:  stateHash.String(),
+		State:      state,
+		Signatures: make([]Signature, 0),
+	}
+
+	own, signed := m.sign(ctx, snapshot)
+
+	next, err := newReplicaFromSnapshot(ctx, m.cryptoService, snapshot, m.target.Ledger.SlotAccounting())
+	if err != nil {
+		m.logger.WarnContext(ctx, "failed to restart snapshot replica", logging.Height(height), logging.Err(err))
+		next = nil
+	}
+
+	m.mu.Lock()
+	for _, signature := range m.pending[height] {
+		if signature.matches(snapshot.Height, snapshot.BlockHash, snapshot.StateHash) {
+			addSignature(snapshot, signature)
+		}
+	}
+	for pendingHeight := range m.pending {
+		if pendingHeight <= height {
+			delete(m.pending, pendingHeight)
+		}
+	}
+	m.store(snapshot)
+	if next != nil {
+		m.replica = next
+	}
+	broadcaster := m.broadcaster
+	m.mu.Unlock()
+
+	m.logger.InfoContext(ctx, "state snapshot captured",
+		logging.Height(height), "state_hash", snapshot.StateHash,
+		"elections", len(state.Elections), "signed", signed)
+
+	if signed && broadcaster != nil {
+		if err := broadcaster(ctx, own); err != nil {
+			m.logger.WarnContext(ctx, "failed to broadcast snapshot signature", logging.Height(height), logging.Err(err))
+		}
+	}
+
+	return next
+}
+
+// sign assina o snapshot com a chave do nó quando ele é validador na altura do snapshot
+func (m *Manager) sign(ctx context.Context, snapshot *Snapshot) (Signature, bool) {
+	m.mu.RLock()
+	nodeID, privateKey := m.nodeID, m.privateKey
+	m.mu.RUnlock()
+
+	if privateKey == nil {
+		return Signature{}, false
+	}
+
+	member := false
+	for _, key := range snapshot.State.validatorSetAt(snapshot.Height) {
+		if key.NodeID == nodeID.String() {
+			member = true
+			break
+		}
+	}
+	if !member {
+		return Signature{}, false
+	}
+
+	signature, err := m.cryptoService.Sign(ctx, SigningBytes(snapshot.Height, snapshot.BlockHash, snapshot.StateHash), privateKey)
+	if err != nil {
+		m.logger.WarnContext(ctx, "failed to sign snapshot", logging.Height(snapshot.Height), logging.Err(err))
+		return Signature{}, false
+	}
+
+	own := Signature{
+		Height:    snapshot.Height,
+		BlockHash: snapshot.BlockHash,
+		StateHash: snapshot.StateHash,
+		Validator: nodeID.String(),
+		Signature: signature.String(),
+	}
+	addSignature(snapshot, own)
+	return own, true
+}
+
+// store guarda o snapshot mantendo apenas os mais recentes (deve ser chamado com lock)
+func (m *Manager) store(snapshot *Snapshot) {
+	for i, existing := range m.snapshots {
+		if existing.Height == snapshot.Height {
+			m.snapshots[i] = snapshot
+			return
+		}
+	}
+
+	m.snapshots = append(m.snapshots, snapshot)
+	sort.Slice(m.snapshots, func(i, j int) bool {
+		return m.snapshots[i].Height < m.snapshots[j].Height
+	})
+	if len(m.snapshots) > m.config.Keep {
+		m.snapshots = m.snapshots[len(m.snapshots)-m.config.Keep:]
+	}
+}
+
+// addSignature adiciona a assinatura ao snapshot se o validador ainda não assinou
+func addSignature(snapshot *Snapshot, signature Signature) bool {
+	for _, existing := range snapshot.Signatures {
+		if existing.Validator == signature.Validator {
+			return false
+		}
+	}
+	snapshot.Signatures = append(snapshot.Signatures, signature)
+	sort.Slice(snapshot.Signatures, func(i, j int) bool {
+		return snapshot.Signatures[i].Validator < snapshot.Signatures[j].Validator
+	})
+	return true
+}
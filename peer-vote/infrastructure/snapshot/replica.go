@@ -0,0 +1,281 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/persistence"
+)
+
+// replica é uma cópia em memória da cadeia que avança apenas com blocos que não podem mais
+// ser revertidos. Ela mantém o próprio conjunto de validadores, penalidades e apuração,
+// de forma que o estado na altura do checkpoint possa ser capturado exatamente.
+type replica struct {
+	cryptoService services.CryptographyService
+	genesis       map[string]*services.PublicKey
+	chain         *blockchain.ChainManager
+	validators    *consensus.ValidatorManager
+	governance    *consensus.ValidatorGovernance
+	penalties     *consensus.PenaltySystem
+	ledger        *consensus.PenaltyLedger
+	elections     *electionFold
+}
+
+// electionFold acumula eleições, apuração e eleitores a partir dos blocos aplicados
+type electionFold struct {
+	order     []string
+	elections map[string]*foldedElection
+	mu        sync.Mutex
+}
+
+// foldedElection é o estado acumulado de uma eleição
+type foldedElection struct {
+	election   *entities.Election
+	data       []byte
+	height     uint64
+	index      int
+	results    map[string]uint64
+	totalVotes uint64
+	voters     map[string]bool
+}
+
+// newReplica cria a réplica vazia com o conjunto de validadores gênese informado.
+// slotAccounting deve seguir o livro de penalidades do nó, para capturar o mesmo estado.
+func newReplica(ctx context.Context, cryptoService services.CryptographyService, genesis map[string]*services.PublicKey, slotAccounting bool) (*replica, error) {
+	chainManager := blockchain.NewChainManager(persistence.NewMemoryBlockchainRepository(cryptoService), cryptoService)
+	validatorManager := consensus.NewValidatorManager()
+
+	nodeIDs := make([]string, 0, len(genesis))
+	for nodeID := range genesis {
+		nodeIDs = append(nodeIDs, nodeID)
+	}
+	sort.Strings(nodeIDs)
+	for _, nodeID := range nodeIDs {
+		if err := validatorManager.AddValidator(ctx, valueobjects.NewNodeID(nodeID), genesis[nodeID]); err != nil {
+			return nil, fmt.Errorf("failed to add genesis validator %s: %w", nodeID, err)
+		}
+	}
+
+	// A ordem de criação segue a do nó: governança antes do livro de penalidades
+	governance := consensus.NewValidatorGovernance(validatorManager, chainManager, cryptoService)
+	penaltySystem := consensus.NewPenaltySystem(validatorManager)
+	detector := consensus.NewDoubleSignDetector(validatorManager, chainManager, cryptoService)
+	ledger := consensus.NewPenaltyLedger(validatorManager, chainManager, penaltySystem, detector)
+	ledger.SetSlotAccounting(slotAccounting)
+
+	fold := &electionFold{elections: make(map[string]*foldedElection)}
+	chainManager.AddBlockListener(func(ctx context.Context, block *entities.Block) {
+		fold.apply(block)
+	})
+
+	return &replica{
+		cryptoService: cryptoService,
+		genesis:       genesis,
+		chain:         chainManager,
+		validators:    validatorManager,
+		governance:    governance,
+		penalties:     penaltySystem,
+		ledger:        ledger,
+		elections:     fold,
+	}, nil
+}
+
+// newReplicaFromSnapshot cria a réplica a partir de um snapshot já verificado
+func newReplicaFromSnapshot(ctx context.Context, cryptoService services.CryptographyService, snapshot *Snapshot, slotAccounting bool) (*replica, error) {
+	genesis, err := archive.DecodeValidatorKeys(snapshot.State.GenesisValidators)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := newReplica(ctx, cryptoService, genesis, slotAccounting)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Restore(ctx, snapshot, Target{
+		Chain:      r.chain,
+		Governance: r.governance,
+		Ledger:     r.ledger,
+	}); err != nil {
+		return nil, err
+	}
+	r.elections.seed(snapshot.State.Elections)
+
+	return r, nil
+}
+
+// height retorna a altura da réplica (false quando vazia)
+func (r *replica) height(ctx context.Context) (uint64, bool) {
+	if _, err := r.chain.GetLatestBlock(ctx); err != nil {
+		return 0, false
+	}
+	height, err := r.chain.GetChainHeight(ctx)
+	if err != nil {
+		return 0, false
+	}
+	return height, true
+}
+
+// pendingChanges verifica se há alterações do conjunto de validadores ainda não efetivas
+// na altura: o snapshot seria inconsistente para quem continuar a partir dele
+func (r *replica) pendingChanges(ctx context.Context, height uint64) bool {
+	if len(r.governance.GetPendingProposals(ctx)) > 0 {
+		return true
+	}
+	for _, epoch := range r.validators.GetValidatorSchedule(ctx) {
+		if epoch.FromHeight > height+1 {
+			return true
+		}
+	}
+	return false
+}
+
+// capture monta o estado da réplica na altura atual
+func (r *replica) capture(ctx context.Context) (*State, error) {
+	anchor, err := r.chain.GetLatestBlock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get anchor block: %w", err)
+	}
+	height := anchor.GetIndex()
+	hash := r.chain.CalculateBlockHash(ctx, anchor)
+
+	genesisHash, err := r.chain.GetGenesisHash(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerHeight, ledgerState, ok := r.ledger.GetState()
+	if !ok || ledgerHeight != height {
+		return nil, fmt.Errorf("penalty ledger is at height %d, expected %d", ledgerHeight, height)
+	}
+
+	return &State{
+		Height:            height,
+		BlockHash:         hash.String(),
+		GenesisHash:       genesisHash.String(),
+		Timestamp:         anchor.GetTimestamp().Unix(),
+		Anchor:            archive.NewArchivedBlock(anchor, hash),
+		Elections:         r.elections.capture(anchor.GetTimestamp()),
+		GenesisValidators: archive.NewValidatorKeys(r.validators.GetGenesisValidatorSet(ctx)),
+		ValidatorHistory:  archive.NewValidatorHistory(r.validators.GetValidatorSchedule(ctx)),
+		Validators:        archive.NewValidatorKeys(r.validators.GetValidatorSetAtHeight(ctx, height+1)),
+		Penalties:         newPenaltyStates(r.penalties.GetAllPenalties(ctx)),
+		Ledger:            ledgerState,
+	}, nil
+}
+
+// apply aplica eleições e votos do bloco. Um voto conta apenas se for válido e sua
+// eleição já estiver registrada, a mesma regra do EventHub.
+func (f *electionFold) apply(block *entities.Block) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i, tx := range block.GetTransactions() {
+		switch tx.GetType() {
+		case entities.ElectionTransaction:
+			election := &entities.Election{}
+			if err := election.FromBytes(tx.GetData()); err != nil {
+				continue
+			}
+
+			electionID := election.GetID().String()
+			state, exists := f.elections[electionID]
+			if !exists {
+				state = &foldedElection{
+					height:  block.GetIndex(),
+					index:   i,
+					results: make(map[string]uint64),
+					voters:  make(map[string]bool),
+				}
+				f.elections[electionID] = state
+				f.order = append(f.order, electionID)
+			}
+			state.election = election
+			state.data = tx.GetData()
+
+		case entities.VoteTransaction:
+			vote := &entities.Vote{}
+			if err := vote.FromBytes(tx.GetData()); err != nil || !vote.IsValid() {
+				continue
+			}
+
+			state, exists := f.elections[vote.GetElectionID().String()]
+			if !exists {
+				continue
+			}
+			state.results[vote.GetCandidateID()]++
+			state.totalVotes++
+			state.voters[tx.GetFrom().String()] = true
+		}
+	}
+}
+
+// seed inicia o acumulado com as eleições de um snapshot
+func (f *electionFold) seed(elections []ElectionState) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.order = nil
+	f.elections = make(map[string]*foldedElection, len(elections))
+	for _, entry := range elections {
+		election := &entities.Election{}
+		if err := election.FromBytes(entry.Data); err != nil {
+			continue
+		}
+
+		state := &foldedElection{
+			election:   election,
+			data:       entry.Data,
+			height:     entry.Height,
+			index:      entry.Index,
+			results:    make(map[string]uint64, len(entry.Results)),
+			totalVotes: entry.TotalVotes,
+			voters:     make(map[string]bool, len(entry.Voters)),
+		}
+		for candidateID, votes := range entry.Results {
+			state.results[candidateID] = votes
+		}
+		for _, voter := range entry.Voters {
+			state.voters[voter] = true
+		}
+
+		f.elections[entry.ID] = state
+		f.order = append(f.order, entry.ID)
+	}
+}
+
+// capture retorna as eleições acumuladas em ordem de registro, com o status no instante informado
+func (f *electionFold) capture(at valueobjects.Timestamp) []ElectionState {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	elections := make([]ElectionState, 0, len(f.order))
+	for _, electionID := range f.order {
+		state := f.elections[electionID]
+
+		results := make(map[string]uint64, len(state.results))
+		for candidateID, votes := range state.results {
+			results[candidateID] = votes
+		}
+
+		elections = append(elections, ElectionState{
+			ID:         electionID,
+			Status:     state.election.EffectiveStatus(at),
+			Data:       state.data,
+			Height:     state.height,
+			Index:      state.index,
+			Results:    results,
+			TotalVotes: state.totalVotes,
+			Voters:     sortedKeys(state.voters),
+		})
+	}
+	return elections
+}
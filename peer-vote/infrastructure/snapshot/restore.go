@@ -0,0 +1,68 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/events"
+)
+
+// Target reúne os componentes do nó reconstruídos a partir de um snapshot
+type Target struct {
+	Chain      *blockchain.ChainManager
+	Governance *consensus.ValidatorGovernance
+	Ledger     *consensus.PenaltyLedger
+	Mempool    *consensus.Mempool // Opcional
+	Events     *events.EventHub   // Opcional
+}
+
+// Restore inicia uma cadeia vazia a partir de um snapshot já verificado (ver Verify):
+// salva o bloco âncora como base e herda o conjunto de validadores, as penalidades e a
+// apuração. Os blocos seguintes devem ser sincronizados normalmente.
+func Restore(ctx context.Context, snapshot *Snapshot, target Target) error {
+	if snapshot == nil || snapshot.State == nil {
+		return errors.New("snapshot has no state")
+	}
+	if target.Chain == nil || target.Governance == nil || target.Ledger == nil {
+		return errors.New("chain, governance and penalty ledger are required to restore a snapshot")
+	}
+
+	state := snapshot.State
+	anchor, err := state.Anchor.Block()
+	if err != nil {
+		return fmt.Errorf("invalid anchor block: %w", err)
+	}
+	base, err := state.chainBase()
+	if err != nil {
+		return err
+	}
+	epochs, err := state.validatorEpochs()
+	if err != nil {
+		return err
+	}
+
+	if err := target.Chain.InitializeFromBase(ctx, anchor, base); err != nil {
+		return err
+	}
+	if err := target.Governance.SetBase(ctx, state.Height, epochs); err != nil {
+		return fmt.Errorf("failed to restore validator set: %w", err)
+	}
+	if err := target.Ledger.SetBase(ctx, state.Height, state.Ledger, state.penaltyRecords()); err != nil {
+		return fmt.Errorf("failed to restore penalties: %w", err)
+	}
+
+	if target.Mempool != nil {
+		if err := target.Mempool.Rebuild(ctx); err != nil {
+			return fmt.Errorf("failed to rebuild mempool: %w", err)
+		}
+	}
+	if target.Events != nil {
+		if err := target.Events.Rebuild(ctx); err != nil {
+			return fmt.Errorf("failed to rebuild event hub: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/entities"
+	"github.com/matscats/peer-vote/peer-vote/domain/repositories"
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/consensus"
+)
+
+// Identificação do formato de snapshot
+const (
+	Format  = "peer-vote-state-snapshot"
+	Version = 1
+)
+
+// Snapshot é o estado derivado da cadeia em uma altura de checkpoint, identificado pelo
+// hash do estado e coassinado pelos validadores. Um nó novo pode iniciar a partir dele e
+// sincronizar apenas os blocos seguintes.
+type Snapshot struct {
+	Format     string      `json:"format"`
+	Version    int         `json:"version"`
+	Height     uint64      `json:"height"`
+	BlockHash  string      `json:"block_hash"`
+	StateHash  string      `json:"state_hash"`
+	State      *State      `json:"state"`
+	Signatures []Signature `json:"signatures"`
+}
+
+// State é o conteúdo determinístico do snapshot: todos os nós que processaram a mesma
+// cadeia até Height produzem exatamente os mesmos bytes (e portanto o mesmo StateHash)
+type State struct {
+	Height            uint64                       `json:"height"`
+	BlockHash         string                       `json:"block_hash"`
+	GenesisHash       string                       `json:"genesis_hash"`
+	Timestamp         int64                        `json:"timestamp"` // Timestamp do bloco âncora
+	Anchor            *archive.ArchivedBlock       `json:"anchor"`
+	Elections         []ElectionState              `json:"elections"`
+	GenesisValidators []archive.ValidatorKey       `json:"genesis_validators"`
+	ValidatorHistory  []archive.ValidatorEpoch     `json:"validator_history"`
+	Validators        []archive.ValidatorKey       `json:"validators"` // Conjunto vigente em Height+1
+	Penalties         []PenaltyState               `json:"penalties"`
+	Ledger            consensus.PenaltyLedgerState `json:"ledger"`
+}
+
+// ElectionState é uma eleição registrada até a altura do snapshot com a apuração acumulada
+type ElectionState struct {
+	ID         string                  `json:"id"`
+	Status     entities.ElectionStatus `json:"status"` // Status efetivo no timestamp da âncora
+	Data       []byte                  `json:"data"`   // Dados da transação ELECTION
+	Height     uint64                  `json:"height"`
+	Index      int                     `json:"index"`
+	Results    map[string]uint64       `json:"results"`
+	TotalVotes uint64                  `json:"total_votes"`
+	Voters     []string                `json:"voters"` // Remetentes dos votos contados, ordenados
+}
+
+// PenaltyState é um registro de penalidade de validador (instantes em segundos Unix)
+type PenaltyState struct {
+	ID          string                    `json:"id"`
+	ValidatorID string                    `json:"validator_id"`
+	Type        consensus.PenaltyType     `json:"type"`
+	Severity    consensus.PenaltySeverity `json:"severity"`
+	Reason      string                    `json:"reason"`
+	AppliedAt   int64                     `json:"applied_at"`
+	ExpiresAt   int64                     `json:"expires_at"`
+	IsActive    bool                      `json:"is_active"`
+	Evidence    map[string]interface{}    `json:"evidence,omitempty"`
+}
+
+// Signature é a assinatura de um validador sobre (altura, hash do bloco, hash do estado)
+type Signature struct {
+	Height    uint64 `json:"height"`
+	BlockHash string `json:"block_hash"`
+	StateHash string `json:"state_hash"`
+	Validator string `json:"validator"`
+	Signature string `json:"signature"`
+}
+
+// Offer descreve um snapshot disponível em um peer, sem o estado
+type Offer struct {
+	Height     uint64      `json:"height"`
+	BlockHash  string      `json:"block_hash"`
+	StateHash  string      `json:"state_hash"`
+	Signatures []Signature `json:"signatures"`
+}
+
+// HashState calcula o hash SHA-256 da serialização JSON do estado
+func HashState(ctx context.Context, cryptoService services.CryptographyService, state *State) (valueobjects.Hash, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return valueobjects.EmptyHash(), fmt.Errorf("failed to serialize snapshot state: %w", err)
+	}
+	return cryptoService.Hash(ctx, data), nil
+}
+
+// SigningBytes retorna os bytes assinados pelos validadores
+func SigningBytes(height uint64, blockHash, stateHash string) []byte {
+	return []byte(fmt.Sprintf("%s:%d:%s:%s", Format, height, blockHash, stateHash))
+}
+
+// Offer retorna a descrição do snapshot sem o estado
+func (s *Snapshot) Offer() Offer {
+	signatures := make([]Signature, len(s.Signatures))
+	copy(signatures, s.Signatures)
+	return Offer{Height: s.Height, BlockHash: s.BlockHash, StateHash: s.StateHash, Signatures: signatures}
+}
+
+// matches verifica se a assinatura se refere ao snapshot
+func (sig Signature) matches(height uint64, blockHash, stateHash string) bool {
+	return sig.Height == height && sig.BlockHash == blockHash && sig.StateHash == stateHash
+}
+
+// newPenaltyStates converte os registros de penalidade
+func newPenaltyStates(records []*consensus.PenaltyRecord) []PenaltyState {
+	penalties := make([]PenaltyState, len(records))
+	for i, record := range records {
+		penalties[i] = PenaltyState{
+			ID:          record.ID,
+			ValidatorID: record.ValidatorID.String(),
+			Type:        record.Type,
+			Severity:    record.Severity,
+			Reason:      record.Reason,
+			AppliedAt:   record.AppliedAt.Unix(),
+			ExpiresAt:   record.ExpiresAt.Unix(),
+			IsActive:    record.IsActive,
+			Evidence:    record.Evidence,
+		}
+	}
+	return penalties
+}
+
+// penaltyRecords reconstrói os registros de penalidade
+func (s *State) penaltyRecords() []*consensus.PenaltyRecord {
+	records := make([]*consensus.PenaltyRecord, len(s.Penalties))
+	for i, penalty := range s.Penalties {
+		records[i] = &consensus.PenaltyRecord{
+			ID:          penalty.ID,
+			ValidatorID: valueobjects.NewNodeID(penalty.ValidatorID),
+			Type:        penalty.Type,
+			Severity:    penalty.Severity,
+			Reason:      penalty.Reason,
+			AppliedAt:   valueobjects.Unix(penalty.AppliedAt, 0),
+			ExpiresAt:   valueobjects.Unix(penalty.ExpiresAt, 0),
+			IsActive:    penalty.IsActive,
+			Evidence:    penalty.Evidence,
+		}
+	}
+	return records
+}
+
+// validatorEpochs reconstrói o histórico do conjunto de validadores
+func (s *State) validatorEpochs() ([]consensus.ValidatorSetEpoch, error) {
+	epochs := make([]consensus.ValidatorSetEpoch, len(s.ValidatorHistory))
+	for i, epoch := range s.ValidatorHistory {
+		set, err := archive.DecodeValidatorKeys(epoch.Validators)
+		if err != nil {
+			return nil, err
+		}
+		epochs[i] = consensus.ValidatorSetEpoch{FromHeight: epoch.FromHeight, Validators: set}
+	}
+	return epochs, nil
+}
+
+// validatorSetAt retorna o conjunto de validadores do histórico vigente na altura
+func (s *State) validatorSetAt(height uint64) []archive.ValidatorKey {
+	current := s.GenesisValidators
+	for _, epoch := range s.ValidatorHistory {
+		if epoch.FromHeight > height {
+			break
+		}
+		current = epoch.Validators
+	}
+	return current
+}
+
+// chainBase converte as eleições do estado na base usada pelo ChainManager
+func (s *State) chainBase() (*blockchain.ChainBase, error) {
+	blockHash, err := valueobjects.NewHashFromString(s.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block hash: %w", err)
+	}
+	genesisHash, err := valueobjects.NewHashFromString(s.GenesisHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid genesis hash: %w", err)
+	}
+
+	base := &blockchain.ChainBase{
+		Height:      s.Height,
+		Hash:        blockHash,
+		GenesisHash: genesisHash,
+		Elections:   make([]blockchain.BaseElection, 0, len(s.Elections)),
+	}
+	for _, entry := range s.Elections {
+		election := &entities.Election{}
+		if err := election.FromBytes(entry.Data); err != nil {
+			return nil, fmt.Errorf("invalid election %s: %w", entry.ID, err)
+		}
+		if election.GetID().String() != entry.ID {
+			return nil, fmt.Errorf("election %s does not match its data", entry.ID)
+		}
+
+		results := make(map[string]uint64, len(entry.Results))
+		for candidateID, votes := range entry.Results {
+			results[candidateID] = votes
+		}
+		voters := make([]string, len(entry.Voters))
+		copy(voters, entry.Voters)
+
+		base.Elections = append(base.Elections, blockchain.BaseElection{
+			Election:   election,
+			Position:   repositories.ChainPosition{Height: entry.Height, Index: entry.Index},
+			Results:    results,
+			TotalVotes: entry.TotalVotes,
+			Voters:     voters,
+		})
+	}
+	return base, nil
+}
+
+// sortedKeys retorna as chaves de um conjunto em ordem alfabética
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
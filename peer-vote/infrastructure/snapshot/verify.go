@@ -0,0 +1,141 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/matscats/peer-vote/peer-vote/domain/services"
+	"github.com/matscats/peer-vote/peer-vote/domain/valueobjects"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/archive"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/blockchain"
+	"github.com/matscats/peer-vote/peer-vote/infrastructure/persistence"
+)
+
+// VerifySignatures confere as assinaturas de uma oferta contra o conjunto de validadores
+// confiável do nó (o gênese configurado) e retorna os validadores que assinaram.
+// Retorna erro se não houver assinaturas de mais de 2/3 do conjunto confiável.
+func VerifySignatures(ctx context.Context, cryptoService services.CryptographyService, offer Offer, trusted map[string]*services.PublicKey) ([]string, error) {
+	return verifySignatures(ctx, cryptoService, offer, trusted, nil)
+}
+
+// verifySignatures implementa VerifySignatures; eligible restringe os signatários aceitos
+func verifySignatures(ctx context.Context, cryptoService services.CryptographyService, offer Offer, trusted map[string]*services.PublicKey, eligible map[string]bool) ([]string, error) {
+	if len(trusted) == 0 {
+		return nil, errors.New("no trusted validator set to verify snapshot signatures")
+	}
+
+	message := SigningBytes(offer.Height, offer.BlockHash, offer.StateHash)
+	signers := make(map[string]bool)
+
+	for _, sig := range offer.Signatures {
+		if !sig.matches(offer.Height, offer.BlockHash, offer.StateHash) || signers[sig.Validator] {
+			continue
+		}
+		publicKey, exists := trusted[sig.Validator]
+		if !exists || publicKey == nil {
+			continue
+		}
+		if eligible != nil && !eligible[sig.Validator] {
+			continue
+		}
+
+		signature, err := valueobjects.NewSignatureFromString(sig.Signature)
+		if err != nil {
+			continue
+		}
+		valid, err := cryptoService.Verify(ctx, message, signature, publicKey)
+		if err != nil || !valid {
+			continue
+		}
+		signers[sig.Validator] = true
+	}
+
+	if len(signers)*3 <= len(trusted)*2 {
+		return nil, fmt.Errorf("snapshot at height %d signed by %d of %d trusted validators (more than 2/3 required)",
+			offer.Height, len(signers), len(trusted))
+	}
+	return sortedKeys(signers), nil
+}
+
+// Verify confere um snapshot completo: formato, hash do estado, bloco âncora, conjunto
+// gênese (que deve ser igual ao confiável) e o quorum de assinaturas. Os signatários
+// também precisam fazer parte do conjunto vigente na altura do snapshot.
+func Verify(ctx context.Context, cryptoService services.CryptographyService, snapshot *Snapshot, trusted map[string]*services.PublicKey) error {
+	if snapshot == nil || snapshot.State == nil {
+		return errors.New("snapshot has no state")
+	}
+	if snapshot.Format != Format || snapshot.Version != Version {
+		return fmt.Errorf("unsupported snapshot format %q version %d", snapshot.Format, snapshot.Version)
+	}
+
+	state := snapshot.State
+	if state.Height != snapshot.Height || state.BlockHash != snapshot.BlockHash {
+		return errors.New("snapshot header does not match its state")
+	}
+
+	stateHash, err := HashState(ctx, cryptoService, state)
+	if err != nil {
+		return err
+	}
+	if stateHash.String() != snapshot.StateHash {
+		return fmt.Errorf("state hash mismatch: computed %s, declared %s", stateHash.String(), snapshot.StateHash)
+	}
+
+	if state.Anchor == nil {
+		return errors.New("snapshot has no anchor block")
+	}
+	anchor, err := state.Anchor.Block()
+	if err != nil {
+		return fmt.Errorf("invalid anchor block: %w", err)
+	}
+	if anchor.GetIndex() != state.Height {
+		return fmt.Errorf("anchor block index %d does not match snapshot height %d", anchor.GetIndex(), state.Height)
+	}
+	chain := blockchain.NewChainManager(persistence.NewMemoryBlockchainRepository(cryptoService), cryptoService)
+	if hash := chain.CalculateBlockHash(ctx, anchor); hash.String() != state.BlockHash {
+		return fmt.Errorf("anchor block hash mismatch: computed %s, declared %s", hash.String(), state.BlockHash)
+	}
+
+	genesis, err := archive.DecodeValidatorKeys(state.GenesisValidators)
+	if err != nil {
+		return err
+	}
+	if !archive.SameValidatorSet(genesis, trusted) {
+		return errors.New("snapshot genesis validator set differs from the trusted set")
+	}
+	if _, err := state.validatorEpochs(); err != nil {
+		return err
+	}
+	if !sort.SliceIsSorted(state.ValidatorHistory, func(i, j int) bool {
+		return state.ValidatorHistory[i].FromHeight < state.ValidatorHistory[j].FromHeight
+	}) {
+		return errors.New("validator history is not ordered by height")
+	}
+
+	next, err := archive.DecodeValidatorKeys(state.Validators)
+	if err != nil {
+		return err
+	}
+	declared, err := archive.DecodeValidatorKeys(state.validatorSetAt(state.Height + 1))
+	if err != nil {
+		return err
+	}
+	if !archive.SameValidatorSet(next, declared) {
+		return errors.New("current validator set does not match the validator history")
+	}
+
+	eligible := make(map[string]bool)
+	for _, key := range state.validatorSetAt(state.Height) {
+		eligible[key.NodeID] = true
+	}
+	if _, err := verifySignatures(ctx, cryptoService, snapshot.Offer(), trusted, eligible); err != nil {
+		return err
+	}
+
+	if _, err := state.chainBase(); err != nil {
+		return err
+	}
+	return nil
+}